@@ -0,0 +1,291 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Stats represents a cache's cumulative statistics.
+type Stats struct {
+	// Hits represents the number of lookups satisfied by a cached value.
+	Hits int64 `json:"hits"`
+
+	// Misses represents the number of lookups without a cached value, including expired values.
+	Misses int64 `json:"misses"`
+
+	// Loads represents the number of [Cache.Load] lookup functions called.
+	Loads int64 `json:"loads"`
+
+	// Failures represents the number of [Cache.Load] lookup functions returning an error.
+	Failures int64 `json:"failures"`
+
+	// Evictions represents the number of values evicted to satisfy [Cache.Capacity].
+	Evictions int64 `json:"evictions"`
+
+	// Expirations represents the number of values removed upon expiring.
+	Expirations int64 `json:"expirations"`
+
+	// Size represents the number of cached values.
+	Size int `json:"size"`
+}
+
+// HitRatio returns the ratio of hits to lookups, or zero without lookups.
+func (s Stats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+
+	return 0
+}
+
+// item represents a cached value.
+type item[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// flight represents a [Cache.Load] lookup in progress.
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	e     error
+}
+
+// Cache represents an in-process cache of up to [Cache.Capacity] values, each expiring after [Cache.TTL]; once full, the least
+// recently used value is evicted. The zero value is ready for use, and a Cache is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	// Capacity represents the maximum number of cached values. Defaults to 1024.
+	Capacity int
+
+	// TTL represents the duration values are cached for, unless set via [Cache.SetTTL]. Defaults to five minutes.
+	TTL time.Duration
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+
+	mutex   sync.Mutex
+	items   map[K]*list.Element
+	order   *list.List
+	flights map[K]*flight[V]
+	stats   Stats
+}
+
+// now returns the current time.
+func (c *Cache[K, V]) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+
+	return time.Now()
+}
+
+// capacity returns the cache's maximum number of values.
+func (c *Cache[K, V]) capacity() int {
+	if c.Capacity > 0 {
+		return c.Capacity
+	}
+
+	return 1024
+}
+
+// ttl returns the cache's default time-to-live.
+func (c *Cache[K, V]) ttl() time.Duration {
+	if c.TTL > 0 {
+		return c.TTL
+	}
+
+	return time.Minute * 5
+}
+
+// initialize allocates the cache's internal state. The caller must hold the mutex.
+func (c *Cache[K, V]) initialize() {
+	if c.items == nil {
+		c.items = make(map[K]*list.Element)
+		c.order = list.New()
+		c.flights = make(map[K]*flight[V])
+	}
+}
+
+// remove removes the element. The caller must hold the mutex.
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+
+	delete(c.items, element.Value.(*item[K, V]).key)
+}
+
+// get returns the key's unexpired value, recording a hit or miss. The caller must hold the mutex.
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	c.initialize()
+
+	if element, found := c.items[key]; found {
+		entry := element.Value.(*item[K, V])
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(element)
+			c.stats.Hits++
+
+			return entry.value, true
+		}
+
+		c.remove(element)
+		c.stats.Expirations++
+	}
+
+	c.stats.Misses++
+
+	var zero V
+
+	return zero, false
+}
+
+// set caches the value. The caller must hold the mutex.
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) {
+	c.initialize()
+
+	expires := c.now().Add(ttl)
+
+	if element, found := c.items[key]; found {
+		entry := element.Value.(*item[K, V])
+		entry.value, entry.expires = value, expires
+
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&item[K, V]{key: key, value: value, expires: expires})
+
+	for c.order.Len() > c.capacity() {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Get returns the key's cached value, and whether it was found. Expired values aren't returned.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.get(key)
+}
+
+// Set caches the value for [Cache.TTL].
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetTTL(key, value, 0)
+}
+
+// SetTTL caches the value for the given duration, capped at [Cache.TTL] - e.g. bounded by a credential's expiry. A non-positive
+// duration uses [Cache.TTL].
+func (c *Cache[K, V]) SetTTL(key K, value V, ttl time.Duration) {
+	if ttl <= 0 || ttl > c.ttl() {
+		ttl = c.ttl()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.set(key, value, ttl)
+}
+
+// Load returns the key's cached value, or calls fn to look it up and caches its result for [Cache.TTL]. Concurrent misses of the
+// same key wait for the first caller's lookup, and share its result - preventing a cache stampede. Errors aren't cached. A
+// waiting caller returns early with the context's error once the context is done.
+func (c *Cache[K, V]) Load(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (V, error) {
+	c.mutex.Lock()
+
+	if value, found := c.get(key); found {
+		c.mutex.Unlock()
+
+		return value, nil
+	}
+
+	if current, found := c.flights[key]; found {
+		c.mutex.Unlock()
+
+		select {
+		case <-current.done:
+			return current.value, current.e
+		case <-ctx.Done():
+			var zero V
+
+			return zero, ctx.Err()
+		}
+	}
+
+	current := &flight[V]{done: make(chan struct{})}
+	c.flights[key] = current
+	c.stats.Loads++
+
+	c.mutex.Unlock()
+
+	completed := false
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.flights, key)
+
+		switch {
+		case !(completed):
+			// fn panicked; release waiting callers.
+			current.e = fmt.Errorf("cache: lookup of %v panicked", key)
+			c.stats.Failures++
+		case current.e != nil:
+			c.stats.Failures++
+		default:
+			c.set(key, current.value, c.ttl())
+		}
+
+		c.mutex.Unlock()
+
+		close(current.done)
+	}()
+
+	current.value, current.e = fn(ctx)
+
+	completed = true
+
+	return current.value, current.e
+}
+
+// Delete removes the key's cached value.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.remove(element)
+	}
+}
+
+// Purge removes every cached value. Lookups in progress aren't affected.
+func (c *Cache[K, V]) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.items != nil {
+		c.items = make(map[K]*list.Element)
+		c.order.Init()
+	}
+}
+
+// Len returns the number of cached values, including expired values not yet removed.
+func (c *Cache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the cache's statistics.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Size = len(c.items)
+
+	return stats
+}
@@ -0,0 +1,176 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/cache"
+)
+
+func Test(t *testing.T) {
+	t.Run("TTL", func(t *testing.T) {
+		now := time.Now()
+
+		c := &cache.Cache[string, int]{TTL: time.Minute, Clock: func() time.Time { return now }}
+
+		c.Set("a", 1)
+		c.SetTTL("b", 2, time.Second)
+
+		now = now.Add(time.Second * 2)
+
+		if v, found := c.Get("a"); !(found) || v != 1 {
+			t.Errorf("Value = %d\n    - Expectation = %d", v, 1)
+		}
+
+		if _, found := c.Get("b"); found {
+			t.Errorf("Expired Value Returned")
+		}
+
+		now = now.Add(time.Minute)
+
+		if _, found := c.Get("a"); found {
+			t.Errorf("Expired Value Returned")
+		}
+
+		if v := c.Stats().Expirations; v != 2 {
+			t.Errorf("Expirations = %d\n    - Expectation = %d", v, 2)
+		}
+	})
+
+	t.Run("LRU", func(t *testing.T) {
+		c := &cache.Cache[string, int]{Capacity: 2}
+
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Get("a") // "b" becomes the least recently used value.
+		c.Set("c", 3)
+
+		if _, found := c.Get("b"); found {
+			t.Errorf("Least Recently Used Value Wasn't Evicted")
+		}
+
+		for _, k := range []string{"a", "c"} {
+			if _, found := c.Get(k); !(found) {
+				t.Errorf("Value %s Unexpectedly Evicted", k)
+			}
+		}
+
+		if stats := c.Stats(); stats.Evictions != 1 || stats.Size != 2 {
+			t.Errorf("Stats = %+v\n    - Expectation = %s", stats, "1 eviction, size 2")
+		}
+	})
+
+	t.Run("Stampede-Protection", func(t *testing.T) {
+		c := new(cache.Cache[string, string])
+
+		var calls atomic.Int64
+
+		release := make(chan struct{})
+
+		var group sync.WaitGroup
+		for range 16 {
+			group.Add(1)
+
+			go func() {
+				defer group.Done()
+
+				v, e := c.Load(context.Background(), "tenant", func(ctx context.Context) (string, error) {
+					calls.Add(1)
+
+					<-release
+
+					return "tenant-a", nil
+				})
+
+				if e != nil || v != "tenant-a" {
+					t.Errorf("Value = %s, %v\n    - Expectation = %s", v, e, "tenant-a")
+				}
+			}()
+		}
+
+		time.Sleep(time.Millisecond * 10)
+
+		close(release)
+
+		group.Wait()
+
+		if v := calls.Load(); v != 1 {
+			t.Errorf("Calls = %d\n    - Expectation = %d", v, 1)
+		}
+
+		if v, found := c.Get("tenant"); !(found) || v != "tenant-a" {
+			t.Errorf("Value = %s\n    - Expectation = %s", v, "tenant-a")
+		}
+	})
+
+	t.Run("Load-Errors-Not-Cached", func(t *testing.T) {
+		c := new(cache.Cache[string, int])
+
+		failure := errors.New("lookup failure")
+
+		if _, e := c.Load(context.Background(), "k", func(ctx context.Context) (int, error) { return 0, failure }); !(errors.Is(e, failure)) {
+			t.Errorf("Error = %v\n    - Expectation = %v", e, failure)
+		}
+
+		if v, e := c.Load(context.Background(), "k", func(ctx context.Context) (int, error) { return 7, nil }); e != nil || v != 7 {
+			t.Errorf("Value = %d, %v\n    - Expectation = %d", v, e, 7)
+		}
+
+		if stats := c.Stats(); stats.Loads != 2 || stats.Failures != 1 {
+			t.Errorf("Stats = %+v\n    - Expectation = %s", stats, "2 loads, 1 failure")
+		}
+	})
+
+	t.Run("Hit-Ratio", func(t *testing.T) {
+		c := new(cache.Cache[string, int])
+
+		c.Set("a", 1)
+		c.Get("a")
+		c.Get("a")
+		c.Get("a")
+		c.Get("b")
+
+		if v := c.Stats().HitRatio(); v != 0.75 {
+			t.Errorf("Hit Ratio = %f\n    - Expectation = %f", v, 0.75)
+		}
+
+		if v := (cache.Stats{}).HitRatio(); v != 0 {
+			t.Errorf("Hit Ratio = %f\n    - Expectation = %f", v, 0.0)
+		}
+	})
+
+	t.Run("Invalidation", func(t *testing.T) {
+		a, b := new(cache.Cache[string, int]), new(cache.Cache[int, string])
+
+		cache.Register("test-a", a)
+		cache.Register("test-b", b)
+
+		defer cache.Unregister("test-a")
+		defer cache.Unregister("test-b")
+
+		a.Set("k", 1)
+		b.Set(1, "v")
+
+		cache.Invalidate("test-a")
+
+		if a.Len() != 0 || b.Len() != 1 {
+			t.Errorf("Lengths = %d, %d\n    - Expectation = %d, %d", a.Len(), b.Len(), 0, 1)
+		}
+
+		cache.Invalidate()
+
+		if b.Len() != 0 {
+			t.Errorf("Length = %d\n    - Expectation = %d", b.Len(), 0)
+		}
+
+		if report := cache.Report(); len(report) < 2 {
+			t.Errorf("Report = %v\n    - Expectation = %s", report, "test-a and test-b statistics")
+		}
+
+		a.Delete("k")
+	})
+}
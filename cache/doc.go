@@ -0,0 +1,7 @@
+// Package cache provides an in-process, generic [Cache] combining time-to-live expiration with least-recently-used eviction,
+// shared by middleware performing expensive lookups (e.g. token verification, or tenant resolution).
+//
+// [Cache.Load] provides stampede protection: concurrent misses of the same key wait for, and share, a single lookup. Every cache
+// tracks [Stats] - including its hit ratio - and caches registered by name (see [Register]) share a common invalidation API:
+// [Invalidate] purges caches by name, and [Report] returns every registered cache's statistics.
+package cache
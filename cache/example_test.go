@@ -0,0 +1,33 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/poly-gun/go-middleware/cache"
+)
+
+func Example() {
+	tenants := &cache.Cache[string, string]{Capacity: 512, TTL: time.Minute}
+
+	cache.Register("tenants", tenants)
+
+	lookup := func(ctx context.Context) (string, error) {
+		return "Tenant A", nil // e.g. a database query.
+	}
+
+	for range 4 {
+		tenants.Load(context.Background(), "tenant-a", lookup)
+	}
+
+	fmt.Printf("Hit Ratio: %.2f\n", cache.Report()["tenants"].HitRatio())
+
+	cache.Invalidate("tenants")
+
+	fmt.Printf("Size: %d\n", tenants.Len())
+
+	// Output:
+	// Hit Ratio: 0.75
+	// Size: 0
+}
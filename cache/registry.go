@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+)
+
+// Invalidator represents a cache participating in the common invalidation API. Every [Cache] satisfies the interface.
+type Invalidator interface {
+	// Purge removes every cached value.
+	Purge()
+
+	// Stats returns a snapshot of the cache's statistics.
+	Stats() Stats
+}
+
+// registry represents the named caches. See [Register].
+var registry = struct {
+	mutex  sync.RWMutex
+	caches map[string]Invalidator
+}{caches: make(map[string]Invalidator)}
+
+// Register registers the cache by name (e.g. "authentication"), replacing any cache previously registered by the same name.
+// Registered caches are purged by [Invalidate], and reported by [Report].
+func Register(name string, cache Invalidator) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.caches[name] = cache
+}
+
+// Unregister removes the named cache from the registry.
+func Unregister(name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	delete(registry.caches, name)
+}
+
+// Names returns the names of the registered caches, sorted.
+func Names() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	names := make([]string, 0, len(registry.caches))
+	for name := range registry.caches {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Invalidate purges the named caches - or every registered cache, if no names are provided. Unknown names are ignored.
+func Invalidate(names ...string) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	if len(names) == 0 {
+		for _, cache := range registry.caches {
+			cache.Purge()
+		}
+
+		return
+	}
+
+	for _, name := range names {
+		if cache, found := registry.caches[name]; found {
+			cache.Purge()
+		}
+	}
+}
+
+// Report returns the statistics of every registered cache, by name.
+func Report() map[string]Stats {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	report := make(map[string]Stats, len(registry.caches))
+	for name, cache := range registry.caches {
+		report[name] = cache.Stats()
+	}
+
+	return report
+}
+
+// Runtime assurance that [Cache] satisfies the [Invalidator] interface.
+var _ Invalidator = (*Cache[string, struct{}])(nil)
@@ -0,0 +1,230 @@
+package chainconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Document represents a chain configuration document.
+type Document struct {
+	// Chains represents the document's chains.
+	Chains []Chain `json:"chains"`
+}
+
+// Chain represents a single, named listener and its ordered middleware.
+type Chain struct {
+	// Name represents the chain's unique identifier (e.g. "public", "admin", "metrics").
+	Name string `json:"name"`
+
+	// Address represents the chain's listening address (e.g. ":8080").
+	Address string `json:"address,omitempty"`
+
+	// Middleware represents the chain's middleware, in order; the first entry is the outermost.
+	Middleware []Entry `json:"middleware,omitempty"`
+}
+
+// Entry represents a chain's middleware, and its options.
+type Entry struct {
+	// Name represents the middleware's [Definition] name.
+	Name string `json:"name"`
+
+	// Options represents the middleware's options, as decoded from JSON.
+	Options map[string]any `json:"options,omitempty"`
+}
+
+// Kind represents an option's expected JSON kind.
+type Kind string
+
+const (
+	KindString  Kind = "string"  // KindString represents a JSON string.
+	KindNumber  Kind = "number"  // KindNumber represents a JSON number.
+	KindBoolean Kind = "boolean" // KindBoolean represents a JSON boolean.
+	KindArray   Kind = "array"   // KindArray represents a JSON array.
+	KindObject  Kind = "object"  // KindObject represents a JSON object.
+	KindAny     Kind = "any"     // KindAny represents any JSON value.
+)
+
+// Definition describes a middleware to the [Validator].
+type Definition struct {
+	// Options maps the middleware's option names to their expected [Kind]. A nil map skips option validation.
+	Options map[string]Kind
+
+	// Conflicts represents the names of middleware that mustn't share a chain with the middleware (e.g. two compression
+	// middleware).
+	Conflicts []string
+
+	// Validate optionally returns messages describing conflicting, or otherwise invalid, option combinations (e.g. credentialed
+	// CORS with a wildcard origin).
+	Validate func(options map[string]any) []string
+}
+
+// Severity represents an [Issue]'s severity.
+type Severity string
+
+const (
+	// SeverityError represents an issue invalidating the document.
+	SeverityError Severity = "error"
+
+	// SeverityWarning represents a suspicious, but valid, configuration.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue represents a single validation result.
+type Issue struct {
+	// Severity represents the issue's severity.
+	Severity Severity `json:"severity"`
+
+	// Path represents the offending value's location (e.g. "chains[0].middleware[2].options.origins").
+	Path string `json:"path"`
+
+	// Code represents the issue's machine-readable code (e.g. "unknown-middleware").
+	Code string `json:"code"`
+
+	// Message represents the issue's human-readable description.
+	Message string `json:"message"`
+}
+
+// Valid reports whether none of the issues is a [SeverityError].
+func Valid(issues []Issue) bool {
+	for index := range issues {
+		if issues[index].Severity == SeverityError {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validator validates chain configuration [Document] values against its middleware [Definition] values. A Validator is safe for
+// concurrent use, provided its fields aren't modified.
+type Validator struct {
+	// Definitions maps middleware names to their [Definition].
+	Definitions map[string]Definition
+
+	// Extract optionally returns the [Document] conveyed by an AdmissionReview's object - see [Validator.ServeHTTP]. Defaults to
+	// decoding the object's "spec" field, if present, otherwise the object itself.
+	Extract func(object []byte) (*Document, error)
+}
+
+// kind returns the JSON value's [Kind].
+func kind(value any) Kind {
+	switch value.(type) {
+	case string:
+		return KindString
+	case float64, int, int64:
+		return KindNumber
+	case bool:
+		return KindBoolean
+	case []any:
+		return KindArray
+	case map[string]any:
+		return KindObject
+	}
+
+	return ""
+}
+
+// conflicts reports whether either middleware's [Definition] declares a conflict with the other.
+func (v *Validator) conflicts(a, b string) bool {
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		for _, conflict := range v.Definitions[pair[0]].Conflicts {
+			if conflict == pair[1] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// entry validates a chain's middleware entry.
+func (v *Validator) entry(path string, entry Entry, definition Definition) (issues []Issue) {
+	if definition.Options != nil {
+		names := make([]string, 0, len(entry.Options))
+		for name := range entry.Options {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			expected, known := definition.Options[name]
+			switch {
+			case !(known):
+				issues = append(issues, Issue{Severity: SeverityError, Path: path + ".options." + name, Code: "unknown-option", Message: fmt.Sprintf("middleware %q has no option %q", entry.Name, name)})
+			case expected != KindAny && entry.Options[name] != nil && kind(entry.Options[name]) != expected:
+				issues = append(issues, Issue{Severity: SeverityError, Path: path + ".options." + name, Code: "invalid-option-type", Message: fmt.Sprintf("option %q must be of kind %s", name, expected)})
+			}
+		}
+	}
+
+	if definition.Validate != nil {
+		for _, message := range definition.Validate(entry.Options) {
+			issues = append(issues, Issue{Severity: SeverityError, Path: path + ".options", Code: "conflicting-options", Message: message})
+		}
+	}
+
+	return
+}
+
+// Validate returns the document's issues, ordered by their location in the document. The document is valid if none of the issues
+// is a [SeverityError] - see [Valid].
+func (v *Validator) Validate(document Document) (issues []Issue) {
+	if len(document.Chains) == 0 {
+		return []Issue{{Severity: SeverityError, Path: "chains", Code: "no-chains", Message: "document defines no chains"}}
+	}
+
+	names := make(map[string]int)
+	addresses := make(map[string]int)
+
+	for index, chain := range document.Chains {
+		path := fmt.Sprintf("chains[%d]", index)
+
+		switch previous, duplicate := names[chain.Name]; {
+		case strings.TrimSpace(chain.Name) == "":
+			issues = append(issues, Issue{Severity: SeverityError, Path: path + ".name", Code: "chain-name-missing", Message: "chain requires a non-empty name"})
+		case duplicate:
+			issues = append(issues, Issue{Severity: SeverityError, Path: path + ".name", Code: "duplicate-chain", Message: fmt.Sprintf("chain name %q is already defined by chains[%d]", chain.Name, previous)})
+		default:
+			names[chain.Name] = index
+		}
+
+		if chain.Address != "" {
+			if previous, duplicate := addresses[chain.Address]; duplicate {
+				issues = append(issues, Issue{Severity: SeverityError, Path: path + ".address", Code: "duplicate-address", Message: fmt.Sprintf("address %q is already bound by chains[%d]", chain.Address, previous)})
+			} else {
+				addresses[chain.Address] = index
+			}
+		}
+
+		present := make(map[string]int)
+
+		for position, entry := range chain.Middleware {
+			path := fmt.Sprintf("%s.middleware[%d]", path, position)
+
+			definition, known := v.Definitions[entry.Name]
+			if !(known) {
+				issues = append(issues, Issue{Severity: SeverityError, Path: path + ".name", Code: "unknown-middleware", Message: fmt.Sprintf("unknown middleware %q", entry.Name)})
+
+				continue
+			}
+
+			if previous, duplicate := present[entry.Name]; duplicate {
+				issues = append(issues, Issue{Severity: SeverityWarning, Path: path + ".name", Code: "duplicate-middleware", Message: fmt.Sprintf("middleware %q is already applied by middleware[%d]", entry.Name, previous)})
+			} else {
+				present[entry.Name] = position
+			}
+
+			for earlier, previous := range chain.Middleware[:position] {
+				if previous.Name != entry.Name && v.conflicts(entry.Name, previous.Name) {
+					issues = append(issues, Issue{Severity: SeverityError, Path: path + ".name", Code: "conflicting-middleware", Message: fmt.Sprintf("middleware %q conflicts with middleware[%d] (%q)", entry.Name, earlier, previous.Name)})
+				}
+			}
+
+			issues = append(issues, v.entry(path, entry, definition)...)
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,209 @@
+package chainconfig_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/chainconfig"
+)
+
+func Test(t *testing.T) {
+	validator := &chainconfig.Validator{
+		Definitions: map[string]chainconfig.Definition{
+			"cors": {
+				Options: map[string]chainconfig.Kind{"origins": chainconfig.KindArray, "credentials": chainconfig.KindBoolean},
+				Validate: func(options map[string]any) []string {
+					origins, _ := options["origins"].([]any)
+					for _, origin := range origins {
+						if origin == "*" && options["credentials"] == true {
+							return []string{"credentials can't be allowed for a wildcard origin"}
+						}
+					}
+
+					return nil
+				},
+			},
+			"compress": {Options: map[string]chainconfig.Kind{"level": chainconfig.KindNumber}, Conflicts: []string{"brotli"}},
+			"brotli":   {},
+			"logging":  {Options: map[string]chainconfig.Kind{"fields": chainconfig.KindAny}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		codes    []string
+		paths    []string
+		valid    bool
+	}{
+		{
+			name:     "Valid",
+			document: `{"chains": [{"name": "public", "address": ":8080", "middleware": [{"name": "logging", "options": {"fields": ["method"]}}, {"name": "cors", "options": {"origins": ["https://example.com"], "credentials": true}}]}, {"name": "admin", "address": ":9090"}]}`,
+			valid:    true,
+		},
+		{name: "Empty", document: `{"chains": []}`, codes: []string{"no-chains"}, paths: []string{"chains"}},
+		{
+			name:     "Unknown-Middleware",
+			document: `{"chains": [{"name": "public", "middleware": [{"name": "gzip"}]}]}`,
+			codes:    []string{"unknown-middleware"},
+			paths:    []string{"chains[0].middleware[0].name"},
+		},
+		{
+			name:     "Options",
+			document: `{"chains": [{"name": "public", "middleware": [{"name": "cors", "options": {"origins": "*", "maxAge": 600}}]}]}`,
+			codes:    []string{"unknown-option", "invalid-option-type"},
+			paths:    []string{"chains[0].middleware[0].options.maxAge", "chains[0].middleware[0].options.origins"},
+		},
+		{
+			name:     "Conflicting-Options",
+			document: `{"chains": [{"name": "public", "middleware": [{"name": "cors", "options": {"origins": ["*"], "credentials": true}}]}]}`,
+			codes:    []string{"conflicting-options"},
+			paths:    []string{"chains[0].middleware[0].options"},
+		},
+		{
+			name:     "Conflicting-Middleware",
+			document: `{"chains": [{"name": "public", "middleware": [{"name": "brotli"}, {"name": "compress"}]}, {"name": "admin", "middleware": [{"name": "compress"}, {"name": "brotli"}]}]}`,
+			codes:    []string{"conflicting-middleware", "conflicting-middleware"},
+			paths:    []string{"chains[0].middleware[1].name", "chains[1].middleware[1].name"},
+		},
+		{
+			name:     "Duplicates",
+			document: `{"chains": [{"name": "public", "address": ":8080", "middleware": [{"name": "logging"}, {"name": "logging"}]}, {"name": "public", "address": ":8080"}, {"name": " "}]}`,
+			codes:    []string{"duplicate-middleware", "duplicate-chain", "duplicate-address", "chain-name-missing"},
+			paths:    []string{"chains[0].middleware[1].name", "chains[1].name", "chains[1].address", "chains[2].name"},
+		},
+		{
+			name:     "Warnings-Only",
+			document: `{"chains": [{"name": "public", "middleware": [{"name": "logging"}, {"name": "logging"}]}]}`,
+			codes:    []string{"duplicate-middleware"},
+			paths:    []string{"chains[0].middleware[1].name"},
+			valid:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document chainconfig.Document
+			if e := json.Unmarshal([]byte(tt.document), &document); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Document: %v", e)
+			}
+
+			issues := validator.Validate(document)
+
+			if len(issues) != len(tt.codes) {
+				t.Fatalf("Issues = %+v\n    - Expectation = %v", issues, tt.codes)
+			}
+
+			for index, issue := range issues {
+				if issue.Code != tt.codes[index] || issue.Path != tt.paths[index] {
+					t.Errorf("Issue = %s (%s)\n    - Expectation = %s (%s)", issue.Code, issue.Path, tt.codes[index], tt.paths[index])
+				}
+			}
+
+			if v := chainconfig.Valid(issues); v != tt.valid {
+				t.Errorf("Valid = %t\n    - Expectation = %t", v, tt.valid)
+			}
+		})
+	}
+
+	t.Run("Handler", func(t *testing.T) {
+		server := httptest.NewServer(validator)
+
+		defer server.Close()
+
+		post := func(body string) (*http.Response, map[string]any) {
+			response, e := server.Client().Post(server.URL, "application/json", strings.NewReader(body))
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			var decoded map[string]any
+			json.NewDecoder(response.Body).Decode(&decoded)
+
+			return response, decoded
+		}
+
+		t.Run("Document", func(t *testing.T) {
+			response, body := post(`{"chains": [{"name": "public", "middleware": [{"name": "gzip"}]}]}`)
+
+			if response.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusUnprocessableEntity)
+			}
+
+			if issues, _ := body["issues"].([]any); body["valid"] != false || len(issues) != 1 {
+				t.Errorf("Result = %v\n    - Expectation = %s", body, "a single issue")
+			}
+
+			if response, _ := post(`{"chains": [{"name": "public"}]}`); response.StatusCode != http.StatusOK {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+			}
+
+			if response, _ := post(`{"chains": [{"name": "public"}], "unknown": true}`); response.StatusCode != http.StatusBadRequest {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("Admission", func(t *testing.T) {
+			matrix := []struct {
+				name     string
+				object   string
+				allowed  bool
+				warnings int
+			}{
+				{name: "Allowed", object: `{"kind": "ChainConfiguration", "spec": {"chains": [{"name": "public"}]}}`, allowed: true},
+				{name: "Denied", object: `{"kind": "ChainConfiguration", "spec": {"chains": [{"name": "public", "middleware": [{"name": "gzip"}]}]}}`, allowed: false},
+				{name: "Warnings", object: `{"chains": [{"name": "public", "middleware": [{"name": "logging"}, {"name": "logging"}]}]}`, allowed: true, warnings: 1},
+				{name: "Malformed", object: `{"kind": "ChainConfiguration", "spec": {"chains": "public"}}`, allowed: false},
+			}
+
+			for _, matrix := range matrix {
+				t.Run(matrix.name, func(t *testing.T) {
+					response, body := post(`{"apiVersion": "admission.k8s.io/v1", "kind": "AdmissionReview", "request": {"uid": "705ab4f5", "object": ` + matrix.object + `}}`)
+
+					if response.StatusCode != http.StatusOK {
+						t.Fatalf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+					}
+
+					result, _ := body["response"].(map[string]any)
+					if result == nil {
+						t.Fatalf("Response = %v\n    - Expectation = %s", body, "an AdmissionReview response")
+					}
+
+					if result["uid"] != "705ab4f5" || body["kind"] != "AdmissionReview" || body["apiVersion"] != "admission.k8s.io/v1" {
+						t.Errorf("Review = %v\n    - Expectation = %s", body, "the request's uid, kind, and apiVersion")
+					}
+
+					if result["allowed"] != matrix.allowed {
+						t.Errorf("Allowed = %v\n    - Expectation = %t", result["allowed"], matrix.allowed)
+					}
+
+					if warnings, _ := result["warnings"].([]any); len(warnings) != matrix.warnings {
+						t.Errorf("Warnings = %v\n    - Expectation = %d", warnings, matrix.warnings)
+					}
+
+					if _, found := result["status"]; found == matrix.allowed {
+						t.Errorf("Status = %v\n    - Expectation = %s", result["status"], "a status for denied objects only")
+					}
+				})
+			}
+		})
+
+		t.Run("Method", func(t *testing.T) {
+			response, e := server.Client().Get(server.URL)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			response.Body.Close()
+
+			if response.StatusCode != http.StatusMethodNotAllowed {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusMethodNotAllowed)
+			}
+		})
+	})
+}
@@ -0,0 +1,17 @@
+// Package chainconfig validates proposed chain configuration documents - the chains, their addresses, and each chain's ordered
+// middleware entries and options - returning structured [Issue] results: unknown middleware names, unknown or mistyped options,
+// conflicting options, and conflicting middleware.
+//
+// Middleware are described to the [Validator] through [Definition] values, keyed by the name a document refers to them by:
+//
+//	validator := &chainconfig.Validator{
+//		Definitions: map[string]chainconfig.Definition{
+//			"cors": {Options: map[string]chainconfig.Kind{"origins": chainconfig.KindArray, "credentials": chainconfig.KindBoolean}},
+//			"ratelimit": {Options: map[string]chainconfig.Kind{"limit": chainconfig.KindNumber, "window": chainconfig.KindString}},
+//		},
+//	}
+//
+// [Validator.Validate] suits CI pipelines and unit tests. The [Validator] is also an [net/http.Handler], accepting either a bare
+// [Document], or a Kubernetes AdmissionReview - such that it can be registered as a validating admission webhook, rejecting
+// invalid configurations before they're applied.
+package chainconfig
@@ -0,0 +1,40 @@
+package chainconfig_test
+
+import (
+	"fmt"
+
+	"github.com/poly-gun/go-middleware/chainconfig"
+)
+
+func Example() {
+	validator := &chainconfig.Validator{
+		Definitions: map[string]chainconfig.Definition{
+			"cors":      {Options: map[string]chainconfig.Kind{"origins": chainconfig.KindArray}},
+			"ratelimit": {Options: map[string]chainconfig.Kind{"limit": chainconfig.KindNumber}},
+		},
+	}
+
+	issues := validator.Validate(chainconfig.Document{
+		Chains: []chainconfig.Chain{
+			{
+				Name:    "public",
+				Address: ":8080",
+				Middleware: []chainconfig.Entry{
+					{Name: "cors", Options: map[string]any{"origins": "*"}},
+					{Name: "ratelimiter"},
+				},
+			},
+		},
+	})
+
+	for _, issue := range issues {
+		fmt.Printf("%s %s: %s\n", issue.Severity, issue.Path, issue.Message)
+	}
+
+	fmt.Println("valid:", chainconfig.Valid(issues))
+
+	// Output:
+	// error chains[0].middleware[0].options.origins: option "origins" must be of kind array
+	// error chains[0].middleware[1].name: unknown middleware "ratelimiter"
+	// valid: false
+}
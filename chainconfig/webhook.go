@@ -0,0 +1,176 @@
+package chainconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// limit represents the maximum request body size, in bytes.
+const limit = 4 << 20
+
+// review represents the subset of a Kubernetes AdmissionReview (admission.k8s.io/v1) the [Validator] reads, and writes.
+type review struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Request    *request  `json:"request,omitempty"`
+	Response   *response `json:"response,omitempty"`
+}
+
+type request struct {
+	UID    string          `json:"uid"`
+	Object json.RawMessage `json:"object"`
+}
+
+type response struct {
+	UID      string   `json:"uid"`
+	Allowed  bool     `json:"allowed"`
+	Status   *status  `json:"status,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Result represents the [Validator]'s response to a bare [Document].
+type Result struct {
+	// Valid reports whether the document is valid - see [Valid].
+	Valid bool `json:"valid"`
+
+	// Issues represents the document's issues.
+	Issues []Issue `json:"issues"`
+}
+
+// extract is the default [Validator.Extract] function.
+func extract(object []byte) (*Document, error) {
+	var resource struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+
+	if e := json.Unmarshal(object, &resource); e != nil {
+		return nil, e
+	}
+
+	if len(resource.Spec) > 0 {
+		object = resource.Spec
+	}
+
+	return decode(object)
+}
+
+// decode strictly decodes a [Document], rejecting unknown fields.
+func decode(data []byte) (*Document, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var document Document
+	if e := decoder.Decode(&document); e != nil {
+		return nil, e
+	}
+
+	return &document, nil
+}
+
+// admit validates the AdmissionReview's object, returning the review's response.
+func (v *Validator) admit(r *http.Request, uid string, object []byte) *response {
+	fn := v.Extract
+	if fn == nil {
+		fn = extract
+	}
+
+	document, e := fn(object)
+	if e != nil {
+		return &response{UID: uid, Allowed: false, Status: &status{Code: http.StatusBadRequest, Message: "invalid chain configuration document: " + e.Error()}}
+	}
+
+	issues := v.Validate(*document)
+
+	result := &response{UID: uid, Allowed: Valid(issues)}
+
+	var messages []string
+	for _, issue := range issues {
+		message := fmt.Sprintf("%s: %s (%s)", issue.Path, issue.Message, issue.Code)
+		if issue.Severity == SeverityWarning {
+			result.Warnings = append(result.Warnings, message)
+		} else {
+			messages = append(messages, message)
+		}
+	}
+
+	if !(result.Allowed) {
+		slog.InfoContext(r.Context(), "Chain Configuration Denied", slog.String("uid", uid), slog.Int("issues", len(messages)))
+
+		result.Status = &status{Code: http.StatusUnprocessableEntity, Message: strings.Join(messages, "; ")}
+	}
+
+	return result
+}
+
+// ServeHTTP validates the POST request's body: a Kubernetes AdmissionReview - answered with an AdmissionReview, whose response
+// denies objects with error [Issue] values, and conveys warnings - or a bare [Document], answered with a [Result] and a
+// [http.StatusOK] status if valid, otherwise a [http.StatusUnprocessableEntity] status.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	data, e := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if e != nil {
+		status := http.StatusBadRequest
+		if v := new(http.MaxBytesError); errors.As(e, &v) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		http.Error(w, http.StatusText(status), status)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var admission review
+	if e := json.Unmarshal(data, &admission); e == nil && admission.Kind == "AdmissionReview" {
+		if admission.Request == nil {
+			http.Error(w, "AdmissionReview Request Missing", http.StatusBadRequest)
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(review{APIVersion: admission.APIVersion, Kind: admission.Kind, Response: v.admit(r, admission.Request.UID, admission.Request.Object)})
+
+		return
+	}
+
+	document, e := decode(data)
+	if e != nil {
+		http.Error(w, "Invalid Chain Configuration Document: "+e.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	issues := v.Validate(*document)
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	result := Result{Valid: Valid(issues), Issues: issues}
+	if !(result.Valid) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	json.NewEncoder(w).Encode(result)
+}
+
+// Runtime assurance that [Validator] satisfies [http.Handler] requirement(s).
+var _ http.Handler = (*Validator)(nil)
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultShutdownTimeout = time.Second * 30
+
+// Chain represents a single, named listener and its associated middleware chain. Middleware instances (e.g. stores or
+// registries) may be shared across multiple [Chain] values, while each chain remains free to define differing policies.
+type Chain struct {
+	// Name represents the chain's unique identifier (e.g. "public", "admin", "metrics").
+	Name string
+
+	// Server represents the chain's [http.Server]. The server's Handler field is overwritten with the chain's final handler.
+	Server *http.Server
+
+	// Listener represents an optional, pre-established [net.Listener]. When nil, the [Chain.Server] address is used.
+	Listener net.Listener
+
+	// Handler represents the chain's terminal handler - typically a [http.ServeMux].
+	Handler http.Handler
+
+	// Middleware represents the chain's middleware. A nil value results in the [Chain.Handler] being served as is.
+	Middleware *Middleware
+
+	notifier *Notifier
+}
+
+// handler returns the chain's final, middleware-wrapped handler. The outermost handler stores the chain's [Notifier], such that
+// streaming handlers can observe the chain's shutdown - see [Stopping].
+func (c *Chain) handler() http.Handler {
+	c.notifier = new(Notifier)
+
+	if c.Middleware == nil {
+		return c.notifier.Handler(c.Handler)
+	}
+
+	return c.notifier.Handler(c.Middleware.Handler(c.Handler))
+}
+
+// Chains manages multiple named [Chain] values, and their servers, from a single configuration. See [Chains.ListenAndServe]
+// for the coordinated startup and graceful shutdown behavior.
+type Chains struct {
+	// Timeout represents the duration allotted to all servers' graceful shutdown. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	chains []*Chain
+	mutex  sync.Mutex
+}
+
+// Register adds one or more [Chain] values. An error is returned if a chain is missing a name or server, or if its name is already registered.
+func (c *Chains) Register(chains ...*Chain) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, chain := range chains {
+		if chain == nil || chain.Name == "" || chain.Server == nil {
+			return errors.New("chain requires a non-empty name and server")
+		}
+
+		for index := range c.chains {
+			if c.chains[index].Name == chain.Name {
+				return fmt.Errorf("duplicate chain name: %s", chain.Name)
+			}
+		}
+
+		c.chains = append(c.chains, chain)
+	}
+
+	return nil
+}
+
+// Lookup returns the registered [Chain] associated with name, or nil if no such chain exists.
+func (c *Chains) Lookup(name string) *Chain {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for index := range c.chains {
+		if c.chains[index].Name == name {
+			return c.chains[index]
+		}
+	}
+
+	return nil
+}
+
+// ListenAndServe starts every registered chain's server, blocking until the provided context is canceled or any server fails. Once
+// either event occurs, all servers are gracefully shut down together, bounded by [Chains.Timeout]. The first non-[http.ErrServerClosed]
+// error is returned.
+//
+// Before the servers' shutdown, every chain's [Notifier] is notified, such that streaming handlers observing [Stopping] or
+// [OnShutdown] can send a termination event to their clients and return ahead of the drain deadline.
+func (c *Chains) ListenAndServe(ctx context.Context) error {
+	c.mutex.Lock()
+	chains := append([]*Chain(nil), c.chains...)
+	c.mutex.Unlock()
+
+	if len(chains) == 0 {
+		return errors.New("no chains registered")
+	}
+
+	exceptions := make(chan error, len(chains))
+
+	var group sync.WaitGroup
+	for _, chain := range chains {
+		chain.Server.Handler = chain.handler()
+
+		group.Add(1)
+		go func(chain *Chain) {
+			defer group.Done()
+
+			var e error
+			if chain.Listener != nil {
+				e = chain.Server.Serve(chain.Listener)
+			} else {
+				e = chain.Server.ListenAndServe()
+			}
+
+			if e != nil && !(errors.Is(e, http.ErrServerClosed)) {
+				slog.ErrorContext(ctx, "Chain Server Error", slog.String("chain", chain.Name), slog.String("error", e.Error()))
+
+				exceptions <- fmt.Errorf("chain %s: %w", chain.Name, e)
+			}
+		}(chain)
+	}
+
+	var exception error
+
+	select {
+	case <-ctx.Done():
+	case exception = <-exceptions:
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	shutdown, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	// Notify streaming handlers first, as they'd otherwise hold their connections until the deadline elapses.
+	for _, chain := range chains {
+		chain.notifier.Notify()
+	}
+
+	// Shut down all servers concurrently so that a slow chain doesn't consume another chain's share of the deadline.
+	var mutex sync.Mutex
+	for _, chain := range chains {
+		group.Add(1)
+		go func(chain *Chain) {
+			defer group.Done()
+
+			if e := chain.Server.Shutdown(shutdown); e != nil {
+				slog.WarnContext(ctx, "Chain Server Shutdown Error", slog.String("chain", chain.Name), slog.String("error", e.Error()))
+
+				mutex.Lock()
+				exception = errors.Join(exception, fmt.Errorf("chain %s shutdown: %w", chain.Name, e))
+				mutex.Unlock()
+			}
+		}(chain)
+	}
+
+	group.Wait()
+
+	return exception
+}
@@ -0,0 +1,278 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+func TestChains(t *testing.T) {
+	// shared represents a middleware instance shared by both chains.
+	shared := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Shared", "true")
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	// admin represents a policy specific to the admin chain.
+	admin := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	listen := func(t *testing.T) net.Listener {
+		listener, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+		}
+
+		return listener
+	}
+
+	t.Run("Register", func(t *testing.T) {
+		chains := new(middleware.Chains)
+
+		if e := chains.Register(&middleware.Chain{Name: "public", Server: &http.Server{}}); e != nil {
+			t.Fatalf("Unexpected Error While Registering Chain: %v", e)
+		}
+
+		if e := chains.Register(&middleware.Chain{Name: "public", Server: &http.Server{}}); e == nil {
+			t.Errorf("Expected Duplicate Chain Name Error")
+		}
+
+		if e := chains.Register(&middleware.Chain{Name: "admin"}); e == nil {
+			t.Errorf("Expected Missing Server Error")
+		}
+
+		if chains.Lookup("public") == nil {
+			t.Errorf("Expected Registered Chain Lookup")
+		}
+	})
+
+	t.Run("Listen-And-Serve", func(t *testing.T) {
+		public := middleware.New()
+		public.Add(shared)
+
+		private := middleware.New()
+		private.Add(shared, admin)
+
+		chains := &middleware.Chains{Timeout: time.Second * 5}
+
+		listeners := map[string]net.Listener{"public": listen(t), "admin": listen(t)}
+
+		e := chains.Register(
+			&middleware.Chain{Name: "public", Server: &http.Server{}, Listener: listeners["public"], Handler: handler, Middleware: public},
+			&middleware.Chain{Name: "admin", Server: &http.Server{}, Listener: listeners["admin"], Handler: handler, Middleware: private},
+		)
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Registering Chains: %v", e)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan error, 1)
+		go func() { done <- chains.ListenAndServe(ctx) }()
+
+		tests := map[string]int{"public": http.StatusNoContent, "admin": http.StatusUnauthorized}
+		for name, expectation := range tests {
+			response, e := http.Get("http://" + listeners[name].Addr().String())
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			response.Body.Close()
+
+			if response.StatusCode != expectation {
+				t.Errorf("Chain (%s) Status = %d\n    - Expectation = %d", name, response.StatusCode, expectation)
+			}
+
+			if response.Header.Get("X-Shared") != "true" {
+				t.Errorf("Chain (%s) Missing Shared Middleware Header", name)
+			}
+		}
+
+		cancel()
+
+		select {
+		case e := <-done:
+			if e != nil {
+				t.Errorf("Unexpected Error From Graceful Shutdown: %v", e)
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatalf("Chains Failed to Shut Down")
+		}
+	})
+
+	t.Run("Failure-Shuts-Down-Siblings", func(t *testing.T) {
+		occupied := listen(t)
+		defer occupied.Close()
+
+		chains := new(middleware.Chains)
+
+		e := chains.Register(
+			&middleware.Chain{Name: "public", Server: &http.Server{}, Listener: listen(t), Handler: handler},
+			&middleware.Chain{Name: "metrics", Server: &http.Server{Addr: occupied.Addr().String()}, Handler: handler},
+		)
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Registering Chains: %v", e)
+		}
+
+		if e := chains.ListenAndServe(context.Background()); e == nil {
+			t.Errorf("Expected Address-In-Use Error")
+		}
+	})
+	t.Run("Shutdown-Notification", func(t *testing.T) {
+		started := make(chan struct{})
+		called := make(chan struct{})
+
+		stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			stop := middleware.OnShutdown(r.Context(), func() { close(called) })
+			defer stop()
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			http.NewResponseController(w).Flush()
+
+			close(started)
+
+			select {
+			case <-middleware.Stopping(r.Context()):
+				fmt.Fprint(w, "event: shutdown\ndata: reconnect\n\n")
+			case <-r.Context().Done():
+			}
+		})
+
+		listener := listen(t)
+
+		chains := &middleware.Chains{Timeout: time.Second * 5}
+		if e := chains.Register(&middleware.Chain{Name: "stream", Server: &http.Server{}, Listener: listener, Handler: stream}); e != nil {
+			t.Fatalf("Unexpected Error While Registering Chains: %v", e)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan error, 1)
+		go func() { done <- chains.ListenAndServe(ctx) }()
+
+		response, e := http.Get("http://" + listener.Addr().String())
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		defer response.Body.Close()
+
+		<-started
+
+		start := time.Now()
+
+		cancel()
+
+		body, e := io.ReadAll(response.Body)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Reading Response Body: %v", e)
+		}
+
+		if expectation := "event: shutdown\ndata: reconnect\n\n"; string(body) != expectation {
+			t.Errorf("Body = %q\n    - Expectation = %q", string(body), expectation)
+		}
+
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Errorf("Expected Shutdown Callback")
+		}
+
+		select {
+		case e := <-done:
+			if e != nil {
+				t.Errorf("Unexpected Error From Graceful Shutdown: %v", e)
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatalf("Chains Failed to Shut Down")
+		}
+
+		if elapsed := time.Since(start); elapsed >= time.Second*5 {
+			t.Errorf("Shutdown Duration = %s\n    - Expectation < %s", elapsed, time.Second*5)
+		}
+	})
+
+	t.Run("Shutdown-Callback-Stop", func(t *testing.T) {
+		notifier := new(middleware.Notifier)
+
+		var ctx context.Context
+		notifier.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		called := make(chan struct{}, 1)
+		stop := middleware.OnShutdown(ctx, func() { called <- struct{}{} })
+
+		if !(stop()) {
+			t.Errorf("Expected Stop to Prevent Callback")
+		}
+
+		notifier.Notify()
+		notifier.Notify()
+
+		select {
+		case <-middleware.Stopping(ctx):
+		default:
+			t.Errorf("Expected Stopping Channel to Be Closed")
+		}
+
+		select {
+		case <-called:
+			t.Errorf("Unexpected Shutdown Callback After Stop")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		if middleware.Stopping(context.Background()) != nil {
+			t.Errorf("Expected Nil Stopping Channel Without Notifier")
+		}
+	})
+	t.Run("Concurrent-Notify", func(t *testing.T) {
+		notifier := new(middleware.Notifier)
+
+		var group sync.WaitGroup
+		for range 16 {
+			group.Add(1)
+
+			go func() {
+				defer group.Done()
+
+				notifier.Notify()
+			}()
+		}
+
+		group.Wait()
+
+		select {
+		case <-notifier.Done():
+		default:
+			t.Errorf("Expected Done Channel to Be Closed")
+		}
+	})
+}
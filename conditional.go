@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// Conditional wraps the middleware function such that requests for which skip returns true bypass it, and are served by the
+// next handler directly. It allows any middleware to be skipped for e.g. health-check paths, OPTIONS requests, or internal
+// traffic, without a middleware-specific option:
+//
+//	m.Add(middleware.Conditional(func(r *http.Request) bool {
+//		return r.URL.Path == "/health" || r.Method == http.MethodOptions
+//	}, timeout.New().Handler))
+//
+// A nil skip function never skips the middleware.
+func Conditional(skip func(r *http.Request) bool, middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+		if skip == nil {
+			return wrapped
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip(r) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
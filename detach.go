@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+)
+
+// Detach returns a copy of ctx carrying all of its values - e.g. the request identifier, tenant, principal, and trace stored by
+// middleware - but none of its cancellation or deadline. Goroutines started by handlers should use a detached context, such that
+// they retain the request's observability context once the request ends:
+//
+//	go func(ctx context.Context) {
+//		process(ctx)
+//	}(middleware.Detach(r.Context()))
+//
+// Detached contexts are never canceled; callers should apply their own timeout where applicable.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
@@ -82,3 +82,60 @@ func Example() {
 	// Output:
 	// X-Context-Key-1 Middleware Header: Context-Key-Value-1
 }
+
+func ExampleMiddleware_Route() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	middleware := middleware.New()
+
+	middleware.Route("/api/", func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "no-store")
+
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	middleware.Route("/public/", func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/api/users", "/public/index.html"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("%s: %s\n", path, response.Header.Get("Cache-Control"))
+	}
+
+	// Output:
+	// /api/users: no-store
+	// /public/index.html: public, max-age=3600
+}
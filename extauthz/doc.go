@@ -0,0 +1,36 @@
+// Package extauthz implements Envoy's external authorization (ext_authz) HTTP check API, such that this module's authentication,
+// authorization, and rate limiting middleware can run as a mesh-level authorizer - enforcing the same policy code at the proxy.
+//
+// Envoy's HTTP authorization service forwards a check request - the original request's method, path (prefixed by the service's
+// "path_prefix"), and allowed header(s) - to the [Server]. The request is run through [Options.Policy]; if it reaches the end of the
+// policy, the [Server] responds with [net/http.StatusOK], and Envoy forwards the original request upstream, along with any
+// "allowed_upstream_headers" from the check response (see [Options.Headers]). Otherwise, the denying middleware's response is
+// returned to Envoy, which relays its status, and "allowed_client_headers", to the client:
+//
+//	server := extauthz.New().Settings(func(o *extauthz.Options) {
+//		o.Policy = []func(http.Handler) http.Handler{
+//			authentication.New().Settings(...).Handler,
+//			authorization.New().Settings(...).Handler,
+//			ratelimit.New().Settings(...).Handler,
+//		}
+//	})
+//
+//	http.ListenAndServe(":9000", server.Handler(http.NotFoundHandler()))
+//
+// The corresponding Envoy configuration:
+//
+//	http_filters:
+//	  - name: envoy.filters.http.ext_authz
+//	    typed_config:
+//	      "@type": type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthz
+//	      http_service:
+//	        server_uri: { uri: authz:9000, cluster: authz, timeout: 0.25s }
+//	        path_prefix: /ext-authz
+//	        authorization_request:
+//	          allowed_headers: { patterns: [{ exact: authorization }, { exact: cookie }] }
+//	        authorization_response:
+//	          allowed_upstream_headers: { patterns: [{ prefix: x-auth- }] }
+//
+// Only the HTTP check API is implemented; Envoy's gRPC check API requires the Envoy protocol buffer definitions, which this module
+// doesn't depend on.
+package extauthz
@@ -0,0 +1,59 @@
+package extauthz_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware/extauthz"
+)
+
+func Example() {
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	server := httptest.NewServer(extauthz.New().Settings(func(o *extauthz.Options) {
+		o.Policy = []func(http.Handler) http.Handler{authenticate}
+	}).Handler(http.NotFoundHandler()))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	// Envoy's check requests convey the original request's method, and path, beneath the "path_prefix".
+	for _, authorization := range []string{"", "Bearer token"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+"/ext-authz/orders", nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Println(response.StatusCode)
+	}
+
+	// Output:
+	// 401
+	// 200
+}
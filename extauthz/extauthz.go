@@ -0,0 +1,220 @@
+package extauthz
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key, holding the check request's decision.
+const key keyer = "extauthz"
+
+// Options represents the configuration settings for the [Server] middleware component.
+type Options struct {
+	// Prefix represents the check requests' path prefix - Envoy's "path_prefix" setting - which is stripped prior to running
+	// [Options.Policy]. Requests outside the prefix are forwarded to the next handler. Defaults to "/ext-authz".
+	Prefix string
+
+	// Policy represents the authorization policy's middleware, in order; the first entry is the outermost. Defaults to an empty
+	// slice - every check request is allowed.
+	Policy []func(http.Handler) http.Handler
+
+	// Headers optionally returns header(s) added to allowed check responses, such that Envoy forwards them upstream (see Envoy's
+	// "allowed_upstream_headers") - e.g. the authenticated principal's subject. The request carries the policy's context values.
+	// Defaults to nil.
+	Headers func(r *http.Request) http.Header
+
+	// Address returns the original client's address, which replaces the check request's remote address (e.g. for rate limiting by
+	// IP). An empty value retains the remote address - Envoy's. Defaults to a function reading the "X-Envoy-External-Address"
+	// header, falling back to the "X-Forwarded-For" header's last entry, as appended by Envoy.
+	Address func(r *http.Request) string
+}
+
+// Server represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Server struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// address is the default [Options.Address] function.
+func address(r *http.Request) string {
+	if v := strings.TrimSpace(r.Header.Get("X-Envoy-External-Address")); v != "" {
+		return v
+	}
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		entries := strings.Split(values[len(values)-1], ",")
+
+		return strings.TrimSpace(entries[len(entries)-1])
+	}
+
+	return ""
+}
+
+// Settings applies configuration functions to modify the [Server] middleware's [Options] and returns the updated middleware instance.
+func (x *Server) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Prefix:  "/ext-authz",
+			Policy:  []func(http.Handler) http.Handler{},
+			Headers: nil,
+			Address: address,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	x.options.Prefix = strings.TrimSuffix(x.options.Prefix, "/")
+
+	if x.options.Address == nil {
+		x.options.Address = address
+	}
+
+	return x
+}
+
+// writer ensures a check request is only allowed if it reached the end of the policy: a [http.StatusOK] response written by a
+// policy middleware - e.g. one answering the request itself - is denied with a [http.StatusForbidden] response instead.
+type writer struct {
+	http.ResponseWriter
+
+	allowed *bool
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written = true
+
+	if status == http.StatusOK && !(*w.allowed) {
+		slog.Warn("Ext-Authz Policy Responded Without Allowing - Denying Check Request")
+
+		status = http.StatusForbidden
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], enforcing the policy's outcome on the status before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// check returns the original request, as conveyed by the check request.
+func (x *Server) check(r *http.Request) *http.Request {
+	original := r.Clone(r.Context())
+
+	original.URL.Path = strings.TrimPrefix(r.URL.Path, x.options.Prefix)
+	if original.URL.Path == "" {
+		original.URL.Path = "/"
+	}
+
+	if r.URL.RawPath != "" {
+		original.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, x.options.Prefix)
+	}
+
+	original.RequestURI = original.URL.RequestURI()
+
+	if v := x.options.Address(r); v != "" {
+		if ip := net.ParseIP(v); ip != nil {
+			original.RemoteAddr = net.JoinHostPort(ip.String(), "0")
+		}
+	}
+
+	return original
+}
+
+// Handler serves check requests - those beneath [Options.Prefix] - by running them through [Options.Policy]; other requests are
+// forwarded to the next handler. Check requests reaching the end of the policy receive a [http.StatusOK] response, including
+// [Options.Headers]; denied check requests receive the denying middleware's response.
+func (x *Server) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	var policy http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowed, ok := r.Context().Value(key).(*bool); ok {
+			*allowed = true
+		}
+
+		if x.options.Headers != nil {
+			for name, values := range x.options.Headers(r) {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for index := len(x.options.Policy) - 1; index >= 0; index-- {
+		if callable := x.options.Policy[index]; callable != nil {
+			policy = callable(policy)
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if x.options.Prefix != "" && r.URL.Path != x.options.Prefix && !(strings.HasPrefix(r.URL.Path, x.options.Prefix+"/")) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		allowed := false
+
+		original := x.check(r)
+
+		policy.ServeHTTP(&writer{ResponseWriter: w, allowed: &allowed}, original.WithContext(context.WithValue(original.Context(), key, &allowed)))
+
+		slog.DebugContext(r.Context(), "Ext-Authz Check", slog.String("method", original.Method), slog.String("path", original.URL.Path), slog.Bool("allowed", allowed))
+	})
+}
+
+// New creates a new instance of the [Server] middleware, implementing [middleware.Configurable]. If [Server.Settings] isn't called,
+// then the [Server.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Server)
+}
+
+// Runtime assurance that [Server] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Server)(nil)
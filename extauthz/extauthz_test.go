@@ -0,0 +1,167 @@
+package extauthz_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/extauthz"
+)
+
+type principal string
+
+func Test(t *testing.T) {
+	// authenticate represents an authentication middleware, denying requests without a token.
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principal("subject"), "user")))
+		})
+	}
+
+	// authorize represents an authorization middleware, denying requests to the admin subtree.
+	authorize := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete || r.URL.Path == "/admin" {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	// block represents an IP filtering middleware.
+	block := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if host, _, _ := net.SplitHostPort(r.RemoteAddr); host == "203.0.113.7" {
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	// respond represents a policy middleware answering the request itself.
+	respond := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/respond" {
+				w.Write([]byte("ok"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	handler := extauthz.New().Settings(func(o *extauthz.Options) {
+		o.Policy = []func(http.Handler) http.Handler{block, authenticate, authorize, respond}
+		o.Headers = func(r *http.Request) http.Header {
+			subject, _ := r.Context().Value(principal("subject")).(string)
+
+			return http.Header{"X-Auth-Subject": {subject}}
+		}
+	}).Handler(upstream)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		status  int
+		subject string
+	}{
+		{name: "Allowed", method: http.MethodGet, path: "/ext-authz/orders?page=2", headers: map[string]string{"Authorization": "Bearer token"}, status: http.StatusOK, subject: "user"},
+		{name: "Unauthenticated", method: http.MethodGet, path: "/ext-authz/orders", status: http.StatusUnauthorized},
+		{name: "Forbidden-Path", method: http.MethodGet, path: "/ext-authz/admin", headers: map[string]string{"Authorization": "Bearer token"}, status: http.StatusForbidden},
+		{name: "Forbidden-Method", method: http.MethodDelete, path: "/ext-authz/orders", headers: map[string]string{"Authorization": "Bearer token"}, status: http.StatusForbidden},
+		{name: "Blocked-Address", method: http.MethodGet, path: "/ext-authz/orders", headers: map[string]string{"Authorization": "Bearer token", "X-Envoy-External-Address": "203.0.113.7"}, status: http.StatusTooManyRequests},
+		{name: "Blocked-Forwarded", method: http.MethodGet, path: "/ext-authz/orders", headers: map[string]string{"Authorization": "Bearer token", "X-Forwarded-For": "198.51.100.1, 203.0.113.7"}, status: http.StatusTooManyRequests},
+		{name: "Spoofed-Forwarded", method: http.MethodGet, path: "/ext-authz/orders", headers: map[string]string{"Authorization": "Bearer token", "X-Forwarded-For": "203.0.113.7, 198.51.100.1"}, status: http.StatusOK, subject: "user"},
+		{name: "Root", method: http.MethodGet, path: "/ext-authz", headers: map[string]string{"Authorization": "Bearer token"}, status: http.StatusOK, subject: "user"},
+		{name: "Policy-Response", method: http.MethodGet, path: "/ext-authz/respond", headers: map[string]string{"Authorization": "Bearer token"}, status: http.StatusForbidden},
+		{name: "Passthrough", method: http.MethodGet, path: "/healthz", status: http.StatusTeapot},
+		{name: "Passthrough-Prefix", method: http.MethodGet, path: "/ext-authzz", status: http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if v := recorder.Header().Get("X-Auth-Subject"); v != tt.subject {
+				t.Errorf("X-Auth-Subject = %s\n    - Expectation = %s", v, tt.subject)
+			}
+
+			if tt.status == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("WWW-Authenticate = %s\n    - Expectation = %s", recorder.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+		})
+	}
+
+	t.Run("Original-Request", func(t *testing.T) {
+		var path, uri string
+
+		handler := extauthz.New().Settings(func(o *extauthz.Options) {
+			o.Prefix = "/check/"
+			o.Policy = []func(http.Handler) http.Handler{
+				func(next http.Handler) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						path, uri = r.URL.Path, r.RequestURI
+
+						next.ServeHTTP(w, r)
+					})
+				},
+			}
+		}).Handler(upstream)
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/check/v1/orders?id=1", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+
+		if path != "/v1/orders" || uri != "/v1/orders?id=1" {
+			t.Errorf("Path = %s, URI = %s\n    - Expectation = %s, %s", path, uri, "/v1/orders", "/v1/orders?id=1")
+		}
+	})
+
+	t.Run("Empty-Policy", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		extauthz.New().Handler(upstream).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ext-authz/anything", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+// Package failure provides a unified error taxonomy, classifying middleware and handler failures - client errors, authentication
+// and authorization failures, throttling, dependency failures, and internal errors - such that every middleware derives the same
+// status code, Retry-After presence, and log severity for a given failure.
+//
+// Errors are classified explicitly by wrapping them ([Wrap], [Throttled], and [Dependency]), or implicitly through [Classify] -
+// e.g. [context.DeadlineExceeded] is a dependency failure. Responses already written are classified by status ([FromStatus]).
+package failure
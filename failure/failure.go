@@ -0,0 +1,212 @@
+package failure
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Class represents a failure's classification. The zero value represents the absence of a failure.
+type Class string
+
+const (
+	// ClassClient represents a failure caused by the client's request (e.g. validation, or an unknown resource). Not retriable.
+	ClassClient Class = "client"
+
+	// ClassAuth represents an authentication or authorization failure. Not retriable without new credentials.
+	ClassAuth Class = "auth"
+
+	// ClassThrottled represents a request rejected by a rate limit, or load shedding. Retriable.
+	ClassThrottled Class = "throttled"
+
+	// ClassDependency represents the failure of an upstream dependency (e.g. a database, or an upstream service). Retriable.
+	ClassDependency Class = "dependency"
+
+	// ClassInternal represents an unexpected server-side failure. Not retriable.
+	ClassInternal Class = "internal"
+)
+
+// Classes returns all [Class] values.
+func Classes() []Class {
+	return []Class{ClassClient, ClassAuth, ClassThrottled, ClassDependency, ClassInternal}
+}
+
+// Status returns the class's default response status code, or zero for the absence of a failure.
+func (c Class) Status() int {
+	switch c {
+	case ClassClient:
+		return http.StatusBadRequest
+	case ClassAuth:
+		return http.StatusUnauthorized
+	case ClassThrottled:
+		return http.StatusTooManyRequests
+	case ClassDependency:
+		return http.StatusServiceUnavailable
+	case ClassInternal:
+		return http.StatusInternalServerError
+	}
+
+	return 0
+}
+
+// Retriable reports whether a client may retry the failed request unchanged - in which case its response should include a
+// Retry-After header. See [Header].
+func (c Class) Retriable() bool {
+	return c == ClassThrottled || c == ClassDependency
+}
+
+// Retry returns the class's default Retry-After duration, or zero for classes that aren't [Class.Retriable].
+func (c Class) Retry() time.Duration {
+	switch c {
+	case ClassThrottled:
+		return time.Second
+	case ClassDependency:
+		return time.Second * 5
+	}
+
+	return 0
+}
+
+// Level returns the class's log severity: client failures and throttling are expected, and logged at [slog.LevelInfo];
+// authentication and dependency failures at [slog.LevelWarn]; internal failures at [slog.LevelError].
+func (c Class) Level() slog.Level {
+	switch c {
+	case ClassAuth, ClassDependency:
+		return slog.LevelWarn
+	case ClassInternal:
+		return slog.LevelError
+	}
+
+	return slog.LevelInfo
+}
+
+// Error represents a classified error.
+type Error struct {
+	// Class represents the error's classification.
+	Class Class
+
+	// Err represents the underlying error.
+	Err error
+
+	// RetryAfter optionally overrides the [Class.Retry] duration of a [Class.Retriable] error.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Class) + " failure"
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the error's [Class.Status], such that status-aware middleware (e.g. the problem middleware) derive a
+// consistent status code.
+func (e *Error) StatusCode() int {
+	return e.Class.Status()
+}
+
+// Wrap classifies the error.
+func Wrap(class Class, e error) *Error {
+	return &Error{Class: class, Err: e}
+}
+
+// Throttled classifies the error as [ClassThrottled], retriable after the provided duration.
+func Throttled(e error, retry time.Duration) *Error {
+	return &Error{Class: ClassThrottled, Err: e, RetryAfter: retry}
+}
+
+// Dependency classifies the error as [ClassDependency], retriable after the provided duration.
+func Dependency(e error, retry time.Duration) *Error {
+	return &Error{Class: ClassDependency, Err: e, RetryAfter: retry}
+}
+
+// Classify returns the error's [Class]: the class of a wrapped [*Error]; [ClassDependency] for deadline-exceeded errors;
+// [ClassClient] for canceled requests and oversized request bodies; the [FromStatus] class of errors implementing
+// StatusCode() int; and [ClassInternal] for all other errors. A nil error returns the zero value.
+func Classify(e error) Class {
+	if e == nil {
+		return ""
+	}
+
+	var target *Error
+	if errors.As(e, &target) {
+		return target.Class
+	}
+
+	var oversized *http.MaxBytesError
+
+	switch {
+	case errors.Is(e, context.DeadlineExceeded):
+		return ClassDependency
+	case errors.Is(e, context.Canceled), errors.As(e, &oversized):
+		return ClassClient
+	}
+
+	var statuser interface{ StatusCode() int }
+	if errors.As(e, &statuser) {
+		if class := FromStatus(statuser.StatusCode()); class != "" {
+			return class
+		}
+	}
+
+	return ClassInternal
+}
+
+// FromStatus returns the [Class] of a response status code, or the zero value for non-error statuses.
+func FromStatus(status int) Class {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden, status == http.StatusProxyAuthRequired:
+		return ClassAuth
+	case status == http.StatusTooManyRequests:
+		return ClassThrottled
+	case status == http.StatusBadGateway, status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return ClassDependency
+	case status >= 400 && status < 500:
+		return ClassClient
+	case status >= 500 && status < 600:
+		return ClassInternal
+	}
+
+	return ""
+}
+
+// Retry returns the error's Retry-After duration - its [Error.RetryAfter], or its class's [Class.Retry] duration - and whether the
+// error is retriable.
+func Retry(e error) (time.Duration, bool) {
+	class := Classify(e)
+	if !(class.Retriable()) {
+		return 0, false
+	}
+
+	var target *Error
+	if errors.As(e, &target) && target.RetryAfter > 0 {
+		return target.RetryAfter, true
+	}
+
+	return class.Retry(), true
+}
+
+// Header sets the Retry-After header, in whole seconds (rounded up), for retriable errors. A Retry-After header already present
+// (e.g. set by a rate limiter) is retained.
+func Header(header http.Header, e error) {
+	retry, retriable := Retry(e)
+	if !(retriable) || header.Get("Retry-After") != "" {
+		return
+	}
+
+	seconds := int64((retry + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	header.Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
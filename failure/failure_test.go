@@ -0,0 +1,112 @@
+package failure_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/failure"
+)
+
+// status represents an error conveying its own status code.
+type status int
+
+func (s status) Error() string   { return http.StatusText(int(s)) }
+func (s status) StatusCode() int { return int(s) }
+
+func Test(t *testing.T) {
+	t.Run("Classify", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			e     error
+			class failure.Class
+		}{
+			{name: "Nil", e: nil, class: ""},
+			{name: "Wrapped", e: fmt.Errorf("handler: %w", failure.Wrap(failure.ClassAuth, errors.New("expired session"))), class: failure.ClassAuth},
+			{name: "Deadline", e: fmt.Errorf("query: %w", context.DeadlineExceeded), class: failure.ClassDependency},
+			{name: "Canceled", e: context.Canceled, class: failure.ClassClient},
+			{name: "Oversized", e: &http.MaxBytesError{Limit: 1}, class: failure.ClassClient},
+			{name: "Status-Throttled", e: status(http.StatusTooManyRequests), class: failure.ClassThrottled},
+			{name: "Status-Forbidden", e: status(http.StatusForbidden), class: failure.ClassAuth},
+			{name: "Status-Not-Found", e: status(http.StatusNotFound), class: failure.ClassClient},
+			{name: "Status-Bad-Gateway", e: status(http.StatusBadGateway), class: failure.ClassDependency},
+			{name: "Unclassified", e: errors.New("unexpected"), class: failure.ClassInternal},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if v := failure.Classify(tt.e); v != tt.class {
+					t.Errorf("Class = %s\n    - Expectation = %s", v, tt.class)
+				}
+			})
+		}
+	})
+
+	t.Run("Classes", func(t *testing.T) {
+		tests := []struct {
+			class     failure.Class
+			status    int
+			retriable bool
+			level     slog.Level
+		}{
+			{class: failure.ClassClient, status: http.StatusBadRequest, retriable: false, level: slog.LevelInfo},
+			{class: failure.ClassAuth, status: http.StatusUnauthorized, retriable: false, level: slog.LevelWarn},
+			{class: failure.ClassThrottled, status: http.StatusTooManyRequests, retriable: true, level: slog.LevelInfo},
+			{class: failure.ClassDependency, status: http.StatusServiceUnavailable, retriable: true, level: slog.LevelWarn},
+			{class: failure.ClassInternal, status: http.StatusInternalServerError, retriable: false, level: slog.LevelError},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.class), func(t *testing.T) {
+				if v := tt.class.Status(); v != tt.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", v, tt.status)
+				}
+
+				if v := tt.class.Retriable(); v != tt.retriable {
+					t.Errorf("Retriable = %t\n    - Expectation = %t", v, tt.retriable)
+				}
+
+				if v := tt.class.Level(); v != tt.level {
+					t.Errorf("Level = %s\n    - Expectation = %s", v, tt.level)
+				}
+
+				if v := failure.FromStatus(tt.status); v != tt.class {
+					t.Errorf("From Status = %s\n    - Expectation = %s", v, tt.class)
+				}
+			})
+		}
+	})
+
+	t.Run("Header", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			e        error
+			existing string
+			value    string
+		}{
+			{name: "Throttled", e: failure.Throttled(errors.New("quota"), time.Millisecond*1500), value: "2"},
+			{name: "Dependency-Default", e: failure.Wrap(failure.ClassDependency, nil), value: "5"},
+			{name: "Existing", e: failure.Throttled(nil, time.Minute), existing: "10", value: "10"},
+			{name: "Not-Retriable", e: failure.Wrap(failure.ClassInternal, nil), value: ""},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				header := http.Header{}
+				if tt.existing != "" {
+					header.Set("Retry-After", tt.existing)
+				}
+
+				failure.Header(header, tt.e)
+
+				if v := header.Get("Retry-After"); v != tt.value {
+					t.Errorf("Retry-After = %s\n    - Expectation = %s", v, tt.value)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,8 @@
+// Package integration contains full-chain contract tests, assembling representative middleware chains - a public API, an
+// administrative API, and a streaming endpoint - and comparing their responses against golden files in the testdata directory.
+//
+// A change to any middleware's header or body behavior surfaces as a golden file diff. Intentional changes are accepted by
+// regenerating the golden files:
+//
+//	go test ./... -update
+package integration
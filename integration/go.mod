@@ -0,0 +1,50 @@
+module github.com/poly-gun/go-middleware/integration
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../
+
+replace github.com/poly-gun/go-middleware/middleware/authentication => ../middleware/authentication
+
+replace github.com/poly-gun/go-middleware/middleware/compress => ../middleware/compress
+
+replace github.com/poly-gun/go-middleware/middleware/cors => ../middleware/cors
+
+replace github.com/poly-gun/go-middleware/middleware/hosts => ../middleware/hosts
+
+replace github.com/poly-gun/go-middleware/middleware/name => ../middleware/name
+
+replace github.com/poly-gun/go-middleware/middleware/ratelimit => ../middleware/ratelimit
+
+replace github.com/poly-gun/go-middleware/middleware/recover => ../middleware/recover
+
+replace github.com/poly-gun/go-middleware/middleware/requestid => ../middleware/requestid
+
+replace github.com/poly-gun/go-middleware/middleware/rip => ../middleware/rip
+
+replace github.com/poly-gun/go-middleware/middleware/strict => ../middleware/strict
+
+replace github.com/poly-gun/go-middleware/middleware/telemetrics => ../middleware/telemetrics
+
+replace github.com/poly-gun/go-middleware/middleware/versioning => ../middleware/versioning
+
+require (
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/poly-gun/go-middleware v1.1.5
+	github.com/poly-gun/go-middleware/middleware/authentication v0.0.7
+	github.com/poly-gun/go-middleware/middleware/compress v0.0.0
+	github.com/poly-gun/go-middleware/middleware/cors v0.3.8
+	github.com/poly-gun/go-middleware/middleware/hosts v0.0.0
+	github.com/poly-gun/go-middleware/middleware/name v0.1.7
+	github.com/poly-gun/go-middleware/middleware/ratelimit v0.0.0
+	github.com/poly-gun/go-middleware/middleware/recover v0.0.0
+	github.com/poly-gun/go-middleware/middleware/requestid v0.0.0
+	github.com/poly-gun/go-middleware/middleware/strict v0.0.0
+	github.com/poly-gun/go-middleware/middleware/versioning v0.0.5
+)
+
+require (
+	github.com/poly-gun/go-middleware/middleware/rip v0.0.3 // indirect
+	github.com/poly-gun/go-middleware/middleware/telemetrics v0.0.8 // indirect
+	github.com/rs/cors v1.11.1 // indirect
+)
@@ -0,0 +1,293 @@
+package integration_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/middleware/compress"
+	"github.com/poly-gun/go-middleware/middleware/cors"
+	"github.com/poly-gun/go-middleware/middleware/hosts"
+	"github.com/poly-gun/go-middleware/middleware/name"
+	"github.com/poly-gun/go-middleware/middleware/ratelimit"
+	"github.com/poly-gun/go-middleware/middleware/recover"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/strict"
+	"github.com/poly-gun/go-middleware/middleware/versioning"
+)
+
+// update regenerates the golden files, rather than comparing against them.
+var update = flag.Bool("update", false, "regenerate golden files")
+
+// generated matches generated request identifiers, which are normalized in golden files.
+var generated = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// epoch represents the fixed clock of time-dependent middleware.
+var epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// public assembles a representative public API chain.
+func public(t *testing.T) http.Handler {
+	chain := middleware.New()
+
+	chain.Add(recover.New().Settings(func(o *recover.Options) { o.Format = recover.Problem; o.Stack = false }).Handler)
+	chain.Add(requestid.New().Handler)
+	chain.Add(name.New().Settings(func(o *name.Options) { o.Name = "Integration-Server" }).Handler)
+	chain.Add(versioning.New().Settings(func(o *versioning.Options) { o.API = "v1"; o.Service = "1.0.0" }).Handler)
+	chain.Add(cors.New().Settings(func(o *cors.Options) {
+		o.Origins = []string{"https://app.example.com"}
+		o.Headers = []string{"Content-Type", "Authorization"}
+		o.Exposed = []string{"X-Request-ID"}
+		o.Credentials = false
+	}).Handler)
+	chain.Add(compress.New().Handler)
+	chain.Add(ratelimit.New().Settings(func(o *ratelimit.Options) {
+		o.Limit = 3
+		o.Window = time.Minute
+		o.Clock = func() time.Time { return epoch }
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/items", func(w http.ResponseWriter, r *http.Request) {
+		items := make([]map[string]interface{}, 0, 32)
+		for index := range 32 {
+			items = append(items, map[string]interface{}{"id": index, "name": fmt.Sprintf("Item-%d", index), "description": "A representative item."})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+
+	mux.HandleFunc("GET /v1/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("unexpected state")
+	})
+
+	return chain.Handler(mux)
+}
+
+// admin assembles a representative administrative API chain, failing the test on response writer misuse.
+func admin(t *testing.T) http.Handler {
+	chain := middleware.New()
+
+	chain.Add(recover.New().Settings(func(o *recover.Options) { o.Format = recover.JSON; o.Stack = false }).Handler)
+	chain.Add(requestid.New().Settings(func(o *requestid.Options) { o.Trust = false }).Handler)
+	chain.Add(hosts.New().Settings(func(o *hosts.Options) { o.Hosts = []string{"admin.example.com"} }).Handler)
+	chain.Add(authentication.New().Settings(func(o *authentication.Options) {
+		o.Verification = func(ctx context.Context, token string) (*jwt.Token, error) {
+			if token != "valid-token" {
+				return nil, jwt.ErrTokenSignatureInvalid
+			}
+
+			return &jwt.Token{Valid: true}, nil
+		}
+	}).Handler)
+	chain.Add(strict.New().Settings(func(o *strict.Options) {
+		o.Hook = func(violation strict.Violation) { t.Errorf("Unexpected Violation: %+v", violation) }
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /admin/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"users": []string{"administrator"}})
+	})
+
+	return chain.Handler(mux)
+}
+
+// streaming assembles a representative server-sent events chain.
+func streaming(t *testing.T) http.Handler {
+	chain := middleware.New()
+
+	chain.Add(requestid.New().Handler)
+	chain.Add(compress.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		for index := range 3 {
+			fmt.Fprintf(w, "id: %d\ndata: {\"sequence\":%d}\n\n", index, index)
+
+			http.NewResponseController(w).Flush()
+		}
+	})
+
+	return chain.Handler(mux)
+}
+
+// render serializes a request and its response into a deterministic, golden file representation.
+func render(t *testing.T, request *http.Request, recorder *httptest.ResponseRecorder) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	write := func(header http.Header) {
+		keys := make([]string, 0, len(header))
+		for k := range header {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			for _, v := range header[k] {
+				if k == "X-Request-Id" && generated.MatchString(v) {
+					v = "<generated>"
+				}
+
+				fmt.Fprintf(&buffer, "%s: %s\n", k, v)
+			}
+		}
+	}
+
+	fmt.Fprintf(&buffer, "%s %s %s\n", request.Method, request.URL.RequestURI(), request.Proto)
+	fmt.Fprintf(&buffer, "Host: %s\n", request.Host)
+
+	write(request.Header)
+
+	response := recorder.Result()
+
+	fmt.Fprintf(&buffer, "\n%s %s\n", response.Proto, response.Status)
+
+	write(response.Header)
+
+	body := recorder.Body.Bytes()
+	if response.Header.Get("Content-Encoding") == "gzip" {
+		reader, e := gzip.NewReader(bytes.NewReader(body))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response Body: %v", e)
+		}
+
+		body, e = io.ReadAll(reader)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response Body: %v", e)
+		}
+	}
+
+	fmt.Fprintf(&buffer, "\n%s", body)
+
+	return buffer.Bytes()
+}
+
+func Test(t *testing.T) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	type request struct {
+		name    string
+		method  string
+		target  string
+		headers map[string]string
+	}
+
+	chains := []struct {
+		name     string
+		chain    func(t *testing.T) http.Handler
+		requests []request
+	}{
+		{
+			name:  "public",
+			chain: public,
+			requests: []request{
+				{name: "items", method: http.MethodGet, target: "/v1/items", headers: map[string]string{"X-Request-ID": "integration-request-id"}},
+				{name: "items-compressed", method: http.MethodGet, target: "/v1/items", headers: map[string]string{"Accept-Encoding": "gzip", "Origin": "https://app.example.com"}},
+				{name: "preflight", method: http.MethodOptions, target: "/v1/items", headers: map[string]string{"Origin": "https://app.example.com", "Access-Control-Request-Method": "GET", "Access-Control-Request-Headers": "Authorization"}},
+				{name: "panic", method: http.MethodGet, target: "/v1/panic", headers: nil},
+				{name: "rate-limited", method: http.MethodGet, target: "/v1/items", headers: nil},
+			},
+		},
+		{
+			name:  "admin",
+			chain: admin,
+			requests: []request{
+				{name: "authorized", method: http.MethodGet, target: "http://admin.example.com/admin/users", headers: map[string]string{"Authorization": "Bearer valid-token"}},
+				{name: "unauthenticated", method: http.MethodGet, target: "http://admin.example.com/admin/users", headers: nil},
+				{name: "forbidden", method: http.MethodGet, target: "http://admin.example.com/admin/users", headers: map[string]string{"Authorization": "Bearer invalid-token"}},
+				{name: "unknown-host", method: http.MethodGet, target: "http://example.com/admin/users", headers: map[string]string{"Authorization": "Bearer valid-token"}},
+			},
+		},
+		{
+			name:  "streaming",
+			chain: streaming,
+			requests: []request{
+				{name: "events", method: http.MethodGet, target: "/events", headers: map[string]string{"Accept-Encoding": "gzip"}},
+			},
+		},
+	}
+
+	for _, chain := range chains {
+		t.Run(chain.name, func(t *testing.T) {
+			handler := chain.chain(t)
+
+			// Requests are served in order, as chains may be stateful (e.g. rate limiting).
+			for _, test := range chain.requests {
+				t.Run(test.name, func(t *testing.T) {
+					request := httptest.NewRequest(test.method, test.target, nil)
+					for k, v := range test.headers {
+						request.Header.Set(k, v)
+					}
+
+					recorder := httptest.NewRecorder()
+
+					handler.ServeHTTP(recorder, request)
+
+					actual := render(t, request, recorder)
+
+					path := filepath.Join("testdata", chain.name, test.name+".golden")
+
+					if *update {
+						if e := os.MkdirAll(filepath.Dir(path), 0o755); e != nil {
+							t.Fatalf("Unexpected Error While Creating Golden File Directory: %v", e)
+						}
+
+						if e := os.WriteFile(path, actual, 0o644); e != nil {
+							t.Fatalf("Unexpected Error While Writing Golden File: %v", e)
+						}
+
+						return
+					}
+
+					expectation, e := os.ReadFile(path)
+					if e != nil {
+						t.Fatalf("Unexpected Error While Reading Golden File (Regenerate With -update): %v", e)
+					}
+
+					if !(bytes.Equal(actual, expectation)) {
+						t.Errorf("Contract Mismatch (%s)\n    - Received:\n%s\n    - Expectation:\n%s", path, indent(actual), indent(expectation))
+					}
+				})
+			}
+		})
+	}
+}
+
+// indent prefixes each line of a golden file representation for readable test failures.
+func indent(value []byte) string {
+	return "        | " + strings.ReplaceAll(string(value), "\n", "\n        | ")
+}
@@ -0,0 +1,10 @@
+// Package listener provides server bootstrap helpers for establishing [net.Listener] values beyond plain TCP, including
+// unix domain sockets and systemd socket activation, for sidecar-less deployments behind local proxies.
+//
+// The returned listeners are suitable for the middleware package's Chain.Listener field, or any [http.Server.Serve] call. [ConnContext] should
+// be assigned to the [http.Server.ConnContext] field so that the accepted connection remains available to middleware through [Conn].
+//
+// [Counting] wraps any listener such that accepted connections report their byte counts, for environments where
+// infrastructure-level connection monitoring is unavailable. See the proxyproto subpackage for listeners behind layer-4 load
+// balancers conveying client addresses through the PROXY protocol.
+package listener
@@ -0,0 +1,192 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Conn] can the context's value be derived.
+const key keyer = "connection"
+
+// listenFdsStart represents the first file descriptor passed by systemd socket activation (SD_LISTEN_FDS_START).
+const listenFdsStart = 3
+
+// Unix establishes a unix domain socket listener at path, applying the provided file mode to the socket. A stale socket
+// file from a previous process is removed prior to listening; any other pre-existing file type results in an error.
+func Unix(path string, mode fs.FileMode) (net.Listener, error) {
+	if info, e := os.Lstat(path); e == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("unix socket path exists and isn't a socket: %s", path)
+		}
+
+		if e := os.Remove(path); e != nil {
+			return nil, fmt.Errorf("unable to remove stale unix socket: %w", e)
+		}
+	} else if !(errors.Is(e, fs.ErrNotExist)) {
+		return nil, e
+	}
+
+	listener, e := net.Listen("unix", path)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := os.Chmod(path, mode); e != nil {
+		listener.Close()
+
+		return nil, fmt.Errorf("unable to apply unix socket permissions: %w", e)
+	}
+
+	return listener, nil
+}
+
+// Activation represents a single listener inherited through systemd socket activation.
+type Activation struct {
+	net.Listener
+
+	// Name represents the socket's name as specified by the "FileDescriptorName=" systemd directive. Defaults to "unknown" per sd_listen_fds_with_names(3).
+	Name string
+}
+
+// Systemd returns the listeners passed to the current process through systemd socket activation (LISTEN_PID, LISTEN_FDS, and LISTEN_FDNAMES).
+// A nil slice is returned when the process wasn't socket-activated. The activation environment variables are unset once consumed so that
+// child processes don't inherit them.
+func Systemd() ([]Activation, error) {
+	pid, fds, names := os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_FDNAMES")
+	if pid == "" || fds == "" {
+		return nil, nil
+	}
+
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	if v, e := strconv.Atoi(pid); e != nil || v != os.Getpid() {
+		slog.Debug("Systemd Socket Activation Intended for Another Process", slog.String("pid", pid))
+
+		return nil, nil
+	}
+
+	count, e := strconv.Atoi(fds)
+	if e != nil || count < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS value: %q", fds)
+	}
+
+	var labels []string
+	if names != "" {
+		labels = strings.Split(names, ":")
+	}
+
+	activations := make([]Activation, 0, count)
+	for index := 0; index < count; index++ {
+		name := "unknown"
+		if index < len(labels) && labels[index] != "" {
+			name = labels[index]
+		}
+
+		file := os.NewFile(uintptr(listenFdsStart+index), name)
+
+		listener, e := net.FileListener(file)
+
+		// net.FileListener duplicates the descriptor, so the original is always closed.
+		file.Close()
+
+		if e != nil {
+			for _, activation := range activations {
+				activation.Close()
+			}
+
+			return nil, fmt.Errorf("unable to establish systemd listener (%s): %w", name, e)
+		}
+
+		activations = append(activations, Activation{Listener: listener, Name: name})
+	}
+
+	return activations, nil
+}
+
+// ConnContext stores the accepted [net.Conn] in the connection's base context. It's intended to be assigned to [http.Server.ConnContext].
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, key, c)
+}
+
+// Conn retrieves the [net.Conn] stored by [ConnContext]. A nil value is returned if the server wasn't configured with [ConnContext].
+func Conn(ctx context.Context) net.Conn {
+	if v, ok := ctx.Value(key).(net.Conn); ok {
+		return v
+	}
+
+	return nil
+}
+
+// Counter is a [net.Conn] counting the bytes read from, and written to, the underlying connection. See [Counting].
+type Counter struct {
+	net.Conn
+
+	read    atomic.Int64
+	written atomic.Int64
+}
+
+func (c *Counter) Read(p []byte) (int, error) {
+	n, e := c.Conn.Read(p)
+
+	c.read.Add(int64(n))
+
+	return n, e
+}
+
+func (c *Counter) Write(p []byte) (int, error) {
+	n, e := c.Conn.Write(p)
+
+	c.written.Add(int64(n))
+
+	return n, e
+}
+
+// Unwrap returns the underlying connection.
+func (c *Counter) Unwrap() net.Conn {
+	return c.Conn
+}
+
+// BytesRead returns the total number of bytes read from the connection.
+func (c *Counter) BytesRead() int64 {
+	return c.read.Load()
+}
+
+// BytesWritten returns the total number of bytes written to the connection.
+func (c *Counter) BytesWritten() int64 {
+	return c.written.Load()
+}
+
+// counting is a [net.Listener] wrapping each accepted connection in a [Counter].
+type counting struct {
+	net.Listener
+}
+
+func (l counting) Accept() (net.Conn, error) {
+	c, e := l.Listener.Accept()
+	if e != nil {
+		return nil, e
+	}
+
+	return &Counter{Conn: c}, nil
+}
+
+// Counting wraps the provided listener such that every accepted connection is a [*Counter]. Combined with [ConnContext],
+// per-connection byte counts are available to middleware through [Conn].
+func Counting(l net.Listener) net.Listener {
+	return counting{Listener: l}
+}
@@ -0,0 +1,191 @@
+package listener_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/listener"
+)
+
+func Test(t *testing.T) {
+	t.Run("Unix", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.sock")
+
+		// Simulate a stale socket from a previous process.
+		stale, e := net.Listen("unix", path)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Stale Listener: %v", e)
+		}
+
+		if l, ok := stale.(*net.UnixListener); ok {
+			l.SetUnlinkOnClose(false)
+		}
+
+		stale.Close()
+
+		l, e := listener.Unix(path, 0o660)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Unix Listener: %v", e)
+		}
+
+		defer l.Close()
+
+		info, e := os.Stat(path)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Evaluating Socket: %v", e)
+		}
+
+		if permissions := info.Mode().Perm(); permissions != 0o660 {
+			t.Errorf("Permissions = %o\n    - Expectation = %o", permissions, 0o660)
+		}
+
+		server := &http.Server{
+			ConnContext: listener.ConnContext,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if listener.Conn(r.Context()) == nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		}
+
+		go server.Serve(l)
+
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return new(net.Dialer).DialContext(ctx, "unix", path)
+				},
+			},
+		}
+
+		response, e := client.Get("http://unix/")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusNoContent)
+		}
+	})
+
+	t.Run("Unix-Non-Socket-Path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "regular-file")
+		if e := os.WriteFile(path, nil, 0o600); e != nil {
+			t.Fatalf("Unexpected Error While Writing File: %v", e)
+		}
+
+		if _, e := listener.Unix(path, 0o660); e == nil {
+			t.Errorf("Expected Error for Non-Socket Path")
+		}
+	})
+
+	t.Run("Systemd-Not-Activated", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", "")
+		t.Setenv("LISTEN_FDS", "")
+
+		activations, e := listener.Systemd()
+		if e != nil || activations != nil {
+			t.Errorf("Unexpected Activation(s): %v, %v", activations, e)
+		}
+	})
+
+	t.Run("Systemd-Other-Process", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+		t.Setenv("LISTEN_FDS", "1")
+
+		activations, e := listener.Systemd()
+		if e != nil || activations != nil {
+			t.Errorf("Unexpected Activation(s): %v, %v", activations, e)
+		}
+
+		if os.Getenv("LISTEN_FDS") != "" {
+			t.Errorf("Expected LISTEN_FDS to Be Unset")
+		}
+	})
+
+	t.Run("Systemd-Invalid-Count", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+		t.Setenv("LISTEN_FDS", "invalid")
+
+		if _, e := listener.Systemd(); e == nil {
+			t.Errorf("Expected Invalid LISTEN_FDS Error")
+		}
+	})
+
+	t.Run("Counting", func(t *testing.T) {
+		tcp, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+		}
+
+		l := listener.Counting(tcp)
+
+		defer l.Close()
+
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			c, e := l.Accept()
+			if e != nil {
+				close(accepted)
+				return
+			}
+
+			accepted <- c
+		}()
+
+		client, e := net.Dial("tcp", l.Addr().String())
+		if e != nil {
+			t.Fatalf("Unexpected Error While Dialing: %v", e)
+		}
+
+		defer client.Close()
+
+		c := <-accepted
+		if c == nil {
+			t.Fatalf("Unexpected Error While Accepting Connection")
+		}
+
+		defer c.Close()
+
+		counter, ok := c.(*listener.Counter)
+		if !(ok) {
+			t.Fatalf("Accepted Connection Type = %T\n    - Expectation = %T", c, counter)
+		}
+
+		client.Write([]byte("request"))
+
+		buffer := make([]byte, len("request"))
+		if _, e := io.ReadFull(c, buffer); e != nil {
+			t.Fatalf("Unexpected Error While Reading: %v", e)
+		}
+
+		c.Write([]byte("response!"))
+
+		if v := counter.BytesRead(); v != 7 {
+			t.Errorf("Bytes Read = %d\n    - Expectation = %d", v, 7)
+		}
+
+		if v := counter.BytesWritten(); v != 9 {
+			t.Errorf("Bytes Written = %d\n    - Expectation = %d", v, 9)
+		}
+	})
+
+	t.Run("Conn-Default", func(t *testing.T) {
+		if c := listener.Conn(context.Background()); c != nil {
+			t.Errorf("Unexpected Non-Nil Connection: %v", c)
+		}
+	})
+}
@@ -0,0 +1,12 @@
+// Package proxyproto provides a [net.Listener] wrapper parsing PROXY protocol (v1 and v2) headers, as prepended by layer-4 load
+// balancers (e.g. HAProxy, AWS Network Load Balancers, or Envoy), such that accepted connections report the original client's
+// address through [net.Conn.RemoteAddr] - and therefore [http.Request.RemoteAddr].
+//
+// Version 2 type-length-value (TLV) extensions - e.g. the ALPN, authority, or a cloud provider's endpoint identifiers - are
+// available through [Conn.Header]. The companion [Middleware] surfaces the original client address, and the full [Header], through
+// the request context, following the rip middleware's context scheme (see [Value], [Addr], and [Proxied]).
+//
+// A PROXY header is only trustworthy if it was written by a trusted load balancer. Either the wrapped listener shouldn't be
+// reachable by clients directly, or [Listener.Policy] should distinguish trusted upstreams - e.g. via [Trusted] - ignoring, or
+// rejecting, headers from all others. [Listener.Health] passes through load balancer health checks sent without a header.
+package proxyproto
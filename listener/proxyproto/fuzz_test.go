@@ -0,0 +1,95 @@
+package proxyproto_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/listener/proxyproto"
+)
+
+func FuzzRead(f *testing.F) {
+	source := &net.TCPAddr{IP: net.ParseIP("192.0.2.1").To4(), Port: 56324}
+	destination := &net.TCPAddr{IP: net.ParseIP("198.51.100.1").To4(), Port: 443}
+
+	f.Add([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\nGET / HTTP/1.1\r\n\r\n"))
+	f.Add([]byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"))
+	f.Add([]byte("PROXY UNKNOWN\r\n"))
+	f.Add([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 056324 443\r\n"))
+	f.Add([]byte("GET /healthz HTTP/1.1\r\n\r\n"))
+
+	for _, header := range []*proxyproto.Header{
+		{Version: 2, Command: proxyproto.CommandProxy, Source: source, Destination: destination},
+		{Version: 2, Command: proxyproto.CommandLocal},
+		{Version: 2, Command: proxyproto.CommandProxy, Source: source, Destination: destination, TLVs: []proxyproto.TLV{
+			{Type: proxyproto.TypeAuthority, Value: []byte("example.com")},
+			{Type: proxyproto.TypeALPN, Value: []byte("h2")},
+			{Type: proxyproto.TypeAWS, Value: []byte("\x01vpce-0123456789abcdef0")},
+			{Type: proxyproto.TypeNoop},
+		}},
+	} {
+		formatted, e := header.Format()
+		if e != nil {
+			f.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		f.Add(formatted)
+
+		// A TLV whose declared length exceeds the header's payload.
+		truncated := bytes.Clone(formatted)
+		truncated = append(truncated, byte(proxyproto.TypeAuthority), 0xFF, 0xFF)
+		truncated[15] += 3
+
+		f.Add(truncated)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		reader := bufio.NewReader(bytes.NewReader(input))
+
+		header, e := proxyproto.Read(reader)
+		if e != nil {
+			if !(errors.Is(e, proxyproto.ErrInvalid) || errors.Is(e, io.EOF)) {
+				t.Errorf("Error = %v\n    - Expectation = %v", e, proxyproto.ErrInvalid)
+			}
+
+			return
+		}
+
+		if header == nil {
+			// Streams without a header must be left unconsumed.
+			if remainder, _ := io.ReadAll(reader); !(bytes.Equal(remainder, input)) {
+				t.Errorf("Remainder = %q\n    - Expectation = %q", remainder, input)
+			}
+
+			return
+		}
+
+		if header.Version != 2 {
+			return
+		}
+
+		// Version 2 TLVs must survive a round trip through the wire format.
+		formatted, e := header.Format()
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		parsed, e := proxyproto.Read(bufio.NewReader(bytes.NewReader(formatted)))
+		if e != nil || parsed == nil {
+			t.Fatalf("Unexpected Error While Reading Formatted Header: %v", e)
+		}
+
+		if len(parsed.TLVs) != len(header.TLVs) {
+			t.Fatalf("TLVs = %d\n    - Expectation = %d", len(parsed.TLVs), len(header.TLVs))
+		}
+
+		for index, tlv := range header.TLVs {
+			if parsed.TLVs[index].Type != tlv.Type || !(bytes.Equal(parsed.TLVs[index].Value, tlv.Value)) {
+				t.Errorf("TLV (%d) = %+v\n    - Expectation = %+v", index, parsed.TLVs[index], tlv)
+			}
+		}
+	})
+}
@@ -0,0 +1,352 @@
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// signature represents the PROXY protocol v2 header's 12-byte signature.
+var signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// v1 represents the PROXY protocol v1 header's prefix.
+var v1 = []byte("PROXY ")
+
+// limit represents the maximum length of a v1 header, including its CRLF terminator.
+const limit = 107
+
+var (
+	// ErrMissing is returned for connections without a PROXY header, where the [Listener.Policy] is [Require].
+	ErrMissing = errors.New("proxyproto: missing proxy protocol header")
+
+	// ErrInvalid is returned for malformed PROXY headers.
+	ErrInvalid = errors.New("proxyproto: invalid proxy protocol header")
+
+	// ErrRejected is returned for connections with a PROXY header, where the [Listener.Policy] is [Reject].
+	ErrRejected = errors.New("proxyproto: rejected proxy protocol header")
+)
+
+// Command represents the PROXY header's command.
+type Command byte
+
+const (
+	// CommandLocal represents a connection established by the proxy itself (e.g. a health check); the header's addresses are
+	// ignored, and the connection's own addresses retained.
+	CommandLocal Command = 0x0
+
+	// CommandProxy represents a connection relayed on behalf of a client.
+	CommandProxy Command = 0x1
+)
+
+// Type represents a PROXY protocol v2 TLV's type.
+type Type byte
+
+const (
+	TypeALPN      Type = 0x01 // TypeALPN represents the negotiated application protocol.
+	TypeAuthority Type = 0x02 // TypeAuthority represents the client-requested host name (e.g. TLS SNI).
+	TypeCRC32C    Type = 0x03 // TypeCRC32C represents the header's checksum.
+	TypeNoop      Type = 0x04 // TypeNoop represents padding.
+	TypeUniqueID  Type = 0x05 // TypeUniqueID represents the proxy-assigned connection identifier.
+	TypeSSL       Type = 0x20 // TypeSSL represents the client's TLS details.
+	TypeNetNS     Type = 0x30 // TypeNetNS represents the proxy's network namespace.
+	TypeAWS       Type = 0xEA // TypeAWS represents AWS-specific details (e.g. the client's VPC endpoint), prefixed by a subtype byte.
+)
+
+// subtypeVPCEndpoint represents the [TypeAWS] TLV subtype of the client's VPC endpoint identifier.
+const subtypeVPCEndpoint = 0x01
+
+// TLV represents a PROXY protocol v2 type-length-value extension.
+type TLV struct {
+	Type  Type
+	Value []byte
+}
+
+// Header represents a parsed PROXY protocol header.
+type Header struct {
+	// Version represents the header's protocol version - 1 or 2.
+	Version int
+
+	// Command represents the header's command. Version 1 headers are always [CommandProxy].
+	Command Command
+
+	// Source represents the original client's address. Nil for [CommandLocal] headers, and for unknown address families.
+	Source net.Addr
+
+	// Destination represents the address the client connected to. Nil for [CommandLocal] headers, and for unknown address families.
+	Destination net.Addr
+
+	// TLVs represents the header's version 2 extensions, in order of appearance.
+	TLVs []TLV
+}
+
+// Lookup returns the value of the header's first TLV of the provided type.
+func (h *Header) Lookup(t Type) (value []byte, found bool) {
+	for _, tlv := range h.TLVs {
+		if tlv.Type == t {
+			return tlv.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Authority returns the [TypeAuthority] TLV's value, or an empty string if absent.
+func (h *Header) Authority() string {
+	value, _ := h.Lookup(TypeAuthority)
+
+	return string(value)
+}
+
+// ALPN returns the [TypeALPN] TLV's value - the negotiated application protocol (e.g. "h2") - or an empty string if absent.
+func (h *Header) ALPN() string {
+	value, _ := h.Lookup(TypeALPN)
+
+	return string(value)
+}
+
+// VPCEndpoint returns the client's AWS VPC endpoint identifier (e.g. "vpce-0123456789abcdef0"), as conveyed by AWS PrivateLink
+// through a [TypeAWS] TLV, or an empty string if absent.
+func (h *Header) VPCEndpoint() string {
+	for _, tlv := range h.TLVs {
+		if tlv.Type == TypeAWS && len(tlv.Value) > 1 && tlv.Value[0] == subtypeVPCEndpoint {
+			return string(tlv.Value[1:])
+		}
+	}
+
+	return ""
+}
+
+// Read parses a PROXY header from the reader. A nil header, and nil error, is returned if the stream doesn't begin with a PROXY
+// header; the reader is otherwise left unconsumed.
+func Read(reader *bufio.Reader) (*Header, error) {
+	prefix, e := reader.Peek(1)
+	if e != nil {
+		return nil, e
+	}
+
+	switch prefix[0] {
+	case v1[0]:
+		if prefix, e := reader.Peek(len(v1)); e != nil || !(bytes.Equal(prefix, v1)) {
+			return nil, nil
+		}
+
+		return text(reader)
+	case signature[0]:
+		if prefix, e := reader.Peek(len(signature)); e != nil || !(bytes.Equal(prefix, signature)) {
+			return nil, nil
+		}
+
+		return binary2(reader)
+	}
+
+	return nil, nil
+}
+
+// text parses a version 1 (human-readable) header - e.g. "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n".
+func text(reader *bufio.Reader) (*Header, error) {
+	var line []byte
+	for {
+		b, e := reader.ReadByte()
+		if e != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, e)
+		}
+
+		line = append(line, b)
+		if b == '\n' {
+			break
+		} else if len(line) >= limit {
+			return nil, fmt.Errorf("%w: header exceeds %d bytes", ErrInvalid, limit)
+		}
+	}
+
+	if !(bytes.HasSuffix(line, []byte("\r\n"))) {
+		return nil, fmt.Errorf("%w: missing crlf terminator", ErrInvalid)
+	}
+
+	fields := strings.Split(string(line[len(v1):len(line)-2]), " ")
+
+	header := &Header{Version: 1, Command: CommandProxy}
+
+	switch fields[0] {
+	case "UNKNOWN":
+		return header, nil
+	case "TCP4", "TCP6":
+	default:
+		return nil, fmt.Errorf("%w: unsupported protocol: %q", ErrInvalid, fields[0])
+	}
+
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: unexpected field count", ErrInvalid)
+	}
+
+	source, e := address(fields[0], fields[1], fields[3])
+	if e != nil {
+		return nil, e
+	}
+
+	destination, e := address(fields[0], fields[2], fields[4])
+	if e != nil {
+		return nil, e
+	}
+
+	header.Source, header.Destination = source, destination
+
+	return header, nil
+}
+
+// address parses a version 1 header's address and port.
+func address(protocol, host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil || (protocol == "TCP4") != (ip.To4() != nil) {
+		return nil, fmt.Errorf("%w: invalid address: %q", ErrInvalid, host)
+	}
+
+	number, e := strconv.ParseUint(port, 10, 16)
+	if e != nil || (len(port) > 1 && port[0] == '0') {
+		return nil, fmt.Errorf("%w: invalid port: %q", ErrInvalid, port)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: int(number)}, nil
+}
+
+// binary2 parses a version 2 (binary) header.
+func binary2(reader *bufio.Reader) (*Header, error) {
+	fixed := make([]byte, 16)
+	if _, e := io.ReadFull(reader, fixed); e != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, e)
+	}
+
+	if version := fixed[12] >> 4; version != 2 {
+		return nil, fmt.Errorf("%w: unsupported version: %d", ErrInvalid, version)
+	}
+
+	header := &Header{Version: 2, Command: Command(fixed[12] & 0x0F)}
+	if header.Command != CommandLocal && header.Command != CommandProxy {
+		return nil, fmt.Errorf("%w: unsupported command: %d", ErrInvalid, header.Command)
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint16(fixed[14:16]))
+	if _, e := io.ReadFull(reader, payload); e != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, e)
+	}
+
+	family, transport := fixed[13]>>4, fixed[13]&0x0F
+
+	var size int
+	switch family {
+	case 0x1: // AF_INET
+		size = 12
+	case 0x2: // AF_INET6
+		size = 36
+	case 0x3: // AF_UNIX
+		size = 216
+	}
+
+	if len(payload) < size {
+		return nil, fmt.Errorf("%w: truncated addresses", ErrInvalid)
+	}
+
+	if header.Command == CommandProxy {
+		addresses := payload[:size]
+
+		switch family {
+		case 0x1, 0x2:
+			length := (size - 4) / 2
+
+			source, destination := net.IP(bytes.Clone(addresses[:length])), net.IP(bytes.Clone(addresses[length:2*length]))
+			sport, dport := int(binary.BigEndian.Uint16(addresses[2*length:])), int(binary.BigEndian.Uint16(addresses[2*length+2:]))
+
+			if transport == 0x2 { // SOCK_DGRAM
+				header.Source, header.Destination = &net.UDPAddr{IP: source, Port: sport}, &net.UDPAddr{IP: destination, Port: dport}
+			} else {
+				header.Source, header.Destination = &net.TCPAddr{IP: source, Port: sport}, &net.TCPAddr{IP: destination, Port: dport}
+			}
+		case 0x3:
+			header.Source = &net.UnixAddr{Name: string(bytes.TrimRight(addresses[:108], "\x00")), Net: "unix"}
+			header.Destination = &net.UnixAddr{Name: string(bytes.TrimRight(addresses[108:], "\x00")), Net: "unix"}
+		}
+	}
+
+	for remainder := payload[size:]; len(remainder) > 0; {
+		if len(remainder) < 3 {
+			return nil, fmt.Errorf("%w: truncated tlv", ErrInvalid)
+		}
+
+		length := int(binary.BigEndian.Uint16(remainder[1:3]))
+		if len(remainder) < 3+length {
+			return nil, fmt.Errorf("%w: truncated tlv", ErrInvalid)
+		}
+
+		header.TLVs = append(header.TLVs, TLV{Type: Type(remainder[0]), Value: bytes.Clone(remainder[3 : 3+length])})
+
+		remainder = remainder[3+length:]
+	}
+
+	return header, nil
+}
+
+// Format encodes the header in its [Header.Version] wire format - e.g. for proxies, or tests, relaying connections to a [Listener].
+// Version 1 headers don't support TLVs; a header without TCP source and destination addresses is encoded as "UNKNOWN".
+func (h *Header) Format() ([]byte, error) {
+	source, sok := h.Source.(*net.TCPAddr)
+	destination, dok := h.Destination.(*net.TCPAddr)
+
+	if h.Version == 1 {
+		if !(sok && dok) || (source.IP.To4() != nil) != (destination.IP.To4() != nil) {
+			return []byte("PROXY UNKNOWN\r\n"), nil
+		}
+
+		protocol := "TCP6"
+		if source.IP.To4() != nil {
+			protocol = "TCP4"
+		}
+
+		return []byte(fmt.Sprintf("PROXY %s %s %s %d %d\r\n", protocol, source.IP, destination.IP, source.Port, destination.Port)), nil
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(signature)
+	buffer.WriteByte(0x20 | byte(h.Command))
+
+	var addresses []byte
+	switch {
+	case h.Command == CommandLocal || !(sok && dok):
+		buffer.WriteByte(0x00)
+	case source.IP.To4() != nil && destination.IP.To4() != nil:
+		buffer.WriteByte(0x11)
+		addresses = append(append(addresses, source.IP.To4()...), destination.IP.To4()...)
+	default:
+		buffer.WriteByte(0x21)
+		addresses = append(append(addresses, source.IP.To16()...), destination.IP.To16()...)
+	}
+
+	if addresses != nil {
+		addresses = binary.BigEndian.AppendUint16(addresses, uint16(source.Port))
+		addresses = binary.BigEndian.AppendUint16(addresses, uint16(destination.Port))
+	}
+
+	for _, tlv := range h.TLVs {
+		if len(tlv.Value) > 0xFFFF {
+			return nil, fmt.Errorf("%w: tlv exceeds %d bytes", ErrInvalid, 0xFFFF)
+		}
+
+		addresses = append(addresses, byte(tlv.Type))
+		addresses = binary.BigEndian.AppendUint16(addresses, uint16(len(tlv.Value)))
+		addresses = append(addresses, tlv.Value...)
+	}
+
+	if len(addresses) > 0xFFFF {
+		return nil, fmt.Errorf("%w: header exceeds %d bytes", ErrInvalid, 0xFFFF)
+	}
+
+	buffer.Write(binary.BigEndian.AppendUint16(nil, uint16(len(addresses))))
+	buffer.Write(addresses)
+
+	return buffer.Bytes(), nil
+}
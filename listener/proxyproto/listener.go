@@ -0,0 +1,201 @@
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultTimeout = time.Second * 10
+
+// Listener is a [net.Listener] wrapping every accepted connection in a [*Conn]. Headers are parsed lazily - upon the connection's
+// first read, or address lookup - such that a slow client can't stall [Listener.Accept]. Combined with listener.ConnContext, the
+// accepted connection is available to middleware through listener.Conn:
+//
+//	server := &http.Server{ConnContext: listener.ConnContext, Handler: handler}
+//	server.Serve(&proxyproto.Listener{Listener: tcp, Policy: proxyproto.Trusted(proxyproto.Require, subnet)})
+type Listener struct {
+	net.Listener
+
+	// Timeout represents the duration allotted to reading a connection's header. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// Required rejects connections without a PROXY header - reads fail with [ErrMissing]. Otherwise, such connections are served
+	// as-is, with their own addresses. Ignored if [Listener.Policy] is specified. Defaults to false.
+	Required bool
+
+	// Policy returns the [Policy] applied to connections from the upstream - the connection's immediate peer - e.g. [Trusted]. Any
+	// client able to connect directly can otherwise spoof its address through a PROXY header. Defaults to nil: [Require] if
+	// [Listener.Required] is true, and [Use] otherwise.
+	Policy func(upstream net.Addr) Policy
+
+	// Health represents HTTP health check paths (e.g. "/healthz") passed through as-is - with the connection's own addresses - on
+	// [Require] connections without a PROXY header, such as AWS Network Load Balancer health checks, which don't send one. A
+	// connection passes through if its request line is a GET, or HEAD, of one of the paths - for that request alone: reads beyond
+	// the request's headers fail with [ErrMissing], such that a pipelined, or keep-alive, request is never served. Defaults to nil.
+	Health []string
+}
+
+func (l *Listener) Accept() (net.Conn, error) {
+	c, e := l.Listener.Accept()
+	if e != nil {
+		return nil, e
+	}
+
+	timeout := l.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	policy := Use
+	if l.Required {
+		policy = Require
+	}
+
+	if l.Policy != nil {
+		policy = l.Policy(c.RemoteAddr())
+	}
+
+	return &Conn{Conn: c, reader: bufio.NewReader(c), timeout: timeout, policy: policy, health: l.Health, remaining: -1}, nil
+}
+
+// Conn is a [net.Conn] reporting the addresses conveyed by its PROXY header. See [Listener].
+type Conn struct {
+	net.Conn
+
+	reader  *bufio.Reader
+	timeout time.Duration
+	policy  Policy
+	health  []string
+
+	// remaining represents the unread bytes of a passed-through health check request; negative for connections without one.
+	remaining int
+
+	once   sync.Once
+	header *Header
+	e      error
+}
+
+// parse reads the connection's header, exactly once.
+func (c *Conn) parse() {
+	c.once.Do(func() {
+		c.Conn.SetReadDeadline(time.Now().Add(c.timeout))
+		defer c.Conn.SetReadDeadline(time.Time{})
+
+		c.header, c.e = Read(c.reader)
+		if c.e != nil {
+			return
+		}
+
+		switch {
+		case c.header == nil && c.policy == Require:
+			if c.remaining = c.check(); c.remaining <= 0 {
+				c.e = ErrMissing
+			}
+		case c.header != nil && c.policy == Ignore:
+			c.header = nil
+		case c.header != nil && c.policy == Reject:
+			c.header, c.e = nil, ErrRejected
+		}
+	})
+}
+
+// check returns the length of the connection's leading health check request - a GET, or HEAD, of one of the [Listener.Health]
+// paths, through the end of its headers - or 0 if the connection doesn't begin with one.
+func (c *Conn) check() int {
+	matched := false
+	for _, path := range c.health {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			line := method + " " + path + " "
+			if v, _ := c.reader.Peek(len(line)); string(v) == line {
+				matched = true
+			}
+		}
+	}
+
+	if !(matched) {
+		return 0
+	}
+
+	for {
+		buffered := c.reader.Buffered()
+
+		v, _ := c.reader.Peek(buffered)
+		if index := bytes.Index(v, []byte("\r\n\r\n")); index >= 0 {
+			return index + 4
+		}
+
+		if buffered >= c.reader.Size() {
+			return 0
+		}
+
+		if _, e := c.reader.Peek(buffered + 1); e != nil {
+			return 0
+		}
+	}
+}
+
+// Header returns the connection's parsed PROXY header. A nil header, and nil error, is returned for connections without a header,
+// unless the [Listener] requires one, and for connections whose header is ignored by the [Listener.Policy].
+func (c *Conn) Header() (*Header, error) {
+	c.parse()
+
+	return c.header, c.e
+}
+
+func (c *Conn) Read(p []byte) (int, error) {
+	if c.parse(); c.e != nil {
+		return 0, c.e
+	}
+
+	if c.remaining < 0 {
+		return c.reader.Read(p)
+	}
+
+	// A passed-through health check: serve the request alone, failing upon any subsequent data.
+	if c.remaining == 0 {
+		if _, e := c.reader.Peek(1); e != nil {
+			return 0, e
+		}
+
+		return 0, ErrMissing
+	}
+
+	if len(p) > c.remaining {
+		p = p[:c.remaining]
+	}
+
+	n, e := c.reader.Read(p)
+
+	c.remaining -= n
+
+	return n, e
+}
+
+// RemoteAddr returns the header's source address, or the underlying connection's remote address if the connection carries no
+// header, a [CommandLocal] header, or a header of an unknown address family.
+func (c *Conn) RemoteAddr() net.Addr {
+	if c.parse(); c.header != nil && c.header.Source != nil {
+		return c.header.Source
+	}
+
+	return c.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the header's destination address, or the underlying connection's local address if the connection carries no
+// header, a [CommandLocal] header, or a header of an unknown address family.
+func (c *Conn) LocalAddr() net.Addr {
+	if c.parse(); c.header != nil && c.header.Destination != nil {
+		return c.header.Destination
+	}
+
+	return c.Conn.LocalAddr()
+}
+
+// Unwrap returns the underlying connection.
+func (c *Conn) Unwrap() net.Conn {
+	return c.Conn
+}
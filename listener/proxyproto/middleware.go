@@ -0,0 +1,145 @@
+package proxyproto
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/listener"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "proxy-protocol"
+
+// proxied is the package's unexported context key for the connection's header. Only through the use of [Proxied] can the context's value be derived.
+const proxied keyer = "proxy-protocol-header"
+
+// Options represents the configuration settings for the [Middleware] middleware component.
+type Options struct {
+	// Level specifies whether a log message should be logged in the [Middleware] middleware component's [Middleware.Handler] function. A value
+	// of nil causes the [Middleware.Handler] to skip logging of the client address, entirely. Defaults to nil.
+	Level slog.Leveler
+}
+
+// Middleware represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Middleware struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Middleware] middleware's [Options] and returns the updated middleware instance.
+func (x *Middleware) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Level: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	return x
+}
+
+// connection returns the request's [*Conn], unwrapping the connection stored by listener.ConnContext as necessary (e.g. a
+// listener.Counter wrapping the [Listener]'s connections).
+func connection(ctx context.Context) *Conn {
+	c := listener.Conn(ctx)
+	for c != nil {
+		if v, ok := c.(*Conn); ok {
+			return v
+		}
+
+		unwrapper, ok := c.(interface{ Unwrap() net.Conn })
+		if !(ok) {
+			break
+		}
+
+		c = unwrapper.Unwrap()
+	}
+
+	return nil
+}
+
+// Handler stores the original client's address - the request's [http.Request.RemoteAddr] host, as rewritten by the [Listener] - and
+// the connection's PROXY [Header] in the request context. The header is only available if the server assigns listener.ConnContext.
+func (x *Middleware) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		value, _, e := net.SplitHostPort(r.RemoteAddr)
+		if e != nil {
+			value = r.RemoteAddr
+		}
+
+		var header *Header
+		if c := connection(ctx); c != nil {
+			header, _ = c.Header()
+		}
+
+		if v := x.options.Level; v != nil {
+			slog.Log(ctx, v.Level(), "Proxy-Protocol Middleware", slog.String("value", value), slog.Bool("proxied", header != nil))
+		}
+
+		ctx = context.WithValue(ctx, key, value)
+		ctx = context.WithValue(ctx, proxied, header)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Middleware] middleware, implementing [middleware.Configurable]. If [Middleware.Settings] isn't called,
+// then the [Middleware.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Middleware)
+}
+
+// Value retrieves the original client's address from the provided context.
+func Value(ctx context.Context) (address string) {
+	if v, ok := ctx.Value(key).(string); ok {
+		address = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Addr retrieves the original client's address from the provided context, parsed as a [net.IP]. If a nil value is returned, the
+// address isn't an IP address (e.g. a unix socket's path), or it can be assumed that the [Middleware] isn't enabled for the particular
+// caller's chain.
+func Addr(ctx context.Context) net.IP {
+	if v, ok := ctx.Value(key).(string); ok {
+		return net.ParseIP(v)
+	}
+
+	slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+
+	return nil
+}
+
+// Proxied retrieves the connection's PROXY [Header] from the provided context - e.g. to evaluate its TLVs. If a nil value is returned,
+// the connection carried no header, the server doesn't assign listener.ConnContext, or the [Middleware] isn't enabled for the
+// particular caller's chain.
+func Proxied(ctx context.Context) *Header {
+	if v, ok := ctx.Value(proxied).(*Header); ok {
+		return v
+	}
+
+	return nil
+}
+
+// Runtime assurance that [Middleware] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Middleware)(nil)
@@ -0,0 +1,69 @@
+package proxyproto
+
+import (
+	"net"
+	"net/netip"
+)
+
+// Policy represents a [Listener]'s treatment of PROXY headers from a given upstream - the connection's immediate peer.
+type Policy int
+
+const (
+	// Use parses the connection's PROXY header, if present; connections without one are served as-is, with their own addresses.
+	Use Policy = iota
+
+	// Require parses the connection's PROXY header, failing reads with [ErrMissing] if absent - see [Listener.Health] for
+	// exceptions.
+	Require
+
+	// Ignore parses, and discards, the connection's PROXY header, if present; the connection retains its own addresses. Suitable
+	// for untrusted upstreams, whose headers can't be relied upon.
+	Ignore
+
+	// Reject fails reads of connections with a PROXY header with [ErrRejected]; connections without one are served as-is. Suitable
+	// for untrusted upstreams, for which a header indicates an attempt to spoof the client's address.
+	Reject
+)
+
+// String returns the policy's name.
+func (p Policy) String() string {
+	switch p {
+	case Use:
+		return "use"
+	case Require:
+		return "require"
+	case Ignore:
+		return "ignore"
+	case Reject:
+		return "reject"
+	}
+
+	return "unknown"
+}
+
+// Trusted returns a [Listener.Policy] function applying the policy to upstreams within the prefixes (e.g. the load balancers'
+// subnets), and [Reject] to all others:
+//
+//	l := &proxyproto.Listener{Listener: tcp, Policy: proxyproto.Trusted(proxyproto.Require, netip.MustParsePrefix("10.0.0.0/16"))}
+func Trusted(policy Policy, prefixes ...netip.Prefix) func(upstream net.Addr) Policy {
+	return func(upstream net.Addr) Policy {
+		var address netip.Addr
+		if upstream == nil {
+			return Reject
+		} else if v, ok := upstream.(*net.TCPAddr); ok {
+			address = v.AddrPort().Addr()
+		} else if v, e := netip.ParseAddrPort(upstream.String()); e == nil {
+			address = v.Addr()
+		}
+
+		address = address.Unmap()
+
+		for _, prefix := range prefixes {
+			if address.IsValid() && prefix.Contains(address) {
+				return policy
+			}
+		}
+
+		return Reject
+	}
+}
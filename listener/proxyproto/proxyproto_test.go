@@ -0,0 +1,408 @@
+package proxyproto_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/listener"
+	"github.com/poly-gun/go-middleware/listener/proxyproto"
+)
+
+func Test(t *testing.T) {
+	source := &net.TCPAddr{IP: net.ParseIP("192.0.2.1").To4(), Port: 56324}
+	destination := &net.TCPAddr{IP: net.ParseIP("198.51.100.1").To4(), Port: 443}
+
+	t.Run("Read", func(t *testing.T) {
+		v2, e := (&proxyproto.Header{
+			Version:     2,
+			Command:     proxyproto.CommandProxy,
+			Source:      source,
+			Destination: destination,
+			TLVs:        []proxyproto.TLV{{Type: proxyproto.TypeAuthority, Value: []byte("example.com")}},
+		}).Format()
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		local, e := (&proxyproto.Header{Version: 2, Command: proxyproto.CommandLocal}).Format()
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		tests := []struct {
+			name        string
+			input       string
+			version     int
+			source      string
+			authority   string
+			remainder   string
+			invalid     bool
+			passthrough bool
+		}{
+			{name: "V1-TCP4", input: "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\nGET /", version: 1, source: "192.0.2.1:56324", remainder: "GET /"},
+			{name: "V1-TCP6", input: "PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\nGET /", version: 1, source: "[2001:db8::1]:56324", remainder: "GET /"},
+			{name: "V1-Unknown", input: "PROXY UNKNOWN\r\nGET /", version: 1, remainder: "GET /"},
+			{name: "V1-Mismatched-Family", input: "PROXY TCP4 2001:db8::1 198.51.100.1 56324 443\r\n", invalid: true},
+			{name: "V1-Invalid-Port", input: "PROXY TCP4 192.0.2.1 198.51.100.1 65536 443\r\n", invalid: true},
+			{name: "V1-Missing-CRLF", input: "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\n", invalid: true},
+			{name: "V1-Oversized", input: "PROXY TCP4 " + strings.Repeat("1", 128) + "\r\n", invalid: true},
+			{name: "V2-TLV", input: string(v2) + "GET /", version: 2, source: "192.0.2.1:56324", authority: "example.com", remainder: "GET /"},
+			{name: "V2-Local", input: string(local) + "GET /", version: 2, remainder: "GET /"},
+			{name: "V2-Truncated", input: string(v2[:len(v2)-4]), invalid: true},
+			{name: "No-Header", input: "GET / HTTP/1.1\r\n", passthrough: true, remainder: "GET / HTTP/1.1\r\n"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				reader := bufio.NewReader(strings.NewReader(test.input))
+
+				header, e := proxyproto.Read(reader)
+				if test.invalid {
+					if !(errors.Is(e, proxyproto.ErrInvalid)) {
+						t.Errorf("Error = %v\n    - Expectation = %v", e, proxyproto.ErrInvalid)
+					}
+
+					return
+				}
+
+				if e != nil {
+					t.Fatalf("Unexpected Error While Reading Header: %v", e)
+				}
+
+				remainder, _ := io.ReadAll(reader)
+				if string(remainder) != test.remainder {
+					t.Errorf("Remainder = %q\n    - Expectation = %q", string(remainder), test.remainder)
+				}
+
+				if test.passthrough {
+					if header != nil {
+						t.Errorf("Header = %+v\n    - Expectation = nil", header)
+					}
+
+					return
+				}
+
+				if header.Version != test.version {
+					t.Errorf("Version = %d\n    - Expectation = %d", header.Version, test.version)
+				}
+
+				if value := fmt.Sprint(header.Source); test.source != "" && value != test.source {
+					t.Errorf("Source = %s\n    - Expectation = %s", value, test.source)
+				} else if test.source == "" && header.Source != nil {
+					t.Errorf("Source = %s\n    - Expectation = nil", header.Source)
+				}
+
+				if value := header.Authority(); value != test.authority {
+					t.Errorf("Authority = %s\n    - Expectation = %s", value, test.authority)
+				}
+			})
+		}
+	})
+
+	t.Run("Format-V1", func(t *testing.T) {
+		value, e := (&proxyproto.Header{Version: 1, Source: source, Destination: destination}).Format()
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		if expectation := "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"; string(value) != expectation {
+			t.Errorf("Header = %q\n    - Expectation = %q", string(value), expectation)
+		}
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		tcp, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+		}
+
+		l := &proxyproto.Listener{Listener: tcp, Required: true, Timeout: time.Second}
+
+		defer l.Close()
+
+		go func() {
+			client, e := net.Dial("tcp", l.Addr().String())
+			if e != nil {
+				return
+			}
+
+			defer client.Close()
+
+			client.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+		}()
+
+		c, e := l.Accept()
+		if e != nil {
+			t.Fatalf("Unexpected Error While Accepting Connection: %v", e)
+		}
+
+		defer c.Close()
+
+		if _, e := c.Read(make([]byte, 16)); !(errors.Is(e, proxyproto.ErrMissing)) {
+			t.Errorf("Error = %v\n    - Expectation = %v", e, proxyproto.ErrMissing)
+		}
+	})
+
+	t.Run("TLVs", func(t *testing.T) {
+		header := &proxyproto.Header{TLVs: []proxyproto.TLV{
+			{Type: proxyproto.TypeALPN, Value: []byte("h2")},
+			{Type: proxyproto.TypeAWS, Value: append([]byte{0x01}, "vpce-0123456789abcdef0"...)},
+		}}
+
+		if v := header.ALPN(); v != "h2" {
+			t.Errorf("ALPN = %s\n    - Expectation = %s", v, "h2")
+		}
+
+		if v := header.VPCEndpoint(); v != "vpce-0123456789abcdef0" {
+			t.Errorf("VPC Endpoint = %s\n    - Expectation = %s", v, "vpce-0123456789abcdef0")
+		}
+
+		if v := new(proxyproto.Header).VPCEndpoint(); v != "" {
+			t.Errorf("VPC Endpoint = %s\n    - Expectation = %s", v, "")
+		}
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		header, e := (&proxyproto.Header{Version: 2, Command: proxyproto.CommandProxy, Source: source, Destination: destination}).Format()
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		loopback, remote := netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("10.0.0.0/8")
+
+		tests := []struct {
+			name    string
+			policy  func(upstream net.Addr) proxyproto.Policy
+			health  []string
+			input   string
+			e       error
+			proxied bool
+		}{
+			{name: "Trusted", policy: proxyproto.Trusted(proxyproto.Require, loopback), input: string(header) + "GET / HTTP/1.1\r\n", proxied: true},
+			{name: "Trusted-Missing", policy: proxyproto.Trusted(proxyproto.Require, loopback), input: "GET / HTTP/1.1\r\n", e: proxyproto.ErrMissing},
+			{name: "Untrusted-Spoofed", policy: proxyproto.Trusted(proxyproto.Require, remote), input: string(header) + "GET / HTTP/1.1\r\n", e: proxyproto.ErrRejected},
+			{name: "Untrusted-Direct", policy: proxyproto.Trusted(proxyproto.Require, remote), input: "GET / HTTP/1.1\r\n"},
+			{name: "Ignore", policy: func(net.Addr) proxyproto.Policy { return proxyproto.Ignore }, input: string(header) + "GET / HTTP/1.1\r\n"},
+			{name: "Health-Check", policy: func(net.Addr) proxyproto.Policy { return proxyproto.Require }, health: []string{"/healthz"}, input: "GET /healthz HTTP/1.1\r\nHost: localhost\r\n\r\n"},
+			{name: "Health-Check-Incomplete", policy: func(net.Addr) proxyproto.Policy { return proxyproto.Require }, health: []string{"/healthz"}, input: "GET /healthz HTTP/1.1\r\n", e: proxyproto.ErrMissing},
+			{name: "Health-Check-Other-Path", policy: func(net.Addr) proxyproto.Policy { return proxyproto.Require }, health: []string{"/healthz"}, input: "GET /admin HTTP/1.1\r\n", e: proxyproto.ErrMissing},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				tcp, e := net.Listen("tcp", "127.0.0.1:0")
+				if e != nil {
+					t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+				}
+
+				l := &proxyproto.Listener{Listener: tcp, Policy: test.policy, Health: test.health, Timeout: time.Second}
+
+				defer l.Close()
+
+				go func() {
+					client, e := net.Dial("tcp", l.Addr().String())
+					if e != nil {
+						return
+					}
+
+					defer client.Close()
+
+					client.Write([]byte(test.input))
+
+					time.Sleep(time.Millisecond * 100)
+				}()
+
+				c, e := l.Accept()
+				if e != nil {
+					t.Fatalf("Unexpected Error While Accepting Connection: %v", e)
+				}
+
+				defer c.Close()
+
+				line, e := bufio.NewReader(c).ReadString('\n')
+				if !(errors.Is(e, test.e)) {
+					t.Fatalf("Error = %v\n    - Expectation = %v", e, test.e)
+				}
+
+				if test.e != nil {
+					return
+				}
+
+				if !(strings.HasPrefix(line, "GET /") || strings.HasPrefix(line, "HEAD /")) {
+					t.Errorf("Line = %q\n    - Expectation = %s", line, "the request line")
+				}
+
+				if remote := c.RemoteAddr().String(); (remote == source.String()) != test.proxied {
+					t.Errorf("Remote Address = %s\n    - Expectation (Proxied) = %t", remote, test.proxied)
+				}
+			})
+		}
+	})
+
+	t.Run("Health-Check-Pipelined", func(t *testing.T) {
+		tcp, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+		}
+
+		l := &proxyproto.Listener{Listener: tcp, Required: true, Health: []string{"/healthz"}, Timeout: time.Second}
+
+		paths := make(chan string, 2)
+
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+
+			w.WriteHeader(http.StatusOK)
+		})}
+
+		go server.Serve(l)
+
+		defer server.Close()
+
+		client, e := net.Dial("tcp", l.Addr().String())
+		if e != nil {
+			t.Fatalf("Unexpected Error While Dialing Listener: %v", e)
+		}
+
+		defer client.Close()
+
+		client.SetDeadline(time.Now().Add(time.Second * 5))
+
+		client.Write([]byte("GET /healthz HTTP/1.1\r\nHost: localhost\r\n\r\nGET /admin HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+
+		reader := bufio.NewReader(client)
+
+		response, e := http.ReadResponse(reader, nil)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Reading Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+		}
+
+		if _, e := http.ReadResponse(reader, nil); e == nil {
+			t.Errorf("Error = %v\n    - Expectation = %s", e, "the connection's closure")
+		}
+
+		close(paths)
+
+		var served []string
+		for path := range paths {
+			served = append(served, path)
+		}
+
+		if len(served) != 1 || served[0] != "/healthz" {
+			t.Errorf("Served = %v\n    - Expectation = %v", served, []string{"/healthz"})
+		}
+	})
+
+	t.Run("Middleware", func(t *testing.T) {
+		tcp, e := net.Listen("tcp", "127.0.0.1:0")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Establishing Listener: %v", e)
+		}
+
+		l := &proxyproto.Listener{Listener: tcp}
+
+		type observation struct {
+			remote    string
+			value     string
+			authority string
+		}
+
+		observations := make(chan observation, 1)
+
+		m := proxyproto.New()
+
+		server := &http.Server{
+			ConnContext: listener.ConnContext,
+			Handler: m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var authority string
+				if header := proxyproto.Proxied(r.Context()); header != nil {
+					authority = header.Authority()
+				}
+
+				observations <- observation{remote: r.RemoteAddr, value: proxyproto.Value(r.Context()), authority: authority}
+
+				w.WriteHeader(http.StatusNoContent)
+			})),
+		}
+
+		go server.Serve(l)
+
+		defer server.Shutdown(context.Background())
+
+		header, e := (&proxyproto.Header{
+			Version:     2,
+			Command:     proxyproto.CommandProxy,
+			Source:      source,
+			Destination: destination,
+			TLVs:        []proxyproto.TLV{{Type: proxyproto.TypeAuthority, Value: []byte("example.com")}},
+		}).Format()
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Formatting Header: %v", e)
+		}
+
+		client, e := net.Dial("tcp", l.Addr().String())
+		if e != nil {
+			t.Fatalf("Unexpected Error While Dialing: %v", e)
+		}
+
+		defer client.Close()
+
+		client.Write(bytes.Join([][]byte{header, []byte("GET / HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n")}, nil))
+
+		response, e := http.ReadResponse(bufio.NewReader(client), nil)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Reading Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusNoContent)
+		}
+
+		select {
+		case v := <-observations:
+			if v.remote != "192.0.2.1:56324" {
+				t.Errorf("Remote Address = %s\n    - Expectation = %s", v.remote, "192.0.2.1:56324")
+			}
+
+			if v.value != "192.0.2.1" {
+				t.Errorf("Value = %s\n    - Expectation = %s", v.value, "192.0.2.1")
+			}
+
+			if v.authority != "example.com" {
+				t.Errorf("Authority = %s\n    - Expectation = %s", v.authority, "example.com")
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Handler Wasn't Called")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if value := proxyproto.Value(context.Background()); value != "" {
+			t.Errorf("Value = %s\n    - Expectation = %s", value, "an empty string")
+		}
+
+		if header := proxyproto.Proxied(context.Background()); header != nil {
+			t.Errorf("Proxied = %+v\n    - Expectation = nil", header)
+		}
+	})
+}
@@ -0,0 +1,388 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry represents a middleware's [Manifest] entry.
+type Entry struct {
+	// Name represents the middleware's name - its package's name, unless overridden by [Middleware.Describe] (e.g. "cors").
+	Name string `json:"name"`
+
+	// Package represents the middleware's import path (e.g. "github.com/poly-gun/go-middleware/middleware/cors").
+	Package string `json:"package,omitempty"`
+
+	// Version represents the middleware's module version, as recorded in the binary's build information. Empty if unavailable.
+	Version string `json:"version,omitempty"`
+
+	// Options represents the middleware's non-secret options - as read from a [Component], or provided to [Middleware.Describe].
+	Options map[string]any `json:"options,omitempty"`
+
+	component Component // component represents the [Component] whose options are read upon generating the [Manifest].
+}
+
+// Manifest represents a self-description of a middleware chain: its middleware, in order, and its routes' chains.
+type Manifest struct {
+	// Name represents the [Chain]'s name. Empty for a [Middleware] instance's manifest.
+	Name string `json:"name,omitempty"`
+
+	// Address represents the [Chain]'s server address.
+	Address string `json:"address,omitempty"`
+
+	// Prefix represents the route's prefix - see [Middleware.Route]. Empty for the top-level chain.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Middleware represents the chain's middleware, in order; the first entry is the outermost.
+	Middleware []Entry `json:"middleware"`
+
+	// Routes represents the chain's routes, ordered by prefix.
+	Routes []Manifest `json:"routes,omitempty"`
+}
+
+// redacted replaces the values of options whose name suggests a secret.
+const redacted = "[redacted]"
+
+// secrets represents the (lowercase) option name fragments considered secret.
+var secrets = []string{"secret", "password", "passphrase", "token", "key", "credential", "private", "signature"}
+
+// build returns the binary's build information, if available.
+var build = sync.OnceValue(func() *debug.BuildInfo {
+	information, _ := debug.ReadBuildInfo()
+
+	return information
+})
+
+// version returns the package's module version, as recorded in the binary's build information.
+func version(pkg string) (version string) {
+	information := build()
+	if information == nil || pkg == "" {
+		return ""
+	}
+
+	length := -1
+	for _, module := range append([]*debug.Module{&information.Main}, information.Deps...) {
+		if module == nil || module.Path == "" {
+			continue
+		}
+
+		if (pkg == module.Path || strings.HasPrefix(pkg, module.Path+"/")) && len(module.Path) > length {
+			length, version = len(module.Path), module.Version
+			if module.Replace != nil && module.Replace.Version != "" {
+				version = module.Replace.Version
+			}
+		}
+	}
+
+	return
+}
+
+// entry returns the package's [Entry], named after the package's last path element.
+func entry(pkg string) Entry {
+	name := pkg
+	if index := strings.LastIndex(pkg, "/"); index >= 0 {
+		name = pkg[index+1:]
+	}
+
+	return Entry{Name: name, Package: pkg, Version: version(pkg)}
+}
+
+// describe derives the middleware function's [Entry] from its symbol (e.g. "github.com/.../cors.(*CORS).Handler-fm"). Method
+// values of an interface - e.g. a [Configurable] - can't be attributed to their implementation; see [Middleware.Use].
+func describe(fn func(http.Handler) http.Handler) Entry {
+	function := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if function == nil || strings.Contains(function.Name(), ".Configurable[") {
+		return Entry{Name: "unknown"}
+	}
+
+	symbol := function.Name()
+
+	directory, base := "", symbol
+	if index := strings.LastIndex(symbol, "/"); index >= 0 {
+		directory, base = symbol[:index+1], symbol[index+1:]
+	}
+
+	name, _, _ := strings.Cut(base, ".")
+
+	return entry(directory + name)
+}
+
+// sensitive reports whether the option's name suggests a secret.
+func sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range secrets {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sanitize converts the value into its JSON-compatible representation, omitting functions and channels, and redacting options
+// whose name suggests a secret. The boolean reports whether the value is representable.
+func sanitize(value reflect.Value, depth int) (any, bool) {
+	if !(value.IsValid()) || depth > 8 {
+		return nil, false
+	}
+
+	if value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, false
+		}
+
+		if value.CanInterface() {
+			if v, ok := value.Interface().(fmt.Stringer); ok && value.Kind() == reflect.Pointer {
+				return v.String(), true
+			}
+		}
+
+		return sanitize(value.Elem(), depth+1)
+	}
+
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(value.Int()).String(), true
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.String:
+		return value.String(), true
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false // Byte slices are typically keys, or other binary material.
+		}
+
+		items := make([]any, 0, value.Len())
+		for index := 0; index < value.Len(); index++ {
+			if item, ok := sanitize(value.Index(index), depth+1); ok {
+				items = append(items, item)
+			}
+		}
+
+		return items, true
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		object := make(map[string]any, value.Len())
+		for iterator := value.MapRange(); iterator.Next(); {
+			name := iterator.Key().String()
+			if sensitive(name) {
+				object[name] = redacted
+			} else if item, ok := sanitize(iterator.Value(), depth+1); ok {
+				object[name] = item
+			}
+		}
+
+		return object, true
+	case reflect.Struct:
+		object := make(map[string]any)
+
+		exported := false
+		for index := 0; index < value.NumField(); index++ {
+			field := value.Type().Field(index)
+			if !(field.IsExported()) {
+				continue
+			}
+
+			exported = true
+
+			name := field.Name
+			if tag, found := field.Tag.Lookup("json"); found {
+				if tag, _, _ = strings.Cut(tag, ","); tag == "-" {
+					continue
+				} else if tag != "" {
+					name = tag
+				}
+			}
+
+			if sensitive(name) {
+				object[name] = redacted
+			} else if item, ok := sanitize(value.Field(index), depth+1); ok {
+				object[name] = item
+			}
+		}
+
+		// Structs without exported fields (e.g. a [sync.Mutex]) aren't representable.
+		return object, exported
+	}
+
+	return nil, false
+}
+
+// represent returns the value's JSON-compatible representation as an object - see [sanitize].
+func represent(value reflect.Value) map[string]any {
+	v, ok := sanitize(value, 0)
+	if !(ok) {
+		return nil
+	}
+
+	if object, valid := v.(map[string]any); valid {
+		return object
+	}
+
+	return map[string]any{"value": v}
+}
+
+// Component represents a middleware component - e.g. any [Configurable].
+type Component interface {
+	// Handler wraps the provided [http.Handler] with middleware functionality and returns a new [http.Handler].
+	Handler(next http.Handler) http.Handler
+}
+
+// Use appends the components' Handler functions to the middleware chain, as [Middleware.Add] does, while recording each
+// component's package, module version, and Options in the chain's [Manifest]. Components following this module's conventions -
+// an unexported "options" field - have their options read when the manifest is generated.
+func (m *Middleware) Use(components ...Component) {
+	for _, component := range components {
+		if component == nil {
+			continue
+		}
+
+		m.middleware = append(m.middleware, component.Handler)
+
+		v := Entry{Name: "unknown", component: component}
+		if t := reflect.TypeOf(component); t.Kind() == reflect.Pointer && t.Elem().PkgPath() != "" {
+			v = entry(t.Elem().PkgPath())
+			v.component = component
+		}
+
+		m.entries = append(m.entries, v)
+	}
+}
+
+// Describe annotates the most recently added middleware's [Manifest] entry: a non-empty name overrides its derived name, and the
+// options - typically the middleware's Options value - are recorded, omitting functions, channels, and byte slices, and redacting
+// fields whose name suggests a secret (e.g. "Secret", "Password", "Token", "Key"). Describe is a no-op if no middleware was added.
+func (m *Middleware) Describe(name string, options any) {
+	if len(m.entries) == 0 {
+		return
+	}
+
+	entry := &m.entries[len(m.entries)-1]
+	if name != "" {
+		entry.Name = name
+	}
+
+	if v := reflect.ValueOf(options); v.IsValid() {
+		entry.Options, entry.component = represent(v), nil
+	}
+}
+
+// Manifest returns the chain's self-description: its middleware, in order, and its routes' chains.
+func (m *Middleware) Manifest() Manifest {
+	manifest := Manifest{Middleware: append([]Entry{}, m.entries...)}
+
+	for index := range manifest.Middleware {
+		if component := manifest.Middleware[index].component; component != nil {
+			if v := reflect.ValueOf(component); v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+				if field := v.Elem().FieldByName("options"); field.IsValid() {
+					manifest.Middleware[index].Options = represent(field)
+				}
+			}
+
+			manifest.Middleware[index].component = nil
+		}
+	}
+
+	for _, route := range m.routes {
+		v := route.chain.Manifest()
+		v.Prefix = route.prefix
+
+		manifest.Routes = append(manifest.Routes, v)
+	}
+
+	sort.Slice(manifest.Routes, func(i, j int) bool {
+		return manifest.Routes[i].Prefix < manifest.Routes[j].Prefix
+	})
+
+	return manifest
+}
+
+// Stack returns a compact description of the chain's composition, suitable for a response header: its middleware's "name@version"
+// identifiers, in order and comma-separated, followed by each route's ";prefix=" group (e.g. "cors@v1.2.0,service@v1.0.1;/admin/=extauthz").
+// Versions are omitted if unavailable.
+func (m Manifest) Stack() string {
+	var builder strings.Builder
+
+	for index, entry := range m.Middleware {
+		if index > 0 {
+			builder.WriteString(",")
+		}
+
+		builder.WriteString(entry.Name)
+		if entry.Version != "" {
+			builder.WriteString("@" + entry.Version)
+		}
+	}
+
+	for _, route := range m.Routes {
+		builder.WriteString(";" + route.Prefix + "=" + route.Stack())
+	}
+
+	return builder.String()
+}
+
+// Digest returns a short, stable hash of the chain's composition - its middleware's packages and versions, and its routes' - such that
+// services running an identical middleware stack report an identical value (e.g. "sha256:3f2a9c0d1e4b5a6f").
+func (m Manifest) Digest() string {
+	hash := sha256.New()
+
+	var write func(m Manifest)
+	write = func(m Manifest) {
+		for _, entry := range m.Middleware {
+			fmt.Fprintf(hash, "%s\x00%s\x00%s\n", entry.Name, entry.Package, entry.Version)
+		}
+
+		for _, route := range m.Routes {
+			fmt.Fprintf(hash, "route\x00%s\n", route.Prefix)
+
+			write(route)
+
+			hash.Write([]byte("end\n"))
+		}
+	}
+
+	write(m)
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// Manifest returns the registered chains' self-descriptions, in registration order.
+func (c *Chains) Manifest() []Manifest {
+	c.mutex.Lock()
+	chains := append([]*Chain(nil), c.chains...)
+	c.mutex.Unlock()
+
+	manifests := make([]Manifest, 0, len(chains))
+	for _, chain := range chains {
+		manifest := Manifest{Middleware: []Entry{}}
+		if chain.Middleware != nil {
+			manifest = chain.Middleware.Manifest()
+		}
+
+		manifest.Name, manifest.Address = chain.Name, chain.Server.Addr
+
+		manifests = append(manifests, manifest)
+	}
+
+	return manifests
+}
@@ -0,0 +1,24 @@
+// Package manifest provides a protected endpoint serializing the service's active middleware chains - each middleware's name,
+// package, module version, and non-secret options, in order - as JSON, or YAML. Platform tooling can then verify that deployed
+// services run the mandated middleware stack.
+//
+// Chains describe themselves: [middleware.Middleware.Use] records each component's package, module version, and options - read
+// from the component when the manifest is generated - redacting fields whose name suggests a secret. Middleware added as plain
+// functions can be annotated with [middleware.Middleware.Describe]:
+//
+//	chain := middleware.New()
+//	chain.Use(cors.New().Settings(func(o *cors.Options) { o.Origins = []string{"https://example.com"} }))
+//	chain.Add(legacy)
+//	chain.Describe("legacy", map[string]any{"mode": "strict"})
+//
+//	chains := new(middleware.Chains)
+//	chains.Register(&middleware.Chain{Name: "public", Server: server, Handler: mux, Middleware: chain})
+//
+//	chain.Use(manifest.New().Settings(func(o *manifest.Options) {
+//		o.Source = chains.Manifest
+//		o.Token = os.Getenv("MANIFEST_TOKEN")
+//	}))
+//
+// The endpoint requires a bearer [Options.Token], an [Options.Authorize] function, or both; it's otherwise disabled. YAML is served
+// for a "format=yaml" query parameter, or an Accept header preferring YAML.
+package manifest
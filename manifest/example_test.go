@@ -0,0 +1,76 @@
+package manifest_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/extauthz"
+	"github.com/poly-gun/go-middleware/manifest"
+)
+
+func Example() {
+	chain := middleware.New()
+
+	chain.Use(extauthz.New().Settings(func(o *extauthz.Options) {
+		o.Prefix = "/authz"
+	}))
+
+	chain.Use(manifest.New().Settings(func(o *manifest.Options) {
+		o.Source = func() []middleware.Manifest { return []middleware.Manifest{chain.Manifest()} }
+		o.Token = "platform-token"
+	}))
+
+	server := httptest.NewServer(chain.Handler(http.NotFoundHandler()))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/.well-known/middleware-manifest?format=yaml", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Authorization", "Bearer platform-token")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	content, _ := io.ReadAll(response.Body)
+
+	fmt.Println(response.StatusCode)
+
+	// Module versions depend on the binary's build information, and are omitted from the example's output.
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if !(strings.Contains(line, "version:")) {
+			fmt.Println(line)
+		}
+	}
+
+	// Output:
+	// 200
+	// chains:
+	//   - middleware:
+	//       - name: "extauthz"
+	//         options:
+	//           Policy: []
+	//           Prefix: "/authz"
+	//         package: "github.com/poly-gun/go-middleware/extauthz"
+	//       - name: "manifest"
+	//         options:
+	//           Path: "/.well-known/middleware-manifest"
+	//           Token: "[redacted]"
+	//         package: "github.com/poly-gun/go-middleware/manifest"
+}
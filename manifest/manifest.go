@@ -0,0 +1,178 @@
+package manifest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// Document represents the endpoint's response body.
+type Document struct {
+	// Chains represents the service's chains - see [middleware.Manifest].
+	Chains []middleware.Manifest `json:"chains"`
+}
+
+// Options represents the configuration settings for the [Endpoint] middleware component.
+type Options struct {
+	// Path represents the endpoint's path. Other requests are forwarded to the next handler. Defaults to
+	// "/.well-known/middleware-manifest".
+	Path string
+
+	// Source returns the active chains' manifests - typically [middleware.Chains.Manifest], or a single [middleware.Middleware.Manifest].
+	// The endpoint is disabled if nil. Defaults to nil.
+	Source func() []middleware.Manifest
+
+	// Token represents the bearer token authorizing requests, compared in constant time. Defaults to an empty string.
+	Token string
+
+	// Authorize optionally reports whether the request is authorized - e.g. by its mTLS peer certificate. Requests must satisfy both
+	// [Options.Token], and Authorize, if specified. The endpoint is disabled if neither is specified. Defaults to nil.
+	Authorize func(r *http.Request) bool
+}
+
+// Endpoint represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Endpoint struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Endpoint] middleware's [Options] and returns the updated middleware instance.
+func (x *Endpoint) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Path:      "/.well-known/middleware-manifest",
+			Source:    nil,
+			Token:     "",
+			Authorize: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if !(strings.HasPrefix(x.options.Path, "/")) {
+		slog.Warn("Invalid Manifest Path Specified - Using Default Path")
+
+		x.options.Path = "/.well-known/middleware-manifest"
+	}
+
+	return x
+}
+
+// authorized reports whether the request satisfies [Options.Token], and [Options.Authorize].
+func (x *Endpoint) authorized(r *http.Request) bool {
+	if x.options.Token != "" {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !(found) || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(x.options.Token)) != 1 {
+			return false
+		}
+	}
+
+	return x.options.Authorize == nil || x.options.Authorize(r)
+}
+
+// yaml reports whether the request prefers a YAML representation - via a "format=yaml" query parameter, or its Accept header.
+func yaml(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.EqualFold(format, "yaml")
+	}
+
+	accept := strings.ToLower(r.Header.Get("Accept"))
+
+	return strings.Contains(accept, "yaml") && !(strings.Contains(accept, "json"))
+}
+
+// Handler serves the [Options.Source] manifests at [Options.Path] - as JSON, or YAML if preferred - to authorized GET and HEAD
+// requests; unauthorized requests receive a [http.StatusUnauthorized] response. Other requests are forwarded to the next handler.
+func (x *Endpoint) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	enabled := x.options.Source != nil
+	if !(enabled) {
+		slog.Warn("Manifest Source Not Specified - Disabling Endpoint")
+	} else if x.options.Token == "" && x.options.Authorize == nil {
+		slog.Warn("Manifest Endpoint Unprotected - Disabling Endpoint")
+
+		enabled = false
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !(enabled) || r.URL.Path != x.options.Path {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if !(x.authorized(r)) {
+			reject.Record(r.Context(), reject.Reason{Subsystem: "manifest", Code: "manifest-unauthorized", Status: http.StatusUnauthorized})
+
+			if x.options.Token != "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="manifest"`)
+			}
+
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		document := Document{Chains: x.options.Source()}
+		if document.Chains == nil {
+			document.Chains = []middleware.Manifest{}
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Add("Vary", "Accept")
+
+		if yaml(r) {
+			data, e := marshal(document)
+			if e != nil {
+				slog.ErrorContext(r.Context(), "Unable to Encode Manifest", slog.String("error", e.Error()))
+
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/yaml")
+
+			w.Write(data)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(document)
+	})
+}
+
+// New creates a new instance of the [Endpoint] middleware, implementing [middleware.Configurable]. If [Endpoint.Settings] isn't
+// called, then the [Endpoint.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Endpoint)
+}
+
+// Runtime assurance that [Endpoint] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Endpoint)(nil)
@@ -0,0 +1,121 @@
+package manifest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/extauthz"
+	"github.com/poly-gun/go-middleware/manifest"
+)
+
+func Test(t *testing.T) {
+	chain := middleware.New()
+
+	chain.Use(extauthz.New())
+	chain.Add(func(next http.Handler) http.Handler { return next })
+	chain.Describe("legacy", map[string]any{"mode": "strict", "api-key": "value"})
+	chain.Route("/admin/").Use(extauthz.New().Settings(func(o *extauthz.Options) { o.Prefix = "/admin/ext-authz" }))
+
+	source := func() []middleware.Manifest {
+		return []middleware.Manifest{chain.Manifest()}
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name          string
+		options       func(o *manifest.Options)
+		method        string
+		path          string
+		authorization string
+		accept        string
+		status        int
+		content       string
+	}{
+		{name: "Disabled-Source", options: func(o *manifest.Options) { o.Token = "token" }, method: http.MethodGet, path: "/.well-known/middleware-manifest", authorization: "Bearer token", status: http.StatusNoContent},
+		{name: "Disabled-Unprotected", options: func(o *manifest.Options) { o.Source = source }, method: http.MethodGet, path: "/.well-known/middleware-manifest", status: http.StatusNoContent},
+		{name: "Other-Path", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodGet, path: "/", status: http.StatusNoContent},
+		{name: "Unauthorized", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodGet, path: "/.well-known/middleware-manifest", authorization: "Bearer other", status: http.StatusUnauthorized},
+		{name: "Method", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodPost, path: "/.well-known/middleware-manifest", authorization: "Bearer token", status: http.StatusMethodNotAllowed},
+		{name: "JSON", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodGet, path: "/.well-known/middleware-manifest", authorization: "Bearer token", status: http.StatusOK, content: "application/json"},
+		{name: "YAML-Query", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodGet, path: "/.well-known/middleware-manifest?format=yaml", authorization: "Bearer token", status: http.StatusOK, content: "application/yaml"},
+		{name: "YAML-Accept", options: func(o *manifest.Options) { o.Source, o.Token = source, "token" }, method: http.MethodGet, path: "/.well-known/middleware-manifest", authorization: "Bearer token", accept: "application/yaml", status: http.StatusOK, content: "application/yaml"},
+		{
+			name: "Authorize",
+			options: func(o *manifest.Options) {
+				o.Source, o.Path = source, "/manifest"
+				o.Authorize = func(r *http.Request) bool { return r.Header.Get("X-Platform") == "true" }
+			},
+			method: http.MethodGet,
+			path:   "/manifest",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			if tt.accept != "" {
+				request.Header.Set("Accept", tt.accept)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			manifest.New().Settings(tt.options).Handler(handler).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if v := recorder.Header().Get("Content-Type"); tt.content != "" && v != tt.content {
+				t.Errorf("Content-Type = %s\n    - Expectation = %s", v, tt.content)
+			}
+
+			body, _ := io.ReadAll(recorder.Body)
+
+			switch tt.content {
+			case "application/json":
+				var document manifest.Document
+				if e := json.Unmarshal(body, &document); e != nil {
+					t.Fatalf("Unexpected Error While Decoding Manifest: %v", e)
+				}
+
+				if len(document.Chains) != 1 || len(document.Chains[0].Middleware) != 2 || len(document.Chains[0].Routes) != 1 {
+					t.Fatalf("Manifest = %s\n    - Expectation = %s", body, "a single chain, with two middleware, and a route")
+				}
+
+				if entry := document.Chains[0].Middleware[1]; entry.Name != "legacy" || entry.Options["api-key"] != "[redacted]" || entry.Options["mode"] != "strict" {
+					t.Errorf("Entry = %+v\n    - Expectation = %s", entry, "the described legacy middleware, with its key redacted")
+				}
+
+				if v := document.Chains[0].Routes[0].Middleware[0].Options["Prefix"]; v != "/admin/ext-authz" {
+					t.Errorf("Prefix = %v\n    - Expectation = %s", v, "/admin/ext-authz")
+				}
+			case "application/yaml":
+				for _, fragment := range []string{
+					"chains:\n  - middleware:\n      - name: \"extauthz\"\n",
+					"        package: \"github.com/poly-gun/go-middleware/extauthz\"\n",
+					"      - name: \"legacy\"\n        options:\n          api-key: \"[redacted]\"\n          mode: \"strict\"\n",
+					"    routes:\n      - middleware:\n",
+					"            options:\n              Policy: []\n              Prefix: \"/admin/ext-authz\"\n",
+					"        prefix: \"/admin/\"\n",
+				} {
+					if !(strings.Contains(string(body), fragment)) {
+						t.Errorf("Manifest = %s\n    - Expectation = %q", body, fragment)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// plain matches mapping keys that needn't be quoted.
+var plain = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// scalar returns the value's YAML scalar representation. Strings are double-quoted, as JSON strings are valid YAML scalars.
+func scalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		quoted, _ := json.Marshal(v)
+
+		return string(quoted)
+	case map[string]any:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+
+	return "null"
+}
+
+// block reports whether the value is rendered as a block collection, rather than a scalar.
+func block(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+
+	return false
+}
+
+// render writes the decoded JSON value as YAML, indented by the given number of spaces. Mapping keys are sorted.
+func render(buffer *bytes.Buffer, value any, indent int) {
+	padding := strings.Repeat(" ", indent)
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			name := k
+			if !(plain.MatchString(k)) {
+				name = scalar(k)
+			}
+
+			if block(v[k]) {
+				buffer.WriteString(padding + name + ":\n")
+
+				render(buffer, v[k], indent+2)
+			} else {
+				buffer.WriteString(padding + name + ": " + scalar(v[k]) + "\n")
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if !(block(item)) {
+				buffer.WriteString(padding + "- " + scalar(item) + "\n")
+
+				continue
+			}
+
+			// Render the item one level deeper, then replace its first line's indentation with the sequence indicator.
+			var nested bytes.Buffer
+
+			render(&nested, item, indent+2)
+
+			buffer.WriteString(padding + "- ")
+			buffer.Write(nested.Bytes()[indent+2:])
+		}
+	default:
+		buffer.WriteString(padding + scalar(v) + "\n")
+	}
+}
+
+// marshal encodes the value as a YAML document, by way of its JSON representation.
+func marshal(value any) ([]byte, error) {
+	data, e := json.Marshal(value)
+	if e != nil {
+		return nil, e
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded any
+	if e := decoder.Decode(&decoded); e != nil {
+		return nil, e
+	}
+
+	var buffer bytes.Buffer
+
+	render(&buffer, decoded, 0)
+
+	return buffer.Bytes(), nil
+}
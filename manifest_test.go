@@ -0,0 +1,153 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/extauthz"
+	"github.com/poly-gun/go-middleware/manifest"
+)
+
+func TestManifest(t *testing.T) {
+	type Options struct {
+		Prefix   string
+		Timeout  time.Duration
+		Secret   string
+		Key      []byte
+		Callback func()
+		Pattern  *regexp.Regexp
+		Ignored  string `json:"-"`
+		Renamed  int    `json:"limit"`
+		Nested   *struct{ Password, User string }
+		Headers  map[string]any
+	}
+
+	chain := middleware.New()
+
+	chain.Describe("ignored", nil) // No middleware was added; a no-op.
+
+	chain.Use(extauthz.New().Settings(func(o *extauthz.Options) { o.Prefix = "/authz" }))
+
+	chain.Add(extauthz.New().Handler) // An interface's method value can't be attributed to its implementation.
+
+	chain.Add(func(next http.Handler) http.Handler { return next })
+	chain.Describe("", Options{
+		Prefix:   "/ext-authz",
+		Timeout:  time.Second,
+		Secret:   "hunter2",
+		Key:      []byte("key"),
+		Callback: func() {},
+		Pattern:  regexp.MustCompile(`^/api`),
+		Ignored:  "ignored",
+		Renamed:  10,
+		Nested:   &struct{ Password, User string }{Password: "hunter2", User: "admin"},
+		Headers:  map[string]any{"api-token": "value", "level": 3},
+	})
+
+	chain.Add(func(next http.Handler) http.Handler { return next })
+	chain.Describe("custom", 5)
+
+	chain.Route("/admin/").Use(manifest.New())
+
+	m := chain.Manifest()
+
+	if len(m.Middleware) != 4 {
+		t.Fatalf("Middleware = %+v\n    - Expectation = %d entries", m.Middleware, 4)
+	}
+
+	if entry := m.Middleware[0]; entry.Name != "extauthz" || entry.Package != "github.com/poly-gun/go-middleware/extauthz" {
+		t.Errorf("Entry = %s (%s)\n    - Expectation = %s (%s)", entry.Name, entry.Package, "extauthz", "github.com/poly-gun/go-middleware/extauthz")
+	}
+
+	if v := m.Middleware[0].Options; !(reflect.DeepEqual(v, map[string]any{"Prefix": "/authz", "Policy": []any{}})) {
+		t.Errorf("Options = %v\n    - Expectation = %s", v, "the component's representable options")
+	}
+
+	if entry := m.Middleware[1]; entry.Name != "unknown" || entry.Package != "" {
+		t.Errorf("Entry = %s (%s)\n    - Expectation = %s", entry.Name, entry.Package, "unknown")
+	}
+
+	if entry := m.Middleware[2]; entry.Name != "go-middleware_test" {
+		t.Errorf("Entry = %s\n    - Expectation = %s", entry.Name, "go-middleware_test")
+	}
+
+	expectation := map[string]any{
+		"Prefix":  "/ext-authz",
+		"Timeout": "1s",
+		"Secret":  "[redacted]",
+		"Key":     "[redacted]",
+		"Pattern": "^/api",
+		"limit":   float64(10),
+		"Nested":  map[string]any{"Password": "[redacted]", "User": "admin"},
+		"Headers": map[string]any{"api-token": "[redacted]", "level": float64(3)},
+	}
+
+	// Compare the entries' JSON representations, as the manifest endpoint serves them.
+	data, _ := json.Marshal(m.Middleware[2].Options)
+
+	var options map[string]any
+	json.Unmarshal(data, &options)
+
+	if !(reflect.DeepEqual(options, expectation)) {
+		t.Errorf("Options = %v\n    - Expectation = %v", options, expectation)
+	}
+
+	if entry := m.Middleware[3]; entry.Name != "custom" || !(reflect.DeepEqual(entry.Options, map[string]any{"value": int64(5)})) {
+		t.Errorf("Entry = %+v\n    - Expectation = %s", entry, "a custom name, and its value")
+	}
+
+	if len(m.Routes) != 1 || m.Routes[0].Prefix != "/admin/" || len(m.Routes[0].Middleware) != 1 || m.Routes[0].Middleware[0].Name != "manifest" {
+		t.Errorf("Routes = %+v\n    - Expectation = %s", m.Routes, "a single /admin/ route, including the manifest middleware")
+	}
+
+	t.Run("Stack", func(t *testing.T) {
+		stack := middleware.Manifest{
+			Middleware: []middleware.Entry{{Name: "cors", Package: "example.com/cors", Version: "v1.2.0"}, {Name: "service"}},
+			Routes: []middleware.Manifest{
+				{Prefix: "/admin/", Middleware: []middleware.Entry{{Name: "extauthz", Version: "v0.0.1"}}},
+			},
+		}
+
+		if v := stack.Stack(); v != "cors@v1.2.0,service;/admin/=extauthz@v0.0.1" {
+			t.Errorf("Stack = %s\n    - Expectation = %s", v, "cors@v1.2.0,service;/admin/=extauthz@v0.0.1")
+		}
+
+		digest := stack.Digest()
+		if !(regexp.MustCompile(`^sha256:[0-9a-f]{16}$`).MatchString(digest)) {
+			t.Errorf("Digest = %s\n    - Expectation = %s", digest, "sha256:<16 hexadecimal characters>")
+		}
+
+		stack.Routes[0].Middleware[0].Version = "v0.0.2"
+		if v := stack.Digest(); v == digest {
+			t.Errorf("Digest = %s\n    - Expectation = %s", v, "a different digest upon a version change")
+		}
+	})
+
+	t.Run("Chains", func(t *testing.T) {
+		chains := new(middleware.Chains)
+
+		chains.Register(
+			&middleware.Chain{Name: "public", Server: &http.Server{Addr: ":8080"}, Middleware: chain},
+			&middleware.Chain{Name: "metrics", Server: &http.Server{Addr: ":9090"}},
+		)
+
+		manifests := chains.Manifest()
+
+		if len(manifests) != 2 {
+			t.Fatalf("Manifests = %+v\n    - Expectation = %d", manifests, 2)
+		}
+
+		if manifests[0].Name != "public" || manifests[0].Address != ":8080" || len(manifests[0].Middleware) != 4 {
+			t.Errorf("Manifest = %+v\n    - Expectation = %s", manifests[0], "the public chain")
+		}
+
+		if manifests[1].Name != "metrics" || manifests[1].Middleware == nil || len(manifests[1].Middleware) != 0 {
+			t.Errorf("Manifest = %+v\n    - Expectation = %s", manifests[1], "the metrics chain, without middleware")
+		}
+	})
+}
@@ -0,0 +1,8 @@
+// Package affinity provides request affinity (session stickiness) middleware for stateful backends: each request receives a stable
+// affinity key - its affinity cookie's, or a hash of its principal or client address - and, if [Options.Upstreams] is specified,
+// the upstream selected for the key by rendezvous (highest random weight) hashing.
+//
+// Rendezvous hashing rebalances minimally upon upstream changes: only keys whose upstream was removed, or whose key now ranks a
+// newly added upstream highest, move. The cookie records the client's last upstream, such that moves are counted as affinity
+// breaks - see [Affinity.Counts]. Reverse-proxying middleware, or handlers, select upstreams via [Value].
+package affinity
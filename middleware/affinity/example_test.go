@@ -0,0 +1,64 @@
+package affinity_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/affinity"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	instance := affinity.New()
+
+	middleware.Add(instance.Settings(func(o *affinity.Options) {
+		o.Upstreams = func() []string {
+			return []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.0.3:8080"}
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	selections := make(map[string]int)
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		selections[affinity.Value(r.Context()).Upstream]++
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for range 3 {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+	}
+
+	counts := instance.Counts()
+
+	fmt.Printf("Upstreams: %d, Assigned: %d, Breaks: %d\n", len(selections), counts.Assigned, counts.Breaks)
+
+	// Output:
+	// Upstreams: 1, Assigned: 3, Breaks: 0
+}
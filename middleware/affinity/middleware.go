@@ -0,0 +1,313 @@
+package affinity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "affinity"
+
+// Source represents an affinity key's source.
+type Source string
+
+const (
+	// Cookie represents a key read from the [Options.Cookie] cookie.
+	Cookie Source = "cookie"
+
+	// Principal represents a key derived from the [Options.Principal] value.
+	Principal Source = "principal"
+
+	// Address represents a key derived from the [Options.Client] address.
+	Address Source = "address"
+)
+
+// Valuer is the context return type relating to the [Affinity] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Key represents the request's stable affinity key.
+	Key string `json:"key"`
+
+	// Source represents the source the [Valuer.Key] was read from, or derived from.
+	Source Source `json:"source"`
+
+	// Upstream represents the upstream selected for the [Valuer.Key]. Empty if [Options.Upstreams] isn't specified, or is empty.
+	Upstream string `json:"upstream,omitempty"`
+
+	// Broken reports whether the [Valuer.Upstream] differs from the client's previously selected upstream.
+	Broken bool `json:"broken"`
+}
+
+// Counts represents a snapshot of the [Affinity] middleware's cumulative counts.
+type Counts struct {
+	// Assigned represents the cumulative number of newly assigned affinity keys.
+	Assigned uint64 `json:"assigned"`
+
+	// Sticky represents the cumulative number of requests carrying a valid affinity cookie.
+	Sticky uint64 `json:"sticky"`
+
+	// Breaks represents the cumulative number of requests whose selected upstream differs from the client's previous upstream.
+	Breaks uint64 `json:"breaks"`
+
+	// Rebalances represents the cumulative number of observed [Options.Upstreams] changes.
+	Rebalances uint64 `json:"rebalances"`
+}
+
+// Options represents the configuration settings for the [Affinity] middleware component.
+type Options struct {
+	// Cookie represents the affinity cookie's name. An empty value disables the cookie, deriving every request's key. Defaults to
+	// "__affinity".
+	Cookie string
+
+	// Duration represents the affinity cookie's lifetime. Defaults to 24 hours.
+	Duration time.Duration
+
+	// Principal optionally returns the request's authenticated principal (e.g. a user identifier), from which the key of requests
+	// without a valid cookie is derived. Defaults to nil.
+	Principal func(r *http.Request) string
+
+	// Client returns the request's client address, from which the key of requests without a valid cookie, or principal, is derived.
+	// Defaults to a function returning the host component of [http.Request.RemoteAddr].
+	Client func(r *http.Request) string
+
+	// Upstreams optionally returns the current upstreams (e.g. "10.0.0.1:8080"), among which each key's upstream is selected.
+	// Defaults to nil.
+	Upstreams func() []string
+}
+
+// Affinity represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Affinity struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex       sync.Mutex
+	counts      Counts
+	fingerprint string // fingerprint represents the last observed upstreams' fingerprint.
+}
+
+// Settings applies configuration functions to modify the [Affinity] middleware's [Options] and returns the updated middleware instance.
+func (a *Affinity) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if a.options == nil {
+		a.options = &Options{
+			Cookie:    "__affinity",
+			Duration:  24 * time.Hour,
+			Principal: nil,
+			Client: func(r *http.Request) string {
+				if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+					return v
+				}
+
+				return r.RemoteAddr
+			},
+			Upstreams: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(a.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if a.options.Duration <= 0 {
+		slog.Warn("Invalid Affinity Duration Specified - Using Default Duration")
+
+		a.options.Duration = 24 * time.Hour
+	}
+
+	if a.options.Client == nil {
+		slog.Warn("Invalid Affinity Client Specified - Using Default Client")
+
+		a.options.Client = func(r *http.Request) string {
+			if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+				return v
+			}
+
+			return r.RemoteAddr
+		}
+	}
+
+	return a
+}
+
+// digest returns the value's truncated, hexadecimal SHA-256 digest.
+func digest(value string, length int) string {
+	hash := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(hash[:])[:length]
+}
+
+// valid reports whether the value is a well-formed affinity key.
+func valid(value string) bool {
+	if len(value) != 32 {
+		return false
+	}
+
+	_, e := hex.DecodeString(value)
+
+	return e == nil
+}
+
+// Select returns the upstream selected for the affinity key by rendezvous (highest random weight) hashing, or an empty string if
+// there are no upstreams. Selections are stable while the upstreams are; upon changes, only the keys of removed upstreams, and
+// the keys ranking an added upstream highest, move.
+func Select(k string, upstreams []string) (selection string) {
+	var weight uint64
+	for _, upstream := range upstreams {
+		hash := sha256.Sum256([]byte(k + "\x00" + upstream))
+		if v := binary.BigEndian.Uint64(hash[:8]); selection == "" || v > weight {
+			selection, weight = upstream, v
+		}
+	}
+
+	return
+}
+
+// observe records the upstreams' fingerprint, counting changes. The upstreams' order is insignificant.
+func (a *Affinity) observe(upstreams []string) {
+	sorted := append([]string(nil), upstreams...)
+
+	sort.Strings(sorted)
+
+	fingerprint := digest(strings.Join(sorted, "\x00"), 16)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.fingerprint != "" && a.fingerprint != fingerprint {
+		a.counts.Rebalances++
+	}
+
+	a.fingerprint = fingerprint
+}
+
+// Counts returns a snapshot of the middleware's cumulative counts - e.g. for a health, or metrics, endpoint.
+func (a *Affinity) Counts() Counts {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.counts
+}
+
+// Handler assigns each request a stable affinity key - its cookie's, or one derived from its principal, or client address - and
+// selects its upstream among the [Options.Upstreams], storing both in the request context. The cookie records the key and the
+// selected upstream's digest, such that upstream changes are counted as affinity breaks.
+func (a *Affinity) Handler(next http.Handler) http.Handler {
+	a.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{}
+
+		var previous string // previous represents the digest of the client's previously selected upstream.
+		if a.options.Cookie != "" {
+			if cookie, e := r.Cookie(a.options.Cookie); e == nil {
+				if k, upstream, _ := strings.Cut(cookie.Value, "."); valid(k) {
+					valuer.Key, valuer.Source, previous = k, Cookie, upstream
+				}
+			}
+		}
+
+		if valuer.Key == "" {
+			if a.options.Principal != nil {
+				if v := a.options.Principal(r); v != "" {
+					valuer.Key, valuer.Source = digest("principal\x00"+v, 32), Principal
+				}
+			}
+
+			if valuer.Key == "" {
+				valuer.Key, valuer.Source = digest("address\x00"+a.options.Client(r), 32), Address
+			}
+		}
+
+		if a.options.Upstreams != nil {
+			upstreams := a.options.Upstreams()
+
+			a.observe(upstreams)
+
+			valuer.Upstream = Select(valuer.Key, upstreams)
+		}
+
+		current := ""
+		if valuer.Upstream != "" {
+			current = digest(valuer.Upstream, 8)
+		}
+
+		valuer.Broken = previous != "" && current != "" && previous != current
+
+		a.mutex.Lock()
+		if valuer.Source == Cookie {
+			a.counts.Sticky++
+		} else {
+			a.counts.Assigned++
+		}
+
+		if valuer.Broken {
+			a.counts.Breaks++
+		}
+		a.mutex.Unlock()
+
+		if valuer.Broken {
+			slog.DebugContext(ctx, "Affinity Break", slog.String("key", valuer.Key), slog.String("upstream", valuer.Upstream))
+		}
+
+		if a.options.Cookie != "" && (valuer.Source != Cookie || previous != current) {
+			value := valuer.Key
+			if current != "" {
+				value += "." + current
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     a.options.Cookie,
+				Value:    value,
+				Path:     "/",
+				MaxAge:   int(a.options.Duration.Seconds()),
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Affinity] middleware, implementing [middleware.Configurable]. If [Affinity.Settings] isn't called,
+// then the [Affinity.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to call [Affinity.Counts].
+func New() *Affinity {
+	return new(Affinity)
+}
+
+// Value retrieves the request's affinity [Valuer] from the provided context. If a nil value is returned, it can be assumed that
+// the [Affinity] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Affinity] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Affinity)(nil)
@@ -0,0 +1,171 @@
+package affinity_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/affinity"
+)
+
+func Test(t *testing.T) {
+	var captured *affinity.Valuer
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = affinity.Value(r.Context())
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(m http.Handler, address, cookie string) (*affinity.Valuer, *http.Cookie) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = address
+
+		if cookie != "" {
+			request.AddCookie(&http.Cookie{Name: "__affinity", Value: cookie})
+		}
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		for _, c := range recorder.Result().Cookies() {
+			if c.Name == "__affinity" {
+				return captured, c
+			}
+		}
+
+		return captured, nil
+	}
+
+	t.Run("Address", func(t *testing.T) {
+		m := affinity.New().Handler(handler)
+
+		first, cookie := serve(m, "192.0.2.1:1234", "")
+		second, _ := serve(m, "192.0.2.1:5678", "")
+		third, _ := serve(m, "192.0.2.2:1234", "")
+
+		if first.Source != affinity.Address || first.Key != second.Key || first.Key == third.Key {
+			t.Errorf("Keys = %s, %s, %s\n    - Expectation = %s", first.Key, second.Key, third.Key, "a stable key per client address")
+		}
+
+		if cookie == nil || cookie.Value != first.Key || !(cookie.HttpOnly) {
+			t.Errorf("Cookie = %v\n    - Expectation = %s", cookie, first.Key)
+		}
+	})
+
+	t.Run("Principal", func(t *testing.T) {
+		m := affinity.New().Settings(func(o *affinity.Options) {
+			o.Principal = func(r *http.Request) string { return "user-1" }
+		}).Handler(handler)
+
+		first, _ := serve(m, "192.0.2.1:1234", "")
+		second, _ := serve(m, "192.0.2.2:1234", "")
+
+		if first.Source != affinity.Principal || first.Key != second.Key {
+			t.Errorf("Keys = %s, %s\n    - Expectation = %s", first.Key, second.Key, "a stable key per principal")
+		}
+	})
+
+	t.Run("Cookie", func(t *testing.T) {
+		instance := affinity.New()
+
+		m := instance.Handler(handler)
+
+		const k = "0123456789abcdef0123456789abcdef"
+
+		v, cookie := serve(m, "192.0.2.1:1234", k)
+		if v.Source != affinity.Cookie || v.Key != k || cookie != nil {
+			t.Errorf("Valuer = %+v, Cookie = %v\n    - Expectation = %s", v, cookie, "the cookie's key, without a new cookie")
+		}
+
+		if v, _ = serve(m, "192.0.2.1:1234", "invalid"); v.Source != affinity.Address {
+			t.Errorf("Source = %s\n    - Expectation = %s", v.Source, affinity.Address)
+		}
+
+		if counts := instance.Counts(); counts.Sticky != 1 || counts.Assigned != 1 {
+			t.Errorf("Counts = %+v\n    - Expectation = %s", counts, "a sticky, and an assigned request")
+		}
+	})
+
+	t.Run("Upstreams", func(t *testing.T) {
+		upstreams := []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.0.3:8080"}
+
+		instance := affinity.New().Settings(func(o *affinity.Options) {
+			o.Upstreams = func() []string { return upstreams }
+		}).(*affinity.Affinity)
+
+		m := instance.Handler(handler)
+
+		v, cookie := serve(m, "192.0.2.1:1234", "")
+		if v.Upstream == "" || v.Broken {
+			t.Fatalf("Valuer = %+v\n    - Expectation = %s", v, "a selected upstream")
+		}
+
+		selected := v.Upstream
+
+		// The selection is stable across requests.
+		if v, _ = serve(m, "192.0.2.1:1234", cookie.Value); v.Upstream != selected || v.Broken {
+			t.Errorf("Valuer = %+v\n    - Expectation = %s", v, selected)
+		}
+
+		// Removing the selected upstream breaks the client's affinity.
+		remaining := make([]string, 0, len(upstreams))
+		for _, upstream := range upstreams {
+			if upstream != selected {
+				remaining = append(remaining, upstream)
+			}
+		}
+
+		upstreams = remaining
+
+		v, updated := serve(m, "192.0.2.1:1234", cookie.Value)
+		if v.Upstream == selected || v.Upstream == "" || !(v.Broken) {
+			t.Errorf("Valuer = %+v\n    - Expectation = %s", v, "a broken affinity, and another upstream")
+		}
+
+		if updated == nil || !(strings.HasPrefix(updated.Value, v.Key+".")) || updated.Value == cookie.Value {
+			t.Errorf("Cookie = %v\n    - Expectation = %s", updated, "an updated cookie")
+		}
+
+		if counts := instance.Counts(); counts.Breaks != 1 || counts.Rebalances != 1 {
+			t.Errorf("Counts = %+v\n    - Expectation = %s", counts, "a single break, and rebalance")
+		}
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		upstreams := []string{"a", "b", "c", "d"}
+
+		if v := affinity.Select("key", nil); v != "" {
+			t.Errorf("Select = %s\n    - Expectation = %s", v, "an empty string")
+		}
+
+		// Adding an upstream moves only the keys now selecting it.
+		moved := 0
+		for index := 0; index < 1000; index++ {
+			k := fmt.Sprintf("key-%d", index)
+
+			before, after := affinity.Select(k, upstreams), affinity.Select(k, append(upstreams, "e"))
+			if before != after {
+				if after != "e" {
+					t.Fatalf("Select = %s\n    - Expectation = %s", after, "e, or unchanged")
+				}
+
+				moved++
+			}
+		}
+
+		if moved == 0 || moved > 400 {
+			t.Errorf("Moved = %d\n    - Expectation = %s", moved, "approximately a fifth of the keys")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := affinity.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
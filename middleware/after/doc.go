@@ -0,0 +1,6 @@
+// Package after provides middleware for deferring work - such as auditing, metering, or event emission - until a request's
+// response was written, moving it off the request's latency path.
+//
+// Functions registered through [Defer] are executed by a bounded worker pool with the request's context values, detached from
+// its cancellation. Retain the [After] instance returned by [New], and call [After.Close] on shutdown to drain queued functions.
+package after
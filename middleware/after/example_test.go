@@ -0,0 +1,64 @@
+package after_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/after"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	instance := after.New()
+
+	middleware.Add(instance.Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		after.Defer(r.Context(), func(ctx context.Context) error {
+			fmt.Println("Emitted Order Event")
+
+			return nil
+		})
+
+		w.WriteHeader(http.StatusCreated)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodPost, server.URL+"/orders", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	// Drain deferred functions, e.g. on shutdown.
+	if e := instance.Close(context.Background()); e != nil {
+		panic(e)
+	}
+
+	fmt.Println("Status:", response.StatusCode)
+
+	// Output:
+	// Emitted Order Event
+	// Status: 201
+}
@@ -0,0 +1,278 @@
+package after
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Defer] can the context's value be derived.
+const key keyer = "after"
+
+// Options represents the configuration settings for the [After] middleware component.
+type Options struct {
+	// Workers represents the number of goroutines executing deferred functions. Defaults to 4.
+	Workers int
+
+	// Queue represents the capacity of the deferred functions' queue. When the queue is full, functions are executed on the
+	// request's goroutine - after its response was flushed - rather than dropped. Defaults to 1024.
+	Queue int
+
+	// Timeout bounds the context provided to each deferred function. A zero value applies no deadline. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// Level specifies the log level used to record deferred functions' errors and panics. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+}
+
+// task represents a deferred function and its detached request context.
+type task struct {
+	ctx context.Context
+	fn  func(ctx context.Context) error
+}
+
+// After represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+//
+// Unlike most middleware, callers should retain the [After] instance returned by [New] to call [After.Close] on shutdown.
+type After struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex  sync.RWMutex
+	tasks  chan task
+	closed bool
+
+	start sync.Once
+	group sync.WaitGroup
+}
+
+// Settings applies configuration functions to modify the [After] middleware's [Options] and returns the updated middleware instance.
+func (x *After) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Workers: 4,
+			Queue:   1024,
+			Timeout: 30 * time.Second,
+			Level:   slog.LevelWarn,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Workers <= 0 {
+		slog.Warn("Invalid After Workers Specified - Using Default Workers")
+
+		x.options.Workers = 4
+	}
+
+	if x.options.Queue < 0 {
+		slog.Warn("Invalid After Queue Specified - Using Default Queue")
+
+		x.options.Queue = 1024
+	}
+
+	if x.options.Timeout < 0 {
+		slog.Warn("Invalid After Timeout Specified - Using Default Timeout")
+
+		x.options.Timeout = 30 * time.Second
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	return x
+}
+
+// execute runs a deferred function, recovering and logging any panic.
+func (x *After) execute(t task) {
+	ctx := t.ctx
+	if x.options.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, x.options.Timeout)
+
+		defer cancel()
+	}
+
+	defer func() {
+		if value := recover(); value != nil {
+			slog.Log(ctx, x.options.Level.Level(), "Recovered Deferred Function Panic", slog.String("panic", fmt.Sprint(value)))
+		}
+	}()
+
+	if e := t.fn(ctx); e != nil {
+		slog.Log(ctx, x.options.Level.Level(), "Deferred Function Failure", slog.String("error", e.Error()))
+	}
+}
+
+// initialize lazily starts the pool's workers.
+func (x *After) initialize() {
+	x.start.Do(func() {
+		x.tasks = make(chan task, x.options.Queue)
+
+		for range x.options.Workers {
+			x.group.Add(1)
+
+			go func() {
+				defer x.group.Done()
+
+				for t := range x.tasks {
+					x.execute(t)
+				}
+			}()
+		}
+	})
+}
+
+// submit queues a deferred function, executing it on the caller's goroutine if the queue is full or the pool was closed.
+func (x *After) submit(t task) {
+	x.mutex.RLock()
+
+	if !(x.closed) {
+		select {
+		case x.tasks <- t:
+			x.mutex.RUnlock()
+
+			return
+		default:
+		}
+	}
+
+	x.mutex.RUnlock()
+
+	x.execute(t)
+}
+
+// Close stops accepting deferred functions and waits until queued functions complete, or the context is done. Functions
+// deferred after Close are executed on the request's goroutine.
+func (x *After) Close(ctx context.Context) error {
+	x.Settings() // Ensure the options field isn't nil.
+	x.initialize()
+
+	x.mutex.Lock()
+
+	if !(x.closed) {
+		x.closed = true
+
+		close(x.tasks)
+	}
+
+	x.mutex.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		x.group.Wait()
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// registry represents a request's deferred functions, pending the response's completion.
+type registry struct {
+	mutex    sync.Mutex
+	tasks    []task
+	released bool
+
+	after *After
+}
+
+// release submits the request's deferred functions, in registration order.
+func (r *registry) release() {
+	r.mutex.Lock()
+
+	tasks := r.tasks
+
+	r.tasks, r.released = nil, true
+
+	r.mutex.Unlock()
+
+	for _, t := range tasks {
+		r.after.submit(t)
+	}
+}
+
+// Handler initializes a deferral registry in the request context. Once the next handler returns, the response is flushed, and
+// functions registered through [Defer] are submitted to the [After] instance's worker pool.
+func (x *After) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+	x.initialize()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		reg := &registry{after: x}
+
+		defer reg.release()
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, reg)))
+
+		// Flush any buffered response prior to the deferred functions contending for resources.
+		http.NewResponseController(w).Flush()
+	})
+}
+
+// Defer registers fn to be executed once the request's response was written - off the request's latency path - and reports
+// whether the [After] middleware is enabled for the particular caller's chain. If it isn't, fn isn't registered, and callers
+// should perform the work themselves. Deferred functions receive the request's context values, detached from its
+// cancellation.
+func Defer(ctx context.Context, fn func(ctx context.Context) error) bool {
+	reg, ok := ctx.Value(key).(*registry)
+	if !(ok) {
+		return false
+	}
+
+	if fn == nil {
+		return true
+	}
+
+	t := task{ctx: context.WithoutCancel(ctx), fn: fn}
+
+	reg.mutex.Lock()
+
+	if reg.released {
+		reg.mutex.Unlock()
+
+		reg.after.submit(t)
+
+		return true
+	}
+
+	reg.tasks = append(reg.tasks, t)
+
+	reg.mutex.Unlock()
+
+	return true
+}
+
+// New creates a new instance of the [After] middleware, implementing [middleware.Configurable]. If [After.Settings] isn't called,
+// then the [After.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() *After {
+	return new(After)
+}
+
+// Runtime assurance that [After] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*After)(nil)
@@ -0,0 +1,155 @@
+package after_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/after"
+)
+
+// contextual represents a testing context key.
+type contextual string
+
+func Test(t *testing.T) {
+	t.Run("Deferred", func(t *testing.T) {
+		instance := after.New()
+
+		instance.Settings(func(o *after.Options) { o.Workers = 2 })
+
+		var mutex sync.Mutex
+		var calls int
+		var values []interface{}
+		var errs []error
+
+		// responded is closed once the handler returns, such that deferred functions can verify they weren't executed earlier.
+		responded := make(chan struct{})
+
+		handler := instance.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for range 3 {
+				after.Defer(r.Context(), func(ctx context.Context) error {
+					<-responded
+
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					calls++
+					values = append(values, ctx.Value(contextual("tenant")))
+					errs = append(errs, ctx.Err())
+
+					return nil
+				})
+			}
+
+			after.Defer(r.Context(), func(ctx context.Context) error { return errors.New("failure") })
+			after.Defer(r.Context(), func(ctx context.Context) error { panic("unexpected state") })
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextual("tenant"), "example"))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+		cancel()
+		close(responded)
+
+		if recorder.Code != http.StatusAccepted {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusAccepted)
+		}
+
+		if !(recorder.Flushed) {
+			t.Errorf("Response Wasn't Flushed")
+		}
+
+		deadline, stop := context.WithTimeout(context.Background(), time.Second)
+		defer stop()
+
+		if e := instance.Close(deadline); e != nil {
+			t.Fatalf("Unexpected Error While Closing: %v", e)
+		}
+
+		if calls != 3 {
+			t.Errorf("Calls = %d\n    - Expectation = %d", calls, 3)
+		}
+
+		for index := range values {
+			if values[index] != "example" {
+				t.Errorf("Context Value = %v\n    - Expectation = %s", values[index], "example")
+			}
+
+			if errs[index] != nil {
+				t.Errorf("Context Error = %v\n    - Expectation = <nil>", errs[index])
+			}
+		}
+	})
+
+	t.Run("Saturated", func(t *testing.T) {
+		instance := after.New()
+
+		instance.Settings(func(o *after.Options) { o.Workers = 1; o.Queue = 1 })
+
+		block := make(chan struct{})
+		started := make(chan struct{})
+
+		var inline bool
+
+		handler := instance.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/block":
+				after.Defer(r.Context(), func(ctx context.Context) error { close(started); <-block; return nil })
+			case "/queue":
+				after.Defer(r.Context(), func(ctx context.Context) error { return nil })
+			default:
+				after.Defer(r.Context(), func(ctx context.Context) error { inline = true; return nil })
+			}
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/block", nil))
+
+		<-started
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/queue", nil))
+
+		// The single worker is busy, and the queue is full; the function executes on the request's goroutine.
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !(inline) {
+			t.Errorf("Deferred Function Wasn't Executed Inline for a Saturated Pool")
+		}
+
+		close(block)
+
+		instance.Close(context.Background())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		instance := after.New()
+
+		var called bool
+
+		handler := instance.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			after.Defer(r.Context(), func(ctx context.Context) error { called = true; return nil })
+		}))
+
+		instance.Close(context.Background())
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !(called) {
+			t.Errorf("Deferred Function Wasn't Executed Following Close")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if after.Defer(context.Background(), func(ctx context.Context) error { return nil }) {
+			t.Errorf("Unexpected Deferral Without Middleware")
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package authentication
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// edgekey is the package's unexported context key for verified [EdgeClaims]. Only through the use of [EdgeValue] can the context's
+// value be derived.
+const edgekey keyer = "authentication-edge"
+
+var (
+	// ErrEdgeMalformed is returned for edge tokens that aren't of the "<payload>.<signature>" form.
+	ErrEdgeMalformed = errors.New("malformed edge token")
+
+	// ErrEdgeSignature is returned for edge tokens whose signature doesn't match the [Edge.Secret].
+	ErrEdgeSignature = errors.New("invalid edge token signature")
+
+	// ErrEdgeExpired is returned for expired edge tokens.
+	ErrEdgeExpired = errors.New("expired edge token")
+
+	// ErrEdgeSecret is returned for an [Edge] whose [Edge.Secret] is shorter than 32 bytes - e.g. a zero-value [Edge] - as its
+	// tokens would be trivially forgeable.
+	ErrEdgeSecret = errors.New("edge secret must be at least 32 bytes")
+)
+
+// EdgeClaims represents an edge token's payload: the principal's cacheable attributes. The payload is signed, but not encrypted,
+// such that edge layers can read it without the [Edge.Secret]; it must therefore never convey sensitive attributes.
+type EdgeClaims struct {
+	// Role represents the principal's role.
+	Role string `json:"role,omitempty"`
+
+	// Tenant represents the principal's tenant.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Expiration represents the token's expiration time, in seconds since the Unix epoch.
+	Expiration int64 `json:"exp"`
+}
+
+// Edge represents a short-lived, signed "edge token" cookie summarizing the authenticated principal's cacheable attributes (see
+// [EdgeClaims]), such that CDN and edge layers can vary cached responses without validating the principal's JWT. The cookie's value
+// is the base64url-encoded JSON claims, a ".", and the base64url-encoded HMAC-SHA256 signature of the encoded claims.
+//
+// Once configured as [Options.Edge], the [Authentication] middleware issues the cookie to verified principals, and renews it as it
+// nears expiration. [Edge.Handler] verifies inbound edge tokens alone - a soft authentication, for cacheable routes whose responses
+// only vary by the token's attributes. An Edge is safe for concurrent use.
+type Edge struct {
+	// Secret represents the HMAC-SHA256 signing key, which must be at least 32 bytes; edge layers verifying the token's signature
+	// require the same key.
+	Secret []byte
+
+	// Cookie represents the cookie's name. Defaults to "edge-token".
+	Cookie string
+
+	// TTL represents the token's lifetime. Defaults to five minutes.
+	TTL time.Duration
+
+	// Claims derives the principal's [EdgeClaims] from its verified JWT. Defaults to a function reading the "role", and "tenant",
+	// string claims.
+	Claims func(token *jwt.Token) EdgeClaims
+
+	// Domain represents the cookie's Domain attribute. Defaults to an empty string - a host-only cookie.
+	Domain string
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// cookie returns the cookie's name.
+func (x *Edge) cookie() string {
+	if x.Cookie != "" {
+		return x.Cookie
+	}
+
+	return "edge-token"
+}
+
+// ttl returns the token's lifetime.
+func (x *Edge) ttl() time.Duration {
+	if x.TTL > 0 {
+		return x.TTL
+	}
+
+	return time.Minute * 5
+}
+
+// now returns the current time.
+func (x *Edge) now() time.Time {
+	if x.Clock != nil {
+		return x.Clock()
+	}
+
+	return time.Now()
+}
+
+// claims derives the principal's [EdgeClaims], excluding the expiration.
+func (x *Edge) claims(token *jwt.Token) EdgeClaims {
+	if x.Claims != nil {
+		return x.Claims(token)
+	}
+
+	var claims EdgeClaims
+	if mapping, ok := token.Claims.(jwt.MapClaims); ok {
+		claims.Role, _ = mapping["role"].(string)
+		claims.Tenant, _ = mapping["tenant"].(string)
+	}
+
+	return claims
+}
+
+// signature returns the encoded payload's signature.
+func (x *Edge) signature(payload string) []byte {
+	mac := hmac.New(sha256.New, x.Secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
+
+// Sign returns the claims' edge token, or [ErrEdgeSecret].
+func (x *Edge) Sign(claims EdgeClaims) (string, error) {
+	if len(x.Secret) < 32 {
+		return "", ErrEdgeSecret
+	}
+
+	serialized, e := json.Marshal(claims)
+	if e != nil {
+		return "", e
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(serialized)
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(x.signature(payload)), nil
+}
+
+// Verify returns the edge token's [EdgeClaims], or [ErrEdgeSecret], [ErrEdgeMalformed], [ErrEdgeSignature], or [ErrEdgeExpired].
+func (x *Edge) Verify(token string) (*EdgeClaims, error) {
+	if len(x.Secret) < 32 {
+		return nil, ErrEdgeSecret
+	}
+
+	payload, encoded, found := strings.Cut(token, ".")
+	if !(found) {
+		return nil, ErrEdgeMalformed
+	}
+
+	signature, e := base64.RawURLEncoding.DecodeString(encoded)
+	if e != nil {
+		return nil, ErrEdgeMalformed
+	}
+
+	if !(hmac.Equal(signature, x.signature(payload))) {
+		return nil, ErrEdgeSignature
+	}
+
+	serialized, e := base64.RawURLEncoding.DecodeString(payload)
+	if e != nil {
+		return nil, ErrEdgeMalformed
+	}
+
+	var claims EdgeClaims
+	if e := json.Unmarshal(serialized, &claims); e != nil {
+		return nil, ErrEdgeMalformed
+	}
+
+	if !(x.now().Before(time.Unix(claims.Expiration, 0))) {
+		return nil, ErrEdgeExpired
+	}
+
+	return &claims, nil
+}
+
+// inbound returns the request's verified edge token claims, if any.
+func (x *Edge) inbound(r *http.Request) (*EdgeClaims, error) {
+	cookie, e := r.Cookie(x.cookie())
+	if e != nil || cookie.Value == "" {
+		return nil, http.ErrNoCookie
+	}
+
+	return x.Verify(cookie.Value)
+}
+
+// issue sets the verified principal's edge token cookie, unless the request's edge token conveys the same attributes, and isn't
+// nearing expiration.
+func (x *Edge) issue(w http.ResponseWriter, r *http.Request, token *jwt.Token) {
+	claims := x.claims(token)
+
+	now := x.now()
+
+	if current, e := x.inbound(r); e == nil && current.Role == claims.Role && current.Tenant == claims.Tenant {
+		if time.Unix(current.Expiration, 0).Sub(now) > x.ttl()/2 {
+			return
+		}
+	}
+
+	expiration := now.Add(x.ttl())
+
+	claims.Expiration = expiration.Unix()
+
+	value, e := x.Sign(claims)
+	if e != nil {
+		slog.ErrorContext(r.Context(), "Unable to Sign Edge Token", slog.String("error", e.Error()))
+
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     x.cookie(),
+		Value:    value,
+		Path:     "/",
+		Domain:   x.Domain,
+		Expires:  expiration,
+		MaxAge:   int(x.ttl() / time.Second),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Handler verifies the request's edge token cookie alone - a soft authentication, for cacheable routes whose responses only vary by
+// the token's [EdgeClaims]. Verified claims are stored in the request context (see [EdgeValue]); requests without a valid edge token
+// receive a [http.StatusUnauthorized] response, prompting the client to authenticate with the full [Authentication] middleware.
+//
+// Handler panics with [ErrEdgeSecret] if the [Edge.Secret] is shorter than 32 bytes.
+func (x *Edge) Handler(next http.Handler) http.Handler {
+	if len(x.Secret) < 32 {
+		panic(ErrEdgeSecret)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, e := x.inbound(r)
+		switch {
+		case errors.Is(e, http.ErrNoCookie):
+			deny(w, r, http.StatusUnauthorized, "Edge Token Not Found", "edge-token-missing")
+			return
+		case errors.Is(e, ErrEdgeExpired):
+			deny(w, r, http.StatusUnauthorized, "Expired Edge Token", "edge-token-expired")
+			return
+		case e != nil:
+			slog.WarnContext(r.Context(), "Invalid Edge Token", slog.String("error", e.Error()))
+			deny(w, r, http.StatusUnauthorized, "Invalid Edge Token", "edge-token-invalid")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), edgekey, claims)))
+	})
+}
+
+// EdgeValue retrieves the request's verified [EdgeClaims], stored by [Edge.Handler]. If a nil value is returned, it can be assumed
+// that the [Edge.Handler] middleware isn't enabled for the particular caller's chain.
+func EdgeValue(ctx context.Context) (value *EdgeClaims) {
+	if v, ok := ctx.Value(edgekey).(*EdgeClaims); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Edge Claims Not Found", slog.String("key", string(edgekey)))
+	}
+
+	return
+}
@@ -0,0 +1,86 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Extractor retrieves a request's token. The ok return value is false if the request doesn't convey a token in the
+// [Extractor]'s location, in which case the next [Options.Extractors] entry is consulted.
+type Extractor = func(r *http.Request) (token string, ok bool)
+
+// Cookie returns an [Extractor] retrieving the token from the named cookie.
+func Cookie(name string) Extractor {
+	return func(r *http.Request) (string, bool) {
+		cookie, e := r.Cookie(name)
+		if e != nil || cookie.Value == "" {
+			return "", false
+		}
+
+		return cookie.Value, true
+	}
+}
+
+// Bearer returns an [Extractor] retrieving the token from the named header's "Bearer" credentials (e.g. "Authorization: Bearer
+// <token>"). The scheme is matched case-insensitively; header values of any other form are ignored.
+func Bearer(header string) Extractor {
+	return func(r *http.Request) (string, bool) {
+		scheme, token, found := strings.Cut(r.Header.Get(header), " ")
+		if !(found) || !(strings.EqualFold(scheme, "Bearer")) || token == "" || strings.Contains(token, " ") {
+			return "", false
+		}
+
+		return token, true
+	}
+}
+
+// Header returns an [Extractor] retrieving the token from the named header's entire value (e.g. "X-API-Token: <token>").
+func Header(name string) Extractor {
+	return func(r *http.Request) (string, bool) {
+		token := strings.TrimSpace(r.Header.Get(name))
+
+		return token, token != ""
+	}
+}
+
+// Query returns an [Extractor] retrieving the token from the named URL query parameter. Query parameters are commonly logged
+// by proxies and servers, so the [Extractor] should be reserved for clients unable to set header(s) - namely browser-initiated
+// websocket upgrades.
+func Query(parameter string) Extractor {
+	return func(r *http.Request) (string, bool) {
+		token := r.URL.Query().Get(parameter)
+
+		return token, token != ""
+	}
+}
+
+// Proxy returns an [Extractor] retrieving the token from the first non-empty header injected by an authenticating reverse
+// proxy. If no headers are provided, the following defaults are consulted:
+//
+//   - "X-Forwarded-Access-Token" (oauth2-proxy)
+//   - "X-Auth-Request-Access-Token" (oauth2-proxy, as an auth request)
+//   - "X-Amzn-Oidc-Accesstoken" (AWS Application Load Balancer)
+//
+// Proxy-injected headers are trivially spoofed: the [Extractor] must only be used behind a proxy that strips client-provided
+// instances of the header(s).
+func Proxy(headers ...string) Extractor {
+	if len(headers) == 0 {
+		headers = []string{"X-Forwarded-Access-Token", "X-Auth-Request-Access-Token", "X-Amzn-Oidc-Accesstoken"}
+	}
+
+	return func(r *http.Request) (string, bool) {
+		for _, header := range headers {
+			if token := strings.TrimSpace(r.Header.Get(header)); token != "" {
+				return token, true
+			}
+		}
+
+		return "", false
+	}
+}
+
+// Extractors returns the default [Options.Extractors]: the "token" cookie, followed by the "Authorization" and
+// "X-Testing-Authorization" headers' "Bearer" credentials.
+func Extractors() []Extractor {
+	return []Extractor{Cookie("token"), Bearer("Authorization"), Bearer("X-Testing-Authorization")}
+}
@@ -0,0 +1,51 @@
+package authentication_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+)
+
+func FuzzHandler(f *testing.F) {
+	f.Add("Bearer eyJhbGciOiJIUzI1NiJ9.e30.signature", "")
+	f.Add("Bearer", "")
+	f.Add("Basic dXNlcjpwYXNz", "")
+	f.Add("", "token-cookie-value")
+	f.Add("Bearer  two  spaces", "")
+
+	f.Fuzz(func(t *testing.T, authorization string, cookie string) {
+		handler := authentication.New().Settings(func(o *authentication.Options) {
+			o.Verification = func(ctx context.Context, token string) (*jwt.Token, error) {
+				return jwt.ParseWithClaims(token, jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
+					return []byte("fuzzing-secret"), nil
+				})
+			}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+
+		if cookie != "" {
+			request.Header.Set("Cookie", "token="+cookie)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		switch recorder.Code {
+		case http.StatusOK, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		default:
+			t.Errorf("Unexpected Status Code: %d", recorder.Code)
+		}
+	})
+}
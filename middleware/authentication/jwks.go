@@ -0,0 +1,315 @@
+package authentication
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrKeyNotFound is returned for tokens whose "kid" header doesn't match any of the [JWKS] key set's keys.
+var ErrKeyNotFound = errors.New("signing key not found in key set")
+
+// JWKS represents a remote JSON Web Key Set (RFC 7517), cached in memory. Fresh keys are fetched on first use; stale keys continue
+// to verify tokens while being refreshed in the background, and a token signed by an unknown key (e.g. following a key rotation)
+// triggers an immediate refresh, at most once per [JWKS.Cooldown].
+//
+// RSA, ECDSA (P-256, P-384, and P-521), and Ed25519 signing keys are supported. A JWKS is safe for concurrent use.
+type JWKS struct {
+	// URL represents the key set's location (e.g. "https://issuer.example.com/.well-known/jwks.json").
+	URL string
+
+	// Client represents the HTTP client used to fetch the key set. Defaults to a client with a 10 second timeout.
+	Client *http.Client
+
+	// TTL represents the duration for which fetched keys are considered fresh. Defaults to one hour.
+	TTL time.Duration
+
+	// Cooldown represents the minimum duration between refreshes triggered by unknown keys, preventing tokens with arbitrary
+	// "kid" headers from flooding the key set's host. Defaults to 30 seconds.
+	Cooldown time.Duration
+
+	mutex      sync.RWMutex
+	keys       map[string]interface{}
+	fetched    time.Time
+	attempted  time.Time
+	refreshing bool
+
+	// fetch serializes synchronous refreshes.
+	fetch sync.Mutex
+}
+
+// client returns the key set's HTTP client.
+func (j *JWKS) client() *http.Client {
+	if j.Client != nil {
+		return j.Client
+	}
+
+	return &http.Client{Timeout: time.Second * 10}
+}
+
+// ttl returns the key set's freshness duration.
+func (j *JWKS) ttl() time.Duration {
+	if j.TTL > 0 {
+		return j.TTL
+	}
+
+	return time.Hour
+}
+
+// cooldown returns the minimum duration between unknown-key refreshes.
+func (j *JWKS) cooldown() time.Duration {
+	if j.Cooldown > 0 {
+		return j.Cooldown
+	}
+
+	return time.Second * 30
+}
+
+// Refresh fetches the key set, replacing the cached keys upon success.
+func (j *JWKS) Refresh(ctx context.Context) error {
+	j.mutex.Lock()
+	j.attempted = time.Now()
+	j.mutex.Unlock()
+
+	request, e := http.NewRequestWithContext(ctx, http.MethodGet, j.URL, nil)
+	if e != nil {
+		return e
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, e := j.client().Do(request)
+	if e != nil {
+		return e
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected key set response status: %d", response.StatusCode)
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if e := json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(&set); e != nil {
+		return fmt.Errorf("unable to decode key set: %w", e)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, raw := range set.Keys {
+		kid, key, e := parse(raw)
+		if e != nil {
+			slog.WarnContext(ctx, "Skipping Unsupported JSON Web Key", slog.String("kid", kid), slog.String("error", e.Error()))
+
+			continue
+		}
+
+		if key != nil {
+			keys[kid] = key
+		}
+	}
+
+	j.mutex.Lock()
+	j.keys = keys
+	j.fetched = time.Now()
+	j.mutex.Unlock()
+
+	return nil
+}
+
+// lookup returns the cached key identified by kid. A token without a "kid" header matches a key set's sole key.
+func (j *JWKS) lookup(kid string) (key interface{}, found bool, stale bool, attempted time.Time) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	key, found = j.keys[kid]
+	if !(found) && kid == "" && len(j.keys) == 1 {
+		for _, v := range j.keys {
+			key, found = v, true
+		}
+	}
+
+	return key, found, j.fetched.IsZero() || time.Since(j.fetched) > j.ttl(), j.attempted
+}
+
+// background refreshes stale keys, unless a refresh is already underway.
+func (j *JWKS) background(ctx context.Context) {
+	j.mutex.Lock()
+	if j.refreshing {
+		j.mutex.Unlock()
+
+		return
+	}
+
+	j.refreshing = true
+	j.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			j.mutex.Lock()
+			j.refreshing = false
+			j.mutex.Unlock()
+		}()
+
+		if e := j.Refresh(context.WithoutCancel(ctx)); e != nil {
+			slog.WarnContext(ctx, "Unable to Refresh JSON Web Key Set", slog.String("url", j.URL), slog.String("error", e.Error()))
+		}
+	}()
+}
+
+// Keyfunc returns a [jwt.Keyfunc] resolving a token's verification key from the key set by its "kid" header.
+func (j *JWKS) Keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key, found, stale, attempted := j.lookup(kid)
+		if found {
+			if stale {
+				j.background(ctx)
+			}
+
+			return key, nil
+		}
+
+		// The key is unknown - either the key set was never fetched, or the signing key was rotated.
+		j.fetch.Lock()
+		defer j.fetch.Unlock()
+
+		// Another request may have refreshed the key set while awaiting the lock.
+		if key, found, _, latest := j.lookup(kid); found {
+			return key, nil
+		} else if latest.After(attempted) || (!(attempted.IsZero()) && time.Since(attempted) < j.cooldown()) {
+			return nil, ErrKeyNotFound
+		}
+
+		if e := j.Refresh(ctx); e != nil {
+			return nil, fmt.Errorf("unable to fetch key set: %w", e)
+		}
+
+		if key, found, _, _ := j.lookup(kid); found {
+			return key, nil
+		}
+
+		return nil, ErrKeyNotFound
+	}
+}
+
+// decode decodes a JSON Web Key's base64url-encoded (unpadded) big-endian integer.
+func decode(value string) (*big.Int, error) {
+	buffer, e := base64.RawURLEncoding.DecodeString(value)
+	if e != nil {
+		return nil, e
+	} else if len(buffer) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+
+	return new(big.Int).SetBytes(buffer), nil
+}
+
+// parse parses a JSON Web Key into its kid and public key. Keys intended for encryption ("use": "enc") return a nil key.
+func parse(raw json.RawMessage) (string, interface{}, error) {
+	var jwk struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Use string `json:"use"`
+		Crv string `json:"crv"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+
+	if e := json.Unmarshal(raw, &jwk); e != nil {
+		return "", nil, e
+	}
+
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return jwk.Kid, nil, nil
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, e := decode(jwk.N)
+		if e != nil {
+			return jwk.Kid, nil, fmt.Errorf("invalid rsa modulus: %w", e)
+		}
+
+		exponent, e := decode(jwk.E)
+		if e != nil || !(exponent.IsInt64()) || exponent.Int64() > (1<<31-1) {
+			return jwk.Kid, nil, errors.New("invalid rsa exponent")
+		}
+
+		return jwk.Kid, &rsa.PublicKey{N: n, E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		var validator ecdh.Curve
+
+		switch jwk.Crv {
+		case "P-256":
+			curve, validator = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, validator = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, validator = elliptic.P521(), ecdh.P521()
+		default:
+			return jwk.Kid, nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+		}
+
+		x, e := decode(jwk.X)
+		if e != nil {
+			return jwk.Kid, nil, fmt.Errorf("invalid ec x coordinate: %w", e)
+		}
+
+		y, e := decode(jwk.Y)
+		if e != nil {
+			return jwk.Kid, nil, fmt.Errorf("invalid ec y coordinate: %w", e)
+		}
+
+		// Validate the point is on the curve via its uncompressed encoding.
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x.Bytes()) > size || len(y.Bytes()) > size {
+			return jwk.Kid, nil, errors.New("invalid ec point")
+		}
+
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		x.FillBytes(point[1 : 1+size])
+		y.FillBytes(point[1+size:])
+
+		if _, e := validator.NewPublicKey(point); e != nil {
+			return jwk.Kid, nil, fmt.Errorf("invalid ec point: %w", e)
+		}
+
+		return jwk.Kid, &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return jwk.Kid, nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+		}
+
+		x, e := base64.RawURLEncoding.DecodeString(jwk.X)
+		if e != nil || len(x) != ed25519.PublicKeySize {
+			return jwk.Kid, nil, errors.New("invalid ed25519 public key")
+		}
+
+		return jwk.Kid, ed25519.PublicKey(x), nil
+	}
+
+	return jwk.Kid, nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+}
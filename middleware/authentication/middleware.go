@@ -2,15 +2,20 @@ package authentication
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 	"reflect"
-	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/cache"
+	"github.com/poly-gun/go-middleware/reject"
 )
 
 // keyer is a private string type, unexported to ensure the context, constant key is always unique.
@@ -24,11 +29,55 @@ type Valuer struct {
 	Token *jwt.Token
 }
 
+// MarshalJSON encodes the [Valuer]'s verified token as its "header", "claims", and "valid" fields. The raw token and its
+// signature are omitted, such that a serialized [Valuer] can't be replayed as a credential.
+func (v Valuer) MarshalJSON() ([]byte, error) {
+	type token struct {
+		Header map[string]interface{} `json:"header,omitempty"`
+		Claims jwt.Claims             `json:"claims,omitempty"`
+		Valid  bool                   `json:"valid"`
+	}
+
+	if v.Token == nil {
+		return json.Marshal(token{})
+	}
+
+	return json.Marshal(token{Header: v.Token.Header, Claims: v.Token.Claims, Valid: v.Token.Valid})
+}
+
 // Options represents the configuration settings for the [Authentication] middleware component, including customizable server and header options.
 type Options struct {
 	Verification func(ctx context.Context, token string) (*jwt.Token, error) // Verification is a user-provided jwt-verification function.
 
 	Level slog.Leveler // Level represents a [log/slog] log level - defaults to [slog.LevelDebug] - 4 (trace).
+
+	// JWKS optionally enables built-in token verification against a remote JSON Web Key Set, honoring [Options.Issuer],
+	// [Options.Audience], and [Options.Algorithms]. It's only used if [Options.Verification] is nil. Defaults to nil.
+	JWKS *JWKS
+
+	// Issuer represents the expected "iss" claim of [Options.JWKS]-verified tokens. An empty string skips the issuer check.
+	// Defaults to an empty string.
+	Issuer string
+
+	// Audience represents the expected "aud" claim of [Options.JWKS]-verified tokens. An empty string skips the audience check.
+	// Defaults to an empty string.
+	Audience string
+
+	// Algorithms represents the permitted signing algorithms of [Options.JWKS]-verified tokens. Defaults to "RS256" and "ES256".
+	Algorithms []string
+
+	// Extractors retrieve the request's token, in order of precedence; the first [Extractor] reporting a token wins. See [Cookie],
+	// [Bearer], [Header], [Query], and [Proxy] for built-in extractors. Defaults to [Extractors].
+	Extractors []func(r *http.Request) (string, bool)
+
+	// Cache optionally caches verified tokens, keyed by the token's SHA-256 digest, such that repeated requests bearing the same
+	// token skip verification. Cached tokens expire with their "exp" claim, or after [cache.Cache.TTL] - whichever is sooner;
+	// revoked tokens therefore remain valid for up to the cache's TTL. Register the cache (see [cache.Register]) to expose its
+	// statistics, and to purge it via [cache.Invalidate]. Defaults to nil.
+	Cache *cache.Cache[string, *jwt.Token]
+
+	// Edge optionally issues verified principals a short-lived, signed edge token cookie - see [Edge]. Defaults to nil.
+	Edge *Edge
 }
 
 // Authentication represents a middleware component that applies configurable [Options] settings to HTTP requests. It
@@ -45,6 +94,13 @@ func (a *Authentication) Settings(configuration ...func(o *Options)) middleware.
 		a.options = &Options{
 			Level:        (slog.LevelDebug - 4),
 			Verification: nil,
+			Extractors:   Extractors(),
+			JWKS:         nil,
+			Issuer:       "",
+			Audience:     "",
+			Algorithms:   []string{"RS256", "ES256"},
+			Cache:        nil,
+			Edge:         nil,
 		}
 	}
 
@@ -54,108 +110,173 @@ func (a *Authentication) Settings(configuration ...func(o *Options)) middleware.
 		}
 	}
 
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if len(a.options.Extractors) == 0 {
+		slog.Warn("Invalid Authentication Extractors Specified - Using Default Extractors")
+
+		a.options.Extractors = Extractors()
+	}
+
+	if len(a.options.Algorithms) == 0 {
+		slog.Warn("Invalid Authentication Algorithms Specified - Using Default Algorithms")
+
+		a.options.Algorithms = []string{"RS256", "ES256"}
+	}
+
+	if a.options.Edge != nil && len(a.options.Edge.Secret) < 32 {
+		slog.Warn("Invalid Authentication Edge Secret Specified - Disabling Edge Tokens")
+
+		a.options.Edge = nil
+	}
+
 	return a
 }
 
+// verification returns the [Options.Verification] function, or - if unspecified and [Options.JWKS] is configured - a function
+// verifying tokens against the key set.
+func (a *Authentication) verification() func(ctx context.Context, token string) (*jwt.Token, error) {
+	if a.options.Verification != nil || a.options.JWKS == nil {
+		return a.options.Verification
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(a.options.Algorithms), jwt.WithExpirationRequired()}
+	if a.options.Issuer != "" {
+		options = append(options, jwt.WithIssuer(a.options.Issuer))
+	}
+
+	if a.options.Audience != "" {
+		options = append(options, jwt.WithAudience(a.options.Audience))
+	}
+
+	parser := jwt.NewParser(options...)
+	keys := a.options.JWKS
+
+	return func(ctx context.Context, token string) (*jwt.Token, error) {
+		return parser.Parse(token, keys.Keyfunc(ctx))
+	}
+}
+
+// cached wraps the verification function with [Options.Cache], if configured.
+func (a *Authentication) cached(verification func(ctx context.Context, token string) (*jwt.Token, error)) func(ctx context.Context, token string) (*jwt.Token, error) {
+	store := a.options.Cache
+	if verification == nil || store == nil {
+		return verification
+	}
+
+	return func(ctx context.Context, token string) (*jwt.Token, error) {
+		digest := sha256.Sum256([]byte(token))
+		k := hex.EncodeToString(digest[:])
+
+		if jwttoken, found := store.Get(k); found {
+			return jwttoken, nil
+		}
+
+		jwttoken, e := verification(ctx, token)
+		if e != nil || jwttoken == nil {
+			return jwttoken, e
+		}
+
+		var ttl time.Duration
+		if expiration, e := jwttoken.Claims.GetExpirationTime(); e == nil && expiration != nil {
+			if ttl = time.Until(expiration.Time); ttl <= 0 {
+				return jwttoken, nil
+			}
+		}
+
+		store.SetTTL(k, jwttoken, ttl)
+
+		return jwttoken, nil
+	}
+}
+
+// deny records the request's rejection reason - see [reject.Record] - and writes the rejected response.
+func deny(w http.ResponseWriter, r *http.Request, status int, message, code string) {
+	reject.Record(r.Context(), reject.Reason{Subsystem: "authentication", Code: code, Status: status})
+
+	http.Error(w, message, status)
+}
+
 // Handler applies middleware settings to modify the request context and set response headers. It forwards the request to the next handler in the chain.
 func (a *Authentication) Handler(next http.Handler) http.Handler {
 	a.Settings() // Ensure the options field isn't nil.
 
+	verification := a.cached(a.verification())
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
 		var tokenstring string
+		var found bool
 
-		cookie, e := r.Cookie("token")
-		if e == nil {
-			tokenstring = cookie.Value
-		} else {
-			slog.Log(ctx, a.options.Level.Level(), "Cookie Not Found - Attempting Authorization Authentication")
-
-			authorization := r.Header.Get("Authorization")
-			if authorization == "" {
-				authorization = r.Header.Get("X-Testing-Authorization") // To bypass proxy url header issues
-			}
-
-			if authorization != "" {
-				partials := strings.Split(authorization, " ")
-				slog.Log(ctx, a.options.Level.Level(), "Authorization Header Partial(s)", slog.Any("partials", partials))
-				if len(partials) != 2 || partials[0] != "Bearer" {
-					slog.WarnContext(ctx, "Invalid Authorization Format")
-					http.Error(w, "Invalid Authorization Header Format", http.StatusUnauthorized)
-					return
-				}
-			}
-
-			if authorization == "" && errors.Is(e, http.ErrNoCookie) {
-				slog.WarnContext(ctx, "No Valid Authorization Header or Cookie Found")
-				http.Error(w, "Invalid JWT Token", http.StatusUnauthorized)
-				return
-			} else if authorization == "" {
-				slog.WarnContext(ctx, "No Valid Authorization Header, and Unknown Cookie Error", slog.String("error", e.Error()))
-				http.Error(w, "Invalid JWT Token", http.StatusUnauthorized)
-				return
+		for _, extractor := range a.options.Extractors {
+			if extractor == nil {
+				continue
 			}
 
-			partials := strings.Split(authorization, " ")
-			if len(partials) != 2 || partials[0] != "Bearer" {
-				slog.WarnContext(ctx, "Invalid Authorization Format")
-				http.Error(w, "Invalid Authorization Header Format", http.StatusUnauthorized)
-				return
+			if tokenstring, found = extractor(r); found {
+				break
 			}
+		}
 
-			tokenstring = partials[1]
+		if !(found) {
+			slog.WarnContext(ctx, "No Valid Token Found")
+			deny(w, r, http.StatusUnauthorized, "Invalid JWT Token", "token-missing")
+			return
 		}
 
-		if a.options.Verification != nil {
-			jwttoken, e := a.options.Verification(ctx, tokenstring)
+		if verification != nil {
+			jwttoken, e := verification(ctx, tokenstring)
 			if e != nil {
 				switch {
 				case errors.Is(e, jwt.ErrTokenMalformed):
-					http.Error(w, "Malformed JWT Token", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Malformed JWT Token", "token-malformed")
 					return
 				case errors.Is(e, jwt.ErrTokenSignatureInvalid):
-					http.Error(w, "Invalid JWT Token Signature", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Invalid JWT Token Signature", "signature-invalid")
 					return
 				case errors.Is(e, jwt.ErrTokenExpired):
-					http.Error(w, "Expired JWT Token", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Expired JWT Token", "token-expired")
 					return
 				case errors.Is(e, jwt.ErrTokenNotValidYet):
-					http.Error(w, "JWT Token Not Valid Yet", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "JWT Token Not Valid Yet", "token-not-yet-valid")
 					return
 				case errors.Is(e, jwt.ErrTokenInvalidAudience):
-					http.Error(w, "Invalid Audience Claim", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Invalid Audience Claim", "audience-invalid")
 					return
 				case errors.Is(e, jwt.ErrTokenRequiredClaimMissing):
-					http.Error(w, "Missing Required Claim(s)", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Missing Required Claim(s)", "claim-missing")
 					return
 				case errors.Is(e, jwt.ErrTokenInvalidIssuer):
-					http.Error(w, "Invalid Token Issuer", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Invalid Token Issuer", "issuer-invalid")
 					return
 				case errors.Is(e, jwt.ErrTokenInvalidId):
-					http.Error(w, "Invalid JTI Session ID", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Invalid JTI Session ID", "jti-invalid")
 					return
 				case errors.Is(e, jwt.ErrTokenInvalidSubject):
-					http.Error(w, "Invalid JWT Subject", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Invalid JWT Subject", "subject-invalid")
 					return
 				case errors.Is(e, jwt.ErrTokenUnverifiable):
-					http.Error(w, "Unverifiable JWT Token", http.StatusForbidden)
+					deny(w, r, http.StatusForbidden, "Unverifiable JWT Token", "token-unverifiable")
 					return
 				default:
 					slog.ErrorContext(ctx, "Unhandled JWT Error", slog.String("error", e.Error()), slog.String("error-type", reflect.TypeOf(e).String()))
-					http.Error(w, "Unhandled JWT Exception", http.StatusInternalServerError)
+					deny(w, r, http.StatusInternalServerError, "Unhandled JWT Exception", "verification-error")
 					return
 				}
 			}
 
 			if jwttoken == nil {
 				slog.WarnContext(ctx, "JWT Token Not Found")
-				http.Error(w, "JWT Token Not Found", http.StatusUnauthorized)
+				deny(w, r, http.StatusUnauthorized, "JWT Token Not Found", "token-missing")
 				return
 			}
 
 			slog.Log(ctx, a.options.Level.Level(), "JWT Token Structure", slog.Any("header(s)", jwttoken.Header), slog.Any("claim(s)", jwttoken.Claims))
 
+			if a.options.Edge != nil {
+				a.options.Edge.issue(w, r, jwttoken)
+			}
+
 			ctx = context.WithValue(ctx, key, &Valuer{
 				Token: jwttoken,
 			})
@@ -3,15 +3,26 @@ package authentication_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"github.com/poly-gun/go-middleware/cache"
 	"github.com/poly-gun/go-middleware/middleware/authentication"
 )
 
@@ -94,6 +105,459 @@ func Test(t *testing.T) {
 		})
 	})
 
+	t.Run("Extractors", func(t *testing.T) {
+		signed, e := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user"}).SignedString([]byte("mHTuL3Xko1FKxqxEa3WFrVXyfQEOsfsODyusTDgD9F4"))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Signing Token: %v", e)
+		}
+
+		tests := []struct {
+			name       string
+			extractors []func(r *http.Request) (string, bool)
+			request    func(r *http.Request)
+			status     int
+		}{
+			{
+				name:    "Default-Authorization",
+				request: func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+signed) },
+				status:  http.StatusOK,
+			},
+			{
+				name:    "Default-Cookie",
+				request: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "token", Value: signed}) },
+				status:  http.StatusOK,
+			},
+			{
+				name:    "Default-Case-Insensitive-Scheme",
+				request: func(r *http.Request) { r.Header.Set("Authorization", "bearer "+signed) },
+				status:  http.StatusOK,
+			},
+			{
+				name:    "Default-Invalid-Scheme",
+				request: func(r *http.Request) { r.Header.Set("Authorization", "Basic "+signed) },
+				status:  http.StatusUnauthorized,
+			},
+			{
+				name:       "Query",
+				extractors: []func(r *http.Request) (string, bool){authentication.Query("access_token")},
+				request:    func(r *http.Request) { r.URL.RawQuery = "access_token=" + signed },
+				status:     http.StatusOK,
+			},
+			{
+				name:       "Header",
+				extractors: []func(r *http.Request) (string, bool){authentication.Header("X-API-Token")},
+				request:    func(r *http.Request) { r.Header.Set("X-API-Token", signed) },
+				status:     http.StatusOK,
+			},
+			{
+				name:       "Cookie",
+				extractors: []func(r *http.Request) (string, bool){authentication.Cookie("session")},
+				request:    func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "session", Value: signed}) },
+				status:     http.StatusOK,
+			},
+			{
+				name:       "Proxy",
+				extractors: []func(r *http.Request) (string, bool){authentication.Proxy()},
+				request:    func(r *http.Request) { r.Header.Set("X-Forwarded-Access-Token", signed) },
+				status:     http.StatusOK,
+			},
+			{
+				name:       "Precedence",
+				extractors: []func(r *http.Request) (string, bool){authentication.Header("X-API-Token"), authentication.Query("access_token")},
+				request: func(r *http.Request) {
+					r.Header.Set("X-API-Token", "invalid")
+					r.URL.RawQuery = "access_token=" + signed
+				},
+				status: http.StatusForbidden,
+			},
+			{
+				name:       "Unconfigured-Location",
+				extractors: []func(r *http.Request) (string, bool){authentication.Query("access_token")},
+				request:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+signed) },
+				status:     http.StatusUnauthorized,
+			},
+		}
+
+		for _, matrix := range tests {
+			t.Run(matrix.name, func(t *testing.T) {
+				m := authentication.New().Settings(func(o *authentication.Options) {
+					o.Verification = verify
+					if matrix.extractors != nil {
+						o.Extractors = matrix.extractors
+					}
+				}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				matrix.request(request)
+
+				recorder := httptest.NewRecorder()
+
+				m.ServeHTTP(recorder, request)
+
+				if recorder.Code != matrix.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, matrix.status)
+				}
+			})
+		}
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		rsakey, e := rsa.GenerateKey(rand.Reader, 2048)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating RSA Key: %v", e)
+		}
+
+		rotated, e := rsa.GenerateKey(rand.Reader, 2048)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating RSA Key: %v", e)
+		}
+
+		eckey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating ECDSA Key: %v", e)
+		}
+
+		encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+		jwk := func(kid string, key *rsa.PublicKey) map[string]string {
+			return map[string]string{"kid": kid, "kty": "RSA", "use": "sig", "n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())}
+		}
+
+		var (
+			mutex   sync.Mutex
+			fetches int
+			keys    = []map[string]string{
+				jwk("rsa-1", &rsakey.PublicKey),
+				{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": encode(eckey.X.FillBytes(make([]byte, 32))), "y": encode(eckey.Y.FillBytes(make([]byte, 32)))},
+				{"kid": "unsupported", "kty": "oct", "k": "c2VjcmV0"},
+			}
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			fetches++
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+		}))
+
+		defer server.Close()
+
+		sign := func(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+			token := jwt.NewWithClaims(method, claims)
+			token.Header["kid"] = kid
+
+			signed, e := token.SignedString(key)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Signing Token: %v", e)
+			}
+
+			return signed
+		}
+
+		claims := func(issuer string) jwt.MapClaims {
+			return jwt.MapClaims{"iss": issuer, "aud": "api", "sub": "user", "exp": time.Now().Add(time.Hour).Unix()}
+		}
+
+		jwks := &authentication.JWKS{URL: server.URL, Cooldown: time.Hour}
+
+		m := authentication.New().Settings(func(o *authentication.Options) {
+			o.JWKS = jwks
+			o.Issuer = "https://issuer.example.com"
+			o.Audience = "api"
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Subject", fmt.Sprint(authentication.Value(r.Context()).Token.Claims.(jwt.MapClaims)["sub"]))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		serve := func(token string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			return recorder
+		}
+
+		tests := []struct {
+			name   string
+			token  string
+			status int
+		}{
+			{name: "RS256", token: sign(jwt.SigningMethodRS256, "rsa-1", rsakey, claims("https://issuer.example.com")), status: http.StatusOK},
+			{name: "ES256", token: sign(jwt.SigningMethodES256, "ec-1", eckey, claims("https://issuer.example.com")), status: http.StatusOK},
+			{name: "Invalid-Issuer", token: sign(jwt.SigningMethodRS256, "rsa-1", rsakey, claims("https://attacker.example.com")), status: http.StatusForbidden},
+			{name: "Disallowed-Algorithm", token: sign(jwt.SigningMethodRS512, "rsa-1", rsakey, claims("https://issuer.example.com")), status: http.StatusForbidden},
+			{name: "Unknown-Key", token: sign(jwt.SigningMethodRS256, "unknown", rotated, claims("https://issuer.example.com")), status: http.StatusForbidden},
+		}
+
+		for _, matrix := range tests {
+			t.Run(matrix.name, func(t *testing.T) {
+				if recorder := serve(matrix.token); recorder.Code != matrix.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, matrix.status)
+				}
+			})
+		}
+
+		// The unknown key's refresh attempt is suppressed by the cooldown.
+		mutex.Lock()
+		if fetches != 1 {
+			t.Errorf("Fetches = %d\n    - Expectation = %d", fetches, 1)
+		}
+
+		keys = append(keys, jwk("rsa-2", &rotated.PublicKey))
+		mutex.Unlock()
+
+		t.Run("Rotation", func(t *testing.T) {
+			jwks.Cooldown = time.Nanosecond
+
+			recorder := serve(sign(jwt.SigningMethodRS256, "rsa-2", rotated, claims("https://issuer.example.com")))
+			if recorder.Code != http.StatusOK || recorder.Header().Get("X-Subject") != "user" {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+			}
+		})
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		secret := []byte("mHTuL3Xko1FKxqxEa3WFrVXyfQEOsfsODyusTDgD9F4")
+
+		var calls int
+
+		counting := func(ctx context.Context, token string) (*jwt.Token, error) {
+			calls++
+
+			return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return secret, nil })
+		}
+
+		store := &cache.Cache[string, *jwt.Token]{TTL: time.Minute}
+
+		handler := authentication.New().Settings(func(o *authentication.Options) {
+			o.Verification = counting
+			o.Cache = store
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		valid, e := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user", "exp": time.Now().Add(time.Hour).Unix()}).SignedString(secret)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Signing Token: %v", e)
+		}
+
+		invalid, e := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user"}).SignedString([]byte("invalid"))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Signing Token: %v", e)
+		}
+
+		for _, token := range []string{valid, valid, valid, invalid, invalid} {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			expectation := http.StatusOK
+			if token == invalid {
+				expectation = http.StatusForbidden
+			}
+
+			if recorder.Code != expectation {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, expectation)
+			}
+		}
+
+		if calls != 3 {
+			t.Errorf("Verification Calls = %d\n    - Expectation = %d", calls, 3)
+		}
+
+		if stats := store.Stats(); stats.Hits != 2 || stats.Size != 1 {
+			t.Errorf("Stats = %+v\n    - Expectation = %s", stats, "2 hits, size 1")
+		}
+	})
+
+	t.Run("Edge", func(t *testing.T) {
+		secret := []byte("mHTuL3Xko1FKxqxEa3WFrVXyfQEOsfsODyusTDgD9F4")
+
+		now := time.Unix(1700000000, 0)
+
+		edge := &authentication.Edge{Secret: []byte("edge-secret-edge-secret-edge-secret"), Clock: func() time.Time { return now }}
+
+		handler := authentication.New().Settings(func(o *authentication.Options) {
+			o.Verification = func(ctx context.Context, token string) (*jwt.Token, error) {
+				return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return secret, nil })
+			}
+
+			o.Edge = edge
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		signed, e := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user", "role": "admin", "tenant": "acme"}).SignedString(secret)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Signing Token: %v", e)
+		}
+
+		issue := func(cookies ...*http.Cookie) *http.Cookie {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Authorization", "Bearer "+signed)
+
+			for _, cookie := range cookies {
+				request.AddCookie(cookie)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+			}
+
+			for _, cookie := range recorder.Result().Cookies() {
+				if cookie.Name == "edge-token" {
+					return cookie
+				}
+			}
+
+			return nil
+		}
+
+		cookie := issue()
+		if cookie == nil {
+			t.Fatalf("Edge Token Cookie Not Issued")
+		}
+
+		claims, e := edge.Verify(cookie.Value)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Verifying Edge Token: %v", e)
+		}
+
+		if expectation := (authentication.EdgeClaims{Role: "admin", Tenant: "acme", Expiration: now.Add(time.Minute * 5).Unix()}); *claims != expectation {
+			t.Errorf("Claims = %+v\n    - Expectation = %+v", *claims, expectation)
+		}
+
+		if v := issue(cookie); v != nil {
+			t.Errorf("Fresh Edge Token Reissued = %s", v.Value)
+		}
+
+		now = now.Add(time.Minute * 3)
+
+		if v := issue(cookie); v == nil {
+			t.Errorf("Expiring Edge Token Not Renewed")
+		}
+
+		t.Run("Verification", func(t *testing.T) {
+			payload, _, _ := strings.Cut(cookie.Value, ".")
+
+			forged, _ := (&authentication.Edge{Secret: []byte("forged-secret-forged-secret-forged")}).Sign(authentication.EdgeClaims{Role: "admin", Tenant: "acme", Expiration: now.Add(time.Hour).Unix()})
+
+			_, signature, _ := strings.Cut(forged, ".")
+
+			tampered := payload + "." + signature
+
+			matrix := []struct {
+				name   string
+				token  string
+				status int
+				error  error
+			}{
+				{name: "Valid", token: cookie.Value, status: http.StatusOK},
+				{name: "Malformed", token: "invalid", status: http.StatusUnauthorized, error: authentication.ErrEdgeMalformed},
+				{name: "Tampered", token: tampered, status: http.StatusUnauthorized, error: authentication.ErrEdgeSignature},
+				{name: "Missing", status: http.StatusUnauthorized},
+			}
+
+			soft := edge.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Tenant", authentication.EdgeValue(r.Context()).Tenant)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			for _, matrix := range matrix {
+				t.Run(matrix.name, func(t *testing.T) {
+					if matrix.error != nil {
+						if _, e := edge.Verify(matrix.token); !(errors.Is(e, matrix.error)) {
+							t.Errorf("Verify Error = %v\n    - Expectation = %v", e, matrix.error)
+						}
+					}
+
+					request := httptest.NewRequest(http.MethodGet, "/", nil)
+					if matrix.token != "" {
+						request.AddCookie(&http.Cookie{Name: "edge-token", Value: matrix.token})
+					}
+
+					recorder := httptest.NewRecorder()
+
+					soft.ServeHTTP(recorder, request)
+
+					if recorder.Code != matrix.status {
+						t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, matrix.status)
+					}
+
+					if matrix.status == http.StatusOK && recorder.Header().Get("X-Tenant") != "acme" {
+						t.Errorf("X-Tenant = %s\n    - Expectation = %s", recorder.Header().Get("X-Tenant"), "acme")
+					}
+				})
+			}
+
+			now = now.Add(time.Minute * 5)
+
+			if _, e := edge.Verify(cookie.Value); !(errors.Is(e, authentication.ErrEdgeExpired)) {
+				t.Errorf("Verify Error = %v\n    - Expectation = %v", e, authentication.ErrEdgeExpired)
+			}
+		})
+
+		t.Run("Secret", func(t *testing.T) {
+			for name, weak := range map[string]*authentication.Edge{"Zero-Value": {}, "Short": {Secret: []byte("short")}} {
+				if _, e := weak.Sign(authentication.EdgeClaims{Role: "admin"}); !(errors.Is(e, authentication.ErrEdgeSecret)) {
+					t.Errorf("Sign Error (%s) = %v\n    - Expectation = %v", name, e, authentication.ErrEdgeSecret)
+				}
+
+				if _, e := weak.Verify("e30.AAAA"); !(errors.Is(e, authentication.ErrEdgeSecret)) {
+					t.Errorf("Verify Error (%s) = %v\n    - Expectation = %v", name, e, authentication.ErrEdgeSecret)
+				}
+
+				func() {
+					defer func() {
+						if v := recover(); v != authentication.ErrEdgeSecret {
+							t.Errorf("Handler Panic (%s) = %v\n    - Expectation = %v", name, v, authentication.ErrEdgeSecret)
+						}
+					}()
+
+					weak.Handler(http.NotFoundHandler())
+				}()
+			}
+		})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		valuer := authentication.Valuer{Token: &jwt.Token{
+			Raw:       "header.payload.signature",
+			Header:    map[string]interface{}{"alg": "HS256", "typ": "JWT"},
+			Claims:    jwt.MapClaims{"sub": "user"},
+			Signature: []byte("signature"),
+			Valid:     true,
+		}}
+
+		serialized, e := json.Marshal(valuer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if expectation := `{"header":{"alg":"HS256","typ":"JWT"},"claims":{"sub":"user"},"valid":true}`; string(serialized) != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+		}
+
+		if serialized, _ := json.Marshal(authentication.Valuer{}); string(serialized) != `{"valid":false}` {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, `{"valid":false}`)
+		}
+	})
+
 	t.Run("Context", func(t *testing.T) {
 		t.Run("Default", func(t *testing.T) {
 			t.Parallel()
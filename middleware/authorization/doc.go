@@ -0,0 +1,6 @@
+// Package authorization provides role- and scope-based access control middleware, layered on the authentication middleware.
+//
+// Policies are enforced against the verified token of [authentication.Value]: a request-wide [Options.Policy], or per-route
+// [Options.Routes] policies selected by [Options.Mux] pattern. [Require] composes the common case - e.g.
+// Require("scope:read", "role:admin") - while [Scope], [Role], [Claim], [All], and [Any] compose arbitrary policies.
+package authorization
@@ -0,0 +1,83 @@
+package authorization_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/middleware/authorization"
+)
+
+func Example() {
+	secret := []byte("example-signing-secret")
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /reports", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Reports"))
+	})
+
+	mux.HandleFunc("DELETE /reports/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(authentication.New().Settings(func(o *authentication.Options) {
+		o.Verification = func(ctx context.Context, token string) (*jwt.Token, error) {
+			return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return secret, nil }, jwt.WithValidMethods([]string{"HS256"}))
+		}
+	}).Handler)
+
+	middleware.Add(authorization.New().Settings(func(o *authorization.Options) {
+		o.Policy = authorization.Require("scope:reports:read")
+		o.Mux = mux
+		o.Routes = map[string]authorization.Policy{
+			"DELETE /reports/{id}": authorization.Require("scope:reports:read", "role:admin"),
+		}
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	token, e := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "analyst", "scope": "reports:read"}).SignedString(secret)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while signing token: %w", e)
+
+		panic(e)
+	}
+
+	client := server.Client()
+
+	for _, target := range []struct{ method, path string }{{http.MethodGet, "/reports"}, {http.MethodDelete, "/reports/1"}} {
+		request, e := http.NewRequest(target.method, server.URL+target.path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Println(target.method, target.path, response.StatusCode)
+	}
+
+	// Output:
+	// GET /reports 200
+	// DELETE /reports/1 403
+}
@@ -0,0 +1,164 @@
+package authorization
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "authorization"
+
+// Options represents the configuration settings for the [Authorization] middleware component.
+type Options struct {
+	// Policy represents the policy enforced for requests without an [Options.Routes] policy. A nil value authorizes any
+	// authenticated request. Defaults to nil.
+	Policy Policy
+
+	// Routes maps [Options.Mux] patterns (e.g. "DELETE /users/{id}") to their policy, superseding [Options.Policy]. Defaults to
+	// an empty map.
+	Routes map[string]Policy
+
+	// Mux represents the [http.ServeMux] whose matched pattern selects the request's [Options.Routes] policy. Defaults to nil.
+	Mux *http.ServeMux
+
+	// Status represents the status code of unauthorized requests' responses. Defaults to [http.StatusForbidden].
+	Status int
+
+	// Message represents the unauthorized response's plain-text body. Defaults to [http.StatusText] of [Options.Status].
+	Message string
+
+	// Level specifies the log level used to record denied requests. Defaults to [slog.LevelDebug].
+	Level slog.Leveler
+}
+
+// Authorization represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Authorization struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Authorization] middleware's [Options] and returns the updated middleware instance.
+func (x *Authorization) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Policy:  nil,
+			Routes:  map[string]Policy{},
+			Mux:     nil,
+			Status:  http.StatusForbidden,
+			Message: "",
+			Level:   slog.LevelDebug,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Status < 400 || x.options.Status > 499 {
+		slog.Warn("Invalid Authorization Status Specified - Using Default Status")
+
+		x.options.Status = http.StatusForbidden
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = http.StatusText(x.options.Status)
+	}
+
+	if len(x.options.Routes) > 0 && x.options.Mux == nil {
+		slog.Warn("Authorization Mux Unspecified - Route Policies Disabled")
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelDebug
+	}
+
+	return x
+}
+
+// policy returns the request's policy, and its name: the matched [Options.Routes] pattern, or an empty string for [Options.Policy].
+func (x *Authorization) policy(r *http.Request) (string, Policy) {
+	if x.options.Mux != nil && len(x.options.Routes) > 0 {
+		if _, pattern := x.options.Mux.Handler(r); pattern != "" {
+			if policy, found := x.options.Routes[pattern]; found {
+				return pattern, policy
+			}
+		}
+	}
+
+	return "", x.options.Policy
+}
+
+// Handler enforces the request's policy against the token verified by the authentication middleware, which must precede the
+// [Authorization] middleware in the chain. Requests without a verified token receive a [http.StatusUnauthorized] response;
+// requests whose token doesn't satisfy the policy receive an [Options.Status] response.
+func (x *Authorization) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := authentication.Value(ctx)
+		if valuer == nil || valuer.Token == nil {
+			slog.WarnContext(ctx, "Authorization Requires a Verified Token - Ensure the Authentication Middleware Precedes Authorization")
+
+			reject.Record(ctx, reject.Reason{Subsystem: "authorization", Code: "token-missing", Status: http.StatusUnauthorized})
+
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		pattern, policy := x.policy(r)
+		if policy != nil && !(policy(valuer.Token)) {
+			slog.Log(ctx, x.options.Level.Level(), "Unauthorized Request", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.String("pattern", pattern))
+
+			reject.Record(ctx, reject.Reason{Subsystem: "authorization", Code: "policy-denied", Rule: pattern, Status: x.options.Status})
+
+			http.Error(w, x.options.Message, x.options.Status)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, pattern)))
+	})
+}
+
+// New creates a new instance of the [Authorization] middleware, implementing [middleware.Configurable]. If [Authorization.Settings]
+// isn't called, then the [Authorization.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Authorization)
+}
+
+// Value retrieves the [Options.Routes] pattern whose policy authorized the request from the provided context. An empty string
+// represents the [Options.Policy] default, or that the [Authorization] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (pattern string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		pattern = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		pattern = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Authorization] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Authorization)(nil)
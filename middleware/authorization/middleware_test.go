@@ -0,0 +1,144 @@
+package authorization_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/middleware/authorization"
+)
+
+func Test(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("DELETE /users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name     string
+		settings func(o *authorization.Options)
+		method   string
+		claims   jwt.MapClaims
+		status   int
+		body     string
+		pattern  string
+	}{
+		{name: "Missing-Token", settings: nil, method: http.MethodGet, claims: nil, status: http.StatusUnauthorized, body: "Unauthorized"},
+		{name: "Default-Policy", settings: nil, method: http.MethodGet, claims: jwt.MapClaims{}, status: http.StatusOK},
+		{name: "Scope-Granted", settings: func(o *authorization.Options) { o.Policy = authorization.Require("scope:read") }, method: http.MethodGet, claims: jwt.MapClaims{"scope": "read write"}, status: http.StatusOK},
+		{name: "Scope-Denied", settings: func(o *authorization.Options) { o.Policy = authorization.Require("scope:admin") }, method: http.MethodGet, claims: jwt.MapClaims{"scope": "read write"}, status: http.StatusForbidden, body: "Forbidden"},
+		{name: "Scope-Role-Granted", settings: func(o *authorization.Options) { o.Policy = authorization.Require("scope:users:read", "role:admin") }, method: http.MethodGet, claims: jwt.MapClaims{"scp": []interface{}{"users:read"}, "roles": []interface{}{"admin"}}, status: http.StatusOK},
+		{name: "Role-Denied", settings: func(o *authorization.Options) { o.Policy = authorization.Require("scope:read", "role:admin") }, method: http.MethodGet, claims: jwt.MapClaims{"scope": "read", "roles": []interface{}{"viewer"}}, status: http.StatusForbidden},
+		{name: "Malformed-Requirement", settings: func(o *authorization.Options) { o.Policy = authorization.Require("read") }, method: http.MethodGet, claims: jwt.MapClaims{"scope": "read"}, status: http.StatusForbidden},
+		{
+			name: "Claim-Predicate",
+			settings: func(o *authorization.Options) {
+				o.Policy = authorization.Claim("tenant", func(value interface{}) bool { return value == "acme" })
+			},
+			method: http.MethodGet,
+			claims: jwt.MapClaims{"tenant": "acme"},
+			status: http.StatusOK,
+		},
+		{
+			name: "Any",
+			settings: func(o *authorization.Options) {
+				o.Policy = authorization.Any(authorization.Role("admin"), authorization.Scope("read"))
+			},
+			method: http.MethodGet,
+			claims: jwt.MapClaims{"scope": "read"},
+			status: http.StatusOK,
+		},
+		{
+			name: "Route-Policy-Denied",
+			settings: func(o *authorization.Options) {
+				o.Mux = mux
+				o.Routes = map[string]authorization.Policy{"DELETE /users/{id}": authorization.Require("role:admin")}
+			},
+			method: http.MethodDelete,
+			claims: jwt.MapClaims{"roles": "viewer"},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "Route-Policy-Granted",
+			settings: func(o *authorization.Options) {
+				o.Mux = mux
+				o.Routes = map[string]authorization.Policy{"DELETE /users/{id}": authorization.Require("role:admin")}
+				o.Policy = authorization.Require("scope:write")
+			},
+			method:  http.MethodDelete,
+			claims:  jwt.MapClaims{"role": "admin"},
+			status:  http.StatusOK,
+			pattern: "DELETE /users/{id}",
+		},
+		{
+			name: "Route-Policy-Fallback",
+			settings: func(o *authorization.Options) {
+				o.Mux = mux
+				o.Routes = map[string]authorization.Policy{"DELETE /users/{id}": authorization.Require("role:admin")}
+			},
+			method: http.MethodGet,
+			claims: jwt.MapClaims{"roles": "viewer"},
+			status: http.StatusOK,
+		},
+		{
+			name: "Custom-Response",
+			settings: func(o *authorization.Options) {
+				o.Policy = authorization.Require("role:admin")
+				o.Status = http.StatusNotFound
+				o.Message = "Resource Not Found"
+			},
+			method: http.MethodGet,
+			claims: jwt.MapClaims{},
+			status: http.StatusNotFound,
+			body:   "Resource Not Found",
+		},
+	}
+
+	for _, matrix := range tests {
+		t.Run(matrix.name, func(t *testing.T) {
+			var pattern string
+
+			handler := authorization.New().Settings(matrix.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pattern = authorization.Value(r.Context())
+			}))
+
+			request := httptest.NewRequest(matrix.method, "/users/42", nil)
+			if matrix.claims != nil {
+				valuer := &authentication.Valuer{Token: jwt.NewWithClaims(jwt.SigningMethodHS256, matrix.claims)}
+
+				request = request.WithContext(context.WithValue(request.Context(), "x-testing-key", valuer))
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != matrix.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, matrix.status)
+			}
+
+			if body := strings.TrimSpace(recorder.Body.String()); matrix.body != "" && body != matrix.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", body, matrix.body)
+			}
+
+			if pattern != matrix.pattern {
+				t.Errorf("Pattern = %s\n    - Expectation = %s", pattern, matrix.pattern)
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := authorization.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "GET /users/{id}")
+		if v := authorization.Value(ctx); v != "GET /users/{id}" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package authorization
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Policy reports whether a verified token is authorized. See [Require] for composing policies from scope and role requirements.
+type Policy func(token *jwt.Token) bool
+
+// claims returns the token's claims as a [jwt.MapClaims]. Tokens parsed with other claim types yield nil.
+func claims(token *jwt.Token) jwt.MapClaims {
+	if token == nil {
+		return nil
+	}
+
+	switch typecast := token.Claims.(type) {
+	case jwt.MapClaims:
+		return typecast
+	case *jwt.MapClaims:
+		if typecast != nil {
+			return *typecast
+		}
+	}
+
+	return nil
+}
+
+// values returns a claim's value(s): a space-delimited string's fields, or an array's string elements.
+func values(claim interface{}) []string {
+	switch typecast := claim.(type) {
+	case string:
+		return strings.Fields(typecast)
+	case []string:
+		return typecast
+	case []interface{}:
+		result := make([]string, 0, len(typecast))
+		for _, element := range typecast {
+			if v, ok := element.(string); ok {
+				result = append(result, v)
+			}
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// contains reports whether every expectation is among the union of the token's named claims' values.
+func contains(token *jwt.Token, names []string, expectations []string) bool {
+	mapping := claims(token)
+	if mapping == nil {
+		return false
+	}
+
+	var granted []string
+	for _, name := range names {
+		granted = append(granted, values(mapping[name])...)
+	}
+
+	for _, expectation := range expectations {
+		if !(slices.Contains(granted, expectation)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Scope returns a [Policy] requiring every provided scope among the token's "scope" (space-delimited, RFC 8693) or "scp"
+// claim(s).
+func Scope(scopes ...string) Policy {
+	return func(token *jwt.Token) bool {
+		return contains(token, []string{"scope", "scp"}, scopes)
+	}
+}
+
+// Role returns a [Policy] requiring every provided role among the token's "roles" or "role" claim(s).
+func Role(roles ...string) Policy {
+	return func(token *jwt.Token) bool {
+		return contains(token, []string{"roles", "role"}, roles)
+	}
+}
+
+// Claim returns a [Policy] requiring the token's named claim to satisfy the predicate. Absent claims are passed as nil.
+func Claim(name string, predicate func(value interface{}) bool) Policy {
+	return func(token *jwt.Token) bool {
+		mapping := claims(token)
+		if mapping == nil {
+			return false
+		}
+
+		return predicate(mapping[name])
+	}
+}
+
+// All returns a [Policy] requiring every provided policy. An empty set of policies authorizes every token.
+func All(policies ...Policy) Policy {
+	return func(token *jwt.Token) bool {
+		for _, policy := range policies {
+			if !(policy(token)) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Any returns a [Policy] requiring at least one of the provided policies. An empty set of policies authorizes no token.
+func Any(policies ...Policy) Policy {
+	return func(token *jwt.Token) bool {
+		for _, policy := range policies {
+			if policy(token) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Require returns a [Policy] requiring every provided requirement, each of the form "scope:<scope>" or "role:<role>" (e.g.
+// Require("scope:read", "role:admin")). Scopes containing colons (e.g. "scope:users:read") are supported. A malformed
+// requirement fails closed: the returned [Policy] authorizes no token.
+func Require(requirements ...string) Policy {
+	var scopes, roles []string
+
+	for _, requirement := range requirements {
+		kind, value, found := strings.Cut(requirement, ":")
+		if !(found) || value == "" {
+			slog.Warn("Invalid Authorization Requirement Specified - Denying All Tokens", slog.String("requirement", requirement))
+
+			return func(token *jwt.Token) bool { return false }
+		}
+
+		switch kind {
+		case "scope":
+			scopes = append(scopes, value)
+		case "role":
+			roles = append(roles, value)
+		default:
+			slog.Warn("Invalid Authorization Requirement Specified - Denying All Tokens", slog.String("requirement", requirement))
+
+			return func(token *jwt.Token) bool { return false }
+		}
+	}
+
+	return All(Scope(scopes...), Role(roles...))
+}
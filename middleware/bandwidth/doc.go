@@ -0,0 +1,10 @@
+// Package bandwidth provides middleware throttling the request body's bandwidth for untrusted clients.
+//
+// The request body is wrapped with a token bucket rate limit - reads are delayed once a client exceeds [Options.Rate], which in
+// turn applies TCP backpressure to the client - and with a per-read deadline, such that a client trickling its body (e.g. a
+// slow-body attack) can't hold a handler indefinitely. Trusted clients (by default, loopback and private-network addresses) are
+// exempt.
+//
+// Throttled bytes, delays, and read timeouts are reported per request through [Options.Observe], and aggregated for metrics
+// through [Bandwidth.Counts].
+package bandwidth
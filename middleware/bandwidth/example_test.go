@@ -0,0 +1,63 @@
+package bandwidth_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/bandwidth"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(bandwidth.New().Settings(func(o *bandwidth.Options) {
+		o.Rate = 256 << 10 // 256 KiB per second.
+
+		o.Trusted = func(r *http.Request) bool {
+			return r.Header.Get("X-Internal-Token") == "secret"
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /ingest", func(w http.ResponseWriter, r *http.Request) {
+		size, _ := io.Copy(io.Discard, r.Body)
+
+		fmt.Printf("Received %d Bytes (Throttled: %t)\n", size, bandwidth.Value(r.Context()) != nil)
+
+		w.WriteHeader(http.StatusAccepted)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodPost, server.URL+"/ingest", bytes.NewReader(make([]byte, 1024)))
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Status: %d\n", response.StatusCode)
+
+	// Output:
+	// Received 1024 Bytes (Throttled: true)
+	// Status: 202
+}
@@ -0,0 +1,282 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "bandwidth"
+
+// ErrTimeout is returned by a throttled request body's Read once [Options.Timeout] elapses without the client sending data.
+// Handlers may answer such requests with a [http.StatusRequestTimeout] response.
+var ErrTimeout = errors.New("bandwidth: request body read timeout")
+
+// Stats represents a request body's throttling statistics.
+type Stats struct {
+	// Bytes represents the number of body bytes read.
+	Bytes int64 `json:"bytes"`
+
+	// Throttled represents the number of body bytes whose read was delayed by the rate limit.
+	Throttled int64 `json:"throttled"`
+
+	// Delay represents the cumulative delay imposed by the rate limit.
+	Delay time.Duration `json:"delay"`
+
+	// Timeouts represents the number of reads that exceeded [Options.Timeout].
+	Timeouts int64 `json:"timeouts"`
+}
+
+// Options represents the configuration settings for the [Bandwidth] middleware component.
+type Options struct {
+	// Rate represents the maximum request body bandwidth, in bytes per second, of untrusted clients. Defaults to 1 MiB.
+	Rate int64
+
+	// Burst represents the number of bytes an untrusted client may send at once, exceeding [Options.Rate]. Defaults to [Options.Rate].
+	Burst int64
+
+	// Timeout represents the maximum duration each read of an untrusted client's request body may wait for data. The deadline
+	// supersedes the server's read timeout while the body is read. A value of zero disables the deadline. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// Trusted reports whether the request's client is exempt from throttling. Defaults to a function trusting loopback and
+	// private-network remote addresses.
+	Trusted func(r *http.Request) bool
+
+	// Observe optionally receives every throttled request's [Stats], once its handler returns - e.g. for metrics. Defaults to nil.
+	Observe func(r *http.Request, stats Stats)
+}
+
+// Bandwidth represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Bandwidth struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex  sync.Mutex
+	counts Stats
+}
+
+// trusted is the default [Options.Trusted] function.
+func trusted(r *http.Request) bool {
+	host, _, e := net.SplitHostPort(r.RemoteAddr)
+	if e != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
+
+// Settings applies configuration functions to modify the [Bandwidth] middleware's [Options] and returns the updated middleware instance.
+func (x *Bandwidth) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Rate:    1 << 20,
+			Burst:   0,
+			Timeout: time.Second * 10,
+			Trusted: trusted,
+			Observe: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Rate <= 0 {
+		slog.Warn("Invalid Bandwidth Rate Specified - Using Default Rate")
+
+		x.options.Rate = 1 << 20
+	}
+
+	if x.options.Burst <= 0 {
+		x.options.Burst = x.options.Rate
+	}
+
+	if x.options.Timeout < 0 {
+		slog.Warn("Invalid Bandwidth Timeout Specified - Using Default Timeout")
+
+		x.options.Timeout = time.Second * 10
+	}
+
+	if x.options.Trusted == nil {
+		x.options.Trusted = trusted
+	}
+
+	return x
+}
+
+// Counts returns the aggregated [Stats] of every throttled request served so far.
+func (x *Bandwidth) Counts() Stats {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return x.counts
+}
+
+// reader throttles a request body through a token bucket, and applies a per-read deadline.
+type reader struct {
+	io.ReadCloser
+
+	ctx        context.Context
+	controller *http.ResponseController
+	options    *Options
+
+	tokens float64
+	last   time.Time
+
+	mutex sync.Mutex
+	stats Stats
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.options.Burst {
+		p = p[:r.options.Burst]
+	}
+
+	if r.options.Timeout > 0 && r.controller != nil {
+		if e := r.controller.SetReadDeadline(time.Now().Add(r.options.Timeout)); errors.Is(e, http.ErrNotSupported) {
+			r.controller = nil
+		}
+	}
+
+	n, e := r.ReadCloser.Read(p)
+
+	var timeout net.Error
+	if errors.As(e, &timeout) && timeout.Timeout() {
+		r.mutex.Lock()
+		r.stats.Timeouts++
+		r.mutex.Unlock()
+
+		e = fmt.Errorf("%w: %w", ErrTimeout, e)
+	}
+
+	now := time.Now()
+
+	r.tokens = min(float64(r.options.Burst), r.tokens+now.Sub(r.last).Seconds()*float64(r.options.Rate)) - float64(n)
+	r.last = now
+
+	var delay time.Duration
+	if r.tokens < 0 {
+		delay = time.Duration(-r.tokens / float64(r.options.Rate) * float64(time.Second))
+	}
+
+	r.mutex.Lock()
+	r.stats.Bytes += int64(n)
+	if delay > 0 {
+		r.stats.Throttled += int64(n)
+		r.stats.Delay += delay
+	}
+	r.mutex.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			return n, r.ctx.Err()
+		}
+	}
+
+	return n, e
+}
+
+// snapshot returns the reader's current [Stats].
+func (r *reader) snapshot() Stats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.stats
+}
+
+// Handler wraps untrusted clients' request bodies with the bandwidth rate limit, and the per-read deadline, and stores the body's
+// live [Stats] in the request context. Requests without a body, and trusted clients' requests, are forwarded unchanged.
+func (x *Bandwidth) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody || x.options.Trusted(r) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := r.Context()
+
+		controller := http.NewResponseController(w)
+
+		body := &reader{ReadCloser: r.Body, ctx: ctx, controller: controller, options: x.options, tokens: float64(x.options.Burst), last: time.Now()}
+
+		if x.options.Timeout > 0 {
+			defer controller.SetReadDeadline(time.Time{})
+		}
+
+		r = r.WithContext(context.WithValue(ctx, key, body))
+		r.Body = body
+
+		next.ServeHTTP(w, r)
+
+		stats := body.snapshot()
+
+		x.mutex.Lock()
+		x.counts.Bytes += stats.Bytes
+		x.counts.Throttled += stats.Throttled
+		x.counts.Delay += stats.Delay
+		x.counts.Timeouts += stats.Timeouts
+		x.mutex.Unlock()
+
+		if stats.Timeouts > 0 {
+			slog.InfoContext(ctx, "Request Body Read Timeout", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.String("remote", r.RemoteAddr))
+		}
+
+		if x.options.Observe != nil {
+			x.options.Observe(r, stats)
+		}
+	})
+}
+
+// New creates a new instance of the [Bandwidth] middleware, implementing [middleware.Configurable]. If [Bandwidth.Settings] isn't called,
+// then the [Bandwidth.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers may retain the returned instance to call [Bandwidth.Counts].
+func New() *Bandwidth {
+	return new(Bandwidth)
+}
+
+// Value retrieves the request body's live throttling [Stats] from the provided context. If a nil value is returned, it can be assumed
+// that the request wasn't throttled, or that the [Bandwidth] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Stats) {
+	if v, ok := ctx.Value(key).(*reader); ok {
+		stats := v.snapshot()
+
+		value = &stats
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Bandwidth Statistics Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Bandwidth] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Bandwidth)(nil)
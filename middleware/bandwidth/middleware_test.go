@@ -0,0 +1,124 @@
+package bandwidth_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/bandwidth"
+)
+
+func Test(t *testing.T) {
+	untrusted := func(r *http.Request) bool { return false }
+
+	t.Run("Throttled", func(t *testing.T) {
+		var observed bandwidth.Stats
+
+		instance := bandwidth.New()
+
+		handler := instance.Settings(func(o *bandwidth.Options) {
+			o.Rate = 10000
+			o.Burst = 1000
+			o.Trusted = untrusted
+			o.Observe = func(r *http.Request, stats bandwidth.Stats) { observed = stats }
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+
+			if stats := bandwidth.Value(r.Context()); stats == nil || stats.Bytes != 3000 {
+				t.Errorf("Value = %+v\n    - Expectation = %d Bytes", stats, 3000)
+			}
+		}))
+
+		start := time.Now()
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(make([]byte, 3000))))
+
+		if elapsed := time.Since(start); elapsed < time.Millisecond*150 {
+			t.Errorf("Elapsed = %s\n    - Expectation >= %s", elapsed, time.Millisecond*150)
+		}
+
+		if observed.Bytes != 3000 || observed.Throttled == 0 || observed.Delay == 0 {
+			t.Errorf("Stats = %+v\n    - Expectation = Throttled", observed)
+		}
+
+		if counts := instance.Counts(); counts != observed {
+			t.Errorf("Counts = %+v\n    - Expectation = %+v", counts, observed)
+		}
+	})
+
+	t.Run("Trusted", func(t *testing.T) {
+		handler := bandwidth.New().Settings(func(o *bandwidth.Options) {
+			o.Rate = 1
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+
+			if stats := bandwidth.Value(r.Context()); stats != nil {
+				t.Errorf("Value = %+v\n    - Expectation = nil", stats)
+			}
+		}))
+
+		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(make([]byte, 3000)))
+		request.RemoteAddr = "10.0.0.1:41234"
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+	})
+
+	t.Run("Read-Timeout", func(t *testing.T) {
+		instance := bandwidth.New()
+
+		server := httptest.NewServer(instance.Settings(func(o *bandwidth.Options) {
+			o.Timeout = time.Millisecond * 50
+			o.Trusted = untrusted
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, e := io.ReadAll(r.Body); errors.Is(e, bandwidth.ErrTimeout) {
+				w.Header().Set("Connection", "close")
+				w.WriteHeader(http.StatusRequestTimeout)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		})))
+
+		defer server.Close()
+
+		client, e := net.Dial("tcp", server.Listener.Addr().String())
+		if e != nil {
+			t.Fatalf("Unexpected Error While Dialing: %v", e)
+		}
+
+		defer client.Close()
+
+		client.Write([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nContent-Length: 10\r\n\r\nab"))
+
+		client.SetReadDeadline(time.Now().Add(time.Second * 5))
+
+		response, e := http.ReadResponse(bufio.NewReader(client), nil)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Reading Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusRequestTimeout {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusRequestTimeout)
+		}
+
+		if counts := instance.Counts(); counts.Timeouts != 1 || counts.Bytes != 2 {
+			t.Errorf("Counts = %+v\n    - Expectation = 1 Timeout, 2 Bytes", counts)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := bandwidth.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
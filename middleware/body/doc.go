@@ -0,0 +1,26 @@
+// Package body provides a generic request-decoding middleware, decoding JSON, form, multipart form, and XML request bodies into a
+// caller-provided type - such that handlers needn't hand-roll decoding, and its error responses.
+//
+//	type User struct {
+//		Name  string `json:"name"`
+//		Email string `json:"email"`
+//	}
+//
+//	body.New[User]().Settings(func(o *body.Options[User]) {
+//		o.Required = true
+//		o.Validate = func(ctx context.Context, user *User) error {
+//			if user.Email == "" {
+//				return errors.New("email is required")
+//			}
+//
+//			return nil
+//		}
+//	})
+//
+// Handlers retrieve the decoded value through [Value]:
+//
+//	user := body.Value[User](r.Context())
+//
+// Requests with an unaccepted Content-Type, oversized or malformed bodies, unknown fields, or bodies failing validation receive a
+// JSON error response. Form fields are matched by their "form" tag, falling back to their "json" tag, then their name.
+package body
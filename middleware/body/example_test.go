@@ -0,0 +1,59 @@
+package body_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/body"
+)
+
+func Example() {
+	type Order struct {
+		Item     string `json:"item"`
+		Quantity int    `json:"quantity"`
+	}
+
+	middleware := middleware.New()
+
+	middleware.Add(body.New[Order]().Settings(func(o *body.Options[Order]) {
+		o.Required = true
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		order := body.Value[Order](r.Context())
+
+		fmt.Fprintf(w, "ordered %d %s", order.Quantity, order.Item)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, content := range []string{`{"item": "widget", "quantity": 2}`, `{"item": "widget", "price": 0}`} {
+		response, e := client.Post(server.URL+"/orders", "application/json", strings.NewReader(content))
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		content, _ := io.ReadAll(response.Body)
+
+		response.Body.Close()
+
+		fmt.Println(response.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	// Output:
+	// 200 ordered 2 widget
+	// 400 {"title":"Bad Request","status":400,"detail":"unknown field \"price\""}
+}
@@ -0,0 +1,125 @@
+package body
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownField is returned for bodies containing a field absent from the decoded type, unless [Options.Unknown] permits them.
+var ErrUnknownField = errors.New("unknown field")
+
+// field returns the struct field's form name: its "form" tag, its "json" tag, or its name - and whether it's decodable.
+func field(f reflect.StructField) (string, bool) {
+	if !(f.IsExported()) {
+		return "", false
+	}
+
+	for _, tag := range []string{"form", "json"} {
+		if v, found := f.Tag.Lookup(tag); found {
+			name, _, _ := strings.Cut(v, ",")
+			if name == "-" {
+				return "", false
+			}
+
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+
+	return f.Name, true
+}
+
+// scalar parses the form value into the scalar value v.
+func scalar(v reflect.Value, value string) (e error) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		var b bool
+		if b, e = strconv.ParseBool(value); e == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, e = strconv.ParseInt(value, 10, v.Type().Bits()); e == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, e = strconv.ParseUint(value, 10, v.Type().Bits()); e == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, e = strconv.ParseFloat(value, v.Type().Bits()); e == nil {
+			v.SetFloat(n)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return
+}
+
+// form decodes the form values into the struct pointed to by target. Fields are matched by their "form" tag, falling back to their
+// "json" tag, then their name; slice fields receive every value, and pointer fields are allocated as needed.
+func form(values url.Values, target any, unknown bool) error {
+	v := reflect.ValueOf(target).Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("form bodies require a struct type, not %s", v.Type())
+	}
+
+	fields := make(map[string]reflect.Value)
+	for index := 0; index < v.NumField(); index++ {
+		if name, ok := field(v.Type().Field(index)); ok {
+			fields[name] = v.Field(index)
+		}
+	}
+
+	for name, entries := range values {
+		destination, found := fields[name]
+		if !(found) {
+			if unknown {
+				continue
+			}
+
+			return fmt.Errorf("%w %q", ErrUnknownField, name)
+		}
+
+		if len(entries) == 0 {
+			continue
+		}
+
+		if destination.Kind() == reflect.Pointer {
+			if destination.IsNil() {
+				destination.Set(reflect.New(destination.Type().Elem()))
+			}
+
+			destination = destination.Elem()
+		}
+
+		if destination.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(destination.Type(), len(entries), len(entries))
+			for index, entry := range entries {
+				if e := scalar(slice.Index(index), entry); e != nil {
+					return fmt.Errorf("invalid value for field %q: %w", name, e)
+				}
+			}
+
+			destination.Set(slice)
+
+			continue
+		}
+
+		if e := scalar(destination, entries[0]); e != nil {
+			return fmt.Errorf("invalid value for field %q: %w", name, e)
+		}
+	}
+
+	return nil
+}
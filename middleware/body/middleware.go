@@ -0,0 +1,367 @@
+package body
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "body"
+
+// ErrDoctype is returned for XML documents containing a document type declaration, the vehicle for entity-expansion, and
+// external-entity, attacks.
+var ErrDoctype = errors.New("document type declarations are prohibited")
+
+// format represents a supported body encoding.
+type format int
+
+const (
+	unsupported format = iota
+	jsonBody
+	formBody
+	multipartBody
+	xmlBody
+)
+
+// classify returns the media type's encoding.
+func classify(mediatype string) format {
+	switch {
+	case mediatype == "application/json", strings.HasSuffix(mediatype, "+json"):
+		return jsonBody
+	case mediatype == "application/x-www-form-urlencoded":
+		return formBody
+	case mediatype == "multipart/form-data":
+		return multipartBody
+	case mediatype == "application/xml", mediatype == "text/xml", strings.HasSuffix(mediatype, "+xml"):
+		return xmlBody
+	}
+
+	return unsupported
+}
+
+// Options represents the configuration settings for the [Body] middleware component.
+type Options[T any] struct {
+	// Limit represents the maximum request body size, in bytes. Larger bodies receive a [http.StatusRequestEntityTooLarge] response.
+	// Defaults to 1 MiB.
+	Limit int64
+
+	// Types represents the accepted media types: "application/json", "application/x-www-form-urlencoded", "multipart/form-data",
+	// "application/xml", and "text/xml" are supported. Structured-syntax suffixes ("+json", "+xml") are accepted alongside their
+	// base type. Other requests receive a [http.StatusUnsupportedMediaType] response. Defaults to "application/json".
+	Types []string
+
+	// Unknown reports whether JSON and form fields absent from T are permitted; otherwise, such bodies receive a
+	// [http.StatusBadRequest] response. XML bodies' unknown elements are always ignored. Defaults to false.
+	Unknown bool
+
+	// Required reports whether requests must include a body; otherwise, requests without a body are forwarded without a stored
+	// value. Defaults to false.
+	Required bool
+
+	// Validate represents an optional validation hook, evaluated against the decoded value. A non-nil error results in a
+	// [http.StatusUnprocessableEntity] response. Defaults to nil.
+	Validate func(ctx context.Context, value *T) error
+}
+
+// Body represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Body[T any] struct {
+	middleware.Configurable[Options[T]]
+
+	options *Options[T]
+
+	accepted map[string]format // accepted maps the [Options.Types] to their encoding.
+}
+
+// Settings applies configuration functions to modify the [Body] middleware's [Options] and returns the updated middleware instance.
+func (x *Body[T]) Settings(configuration ...func(o *Options[T])) middleware.Configurable[Options[T]] {
+	if x.options == nil {
+		x.options = &Options[T]{
+			Limit:    1 << 20,
+			Types:    []string{"application/json"},
+			Unknown:  false,
+			Required: false,
+			Validate: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Body Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1 << 20
+	}
+
+	x.accepted = make(map[string]format)
+	for _, v := range x.options.Types {
+		mediatype := strings.ToLower(strings.TrimSpace(v))
+		if f := classify(mediatype); f != unsupported {
+			x.accepted[mediatype] = f
+		} else {
+			slog.Warn("Invalid Body Type Specified - Ignoring Type", slog.String("type", v))
+		}
+	}
+
+	if len(x.accepted) == 0 {
+		slog.Warn("Invalid Body Types Specified - Using Default Types")
+
+		x.accepted["application/json"] = jsonBody
+	}
+
+	return x
+}
+
+// negotiate returns the request's accepted encoding, and its media type parameters.
+func (x *Body[T]) negotiate(r *http.Request) (format, map[string]string) {
+	mediatype, parameters, e := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if e != nil {
+		return unsupported, nil
+	}
+
+	if f, found := x.accepted[mediatype]; found {
+		return f, parameters
+	}
+
+	// Structured-syntax suffixes are accepted alongside their base type.
+	for suffix, base := range map[string]string{"+json": "application/json", "+xml": "application/xml"} {
+		if _, found := x.accepted[base]; found && strings.HasSuffix(mediatype, suffix) {
+			return classify(mediatype), parameters
+		}
+	}
+
+	return unsupported, nil
+}
+
+// doctype reports whether the XML document contains a document type declaration.
+func doctype(data []byte) (bool, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+
+	for {
+		token, e := d.RawToken()
+		if errors.Is(e, io.EOF) {
+			return false, nil
+		} else if e != nil {
+			return false, e
+		}
+
+		if v, ok := token.(xml.Directive); ok {
+			if directive := strings.TrimSpace(string(v)); len(directive) >= 7 && strings.EqualFold(directive[:7], "DOCTYPE") {
+				return true, nil
+			}
+		}
+	}
+}
+
+// failure represents a decoding failure's response status, and rejection code.
+type failure struct {
+	status int
+	code   string
+	e      error
+}
+
+func (f *failure) Error() string {
+	return f.e.Error()
+}
+
+func (f *failure) Unwrap() error {
+	return f.e
+}
+
+// decode decodes the body's data, according to its encoding, into a T.
+func (x *Body[T]) decode(ctx context.Context, f format, parameters map[string]string, data []byte) (*T, error) {
+	value := new(T)
+
+	malformed := func(e error) error {
+		if errors.Is(e, ErrUnknownField) {
+			return &failure{status: http.StatusBadRequest, code: "unknown-field", e: e}
+		}
+
+		return &failure{status: http.StatusBadRequest, code: "malformed-body", e: e}
+	}
+
+	switch f {
+	case jsonBody:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if !(x.options.Unknown) {
+			decoder.DisallowUnknownFields()
+		}
+
+		if e := decoder.Decode(value); e != nil {
+			if name, found := strings.CutPrefix(e.Error(), "json: unknown field "); found {
+				e = fmt.Errorf("%w %s", ErrUnknownField, name)
+			}
+
+			return nil, malformed(e)
+		}
+
+		if decoder.More() {
+			return nil, malformed(errors.New("unexpected data after top-level value"))
+		}
+	case formBody:
+		values, e := url.ParseQuery(string(data))
+		if e != nil {
+			return nil, malformed(e)
+		}
+
+		if e := form(values, value, x.options.Unknown); e != nil {
+			return nil, malformed(e)
+		}
+	case multipartBody:
+		reader := multipart.NewReader(bytes.NewReader(data), parameters["boundary"])
+
+		fields, e := reader.ReadForm(x.options.Limit)
+		if e != nil {
+			return nil, malformed(e)
+		}
+
+		defer fields.RemoveAll()
+
+		if e := form(fields.Value, value, x.options.Unknown); e != nil {
+			return nil, malformed(e)
+		}
+	case xmlBody:
+		if prohibited, e := doctype(data); e != nil || prohibited {
+			if e == nil {
+				e = ErrDoctype
+			}
+
+			return nil, malformed(e)
+		}
+
+		decoder := xml.NewDecoder(bytes.NewReader(data))
+		decoder.Strict = true
+
+		if e := decoder.Decode(value); e != nil {
+			return nil, malformed(e)
+		}
+	}
+
+	if x.options.Validate != nil {
+		if e := x.options.Validate(ctx, value); e != nil {
+			return nil, &failure{status: http.StatusUnprocessableEntity, code: "validation-failed", e: e}
+		}
+	}
+
+	return value, nil
+}
+
+// respond records the request's rejection, and writes its JSON error response.
+func respond(w http.ResponseWriter, r *http.Request, f *failure) {
+	reject.Record(r.Context(), reject.Reason{Subsystem: "body", Code: f.code, Status: f.status})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	w.WriteHeader(f.status)
+
+	json.NewEncoder(w).Encode(struct {
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}{Title: http.StatusText(f.status), Status: f.status, Detail: f.Error()})
+}
+
+// Handler decodes the request's body - according to its Content-Type, and the accepted [Options.Types] - into a T, and stores the
+// decoded value in the request context; see [Value]. Bodies that can't be decoded, or fail [Options.Validate], receive a JSON error
+// response. The request's body remains readable by downstream handlers.
+func (x *Body[T]) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+			if x.options.Required {
+				respond(w, r, &failure{status: http.StatusBadRequest, code: "body-required", e: errors.New("request body is required")})
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		f, parameters := x.negotiate(r)
+		if f == unsupported {
+			respond(w, r, &failure{status: http.StatusUnsupportedMediaType, code: "unsupported-media-type", e: fmt.Errorf("unsupported content type %q", r.Header.Get("Content-Type"))})
+
+			return
+		}
+
+		data, e := io.ReadAll(http.MaxBytesReader(w, r.Body, x.options.Limit))
+		if e != nil {
+			status, code := http.StatusBadRequest, "malformed-body"
+			if v := new(http.MaxBytesError); errors.As(e, &v) {
+				status, code = http.StatusRequestEntityTooLarge, "body-too-large"
+			}
+
+			respond(w, r, &failure{status: status, code: code, e: e})
+
+			return
+		}
+
+		value, e := x.decode(ctx, f, parameters, data)
+		if e != nil {
+			slog.DebugContext(ctx, "Unable to Decode Request Body", slog.String("error", e.Error()))
+
+			var v *failure
+			errors.As(e, &v)
+
+			respond(w, r, v)
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, value)))
+	})
+}
+
+// New creates a new instance of the [Body] middleware, decoding request bodies into a T, implementing [middleware.Configurable].
+// If [Body.Settings] isn't called, then the [Body.Handler] function will hydrate the middleware's configuration with sane
+// default(s) if applicable.
+func New[T any]() middleware.Configurable[Options[T]] {
+	return new(Body[T])
+}
+
+// Value retrieves the request's decoded body from the provided context. The type parameter must match the [New] function's. If a
+// nil value is returned, it can be assumed that the [Body] middleware isn't enabled for the particular caller's chain, or that the
+// request didn't include a body.
+func Value[T any](ctx context.Context) (value *T) {
+	if v, ok := ctx.Value(key).(*T); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Body Value Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Body] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options[struct{}]] = (*Body[struct{}])(nil)
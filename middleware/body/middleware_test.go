@@ -0,0 +1,146 @@
+package body_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/body"
+)
+
+type User struct {
+	Name   string   `json:"name" xml:"name"`
+	Age    int      `json:"age" form:"age" xml:"age"`
+	Admin  *bool    `json:"admin,omitempty"`
+	Tags   []string `json:"tags" form:"tag"`
+	Ignore string   `json:"-"`
+}
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+
+		if v := body.Value[User](r.Context()); v != nil {
+			admin := v.Admin != nil && *v.Admin
+
+			w.Header().Set("X-User", v.Name+":"+strconv.Itoa(v.Age)+":"+strconv.FormatBool(admin)+":"+strings.Join(v.Tags, ","))
+		}
+
+		w.Header().Set("X-Body-Length", strconv.Itoa(len(content)))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	multipartbody := func() (string, string) {
+		var buffer bytes.Buffer
+
+		writer := multipart.NewWriter(&buffer)
+		writer.WriteField("name", "Jane")
+		writer.WriteField("tag", "a")
+		writer.WriteField("tag", "b")
+		writer.Close()
+
+		return writer.FormDataContentType(), buffer.String()
+	}
+
+	multiparttype, multipartcontent := multipartbody()
+
+	all := func(o *body.Options[User]) {
+		o.Types = []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data", "application/xml"}
+	}
+
+	tests := []struct {
+		name    string
+		options func(o *body.Options[User])
+		content string
+		body    string
+		status  int
+		user    string
+	}{
+		{name: "JSON", content: "application/json", body: `{"name": "Jane", "age": 30, "admin": true, "tags": ["a"]}`, status: http.StatusOK, user: "Jane:30:true:a"},
+		{name: "JSON-Suffix", content: "application/vnd.user+json; charset=utf-8", body: `{"name": "Jane"}`, status: http.StatusOK, user: "Jane:0:false:"},
+		{name: "JSON-Unknown-Field", content: "application/json", body: `{"name": "Jane", "role": "admin"}`, status: http.StatusBadRequest},
+		{name: "JSON-Unknown-Permitted", options: func(o *body.Options[User]) { o.Unknown = true }, content: "application/json", body: `{"name": "Jane", "role": "admin"}`, status: http.StatusOK, user: "Jane:0:false:"},
+		{name: "JSON-Malformed", content: "application/json", body: `{"name": `, status: http.StatusBadRequest},
+		{name: "JSON-Trailing", content: "application/json", body: `{} {}`, status: http.StatusBadRequest},
+		{name: "JSON-Type", content: "application/json", body: `{"age": "thirty"}`, status: http.StatusBadRequest},
+		{name: "Unsupported-Media-Type", content: "application/x-www-form-urlencoded", body: `name=Jane`, status: http.StatusUnsupportedMediaType},
+		{name: "Form", options: all, content: "application/x-www-form-urlencoded", body: `name=Jane&age=30&admin=true&tag=a&tag=b`, status: http.StatusOK, user: "Jane:30:true:a,b"},
+		{name: "Form-Unknown-Field", options: all, content: "application/x-www-form-urlencoded", body: `name=Jane&role=admin`, status: http.StatusBadRequest},
+		{name: "Form-Ignored-Field", options: all, content: "application/x-www-form-urlencoded", body: `Ignore=value`, status: http.StatusBadRequest},
+		{name: "Form-Type", options: all, content: "application/x-www-form-urlencoded", body: `age=thirty`, status: http.StatusBadRequest},
+		{name: "Multipart", options: all, content: multiparttype, body: multipartcontent, status: http.StatusOK, user: "Jane:0:false:a,b"},
+		{name: "XML-Unaccepted", options: all, content: "text/xml", body: `<user><name>Jane</name><age>30</age></user>`, status: http.StatusUnsupportedMediaType},
+		{name: "XML-Suffix", options: all, content: "application/soap+xml", body: `<user><name>Jane</name><age>30</age></user>`, status: http.StatusOK, user: "Jane:30:false:"},
+		{name: "XML-Doctype", options: all, content: "application/xml", body: `<!DOCTYPE user [<!ENTITY xxe SYSTEM "file:///etc/passwd">]><user><name>&xxe;</name></user>`, status: http.StatusBadRequest},
+		{name: "Empty", content: "application/json", status: http.StatusOK, user: ""},
+		{name: "Required", options: func(o *body.Options[User]) { o.Required = true }, content: "application/json", status: http.StatusBadRequest},
+		{name: "Limit", options: func(o *body.Options[User]) { o.Limit = 8 }, content: "application/json", body: `{"name": "Jane"}`, status: http.StatusRequestEntityTooLarge},
+		{name: "Validation", options: func(o *body.Options[User]) {
+			o.Validate = func(ctx context.Context, user *User) error {
+				if user.Age < 18 {
+					return errors.New("user must be an adult")
+				}
+
+				return nil
+			}
+		}, content: "application/json", body: `{"name": "Jane", "age": 12}`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := body.New[User]().Settings(tt.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.content)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d (%s)", recorder.Code, tt.status, recorder.Body.String())
+			}
+
+			if v := recorder.Header().Get("X-User"); v != tt.user {
+				t.Errorf("X-User = %s\n    - Expectation = %s", v, tt.user)
+			}
+
+			if tt.status != http.StatusOK {
+				var problem struct {
+					Title  string `json:"title"`
+					Status int    `json:"status"`
+					Detail string `json:"detail"`
+				}
+
+				if e := json.NewDecoder(recorder.Body).Decode(&problem); e != nil {
+					t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+				}
+
+				if problem.Status != tt.status || problem.Detail == "" {
+					t.Errorf("Problem = %+v\n    - Expectation = %s", problem, "a status, and detail")
+				}
+
+				return
+			}
+
+			if v := recorder.Header().Get("X-Body-Length"); v != strconv.Itoa(len(tt.body)) {
+				t.Errorf("X-Body-Length = %s\n    - Expectation = %d", v, len(tt.body))
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := body.Value[User](context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
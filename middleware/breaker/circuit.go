@@ -0,0 +1,150 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State represents a circuit breaker's state.
+type State string
+
+const (
+	// Closed represents a healthy breaker, admitting every request.
+	Closed State = "closed"
+
+	// Open represents a tripped breaker, rejecting every request until its cooldown elapses.
+	Open State = "open"
+
+	// HalfOpen represents a recovering breaker, admitting a limited number of probe requests.
+	HalfOpen State = "half-open"
+)
+
+// buckets represents the number of buckets a breaker's rolling window is divided into.
+const buckets = 10
+
+// bucket represents a slice of a breaker's rolling window.
+type bucket struct {
+	epoch    int64
+	requests int
+	failures int
+}
+
+// circuit represents a single breaker's state machine.
+type circuit struct {
+	mutex sync.Mutex
+
+	state   State
+	buckets [buckets]bucket
+	opened  time.Time
+
+	probes    int // probes represents the number of in-flight half-open probes.
+	successes int // successes represents the number of successful half-open probes.
+}
+
+// transition represents a breaker's change of state.
+type transition struct {
+	from, to State
+}
+
+// epoch returns the rolling window's bucket epoch of the time.
+func epoch(now time.Time, options *Options) int64 {
+	return now.UnixNano() / int64(options.Window/buckets)
+}
+
+// admit reports whether a request may proceed, and the breaker's state at its admission.
+func (c *circuit) admit(now time.Time, options *Options) (State, bool, *transition) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var change *transition
+
+	if c.state == Open {
+		if now.Sub(c.opened) < options.Cooldown {
+			return Open, false, nil
+		}
+
+		change = &transition{from: Open, to: HalfOpen}
+
+		c.state, c.probes, c.successes = HalfOpen, 0, 0
+	}
+
+	if c.state == HalfOpen {
+		if c.probes >= options.Probes {
+			return HalfOpen, false, change
+		}
+
+		c.probes++
+
+		return HalfOpen, true, change
+	}
+
+	return Closed, true, change
+}
+
+// record records the outcome of a request admitted in the given state.
+func (c *circuit) record(state State, failed bool, now time.Time, options *Options) *transition {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if state == HalfOpen {
+		c.probes--
+
+		if c.state != HalfOpen {
+			return nil
+		}
+
+		if failed {
+			c.state, c.opened = Open, now
+
+			return &transition{from: HalfOpen, to: Open}
+		}
+
+		if c.successes++; c.successes >= options.Probes {
+			c.state, c.buckets = Closed, [buckets]bucket{}
+
+			return &transition{from: HalfOpen, to: Closed}
+		}
+
+		return nil
+	}
+
+	current := epoch(now, options)
+
+	b := &c.buckets[current%buckets]
+	if b.epoch != current {
+		*b = bucket{epoch: current}
+	}
+
+	b.requests++
+	if failed {
+		b.failures++
+	}
+
+	if c.state != Closed || !(failed) {
+		return nil
+	}
+
+	var requests, failures int
+	for _, b := range c.buckets {
+		if b.epoch > current-buckets {
+			requests += b.requests
+			failures += b.failures
+		}
+	}
+
+	if requests >= options.Minimum && float64(failures)/float64(requests) >= options.Threshold {
+		c.state, c.opened = Open, now
+
+		return &transition{from: Closed, to: Open}
+	}
+
+	return nil
+}
+
+// remaining returns the duration until an open breaker's cooldown elapses.
+func (c *circuit) remaining(now time.Time, options *Options) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return options.Cooldown - now.Sub(c.opened)
+}
@@ -0,0 +1,14 @@
+// Package breaker provides circuit breaker middleware, shielding failing upstreams - and their callers - from further load.
+//
+// Each route has its own breaker: routes are derived from the [route] middleware, which should precede the [Breaker] middleware in
+// the chain. A breaker starts closed. Once the ratio of failed requests within a rolling window reaches [Options.Threshold], the
+// breaker opens: requests are rejected with a [net/http.StatusServiceUnavailable] response (or served by [Options.Fallback]) until
+// [Options.Cooldown] elapses. The breaker then turns half-open, admitting a limited number of probe requests; if every probe
+// succeeds the breaker closes, otherwise it re-opens.
+//
+// A request fails if its response has a server-error (5xx) status, or if its handler reports an error through [Fail] - e.g. an
+// upstream call that failed, but was answered with a cached, or degraded, response. Handlers can inspect the breaker's state
+// through [Value], and degrade gracefully while it's half-open.
+//
+// [route]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/route
+package breaker
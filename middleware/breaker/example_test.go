@@ -0,0 +1,61 @@
+package breaker_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/breaker"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /inventory", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway) // The upstream inventory service is unavailable.
+		return
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(route.New().Settings(func(o *route.Options) {
+		o.Mux = mux
+	}).Handler)
+
+	middleware.Add(breaker.New().Settings(func(o *breaker.Options) {
+		o.Minimum = 2
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for range 3 {
+		request, e := http.NewRequest(http.MethodGet, server.URL+"/inventory", nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Status: 502
+	// Status: 502
+	// Status: 503
+}
@@ -0,0 +1,314 @@
+package breaker
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/route"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "breaker"
+
+// Valuer is the context return type relating to the [Breaker] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Key represents the request's breaker key - by default, its route.
+	Key string `json:"key"`
+
+	// State represents the breaker's state at the request's admission. A [HalfOpen] state indicates the request is a probe, and
+	// that handlers should prefer degraded responses over expensive upstream calls.
+	State State `json:"state"`
+
+	mutex  sync.Mutex
+	failed error
+}
+
+// Options represents the configuration settings for the [Breaker] middleware component.
+type Options struct {
+	// Key returns the request's breaker key; each key has its own breaker. Defaults to a function returning the route middleware's
+	// route template - requests without a route share a single breaker.
+	Key func(r *http.Request) string
+
+	// Failure reports whether a response status represents a failure. Defaults to a function matching server errors (5xx).
+	Failure func(status int) bool
+
+	// Threshold represents the failure ratio, within [Options.Window], at which a closed breaker opens. Must be within (0, 1].
+	// Defaults to 0.5.
+	Threshold float64
+
+	// Minimum represents the minimum number of requests, within [Options.Window], before a closed breaker can open. Defaults to 20.
+	Minimum int
+
+	// Window represents the rolling window failure ratios are evaluated over. Defaults to 10 seconds.
+	Window time.Duration
+
+	// Cooldown represents the duration an open breaker rejects requests, prior to turning half-open. Defaults to 30 seconds.
+	Cooldown time.Duration
+
+	// Probes represents the number of concurrent probe requests a half-open breaker admits - and the number of successful probes
+	// required to close it. Defaults to 1.
+	Probes int
+
+	// Fallback optionally serves requests rejected by an open breaker (e.g. a cached, or degraded, response). Defaults to nil - such
+	// requests receive a [http.StatusServiceUnavailable] response, and a Retry-After header.
+	Fallback http.Handler
+
+	// Change is optionally called on every breaker state change - e.g. for metrics, or alerting. Defaults to nil.
+	Change func(key string, from, to State)
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Breaker represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Breaker struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+// keying is the default [Options.Key] function. The request's route is looked up without logging its absence, as the route
+// middleware is optional; unrouted requests share a single breaker, rather than a breaker per client-controlled path.
+func keying(r *http.Request) string {
+	v, _ := route.Lookup(r.Context())
+
+	return v
+}
+
+// failure is the default [Options.Failure] function.
+func failure(status int) bool {
+	return status >= http.StatusInternalServerError
+}
+
+// Settings applies configuration functions to modify the [Breaker] middleware's [Options] and returns the updated middleware instance.
+func (x *Breaker) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Key:       keying,
+			Failure:   failure,
+			Threshold: 0.5,
+			Minimum:   20,
+			Window:    time.Second * 10,
+			Cooldown:  time.Second * 30,
+			Probes:    1,
+			Fallback:  nil,
+			Change:    nil,
+			Clock:     time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Key == nil {
+		x.options.Key = keying
+	}
+
+	if x.options.Failure == nil {
+		x.options.Failure = failure
+	}
+
+	if x.options.Threshold <= 0 || x.options.Threshold > 1 {
+		slog.Warn("Invalid Breaker Threshold Specified - Using Default Threshold")
+
+		x.options.Threshold = 0.5
+	}
+
+	if x.options.Minimum <= 0 {
+		slog.Warn("Invalid Breaker Minimum Specified - Using Default Minimum")
+
+		x.options.Minimum = 20
+	}
+
+	if x.options.Window < buckets {
+		slog.Warn("Invalid Breaker Window Specified - Using Default Window")
+
+		x.options.Window = time.Second * 10
+	}
+
+	if x.options.Cooldown <= 0 {
+		slog.Warn("Invalid Breaker Cooldown Specified - Using Default Cooldown")
+
+		x.options.Cooldown = time.Second * 30
+	}
+
+	if x.options.Probes <= 0 {
+		slog.Warn("Invalid Breaker Probes Specified - Using Default Probes")
+
+		x.options.Probes = 1
+	}
+
+	if x.options.Clock == nil {
+		x.options.Clock = time.Now
+	}
+
+	return x
+}
+
+// circuit returns the key's breaker, creating it if necessary.
+func (x *Breaker) circuit(k string) *circuit {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if x.circuits == nil {
+		x.circuits = make(map[string]*circuit)
+	}
+
+	c, ok := x.circuits[k]
+	if !(ok) {
+		c = &circuit{state: Closed}
+		x.circuits[k] = c
+	}
+
+	return c
+}
+
+// States returns a snapshot of every breaker's state, keyed by breaker key - e.g. for a health endpoint.
+func (x *Breaker) States() map[string]State {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	states := make(map[string]State, len(x.circuits))
+	for k, c := range x.circuits {
+		c.mutex.Lock()
+		states[k] = c.state
+		c.mutex.Unlock()
+	}
+
+	return states
+}
+
+// change logs, and reports, a breaker's state change.
+func (x *Breaker) change(ctx context.Context, k string, t *transition) {
+	if t == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if t.to == Open {
+		level = slog.LevelWarn
+	}
+
+	slog.Log(ctx, level, "Circuit Breaker State Change", slog.String("key", k), slog.String("from", string(t.from)), slog.String("to", string(t.to)))
+
+	if x.options.Change != nil {
+		x.options.Change(k, t.from, t.to)
+	}
+}
+
+// Handler admits requests through their key's breaker. Requests rejected by an open breaker are served by [Options.Fallback], or
+// receive a [http.StatusServiceUnavailable] response and a Retry-After header. Admitted requests' outcomes - their response status,
+// a reported [Fail], or a panic - drive the breaker's state.
+func (x *Breaker) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		k := x.options.Key(r)
+
+		c := x.circuit(k)
+
+		state, admitted, t := c.admit(x.options.Clock(), x.options)
+
+		x.change(ctx, k, t)
+
+		valuer := &Valuer{Key: k, State: state}
+
+		if !(admitted) {
+			if x.options.Fallback != nil {
+				x.options.Fallback.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+
+				return
+			}
+
+			reject.Record(ctx, reject.Reason{Subsystem: "breaker", Code: "circuit-open", Rule: k, Status: http.StatusServiceUnavailable})
+
+			retry := c.remaining(x.options.Clock(), x.options)
+			if retry < time.Second {
+				retry = time.Second
+			}
+
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((retry+time.Second-1)/time.Second), 10))
+
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		recorder := middleware.Wrap(w)
+
+		completed := false
+
+		defer func() {
+			valuer.mutex.Lock()
+			failed := !(completed) || valuer.failed != nil || x.options.Failure(recorder.Status())
+			valuer.mutex.Unlock()
+
+			x.change(ctx, k, c.record(state, failed, x.options.Clock(), x.options))
+		}()
+
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(ctx, key, valuer)))
+
+		completed = true
+	})
+}
+
+// New creates a new instance of the [Breaker] middleware, implementing [middleware.Configurable]. If [Breaker.Settings] isn't called,
+// then the [Breaker.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers may retain the returned instance to call [Breaker.States].
+func New() *Breaker {
+	return new(Breaker)
+}
+
+// Fail reports the request as failed - e.g. following an upstream error the handler answered with a degraded response - such that it
+// counts towards its breaker's failure ratio regardless of the response's status. False is returned if the [Breaker] middleware
+// isn't enabled for the particular caller's chain.
+func Fail(ctx context.Context, e error) bool {
+	v, ok := ctx.Value(key).(*Valuer)
+	if !(ok) || e == nil {
+		return false
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.failed == nil {
+		v.failed = e
+	}
+
+	return true
+}
+
+// Value retrieves the request's breaker [Valuer] from the provided context. If a nil value is returned, it can be assumed that the
+// [Breaker] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Breaker] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Breaker)(nil)
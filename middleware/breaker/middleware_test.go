@@ -0,0 +1,161 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/breaker"
+)
+
+func Test(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var changes []string
+
+	instance := breaker.New()
+
+	handler := instance.Settings(func(o *breaker.Options) {
+		o.Key = func(r *http.Request) string { return r.URL.Path }
+		o.Minimum = 4
+		o.Threshold = 0.5
+		o.Cooldown = time.Second * 5
+		o.Clock = func() time.Time { return now }
+		o.Change = func(key string, from, to breaker.State) {
+			changes = append(changes, key+":"+string(from)+"->"+string(to))
+		}
+	}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Breaker-State", string(breaker.Value(r.Context()).State))
+
+		if r.URL.Query().Has("fail") {
+			breaker.Fail(r.Context(), errors.New("upstream unavailable"))
+		}
+
+		status, _ := strconv.Atoi(r.URL.Query().Get("status"))
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		w.WriteHeader(status)
+	}))
+
+	tests := []struct {
+		name    string
+		target  string
+		advance time.Duration
+		status  int
+		state   breaker.State
+	}{
+		{name: "Closed-Success", target: "/orders", status: http.StatusOK, state: breaker.Closed},
+		{name: "Closed-Failure", target: "/orders?status=502", status: http.StatusBadGateway, state: breaker.Closed},
+		{name: "Closed-Reported-Failure", target: "/orders?fail", status: http.StatusOK, state: breaker.Closed},
+		{name: "Closed-Client-Error", target: "/orders?status=404", status: http.StatusNotFound, state: breaker.Closed},
+		{name: "Closed-Tripping-Failure", target: "/orders?status=500", status: http.StatusInternalServerError, state: breaker.Closed},
+		{name: "Open", target: "/orders", status: http.StatusServiceUnavailable},
+		{name: "Independent-Key", target: "/users", status: http.StatusOK, state: breaker.Closed},
+		{name: "Half-Open-Failed-Probe", target: "/orders?status=500", advance: time.Second * 5, status: http.StatusInternalServerError, state: breaker.HalfOpen},
+		{name: "Reopened", target: "/orders", status: http.StatusServiceUnavailable},
+		{name: "Half-Open-Probe", target: "/orders", advance: time.Second * 5, status: http.StatusOK, state: breaker.HalfOpen},
+		{name: "Closed", target: "/orders", status: http.StatusOK, state: breaker.Closed},
+	}
+
+	for _, test := range tests {
+		now = now.Add(test.advance)
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+		if recorder.Code != test.status {
+			t.Fatalf("%s Status = %d\n    - Expectation = %d", test.name, recorder.Code, test.status)
+		}
+
+		if v := breaker.State(recorder.Header().Get("X-Breaker-State")); v != test.state {
+			t.Errorf("%s State = %s\n    - Expectation = %s", test.name, v, test.state)
+		}
+
+		if test.status == http.StatusServiceUnavailable && recorder.Header().Get("Retry-After") != "5" {
+			t.Errorf("%s Retry-After = %s\n    - Expectation = %s", test.name, recorder.Header().Get("Retry-After"), "5")
+		}
+	}
+
+	expectation := []string{"/orders:closed->open", "/orders:open->half-open", "/orders:half-open->open", "/orders:open->half-open", "/orders:half-open->closed"}
+	if len(changes) != len(expectation) {
+		t.Fatalf("Changes = %v\n    - Expectation = %v", changes, expectation)
+	}
+
+	for index := range expectation {
+		if changes[index] != expectation[index] {
+			t.Errorf("Change = %s\n    - Expectation = %s", changes[index], expectation[index])
+		}
+	}
+
+	if states := instance.States(); states["/orders"] != breaker.Closed || states["/users"] != breaker.Closed {
+		t.Errorf("States = %v\n    - Expectation = %s", states, breaker.Closed)
+	}
+
+	t.Run("Fallback", func(t *testing.T) {
+		handler := breaker.New().Settings(func(o *breaker.Options) {
+			o.Minimum = 1
+			o.Fallback = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Breaker-State", string(breaker.Value(r.Context()).State))
+
+				w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			})
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Panic Wasn't Propagated")
+				}
+			}()
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusNonAuthoritativeInfo {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusNonAuthoritativeInfo)
+		}
+
+		if v := recorder.Header().Get("X-Breaker-State"); v != string(breaker.Open) {
+			t.Errorf("State = %s\n    - Expectation = %s", v, breaker.Open)
+		}
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		h := breaker.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if e := http.NewResponseController(w).Flush(); e != nil {
+				t.Errorf("Unexpected Error While Flushing Response: %v", e)
+			}
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+		if !(recorder.Flushed) {
+			t.Errorf("Flushed = %t\n    - Expectation = %t", recorder.Flushed, true)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := breaker.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+
+		if breaker.Fail(context.Background(), errors.New("unavailable")) {
+			t.Errorf("Fail = true\n    - Expectation = false")
+		}
+	})
+}
@@ -0,0 +1,4 @@
+// Package brownout provides middleware for scheduled brownouts of deprecated endpoints. Configured routes
+// intermittently reject requests for short, recurring windows - returning an explanatory status code and
+// header(s) - so that API owners can measure, and push, any remaining consumers off old endpoints before removal.
+package brownout
@@ -0,0 +1,61 @@
+package brownout_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/brownout"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(brownout.New().Settings(func(o *brownout.Options) {
+		o.Routes = []string{"/v1/"}
+		o.Period = time.Hour
+		o.Window = time.Minute * 5
+		o.Link = "https://example.com/migrations/v2"
+
+		// A fixed clock within the first five minutes of the hour ensures the example is deterministic.
+		o.Clock = func() time.Time { return time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC) }
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	for _, path := range []string{"/v1/users", "/v2/users"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("%s: %d\n", path, response.StatusCode)
+	}
+
+	// Output:
+	// /v1/users: 410
+	// /v2/users: 200
+}
@@ -0,0 +1,212 @@
+package brownout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "brownout"
+
+const (
+	defaultPeriod = time.Hour
+	defaultWindow = time.Minute * 5
+)
+
+// Valuer is the context return type relating to the [Brownout] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Deprecated reports whether the request matched one of the configured [Options.Routes].
+	Deprecated bool `json:"deprecated"`
+
+	// Active reports whether the request was received during a scheduled brownout window. Handlers will only
+	// ever observe a false value, as active brownouts are rejected before reaching the next handler.
+	Active bool `json:"active"`
+}
+
+// Options represents the configuration settings for the [Brownout] middleware component.
+type Options struct {
+	// Routes represents the deprecated url path prefixes subject to brownouts. An empty slice disables the middleware's behavior.
+	Routes []string
+
+	// Period represents the length of a single brownout schedule cycle. Defaults to one hour.
+	Period time.Duration
+
+	// Window represents the duration, at the start of every [Options.Period], in which deprecated routes are rejected. Defaults to five minutes.
+	Window time.Duration
+
+	// Status represents the response status code written during a brownout window. Only [http.StatusGone] and [http.StatusTooManyRequests]
+	// are considered valid. Defaults to [http.StatusGone].
+	Status int
+
+	// Sunset represents an optional removal date for the deprecated routes. When non-zero, the "Sunset" response header (RFC 8594) is included.
+	Sunset time.Time
+
+	// Link represents an optional url to migration documentation. When non-empty, a "Link" response header is included.
+	Link string
+
+	// Message represents the plain-text response body written during a brownout window.
+	Message string
+
+	// Level specifies the log level used to record rejected brownout requests. A value of nil disables logging. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Brownout represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Brownout struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Brownout] middleware's [Options] and returns the updated middleware instance.
+func (b *Brownout) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if b.options == nil {
+		b.options = &Options{
+			Routes:  []string{},
+			Period:  defaultPeriod,
+			Window:  defaultWindow,
+			Status:  http.StatusGone,
+			Message: "Endpoint Deprecated - Scheduled Brownout in Progress",
+			Level:   slog.LevelWarn,
+			Clock:   time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(b.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if b.options.Period <= 0 {
+		slog.Warn("Invalid Brownout Period Specified - Using Default Period")
+
+		b.options.Period = defaultPeriod
+	}
+
+	if b.options.Window <= 0 || b.options.Window > b.options.Period {
+		slog.Warn("Invalid Brownout Window Specified - Using Default Window")
+
+		b.options.Window = min(defaultWindow, b.options.Period)
+	}
+
+	if b.options.Status != http.StatusGone && b.options.Status != http.StatusTooManyRequests {
+		slog.Warn("Invalid Brownout Status Specified - Using Default Status")
+
+		b.options.Status = http.StatusGone
+	}
+
+	if b.options.Clock == nil {
+		b.options.Clock = time.Now
+	}
+
+	return b
+}
+
+// deprecated reports whether the request's path matches any of the configured route prefixes.
+func (b *Brownout) deprecated(r *http.Request) bool {
+	for _, route := range b.options.Routes {
+		if route != "" && strings.HasPrefix(r.URL.Path, route) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remaining returns the duration left in the current brownout window, or zero if no window is active.
+func (b *Brownout) remaining(now time.Time) time.Duration {
+	elapsed := time.Duration(now.UnixNano() % int64(b.options.Period))
+	if elapsed < b.options.Window {
+		return b.options.Window - elapsed
+	}
+
+	return 0
+}
+
+// Handler applies brownout middleware to the provided HTTP handler, rejecting requests to deprecated routes during scheduled windows.
+func (b *Brownout) Handler(next http.Handler) http.Handler {
+	b.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{
+			Deprecated: b.deprecated(r),
+		}
+
+		if valuer.Deprecated {
+			w.Header().Set("Deprecation", "true")
+
+			if !(b.options.Sunset.IsZero()) {
+				w.Header().Set("Sunset", b.options.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			if b.options.Link != "" {
+				w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", b.options.Link))
+			}
+
+			if remaining := b.remaining(b.options.Clock()); remaining > 0 {
+				valuer.Active = true
+
+				if v := b.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Brownout Middleware - Rejected Deprecated Route Request", slog.String("path", r.URL.Path), slog.String("user-agent", r.UserAgent()), slog.Duration("remaining", remaining))
+				}
+
+				seconds := int64(remaining.Round(time.Second) / time.Second)
+
+				w.Header().Set("X-Brownout", "active")
+				w.Header().Set("Retry-After", strconv.FormatInt(max(seconds, 1), 10))
+
+				http.Error(w, b.options.Message, b.options.Status)
+				return
+			}
+		}
+
+		ctx = context.WithValue(ctx, key, valuer)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Brownout] middleware, implementing [middleware.Configurable]. If [Brownout.Settings] isn't called,
+// then the [Brownout.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Brownout)
+}
+
+// Value retrieves a [Valuer] pointer representing [Brownout] related context. If a nil value is returned, it can be
+// assumed that the [Brownout] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Brownout] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Brownout)(nil)
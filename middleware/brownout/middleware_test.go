@@ -0,0 +1,172 @@
+package brownout_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/brownout"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		datum := map[string]interface{}{
+			"brownout": brownout.Value(ctx),
+		}
+
+		defer json.NewEncoder(w).Encode(datum)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	// inside represents a time within the first minute of a scheduled hourly window; outside represents a time past the window.
+	inside := time.Date(2024, time.January, 1, 12, 1, 0, 0, time.UTC)
+	outside := time.Date(2024, time.January, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		middleware func(next http.Handler) http.Handler
+		path       string
+		status     int
+		headers    map[string]string
+	}{
+		{
+			name: "Deprecated-Route-Active-Window",
+			middleware: brownout.New().Settings(func(o *brownout.Options) {
+				o.Routes = []string{"/v1/"}
+				o.Clock = func() time.Time { return inside }
+			}).Handler,
+			path:   "/v1/users",
+			status: http.StatusGone,
+			headers: map[string]string{
+				"Deprecation": "true",
+				"X-Brownout":  "active",
+				"Retry-After": "240",
+			},
+		},
+		{
+			name: "Deprecated-Route-Active-Window-Too-Many-Requests",
+			middleware: brownout.New().Settings(func(o *brownout.Options) {
+				o.Routes = []string{"/v1/"}
+				o.Status = http.StatusTooManyRequests
+				o.Link = "https://example.com/migrations/v2"
+				o.Clock = func() time.Time { return inside }
+			}).Handler,
+			path:   "/v1/users",
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"Link": "<https://example.com/migrations/v2>; rel=\"deprecation\"",
+			},
+		},
+		{
+			name: "Deprecated-Route-Inactive-Window",
+			middleware: brownout.New().Settings(func(o *brownout.Options) {
+				o.Routes = []string{"/v1/"}
+				o.Sunset = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+				o.Clock = func() time.Time { return outside }
+			}).Handler,
+			path:   "/v1/users",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"Deprecation": "true",
+				"Sunset":      "Wed, 01 Jan 2025 00:00:00 GMT",
+				"X-Brownout":  "",
+			},
+		},
+		{
+			name: "Supported-Route-Active-Window",
+			middleware: brownout.New().Settings(func(o *brownout.Options) {
+				o.Routes = []string{"/v1/"}
+				o.Clock = func() time.Time { return inside }
+			}).Handler,
+			path:   "/v2/users",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"Deprecation": "",
+			},
+		},
+		{
+			name:       "Defaults",
+			middleware: brownout.New().Handler,
+			path:       "/v1/users",
+			status:     http.StatusOK,
+		},
+	}
+
+	for _, matrix := range tests {
+		t.Run(matrix.name, func(t *testing.T) {
+			server := httptest.NewServer(matrix.middleware(handler))
+
+			defer server.Close()
+
+			client := server.Client()
+			request, e := http.NewRequest(http.MethodGet, server.URL+matrix.path, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			response, e := client.Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			if status := response.StatusCode; status != matrix.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", status, matrix.status)
+			}
+
+			for header, expectation := range matrix.headers {
+				if value := response.Header.Get(header); value != expectation {
+					t.Errorf("Header (%s) = %q\n    - Expectation = %q", header, value, expectation)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid-Options", func(t *testing.T) {
+		server := httptest.NewServer(brownout.New().Settings(func(o *brownout.Options) {
+			o.Routes = []string{"/"}
+			o.Period = -1
+			o.Window = time.Hour * 2
+			o.Status = http.StatusTeapot
+			o.Clock = func() time.Time { return inside }
+		}).Handler(handler))
+
+		defer server.Close()
+
+		response, e := server.Client().Get(server.URL)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusGone {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusGone)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		t.Run("Default", func(t *testing.T) {
+			if value := brownout.Value(context.Background()); value != nil {
+				t.Errorf("Unexpected Non-Nil Context Value: %v", value)
+			}
+		})
+
+		t.Run("User-Specified-Value", func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "x-testing-key", &brownout.Valuer{Deprecated: true})
+
+			if value := brownout.Value(ctx); value == nil || !(value.Deprecated) {
+				t.Errorf("Invalid Context Value: %v", value)
+			}
+		})
+	})
+}
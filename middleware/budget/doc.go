@@ -0,0 +1,12 @@
+// Package budget provides middleware enforcing per-route response time budgets, for SLO tuning.
+//
+// Each request's budget is selected by its route template - resolved by the [route] middleware, which must precede the [Budget]
+// middleware in the chain - from a configuration table. Requests exceeding their budget are logged, and reported to an optional
+// hook (e.g. for metrics), even if they succeed. Optionally, requests exceeding a hard multiple of their budget are aborted with a
+// [net/http.StatusServiceUnavailable] response.
+//
+// Enforcement is softer than the timeout middleware's: the handler runs in the serving goroutine, its context is canceled at the
+// hard limit, and the abort response is only written if the handler hasn't yet written its own.
+//
+// [route]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/route
+package budget
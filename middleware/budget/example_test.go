@@ -0,0 +1,59 @@
+package budget_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/budget"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /reports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Budget: %s\n", budget.Value(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(route.New().Settings(func(o *route.Options) {
+		o.Mux = mux
+	}).Handler)
+
+	middleware.Add(budget.New().Settings(func(o *budget.Options) {
+		o.Budgets = map[string]time.Duration{"GET /reports": time.Millisecond * 250}
+		o.Multiple = 4 // Abort requests exceeding one second.
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/reports", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Budget: 250ms
+}
@@ -0,0 +1,11 @@
+module github.com/poly-gun/go-middleware/middleware/budget
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace github.com/poly-gun/go-middleware/middleware/route => ../route
+
+require github.com/poly-gun/go-middleware/middleware/route v0.0.0
@@ -0,0 +1,264 @@
+package budget
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "budget"
+
+// Options represents the configuration settings for the [Budget] middleware component.
+type Options struct {
+	// Budgets maps route templates (e.g. "GET /users/{id}") to their response time budget. Defaults to an empty map.
+	Budgets map[string]time.Duration
+
+	// Default represents the budget of routes absent from [Options.Budgets]. A zero value exempts such routes. Defaults to zero.
+	Default time.Duration
+
+	// Multiple represents the multiple of a route's budget after which its request is aborted with a
+	// [http.StatusServiceUnavailable] response. A zero value disables aborting; otherwise, values must exceed one. Defaults to zero.
+	Multiple float64
+
+	// Level specifies the log level used to record requests exceeding their budget. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Exceeded optionally receives every request exceeding its budget - including aborted requests - e.g. for metrics. Defaults
+	// to nil.
+	Exceeded func(r *http.Request, route string, budget, elapsed time.Duration)
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Budget represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Budget struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Budget] middleware's [Options] and returns the updated middleware instance.
+func (x *Budget) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Budgets:  map[string]time.Duration{},
+			Default:  0,
+			Multiple: 0,
+			Level:    slog.LevelWarn,
+			Exceeded: nil,
+			Clock:    time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Multiple != 0 && x.options.Multiple <= 1 {
+		slog.Warn("Invalid Budget Multiple Specified - Aborting Disabled")
+
+		x.options.Multiple = 0
+	}
+
+	if x.options.Default < 0 {
+		slog.Warn("Invalid Budget Default Specified - Using Default Budget")
+
+		x.options.Default = 0
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	if x.options.Clock == nil {
+		x.options.Clock = time.Now
+	}
+
+	return x
+}
+
+// budget returns the route's budget.
+func (x *Budget) budget(template string) time.Duration {
+	if v, found := x.options.Budgets[template]; found && v > 0 {
+		return v
+	}
+
+	return x.options.Default
+}
+
+// Handler measures each request's duration against its route's budget, logging requests exceeding it, and - if [Options.Multiple]
+// is configured - aborting requests exceeding the hard limit. It forwards the request to the next handler in the chain.
+func (x *Budget) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		template := route.Value(ctx)
+
+		budget := x.budget(template)
+		if budget <= 0 {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		start := x.options.Clock()
+
+		ctx = context.WithValue(ctx, key, budget)
+
+		if x.options.Multiple == 0 {
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			x.evaluate(r, template, budget, x.options.Clock().Sub(start), false)
+
+			return
+		}
+
+		limit := time.Duration(float64(budget) * x.options.Multiple)
+
+		ctx, cancel := context.WithTimeout(ctx, limit)
+		defer cancel()
+
+		wrapper := &writer{ResponseWriter: w, ctx: ctx}
+
+		next.ServeHTTP(wrapper, r.WithContext(ctx))
+
+		aborted := wrapper.abort()
+
+		x.evaluate(r, template, budget, x.options.Clock().Sub(start), aborted)
+	})
+}
+
+// evaluate records a request exceeding its budget.
+func (x *Budget) evaluate(r *http.Request, template string, budget, elapsed time.Duration, aborted bool) {
+	if elapsed <= budget && !(aborted) {
+		return
+	}
+
+	slog.Log(r.Context(), x.options.Level.Level(), "Response Time Budget Exceeded", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.String("route", template), slog.Duration("budget", budget), slog.Duration("elapsed", elapsed), slog.Bool("aborted", aborted))
+
+	if x.options.Exceeded != nil {
+		x.options.Exceeded(r, template, budget, elapsed)
+	}
+}
+
+// New creates a new instance of the [Budget] middleware, implementing [middleware.Configurable]. If [Budget.Settings] isn't called,
+// then the [Budget.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Budget)
+}
+
+// Value retrieves the request's response time budget from the provided context, such that handlers can adapt (e.g. by skipping
+// optional work). If a zero value is returned, the request's route has no budget, or it can be assumed that the [Budget]
+// middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (budget time.Duration) {
+	if v, ok := ctx.Value(key).(time.Duration); ok {
+		budget = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Request Budget Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// writer discards the handler's response once the hard limit elapses, such that the abort response can be written instead.
+type writer struct {
+	http.ResponseWriter
+
+	ctx context.Context
+
+	mutex   sync.Mutex
+	written bool
+	aborted bool
+}
+
+// admit reports whether the handler's write may proceed.
+func (w *writer) admit() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.written {
+		return true
+	}
+
+	if w.aborted || w.ctx.Err() != nil {
+		w.aborted = true
+
+		return false
+	}
+
+	w.written = true
+
+	return true
+}
+
+// abort writes the abort response if the hard limit elapsed prior to the handler writing its response, and reports whether it did.
+func (w *writer) abort() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.written || !(w.aborted || w.ctx.Err() != nil) {
+		return false
+	}
+
+	w.written, w.aborted = true, true
+
+	http.Error(w.ResponseWriter, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+	return true
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.admit() {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.admit()) {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher]. Once the response is aborted, the flush is discarded.
+func (w *writer) Flush() {
+	if w.admit() {
+		http.NewResponseController(w.ResponseWriter).Flush()
+	}
+}
+
+// Hijack implements [http.Hijacker]. Once the response is aborted, hijacking fails with [http.ErrHandlerTimeout].
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !(w.admit()) {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Budget] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Budget)(nil)
@@ -0,0 +1,152 @@
+package budget_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/budget"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		settings func(o *budget.Options)
+		delay    time.Duration
+		status   int
+		exceeded bool
+	}{
+		{
+			name:     "Within-Budget",
+			template: "GET /users/{id}",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Second}
+			},
+			status:   http.StatusOK,
+			exceeded: false,
+		},
+		{
+			name:     "Exceeded-Budget",
+			template: "GET /users/{id}",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Millisecond * 5}
+			},
+			delay:    time.Millisecond * 10,
+			status:   http.StatusOK,
+			exceeded: true,
+		},
+		{
+			name:     "Default-Budget",
+			template: "GET /reports",
+			settings: func(o *budget.Options) {
+				o.Default = time.Millisecond * 5
+			},
+			delay:    time.Millisecond * 10,
+			status:   http.StatusOK,
+			exceeded: true,
+		},
+		{
+			name:     "Exempt-Route",
+			template: "GET /reports",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Millisecond}
+			},
+			delay:    time.Millisecond * 5,
+			status:   http.StatusOK,
+			exceeded: false,
+		},
+		{
+			name:     "Exceeded-Within-Hard-Limit",
+			template: "GET /users/{id}",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Millisecond * 5}
+				o.Multiple = 100
+			},
+			delay:    time.Millisecond * 10,
+			status:   http.StatusOK,
+			exceeded: true,
+		},
+		{
+			name:     "Aborted",
+			template: "GET /users/{id}",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Millisecond * 5}
+				o.Multiple = 2
+			},
+			delay:    time.Second,
+			status:   http.StatusServiceUnavailable,
+			exceeded: true,
+		},
+		{
+			name:     "Invalid-Multiple",
+			template: "GET /users/{id}",
+			settings: func(o *budget.Options) {
+				o.Budgets = map[string]time.Duration{"GET /users/{id}": time.Millisecond * 5}
+				o.Multiple = 0.5
+			},
+			delay:    time.Millisecond * 10,
+			status:   http.StatusOK,
+			exceeded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var exceeded bool
+
+			handler := budget.New().Settings(tt.settings, func(o *budget.Options) {
+				o.Exceeded = func(r *http.Request, template string, budget, elapsed time.Duration) {
+					exceeded = true
+
+					if template != tt.template {
+						t.Errorf("Route = %s\n    - Expectation = %s", template, tt.template)
+					}
+				}
+			}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			handler = route.New().Settings(func(o *route.Options) {
+				o.Resolver = func(r *http.Request) string { return tt.template }
+			}).Handler(handler)
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if recorder.Code != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if exceeded != tt.exceeded {
+				t.Errorf("Exceeded = %v\n    - Expectation = %v", exceeded, tt.exceeded)
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		t.Run("Budget", func(t *testing.T) {
+			var value time.Duration
+
+			handler := budget.New().Settings(func(o *budget.Options) {
+				o.Default = time.Second
+			}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				value = budget.Value(r.Context())
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if value != time.Second {
+				t.Errorf("Value = %s\n    - Expectation = %s", value, time.Second)
+			}
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package challenge
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Resolver performs the reverse and forward DNS lookups verifying crawlers. [net.Resolver] satisfies the interface.
+type Resolver interface {
+	LookupAddr(ctx context.Context, address string) ([]string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// verifier verifies crawlers via forward-confirmed reverse DNS, caching results per address.
+type verifier struct {
+	mutex sync.Mutex
+	cache map[string]bool
+}
+
+// capacity represents the maximum number of cached verifications before the cache is reset.
+const capacity = 4096
+
+// verify reports whether the address's reverse DNS hostname ends with one of the domains, and resolves back to the address.
+func (v *verifier) verify(ctx context.Context, resolver Resolver, domains []string, address string) bool {
+	v.mutex.Lock()
+	result, found := v.cache[address]
+	v.mutex.Unlock()
+
+	if found {
+		return result
+	}
+
+	result = confirm(ctx, resolver, domains, address)
+
+	v.mutex.Lock()
+	if v.cache == nil || len(v.cache) >= capacity {
+		v.cache = make(map[string]bool)
+	}
+
+	v.cache[address] = result
+	v.mutex.Unlock()
+
+	return result
+}
+
+// confirm performs the forward-confirmed reverse DNS lookup.
+func confirm(ctx context.Context, resolver Resolver, domains []string, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	hosts, e := resolver.LookupAddr(ctx, address)
+	if e != nil {
+		return false
+	}
+
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+		trusted := false
+		for _, domain := range domains {
+			if domain = strings.ToLower(strings.TrimPrefix(domain, ".")); host == domain || strings.HasSuffix(host, "."+domain) {
+				trusted = true
+				break
+			}
+		}
+
+		if !(trusted) {
+			continue
+		}
+
+		addresses, e := resolver.LookupHost(ctx, host)
+		if e != nil {
+			continue
+		}
+
+		for _, candidate := range addresses {
+			if v := net.ParseIP(candidate); v != nil && v.Equal(ip) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
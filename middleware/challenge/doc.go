@@ -0,0 +1,12 @@
+// Package challenge provides a managed-challenge middleware, serving a lightweight challenge to requests flagged as suspicious
+// before letting them through.
+//
+// Requests are selected by a trigger - by default, the [rules] middleware's challenge action. Selected requests receive either a
+// JavaScript proof-of-work page, or a cookie-set-and-redirect round trip. Upon completion, the middleware's verification endpoint
+// issues a signed, client-bound clearance cookie, and redirects the client back to the original URL.
+//
+// Verified search-engine crawlers (via forward-confirmed reverse DNS) and API clients - which can't complete interactive
+// challenges - are exempt.
+//
+// [rules]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/rules
+package challenge
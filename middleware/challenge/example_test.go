@@ -0,0 +1,79 @@
+package challenge_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/challenge"
+	"github.com/poly-gun/go-middleware/middleware/rules"
+)
+
+func Example() {
+	engine := new(rules.Engine)
+
+	if e := engine.Update([]rules.Rule{{Name: "login", Action: rules.ActionChallenge, Paths: []string{"^/login$"}}}); e != nil {
+		e = fmt.Errorf("unexpected error while updating rules: %w", e)
+
+		panic(e)
+	}
+
+	middleware := middleware.New()
+
+	middleware.Add(rules.New().Settings(func(o *rules.Options) {
+		o.Engine = engine
+	}).Handler)
+
+	middleware.Add(challenge.New().Settings(func(o *challenge.Options) {
+		o.Mode = challenge.ModeCookie
+		o.Secret = []byte("example-secret")
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /login", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Cleared: %t\n", challenge.Value(r.Context()).Cleared)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	jar, e := cookiejar.New(nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating cookie jar: %w", e)
+
+		panic(e)
+	}
+
+	client := server.Client()
+	client.Jar = jar
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/login", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	// The client follows the challenge's redirects, retaining its cookies.
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Status: %d\n", response.StatusCode)
+
+	// Output:
+	// Cleared: true
+	// Status: 200
+}
@@ -0,0 +1,20 @@
+module github.com/poly-gun/go-middleware/middleware/challenge
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace (
+	github.com/poly-gun/go-middleware/middleware/rip => ../rip
+	github.com/poly-gun/go-middleware/middleware/rules => ../rules
+	github.com/poly-gun/go-middleware/middleware/useragent => ../useragent
+)
+
+require (
+	github.com/poly-gun/go-middleware/middleware/rip v0.0.3
+	github.com/poly-gun/go-middleware/middleware/rules v0.0.0
+)
+
+require github.com/poly-gun/go-middleware/middleware/useragent v0.0.1 // indirect
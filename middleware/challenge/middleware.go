@@ -0,0 +1,396 @@
+package challenge
+
+import (
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/rip"
+	"github.com/poly-gun/go-middleware/middleware/rules"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "challenge"
+
+// window represents the lifetime of an issued, unsolved challenge.
+const window = 5 * time.Minute
+
+// Mode represents the challenge type served to triggered requests.
+type Mode string
+
+const (
+	// ModeWork serves a lightweight JavaScript proof-of-work challenge. See [Options.Difficulty].
+	ModeWork Mode = "proof-of-work"
+
+	// ModeCookie sets a challenge cookie and redirects through the verification endpoint, verifying the client retains cookies.
+	ModeCookie Mode = "cookie"
+)
+
+// Exemption represents the reason a triggered request wasn't challenged.
+type Exemption string
+
+const (
+	// ExemptionCrawler represents a verified search-engine crawler. See [Options.Domains].
+	ExemptionCrawler Exemption = "crawler"
+
+	// ExemptionAPI represents an API client. See [Options.API].
+	ExemptionAPI Exemption = "api"
+)
+
+// Valuer is the context return type relating to the [Challenge] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Triggered reports whether [Options.Trigger] selected the request for a challenge.
+	Triggered bool `json:"triggered"`
+
+	// Cleared reports whether the request carried a valid clearance cookie from a completed challenge.
+	Cleared bool `json:"cleared"`
+
+	// Exemption represents the reason a triggered request wasn't challenged. Empty if the request wasn't exempt.
+	Exemption Exemption `json:"exemption,omitempty"`
+}
+
+// Options represents the configuration settings for the [Challenge] middleware component.
+type Options struct {
+	// Mode represents the challenge type. Defaults to [ModeWork].
+	Mode Mode
+
+	// Trigger determines whether a request requires a challenge. Defaults to a function selecting requests for which the [rules]
+	// middleware evaluated [rules.ActionChallenge].
+	Trigger func(r *http.Request) bool
+
+	// Secret represents the key signing challenge and clearance tokens; deployments with multiple instances must share a secret.
+	// An empty value generates a random, per-process secret. Defaults to an empty slice.
+	Secret []byte
+
+	// Difficulty represents the proof-of-work's required number of leading zero bits - each additional bit doubles the expected
+	// work. Only values between 1 and 32 are considered valid. Defaults to 16.
+	Difficulty int
+
+	// Duration represents the lifetime of the clearance cookie issued upon completing a challenge. Defaults to 30 minutes.
+	Duration time.Duration
+
+	// Path represents the verification endpoint's URL path, served by the middleware. Defaults to "/.well-known/challenge".
+	Path string
+
+	// Cookie represents the challenge and clearance cookie's name. Defaults to "__challenge".
+	Cookie string
+
+	// Client returns the request's client address, binding tokens to the client. Defaults to a function returning the [rip]
+	// middleware's value, falling back to the host component of [http.Request.RemoteAddr].
+	Client func(r *http.Request) string
+
+	// Agents matches user-agents claiming to be a search-engine crawler. Claims are verified against [Options.Domains]. A nil
+	// value disables crawler exemptions. Defaults to an expression matching Googlebot, Bingbot, Applebot, DuckDuckBot, and YandexBot.
+	Agents *regexp.Regexp
+
+	// Domains represents the crawler domains a verified crawler's forward-confirmed reverse DNS hostname must belong to.
+	Domains []string
+
+	// Resolver performs crawler verification's DNS lookups. Defaults to [net.DefaultResolver].
+	Resolver Resolver
+
+	// API determines whether a request originates from an API client, which can't complete interactive challenges. Exempt requests
+	// must be authenticated downstream. A nil value disables API exemptions. Defaults to a function selecting requests with an
+	// Authorization header.
+	API func(r *http.Request) bool
+
+	// Status represents the response status code of challenge pages, and of challenged requests unable to complete a challenge (e.g.
+	// non-GET requests). Defaults to [http.StatusForbidden].
+	Status int
+
+	// Clock returns the current time. Defaults to [time.Now].
+	Clock func() time.Time
+
+	// Level specifies the log level used to record issued challenges and verification failures. A value of nil disables logging.
+	// Defaults to nil.
+	Level slog.Leveler
+}
+
+// Challenge represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Challenge struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	signer   *signer
+	verifier verifier
+}
+
+// Settings applies configuration functions to modify the [Challenge] middleware's [Options] and returns the updated middleware instance.
+func (c *Challenge) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Mode: ModeWork,
+			Trigger: func(r *http.Request) bool {
+				v := rules.Value(r.Context())
+
+				return v != nil && v.Action == rules.ActionChallenge
+			},
+			Secret:     []byte{},
+			Difficulty: 16,
+			Duration:   30 * time.Minute,
+			Path:       "/.well-known/challenge",
+			Cookie:     "__challenge",
+			Client: func(r *http.Request) string {
+				if v := rip.Value(r.Context()); v != "" {
+					return v
+				}
+
+				if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+					return v
+				}
+
+				return r.RemoteAddr
+			},
+			Agents:   regexp.MustCompile(`(?i)googlebot|bingbot|applebot|duckduckbot|yandexbot`),
+			Domains:  []string{"googlebot.com", "google.com", "search.msn.com", "applebot.apple.com", "duckduckgo.com", "yandex.com", "yandex.net", "yandex.ru"},
+			Resolver: net.DefaultResolver,
+			API: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") != ""
+			},
+			Status: http.StatusForbidden,
+			Clock:  time.Now,
+			Level:  nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Mode != ModeWork && c.options.Mode != ModeCookie {
+		slog.Warn("Invalid Challenge Mode Specified - Using Default Mode")
+
+		c.options.Mode = ModeWork
+	}
+
+	if c.options.Difficulty < 1 || c.options.Difficulty > 32 {
+		slog.Warn("Invalid Challenge Difficulty Specified - Using Default Difficulty")
+
+		c.options.Difficulty = 16
+	}
+
+	if c.options.Duration <= 0 {
+		slog.Warn("Invalid Challenge Duration Specified - Using Default Duration")
+
+		c.options.Duration = 30 * time.Minute
+	}
+
+	if !(strings.HasPrefix(c.options.Path, "/")) {
+		slog.Warn("Invalid Challenge Path Specified - Using Default Path")
+
+		c.options.Path = "/.well-known/challenge"
+	}
+
+	if c.options.Cookie == "" {
+		slog.Warn("Invalid Challenge Cookie Specified - Using Default Cookie")
+
+		c.options.Cookie = "__challenge"
+	}
+
+	if c.options.Status < 400 || c.options.Status > 599 {
+		slog.Warn("Invalid Challenge Status Specified - Using Default Status")
+
+		c.options.Status = http.StatusForbidden
+	}
+
+	if c.options.Resolver == nil {
+		c.options.Resolver = net.DefaultResolver
+	}
+
+	if c.options.Clock == nil {
+		c.options.Clock = time.Now
+	}
+
+	return c
+}
+
+// redirect returns the validated, same-origin redirect target; unsafe targets fall back to the root path.
+func redirect(target string) string {
+	if !(strings.HasPrefix(target, "/")) || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return "/"
+	}
+
+	return target
+}
+
+// cookie writes the challenge cookie with the provided value and lifetime.
+func (c *Challenge) cookie(w http.ResponseWriter, r *http.Request, value string, lifetime time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.options.Cookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(lifetime.Seconds()),
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// verification serves the verification endpoint, issuing a clearance cookie upon a completed challenge.
+func (c *Challenge) verification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	client, now, query := c.options.Client(r), c.options.Clock(), r.URL.Query()
+
+	var valid bool
+	switch c.options.Mode {
+	case ModeWork:
+		token := query.Get("challenge")
+
+		valid = c.signer.verify(token, client, now) && solved(token, query.Get("nonce"), c.options.Difficulty)
+	case ModeCookie:
+		if cookie, e := r.Cookie(c.options.Cookie); e == nil {
+			valid = c.signer.verify(cookie.Value, client, now)
+		}
+	}
+
+	if !(valid) {
+		if v := c.options.Level; v != nil {
+			slog.Log(ctx, v.Level(), "Challenge Verification Failed", slog.String("mode", string(c.options.Mode)), slog.String("client", client))
+		}
+
+		http.Error(w, "Challenge Verification Failed", c.options.Status)
+
+		return
+	}
+
+	c.cookie(w, r, c.signer.clearance(client, now.Add(c.options.Duration)), c.options.Duration)
+
+	http.Redirect(w, r, redirect(query.Get("redirect")), http.StatusSeeOther)
+}
+
+// exempt returns the request's exemption, if any.
+func (c *Challenge) exempt(r *http.Request, client string) Exemption {
+	if c.options.API != nil && c.options.API(r) {
+		return ExemptionAPI
+	}
+
+	if c.options.Agents != nil && len(c.options.Domains) > 0 && c.options.Agents.MatchString(r.UserAgent()) {
+		if c.verifier.verify(r.Context(), c.options.Resolver, c.options.Domains, client) {
+			return ExemptionCrawler
+		}
+	}
+
+	return ""
+}
+
+// challenge serves the configured challenge to the request.
+func (c *Challenge) challenge(w http.ResponseWriter, r *http.Request, client string) {
+	ctx := r.Context()
+
+	if v := c.options.Level; v != nil {
+		slog.Log(ctx, v.Level(), "Issuing Challenge", slog.String("mode", string(c.options.Mode)), slog.String("client", client), slog.String("url", r.URL.String()))
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	// Neither challenge can replay a request body; only safe requests are challenged.
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Challenge Required", c.options.Status)
+
+		return
+	}
+
+	token := c.signer.challenge(client, c.options.Clock().Add(window))
+
+	switch c.options.Mode {
+	case ModeCookie:
+		c.cookie(w, r, token, window)
+
+		http.Redirect(w, r, c.options.Path+"?"+url.Values{"redirect": {r.URL.RequestURI()}}.Encode(), http.StatusFound)
+	case ModeWork:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(c.options.Status)
+
+		if e := page.Execute(w, document{Challenge: token, Difficulty: c.options.Difficulty, Path: c.options.Path, Redirect: r.URL.RequestURI()}); e != nil {
+			slog.ErrorContext(ctx, "Unable to Write Challenge Page", slog.String("error", e.Error()))
+		}
+	}
+}
+
+// Handler serves a challenge to requests selected by [Options.Trigger], unless the request carries a valid clearance cookie or
+// is exempt. It serves the verification endpoint at [Options.Path], and stores the request's [Valuer] in the request context.
+func (c *Challenge) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	if len(c.options.Secret) == 0 {
+		slog.Warn("Challenge Secret Unspecified - Using Random, Per-Process Secret")
+
+		c.options.Secret = make([]byte, 32)
+		if _, e := rand.Read(c.options.Secret); e != nil {
+			panic(e)
+		}
+	}
+
+	c.signer = &signer{secret: c.options.Secret}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.URL.Path == c.options.Path {
+			c.verification(w, r)
+
+			return
+		}
+
+		valuer := &Valuer{}
+
+		if c.options.Trigger != nil && c.options.Trigger(r) {
+			valuer.Triggered = true
+
+			client := c.options.Client(r)
+
+			if cookie, e := r.Cookie(c.options.Cookie); e == nil && c.signer.cleared(cookie.Value, client, c.options.Clock()) {
+				valuer.Cleared = true
+			} else if valuer.Exemption = c.exempt(r, client); valuer.Exemption == "" {
+				c.challenge(w, r, client)
+
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Challenge] middleware, implementing [middleware.Configurable]. If [Challenge.Settings] isn't
+// called, then the [Challenge.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Challenge)
+}
+
+// Value retrieves the request's challenge state from the provided context. If a nil value is returned, it can be assumed that the
+// [Challenge] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Challenge] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Challenge)(nil)
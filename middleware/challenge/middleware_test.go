@@ -0,0 +1,288 @@
+package challenge_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"math/bits"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/challenge"
+)
+
+// resolver is a static [challenge.Resolver] implementation.
+type resolver struct {
+	reverse map[string][]string
+	forward map[string][]string
+}
+
+func (r resolver) LookupAddr(_ context.Context, address string) ([]string, error) {
+	if v, ok := r.reverse[address]; ok {
+		return v, nil
+	}
+
+	return nil, errors.New("not found")
+}
+
+func (r resolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if v, ok := r.forward[host]; ok {
+		return v, nil
+	}
+
+	return nil, errors.New("not found")
+}
+
+// zeros returns the number of leading zero bits of the nonce's proof-of-work digest.
+func zeros(token, nonce string) (count int) {
+	for _, b := range sha256.Sum256([]byte(token + ":" + nonce)) {
+		count += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+
+	return
+}
+
+// solve brute-forces a nonce whose digest satisfies (or, if valid is false, fails) the difficulty.
+func solve(token string, difficulty int, valid bool) string {
+	for nonce := 0; ; nonce++ {
+		if v := strconv.Itoa(nonce); (zeros(token, v) >= difficulty) == valid {
+			return v
+		}
+	}
+}
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := challenge.Value(r.Context())
+
+		w.Header().Set("X-Triggered", strconv.FormatBool(v.Triggered))
+		w.Header().Set("X-Cleared", strconv.FormatBool(v.Cleared))
+		w.Header().Set("X-Exemption", string(v.Exemption))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	settings := func(o *challenge.Options) {
+		o.Trigger = func(r *http.Request) bool { return r.Header.Get("X-Suspicious") != "" }
+		o.Secret = []byte("secret")
+		o.Difficulty = 8
+		o.Clock = func() time.Time { return now }
+		o.Resolver = resolver{
+			reverse: map[string][]string{"66.249.66.1": {"crawl-66-249-66-1.googlebot.com."}, "192.0.2.1": {"crawl.googlebot.com.attacker.example."}},
+			forward: map[string][]string{"crawl-66-249-66-1.googlebot.com": {"66.249.66.1"}},
+		}
+	}
+
+	serve := func(m http.Handler, method, target, remote string, header http.Header, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, target, nil)
+		request.RemoteAddr = remote
+
+		for k, v := range header {
+			request.Header[k] = v
+		}
+
+		for _, cookie := range cookies {
+			request.AddCookie(cookie)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	suspicious := http.Header{"X-Suspicious": {"true"}}
+
+	t.Run("Untriggered", func(t *testing.T) {
+		m := challenge.New().Settings(settings).Handler(handler)
+
+		recorder := serve(m, http.MethodGet, "/", "203.0.113.1:1234", nil)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+
+		if v := recorder.Header().Get("X-Triggered"); v != "false" {
+			t.Errorf("Triggered = %s\n    - Expectation = %s", v, "false")
+		}
+	})
+
+	t.Run("Proof-of-Work", func(t *testing.T) {
+		m := challenge.New().Settings(settings).Handler(handler)
+
+		recorder := serve(m, http.MethodGet, "/page?q=1", "203.0.113.1:1234", suspicious)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusForbidden)
+		}
+
+		match := regexp.MustCompile(`const challenge = "([^"]+)"`).FindStringSubmatch(recorder.Body.String())
+		if match == nil {
+			t.Fatalf("Challenge Token Not Found in Page: %s", recorder.Body.String())
+		}
+
+		token := match[1]
+
+		verify := func(nonce, redirect, remote string) *httptest.ResponseRecorder {
+			target := "/.well-known/challenge?" + url.Values{"challenge": {token}, "nonce": {nonce}, "redirect": {redirect}}.Encode()
+
+			return serve(m, http.MethodGet, target, remote, nil)
+		}
+
+		nonce := solve(token, 8, true)
+
+		if v := verify(solve(token, 8, false), "/page?q=1", "203.0.113.1:1234"); v.Code != http.StatusForbidden {
+			t.Errorf("Invalid Nonce Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+
+		if v := verify(nonce, "/page?q=1", "203.0.113.2:1234"); v.Code != http.StatusForbidden {
+			t.Errorf("Foreign Client Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+
+		if v := verify(nonce, "//evil.example/", "203.0.113.1:1234"); v.Header().Get("Location") != "/" {
+			t.Errorf("Open Redirect Location = %s\n    - Expectation = %s", v.Header().Get("Location"), "/")
+		}
+
+		verified := verify(nonce, "/page?q=1", "203.0.113.1:1234")
+
+		if verified.Code != http.StatusSeeOther {
+			t.Fatalf("Verification Status = %d\n    - Expectation = %d", verified.Code, http.StatusSeeOther)
+		}
+
+		if v := verified.Header().Get("Location"); v != "/page?q=1" {
+			t.Errorf("Location = %s\n    - Expectation = %s", v, "/page?q=1")
+		}
+
+		cookies := verified.Result().Cookies()
+		if len(cookies) != 1 || !(cookies[0].HttpOnly) {
+			t.Fatalf("Invalid Clearance Cookie(s): %v", cookies)
+		}
+
+		cleared := serve(m, http.MethodGet, "/page?q=1", "203.0.113.1:1234", suspicious, cookies[0])
+
+		if cleared.Code != http.StatusOK {
+			t.Fatalf("Cleared Status = %d\n    - Expectation = %d", cleared.Code, http.StatusOK)
+		}
+
+		if v := cleared.Header().Get("X-Cleared"); v != "true" {
+			t.Errorf("Cleared = %s\n    - Expectation = %s", v, "true")
+		}
+
+		if v := serve(m, http.MethodGet, "/page", "203.0.113.2:1234", suspicious, cookies[0]); v.Code != http.StatusForbidden {
+			t.Errorf("Foreign Client Clearance Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+
+		now = now.Add(time.Hour)
+		defer func() { now = now.Add(-time.Hour) }()
+
+		if v := serve(m, http.MethodGet, "/page", "203.0.113.1:1234", suspicious, cookies[0]); v.Code != http.StatusForbidden {
+			t.Errorf("Expired Clearance Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+
+		if v := verify(nonce, "/page?q=1", "203.0.113.1:1234"); v.Code != http.StatusForbidden {
+			t.Errorf("Expired Challenge Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("Cookie", func(t *testing.T) {
+		m := challenge.New().Settings(settings, func(o *challenge.Options) { o.Mode = challenge.ModeCookie }).Handler(handler)
+
+		recorder := serve(m, http.MethodGet, "/page", "203.0.113.1:1234", suspicious)
+
+		if recorder.Code != http.StatusFound {
+			t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusFound)
+		}
+
+		location := recorder.Header().Get("Location")
+		cookies := recorder.Result().Cookies()
+
+		if v := serve(m, http.MethodGet, location, "203.0.113.1:1234", nil); v.Code != http.StatusForbidden {
+			t.Errorf("Cookie-Less Verification Status = %d\n    - Expectation = %d", v.Code, http.StatusForbidden)
+		}
+
+		verified := serve(m, http.MethodGet, location, "203.0.113.1:1234", nil, cookies...)
+
+		if verified.Code != http.StatusSeeOther || verified.Header().Get("Location") != "/page" {
+			t.Fatalf("Verification Status = %d (%s)\n    - Expectation = %d (%s)", verified.Code, verified.Header().Get("Location"), http.StatusSeeOther, "/page")
+		}
+
+		// The challenge cookie itself mustn't grant clearance.
+		if v := serve(m, http.MethodGet, "/page", "203.0.113.1:1234", suspicious, cookies...); v.Code != http.StatusFound {
+			t.Errorf("Challenge Cookie Status = %d\n    - Expectation = %d", v.Code, http.StatusFound)
+		}
+
+		if v := serve(m, http.MethodGet, "/page", "203.0.113.1:1234", suspicious, verified.Result().Cookies()...); v.Code != http.StatusOK {
+			t.Errorf("Cleared Status = %d\n    - Expectation = %d", v.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("Unsafe-Method", func(t *testing.T) {
+		m := challenge.New().Settings(settings).Handler(handler)
+
+		recorder := serve(m, http.MethodPost, "/page", "203.0.113.1:1234", suspicious)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusForbidden)
+		}
+
+		if v := recorder.Body.String(); v != "Challenge Required\n" {
+			t.Errorf("Body = %q\n    - Expectation = %q", v, "Challenge Required\n")
+		}
+	})
+
+	t.Run("Exemptions", func(t *testing.T) {
+		m := challenge.New().Settings(settings).Handler(handler)
+
+		tests := []struct {
+			name      string
+			remote    string
+			header    http.Header
+			status    int
+			exemption challenge.Exemption
+		}{
+			{name: "API", remote: "203.0.113.1:1234", header: http.Header{"X-Suspicious": {"true"}, "Authorization": {"Bearer token"}}, status: http.StatusOK, exemption: challenge.ExemptionAPI},
+			{name: "Crawler", remote: "66.249.66.1:1234", header: http.Header{"X-Suspicious": {"true"}, "User-Agent": {"Mozilla/5.0 (compatible; Googlebot/2.1)"}}, status: http.StatusOK, exemption: challenge.ExemptionCrawler},
+			{name: "Crawler-Spoofed-Agent", remote: "203.0.113.1:1234", header: http.Header{"X-Suspicious": {"true"}, "User-Agent": {"Googlebot/2.1"}}, status: http.StatusForbidden},
+			{name: "Crawler-Spoofed-PTR", remote: "192.0.2.1:1234", header: http.Header{"X-Suspicious": {"true"}, "User-Agent": {"Googlebot/2.1"}}, status: http.StatusForbidden},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				recorder := serve(m, http.MethodGet, "/", test.remote, test.header)
+
+				if recorder.Code != test.status {
+					t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+				}
+
+				if v := challenge.Exemption(recorder.Header().Get("X-Exemption")); test.status == http.StatusOK && v != test.exemption {
+					t.Errorf("Exemption = %s\n    - Expectation = %s", v, test.exemption)
+				}
+			})
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := challenge.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &challenge.Valuer{Triggered: true, Cleared: true}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := challenge.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
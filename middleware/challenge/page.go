@@ -0,0 +1,53 @@
+package challenge
+
+import (
+	"html/template"
+)
+
+// page represents the proof-of-work challenge's HTML document. The script searches for a nonce whose SHA-256 digest, combined
+// with the challenge token, begins with the required number of zero bits, then submits it to the verification endpoint.
+var page = template.Must(template.New("challenge").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex, nofollow">
+<title>Checking Your Browser</title>
+</head>
+<body>
+<p>Checking your browser - this should only take a moment.</p>
+<noscript><p>JavaScript is required to continue.</p></noscript>
+<script>
+(async () => {
+	const challenge = {{.Challenge}}, difficulty = {{.Difficulty}}, encoder = new TextEncoder();
+
+	const zeros = (digest) => {
+		let count = 0;
+		for (const byte of digest) {
+			if (byte === 0) { count += 8; continue; }
+			count += Math.clz32(byte) - 24;
+			break;
+		}
+
+		return count;
+	};
+
+	for (let nonce = 0; ; nonce++) {
+		const digest = new Uint8Array(await crypto.subtle.digest("SHA-256", encoder.encode(challenge + ":" + nonce)));
+		if (zeros(digest) >= difficulty) {
+			location.replace({{.Path}} + "?" + new URLSearchParams({challenge: challenge, nonce: String(nonce), redirect: {{.Redirect}}}));
+			return;
+		}
+	}
+})();
+</script>
+</body>
+</html>
+`))
+
+// document represents the [page] template's data.
+type document struct {
+	Challenge  string
+	Difficulty int
+	Path       string
+	Redirect   string
+}
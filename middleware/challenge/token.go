@@ -0,0 +1,92 @@
+package challenge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signer issues and verifies HMAC-signed, client-bound, expiring tokens.
+type signer struct {
+	secret []byte
+}
+
+// mac returns the signature of the provided parts.
+func (s *signer) mac(parts ...string) string {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(strings.Join(parts, "|")))
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// clearance returns a clearance token for the client, valid until expiry.
+func (s *signer) clearance(client string, expiry time.Time) string {
+	v := strconv.FormatInt(expiry.Unix(), 10)
+
+	return v + "." + s.mac("clearance", v, client)
+}
+
+// cleared reports whether the clearance token is valid for the client at the provided time.
+func (s *signer) cleared(token, client string, now time.Time) bool {
+	expiry, signature, found := strings.Cut(token, ".")
+	if !(found) {
+		return false
+	}
+
+	return s.valid(expiry, now) && hmac.Equal([]byte(signature), []byte(s.mac("clearance", expiry, client)))
+}
+
+// challenge returns a unique challenge token for the client, valid until expiry.
+func (s *signer) challenge(client string, expiry time.Time) string {
+	random := make([]byte, 16)
+	if _, e := rand.Read(random); e != nil {
+		panic(e)
+	}
+
+	v, nonce := strconv.FormatInt(expiry.Unix(), 10), hex.EncodeToString(random)
+
+	return v + "." + nonce + "." + s.mac("challenge", v, nonce, client)
+}
+
+// verify reports whether the challenge token was issued to the client, and remains valid at the provided time.
+func (s *signer) verify(token, client string, now time.Time) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	return s.valid(parts[0], now) && hmac.Equal([]byte(parts[2]), []byte(s.mac("challenge", parts[0], parts[1], client)))
+}
+
+// valid reports whether the unix-timestamp expiry hasn't passed.
+func (s *signer) valid(expiry string, now time.Time) bool {
+	v, e := strconv.ParseInt(expiry, 10, 64)
+
+	return e == nil && now.Unix() < v
+}
+
+// solved reports whether the nonce solves the challenge token's proof-of-work: the SHA-256 digest of "<token>:<nonce>" must
+// begin with at least difficulty zero bits.
+func solved(token, nonce string, difficulty int) bool {
+	if nonce == "" || len(nonce) > 32 {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(token + ":" + nonce))
+
+	zeros := 0
+	for _, b := range digest {
+		zeros += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+
+	return zeros >= difficulty
+}
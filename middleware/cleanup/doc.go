@@ -0,0 +1,6 @@
+// Package cleanup provides middleware for registering request-scoped resource cleanup, replacing deferred calls spread across
+// handlers and middleware.
+//
+// Functions registered through [Register] are guaranteed to be called once the response completes - even if the handler panics
+// or its context is canceled by a timeout - in last-in, first-out order.
+package cleanup
@@ -0,0 +1,66 @@
+package cleanup_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/cleanup"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(cleanup.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		cleanup.Register(ctx, func(ctx context.Context) error {
+			fmt.Println("Closed Connection")
+
+			return nil
+		})
+
+		cleanup.Register(ctx, func(ctx context.Context) error {
+			fmt.Println("Removed Temporary File")
+
+			return nil
+		})
+
+		fmt.Println("Handled Request")
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	// Output:
+	// Handled Request
+	// Removed Temporary File
+	// Closed Connection
+}
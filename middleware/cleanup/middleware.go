@@ -0,0 +1,186 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Register] can the context's value be derived.
+const key keyer = "cleanup"
+
+// Options represents the configuration settings for the [Cleanup] middleware component.
+type Options struct {
+	// Timeout bounds the context provided to registered functions. Because registered functions run after the request's context
+	// may have been canceled (e.g. a disconnected client, or an elapsed timeout), their context is detached from the request's
+	// cancellation. A zero value applies no deadline. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// Level specifies the log level used to record registered functions' errors and panics. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+}
+
+// Cleanup represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Cleanup struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Cleanup] middleware's [Options] and returns the updated middleware instance.
+func (x *Cleanup) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Timeout: 30 * time.Second,
+			Level:   slog.LevelWarn,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Timeout < 0 {
+		slog.Warn("Invalid Cleanup Timeout Specified - Using Default Timeout")
+
+		x.options.Timeout = 30 * time.Second
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	return x
+}
+
+// registry represents a request's registered cleanup functions.
+type registry struct {
+	mutex     sync.Mutex
+	functions []func(ctx context.Context) error
+	done      bool
+
+	ctx   context.Context // ctx represents the request's context, detached from its cancellation.
+	level slog.Leveler
+}
+
+// call runs a registered function, recovering and logging any panic such that remaining functions still run.
+func (r *registry) call(ctx context.Context, fn func(ctx context.Context) error) {
+	defer func() {
+		if value := recover(); value != nil {
+			slog.Log(ctx, r.level.Level(), "Recovered Cleanup Function Panic", slog.String("panic", fmt.Sprint(value)))
+		}
+	}()
+
+	if e := fn(ctx); e != nil {
+		slog.Log(ctx, r.level.Level(), "Cleanup Function Failure", slog.String("error", e.Error()))
+	}
+}
+
+// run calls the registered functions in last-in, first-out order. Functions registered by a function during the run are also
+// called.
+func (r *registry) run(ctx context.Context) {
+	for {
+		r.mutex.Lock()
+
+		if len(r.functions) == 0 {
+			r.done = true
+
+			r.mutex.Unlock()
+
+			return
+		}
+
+		fn := r.functions[len(r.functions)-1]
+		r.functions = r.functions[:len(r.functions)-1]
+
+		r.mutex.Unlock()
+
+		r.call(ctx, fn)
+	}
+}
+
+// Handler initializes a cleanup registry in the request context. Functions registered through [Register] are called after the
+// next handler returns - including when it panics, or its context is canceled - in last-in, first-out order, like deferred
+// function calls.
+func (x *Cleanup) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		reg := &registry{level: x.options.Level}
+
+		ctx = context.WithValue(ctx, key, reg)
+
+		// Registered functions receive the request's context values - including the registry - without its cancellation.
+		reg.ctx = context.WithoutCancel(ctx)
+
+		defer func() {
+			ctx := reg.ctx
+			if x.options.Timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, x.options.Timeout)
+
+				defer cancel()
+			}
+
+			reg.run(ctx)
+		}()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Register registers fn to be called after the request's response completes, and reports whether the [Cleanup] middleware is
+// enabled for the particular caller's chain. If it isn't, fn isn't registered. Functions registered after the registry's
+// functions were called (e.g. from a goroutine outliving the request) are called immediately.
+func Register(ctx context.Context, fn func(ctx context.Context) error) bool {
+	reg, ok := ctx.Value(key).(*registry)
+	if !(ok) {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+
+		return false
+	}
+
+	if fn == nil {
+		return true
+	}
+
+	reg.mutex.Lock()
+
+	if reg.done {
+		reg.mutex.Unlock()
+
+		reg.call(reg.ctx, fn)
+
+		return true
+	}
+
+	reg.functions = append(reg.functions, fn)
+
+	reg.mutex.Unlock()
+
+	return true
+}
+
+// New creates a new instance of the [Cleanup] middleware, implementing [middleware.Configurable]. If [Cleanup.Settings] isn't
+// called, then the [Cleanup.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Cleanup)
+}
+
+// Runtime assurance that [Cleanup] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Cleanup)(nil)
@@ -0,0 +1,116 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/cleanup"
+)
+
+func Test(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		var calls []string
+
+		handler := cleanup.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			cleanup.Register(ctx, func(ctx context.Context) error { calls = append(calls, "first"); return nil })
+			cleanup.Register(ctx, func(ctx context.Context) error { calls = append(calls, "second"); return errors.New("failure") })
+			cleanup.Register(ctx, func(ctx context.Context) error { panic("unexpected state") })
+			cleanup.Register(ctx, func(ctx context.Context) error {
+				cleanup.Register(ctx, func(ctx context.Context) error { calls = append(calls, "nested"); return nil })
+
+				calls = append(calls, "third")
+
+				return nil
+			})
+
+			calls = append(calls, "handler")
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		expectation := []string{"handler", "third", "nested", "second", "first"}
+		if !(slices.Equal(calls, expectation)) {
+			t.Errorf("Calls = %v\n    - Expectation = %v", calls, expectation)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		var called bool
+
+		handler := cleanup.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cleanup.Register(r.Context(), func(ctx context.Context) error { called = true; return nil })
+
+			panic("unexpected state")
+		}))
+
+		func() {
+			defer func() {
+				if v := recover(); v != "unexpected state" {
+					t.Errorf("Panic = %v\n    - Expectation = %s", v, "unexpected state")
+				}
+			}()
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+
+		if !(called) {
+			t.Errorf("Cleanup Function Wasn't Called Following a Panic")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		var e error = errors.New("uncalled")
+		var deadline bool
+
+		handler := cleanup.New().Settings(func(o *cleanup.Options) { o.Timeout = time.Second }).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cleanup.Register(r.Context(), func(ctx context.Context) error {
+				e = ctx.Err()
+				_, deadline = ctx.Deadline()
+
+				return nil
+			})
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		cancel()
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+		if e != nil {
+			t.Errorf("Cleanup Context Error = %v\n    - Expectation = <nil>", e)
+		}
+
+		if !(deadline) {
+			t.Errorf("Cleanup Context Missing Deadline")
+		}
+	})
+
+	t.Run("Late-Registration", func(t *testing.T) {
+		var ctx context.Context
+
+		handler := cleanup.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var called bool
+		if !(cleanup.Register(ctx, func(ctx context.Context) error { called = true; return nil })) || !(called) {
+			t.Errorf("Late Cleanup Function Wasn't Called Immediately")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if cleanup.Register(context.Background(), func(ctx context.Context) error { return nil }) {
+			t.Errorf("Unexpected Registration Without Middleware")
+		}
+	})
+}
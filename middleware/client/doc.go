@@ -0,0 +1,13 @@
+// Package client provides integration shims for outbound requests initiated within a middleware-managed request context.
+//
+// The [Client] middleware stores a [Valuer] in the request context. Outbound requests made through a [Transport] with that
+// context are traced via [net/http/httptrace]: their DNS, connect, TLS, and wait (time-to-first-byte) timings are recorded as
+// [Timing] values, and their connection events as [Event] values - suitable as span events via [Options.Event]. The timings are
+// summed and stamped onto the inbound request's response as Server-Timing entries:
+//
+//	Server-Timing: upstream-connect;dur=1.2, upstream-tls;dur=4.7, upstream-wait;dur=15.3
+//
+// [NewRequestWithContext] constructs outbound requests pre-populated with header(s) propagated from the inbound middleware
+// context - the propagation middleware's header bag, the trace, and the request identifier by default - via composable
+// [Propagator] values, including [Tenant] and [Signature] for tenant and internal signature propagation.
+package client
@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/client"
+)
+
+func Example() {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer upstream.Close()
+
+	outbound := &http.Client{Transport: new(client.Transport)}
+
+	middleware := middleware.New()
+
+	middleware.Add(client.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		// Outbound requests must carry the inbound request's context.
+		request, e := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := outbound.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Outbound Request(s): %d\n", len(client.Value(r.Context()).Timings()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	response, e := server.Client().Get(server.URL)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Server-Timing Entries: %v\n", len(response.Header.Values("Server-Timing")) > 0)
+
+	// Output:
+	// Outbound Request(s): 1
+	// Server-Timing Entries: true
+}
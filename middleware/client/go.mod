@@ -0,0 +1,19 @@
+module github.com/poly-gun/go-middleware/middleware/client
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace github.com/poly-gun/go-middleware/middleware/propagation => ../propagation
+
+replace github.com/poly-gun/go-middleware/middleware/requestid => ../requestid
+
+replace github.com/poly-gun/go-middleware/middleware/telemetrics => ../telemetrics
+
+require github.com/poly-gun/go-middleware/middleware/propagation v0.0.0
+
+require github.com/poly-gun/go-middleware/middleware/requestid v0.0.0
+
+require github.com/poly-gun/go-middleware/middleware/telemetrics v0.0.8
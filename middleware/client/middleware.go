@@ -0,0 +1,282 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "client"
+
+// Timing represents the connection phase durations of a single outbound request, as traced by the [Transport]. Zero durations
+// represent phases that didn't occur (e.g. reused connections skip DNS, connect, and TLS phases).
+type Timing struct {
+	// Host represents the outbound request's host.
+	Host string `json:"host"`
+
+	// DNS represents the duration of the DNS lookup.
+	DNS time.Duration `json:"dns"`
+
+	// Connect represents the duration of establishing the TCP connection.
+	Connect time.Duration `json:"connect"`
+
+	// TLS represents the duration of the TLS handshake.
+	TLS time.Duration `json:"tls"`
+
+	// Wait represents the duration between obtaining the connection and receiving the response's first byte.
+	Wait time.Duration `json:"wait"`
+
+	// Reused reports whether the outbound request used a previously established connection.
+	Reused bool `json:"reused"`
+}
+
+// Event represents a timestamped occurrence during an outbound request (e.g. "dns.start"), suitable as a span event.
+type Event struct {
+	// Name represents the event's name: one of "dns.start", "dns.done", "connect.start", "connect.done", "tls.start",
+	// "tls.done", "connection", or "first-byte".
+	Name string `json:"name"`
+
+	// Time represents the event's timestamp.
+	Time time.Time `json:"time"`
+
+	// Attributes represents the event's attributes (e.g. the resolved addresses, or an error).
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Valuer is the context return type relating to the [Client] middleware, and is safe for concurrent use. It collects the timings
+// and events of every outbound request made through a [Transport] with the inbound request's context. See the [Value] function
+// for additional details.
+type Valuer struct {
+	mutex   sync.Mutex
+	timings []Timing
+	events  []Event
+
+	event func(ctx context.Context, event Event)
+}
+
+// Timings returns a copy of the recorded outbound requests' timings.
+func (v *Valuer) Timings() []Timing {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return append([]Timing(nil), v.timings...)
+}
+
+// Events returns a copy of the recorded outbound requests' events.
+func (v *Valuer) Events() []Event {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return append([]Event(nil), v.events...)
+}
+
+// MarshalJSON encodes the [Valuer] as {"timings": [...], "events": [...]}.
+func (v *Valuer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Timings []Timing `json:"timings"`
+		Events  []Event  `json:"events"`
+	}{Timings: v.Timings(), Events: v.Events()})
+}
+
+// record appends an outbound request's event, forwarding it to the [Options.Event] hook.
+func (v *Valuer) record(ctx context.Context, event Event) {
+	v.mutex.Lock()
+	v.events = append(v.events, event)
+	v.mutex.Unlock()
+
+	if v.event != nil {
+		v.event(ctx, event)
+	}
+}
+
+// total returns the sums of the recorded timings' phases.
+func (v *Valuer) total() (total Timing) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for _, timing := range v.timings {
+		total.DNS += timing.DNS
+		total.Connect += timing.Connect
+		total.TLS += timing.TLS
+		total.Wait += timing.Wait
+	}
+
+	return
+}
+
+// Options represents the configuration settings for the [Client] middleware component.
+type Options struct {
+	// Prefix represents the prefix of the response's Server-Timing metric names (e.g. "upstream-dns"). Defaults to "upstream".
+	Prefix string
+
+	// ServerTiming enables stamping the response with Server-Timing entries - the sums of the outbound requests' DNS, connect,
+	// TLS, and wait phases - at the time its header(s) are written. Defaults to true.
+	ServerTiming bool
+
+	// Event optionally receives every outbound request [Event] as it occurs, e.g. to add span events to the inbound request's
+	// span. Defaults to nil.
+	Event func(ctx context.Context, event Event)
+}
+
+// Client represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Client struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Client] middleware's [Options] and returns the updated middleware instance.
+func (c *Client) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Prefix:       "upstream",
+			ServerTiming: true,
+			Event:        nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Prefix == "" {
+		slog.Warn("Invalid Client Prefix Specified - Using Default Prefix")
+
+		c.options.Prefix = "upstream"
+	}
+
+	return c
+}
+
+// stamp adds the outbound requests' Server-Timing entries to the response header(s).
+func (c *Client) stamp(valuer *Valuer, header http.Header) {
+	if len(valuer.Timings()) == 0 {
+		return
+	}
+
+	total := valuer.total()
+
+	phases := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"dns", total.DNS},
+		{"connect", total.Connect},
+		{"tls", total.TLS},
+		{"wait", total.Wait},
+	}
+
+	for _, phase := range phases {
+		if phase.duration > 0 {
+			header.Add("Server-Timing", fmt.Sprintf("%s-%s;dur=%s", c.options.Prefix, phase.name, strconv.FormatFloat(float64(phase.duration.Microseconds())/1000, 'f', -1, 64)))
+		}
+	}
+}
+
+// Handler stores a [Valuer] in the request context, collecting the timings and events of outbound requests made through a
+// [Transport] with the request's context, and stamps them onto the response as Server-Timing entries. It forwards the request to
+// the next handler in the chain.
+func (c *Client) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		valuer := &Valuer{event: c.options.Event}
+
+		ctx := context.WithValue(r.Context(), key, valuer)
+
+		capture := &writer{ResponseWriter: w}
+		if c.options.ServerTiming {
+			capture.stamp = func(header http.Header) {
+				c.stamp(valuer, header)
+			}
+		}
+
+		next.ServeHTTP(capture, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Client] middleware, implementing [middleware.Configurable]. If [Client.Settings] isn't called,
+// then the [Client.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Client)
+}
+
+// Value retrieves the request's [Valuer] from the provided context. If a nil value is returned, it can be assumed that the
+// [Client] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// writer stamps the Server-Timing entries prior to writing the response's header(s).
+type writer struct {
+	http.ResponseWriter
+
+	stamp   func(header http.Header)
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) && status >= 200 {
+		w.written = true
+
+		if w.stamp != nil {
+			w.stamp(w.ResponseWriter.Header())
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], stamping the Server-Timing entries before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Client] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Client)(nil)
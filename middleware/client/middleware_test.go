@@ -0,0 +1,281 @@
+package client_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/client"
+	"github.com/poly-gun/go-middleware/middleware/propagation"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		tls      bool
+		settings func(o *client.Options)
+		timing   []string
+		events   []string
+	}{
+		{
+			name:   "HTTP",
+			tls:    false,
+			timing: []string{"upstream-connect;dur=", "upstream-wait;dur="},
+			events: []string{"connect.start", "connect.done", "connection", "first-byte"},
+		},
+		{
+			name:   "HTTPS",
+			tls:    true,
+			timing: []string{"upstream-connect;dur=", "upstream-tls;dur=", "upstream-wait;dur="},
+			events: []string{"connect.start", "connect.done", "tls.start", "tls.done", "connection", "first-byte"},
+		},
+		{
+			name: "Custom-Prefix",
+			tls:  false,
+			settings: func(o *client.Options) {
+				o.Prefix = "backend"
+			},
+			timing: []string{"backend-connect;dur=", "backend-wait;dur="},
+			events: []string{"connect.start", "connect.done", "connection", "first-byte"},
+		},
+		{
+			name: "Server-Timing-Disabled",
+			tls:  false,
+			settings: func(o *client.Options) {
+				o.ServerTiming = false
+			},
+			timing: nil,
+			events: []string{"connect.start", "connect.done", "connection", "first-byte"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			if tt.tls {
+				upstream.StartTLS()
+			} else {
+				upstream.Start()
+			}
+
+			defer upstream.Close()
+
+			var mutex sync.Mutex
+			var hooked []string
+
+			outbound := &http.Client{Transport: &client.Transport{Base: upstream.Client().Transport}}
+
+			handler := client.New().Settings(func(o *client.Options) {
+				o.Event = func(ctx context.Context, event client.Event) {
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					hooked = append(hooked, event.Name)
+				}
+			}, tt.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request, e := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Request: %v", e)
+				}
+
+				response, e := outbound.Do(request)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Response: %v", e)
+				}
+
+				response.Body.Close()
+
+				timings := client.Value(r.Context()).Timings()
+				if len(timings) != 1 {
+					t.Fatalf("Timings = %d\n    - Expectation = %d", len(timings), 1)
+				}
+
+				if timings[0].Host != request.URL.Host {
+					t.Errorf("Host = %s\n    - Expectation = %s", timings[0].Host, request.URL.Host)
+				}
+
+				if timings[0].Reused {
+					t.Errorf("Reused = %v\n    - Expectation = %v", timings[0].Reused, false)
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			header := strings.Join(recorder.Header().Values("Server-Timing"), ", ")
+			for _, expectation := range tt.timing {
+				if !(strings.Contains(header, expectation)) {
+					t.Errorf("Server-Timing = %s\n    - Expectation = %s", header, expectation)
+				}
+			}
+
+			if len(tt.timing) == 0 && header != "" {
+				t.Errorf("Server-Timing = %s\n    - Expectation = %s", header, "")
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			for _, expectation := range tt.events {
+				found := false
+				for _, name := range hooked {
+					found = found || name == expectation
+				}
+
+				if !(found) {
+					t.Errorf("Events = %v\n    - Expectation = %s", hooked, expectation)
+				}
+			}
+		})
+	}
+
+	t.Run("Passthrough", func(t *testing.T) {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		defer upstream.Close()
+
+		outbound := &http.Client{Transport: new(client.Transport)}
+
+		response, e := outbound.Get(upstream.URL)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusNoContent)
+		}
+	})
+
+	t.Run("No-Outbound-Requests", func(t *testing.T) {
+		handler := client.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Values("Server-Timing"); len(v) != 0 {
+			t.Errorf("Server-Timing = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+
+	t.Run("Request", func(t *testing.T) {
+		const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+		secret := []byte("secret")
+
+		var outbound *http.Request
+
+		handler := propagation.New().Handler(telemetrics.New().Handler(requestid.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant := func(ctx context.Context) string { return "tenant-a" }
+
+			request, e := client.NewRequestWithContext(r.Context(), http.MethodPost, "http://localhost/orders?id=1", strings.NewReader("body"), append(client.Propagators(), client.Tenant("X-Tenant-ID", tenant), client.Signature("key-1", secret))...)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			if v, expectation := request.Header.Get("X-Request-ID"), requestid.Value(r.Context()); v == "" || v != expectation {
+				t.Errorf("X-Request-ID = %s\n    - Expectation = %s", v, expectation)
+			}
+
+			outbound = request
+		}))))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Traceparent", traceparent)
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if outbound == nil {
+			t.Fatalf("Outbound Request Wasn't Generated")
+		}
+
+		if v := outbound.Header.Get("Traceparent"); v != traceparent {
+			t.Errorf("Traceparent = %s\n    - Expectation = %s", v, traceparent)
+		}
+
+		if v := outbound.Header.Get("X-Tenant-ID"); v != "tenant-a" {
+			t.Errorf("X-Tenant-ID = %s\n    - Expectation = %s", v, "tenant-a")
+		}
+
+		if v := outbound.Header.Get("X-Signature-Key-ID"); v != "key-1" {
+			t.Errorf("X-Signature-Key-ID = %s\n    - Expectation = %s", v, "key-1")
+		}
+
+		digest := sha256.Sum256([]byte("body"))
+		if v, expectation := outbound.Header.Get("X-Content-SHA256"), hex.EncodeToString(digest[:]); v != expectation {
+			t.Errorf("X-Content-SHA256 = %s\n    - Expectation = %s", v, expectation)
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(outbound.Header.Get("X-Signature-Timestamp") + "\n" + http.MethodPost + "\n" + "/orders?id=1" + "\n" + hex.EncodeToString(digest[:])))
+
+		if v, expectation := outbound.Header.Get("X-Signature"), "v1="+hex.EncodeToString(mac.Sum(nil)); v != expectation {
+			t.Errorf("X-Signature = %s\n    - Expectation = %s", v, expectation)
+		}
+
+		if body, e := outbound.GetBody(); e != nil {
+			t.Errorf("Unexpected Error While Retrieving Body: %v", e)
+		} else {
+			body.Close()
+		}
+	})
+
+	t.Run("Request-Precedence", func(t *testing.T) {
+		var ctx context.Context
+
+		requestid.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		request, e := client.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/", nil, func(ctx context.Context, r *http.Request) error {
+			r.Header.Set("X-Request-ID", "explicit")
+
+			return nil
+		}, client.RequestID("X-Request-ID"))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Request: %v", e)
+		}
+
+		if v := request.Header.Get("X-Request-ID"); v != "explicit" {
+			t.Errorf("X-Request-ID = %s\n    - Expectation = %s", v, "explicit")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		buffer, e := json.Marshal(new(client.Valuer))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if v, expectation := string(buffer), `{"timings":null,"events":null}`; v != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", v, expectation)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := client.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/propagation"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// Propagator populates an outbound request with header(s) derived from the inbound middleware context. Propagators apply in
+// order; unless stated otherwise, they don't overwrite header(s) already set on the outbound request.
+type Propagator func(ctx context.Context, r *http.Request) error
+
+// Propagated returns a [Propagator] applying the propagation middleware's header bag.
+func Propagated() Propagator {
+	return func(ctx context.Context, r *http.Request) error {
+		for k, values := range propagation.Value(ctx) {
+			if _, found := r.Header[k]; !(found) {
+				r.Header[k] = append([]string(nil), values...)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Trace returns a [Propagator] applying the trace parsed by the telemetrics middleware, encoded in its original format.
+func Trace() Propagator {
+	return func(ctx context.Context, r *http.Request) error {
+		valuer := telemetrics.Value(ctx)
+		if valuer == nil || valuer.Trace == nil {
+			return nil
+		}
+
+		for k, values := range valuer.Trace.Header(valuer.Trace.Format) {
+			if _, found := r.Header[k]; !(found) {
+				r.Header[k] = values
+			}
+		}
+
+		return nil
+	}
+}
+
+// RequestID returns a [Propagator] applying the requestid middleware's identifier as the given header (e.g. "X-Request-ID").
+func RequestID(header string) Propagator {
+	return func(ctx context.Context, r *http.Request) error {
+		if value := requestid.Value(ctx); value != "" && r.Header.Get(header) == "" {
+			r.Header.Set(header, value)
+		}
+
+		return nil
+	}
+}
+
+// Tenant returns a [Propagator] applying the request's tenant identifier, as returned by tenant, as the given header (e.g.
+// "X-Tenant-ID"). Empty tenant identifiers aren't propagated.
+func Tenant(header string, tenant func(ctx context.Context) string) Propagator {
+	return func(ctx context.Context, r *http.Request) error {
+		if value := tenant(ctx); value != "" && r.Header.Get(header) == "" {
+			r.Header.Set(header, value)
+		}
+
+		return nil
+	}
+}
+
+// Signature returns a [Propagator] signing the outbound request for internal service-to-service authentication, overwriting
+// any existing signature header(s). The signature is the hex-encoded HMAC-SHA256, keyed by secret, of the request's
+// newline-delimited timestamp, method, request URI, and hex-encoded body SHA-256 digest. The following header(s) are set:
+//
+//   - "X-Signature-Key-ID": the identifier of the secret, if non-empty.
+//   - "X-Signature-Timestamp": the signing time, in unix seconds.
+//   - "X-Content-SHA256": the body's hex-encoded SHA-256 digest.
+//   - "X-Signature": the signature, prefixed with "v1=".
+//
+// Signature should be the final [Propagator], such that the signature covers the request as sent.
+func Signature(id string, secret []byte) Propagator {
+	return func(ctx context.Context, r *http.Request) error {
+		digest := sha256.New()
+
+		if r.Body != nil && r.Body != http.NoBody {
+			var body io.ReadCloser
+
+			if r.GetBody != nil {
+				reader, e := r.GetBody()
+				if e != nil {
+					return e
+				}
+
+				body = reader
+			} else {
+				buffer, e := io.ReadAll(r.Body)
+				r.Body.Close()
+				if e != nil {
+					return e
+				}
+
+				r.Body = io.NopCloser(bytes.NewReader(buffer))
+				r.GetBody = func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(buffer)), nil
+				}
+
+				body = io.NopCloser(bytes.NewReader(buffer))
+			}
+
+			_, e := io.Copy(digest, body)
+			body.Close()
+			if e != nil {
+				return e
+			}
+		}
+
+		sum := hex.EncodeToString(digest.Sum(nil))
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(timestamp + "\n" + r.Method + "\n" + r.URL.RequestURI() + "\n" + sum))
+
+		if id != "" {
+			r.Header.Set("X-Signature-Key-ID", id)
+		}
+
+		r.Header.Set("X-Signature-Timestamp", timestamp)
+		r.Header.Set("X-Content-SHA256", sum)
+		r.Header.Set("X-Signature", "v1="+hex.EncodeToString(mac.Sum(nil)))
+
+		return nil
+	}
+}
+
+// Propagators returns the default propagators: [Propagated], [Trace], and [RequestID] ("X-Request-ID").
+func Propagators() []Propagator {
+	return []Propagator{Propagated(), Trace(), RequestID("X-Request-ID")}
+}
+
+// NewRequestWithContext wraps [http.NewRequestWithContext], populating the outbound request with header(s) derived from the
+// inbound middleware context via the given propagators - [Propagators] if none are provided:
+//
+//	request, e := client.NewRequestWithContext(r.Context(), http.MethodGet, url, nil,
+//		append(client.Propagators(), client.Tenant("X-Tenant-ID", tenant), client.Signature("v1", secret))...)
+//
+// Combined with a [Transport], the outbound request's timings are additionally recorded.
+func NewRequestWithContext(ctx context.Context, method, url string, body io.Reader, propagators ...Propagator) (*http.Request, error) {
+	request, e := http.NewRequestWithContext(ctx, method, url, body)
+	if e != nil {
+		return nil, e
+	}
+
+	if len(propagators) == 0 {
+		propagators = Propagators()
+	}
+
+	for _, propagator := range propagators {
+		if propagator == nil {
+			continue
+		}
+
+		if e := propagator(ctx, request); e != nil {
+			return nil, e
+		}
+	}
+
+	return request, nil
+}
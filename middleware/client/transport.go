@@ -0,0 +1,180 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tracer records a single outbound request's phases.
+type tracer struct {
+	mutex  sync.Mutex
+	timing Timing
+	starts map[string]time.Time
+	got    time.Time
+}
+
+// start records the phase's start, retaining the earliest (e.g. for dual-stack dials).
+func (t *tracer) start(phase string, at time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, found := t.starts[phase]; !(found) {
+		t.starts[phase] = at
+	}
+}
+
+// done records the phase's duration since its start.
+func (t *tracer) done(phase string, at time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	start, found := t.starts[phase]
+	if !(found) {
+		return
+	}
+
+	switch phase {
+	case "dns":
+		t.timing.DNS = at.Sub(start)
+	case "connect":
+		t.timing.Connect = at.Sub(start)
+	case "tls":
+		t.timing.TLS = at.Sub(start)
+	}
+}
+
+// attributes returns an event's attributes from key-value pairs, including the error if non-nil.
+func attributes(e error, pairs ...string) map[string]string {
+	mapping := make(map[string]string, len(pairs)/2+1)
+	for index := 0; index+1 < len(pairs); index += 2 {
+		mapping[pairs[index]] = pairs[index+1]
+	}
+
+	if e != nil {
+		mapping["error"] = e.Error()
+	}
+
+	return mapping
+}
+
+// Transport is an [http.RoundTripper] tracing outbound requests via [httptrace.ClientTrace]. When the outbound request's context
+// carries a [Valuer] - i.e. the request was initiated within a [Client] middleware-managed request context - its DNS, connect,
+// TLS, and wait timings are recorded as a [Timing], and its connection events as [Event] values. Requests without a [Valuer] are
+// passed through as-is. Client traces already present on the outbound request's context continue to receive their callbacks.
+type Transport struct {
+	// Base represents the underlying [http.RoundTripper]. Defaults to [http.DefaultTransport].
+	Base http.RoundTripper
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx := request.Context()
+
+	valuer, ok := ctx.Value(key).(*Valuer)
+	if !(ok) || valuer == nil {
+		return base.RoundTrip(request)
+	}
+
+	state := &tracer{timing: Timing{Host: request.URL.Host}, starts: make(map[string]time.Time)}
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(information httptrace.DNSStartInfo) {
+			now := time.Now()
+
+			state.start("dns", now)
+
+			valuer.record(ctx, Event{Name: "dns.start", Time: now, Attributes: attributes(nil, "host", information.Host)})
+		},
+		DNSDone: func(information httptrace.DNSDoneInfo) {
+			now := time.Now()
+
+			state.done("dns", now)
+
+			addresses := make([]string, 0, len(information.Addrs))
+			for _, address := range information.Addrs {
+				addresses = append(addresses, address.String())
+			}
+
+			valuer.record(ctx, Event{Name: "dns.done", Time: now, Attributes: attributes(information.Err, "addresses", strings.Join(addresses, ","))})
+		},
+		ConnectStart: func(network, address string) {
+			now := time.Now()
+
+			state.start("connect", now)
+
+			valuer.record(ctx, Event{Name: "connect.start", Time: now, Attributes: attributes(nil, "network", network, "address", address)})
+		},
+		ConnectDone: func(network, address string, e error) {
+			now := time.Now()
+
+			if e == nil {
+				state.done("connect", now)
+			}
+
+			valuer.record(ctx, Event{Name: "connect.done", Time: now, Attributes: attributes(e, "network", network, "address", address)})
+		},
+		TLSHandshakeStart: func() {
+			now := time.Now()
+
+			state.start("tls", now)
+
+			valuer.record(ctx, Event{Name: "tls.start", Time: now})
+		},
+		TLSHandshakeDone: func(connection tls.ConnectionState, e error) {
+			now := time.Now()
+
+			state.done("tls", now)
+
+			valuer.record(ctx, Event{Name: "tls.done", Time: now, Attributes: attributes(e, "version", tls.VersionName(connection.Version))})
+		},
+		GotConn: func(information httptrace.GotConnInfo) {
+			now := time.Now()
+
+			state.mutex.Lock()
+			state.got = now
+			state.timing.Reused = information.Reused
+			state.mutex.Unlock()
+
+			valuer.record(ctx, Event{Name: "connection", Time: now, Attributes: attributes(nil, "reused", strconv.FormatBool(information.Reused))})
+		},
+		GotFirstResponseByte: func() {
+			now := time.Now()
+
+			state.mutex.Lock()
+			if !(state.got.IsZero()) {
+				state.timing.Wait = now.Sub(state.got)
+			}
+			state.mutex.Unlock()
+
+			valuer.record(ctx, Event{Name: "first-byte", Time: now})
+		},
+	}
+
+	// A RoundTripper mustn't modify the caller's request.
+	clone := request.WithContext(httptrace.WithClientTrace(ctx, trace))
+
+	response, e := base.RoundTrip(clone)
+
+	state.mutex.Lock()
+	timing := state.timing
+	state.mutex.Unlock()
+
+	valuer.mutex.Lock()
+	valuer.timings = append(valuer.timings, timing)
+	valuer.mutex.Unlock()
+
+	return response, e
+}
+
+// Runtime assurance that [Transport] satisfies the [http.RoundTripper] interface.
+var _ http.RoundTripper = (*Transport)(nil)
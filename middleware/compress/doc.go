@@ -0,0 +1,13 @@
+// Package compress provides response compression middleware, negotiating the request's Accept-Encoding header against the
+// supported content-codings - [Gzip] and [Deflate] by default - and encoding compressible responses.
+//
+// Responses are buffered until the minimum compressible size is reached, then encoded; smaller responses, incompressible media
+// types, partial content, and already-encoded responses are written as is. Additional content-codings, such as Brotli, are
+// supported through an [Encoding] wrapping a third-party encoder:
+//
+//	compress.New().Settings(func(o *compress.Options) {
+//		o.Encodings = append([]compress.Encoding{{Name: "br", Writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+//			return brotli.NewWriterLevel(w, brotli.DefaultCompression), nil
+//		}}}, o.Encodings...)
+//	})
+package compress
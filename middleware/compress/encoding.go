@@ -0,0 +1,85 @@
+package compress
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Encoding represents a content-coding and its encoder.
+type Encoding struct {
+	// Name represents the content-coding's Accept-Encoding and Content-Encoding token (e.g. "gzip").
+	Name string
+
+	// Writer returns an encoder writing to w at the given compression level. Encoders must treat out-of-range levels as their
+	// default level.
+	Writer func(w io.Writer, level int) (io.WriteCloser, error)
+}
+
+var (
+	// Gzip represents the "gzip" content-coding.
+	Gzip = Encoding{Name: "gzip", Writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			level = gzip.DefaultCompression
+		}
+
+		return gzip.NewWriterLevel(w, level)
+	}}
+
+	// Deflate represents the "deflate" content-coding - a zlib-less DEFLATE stream, as produced by most servers.
+	Deflate = Encoding{Name: "deflate", Writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level < flate.HuffmanOnly || level > flate.BestCompression {
+			level = flate.DefaultCompression
+		}
+
+		return flate.NewWriter(w, level)
+	}}
+)
+
+// negotiate returns the acceptable encoding with the highest Accept-Encoding quality, preferring earlier encodings on ties. A
+// nil value represents the identity encoding.
+func negotiate(header string, encodings []Encoding) *Encoding {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		token, parameters, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		quality := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(parameters), "q="); found {
+			if q, e := strconv.ParseFloat(v, 64); e == nil {
+				quality = q
+			}
+		}
+
+		if token = strings.ToLower(strings.TrimSpace(token)); token != "" {
+			qualities[token] = quality
+		}
+	}
+
+	type candidate struct {
+		encoding *Encoding
+		quality  float64
+	}
+
+	var candidates []candidate
+	for index := range encodings {
+		quality, found := qualities[strings.ToLower(encodings[index].Name)]
+		if !(found) {
+			quality, found = qualities["*"]
+		}
+
+		if found && quality > 0 {
+			candidates = append(candidates, candidate{encoding: &encodings[index], quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+
+	return candidates[0].encoding
+}
@@ -0,0 +1,74 @@
+package compress_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/compress"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(compress.New().Settings(func(o *compress.Options) {
+		o.Minimum = 256
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(strings.Repeat("a", 1024)))
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	// Setting the header explicitly disables the transport's transparent decompression.
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	reader, e := gzip.NewReader(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while decoding response: %w", e)
+
+		panic(e)
+	}
+
+	body, e := io.ReadAll(reader)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading response: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("Content-Encoding: %s, Length: %d\n", response.Header.Get("Content-Encoding"), len(body))
+
+	// Output:
+	// Content-Encoding: gzip, Length: 1024
+}
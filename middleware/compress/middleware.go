@@ -0,0 +1,311 @@
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "compress"
+
+// Options represents the configuration settings for the [Compress] middleware component.
+type Options struct {
+	// Encodings represents the supported content-codings, in order of server preference for equally-weighted Accept-Encoding
+	// tokens. Additional codings (e.g. "br") can be supported by providing an [Encoding] wrapping a third-party encoder.
+	// Defaults to [Gzip] and [Deflate].
+	Encodings []Encoding
+
+	// Level represents the encoders' compression level. Defaults to -1, each encoder's default level.
+	Level int
+
+	// Minimum represents the minimum response body size, in bytes, for compression. Smaller responses are written as is.
+	// Defaults to 1024.
+	Minimum int
+
+	// Types represents the allowlist of compressible media types. Entries ending in "/*" match the type's subtypes, and entries
+	// beginning with "*+" match structured syntax suffixes. Defaults to common textual types (e.g. "text/*", "application/json").
+	Types []string
+}
+
+// Compress represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Compress struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Compress] middleware's [Options] and returns the updated middleware instance.
+func (c *Compress) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Encodings: []Encoding{Gzip, Deflate},
+			Level:     -1,
+			Minimum:   1024,
+			Types: []string{
+				"text/*",
+				"application/json",
+				"application/javascript",
+				"application/xml",
+				"application/wasm",
+				"image/svg+xml",
+				"*+json",
+				"*+xml",
+			},
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Minimum < 0 {
+		slog.Warn("Invalid Compress Minimum Specified - Using Default Minimum")
+
+		c.options.Minimum = 1024
+	}
+
+	return c
+}
+
+// compressible reports whether the content type matches the [Options.Types] allowlist.
+func (c *Compress) compressible(content string) bool {
+	mediatype, _, e := mime.ParseMediaType(content)
+	if e != nil {
+		return false
+	}
+
+	for _, v := range c.options.Types {
+		switch {
+		case strings.HasSuffix(v, "/*"):
+			if strings.HasPrefix(mediatype, strings.TrimSuffix(v, "*")) {
+				return true
+			}
+		case strings.HasPrefix(v, "*+"):
+			if strings.HasSuffix(mediatype, v[1:]) {
+				return true
+			}
+		case mediatype == v:
+			return true
+		}
+	}
+
+	return false
+}
+
+// writer defers the compression decision until [Options.Minimum] bytes are written, the response is flushed, or the handler
+// returns; thereafter, the response is either encoded or passed through.
+type writer struct {
+	http.ResponseWriter
+
+	compress *Compress
+	encoding *Encoding
+
+	status  int
+	written bool // written represents whether the handler wrote the response's header(s).
+	decided bool // decided represents whether the compression decision was made, and the header(s) forwarded.
+	buffer  bytes.Buffer
+	encoder io.WriteCloser
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written, w.status = true, status
+
+	// Informational responses aren't buffered.
+	if status >= 100 && status < 200 {
+		w.written = false
+
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(p)
+		}
+
+		return w.ResponseWriter.Write(p)
+	}
+
+	n, _ := w.buffer.Write(p)
+	if w.buffer.Len() >= w.compress.options.Minimum {
+		if e := w.decide(true); e != nil {
+			return 0, e
+		}
+	}
+
+	return n, nil
+}
+
+// decide determines whether to encode the response, forwards the response's header(s), and writes the buffered body. The
+// sufficient argument reports whether the body satisfies, or may yet satisfy, the [Options.Minimum] size.
+func (w *writer) decide(sufficient bool) error {
+	w.decided = true
+
+	header := w.ResponseWriter.Header()
+
+	if header.Get("Content-Type") == "" && w.buffer.Len() > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buffer.Bytes()))
+	}
+
+	eligible := sufficient && w.status != http.StatusNoContent && w.status != http.StatusNotModified && w.status != http.StatusPartialContent
+	eligible = eligible && header.Get("Content-Encoding") == "" && header.Get("Content-Range") == "" && w.compress.compressible(header.Get("Content-Type"))
+
+	if eligible {
+		encoder, e := w.encoding.Writer(w.ResponseWriter, w.compress.options.Level)
+		if e != nil {
+			slog.Warn("Unable to Create Response Encoder - Writing Unencoded Response", slog.String("encoding", w.encoding.Name), slog.String("error", e.Error()))
+		} else {
+			w.encoder = encoder
+
+			header.Set("Content-Encoding", w.encoding.Name)
+			header.Del("Content-Length")
+
+			if etag := header.Get("ETag"); etag != "" && !(strings.HasPrefix(etag, "W/")) {
+				header.Set("ETag", "W/"+etag)
+			}
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	defer w.buffer.Reset()
+
+	if w.encoder != nil {
+		_, e := w.encoder.Write(w.buffer.Bytes())
+
+		return e
+	}
+
+	_, e := w.ResponseWriter.Write(w.buffer.Bytes())
+
+	return e
+}
+
+// Flush commits the compression decision - encoding any compressible response irrespective of its size - and flushes the
+// encoder and the underlying [http.ResponseWriter].
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !(w.decided) {
+		w.decide(true)
+	}
+
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// close finalizes the response, writing any buffered body and closing the encoder.
+func (w *writer) close() {
+	if !(w.written) {
+		return
+	}
+
+	if !(w.decided) {
+		w.decide(w.buffer.Len() >= w.compress.options.Minimum)
+	}
+
+	if w.encoder != nil {
+		if e := w.encoder.Close(); e != nil {
+			slog.Warn("Unable to Close Response Encoder", slog.String("encoding", w.encoding.Name), slog.String("error", e.Error()))
+		}
+	}
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler negotiates the request's Accept-Encoding header against the [Options.Encodings], and encodes compressible responses
+// of at least [Options.Minimum] bytes. Responses vary on Accept-Encoding, and the negotiated encoding is stored in the request
+// context.
+func (c *Compress) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiate(r.Header.Get("Accept-Encoding"), c.options.Encodings)
+		if encoding == nil || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, "")))
+
+			return
+		}
+
+		wrapper := &writer{ResponseWriter: w, compress: c, encoding: encoding}
+
+		defer wrapper.close()
+
+		next.ServeHTTP(wrapper, r.WithContext(context.WithValue(ctx, key, encoding.Name)))
+	})
+}
+
+// New creates a new instance of the [Compress] middleware, implementing [middleware.Configurable]. If [Compress.Settings] isn't
+// called, then the [Compress.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Compress)
+}
+
+// Value retrieves the request's negotiated content-coding from the provided context. An empty string represents the identity
+// encoding, or that the [Compress] middleware isn't enabled for the particular caller's chain. Responses below the
+// [Options.Minimum] size, or of an incompressible type, are written unencoded irrespective of the negotiated encoding.
+func Value(ctx context.Context) (value string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Compress] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Compress)(nil)
@@ -0,0 +1,192 @@
+package compress_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/compress"
+)
+
+func Test(t *testing.T) {
+	payload := strings.Repeat("compressible payload ", 128)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Encoding", compress.Value(r.Context()))
+
+		switch r.URL.Path {
+		case "/small":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte("small"))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(payload))
+		case "/encoded":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write([]byte(payload))
+		case "/problem":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(payload))
+		case "/sniffed":
+			w.Write([]byte("<html><body>" + payload + "</body></html>"))
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Set("Content-Length", "2688")
+			for index := 0; index < 8; index++ {
+				w.Write([]byte(payload[:len(payload)/8]))
+			}
+		}
+	})
+
+	tests := []struct {
+		name     string
+		options  func(o *compress.Options)
+		path     string
+		accept   string
+		value    string
+		encoding string
+		status   int
+		etag     string
+	}{
+		{name: "Gzip", path: "/", accept: "gzip, deflate", value: "gzip", encoding: "gzip", status: http.StatusOK},
+		{name: "Deflate-Preferred", path: "/", accept: "gzip;q=0.5, deflate", value: "deflate", encoding: "deflate", status: http.StatusOK},
+		{name: "Wildcard", path: "/", accept: "*", value: "gzip", encoding: "gzip", status: http.StatusOK},
+		{name: "Excluded", path: "/", accept: "gzip;q=0, deflate;q=0", value: "", encoding: "", status: http.StatusOK},
+		{name: "Identity", path: "/", accept: "", value: "", encoding: "", status: http.StatusOK},
+		{name: "Unsupported", path: "/", accept: "br", value: "", encoding: "", status: http.StatusOK},
+		{name: "Below-Minimum", path: "/small", accept: "gzip", value: "gzip", encoding: "", status: http.StatusOK},
+		{name: "Incompressible-Type", path: "/image", accept: "gzip", value: "gzip", encoding: "", status: http.StatusOK},
+		{name: "Already-Encoded", path: "/encoded", accept: "deflate", value: "deflate", encoding: "gzip", status: http.StatusOK},
+		{name: "Structured-Suffix", path: "/problem", accept: "gzip", value: "gzip", encoding: "gzip", status: http.StatusBadRequest, etag: `W/"v1"`},
+		{name: "Sniffed-Type", path: "/sniffed", accept: "gzip", value: "gzip", encoding: "gzip", status: http.StatusOK},
+		{name: "No-Content", path: "/empty", accept: "gzip", value: "gzip", encoding: "", status: http.StatusNoContent},
+		{name: "Custom-Types", options: func(o *compress.Options) {
+			o.Types = []string{"image/png"}
+		}, path: "/image", accept: "gzip", value: "gzip", encoding: "gzip", status: http.StatusOK},
+		{name: "Custom-Minimum", options: func(o *compress.Options) {
+			o.Minimum = 0
+		}, path: "/small", accept: "gzip", value: "gzip", encoding: "gzip", status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := compress.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			request.Header.Set("Accept-Encoding", test.accept)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("X-Encoding"); v != test.value {
+				t.Errorf("Value = %s\n    - Expectation = %s", v, test.value)
+			}
+
+			if v := recorder.Header().Get("Content-Encoding"); v != test.encoding {
+				t.Errorf("Content-Encoding = %s\n    - Expectation = %s", v, test.encoding)
+			}
+
+			if v := recorder.Header().Get("Vary"); v != "Accept-Encoding" {
+				t.Errorf("Vary = %s\n    - Expectation = %s", v, "Accept-Encoding")
+			}
+
+			if test.etag != "" {
+				if v := recorder.Header().Get("ETag"); v != test.etag {
+					t.Errorf("ETag = %s\n    - Expectation = %s", v, test.etag)
+				}
+			}
+
+			var reader io.Reader = recorder.Body
+			switch {
+			case test.encoding == "gzip" && test.path != "/encoded":
+				if v := recorder.Header().Get("Content-Length"); v != "" {
+					t.Errorf("Unexpected Content-Length Header: %s", v)
+				}
+
+				gz, e := gzip.NewReader(recorder.Body)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Decoding Gzip Response: %v", e)
+				}
+
+				reader = gz
+			case test.encoding == "deflate":
+				reader = flate.NewReader(recorder.Body)
+			}
+
+			body, e := io.ReadAll(reader)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Reading Response: %v", e)
+			}
+
+			if test.status != http.StatusNoContent && !(bytes.Contains(body, []byte("small"))) && !(bytes.Contains(body, []byte("compressible payload"))) {
+				t.Errorf("Unexpected Response Body: %q", body)
+			}
+		})
+	}
+
+	t.Run("Flush", func(t *testing.T) {
+		m := compress.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("data: event\n\n"))
+
+			http.NewResponseController(w).Flush()
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		if !(recorder.Flushed) {
+			t.Errorf("Expected Flushed Response")
+		}
+
+		if v := recorder.Header().Get("Content-Encoding"); v != "gzip" {
+			t.Errorf("Content-Encoding = %s\n    - Expectation = %s", v, "gzip")
+		}
+	})
+
+	t.Run("Head", func(t *testing.T) {
+		m := compress.New().Handler(handler)
+
+		request := httptest.NewRequest(http.MethodHead, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		if v := recorder.Header().Get("Content-Encoding"); v != "" {
+			t.Errorf("Unexpected Content-Encoding Header: %s", v)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := compress.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "gzip")
+		if v := compress.Value(ctx); v != "gzip" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
@@ -0,0 +1,12 @@
+// Package connstats provides middleware that counts the bytes read and written per request, and per connection, without relying
+// on infrastructure-level (e.g. eBPF) monitoring.
+//
+// Request-level counts are measured by wrapping the request body and [http.ResponseWriter]. Connection-level counts are available
+// when the server's listener is wrapped with the root module's listener.Counting function, and the server's ConnContext field is
+// assigned listener.ConnContext.
+//
+// Counts are rolled up by route and client - subject to a cardinality limit - and exposed as JSON via [Stats.Endpoint]. Routes
+// are derived from the [route] middleware, which should precede the [Stats] middleware in the chain.
+//
+// [route]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/route
+package connstats
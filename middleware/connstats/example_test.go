@@ -0,0 +1,62 @@
+package connstats_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/listener"
+	"github.com/poly-gun/go-middleware/middleware/connstats"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /upload", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+
+		w.WriteHeader(http.StatusOK)
+
+		io.WriteString(w, "stored")
+		return
+	})
+
+	stats := connstats.New()
+
+	middleware := middleware.New()
+
+	middleware.Add(route.New().Settings(func(o *route.Options) { o.Mux = mux }).Handler)
+	middleware.Add(stats.Handler)
+
+	server := httptest.NewUnstartedServer(middleware.Handler(mux))
+
+	// Wrap the listener for connection-level byte counts.
+	server.Listener = listener.Counting(server.Listener)
+	server.Config.ConnContext = listener.ConnContext
+
+	server.Start()
+
+	defer server.Close()
+
+	response, e := server.Client().Post(server.URL+"/upload", "text/plain", strings.NewReader("0123456789"))
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	io.Copy(io.Discard, response.Body)
+
+	response.Body.Close()
+
+	for _, rollup := range stats.Report(false) {
+		fmt.Printf("Route: %s, Requests: %d, Received: %d, Sent: %d\n", rollup.Route, rollup.Requests, rollup.Received, rollup.Sent)
+	}
+
+	// Output:
+	// Route: POST /upload, Requests: 1, Received: 10, Sent: 6
+}
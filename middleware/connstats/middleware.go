@@ -0,0 +1,352 @@
+package connstats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/listener"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "connstats"
+
+const (
+	defaultLimit    = 1000
+	defaultOverflow = "other"
+)
+
+// Valuer is the context return type relating to the [Stats] middleware, and is safe for concurrent use. See the [Value] function
+// for additional details.
+type Valuer struct {
+	received atomic.Int64
+	sent     atomic.Int64
+
+	// connection represents the request's counting connection, if available.
+	connection *listener.Counter
+}
+
+// Received returns the number of request body bytes read thus far.
+func (v *Valuer) Received() int64 {
+	return v.received.Load()
+}
+
+// Sent returns the number of response body bytes written thus far.
+func (v *Valuer) Sent() int64 {
+	return v.sent.Load()
+}
+
+// Connection returns the cumulative bytes read from, and written to, the request's underlying connection - including protocol
+// overhead, and any prior requests on a persistent connection. The ok return value is false if the server's listener isn't wrapped
+// with listener.Counting, or its ConnContext field isn't listener.ConnContext.
+func (v *Valuer) Connection() (read, written int64, ok bool) {
+	if v.connection == nil {
+		return 0, 0, false
+	}
+
+	return v.connection.BytesRead(), v.connection.BytesWritten(), true
+}
+
+// MarshalJSON encodes the [Valuer]'s request body byte counts, and - if available - its connection's cumulative byte counts.
+func (v *Valuer) MarshalJSON() ([]byte, error) {
+	type connection struct {
+		Read    int64 `json:"read"`
+		Written int64 `json:"written"`
+	}
+
+	value := struct {
+		Received   int64       `json:"received"`
+		Sent       int64       `json:"sent"`
+		Connection *connection `json:"connection,omitempty"`
+	}{Received: v.Received(), Sent: v.Sent()}
+
+	if read, written, ok := v.Connection(); ok {
+		value.Connection = &connection{Read: read, Written: written}
+	}
+
+	return json.Marshal(value)
+}
+
+// Rollup represents aggregated byte counts of a single route and client.
+type Rollup struct {
+	// Route represents the route template.
+	Route string `json:"route"`
+
+	// Client represents the client address.
+	Client string `json:"client"`
+
+	// Requests represents the number of requests.
+	Requests int64 `json:"requests"`
+
+	// Received represents the sum of request body bytes read.
+	Received int64 `json:"received"`
+
+	// Sent represents the sum of response body bytes written.
+	Sent int64 `json:"sent"`
+}
+
+// Options represents the configuration settings for the [Stats] middleware component.
+type Options struct {
+	// Route returns the route a request's counts are rolled up by. Defaults to a function returning the [route] middleware's value.
+	//
+	// [route]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/route
+	Route func(r *http.Request) string
+
+	// Client returns the client a request's counts are rolled up by. Defaults to a function returning the host component of
+	// [http.Request.RemoteAddr].
+	Client func(r *http.Request) string
+
+	// Limit represents the maximum number of distinct route-client rollups. Once exceeded, counts of previously unseen pairs
+	// are rolled up with a route and client of [Options.Overflow]. Defaults to 1000.
+	Limit int
+
+	// Overflow represents the route and client value assigned to rollups exceeding [Options.Limit]. Defaults to "other".
+	Overflow string
+
+	// Level specifies the log level used to record each request's counts. A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+}
+
+// Stats represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Stats struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex   sync.Mutex
+	rollups map[[2]string]*Rollup
+}
+
+// Settings applies configuration functions to modify the [Stats] middleware's [Options] and returns the updated middleware instance.
+func (s *Stats) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if s.options == nil {
+		s.options = &Options{
+			Route: func(r *http.Request) string {
+				return route.Value(r.Context())
+			},
+			Client: func(r *http.Request) string {
+				if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+					return v
+				}
+
+				return r.RemoteAddr
+			},
+			Limit:    defaultLimit,
+			Overflow: defaultOverflow,
+			Level:    nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(s.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if s.options.Limit <= 0 {
+		slog.Warn("Invalid Connection-Stats Limit Specified - Using Default Limit")
+
+		s.options.Limit = defaultLimit
+	}
+
+	if s.options.Overflow == "" {
+		s.options.Overflow = defaultOverflow
+	}
+
+	return s
+}
+
+// aggregate rolls up a request's counts, enforcing the cardinality limit.
+func (s *Stats) aggregate(route, client string, received, sent int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.rollups == nil {
+		s.rollups = make(map[[2]string]*Rollup)
+	}
+
+	k := [2]string{route, client}
+
+	rollup, ok := s.rollups[k]
+	if !(ok) {
+		if len(s.rollups) >= s.options.Limit {
+			k = [2]string{s.options.Overflow, s.options.Overflow}
+		}
+
+		if rollup, ok = s.rollups[k]; !(ok) {
+			rollup = &Rollup{Route: k[0], Client: k[1]}
+
+			s.rollups[k] = rollup
+		}
+	}
+
+	rollup.Requests++
+	rollup.Received += received
+	rollup.Sent += sent
+}
+
+// Report returns a snapshot of the rollups, ordered by route and client. If reset is true, the rollups are cleared.
+func (s *Stats) Report(reset bool) []Rollup {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rollups := make([]Rollup, 0, len(s.rollups))
+	for _, rollup := range s.rollups {
+		rollups = append(rollups, *rollup)
+	}
+
+	if reset {
+		s.rollups = nil
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Route != rollups[j].Route {
+			return rollups[i].Route < rollups[j].Route
+		}
+
+		return rollups[i].Client < rollups[j].Client
+	})
+
+	return rollups
+}
+
+// Endpoint returns an [http.Handler] responding with [Stats.Report] as JSON. A "reset" query parameter of "true" clears the rollups.
+func (s *Stats) Endpoint() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reset, _ := strconv.ParseBool(r.URL.Query().Get("reset"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(s.Report(reset))
+	})
+}
+
+// Handler counts each request's body bytes read and response bytes written, storing the live counts in the request context, and
+// rolling them up by route and client. It forwards the request to the next handler in the chain.
+func (s *Stats) Handler(next http.Handler) http.Handler {
+	s.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := new(Valuer)
+		if c, ok := listener.Conn(ctx).(*listener.Counter); ok {
+			valuer.connection = c
+		}
+
+		ctx = context.WithValue(ctx, key, valuer)
+
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &reader{ReadCloser: r.Body, valuer: valuer}
+		}
+
+		next.ServeHTTP(&writer{ResponseWriter: w, valuer: valuer}, r.WithContext(ctx))
+
+		var route, client string
+		if s.options.Route != nil {
+			route = s.options.Route(r.WithContext(ctx))
+		}
+
+		if s.options.Client != nil {
+			client = s.options.Client(r)
+		}
+
+		s.aggregate(route, client, valuer.Received(), valuer.Sent())
+
+		if v := s.options.Level; v != nil {
+			slog.Log(ctx, v.Level(), "Connection-Stats Request Byte Count(s)", slog.String("route", route), slog.String("client", client), slog.Int64("received", valuer.Received()), slog.Int64("sent", valuer.Sent()))
+		}
+	})
+}
+
+// New creates a new instance of the [Stats] middleware, implementing [middleware.Configurable]. If [Stats.Settings] isn't called,
+// then the [Stats.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to serve [Stats.Endpoint], or to call [Stats.Report].
+func New() *Stats {
+	return new(Stats)
+}
+
+// Value retrieves the request's live byte counts from the provided context. If a nil value is returned, it can be assumed that
+// the [Stats] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// reader counts the request body's bytes read.
+type reader struct {
+	io.ReadCloser
+
+	valuer *Valuer
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, e := r.ReadCloser.Read(p)
+
+	r.valuer.received.Add(int64(n))
+
+	return n, e
+}
+
+// writer counts the response body's bytes written.
+type writer struct {
+	http.ResponseWriter
+
+	valuer *Valuer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, e := w.ResponseWriter.Write(p)
+
+	w.valuer.sent.Add(int64(n))
+
+	return n, e
+}
+
+// Flush implements [http.Flusher], flushing the underlying [http.ResponseWriter] if supported.
+func (w *writer) Flush() {
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. Bytes written to a hijacked connection aren't counted.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Stats] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Stats)(nil)
@@ -0,0 +1,160 @@
+package connstats_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/listener"
+	"github.com/poly-gun/go-middleware/middleware/connstats"
+)
+
+func Test(t *testing.T) {
+	// handler echoes the request body, reporting the request's counts via response header(s).
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		valuer := connstats.Value(r.Context())
+
+		_, _, ok := valuer.Connection()
+
+		w.Header().Set("X-Connection-Counted", strconv.FormatBool(ok))
+		w.Header().Set("X-Received", strconv.FormatInt(valuer.Received(), 10))
+
+		w.WriteHeader(http.StatusOK)
+
+		w.Write(body)
+	})
+
+	// route returns a static route, avoiding a dependency on the route middleware.
+	route := func(r *http.Request) string { return "echo" }
+
+	t.Run("Request-Counts", func(t *testing.T) {
+		stats := connstats.New()
+
+		server := httptest.NewServer(stats.Settings(func(o *connstats.Options) { o.Route = route }).Handler(handler))
+
+		defer server.Close()
+
+		for _, body := range []string{"hello", "world!"} {
+			response, e := server.Client().Post(server.URL, "text/plain", strings.NewReader(body))
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			io.Copy(io.Discard, response.Body)
+
+			response.Body.Close()
+
+			if v, expectation := response.Header.Get("X-Received"), strconv.Itoa(len(body)); v != expectation {
+				t.Errorf("Received = %s\n    - Expectation = %s", v, expectation)
+			}
+
+			if v := response.Header.Get("X-Connection-Counted"); v != "false" {
+				t.Errorf("Unexpected Connection-Level Counts Without a Counting Listener")
+			}
+		}
+
+		report := stats.Report(false)
+		if len(report) != 1 {
+			t.Fatalf("Report = %+v", report)
+		}
+
+		expectation := connstats.Rollup{Route: "echo", Client: "127.0.0.1", Requests: 2, Received: 11, Sent: 11}
+		if report[0] != expectation {
+			t.Errorf("Rollup = %+v\n    - Expectation = %+v", report[0], expectation)
+		}
+	})
+
+	t.Run("Connection-Counts", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(connstats.New().Settings(func(o *connstats.Options) { o.Route = route }).Handler(handler))
+
+		server.Listener = listener.Counting(server.Listener)
+		server.Config.ConnContext = listener.ConnContext
+
+		server.Start()
+
+		defer server.Close()
+
+		response, e := server.Client().Post(server.URL, "text/plain", strings.NewReader("payload"))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if v := response.Header.Get("X-Connection-Counted"); v != "true" {
+			t.Errorf("Expected Connection-Level Counts With a Counting Listener")
+		}
+	})
+
+	t.Run("Cardinality-Limit", func(t *testing.T) {
+		stats := connstats.New()
+
+		clients := []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}
+
+		m := stats.Settings(func(o *connstats.Options) {
+			o.Route = route
+			o.Limit = 1
+		}).Handler(handler)
+
+		for _, client := range clients {
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+			request.RemoteAddr = net.JoinHostPort(client, "443")
+
+			m.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		stats.Endpoint().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?reset=true", nil))
+
+		var rollups []connstats.Rollup
+		if e := json.NewDecoder(recorder.Body).Decode(&rollups); e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+		}
+
+		expectations := []connstats.Rollup{
+			{Route: "echo", Client: "192.0.2.1", Requests: 1, Received: 1, Sent: 1},
+			{Route: "other", Client: "other", Requests: 2, Received: 2, Sent: 2},
+		}
+
+		if len(rollups) != len(expectations) || rollups[0] != expectations[0] || rollups[1] != expectations[1] {
+			t.Errorf("Rollups = %+v\n    - Expectation = %+v", rollups, expectations)
+		}
+
+		if v := stats.Report(false); len(v) != 0 {
+			t.Errorf("Expected the Rollups to be Reset: %+v", v)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		serialized, e := json.Marshal(new(connstats.Valuer))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if expectation := `{"received":0,"sent":0}`; string(serialized) != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := connstats.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		valuer := new(connstats.Valuer)
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", valuer)
+		if v := connstats.Value(ctx); v != valuer {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+// Package consent provides consent management integration middleware, decoding a client's consent signal - a cookie or header,
+// such as an IAB TCF v2 consent string - into granted purposes exposed via context.
+//
+// Other middleware can be gated behind required purposes with [Gate]; for example, analytics emitters, request capture, or
+// fingerprinting only apply to requests granting the relevant purposes. Every gating decision is recorded in the request's audit
+// trail, available through [Valuer.Decisions] and the [Options.Audit] function.
+package consent
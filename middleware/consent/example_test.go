@@ -0,0 +1,68 @@
+package consent_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/consent"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(consent.New().Settings(func(o *consent.Options) {
+		o.Default = []string{"necessary"}
+	}).Handler)
+
+	middleware.Add(consent.Gate("analytics", []string{"analytics"}, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Println("Analytics Event Emitted")
+
+			next.ServeHTTP(w, r)
+		})
+	}))
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		v := consent.Value(r.Context())
+
+		fmt.Printf("Purposes: %v, Decisions: %d\n", v.Purposes, len(v.Decisions()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, value := range []string{"analytics", ""} {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("X-Consent", value)
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+	}
+
+	// Output:
+	// Analytics Event Emitted
+	// Purposes: [necessary analytics], Decisions: 1
+	// Purposes: [necessary], Decisions: 1
+}
@@ -0,0 +1,256 @@
+package consent
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "consent"
+
+// Decision represents a gating decision made by a [Gate], recorded in the request's audit trail.
+type Decision struct {
+	// Name represents the gated middleware's name.
+	Name string `json:"name"`
+
+	// Purposes represents the gate's required purposes.
+	Purposes []string `json:"purposes"`
+
+	// Allowed reports whether every required purpose was granted, and the gated middleware applied.
+	Allowed bool `json:"allowed"`
+}
+
+// Valuer is the context return type relating to the [Consent] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Purposes represents the request's granted consent purposes, including [Options.Default] purposes.
+	Purposes []string `json:"purposes"`
+
+	// Source represents the consent signal's source - "cookie" or "header" - or an empty string if the request carried no valid
+	// consent signal.
+	Source string `json:"source,omitempty"`
+
+	mutex     sync.Mutex
+	decisions []Decision
+	audit     func(ctx context.Context, decision Decision)
+}
+
+// Granted reports whether every purpose was granted.
+func (v *Valuer) Granted(purposes ...string) bool {
+	for _, purpose := range purposes {
+		if !(slices.Contains(v.Purposes, purpose)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Decisions returns the request's gating decisions, in evaluation order.
+func (v *Valuer) Decisions() []Decision {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return slices.Clone(v.decisions)
+}
+
+// record appends the decision to the request's audit trail, and reports it to the [Options.Audit] function.
+func (v *Valuer) record(ctx context.Context, decision Decision) {
+	v.mutex.Lock()
+	v.decisions = append(v.decisions, decision)
+	v.mutex.Unlock()
+
+	if v.audit != nil {
+		v.audit(ctx, decision)
+	}
+}
+
+// Options represents the configuration settings for the [Consent] middleware component.
+type Options struct {
+	// Cookie represents the name of the cookie carrying the consent signal. An empty value disables the cookie source. Defaults
+	// to "consent".
+	Cookie string
+
+	// Header represents the request header carrying the consent signal. An empty value disables the header source. The cookie
+	// takes precedence. Defaults to "X-Consent".
+	Header string
+
+	// Decode decodes a consent signal into its granted purposes. See [TCF] for decoding IAB TCF v2 consent strings. Defaults to
+	// a function splitting a comma-separated purpose list (e.g. "analytics,personalization").
+	Decode func(value string) ([]string, error)
+
+	// Default represents the purposes granted irrespective of consent (e.g. "necessary"). Defaults to an empty slice.
+	Default []string
+
+	// Audit optionally receives every [Gate] decision, in addition to the request's [Valuer.Decisions] audit trail. Defaults to nil.
+	Audit func(ctx context.Context, decision Decision)
+
+	// Level specifies the log level used to record gating decisions. A value of nil disables logging. Defaults to [slog.LevelDebug].
+	Level slog.Leveler
+}
+
+// Consent represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Consent struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// list decodes a comma-separated purpose list.
+func list(value string) ([]string, error) {
+	var purposes []string
+	for _, purpose := range strings.Split(value, ",") {
+		if purpose = strings.TrimSpace(purpose); purpose != "" {
+			purposes = append(purposes, purpose)
+		}
+	}
+
+	return purposes, nil
+}
+
+// Settings applies configuration functions to modify the [Consent] middleware's [Options] and returns the updated middleware instance.
+func (c *Consent) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Cookie:  "consent",
+			Header:  "X-Consent",
+			Decode:  list,
+			Default: []string{},
+			Audit:   nil,
+			Level:   slog.LevelDebug,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Decode == nil {
+		slog.Warn("Invalid Consent Decode Function Specified - Using Default Decode Function")
+
+		c.options.Decode = list
+	}
+
+	return c
+}
+
+// signal returns the request's consent signal and its source.
+func (c *Consent) signal(r *http.Request) (value, source string) {
+	if c.options.Cookie != "" {
+		if cookie, e := r.Cookie(c.options.Cookie); e == nil && cookie.Value != "" {
+			return cookie.Value, "cookie"
+		}
+	}
+
+	if c.options.Header != "" {
+		if v := r.Header.Get(c.options.Header); v != "" {
+			return v, "header"
+		}
+	}
+
+	return "", ""
+}
+
+// Handler decodes the request's consent signal - from the [Options.Cookie] cookie, else the [Options.Header] header - and stores
+// the granted purposes in the request context, for use by [Gate] wrapped middleware and downstream handlers. Undecodable signals
+// are treated as absent.
+func (c *Consent) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Purposes: slices.Clone(c.options.Default)}
+
+		valuer.audit = func(ctx context.Context, decision Decision) {
+			if v := c.options.Level; v != nil {
+				slog.Log(ctx, v.Level(), "Consent Gating Decision", slog.String("name", decision.Name), slog.Any("purposes", decision.Purposes), slog.Bool("allowed", decision.Allowed))
+			}
+
+			if c.options.Audit != nil {
+				c.options.Audit(ctx, decision)
+			}
+		}
+
+		if value, source := c.signal(r); value != "" {
+			purposes, e := c.options.Decode(value)
+			if e != nil {
+				slog.WarnContext(ctx, "Unable to Decode Consent Signal", slog.String("source", source), slog.String("error", e.Error()))
+			} else {
+				valuer.Source = source
+
+				for _, purpose := range purposes {
+					if !(slices.Contains(valuer.Purposes, purpose)) {
+						valuer.Purposes = append(valuer.Purposes, purpose)
+					}
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// Gate wraps the named middleware function such that it only applies to requests granting every required purpose; otherwise,
+// requests bypass it. Each decision is recorded in the request's audit trail. Requests without a [Consent] middleware context
+// are treated as granting no purposes, so gates must be added after the [Consent] middleware:
+//
+//	m.Add(consent.New().Handler)
+//	m.Add(consent.Gate("analytics", []string{"analytics"}, telemetrics.New().Handler))
+func Gate(name string, purposes []string, fn func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return middleware.Conditional(func(r *http.Request) bool {
+		ctx := r.Context()
+
+		valuer, ok := ctx.Value(key).(*Valuer)
+		if !(ok) {
+			slog.WarnContext(ctx, "Consent Gate Missing Consent Middleware Context", slog.String("name", name))
+
+			return true
+		}
+
+		allowed := valuer.Granted(purposes...)
+
+		valuer.record(ctx, Decision{Name: name, Purposes: purposes, Allowed: allowed})
+
+		return !(allowed)
+	}, fn)
+}
+
+// New creates a new instance of the [Consent] middleware, implementing [middleware.Configurable]. If [Consent.Settings] isn't
+// called, then the [Consent.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Consent)
+}
+
+// Value retrieves the request's granted consent purposes from the provided context. If a nil value is returned, it can be assumed
+// that the [Consent] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Consent] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Consent)(nil)
@@ -0,0 +1,199 @@
+package consent_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/consent"
+)
+
+// tcf encodes a minimal IAB TCF v2 core segment consenting to the given purposes.
+func tcf(version int, purposes ...int) string {
+	data := make([]byte, 22)
+
+	set := func(index int) {
+		data[index/8] |= 0x80 >> (index % 8)
+	}
+
+	for index := 0; index < 6; index++ {
+		if version&(1<<(5-index)) != 0 {
+			set(index)
+		}
+	}
+
+	for _, purpose := range purposes {
+		set(152 + purpose - 1)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := consent.Value(r.Context())
+
+		w.Header().Set("X-Purposes", strings.Join(v.Purposes, ","))
+		w.Header().Set("X-Source", v.Source)
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		options  func(o *consent.Options)
+		header   http.Header
+		cookies  []*http.Cookie
+		purposes string
+		source   string
+	}{
+		{name: "No-Signal", purposes: "", source: ""},
+		{name: "Header", header: http.Header{"X-Consent": {"analytics, personalization"}}, purposes: "analytics,personalization", source: "header"},
+		{name: "Cookie-Precedence", header: http.Header{"X-Consent": {"analytics"}}, cookies: []*http.Cookie{{Name: "consent", Value: "personalization"}}, purposes: "personalization", source: "cookie"},
+		{name: "Defaults", options: func(o *consent.Options) {
+			o.Default = []string{"necessary"}
+		}, header: http.Header{"X-Consent": {"analytics,necessary"}}, purposes: "necessary,analytics", source: "header"},
+		{name: "TCF", options: func(o *consent.Options) {
+			o.Decode = consent.TCF
+		}, cookies: []*http.Cookie{{Name: "consent", Value: tcf(2, 1, 7, 24) + ".segment"}}, purposes: "1,7,24", source: "cookie"},
+		{name: "TCF-Invalid-Version", options: func(o *consent.Options) {
+			o.Decode = consent.TCF
+		}, cookies: []*http.Cookie{{Name: "consent", Value: tcf(1, 1)}}, purposes: "", source: ""},
+		{name: "TCF-Truncated", options: func(o *consent.Options) {
+			o.Decode = consent.TCF
+		}, cookies: []*http.Cookie{{Name: "consent", Value: "CPX"}}, purposes: "", source: ""},
+		{name: "Decode-Failure", options: func(o *consent.Options) {
+			o.Decode = func(value string) ([]string, error) { return nil, errors.New("undecodable") }
+			o.Default = []string{"necessary"}
+		}, header: http.Header{"X-Consent": {"analytics"}}, purposes: "necessary", source: ""},
+		{name: "Disabled-Source", options: func(o *consent.Options) {
+			o.Header = ""
+		}, header: http.Header{"X-Consent": {"analytics"}}, purposes: "", source: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := consent.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range test.header {
+				request.Header[k] = v
+			}
+
+			for _, cookie := range test.cookies {
+				request.AddCookie(cookie)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Purposes"); v != test.purposes {
+				t.Errorf("Purposes = %s\n    - Expectation = %s", v, test.purposes)
+			}
+
+			if v := recorder.Header().Get("X-Source"); v != test.source {
+				t.Errorf("Source = %s\n    - Expectation = %s", v, test.source)
+			}
+		})
+	}
+
+	t.Run("Gate", func(t *testing.T) {
+		var audited []consent.Decision
+
+		emitter := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Analytics", "emitted")
+
+				next.ServeHTTP(w, r)
+			})
+		}
+
+		chain := middleware.New()
+
+		chain.Add(consent.New().Settings(func(o *consent.Options) {
+			o.Audit = func(ctx context.Context, decision consent.Decision) {
+				audited = append(audited, decision)
+			}
+		}).Handler)
+
+		chain.Add(consent.Gate("analytics", []string{"analytics", "measurement"}, emitter))
+
+		var decisions []consent.Decision
+
+		handler := chain.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decisions = consent.Value(r.Context()).Decisions()
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		tests := []struct {
+			name    string
+			consent string
+			allowed bool
+		}{
+			{name: "Granted", consent: "analytics,measurement", allowed: true},
+			{name: "Partial", consent: "analytics", allowed: false},
+			{name: "Absent", consent: "", allowed: false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				audited = nil
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("X-Consent", test.consent)
+
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				if v := recorder.Header().Get("X-Analytics") == "emitted"; v != test.allowed {
+					t.Errorf("Emitted = %t\n    - Expectation = %t", v, test.allowed)
+				}
+
+				expectation := []consent.Decision{{Name: "analytics", Purposes: []string{"analytics", "measurement"}, Allowed: test.allowed}}
+
+				if !(reflect.DeepEqual(decisions, expectation)) {
+					t.Errorf("Decisions = %v\n    - Expectation = %v", decisions, expectation)
+				}
+
+				if !(slices.EqualFunc(audited, expectation, func(a, b consent.Decision) bool { return reflect.DeepEqual(a, b) })) {
+					t.Errorf("Audit = %v\n    - Expectation = %v", audited, expectation)
+				}
+			})
+		}
+
+		t.Run("Missing-Consent-Middleware", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			consent.Gate("analytics", []string{"analytics"}, emitter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if v := recorder.Header().Get("X-Analytics"); v != "" {
+				t.Errorf("Unexpected Gated Middleware Application")
+			}
+		})
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := consent.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &consent.Valuer{Purposes: []string{"analytics"}, Source: "header"}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := consent.Value(ctx); v != expectation {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package consent
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrTCF represents an invalid, or unsupported, IAB Transparency & Consent Framework (TCF) string.
+var ErrTCF = errors.New("invalid tcf consent string")
+
+// TCF decodes an IAB TCF v2 consent string's core segment, returning the consented purposes' identifiers (e.g. "1" for "Store
+// and/or access information on a device"). Vendor consents, legitimate interests, and publisher restrictions aren't decoded.
+func TCF(value string) ([]string, error) {
+	core, _, _ := strings.Cut(value, ".")
+
+	data, e := base64.RawURLEncoding.DecodeString(strings.TrimRight(core, "="))
+	if e != nil {
+		return nil, ErrTCF
+	}
+
+	// The core segment's fixed-width fields preceding the 24-bit purposes consent field total 152 bits.
+	const offset, purposes = 152, 24
+
+	if len(data)*8 < offset+purposes {
+		return nil, ErrTCF
+	}
+
+	bit := func(index int) bool {
+		return data[index/8]&(0x80>>(index%8)) != 0
+	}
+
+	version := 0
+	for index := 0; index < 6; index++ {
+		version <<= 1
+		if bit(index) {
+			version |= 1
+		}
+	}
+
+	if version != 2 {
+		return nil, ErrTCF
+	}
+
+	granted := make([]string, 0, purposes)
+	for index := 0; index < purposes; index++ {
+		if bit(offset + index) {
+			granted = append(granted, strconv.Itoa(index+1))
+		}
+	}
+
+	return granted, nil
+}
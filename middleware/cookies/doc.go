@@ -0,0 +1,11 @@
+// Package cookies provides middleware transparently signing - and optionally encrypting - a configurable set of cookies: cookies
+// set by downstream handlers are protected on the way out, and verified on the way in, such that handlers read and write plain
+// values while clients can neither read (if encrypted) nor forge them.
+//
+// Signed values take the form "<value>.<signature>", where the signature is an HMAC-SHA256 over the cookie's name and value.
+// Encrypted values are AES-256-GCM sealed, with the cookie's name as additional data, preventing values from being swapped between
+// cookies. Tampered cookies are stripped from the request, or the request is rejected, per [Options.Reject].
+//
+// Keys are rotated by prepending a new key to [Options.Keys]: the first key protects outgoing cookies, while every key verifies
+// incoming ones. Retire old keys once cookies protected with them have been re-issued, or have expired.
+package cookies
@@ -0,0 +1,85 @@
+package cookies_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/cookies"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(cookies.New().Settings(func(o *cookies.Options) {
+		o.Names = []string{"session"}
+		o.Keys = [][]byte{bytes.Repeat([]byte("k"), 32)} // Typically loaded from a secret store.
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "user-1", HttpOnly: true})
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		if cookie, e := r.Cookie("session"); e == nil {
+			fmt.Printf("Session: %s\n", cookie.Value)
+		} else {
+			fmt.Printf("Session: None (Tampered: %v)\n", cookies.Value(r.Context()).Tampered)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	response, e := client.Get(server.URL + "/login")
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	session := response.Cookies()[0]
+
+	fmt.Printf("Signed: %t\n", strings.HasPrefix(session.Value, "user-1."))
+
+	for _, value := range []string{session.Value, "user-2" + strings.TrimPrefix(session.Value, "user-1")} {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.AddCookie(&http.Cookie{Name: "session", Value: value})
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+	}
+
+	// Output:
+	// Signed: true
+	// Session: user-1
+	// Session: None (Tampered: [session])
+}
@@ -0,0 +1,382 @@
+package cookies
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "cookies"
+
+// ErrTampered is the error representing a protected cookie value failing verification.
+var ErrTampered = errors.New("cookie value failed verification")
+
+// Valuer is the context return type relating to the [Cookies] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Verified represents the names of the request's protected cookies that passed verification.
+	Verified []string `json:"verified"`
+
+	// Tampered represents the names of the request's protected cookies that failed verification, and were stripped.
+	Tampered []string `json:"tampered"`
+}
+
+// Options represents the configuration settings for the [Cookies] middleware component.
+type Options struct {
+	// Names represents the names of the protected cookies. Other cookies pass through unmodified. Defaults to an empty slice.
+	Names []string
+
+	// Keys represents the protection keys, newest first: the first key protects outgoing cookies, while every key verifies incoming
+	// ones. Deployments with multiple instances must share keys. Defaults to a random, per-process key.
+	Keys [][]byte
+
+	// Encrypt specifies whether protected cookies are encrypted, rather than only signed. Defaults to false.
+	Encrypt bool
+
+	// Reject specifies whether requests carrying a tampered cookie receive a [http.StatusBadRequest] response, rather than having
+	// the cookie stripped. Defaults to false.
+	Reject bool
+}
+
+// Cookies represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Cookies struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	protected map[string]bool
+}
+
+// Settings applies configuration functions to modify the [Cookies] middleware's [Options] and returns the updated middleware instance.
+func (c *Cookies) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Names:   []string{},
+			Keys:    [][]byte{},
+			Encrypt: false,
+			Reject:  false,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	keys := make([][]byte, 0, len(c.options.Keys))
+	for _, k := range c.options.Keys {
+		if len(k) >= 32 {
+			keys = append(keys, k)
+		} else {
+			slog.Warn("Invalid Cookies Key Specified - Ignoring Key Shorter Than 32 Bytes")
+		}
+	}
+
+	c.options.Keys = keys
+
+	return c
+}
+
+// derive returns the key's purpose-specific subkey.
+func derive(k []byte, purpose string) []byte {
+	h := hmac.New(sha256.New, k)
+	h.Write([]byte(purpose))
+
+	return h.Sum(nil)
+}
+
+// sign returns the value's signed form.
+func sign(k []byte, name, value string) string {
+	h := hmac.New(sha256.New, derive(k, "signature"))
+	h.Write([]byte(name + "\x00" + value))
+
+	return value + "." + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// seal returns the value's encrypted form.
+func seal(k []byte, name, value string) (string, error) {
+	block, e := aes.NewCipher(derive(k, "encryption"))
+	if e != nil {
+		return "", e
+	}
+
+	aead, e := cipher.NewGCM(block)
+	if e != nil {
+		return "", e
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, e := rand.Read(nonce); e != nil {
+		return "", e
+	}
+
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(value), []byte(name))), nil
+}
+
+// protect returns the value's protected form, using the newest key.
+func (c *Cookies) protect(name, value string) (string, error) {
+	if c.options.Encrypt {
+		return seal(c.options.Keys[0], name, value)
+	}
+
+	return sign(c.options.Keys[0], name, value), nil
+}
+
+// verify returns the protected value's original form, trying each key, or [ErrTampered].
+func (c *Cookies) verify(name, value string) (string, error) {
+	if c.options.Encrypt {
+		data, e := base64.RawURLEncoding.DecodeString(value)
+		if e != nil {
+			return "", ErrTampered
+		}
+
+		for _, k := range c.options.Keys {
+			block, e := aes.NewCipher(derive(k, "encryption"))
+			if e != nil {
+				return "", e
+			}
+
+			aead, e := cipher.NewGCM(block)
+			if e != nil {
+				return "", e
+			}
+
+			if len(data) < aead.NonceSize() {
+				return "", ErrTampered
+			}
+
+			if plaintext, e := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(name)); e == nil {
+				return string(plaintext), nil
+			}
+		}
+
+		return "", ErrTampered
+	}
+
+	index := strings.LastIndex(value, ".")
+	if index < 0 {
+		return "", ErrTampered
+	}
+
+	for _, k := range c.options.Keys {
+		if hmac.Equal([]byte(sign(k, name, value[:index])), []byte(value)) {
+			return value[:index], nil
+		}
+	}
+
+	return "", ErrTampered
+}
+
+// writer protects the response's Set-Cookie headers prior to writing the response's header.
+type writer struct {
+	http.ResponseWriter
+
+	cookies *Cookies
+	ctx     context.Context
+	wrote   bool
+}
+
+// rewrite replaces the protected cookies' values in the response's Set-Cookie headers, preserving their attributes. Cookies
+// being deleted - those with an empty value - are left as-is.
+func (w *writer) rewrite() {
+	header := w.ResponseWriter.Header()
+
+	lines := header.Values("Set-Cookie")
+	for index, line := range lines {
+		pair, attributes, _ := strings.Cut(line, ";")
+
+		name, value, found := strings.Cut(pair, "=")
+		if name = strings.TrimSpace(name); !(found) || !(w.cookies.protected[name]) {
+			continue
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		if value == "" {
+			continue
+		}
+
+		protected, e := w.cookies.protect(name, value)
+		if e != nil {
+			slog.ErrorContext(w.ctx, "Unable to Protect Cookie - Omitting Cookie", slog.String("cookie", name), slog.String("error", e.Error()))
+
+			lines[index] = ""
+
+			continue
+		}
+
+		if len(protected) > 4096 {
+			slog.WarnContext(w.ctx, "Protected Cookie Exceeds 4096 Bytes - Clients May Discard It", slog.String("cookie", name), slog.Int("size", len(protected)))
+		}
+
+		lines[index] = name + "=" + protected
+		if attributes != "" {
+			lines[index] += ";" + attributes
+		}
+	}
+
+	header.Del("Set-Cookie")
+	for _, line := range lines {
+		if line != "" {
+			header.Add("Set-Cookie", line)
+		}
+	}
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.wrote) {
+		w.wrote = true
+
+		w.rewrite()
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.wrote) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], protecting the response's cookies before flushing its header(s).
+func (w *writer) Flush() {
+	if !(w.wrote) {
+		w.wrote = true
+
+		w.rewrite()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], protecting the response's cookies before relinquishing the connection.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !(w.wrote) {
+		w.wrote = true
+
+		w.rewrite()
+	}
+
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler verifies the request's protected cookies - replacing their values with the original values, and stripping, or rejecting,
+// tampered cookies - and protects the response's protected cookies upon writing its header.
+func (c *Cookies) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	if len(c.options.Keys) == 0 {
+		slog.Warn("Cookies Keys Unspecified - Using Random, Per-Process Key")
+
+		k := make([]byte, 32)
+		if _, e := rand.Read(k); e != nil {
+			panic(e)
+		}
+
+		c.options.Keys = [][]byte{k}
+	}
+
+	c.protected = make(map[string]bool, len(c.options.Names))
+	for _, name := range c.options.Names {
+		c.protected[name] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Verified: []string{}, Tampered: []string{}}
+
+		if len(c.protected) > 0 && r.Header.Get("Cookie") != "" {
+			incoming := r.Cookies()
+
+			r = r.Clone(ctx)
+			r.Header.Del("Cookie")
+
+			for _, cookie := range incoming {
+				if c.protected[cookie.Name] {
+					value, e := c.verify(cookie.Name, cookie.Value)
+					if e != nil {
+						valuer.Tampered = append(valuer.Tampered, cookie.Name)
+
+						continue
+					}
+
+					cookie.Value = value
+
+					valuer.Verified = append(valuer.Verified, cookie.Name)
+				}
+
+				r.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+			}
+		}
+
+		if len(valuer.Tampered) > 0 {
+			slog.WarnContext(ctx, "Tampered Cookie(s) Received", slog.Any("cookies", valuer.Tampered), slog.Bool("rejected", c.options.Reject))
+
+			if c.options.Reject {
+				reject.Record(ctx, reject.Reason{Subsystem: "cookies", Code: "cookie-tampered", Status: http.StatusBadRequest})
+
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		writer := &writer{ResponseWriter: w, cookies: c, ctx: ctx}
+
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(ctx, key, valuer)))
+
+		// Handlers not writing a response leave the header to be written by the server.
+		if !(writer.wrote) {
+			writer.wrote = true
+
+			writer.rewrite()
+		}
+	})
+}
+
+// New creates a new instance of the [Cookies] middleware, implementing [middleware.Configurable]. If [Cookies.Settings] isn't called,
+// then the [Cookies.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Cookies)
+}
+
+// Value retrieves the request's cookie verification [Valuer] from the provided context. If a nil value is returned, it can be
+// assumed that the [Cookies] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Cookies] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Cookies)(nil)
@@ -0,0 +1,165 @@
+package cookies_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/cookies"
+)
+
+func Test(t *testing.T) {
+	older, newer := bytes.Repeat([]byte("o"), 32), bytes.Repeat([]byte("n"), 32)
+
+	// handler echoes the request's session cookie, and sets a session, and a theme cookie.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, e := r.Cookie("session"); e == nil {
+			w.Header().Set("X-Session", cookie.Value)
+		}
+
+		if cookie, e := r.Cookie("theme"); e == nil {
+			w.Header().Set("X-Theme", cookie.Value)
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "user-1", Path: "/", HttpOnly: true})
+		http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// issue returns the protected session cookie's value, as set by the handler.
+	issue := func(t *testing.T, options func(o *cookies.Options)) string {
+		recorder := httptest.NewRecorder()
+
+		cookies.New().Settings(options).Handler(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		for _, cookie := range recorder.Result().Cookies() {
+			if cookie.Name == "session" {
+				return cookie.Value
+			}
+		}
+
+		t.Fatalf("Set-Cookie = %v\n    - Expectation = %s", recorder.Header().Values("Set-Cookie"), "a session cookie")
+
+		return ""
+	}
+
+	for _, encrypt := range []bool{false, true} {
+		name := "Signed"
+		if encrypt {
+			name = "Encrypted"
+		}
+
+		options := func(keys ...[]byte) func(o *cookies.Options) {
+			return func(o *cookies.Options) {
+				o.Names, o.Keys, o.Encrypt = []string{"session"}, keys, encrypt
+			}
+		}
+
+		t.Run(name, func(t *testing.T) {
+			value := issue(t, options(older))
+
+			if value == "user-1" || (encrypt && strings.Contains(value, "user-1")) || (!(encrypt) && !(strings.HasPrefix(value, "user-1."))) {
+				t.Fatalf("Session = %s\n    - Expectation = %s", value, "a protected value")
+			}
+
+			tests := []struct {
+				name    string
+				options func(o *cookies.Options)
+				value   string
+				status  int
+				session string
+			}{
+				{name: "Verified", options: options(older), value: value, status: http.StatusOK, session: "user-1"},
+				{name: "Rotated", options: options(newer, older), value: value, status: http.StatusOK, session: "user-1"},
+				{name: "Retired", options: options(newer), value: value, status: http.StatusOK},
+				{name: "Tampered", options: options(older), value: "x" + value, status: http.StatusOK},
+				{name: "Unprotected", options: options(older), value: "user-1", status: http.StatusOK},
+				{name: "Rejected", options: func(o *cookies.Options) { options(older)(o); o.Reject = true }, value: "x" + value, status: http.StatusBadRequest},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					request := httptest.NewRequest(http.MethodGet, "/", nil)
+					request.AddCookie(&http.Cookie{Name: "session", Value: tt.value})
+					request.AddCookie(&http.Cookie{Name: "theme", Value: "light"})
+
+					recorder := httptest.NewRecorder()
+
+					cookies.New().Settings(tt.options).Handler(handler).ServeHTTP(recorder, request)
+
+					if recorder.Code != tt.status {
+						t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+					}
+
+					if tt.status != http.StatusOK {
+						return
+					}
+
+					if v := recorder.Header().Get("X-Session"); v != tt.session {
+						t.Errorf("Session = %s\n    - Expectation = %s", v, tt.session)
+					}
+
+					if v := recorder.Header().Get("X-Theme"); v != "light" {
+						t.Errorf("Theme = %s\n    - Expectation = %s", v, "light")
+					}
+				})
+			}
+		})
+	}
+
+	t.Run("Attributes", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		cookies.New().Settings(func(o *cookies.Options) {
+			o.Names, o.Keys = []string{"session"}, [][]byte{newer}
+		}).Handler(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		lines := recorder.Header().Values("Set-Cookie")
+		if len(lines) != 2 || !(strings.HasPrefix(lines[0], "session=user-1.")) || !(strings.HasSuffix(lines[0], "; Path=/; HttpOnly")) || lines[1] != "theme=dark" {
+			t.Errorf("Set-Cookie = %v\n    - Expectation = %s", lines, "a signed session cookie retaining its attributes, and an unmodified theme cookie")
+		}
+	})
+
+	t.Run("Implicit-Header", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		cookies.New().Settings(func(o *cookies.Options) {
+			o.Names, o.Keys = []string{"session"}, [][]byte{newer}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "user-1"})
+		})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Get("Set-Cookie"); !(strings.HasPrefix(v, "session=user-1.")) {
+			t.Errorf("Set-Cookie = %s\n    - Expectation = %s", v, "a signed session cookie")
+		}
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		cookies.New().Settings(func(o *cookies.Options) {
+			o.Names, o.Keys = []string{"session"}, [][]byte{newer}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "user-1"})
+
+			http.NewResponseController(w).Flush()
+
+			w.Write([]byte("streamed"))
+		})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// The result's header(s) are those snapshotted upon flushing.
+		if v := recorder.Result().Header.Get("Set-Cookie"); !(strings.HasPrefix(v, "session=user-1.")) || !(recorder.Flushed) {
+			t.Errorf("Set-Cookie = %s\n    - Expectation = %s", v, "a signed session cookie, flushed")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := cookies.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -20,6 +20,41 @@ const key keyer = "cors"
 type Options struct {
 	// Debug represents a boolean flag to enable debug-related logging. Defaults to false.
 	Debug bool
+
+	// Origins represents the allowed origins. An origin may contain a single "*" wildcard (e.g. "https://*.example.com"), and
+	// a "*" entry allows all origins. If both [Options.Origins] and [Options.Origin] are unspecified, all origins are allowed.
+	// Defaults to nil.
+	Origins []string
+
+	// Origin optionally determines whether an origin is allowed, taking precedence over [Options.Origins]. Defaults to nil.
+	Origin func(origin string) bool
+
+	// Methods represents the allowed, cross-origin request methods. Defaults to HEAD, GET, POST, PUT, PATCH, and DELETE.
+	Methods []string
+
+	// Headers represents the allowed, non-simple request headers. A "*" entry allows all headers. Defaults to "*".
+	Headers []string
+
+	// Exposed represents the response headers exposed to clients, beyond the CORS-safelisted response headers. Defaults to "*".
+	Exposed []string
+
+	// MaxAge represents the duration, in seconds, clients may cache preflight responses. A negative value disables caching,
+	// and zero omits the Access-Control-Max-Age header. Defaults to 300.
+	MaxAge int
+
+	// Credentials allows requests with credentials - cookies, HTTP authentication, and client certificates. Defaults to true.
+	Credentials bool
+
+	// PrivateNetwork allows requests to private networks, per the Private Network Access specification. Defaults to true.
+	PrivateNetwork bool
+
+	// Status represents the successful preflight response's status code. Defaults to [http.StatusNoContent].
+	Status int
+
+	// Policies represents origin-specific policies, evaluated in order. The first policy matching a request's origin applies in
+	// place of the [Options]' own origins, methods, headers, max age, credentials, and private network settings; requests whose
+	// origin matches no policy are subject to the [Options]' settings. Defaults to nil.
+	Policies []Policy
 }
 
 // CORS represents a middleware component that applies configurable [Options] settings to HTTP requests. It
@@ -34,7 +69,23 @@ type CORS struct {
 func (c *CORS) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
 	if c.options == nil {
 		c.options = &Options{
-			Debug: false,
+			Debug:   false,
+			Origins: nil,
+			Origin:  nil,
+			Methods: []string{
+				http.MethodHead,
+				http.MethodGet,
+				http.MethodPost,
+				http.MethodPut,
+				http.MethodPatch,
+				http.MethodDelete,
+			},
+			Headers:        []string{"*"},
+			Exposed:        []string{"*"},
+			MaxAge:         300, // Maximum value not ignored by any of major browsers
+			Credentials:    true,
+			PrivateNetwork: true,
+			Status:         http.StatusNoContent,
 		}
 	}
 
@@ -44,6 +95,13 @@ func (c *CORS) Settings(configuration ...func(o *Options)) middleware.Configurab
 		}
 	}
 
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Status < 200 || c.options.Status > 299 {
+		slog.Warn("Invalid CORS Status Specified - Using Default Status")
+
+		c.options.Status = http.StatusNoContent
+	}
+
 	return c
 }
 
@@ -51,27 +109,47 @@ func (c *CORS) Settings(configuration ...func(o *Options)) middleware.Configurab
 func (c *CORS) Handler(next http.Handler) http.Handler {
 	c.Settings() // Ensure the options field isn't nil.
 
-	internals := external.Options{
-		AllowedOrigins:             nil,
-		AllowOriginFunc:            func(origin string) bool { return true },
+	// Allow all origins, reflecting the request's origin, unless the allowed origins were specified.
+	origin := c.options.Origin
+	if origin == nil && len(c.options.Origins) == 0 {
+		origin = func(origin string) bool { return true }
+	}
+
+	fallback := external.New(external.Options{
+		AllowedOrigins:             c.options.Origins,
+		AllowOriginFunc:            origin,
 		AllowOriginVaryRequestFunc: nil,
-		AllowedMethods: []string{
-			http.MethodHead,
-			http.MethodGet,
-			http.MethodPost,
-			http.MethodPut,
-			http.MethodPatch,
-			http.MethodDelete,
-		},
-		AllowedHeaders:       []string{"*"},
-		ExposedHeaders:       []string{"*"},
-		MaxAge:               300, // Maximum value not ignored by any of major browsers
-		AllowCredentials:     true,
-		AllowPrivateNetwork:  true,
-		OptionsPassthrough:   false,
-		OptionsSuccessStatus: http.StatusNoContent,
-		Debug:                c.options.Debug,
-		Logger:               nil,
+		AllowedMethods:             c.options.Methods,
+		AllowedHeaders:             c.options.Headers,
+		ExposedHeaders:             c.options.Exposed,
+		MaxAge:                     c.options.MaxAge,
+		AllowCredentials:           c.options.Credentials,
+		AllowPrivateNetwork:        c.options.PrivateNetwork,
+		OptionsPassthrough:         false,
+		OptionsSuccessStatus:       c.options.Status,
+		Debug:                      c.options.Debug,
+		Logger:                     nil,
+	})
+
+	policies := make([]*external.Cors, len(c.options.Policies))
+	for index := range c.options.Policies {
+		policy := &c.options.Policies[index]
+
+		policies[index] = external.New(external.Options{
+			AllowedOrigins:             nil,
+			AllowOriginFunc:            policy.matches,
+			AllowOriginVaryRequestFunc: nil,
+			AllowedMethods:             policy.Methods,
+			AllowedHeaders:             policy.Headers,
+			ExposedHeaders:             policy.Exposed,
+			MaxAge:                     policy.MaxAge,
+			AllowCredentials:           policy.Credentials,
+			AllowPrivateNetwork:        policy.PrivateNetwork,
+			OptionsPassthrough:         false,
+			OptionsSuccessStatus:       c.options.Status,
+			Debug:                      c.options.Debug,
+			Logger:                     nil,
+		})
 	}
 
 	wrapper := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -111,9 +189,29 @@ func (c *CORS) Handler(next http.Handler) http.Handler {
 		slog.Debug("Instantiating CORS Handler")
 	}
 
-	handle := external.New(internals)
+	handlers := make([]http.Handler, len(policies))
+	for index := range policies {
+		handlers[index] = policies[index].Handler(wrapper)
+	}
+
+	handle := fallback.Handler(wrapper)
+	if len(handlers) == 0 {
+		return handle
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			for index := range c.options.Policies {
+				if c.options.Policies[index].matches(origin) {
+					handlers[index].ServeHTTP(w, r)
 
-	return handle.Handler(wrapper)
+					return
+				}
+			}
+		}
+
+		handle.ServeHTTP(w, r)
+	})
 }
 
 // New creates a new instance of the [CORS] middleware, implementing [middleware.Configurable]. If [CORS.Settings] isn't called,
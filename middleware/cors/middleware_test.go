@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/poly-gun/go-middleware/middleware/cors"
@@ -101,6 +102,114 @@ func Test(t *testing.T) {
 			})
 		})
 
+		t.Run("Configured-Policy", func(t *testing.T) {
+			policy := cors.New().Settings(func(o *cors.Options) {
+				o.Origins = []string{"https://*.example.com"}
+				o.Methods = []string{http.MethodGet, http.MethodPost}
+				o.Headers = []string{"Content-Type", "Authorization"}
+				o.Exposed = []string{"X-Request-ID"}
+				o.MaxAge = 600
+				o.Credentials = false
+				o.PrivateNetwork = false
+				o.Status = http.StatusOK
+			}).Handler(handler)
+
+			tests := []struct {
+				name    string
+				method  string
+				origin  string
+				request string
+				status  int
+				headers map[string]string
+			}{
+				{name: "Preflight-Allowed-Origin", method: http.MethodOptions, origin: "https://api.example.com", request: http.MethodPost, status: http.StatusOK, headers: map[string]string{"Access-Control-Allow-Origin": "https://api.example.com", "Access-Control-Allow-Methods": http.MethodPost, "Access-Control-Max-Age": "600", "Access-Control-Allow-Credentials": ""}},
+				{name: "Preflight-Disallowed-Method", method: http.MethodOptions, origin: "https://api.example.com", request: http.MethodDelete, status: http.StatusOK, headers: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""}},
+				{name: "Preflight-Disallowed-Origin", method: http.MethodOptions, origin: "https://example.org", request: http.MethodPost, status: http.StatusOK, headers: map[string]string{"Access-Control-Allow-Origin": ""}},
+				{name: "Actual-Allowed-Origin", method: http.MethodGet, origin: "https://www.example.com", status: http.StatusOK, headers: map[string]string{"Access-Control-Allow-Origin": "https://www.example.com", "Access-Control-Expose-Headers": "X-Request-Id", "Access-Control-Allow-Credentials": ""}},
+				{name: "Actual-Disallowed-Origin", method: http.MethodGet, origin: "https://example.org", status: http.StatusOK, headers: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Expose-Headers": ""}},
+			}
+
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					request := httptest.NewRequest(test.method, "/", nil)
+					request.Header.Set("Origin", test.origin)
+					if test.request != "" {
+						request.Header.Set("Access-Control-Request-Method", test.request)
+					}
+
+					recorder := httptest.NewRecorder()
+
+					policy.ServeHTTP(recorder, request)
+
+					if recorder.Code != test.status {
+						t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+					}
+
+					for k, expectation := range test.headers {
+						if v := recorder.Header().Get(k); v != expectation {
+							t.Errorf("%s = %s\n    - Expectation = %s", k, v, expectation)
+						}
+					}
+				})
+			}
+		})
+
+		t.Run("Policies", func(t *testing.T) {
+			policy := cors.New().Settings(func(o *cors.Options) {
+				o.Origins = []string{"https://www.example.com"}
+				o.Credentials = false
+				o.Policies = []cors.Policy{
+					{
+						Origins:     []string{"https://admin.example.com"},
+						Methods:     []string{http.MethodGet, http.MethodDelete},
+						Headers:     []string{"Authorization"},
+						Credentials: true,
+					},
+					{
+						Origins: []string{"https://*.widgets.example.com"},
+						Pattern: regexp.MustCompile(`^https://[a-z]+\.partner\.io$`),
+						Methods: []string{http.MethodGet},
+					},
+				}
+			}).Handler(handler)
+
+			tests := []struct {
+				name    string
+				method  string
+				origin  string
+				request string
+				headers map[string]string
+			}{
+				{name: "Admin-Preflight", method: http.MethodOptions, origin: "https://admin.example.com", request: http.MethodDelete, headers: map[string]string{"Access-Control-Allow-Origin": "https://admin.example.com", "Access-Control-Allow-Methods": http.MethodDelete, "Access-Control-Allow-Credentials": "true"}},
+				{name: "Widget-Preflight", method: http.MethodOptions, origin: "https://shop.widgets.example.com", request: http.MethodGet, headers: map[string]string{"Access-Control-Allow-Origin": "https://shop.widgets.example.com", "Access-Control-Allow-Credentials": ""}},
+				{name: "Widget-Preflight-Disallowed-Method", method: http.MethodOptions, origin: "https://shop.widgets.example.com", request: http.MethodDelete, headers: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""}},
+				{name: "Widget-Bare-Domain", method: http.MethodGet, origin: "https://widgets.example.com", headers: map[string]string{"Access-Control-Allow-Origin": ""}},
+				{name: "Partner-Pattern", method: http.MethodGet, origin: "https://acme.partner.io", headers: map[string]string{"Access-Control-Allow-Origin": "https://acme.partner.io", "Access-Control-Allow-Credentials": ""}},
+				{name: "Fallback", method: http.MethodGet, origin: "https://www.example.com", headers: map[string]string{"Access-Control-Allow-Origin": "https://www.example.com", "Access-Control-Allow-Credentials": ""}},
+				{name: "Unmatched", method: http.MethodGet, origin: "https://example.org", headers: map[string]string{"Access-Control-Allow-Origin": ""}},
+			}
+
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					request := httptest.NewRequest(test.method, "/", nil)
+					request.Header.Set("Origin", test.origin)
+					if test.request != "" {
+						request.Header.Set("Access-Control-Request-Method", test.request)
+					}
+
+					recorder := httptest.NewRecorder()
+
+					policy.ServeHTTP(recorder, request)
+
+					for k, expectation := range test.headers {
+						if v := recorder.Header().Get(k); v != expectation {
+							t.Errorf("%s = %s\n    - Expectation = %s", k, v, expectation)
+						}
+					}
+				})
+			}
+		})
+
 		// t.Run("Preflight-Include-CORS-Headers", func(t *testing.T) {
 		// 	server := httptest.NewServer(cors.New().Settings(func(o *cors.Options) { o.Debug = true }).Handler(handler))
 		//
@@ -0,0 +1,54 @@
+package cors
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Policy represents an origin-specific CORS policy. See [Options.Policies].
+type Policy struct {
+	// Origins represents the policy's exact origins (e.g. "https://admin.example.com"), or wildcard subdomain origins containing
+	// a single "*" (e.g. "https://*.example.com"). Matching is case-insensitive.
+	Origins []string
+
+	// Pattern optionally matches the policy's origins through a regular expression, in addition to [Policy.Origins].
+	Pattern *regexp.Regexp
+
+	// Methods represents the allowed, cross-origin request methods. A nil value allows GET, POST, and HEAD.
+	Methods []string
+
+	// Headers represents the allowed, non-simple request headers. A "*" entry allows all headers.
+	Headers []string
+
+	// Exposed represents the response headers exposed to clients, beyond the CORS-safelisted response headers.
+	Exposed []string
+
+	// MaxAge represents the duration, in seconds, clients may cache preflight responses. A negative value disables caching,
+	// and zero omits the Access-Control-Max-Age header.
+	MaxAge int
+
+	// Credentials allows requests with credentials - cookies, HTTP authentication, and client certificates.
+	Credentials bool
+
+	// PrivateNetwork allows requests to private networks, per the Private Network Access specification.
+	PrivateNetwork bool
+}
+
+// matches reports whether the origin satisfies the policy.
+func (p *Policy) matches(origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, v := range p.Origins {
+		v = strings.ToLower(v)
+
+		if prefix, suffix, found := strings.Cut(v, "*"); found {
+			if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		} else if origin == v {
+			return true
+		}
+	}
+
+	return p.Pattern != nil && p.Pattern.MatchString(origin)
+}
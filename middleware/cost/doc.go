@@ -0,0 +1,10 @@
+// Package cost provides middleware that attributes per-request resource costs to teams and routes, for internal platform chargeback.
+//
+// For each request, the middleware measures wall-clock duration, request and response bytes, and the number of backend calls
+// handlers report via [Value]. Cost attribution headers are stamped onto the response when its header(s) are written, and every
+// request is aggregated into per-team, per-route [Entry] values - available via [Cost.Report], or as JSON via [Cost.Endpoint].
+//
+// Routes are derived from the [route] middleware, which must precede the [Cost] middleware in the chain.
+//
+// [route]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/route
+package cost
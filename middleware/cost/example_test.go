@@ -0,0 +1,62 @@
+package cost_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/cost"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /reports/{id}", func(w http.ResponseWriter, r *http.Request) {
+		// Record the backend calls made on behalf of the request.
+		cost.Value(r.Context()).Call(2)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	costs := cost.New()
+
+	middleware := middleware.New()
+
+	middleware.Add(route.New().Settings(func(o *route.Options) { o.Mux = mux }).Handler)
+	middleware.Add(costs.Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/reports/7", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-Team", "analytics")
+
+	response, e := server.Client().Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("X-Cost-Calls: %s\n", response.Header.Get("X-Cost-Calls"))
+
+	for _, entry := range costs.Report(false) {
+		fmt.Printf("Team: %s, Route: %s, Requests: %d, Calls: %d\n", entry.Team, entry.Route, entry.Requests, entry.Calls)
+	}
+
+	// Output:
+	// X-Cost-Calls: 2
+	// Team: analytics, Route: GET /reports/{id}, Requests: 1, Calls: 2
+}
@@ -0,0 +1,360 @@
+package cost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "cost"
+
+const (
+	defaultLimit    = 1000
+	defaultOverflow = "other"
+)
+
+// Valuer is the context return type relating to the [Cost] middleware, and is safe for concurrent use. See the [Value] function for
+// additional details.
+type Valuer struct {
+	calls atomic.Int64
+}
+
+// Call records one or more backend call(s) (e.g. database queries, or upstream requests) made on behalf of the request.
+func (v *Valuer) Call(n int64) {
+	v.calls.Add(n)
+}
+
+// Calls returns the number of recorded backend call(s).
+func (v *Valuer) Calls() int64 {
+	return v.calls.Load()
+}
+
+// MarshalJSON encodes the [Valuer] as {"calls": n}.
+func (v *Valuer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Calls int64 `json:"calls"`
+	}{Calls: v.Calls()})
+}
+
+// Entry represents aggregated costs of a single team and route.
+type Entry struct {
+	// Team represents the attributed team.
+	Team string `json:"team"`
+
+	// Route represents the attributed route template.
+	Route string `json:"route"`
+
+	// Requests represents the number of requests.
+	Requests int64 `json:"requests"`
+
+	// Duration represents the sum of request wall-clock durations.
+	Duration time.Duration `json:"duration"`
+
+	// Received represents the sum of request body bytes read by handlers.
+	Received int64 `json:"received"`
+
+	// Sent represents the sum of response body bytes written.
+	Sent int64 `json:"sent"`
+
+	// Calls represents the sum of backend calls.
+	Calls int64 `json:"calls"`
+}
+
+// Options represents the configuration settings for the [Cost] middleware component.
+type Options struct {
+	// Team returns the team a request's costs are attributed to. An empty return value attributes costs to "unattributed".
+	// Defaults to a function returning the "X-Team" request header - a client-controlled value, bounded by [Options.Limit].
+	Team func(r *http.Request) string
+
+	// Limit represents the maximum number of distinct team-route entries. Once exceeded, costs of previously unseen pairs are
+	// aggregated with a team and route of [Options.Overflow]. Defaults to 1000.
+	Limit int
+
+	// Overflow represents the team and route value assigned to entries exceeding [Options.Limit]. Defaults to "other".
+	Overflow string
+
+	// Headers enables stamping the response with "X-Cost-Duration" (milliseconds), "X-Cost-Received" (bytes), and "X-Cost-Calls"
+	// header(s). Values reflect the request's costs at the time its response header(s) are written. Defaults to true.
+	Headers bool
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Cost represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Cost struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex   sync.Mutex
+	entries map[[2]string]*Entry
+}
+
+// Settings applies configuration functions to modify the [Cost] middleware's [Options] and returns the updated middleware instance.
+func (c *Cost) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Team: func(r *http.Request) string {
+				return r.Header.Get("X-Team")
+			},
+			Limit:    defaultLimit,
+			Overflow: defaultOverflow,
+			Headers:  true,
+			Clock:    time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Limit <= 0 {
+		slog.Warn("Invalid Cost Limit Specified - Using Default Limit")
+
+		c.options.Limit = defaultLimit
+	}
+
+	if c.options.Overflow == "" {
+		c.options.Overflow = defaultOverflow
+	}
+
+	if c.options.Clock == nil {
+		c.options.Clock = time.Now
+	}
+
+	return c
+}
+
+// aggregate adds a request's costs to its team and route's [Entry], enforcing the cardinality limit.
+func (c *Cost) aggregate(team, route string, duration time.Duration, received, sent, calls int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[[2]string]*Entry)
+	}
+
+	k := [2]string{team, route}
+
+	entry, ok := c.entries[k]
+	if !(ok) {
+		if len(c.entries) >= c.options.Limit {
+			k = [2]string{c.options.Overflow, c.options.Overflow}
+		}
+
+		if entry, ok = c.entries[k]; !(ok) {
+			entry = &Entry{Team: k[0], Route: k[1]}
+
+			c.entries[k] = entry
+		}
+	}
+
+	entry.Requests++
+	entry.Duration += duration
+	entry.Received += received
+	entry.Sent += sent
+	entry.Calls += calls
+}
+
+// Report returns a snapshot of the aggregated cost entries, ordered by team and route. If reset is true, the aggregates are cleared.
+func (c *Cost) Report(reset bool) []Entry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entries := make([]Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, *entry)
+	}
+
+	if reset {
+		c.entries = nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Team != entries[j].Team {
+			return entries[i].Team < entries[j].Team
+		}
+
+		return entries[i].Route < entries[j].Route
+	})
+
+	return entries
+}
+
+// Endpoint returns an [http.Handler] responding with [Cost.Report] as JSON. A "reset" query parameter of "true" clears the aggregates.
+func (c *Cost) Endpoint() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reset, _ := strconv.ParseBool(r.URL.Query().Get("reset"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(c.Report(reset))
+	})
+}
+
+// Handler measures each request's costs, stamping the response with cost attribution header(s) and aggregating the costs by
+// team and route. It forwards the request to the next handler in the chain.
+func (c *Cost) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		start := c.options.Clock()
+
+		valuer := new(Valuer)
+
+		ctx = context.WithValue(ctx, key, valuer)
+
+		body := &reader{ReadCloser: r.Body}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = body
+		}
+
+		capture := &writer{ResponseWriter: w}
+		if c.options.Headers {
+			capture.stamp = func(header http.Header) {
+				header.Set("X-Cost-Duration", strconv.FormatInt(c.options.Clock().Sub(start).Milliseconds(), 10))
+				header.Set("X-Cost-Received", strconv.FormatInt(body.count, 10))
+				header.Set("X-Cost-Calls", strconv.FormatInt(valuer.Calls(), 10))
+			}
+		}
+
+		next.ServeHTTP(capture, r.WithContext(ctx))
+
+		team := ""
+		if c.options.Team != nil {
+			team = c.options.Team(r)
+		}
+
+		if team == "" {
+			team = "unattributed"
+		}
+
+		// The route middleware is optional; its absence isn't logged per request.
+		template, _ := route.Lookup(ctx)
+
+		c.aggregate(team, template, c.options.Clock().Sub(start), body.count, capture.count, valuer.Calls())
+	})
+}
+
+// New creates a new instance of the [Cost] middleware, implementing [middleware.Configurable]. If [Cost.Settings] isn't called,
+// then the [Cost.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to serve [Cost.Endpoint], or to call [Cost.Report].
+func New() *Cost {
+	return new(Cost)
+}
+
+// Value retrieves the request's cost [Valuer] from the provided context. If a nil value is returned, it can be assumed that the
+// [Cost] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// reader counts the request body's bytes read.
+type reader struct {
+	io.ReadCloser
+
+	count int64
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, e := r.ReadCloser.Read(p)
+
+	r.count += int64(n)
+
+	return n, e
+}
+
+// writer counts the response body's bytes written, and stamps cost header(s) prior to writing the response's header(s).
+type writer struct {
+	http.ResponseWriter
+
+	stamp   func(header http.Header)
+	written bool
+	count   int64
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) {
+		w.written = true
+
+		if w.stamp != nil {
+			w.stamp(w.ResponseWriter.Header())
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	n, e := w.ResponseWriter.Write(p)
+
+	w.count += int64(n)
+
+	return n, e
+}
+
+// Flush implements [http.Flusher], stamping the cost header(s) before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Cost] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Cost)(nil)
@@ -0,0 +1,187 @@
+package cost_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/cost"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Test(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+
+		cost.Value(r.Context()).Call(3)
+
+		w.WriteHeader(http.StatusCreated)
+
+		io.WriteString(w, "created")
+	})
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})
+
+	// now represents a deterministic clock, advancing 5 milliseconds per call.
+	now := time.Unix(0, 0)
+	clock := func() time.Time {
+		now = now.Add(time.Millisecond * 5)
+
+		return now
+	}
+
+	instance := cost.New()
+
+	chain := middleware.New()
+
+	chain.Add(route.New().Settings(func(o *route.Options) { o.Mux = mux }).Handler)
+	chain.Add(instance.Settings(func(o *cost.Options) { o.Clock = clock }).Handler)
+
+	server := httptest.NewServer(chain.Handler(mux))
+
+	defer server.Close()
+
+	// evaluate issues a request against the server on behalf of the provided team.
+	evaluate := func(t *testing.T, method, path, team, body string) *http.Response {
+		t.Helper()
+
+		request, e := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Request: %v", e)
+		}
+
+		if team != "" {
+			request.Header.Set("X-Team", team)
+		}
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		io.Copy(io.Discard, response.Body)
+
+		response.Body.Close()
+
+		return response
+	}
+
+	t.Run("Headers", func(t *testing.T) {
+		response := evaluate(t, http.MethodPost, "/orders/1", "payments", "0123456789")
+
+		expectations := map[string]string{
+			"X-Cost-Duration": "5",
+			"X-Cost-Received": "10",
+			"X-Cost-Calls":    "3",
+		}
+
+		for k, expectation := range expectations {
+			if v := response.Header.Get(k); v != expectation {
+				t.Errorf("Header (%s) = %s\n    - Expectation = %s", k, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		evaluate(t, http.MethodPost, "/orders/2", "payments", "01234")
+		evaluate(t, http.MethodGet, "/health", "", "")
+
+		report := instance.Report(true)
+
+		expectations := []cost.Entry{
+			{Team: "payments", Route: "POST /orders/{id}", Requests: 2, Duration: time.Millisecond * 20, Received: 15, Sent: 14, Calls: 6},
+			{Team: "unattributed", Route: "GET /health", Requests: 1, Duration: time.Millisecond * 10, Received: 0, Sent: 2, Calls: 0},
+		}
+
+		if len(report) != len(expectations) {
+			t.Fatalf("Report = %+v\n    - Expectation = %+v", report, expectations)
+		}
+
+		for index := range expectations {
+			if report[index] != expectations[index] {
+				t.Errorf("Entry = %+v\n    - Expectation = %+v", report[index], expectations[index])
+			}
+		}
+
+		if v := instance.Report(false); len(v) != 0 {
+			t.Errorf("Expected the Report to be Reset: %+v", v)
+		}
+	})
+
+	t.Run("Endpoint", func(t *testing.T) {
+		evaluate(t, http.MethodGet, "/health", "platform", "")
+
+		recorder := httptest.NewRecorder()
+
+		instance.Endpoint().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/costs", nil))
+
+		var entries []cost.Entry
+		if e := json.NewDecoder(recorder.Body).Decode(&entries); e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+		}
+
+		if len(entries) != 1 || entries[0].Team != "platform" || entries[0].Requests != 1 {
+			t.Errorf("Entries = %+v", entries)
+		}
+	})
+
+	t.Run("Cardinality-Limit", func(t *testing.T) {
+		limited := cost.New()
+
+		h := limited.Settings(func(o *cost.Options) {
+			o.Team = func(r *http.Request) string { return r.Header.Get("X-Team") }
+			o.Limit = 1
+		}).Handler(mux)
+
+		for _, team := range []string{"payments", "spoofed-1", "spoofed-2"} {
+			request := httptest.NewRequest(http.MethodGet, "/health", nil)
+			request.Header.Set("X-Team", team)
+
+			h.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		report := limited.Report(true)
+
+		if len(report) != 2 || report[0].Team != "other" || report[0].Route != "other" || report[0].Requests != 2 || report[1].Team != "payments" {
+			t.Errorf("Report = %+v\n    - Expectation = %s", report, "payments, and an overflow entry of 2 requests")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		valuer := new(cost.Valuer)
+		valuer.Call(3)
+
+		serialized, e := json.Marshal(valuer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if expectation := `{"calls":3}`; string(serialized) != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := cost.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		valuer := new(cost.Valuer)
+		valuer.Call(1)
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", valuer)
+		if v := cost.Value(ctx); v == nil || v.Calls() != 1 {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
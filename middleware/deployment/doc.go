@@ -0,0 +1,8 @@
+// Package deployment provides blue/green deployment awareness middleware: it exposes the instance's deployment color (or slot),
+// typically read from the "DEPLOYMENT_COLOR" environment variable, via the request context and an "X-Deployment" response header.
+//
+// During a cutover, the middleware can pin sticky clients to a consistent slot: a client's first request is assigned a slot - the
+// instance's own, or one chosen by [Options.Assign] (e.g. a canary's weighted selection) - and recorded in a cookie. Subsequent
+// requests for another slot are forwarded to that slot's handler in [Options.Slots] (e.g. an [net/http/httputil.ReverseProxy]),
+// such that clients don't alternate between versions mid-session.
+package deployment
@@ -0,0 +1,71 @@
+package deployment_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/deployment"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	// The "green" slot's handler would typically be a reverse proxy to the green deployment.
+	green := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Slot: %s (Proxied)\n", deployment.Value(r.Context()).Slot)
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware.Add(deployment.New().Settings(func(o *deployment.Options) {
+		o.Color = "blue"
+		o.Slots = map[string]http.Handler{"green": green}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Slot: %s\n", deployment.Value(r.Context()).Slot)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, cookie := range []string{"", "green"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		if cookie != "" {
+			request.AddCookie(&http.Cookie{Name: "deployment", Value: cookie})
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("X-Deployment: %s\n", response.Header.Get("X-Deployment"))
+	}
+
+	// Output:
+	// Slot: blue
+	// X-Deployment: blue
+	// Slot: green (Proxied)
+	// X-Deployment: green
+}
@@ -0,0 +1,185 @@
+package deployment
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "deployment"
+
+// Valuer is the context return type relating to the [Deployment] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Color represents the instance's deployment color, or slot - see [Options.Color].
+	Color string `json:"color"`
+
+	// Slot represents the slot assigned to the request. It differs from [Valuer.Color] only if the request was forwarded to
+	// another slot's handler - see [Options.Slots].
+	Slot string `json:"slot"`
+
+	// Sticky reports whether the [Valuer.Slot] was read from the client's [Options.Cookie].
+	Sticky bool `json:"sticky"`
+}
+
+// Options represents the configuration settings for the [Deployment] middleware component.
+type Options struct {
+	// Color represents the instance's deployment color, or slot (e.g. "blue", "green"). Defaults to the "DEPLOYMENT_COLOR"
+	// environment variable, or "blue" if unset.
+	Color string
+
+	// Header represents the response header identifying the request's assigned slot. An empty value disables the header. Defaults
+	// to "X-Deployment".
+	Header string
+
+	// Cookie represents the cookie pinning a client to its assigned slot. An empty value disables sticky assignment. Defaults to
+	// "deployment".
+	Cookie string
+
+	// Duration represents the sticky cookie's lifetime - typically the cutover's expected duration. Defaults to one hour.
+	Duration time.Duration
+
+	// Slots represents the handlers serving other slots' requests (e.g. a reverse proxy to the "green" deployment). Sticky clients
+	// assigned to a slot without a handler - or the instance's own [Options.Color] - are served by the next handler. Defaults to
+	// an empty map.
+	Slots map[string]http.Handler
+
+	// Assign optionally returns the slot assigned to a client without a (valid) sticky cookie - e.g. a canary's weighted selection,
+	// or the cutover's target slot. Unknown slots fall back to the instance's [Options.Color]. Defaults to nil, assigning the
+	// instance's own [Options.Color].
+	Assign func(r *http.Request) string
+}
+
+// Deployment represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Deployment struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Deployment] middleware's [Options] and returns the updated middleware instance.
+func (d *Deployment) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if d.options == nil {
+		d.options = &Options{
+			Color:    os.Getenv("DEPLOYMENT_COLOR"),
+			Header:   "X-Deployment",
+			Cookie:   "deployment",
+			Duration: time.Hour,
+			Slots:    map[string]http.Handler{},
+			Assign:   nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(d.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if d.options.Color == "" {
+		slog.Warn("Invalid Deployment Color Specified - Using Default Color")
+
+		d.options.Color = "blue"
+	}
+
+	if d.options.Duration <= 0 {
+		slog.Warn("Invalid Deployment Duration Specified - Using Default Duration")
+
+		d.options.Duration = time.Hour
+	}
+
+	return d
+}
+
+// known reports whether the slot is the instance's own, or has a handler.
+func (d *Deployment) known(slot string) bool {
+	if slot == d.options.Color {
+		return true
+	}
+
+	handler, ok := d.options.Slots[slot]
+
+	return ok && handler != nil
+}
+
+// Handler assigns the request a deployment slot - its sticky cookie's, [Options.Assign]'s, or the instance's own - records it in the
+// request context, and the [Options.Header] response header. Requests assigned another slot are forwarded to its [Options.Slots] handler.
+func (d *Deployment) Handler(next http.Handler) http.Handler {
+	d.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Color: d.options.Color, Slot: d.options.Color}
+
+		if d.options.Cookie != "" {
+			if cookie, e := r.Cookie(d.options.Cookie); e == nil && d.known(cookie.Value) {
+				valuer.Slot, valuer.Sticky = cookie.Value, true
+			}
+		}
+
+		if !(valuer.Sticky) && d.options.Assign != nil {
+			if slot := d.options.Assign(r); d.known(slot) {
+				valuer.Slot = slot
+			} else if slot != "" {
+				slog.DebugContext(ctx, "Unknown Deployment Slot Assigned - Using Instance Color", slog.String("slot", slot), slog.String("color", d.options.Color))
+			}
+		}
+
+		if !(valuer.Sticky) && d.options.Cookie != "" {
+			http.SetCookie(w, &http.Cookie{
+				Name:     d.options.Cookie,
+				Value:    valuer.Slot,
+				Path:     "/",
+				MaxAge:   int(d.options.Duration.Seconds()),
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		if d.options.Header != "" {
+			w.Header().Set(d.options.Header, valuer.Slot)
+		}
+
+		ctx = context.WithValue(ctx, key, valuer)
+
+		if handler := d.options.Slots[valuer.Slot]; valuer.Slot != d.options.Color && handler != nil {
+			handler.ServeHTTP(w, r.WithContext(ctx))
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Deployment] middleware, implementing [middleware.Configurable]. If [Deployment.Settings]
+// isn't called, then the [Deployment.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Deployment)
+}
+
+// Value retrieves the request's deployment [Valuer] from the provided context. If a nil value is returned, it can be assumed that
+// the [Deployment] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Deployment] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Deployment)(nil)
@@ -0,0 +1,108 @@
+package deployment_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/deployment"
+)
+
+func Test(t *testing.T) {
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := deployment.Value(r.Context())
+
+			w.Header().Set("X-Handler", name)
+			w.Header().Set("X-Sticky", strconv.FormatBool(v.Sticky))
+
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	slots := func(o *deployment.Options) {
+		o.Color = "blue"
+		o.Slots = map[string]http.Handler{"green": handler("green")}
+	}
+
+	tests := []struct {
+		name    string
+		options func(o *deployment.Options)
+		cookie  string
+		slot    string
+		target  string
+		sticky  bool
+		set     bool
+	}{
+		{name: "Default", options: func(o *deployment.Options) { o.Color = "blue" }, slot: "blue", target: "next", set: true},
+		{name: "Sticky-Own", options: slots, cookie: "blue", slot: "blue", target: "next", sticky: true},
+		{name: "Sticky-Other", options: slots, cookie: "green", slot: "green", target: "green", sticky: true},
+		{name: "Sticky-Unknown", options: slots, cookie: "red", slot: "blue", target: "next", set: true},
+		{name: "Assign", options: func(o *deployment.Options) {
+			slots(o)
+			o.Assign = func(r *http.Request) string { return "green" }
+		}, slot: "green", target: "green", set: true},
+		{name: "Assign-Unknown", options: func(o *deployment.Options) {
+			slots(o)
+			o.Assign = func(r *http.Request) string { return "red" }
+		}, slot: "blue", target: "next", set: true},
+		{name: "Assign-Ignored-When-Sticky", options: func(o *deployment.Options) {
+			slots(o)
+			o.Assign = func(r *http.Request) string { return "green" }
+		}, cookie: "blue", slot: "blue", target: "next", sticky: true},
+		{name: "Cookie-Disabled", options: func(o *deployment.Options) {
+			slots(o)
+			o.Cookie = ""
+		}, cookie: "green", slot: "blue", target: "next"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				request.AddCookie(&http.Cookie{Name: "deployment", Value: tt.cookie})
+			}
+
+			recorder := httptest.NewRecorder()
+
+			deployment.New().Settings(tt.options).Handler(handler("next")).ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Deployment"); v != tt.slot {
+				t.Errorf("X-Deployment = %s\n    - Expectation = %s", v, tt.slot)
+			}
+
+			if v := recorder.Header().Get("X-Handler"); v != tt.target {
+				t.Errorf("X-Handler = %s\n    - Expectation = %s", v, tt.target)
+			}
+
+			if v := recorder.Header().Get("X-Sticky"); v != strconv.FormatBool(tt.sticky) {
+				t.Errorf("X-Sticky = %s\n    - Expectation = %t", v, tt.sticky)
+			}
+
+			cookies := recorder.Result().Cookies()
+			if set := len(cookies) == 1 && cookies[0].Name == "deployment" && cookies[0].Value == tt.slot && cookies[0].HttpOnly; set != tt.set {
+				t.Errorf("Set-Cookie = %v\n    - Expectation = %t", cookies, tt.set)
+			}
+		})
+	}
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("DEPLOYMENT_COLOR", "green")
+
+		recorder := httptest.NewRecorder()
+
+		deployment.New().Handler(handler("next")).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Get("X-Deployment"); v != "green" {
+			t.Errorf("X-Deployment = %s\n    - Expectation = %s", v, "green")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := deployment.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
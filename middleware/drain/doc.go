@@ -0,0 +1,22 @@
+// Package drain provides graceful shutdown-aware draining middleware.
+//
+// [Drain.Shutdown] flips the chain into draining mode: subsequent requests receive a [net/http.StatusServiceUnavailable] response
+// and a "Connection: close" header - prompting clients, and load balancers, to retry elsewhere - while in-flight requests are
+// tracked until they complete. The caller blocks until every in-flight request completes, or the context's deadline passes:
+//
+//	instance := drain.New()
+//
+//	...
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+//	defer cancel()
+//
+//	if e := instance.Shutdown(ctx); e != nil {
+//		slog.Warn("In-Flight Requests Outstanding", slog.Int64("requests", instance.InFlight()))
+//	}
+//
+//	server.Shutdown(ctx)
+//
+// Unlike [net/http.Server.Shutdown], draining keeps the listener open - e.g. such that a load balancer's readiness probe observes
+// the failing responses before connections are refused.
+package drain
@@ -0,0 +1,63 @@
+package drain_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/drain"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	instance := drain.New()
+
+	middleware.Add(instance.Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	if e := instance.Shutdown(ctx); e != nil {
+		e = fmt.Errorf("unexpected error while draining: %w", e)
+
+		panic(e)
+	}
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	fmt.Println(response.StatusCode, response.Close)
+
+	// Output:
+	// 503 true
+}
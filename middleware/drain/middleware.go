@@ -0,0 +1,170 @@
+package drain
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// Options represents the configuration settings for the [Drain] middleware component.
+type Options struct {
+	// Exempt represents the paths served throughout draining (e.g. a liveness probe). Entries ending in "/" match the path's subtree;
+	// other entries match exactly. Exempt requests are tracked as in-flight requests. Defaults to an empty slice.
+	Exempt []string
+
+	// Retry represents the Retry-After header's value. Defaults to 5 seconds.
+	Retry time.Duration
+
+	// Message represents the draining response's message. Defaults to "Service Draining".
+	Message string
+}
+
+// Drain represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Drain struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex    sync.RWMutex // mutex orders the draining flag's change with the group's additions.
+	draining bool
+	group    sync.WaitGroup
+	inflight atomic.Int64
+}
+
+// Settings applies configuration functions to modify the [Drain] middleware's [Options] and returns the updated middleware instance.
+func (x *Drain) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Exempt:  []string{},
+			Retry:   time.Second * 5,
+			Message: "Service Draining",
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Retry <= 0 {
+		slog.Warn("Invalid Drain Retry Specified - Using Default Retry")
+
+		x.options.Retry = time.Second * 5
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = "Service Draining"
+	}
+
+	return x
+}
+
+// Shutdown flips the chain into draining mode, then blocks until every in-flight request completes - returning nil - or until the
+// context is done, returning the context's error. Subsequent calls re-enter the wait. It's safe for concurrent use.
+func (x *Drain) Shutdown(ctx context.Context) error {
+	x.mutex.Lock()
+	if !(x.draining) {
+		x.draining = true
+
+		slog.InfoContext(ctx, "Draining In-Flight Requests", slog.Int64("requests", x.inflight.Load()))
+	}
+	x.mutex.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		x.group.Wait()
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		slog.WarnContext(ctx, "Drain Deadline Exceeded", slog.Int64("requests", x.inflight.Load()))
+
+		return ctx.Err()
+	}
+}
+
+// Draining reports whether [Drain.Shutdown] was called.
+func (x *Drain) Draining() bool {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	return x.draining
+}
+
+// InFlight returns the number of in-flight requests.
+func (x *Drain) InFlight() int64 {
+	return x.inflight.Load()
+}
+
+// exempt reports whether the path is served throughout draining.
+func (x *Drain) exempt(path string) bool {
+	for _, exemption := range x.options.Exempt {
+		if path == exemption || (strings.HasSuffix(exemption, "/") && strings.HasPrefix(path, exemption)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handler tracks in-flight requests. Once draining, non-exempt requests receive a [http.StatusServiceUnavailable] response, a
+// Retry-After header, and a "Connection: close" header.
+func (x *Drain) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		x.mutex.RLock()
+		if x.draining && !(x.exempt(r.URL.Path)) {
+			x.mutex.RUnlock()
+
+			reject.Record(r.Context(), reject.Reason{Subsystem: "drain", Code: "draining", Status: http.StatusServiceUnavailable})
+
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((x.options.Retry+time.Second-1)/time.Second), 10))
+			w.Header().Set("Cache-Control", "no-store")
+
+			http.Error(w, x.options.Message, http.StatusServiceUnavailable)
+
+			return
+		}
+
+		x.group.Add(1)
+		x.mutex.RUnlock()
+
+		x.inflight.Add(1)
+
+		defer func() {
+			x.inflight.Add(-1)
+			x.group.Done()
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// New creates a new instance of the [Drain] middleware, implementing [middleware.Configurable]. If [Drain.Settings] isn't called,
+// then the [Drain.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to call [Drain.Shutdown].
+func New() *Drain {
+	return new(Drain)
+}
+
+// Runtime assurance that [Drain] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Drain)(nil)
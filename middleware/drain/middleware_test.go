@@ -0,0 +1,135 @@
+package drain_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/drain"
+)
+
+func Test(t *testing.T) {
+	t.Run("Draining", func(t *testing.T) {
+		instance := drain.New()
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		handler := instance.Settings(func(o *drain.Options) {
+			o.Exempt = []string{"/livez"}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				close(started)
+				<-release
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+		<-started
+
+		if v := instance.InFlight(); v != 1 {
+			t.Errorf("InFlight = %d\n    - Expectation = %d", v, 1)
+		}
+
+		// The deadline passes while the slow request is in-flight.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*25)
+		defer cancel()
+
+		if e := instance.Shutdown(ctx); !(errors.Is(e, context.DeadlineExceeded)) {
+			t.Errorf("Shutdown Error = %v\n    - Expectation = %v", e, context.DeadlineExceeded)
+		}
+
+		if !(instance.Draining()) {
+			t.Errorf("Draining = %t\n    - Expectation = %t", false, true)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+
+		if v := recorder.Header().Get("Connection"); v != "close" {
+			t.Errorf("Connection = %s\n    - Expectation = %s", v, "close")
+		}
+
+		if v := recorder.Header().Get("Retry-After"); v != "5" {
+			t.Errorf("Retry-After = %s\n    - Expectation = %s", v, "5")
+		}
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Exempt Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+
+		close(release)
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if e := instance.Shutdown(ctx); e != nil {
+			t.Errorf("Shutdown Error = %v\n    - Expectation = %v", e, nil)
+		}
+
+		if v := instance.InFlight(); v != 0 {
+			t.Errorf("InFlight = %d\n    - Expectation = %d", v, 0)
+		}
+	})
+
+	t.Run("Idle", func(t *testing.T) {
+		instance := drain.New()
+
+		instance.Handler(http.NotFoundHandler())
+
+		if e := instance.Shutdown(context.Background()); e != nil {
+			t.Errorf("Shutdown Error = %v\n    - Expectation = %v", e, nil)
+		}
+	})
+
+	t.Run("Server", func(t *testing.T) {
+		instance := drain.New()
+
+		server := httptest.NewServer(instance.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})))
+
+		defer server.Close()
+
+		client := server.Client()
+
+		response, e := client.Get(server.URL)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+		}
+
+		if e := instance.Shutdown(context.Background()); e != nil {
+			t.Errorf("Shutdown Error = %v\n    - Expectation = %v", e, nil)
+		}
+
+		response, e = client.Get(server.URL)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusServiceUnavailable || !(response.Close) {
+			t.Errorf("Status = %d, Close = %t\n    - Expectation = %d, %t", response.StatusCode, response.Close, http.StatusServiceUnavailable, true)
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Package form provides middleware parsing and validating URL-encoded ("application/x-www-form-urlencoded") request bodies, with
+// integrated cross-site request forgery (CSRF) verification for browser form posts.
+//
+// Parsed values are bounded by body-size and value-count caps, optionally restricted to an allowlist of field names, and stored in
+// the request context (see [Value]). The request's body and [http.Request.PostForm] remain available to downstream handlers.
+//
+// By default, CSRF verification uses the double-submit-cookie pattern: the middleware issues each client a random token cookie,
+// exposed via [Valuer.Token] for embedding in rendered forms, and form posts must submit the same token in a form field or request
+// header. Existing CSRF mechanisms can instead be integrated via [Options.Verify].
+package form
@@ -0,0 +1,84 @@
+package form_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/form"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(form.New().Settings(func(o *form.Options) {
+		o.Fields = []string{"name"}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		// Render the form's hidden CSRF token input.
+		fmt.Fprint(w, form.Value(r.Context()).Token)
+		return
+	})
+
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Name: %s\n", form.Value(r.Context()).Values.Get("name"))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	jar, e := cookiejar.New(nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating cookie jar: %w", e)
+
+		panic(e)
+	}
+
+	client := server.Client()
+	client.Jar = jar
+
+	response, e := client.Get(server.URL)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	token, e := io.ReadAll(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading token: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	for _, v := range []string{string(token), "forged"} {
+		response, e = client.PostForm(server.URL, url.Values{"name": {"Alice"}, "csrf_token": {v}})
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Name: Alice
+	// Status: 200
+	// Status: 403
+}
@@ -0,0 +1,318 @@
+package form
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "form"
+
+// Valuer is the context return type relating to the [Form] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Values represents the request's parsed form values, excluding the CSRF token field. Nil for requests without a URL-encoded body.
+	Values url.Values `json:"values,omitempty"`
+
+	// Token represents the client's CSRF token, to be embedded in rendered forms' [Options.Field] hidden input. Empty if CSRF
+	// verification is disabled, or delegated to [Options.Verify].
+	Token string `json:"-"`
+}
+
+// sensitive represents case-insensitive substrings of form field names whose values are redacted from a serialized [Valuer].
+var sensitive = []string{"password", "passwd", "secret", "token", "cvv"}
+
+// MarshalJSON encodes the [Valuer]'s form values, replacing the values of credential-like fields (e.g. "password",
+// "api_token") with "[REDACTED]". The CSRF token is never serialized.
+func (v Valuer) MarshalJSON() ([]byte, error) {
+	type alias Valuer // alias sheds the MarshalJSON method, avoiding recursion.
+
+	value := alias(v)
+	if v.Values != nil {
+		value.Values = make(url.Values, len(v.Values))
+		for k, values := range v.Values {
+			value.Values[k] = values
+
+			for _, substring := range sensitive {
+				if strings.Contains(strings.ToLower(k), substring) {
+					value.Values[k] = make([]string, len(values))
+					for index := range values {
+						value.Values[k][index] = "[REDACTED]"
+					}
+
+					break
+				}
+			}
+		}
+	}
+
+	return json.Marshal(value)
+}
+
+// Options represents the configuration settings for the [Form] middleware component.
+type Options struct {
+	// Limit represents the maximum form body size, in bytes. Larger bodies receive a [http.StatusRequestEntityTooLarge] response.
+	// Defaults to 64 KiB.
+	Limit int64
+
+	// Count represents the maximum number of form values. Bodies with more values receive a [http.StatusBadRequest] response.
+	// Defaults to 256.
+	Count int
+
+	// Fields represents the allowlist of accepted form field names. Bodies with other fields receive a [http.StatusBadRequest]
+	// response. An empty allowlist accepts every field. Defaults to an empty slice.
+	Fields []string
+
+	// CSRF enables cross-site request forgery verification for form posts. Defaults to true.
+	CSRF bool
+
+	// Verify optionally replaces the built-in double-submit-cookie verification - for integration with an existing CSRF
+	// mechanism - and is provided the request's submitted token. Defaults to nil.
+	Verify func(r *http.Request, token string) bool
+
+	// Field represents the form field carrying the CSRF token. Defaults to "csrf_token".
+	Field string
+
+	// Header represents the request header alternatively carrying the CSRF token (e.g. for script-submitted forms). Defaults to
+	// "X-CSRF-Token".
+	Header string
+
+	// Cookie represents the name of the cookie holding the client's CSRF token. Defaults to "__csrf".
+	Cookie string
+}
+
+// Form represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Form struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Form] middleware's [Options] and returns the updated middleware instance.
+func (f *Form) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if f.options == nil {
+		f.options = &Options{
+			Limit:  64 * 1024,
+			Count:  256,
+			Fields: []string{},
+			CSRF:   true,
+			Verify: nil,
+			Field:  "csrf_token",
+			Header: "X-CSRF-Token",
+			Cookie: "__csrf",
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(f.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if f.options.Limit <= 0 {
+		slog.Warn("Invalid Form Limit Specified - Using Default Limit")
+
+		f.options.Limit = 64 * 1024
+	}
+
+	if f.options.Count <= 0 {
+		slog.Warn("Invalid Form Count Specified - Using Default Count")
+
+		f.options.Count = 256
+	}
+
+	if f.options.Field == "" {
+		slog.Warn("Invalid Form CSRF Field Specified - Using Default Field")
+
+		f.options.Field = "csrf_token"
+	}
+
+	if f.options.Cookie == "" {
+		slog.Warn("Invalid Form CSRF Cookie Specified - Using Default Cookie")
+
+		f.options.Cookie = "__csrf"
+	}
+
+	return f
+}
+
+// token returns the client's CSRF token, issuing a new token cookie if the request doesn't carry one.
+func (f *Form) token(w http.ResponseWriter, r *http.Request) string {
+	if cookie, e := r.Cookie(f.options.Cookie); e == nil && len(cookie.Value) >= 32 {
+		return cookie.Value
+	}
+
+	random := make([]byte, 32)
+	if _, e := rand.Read(random); e != nil {
+		panic(e)
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(random)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     f.options.Cookie,
+		Value:    value,
+		Path:     "/",
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return value
+}
+
+// parse reads, parses, and validates the request's form body.
+func (f *Form) parse(w http.ResponseWriter, r *http.Request) (data []byte, values url.Values, status int, e error) {
+	data, e = io.ReadAll(http.MaxBytesReader(w, r.Body, f.options.Limit))
+	if e != nil {
+		if v := new(http.MaxBytesError); errors.As(e, &v) {
+			return nil, nil, http.StatusRequestEntityTooLarge, e
+		}
+
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	if n := bytes.Count(data, []byte("&")) + 1; n > f.options.Count {
+		return nil, nil, http.StatusBadRequest, errors.New("too many form values")
+	}
+
+	values, e = url.ParseQuery(string(data))
+	if e != nil {
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	if len(f.options.Fields) > 0 {
+		allowed := make(map[string]struct{}, len(f.options.Fields)+1)
+		for _, field := range f.options.Fields {
+			allowed[field] = struct{}{}
+		}
+
+		if f.options.CSRF {
+			allowed[f.options.Field] = struct{}{}
+		}
+
+		for field := range values {
+			if _, ok := allowed[field]; !(ok) {
+				return nil, nil, http.StatusBadRequest, errors.New("unexpected form field: " + field)
+			}
+		}
+	}
+
+	return data, values, http.StatusOK, nil
+}
+
+// verify reports whether the request's submitted CSRF token is valid.
+func (f *Form) verify(r *http.Request, submitted, expected string) bool {
+	if f.options.Verify != nil {
+		return f.options.Verify(r, submitted)
+	}
+
+	return submitted != "" && subtle.ConstantTimeCompare([]byte(submitted), []byte(expected)) == 1
+}
+
+// Handler parses and validates URL-encoded request bodies - storing the parsed values in the request context - and verifies form
+// posts' CSRF tokens. Requests without a URL-encoded body are forwarded to the next handler with their CSRF token.
+func (f *Form) Handler(next http.Handler) http.Handler {
+	f.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{}
+
+		if f.options.CSRF && f.options.Verify == nil {
+			valuer.Token = f.token(w, r)
+		}
+
+		mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediatype != "application/x-www-form-urlencoded" || r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+
+			return
+		}
+
+		data, values, status, e := f.parse(w, r)
+		if e != nil {
+			slog.WarnContext(ctx, "Invalid Form Request Body", slog.String("error", e.Error()), slog.Int("status", status))
+
+			http.Error(w, http.StatusText(status), status)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		default:
+			if !(f.options.CSRF) {
+				break
+			}
+
+			submitted := values.Get(f.options.Field)
+			if submitted == "" && f.options.Header != "" {
+				submitted = r.Header.Get(f.options.Header)
+			}
+
+			if !(f.verify(r, submitted, valuer.Token)) {
+				slog.WarnContext(ctx, "Invalid CSRF Token", slog.String("url", r.URL.String()))
+
+				http.Error(w, "Invalid CSRF Token", http.StatusForbidden)
+
+				return
+			}
+		}
+
+		values.Del(f.options.Field)
+
+		valuer.Values = values
+
+		r = r.WithContext(context.WithValue(ctx, key, valuer))
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		r.PostForm = values
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// New creates a new instance of the [Form] middleware, implementing [middleware.Configurable]. If [Form.Settings] isn't called,
+// then the [Form.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Form)
+}
+
+// Value retrieves the request's parsed form and CSRF token from the provided context. If a nil value is returned, it can be
+// assumed that the [Form] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Form] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Form)(nil)
@@ -0,0 +1,172 @@
+package form_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/form"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := form.Value(r.Context())
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Values", v.Values.Encode())
+		w.Header().Set("X-Token", v.Token)
+		w.Header().Set("X-Form-Value", r.FormValue("name"))
+		w.Header().Set("X-Body", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	token := strings.Repeat("t", 43)
+
+	tests := []struct {
+		name    string
+		options func(o *form.Options)
+		method  string
+		content string
+		body    string
+		cookie  string
+		header  string
+		status  int
+		values  string
+	}{
+		{name: "Valid", method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + token, cookie: token, status: http.StatusOK, values: "name=alice"},
+		{name: "Valid-Header-Token", method: http.MethodPost, content: "application/x-www-form-urlencoded; charset=utf-8", body: "name=alice", cookie: token, header: token, status: http.StatusOK, values: "name=alice"},
+		{name: "Missing-Token", method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice", cookie: token, status: http.StatusForbidden},
+		{name: "Missing-Cookie", method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + token, status: http.StatusForbidden},
+		{name: "Mismatched-Token", method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + strings.Repeat("x", 43), cookie: token, status: http.StatusForbidden},
+		{name: "CSRF-Disabled", options: func(o *form.Options) { o.CSRF = false }, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice", status: http.StatusOK, values: "name=alice"},
+		{name: "CSRF-Delegated", options: func(o *form.Options) {
+			o.Verify = func(r *http.Request, token string) bool { return token == "external" }
+		}, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=external", status: http.StatusOK, values: "name=alice"},
+		{name: "CSRF-Delegated-Invalid", options: func(o *form.Options) {
+			o.Verify = func(r *http.Request, token string) bool { return token == "external" }
+		}, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + token, cookie: token, status: http.StatusForbidden},
+		{name: "Allowlist", options: func(o *form.Options) { o.Fields = []string{"name"} }, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + token, cookie: token, status: http.StatusOK, values: "name=alice"},
+		{name: "Allowlist-Unexpected", options: func(o *form.Options) { o.Fields = []string{"name"} }, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&admin=true&csrf_token=" + token, cookie: token, status: http.StatusBadRequest},
+		{name: "Limit", options: func(o *form.Options) { o.Limit = 8 }, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=alice&csrf_token=" + token, cookie: token, status: http.StatusRequestEntityTooLarge},
+		{name: "Count", options: func(o *form.Options) { o.Count = 2 }, method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "a=1&b=2&csrf_token=" + token, cookie: token, status: http.StatusBadRequest},
+		{name: "Malformed", method: http.MethodPost, content: "application/x-www-form-urlencoded", body: "name=%zz&csrf_token=" + token, cookie: token, status: http.StatusBadRequest},
+		{name: "Non-Form", method: http.MethodPost, content: "application/json", body: `{"name": "alice"}`, status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := form.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(test.method, "/", strings.NewReader(test.body))
+			request.Header.Set("Content-Type", test.content)
+
+			if test.cookie != "" {
+				request.AddCookie(&http.Cookie{Name: "__csrf", Value: test.cookie})
+			}
+
+			if test.header != "" {
+				request.Header.Set("X-CSRF-Token", test.header)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if test.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("X-Values"); v != test.values {
+				t.Errorf("Values = %s\n    - Expectation = %s", v, test.values)
+			}
+
+			if v := recorder.Header().Get("X-Body"); v != test.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+
+			if expectation, _ := url.ParseQuery(test.values); recorder.Header().Get("X-Form-Value") != expectation.Get("name") {
+				t.Errorf("Form Value = %s\n    - Expectation = %s", recorder.Header().Get("X-Form-Value"), expectation.Get("name"))
+			}
+		})
+	}
+
+	t.Run("Token-Issuance", func(t *testing.T) {
+		m := form.New().Handler(handler)
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		cookies := recorder.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].Name != "__csrf" || !(cookies[0].HttpOnly) {
+			t.Fatalf("Invalid CSRF Cookie(s): %v", cookies)
+		}
+
+		if v := recorder.Header().Get("X-Token"); v != cookies[0].Value {
+			t.Errorf("Token = %s\n    - Expectation = %s", v, cookies[0].Value)
+		}
+
+		// The issued token must be accepted on a subsequent form post.
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=bob&csrf_token="+cookies[0].Value))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.AddCookie(cookies[0])
+
+		recorder = httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+
+		if v := recorder.Result().Cookies(); len(v) != 0 {
+			t.Errorf("Unexpected Reissued CSRF Cookie(s): %v", v)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		valuer := &form.Valuer{Values: url.Values{"name": {"Jane"}, "new_password": {"hunter2"}}, Token: "csrf"}
+
+		serialized, e := json.Marshal(valuer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if expectation := `{"values":{"name":["Jane"],"new_password":["[REDACTED]"]}}`; string(serialized) != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+		}
+
+		if valuer.Values.Get("new_password") != "hunter2" {
+			t.Errorf("Valuer Values Mutated During Encoding: %v", valuer.Values)
+		}
+
+		if serialized, _ := json.Marshal(form.Valuer{}); string(serialized) != `{}` {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, `{}`)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := form.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &form.Valuer{Values: url.Values{"name": {"alice"}}}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := form.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
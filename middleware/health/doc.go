@@ -0,0 +1,10 @@
+// Package health provides health check endpoint middleware, serving liveness ("/livez"), readiness ("/readyz"), and combined
+// ("/healthz") checks.
+//
+// Each endpoint runs its registered [Checker] functions concurrently, each bounded by [Options.Timeout], and aggregates their
+// results into a JSON [Report]; any failing check fails the endpoint with a [net/http.StatusServiceUnavailable] response. Once a
+// graceful shutdown begins (see the root package's Notifier), readiness fails, such that load balancers drain the instance.
+//
+// Health requests bypass the remainder of the chain. The [Health] middleware should therefore be the first in the chain, such that
+// authentication, rate limiting, and timeout middleware never interfere with probes.
+package health
@@ -0,0 +1,63 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/health"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(health.New().Settings(func(o *health.Options) {
+		o.Liveness["worker"] = func(ctx context.Context) error {
+			return nil
+		}
+
+		o.Readiness["database"] = func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/livez", "/readyz"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("%s: %d\n", path, response.StatusCode)
+	}
+
+	// Output:
+	// /livez: 200
+	// /readyz: 503
+}
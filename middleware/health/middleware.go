@@ -0,0 +1,272 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// Checker represents a health check. A nil error represents a passing check; the context is canceled once [Options.Timeout] elapses.
+type Checker func(ctx context.Context) error
+
+// Status represents a check's, or a [Report]'s, outcome.
+type Status string
+
+const (
+	// Pass represents a passing check.
+	Pass Status = "pass"
+
+	// Fail represents a failing check.
+	Fail Status = "fail"
+)
+
+// Result represents a single check's outcome.
+type Result struct {
+	// Status represents the check's outcome.
+	Status Status `json:"status"`
+
+	// Duration represents the check's duration.
+	Duration string `json:"duration"`
+
+	// Error represents the failing check's error message. It's only included if [Options.Errors] is enabled.
+	Error string `json:"error,omitempty"`
+}
+
+// Report represents a health endpoint's aggregated results.
+type Report struct {
+	// Status represents the aggregated outcome: [Fail] if any check failed, otherwise [Pass].
+	Status Status `json:"status"`
+
+	// Checks represents the individual checks' outcomes, keyed by name.
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Options represents the configuration settings for the [Health] middleware component.
+type Options struct {
+	// Health represents the combined endpoint's path, running both liveness and readiness checks. An empty value disables the
+	// endpoint. Defaults to "/healthz".
+	Health string
+
+	// Live represents the liveness endpoint's path. An empty value disables the endpoint. Defaults to "/livez".
+	Live string
+
+	// Ready represents the readiness endpoint's path. An empty value disables the endpoint. Defaults to "/readyz".
+	Ready string
+
+	// Liveness represents the liveness checks, keyed by name - checks whose failure warrants restarting the process (e.g. a
+	// deadlocked worker). Defaults to an empty map.
+	Liveness map[string]Checker
+
+	// Readiness represents the readiness checks, keyed by name - checks whose failure warrants withholding traffic (e.g. an
+	// unreachable database). Defaults to an empty map.
+	Readiness map[string]Checker
+
+	// Timeout represents each check's maximum duration; a check exceeding it fails. Defaults to 2 seconds.
+	Timeout time.Duration
+
+	// Shutdown fails readiness once a graceful shutdown begins - see the root package's Notifier. Defaults to true.
+	Shutdown bool
+
+	// Errors includes failing checks' error messages in the [Report]. Defaults to false, as errors may expose internal details.
+	Errors bool
+}
+
+// Health represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Health struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Health] middleware's [Options] and returns the updated middleware instance.
+func (x *Health) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Health:    "/healthz",
+			Live:      "/livez",
+			Ready:     "/readyz",
+			Liveness:  map[string]Checker{},
+			Readiness: map[string]Checker{},
+			Timeout:   time.Second * 2,
+			Shutdown:  true,
+			Errors:    false,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Timeout <= 0 {
+		slog.Warn("Invalid Health Timeout Specified - Using Default Timeout")
+
+		x.options.Timeout = time.Second * 2
+	}
+
+	return x
+}
+
+// errStopping represents the readiness failure reported once a graceful shutdown begins.
+var errStopping = errors.New("graceful shutdown in progress")
+
+// evaluate runs the checks concurrently, returning their aggregated [Report].
+func (x *Health) evaluate(ctx context.Context, checks map[string]Checker) Report {
+	report := Report{Status: Pass, Checks: make(map[string]Result, len(checks))}
+
+	var mutex sync.Mutex
+	var group sync.WaitGroup
+
+	for name, check := range checks {
+		if check == nil {
+			continue
+		}
+
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, x.options.Timeout)
+			defer cancel()
+
+			start := time.Now()
+
+			errs := make(chan error, 1)
+
+			go func() {
+				defer func() {
+					if exception := recover(); exception != nil {
+						errs <- errors.New("check panicked")
+					}
+				}()
+
+				errs <- check(ctx)
+			}()
+
+			var e error
+			select {
+			case e = <-errs:
+			case <-ctx.Done():
+				e = ctx.Err()
+			}
+
+			result := Result{Status: Pass, Duration: time.Since(start).Round(time.Microsecond).String()}
+			if e != nil {
+				slog.WarnContext(ctx, "Health Check Failed", slog.String("check", name), slog.String("error", e.Error()))
+
+				result.Status = Fail
+				if x.options.Errors {
+					result.Error = e.Error()
+				}
+			}
+
+			mutex.Lock()
+			report.Checks[name] = result
+			if result.Status == Fail {
+				report.Status = Fail
+			}
+			mutex.Unlock()
+		}()
+	}
+
+	group.Wait()
+
+	return report
+}
+
+// checks returns the checks of the endpoint serving the path, and whether the path is a health endpoint.
+func (x *Health) checks(ctx context.Context, path string) (map[string]Checker, bool) {
+	live, ready := path != "" && (path == x.options.Live || path == x.options.Health), path != "" && (path == x.options.Ready || path == x.options.Health)
+	if !(live || ready) {
+		return nil, false
+	}
+
+	checks := make(map[string]Checker)
+	if live {
+		for name, check := range x.options.Liveness {
+			checks[name] = check
+		}
+	}
+
+	if ready {
+		for name, check := range x.options.Readiness {
+			checks[name] = check
+		}
+
+		if x.options.Shutdown {
+			stopping := middleware.Stopping(ctx)
+
+			checks["shutdown"] = func(ctx context.Context) error {
+				select {
+				case <-stopping:
+					return errStopping
+				default:
+					return nil
+				}
+			}
+		}
+	}
+
+	return checks, true
+}
+
+// Handler serves the health endpoints, bypassing the next handler; other requests are forwarded unchanged. Health endpoints only
+// accept GET and HEAD requests, and respond with a JSON [Report] - [http.StatusOK] if every check passes, otherwise
+// [http.StatusServiceUnavailable].
+func (x *Health) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		checks, ok := x.checks(ctx, r.URL.Path)
+		if !(ok) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		report := x.evaluate(ctx, checks)
+
+		status := http.StatusOK
+		if report.Status == Fail {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		w.WriteHeader(status)
+
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(report)
+		}
+	})
+}
+
+// New creates a new instance of the [Health] middleware, implementing [middleware.Configurable]. If [Health.Settings] isn't called,
+// then the [Health.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Health)
+}
+
+// Runtime assurance that [Health] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Health)(nil)
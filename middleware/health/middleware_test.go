@@ -0,0 +1,260 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/health"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	passing := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("database unreachable") }
+	blocking := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name     string
+		settings func(o *health.Options)
+		method   string
+		path     string
+		status   int
+		report   health.Status
+		checks   map[string]health.Status
+		error    string
+	}{
+		{name: "Passthrough", path: "/orders", status: http.StatusTeapot},
+		{name: "Empty", path: "/healthz", status: http.StatusOK, report: health.Pass, checks: map[string]health.Status{"shutdown": health.Pass}},
+		{
+			name: "Pass",
+			settings: func(o *health.Options) {
+				o.Liveness["worker"] = passing
+				o.Readiness["database"] = passing
+			},
+			path:   "/healthz",
+			status: http.StatusOK,
+			report: health.Pass,
+			checks: map[string]health.Status{"worker": health.Pass, "database": health.Pass, "shutdown": health.Pass},
+		},
+		{
+			name: "Fail",
+			settings: func(o *health.Options) {
+				o.Readiness["database"] = failing
+			},
+			path:   "/readyz",
+			status: http.StatusServiceUnavailable,
+			report: health.Fail,
+			checks: map[string]health.Status{"database": health.Fail, "shutdown": health.Pass},
+		},
+		{
+			name: "Liveness-Excludes-Readiness",
+			settings: func(o *health.Options) {
+				o.Liveness["worker"] = passing
+				o.Readiness["database"] = failing
+			},
+			path:   "/livez",
+			status: http.StatusOK,
+			report: health.Pass,
+			checks: map[string]health.Status{"worker": health.Pass},
+		},
+		{
+			name: "Timeout",
+			settings: func(o *health.Options) {
+				o.Timeout = time.Millisecond * 10
+				o.Errors = true
+				o.Liveness["worker"] = blocking
+			},
+			path:   "/livez",
+			status: http.StatusServiceUnavailable,
+			report: health.Fail,
+			checks: map[string]health.Status{"worker": health.Fail},
+			error:  context.DeadlineExceeded.Error(),
+		},
+		{
+			name: "Errors-Omitted",
+			settings: func(o *health.Options) {
+				o.Liveness["worker"] = failing
+			},
+			path:   "/livez",
+			status: http.StatusServiceUnavailable,
+			report: health.Fail,
+			checks: map[string]health.Status{"worker": health.Fail},
+		},
+		{
+			name: "Panic",
+			settings: func(o *health.Options) {
+				o.Errors = true
+				o.Liveness["worker"] = func(ctx context.Context) error { panic("unexpected") }
+			},
+			path:   "/livez",
+			status: http.StatusServiceUnavailable,
+			report: health.Fail,
+			checks: map[string]health.Status{"worker": health.Fail},
+			error:  "check panicked",
+		},
+		{
+			name: "Custom-Path",
+			settings: func(o *health.Options) {
+				o.Ready = "/status/ready"
+			},
+			path:   "/status/ready",
+			status: http.StatusOK,
+			report: health.Pass,
+			checks: map[string]health.Status{"shutdown": health.Pass},
+		},
+		{
+			name: "Disabled-Path",
+			settings: func(o *health.Options) {
+				o.Health = ""
+			},
+			path:   "/healthz",
+			status: http.StatusTeapot,
+		},
+		{name: "Method-Not-Allowed", method: http.MethodPost, path: "/healthz", status: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(health.New().Settings(tt.settings).Handler(handler))
+
+			defer server.Close()
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			request, e := http.NewRequest(method, server.URL+tt.path, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, tt.status)
+			}
+
+			if tt.report == "" {
+				return
+			}
+
+			if v := response.Header.Get("Cache-Control"); v != "no-store" {
+				t.Errorf("Cache-Control = %s\n    - Expectation = %s", v, "no-store")
+			}
+
+			var report health.Report
+			if e := json.NewDecoder(response.Body).Decode(&report); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Report: %v", e)
+			}
+
+			if report.Status != tt.report {
+				t.Errorf("Report Status = %s\n    - Expectation = %s", report.Status, tt.report)
+			}
+
+			if len(report.Checks) != len(tt.checks) {
+				t.Errorf("Checks = %v\n    - Expectation = %v", report.Checks, tt.checks)
+			}
+
+			for name, status := range tt.checks {
+				if result := report.Checks[name]; result.Status != status {
+					t.Errorf("Check %s = %s\n    - Expectation = %s", name, result.Status, status)
+				}
+			}
+
+			for name, result := range report.Checks {
+				if result.Status == health.Fail && result.Error != tt.error {
+					t.Errorf("Check %s Error = %q\n    - Expectation = %q", name, result.Error, tt.error)
+				}
+			}
+		})
+	}
+
+	t.Run("Head", func(t *testing.T) {
+		server := httptest.NewServer(health.New().Handler(handler))
+
+		defer server.Close()
+
+		response, e := server.Client().Head(server.URL + "/livez")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		notifier := new(middleware.Notifier)
+
+		server := httptest.NewServer(notifier.Handler(health.New().Handler(handler)))
+
+		defer server.Close()
+
+		status := func(path string) int {
+			response, e := server.Client().Get(server.URL + path)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			response.Body.Close()
+
+			return response.StatusCode
+		}
+
+		if v := status("/readyz"); v != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", v, http.StatusOK)
+		}
+
+		notifier.Notify()
+
+		if v := status("/readyz"); v != http.StatusServiceUnavailable {
+			t.Errorf("Readiness Status = %d\n    - Expectation = %d", v, http.StatusServiceUnavailable)
+		}
+
+		if v := status("/livez"); v != http.StatusOK {
+			t.Errorf("Liveness Status = %d\n    - Expectation = %d", v, http.StatusOK)
+		}
+	})
+
+	t.Run("Shutdown-Disabled", func(t *testing.T) {
+		notifier := new(middleware.Notifier)
+
+		notifier.Notify()
+
+		server := httptest.NewServer(notifier.Handler(health.New().Settings(func(o *health.Options) { o.Shutdown = false }).Handler(handler)))
+
+		defer server.Close()
+
+		response, e := server.Client().Get(server.URL + "/readyz")
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusOK)
+		}
+	})
+}
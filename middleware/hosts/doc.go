@@ -0,0 +1,10 @@
+// Package hosts provides middleware that validates a request's Host header against an allowlist of expected hostnames and IP
+// addresses, rejecting unexpected hosts.
+//
+// A DNS-rebinding attack points an attacker-controlled hostname at an internal address, allowing a victim's browser to reach
+// internal services (e.g. admin UIs) under the attacker's origin. Such requests carry the attacker's hostname in the Host header,
+// and are rejected by the middleware.
+//
+// Allowlist entries support leading wildcards ("*.example.com") matching any subdomain, and are matched case-insensitively. By
+// default, matching ignores the Host header's port; entries containing a port match only that port.
+package hosts
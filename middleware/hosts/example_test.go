@@ -0,0 +1,58 @@
+package hosts_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/hosts"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(hosts.New().Settings(func(o *hosts.Options) {
+		o.Hosts = []string{"127.0.0.1", "admin.internal"}
+		o.Level = nil
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	for _, host := range []string{"", "rebind.attacker.test"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		if host != "" {
+			request.Host = host
+		}
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Status: 200
+	// Status: 421
+}
@@ -0,0 +1,170 @@
+package hosts
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "hosts"
+
+// Options represents the configuration settings for the [Hosts] middleware component.
+type Options struct {
+	// Hosts represents the allowlist of expected hostnames and IP addresses (e.g. "example.com", "*.example.com", "127.0.0.1",
+	// "[::1]", or "localhost:8080"). An empty allowlist disables validation. Defaults to an empty slice.
+	Hosts []string
+
+	// Ports enables port-sensitive matching, requiring the Host header's port to match an entry's port. Entries without a port
+	// then only match Host headers without a port. Defaults to false.
+	Ports bool
+
+	// Status represents the response status code written for unexpected hosts. Only [http.StatusMisdirectedRequest] and
+	// [http.StatusBadRequest] are considered valid. Defaults to [http.StatusMisdirectedRequest].
+	Status int
+
+	// Message represents the plain-text response body written for unexpected hosts.
+	Message string
+
+	// Level specifies the log level used to record rejected hosts. A value of nil disables logging. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+}
+
+// Hosts represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Hosts struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Hosts] middleware's [Options] and returns the updated middleware instance.
+func (h *Hosts) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if h.options == nil {
+		h.options = &Options{
+			Hosts:   []string{},
+			Ports:   false,
+			Status:  http.StatusMisdirectedRequest,
+			Message: "Unexpected Host",
+			Level:   slog.LevelWarn,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(h.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if h.options.Status != http.StatusMisdirectedRequest && h.options.Status != http.StatusBadRequest {
+		slog.Warn("Invalid Hosts Status Specified - Using Default Status")
+
+		h.options.Status = http.StatusMisdirectedRequest
+	}
+
+	return h
+}
+
+// split separates a host from its optional port, lowercasing the host and removing IPv6 brackets and any trailing dot.
+func split(value string) (host, port string) {
+	host = strings.ToLower(strings.TrimSpace(value))
+	if v, p, e := net.SplitHostPort(host); e == nil {
+		host, port = v, p
+	}
+
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	return strings.TrimSuffix(host, "."), port
+}
+
+// match reports whether the host and port match the provided allowlist entry.
+func (h *Hosts) match(entry string, host, port string) bool {
+	pattern, expected := split(entry)
+
+	if expected != "" && expected != port {
+		return false
+	} else if h.options.Ports && expected == "" && port != "" {
+		return false
+	}
+
+	if suffix, wildcard := strings.CutPrefix(pattern, "*."); wildcard {
+		return strings.HasSuffix(host, "."+suffix) && len(host) > len(suffix)+1
+	}
+
+	return host == pattern
+}
+
+// Handler validates the request's Host header, rejecting unexpected hosts. It otherwise stores the normalized host in the request
+// context, and forwards the request to the next handler in the chain.
+func (h *Hosts) Handler(next http.Handler) http.Handler {
+	h.Settings() // Ensure the options field isn't nil.
+
+	if len(h.options.Hosts) == 0 {
+		slog.Warn("Hosts Middleware Allowlist Unspecified - Host Validation Disabled")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		host, port := split(r.Host)
+
+		if len(h.options.Hosts) > 0 {
+			allowed := false
+			for _, entry := range h.options.Hosts {
+				if h.match(entry, host, port) {
+					allowed = true
+					break
+				}
+			}
+
+			if !(allowed) {
+				if v := h.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Rejected Unexpected Host", slog.String("host", r.Host), slog.String("remote-address", r.RemoteAddr))
+				}
+
+				http.Error(w, h.options.Message, h.options.Status)
+
+				return
+			}
+		}
+
+		ctx = context.WithValue(ctx, key, host)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Hosts] middleware, implementing [middleware.Configurable]. If [Hosts.Settings] isn't called,
+// then the [Hosts.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Hosts)
+}
+
+// Value retrieves the request's validated, normalized host (without its port) from the provided context. If an empty string is
+// returned, it can be assumed that the [Hosts] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (host string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		host = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		host = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Hosts] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Hosts)(nil)
@@ -0,0 +1,79 @@
+package hosts_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/hosts"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Host", hosts.Value(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		options func(o *hosts.Options)
+		host    string
+		status  int
+		value   string
+	}{
+		{name: "Exact-Match", host: "admin.internal", status: http.StatusOK, value: "admin.internal"},
+		{name: "Case-Insensitive", host: "ADMIN.Internal", status: http.StatusOK, value: "admin.internal"},
+		{name: "Port-Insensitive", host: "admin.internal:8443", status: http.StatusOK, value: "admin.internal"},
+		{name: "Trailing-Dot", host: "admin.internal.", status: http.StatusOK, value: "admin.internal"},
+		{name: "Wildcard", host: "east.example.com", status: http.StatusOK, value: "east.example.com"},
+		{name: "Wildcard-Apex", host: "example.com", status: http.StatusMisdirectedRequest},
+		{name: "Wildcard-Suffix-Spoof", host: "attackerexample.com", status: http.StatusMisdirectedRequest},
+		{name: "IPv4", host: "127.0.0.1:80", status: http.StatusOK, value: "127.0.0.1"},
+		{name: "IPv6", host: "[::1]:8080", status: http.StatusOK, value: "::1"},
+		{name: "Entry-Port", host: "localhost:9090", status: http.StatusOK, value: "localhost"},
+		{name: "Entry-Port-Mismatch", host: "localhost:9091", status: http.StatusMisdirectedRequest},
+		{name: "Entry-Port-Missing", host: "localhost", status: http.StatusMisdirectedRequest},
+		{name: "Rebinding", host: "rebind.attacker.test", status: http.StatusMisdirectedRequest},
+		{name: "Port-Sensitive", options: func(o *hosts.Options) { o.Ports = true }, host: "admin.internal:8443", status: http.StatusMisdirectedRequest},
+		{name: "Bad-Request-Status", options: func(o *hosts.Options) { o.Status = http.StatusBadRequest }, host: "rebind.attacker.test", status: http.StatusBadRequest},
+		{name: "Invalid-Status", options: func(o *hosts.Options) { o.Status = http.StatusTeapot }, host: "rebind.attacker.test", status: http.StatusMisdirectedRequest},
+		{name: "Empty-Allowlist", options: func(o *hosts.Options) { o.Hosts = nil }, host: "rebind.attacker.test", status: http.StatusOK, value: "rebind.attacker.test"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := hosts.New().Settings(func(o *hosts.Options) {
+				o.Hosts = []string{"admin.internal", "*.example.com", "127.0.0.1", "[::1]", "localhost:9090"}
+				o.Level = nil
+			}, test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Host = test.host
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("X-Host"); v != test.value {
+				t.Errorf("Host = %s\n    - Expectation = %s", v, test.value)
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := hosts.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "example.com")
+		if v := hosts.Value(ctx); v != "example.com" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+// Package imaging provides on-the-fly image transformation middleware for configured image routes, resizing source images per the
+// "w", "h", and "q" query parameters, and negotiating modern output formats (e.g. WebP, AVIF) via the request's Accept header.
+//
+// Image codecs aren't built in; transformations are delegated to a [Transformer], e.g. one wrapping a third-party library:
+//
+//	imaging.New().Settings(func(o *imaging.Options) {
+//		o.Transformer = imaging.TransformerFunc(func(ctx context.Context, mediatype string, src []byte, p imaging.Parameters) ([]byte, string, error) {
+//			return transform(src, p) // e.g. github.com/davidbyttow/govips
+//		})
+//	})
+//
+// The source image is served by the next handler; its response is buffered, transformed, and cached by the source's ETag and the
+// transformation's [Parameters], such that repeated requests skip the transformation. Requested dimensions, source sizes, and
+// concurrent transformations are bounded by [Options], protecting the server from resource exhaustion.
+package imaging
@@ -0,0 +1,60 @@
+package imaging_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/imaging"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(imaging.New().Settings(func(o *imaging.Options) {
+		o.Transformer = imaging.TransformerFunc(func(ctx context.Context, mediatype string, src []byte, p imaging.Parameters) ([]byte, string, error) {
+			// A real transformer decodes, resizes, and re-encodes the source image.
+			return []byte(fmt.Sprintf("%dx%d", p.Width, p.Height)), p.Format, nil
+		})
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /images/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("..."))
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/images/logo.png?w=320&h=240", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Accept", "image/webp,*/*")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	fmt.Println(response.StatusCode, response.Header.Get("Content-Type"), response.Header.Get("Vary"))
+
+	// Output:
+	// 200 image/webp Accept
+}
@@ -0,0 +1,467 @@
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/cache"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "imaging"
+
+// Options represents the configuration settings for the [Imaging] middleware component.
+type Options struct {
+	// Routes represents the image routes' paths. Entries ending in "/" match the path's subtree; other entries match exactly.
+	// Defaults to "/images/".
+	Routes []string
+
+	// Transformer represents the image transformer. Defaults to nil - images are served as is.
+	Transformer Transformer
+
+	// Formats represents the negotiable output media types, in order of preference. A format is only selected if the request's
+	// Accept header explicitly lists it. Defaults to "image/avif", and "image/webp".
+	Formats []string
+
+	// Width represents the maximum requested width, in pixels. Defaults to 4096.
+	Width int
+
+	// Height represents the maximum requested height, in pixels. Defaults to 4096.
+	Height int
+
+	// Maximum represents the maximum source image size, in bytes; larger images are served as is. Defaults to 16 MiB.
+	Maximum int
+
+	// Concurrency represents the maximum number of concurrent transformations. Defaults to [runtime.NumCPU].
+	Concurrency int
+
+	// Cache represents the transformed images' cache, keyed by the source's ETag and the transformation's [Parameters]. Sources
+	// without an ETag aren't cached. Defaults to a cache of 256 images, each expiring after an hour.
+	Cache *cache.Cache[string, Image]
+}
+
+// Imaging represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Imaging struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	semaphore chan struct{}
+}
+
+// Settings applies configuration functions to modify the [Imaging] middleware's [Options] and returns the updated middleware instance.
+func (x *Imaging) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Routes:      []string{"/images/"},
+			Transformer: nil,
+			Formats:     []string{"image/avif", "image/webp"},
+			Width:       4096,
+			Height:      4096,
+			Maximum:     16 << 20,
+			Concurrency: runtime.NumCPU(),
+			Cache:       &cache.Cache[string, Image]{Capacity: 256, TTL: time.Hour},
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Width <= 0 {
+		slog.Warn("Invalid Imaging Width Specified - Using Default Width")
+
+		x.options.Width = 4096
+	}
+
+	if x.options.Height <= 0 {
+		slog.Warn("Invalid Imaging Height Specified - Using Default Height")
+
+		x.options.Height = 4096
+	}
+
+	if x.options.Maximum <= 0 {
+		slog.Warn("Invalid Imaging Maximum Specified - Using Default Maximum")
+
+		x.options.Maximum = 16 << 20
+	}
+
+	if x.options.Concurrency <= 0 {
+		slog.Warn("Invalid Imaging Concurrency Specified - Using Default Concurrency")
+
+		x.options.Concurrency = runtime.NumCPU()
+	}
+
+	if x.options.Cache == nil {
+		x.options.Cache = &cache.Cache[string, Image]{Capacity: 256, TTL: time.Hour}
+	}
+
+	if x.semaphore == nil || cap(x.semaphore) != x.options.Concurrency {
+		x.semaphore = make(chan struct{}, x.options.Concurrency)
+	}
+
+	return x
+}
+
+// route reports whether the path is an image route.
+func (x *Imaging) route(path string) bool {
+	for _, route := range x.options.Routes {
+		if path == route || (strings.HasSuffix(route, "/") && strings.HasPrefix(path, route)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parameters parses, and validates, the request's transformation parameters.
+func (x *Imaging) parameters(r *http.Request) (p Parameters, e error) {
+	query := r.URL.Query()
+
+	parse := func(name string, limit int) (int, error) {
+		value := query.Get(name)
+		if value == "" {
+			return 0, nil
+		}
+
+		n, e := strconv.Atoi(value)
+		if e != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid %q parameter: %q", name, value)
+		}
+
+		if n > limit {
+			return 0, fmt.Errorf("%q parameter exceeds the maximum of %d", name, limit)
+		}
+
+		return n, nil
+	}
+
+	if p.Width, e = parse("w", x.options.Width); e != nil {
+		return
+	}
+
+	if p.Height, e = parse("h", x.options.Height); e != nil {
+		return
+	}
+
+	if p.Quality, e = parse("q", 100); e != nil {
+		return
+	}
+
+	p.Format = x.negotiate(r.Header.Values("Accept"))
+
+	return
+}
+
+// negotiate returns the most preferred of [Options.Formats] explicitly accepted by the Accept header(s), or an empty string.
+func (x *Imaging) negotiate(accept []string) string {
+	accepted := make(map[string]bool)
+
+	for _, header := range accept {
+		for _, entry := range strings.Split(header, ",") {
+			mediatype, parameters, e := mime.ParseMediaType(strings.TrimSpace(entry))
+			if e != nil {
+				continue
+			}
+
+			if q, ok := parameters["q"]; ok {
+				if weight, e := strconv.ParseFloat(q, 64); e != nil || weight <= 0 {
+					accepted[mediatype] = false
+
+					continue
+				}
+			}
+
+			if _, ok := accepted[mediatype]; !(ok) {
+				accepted[mediatype] = true
+			}
+		}
+	}
+
+	for _, format := range x.options.Formats {
+		if accepted[format] {
+			return format
+		}
+	}
+
+	return ""
+}
+
+// transform returns the transformed image, loading it through [Options.Cache] if the source has an ETag. The number of concurrent
+// transformations is bounded by [Options.Concurrency].
+func (x *Imaging) transform(ctx context.Context, etag, mediatype string, src []byte, p Parameters) (Image, error) {
+	fn := func(ctx context.Context) (Image, error) {
+		select {
+		case x.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return Image{}, ctx.Err()
+		}
+
+		defer func() { <-x.semaphore }()
+
+		body, output, e := x.options.Transformer.Transform(ctx, mediatype, src, p)
+		if e != nil {
+			return Image{}, e
+		}
+
+		if output == "" {
+			output = mediatype
+		}
+
+		return Image{Type: output, Body: body}, nil
+	}
+
+	if etag == "" {
+		return fn(ctx)
+	}
+
+	return x.options.Cache.Load(ctx, etag+"|"+mediatype+"|"+p.String(), fn)
+}
+
+// writer buffers the source image, up to [Options.Maximum] bytes, until the handler returns; thereafter, the image is transformed or
+// written as is.
+type writer struct {
+	http.ResponseWriter
+
+	imaging *Imaging
+
+	status      int
+	written     bool // written represents whether the handler wrote the response's header(s).
+	passthrough bool // passthrough represents whether the buffered response was forwarded as is.
+	buffer      bytes.Buffer
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written, w.status = true, status
+
+	// Informational responses aren't buffered.
+	if status >= 100 && status < 200 {
+		w.written = false
+
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.passthrough {
+		return w.ResponseWriter.Write(p)
+	}
+
+	n, _ := w.buffer.Write(p)
+	if w.buffer.Len() > w.imaging.options.Maximum {
+		if e := w.forward(); e != nil {
+			return 0, e
+		}
+	}
+
+	return n, nil
+}
+
+// forward writes the response's header(s), and its buffered body, as is; subsequent writes pass through.
+func (w *writer) forward() error {
+	w.passthrough = true
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	defer w.buffer.Reset()
+
+	_, e := w.ResponseWriter.Write(w.buffer.Bytes())
+
+	return e
+}
+
+// Flush forwards the response as is - streamed responses aren't transformed - and flushes the underlying [http.ResponseWriter].
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !(w.passthrough) {
+		w.forward()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close finalizes the response, transforming the buffered image if it's eligible.
+func (w *writer) close(r *http.Request, p Parameters) {
+	if !(w.written) || w.passthrough {
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+
+	mediatype, _, e := mime.ParseMediaType(header.Get("Content-Type"))
+	if e != nil || w.status != http.StatusOK || !(strings.HasPrefix(mediatype, "image/")) || header.Get("Content-Encoding") != "" || w.buffer.Len() == 0 {
+		w.forward()
+
+		return
+	}
+
+	ctx := r.Context()
+
+	etag := header.Get("ETag")
+
+	image, e := w.imaging.transform(ctx, etag, mediatype, w.buffer.Bytes(), p)
+	if e != nil {
+		level := slog.LevelWarn
+		if errors.Is(e, ErrUnsupported) || errors.Is(e, context.Canceled) {
+			level = slog.LevelDebug
+		}
+
+		slog.Log(ctx, level, "Unable to Transform Image - Writing Source As Is", slog.String("path", r.URL.Path), slog.String("type", mediatype), slog.String("error", e.Error()))
+
+		w.forward()
+
+		return
+	}
+
+	header.Del("ETag")
+	header.Del("Accept-Ranges")
+
+	if etag != "" {
+		sum := sha256.Sum256([]byte(etag + "|" + p.String()))
+
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+		header.Set("ETag", etag)
+
+		for _, match := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+			if match = strings.TrimSpace(match); match == etag || match == strings.TrimPrefix(etag, "W/") {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+
+				w.ResponseWriter.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+		}
+	}
+
+	header.Set("Content-Type", image.Type)
+	header.Set("Content-Length", strconv.Itoa(len(image.Body)))
+
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+	w.ResponseWriter.Write(image.Body)
+}
+
+// Handler transforms images served by the next handler on [Options.Routes], per the request's [Parameters]. Invalid, or excessive,
+// parameters receive a [http.StatusBadRequest] response. Requests to other routes, non-GET requests, and requests without
+// parameters or a negotiated format, are forwarded unchanged.
+func (x *Imaging) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Transformer == nil {
+		slog.Warn("Imaging Transformer Not Specified - Images Are Served As Is")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || x.options.Transformer == nil || !(x.route(r.URL.Path)) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := r.Context()
+
+		if len(x.options.Formats) > 0 {
+			w.Header().Add("Vary", "Accept")
+		}
+
+		p, e := x.parameters(r)
+		if e != nil {
+			reject.Record(ctx, reject.Reason{Subsystem: "imaging", Code: "invalid-parameters", Status: http.StatusBadRequest})
+
+			http.Error(w, e.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		r = r.WithContext(context.WithValue(ctx, key, &p))
+
+		if p.Zero() {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		// The source image is requested in full, and unconditionally; conditional requests are evaluated against the transformed image.
+		source := r.Clone(r.Context())
+		for _, header := range []string{"If-None-Match", "If-Modified-Since", "If-Range", "Range"} {
+			source.Header.Del(header)
+		}
+
+		wrapper := &writer{ResponseWriter: w, imaging: x}
+
+		defer wrapper.close(r, p)
+
+		next.ServeHTTP(wrapper, source)
+	})
+}
+
+// New creates a new instance of the [Imaging] middleware, implementing [middleware.Configurable]. If [Imaging.Settings] isn't called,
+// then the [Imaging.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Imaging)
+}
+
+// Value retrieves the request's transformation [Parameters] from the provided context - e.g. such that the source handler can serve
+// a pre-rendered variant. If a nil value is returned, it can be assumed that the [Imaging] middleware isn't enabled for the
+// particular caller's chain.
+func Value(ctx context.Context) (value *Parameters) {
+	if v, ok := ctx.Value(key).(*Parameters); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Imaging Parameters Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Imaging] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Imaging)(nil)
@@ -0,0 +1,230 @@
+package imaging_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/imaging"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/images/text.txt":
+			w.Header().Set("Content-Type", "text/plain")
+		case "/images/unversioned.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/images/missing.png":
+			http.NotFound(w, r)
+			return
+		default:
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("ETag", `"source"`)
+		}
+
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("Range") != "" {
+			w.Header().Set("X-Conditional", "true")
+		}
+
+		w.Write([]byte("source"))
+	})
+
+	transformer := func(calls *atomic.Int64) imaging.Transformer {
+		return imaging.TransformerFunc(func(ctx context.Context, mediatype string, src []byte, p imaging.Parameters) ([]byte, string, error) {
+			calls.Add(1)
+
+			if p.Quality == 13 {
+				return nil, "", imaging.ErrUnsupported
+			}
+
+			return []byte(string(src) + "?" + p.String()), p.Format, nil
+		})
+	}
+
+	tests := []struct {
+		name     string
+		settings func(o *imaging.Options)
+		path     string
+		headers  map[string]string
+		status   int
+		body     string
+		content  string
+		calls    int64
+	}{
+		{name: "Passthrough-Route", path: "/other.png?w=100", status: http.StatusOK, body: "source", content: "image/png"},
+		{name: "Passthrough-Parameters", path: "/images/a.png", status: http.StatusOK, body: "source", content: "image/png"},
+		{name: "Resize", path: "/images/a.png?w=100&q=80", status: http.StatusOK, body: "source?w=100&h=0&q=80&f=", content: "image/png", calls: 1},
+		{name: "Negotiate-WebP", path: "/images/a.png", headers: map[string]string{"Accept": "image/webp,image/*;q=0.8,*/*;q=0.5"}, status: http.StatusOK, body: "source?w=0&h=0&q=0&f=image/webp", content: "image/webp", calls: 1},
+		{name: "Negotiate-AVIF", path: "/images/a.png", headers: map[string]string{"Accept": "image/webp, image/avif"}, status: http.StatusOK, body: "source?w=0&h=0&q=0&f=image/avif", content: "image/avif", calls: 1},
+		{name: "Negotiate-Refused", path: "/images/a.png", headers: map[string]string{"Accept": "image/avif;q=0, image/*"}, status: http.StatusOK, body: "source", content: "image/png"},
+		{name: "Invalid-Width", path: "/images/a.png?w=abc", status: http.StatusBadRequest},
+		{name: "Negative-Height", path: "/images/a.png?h=-1", status: http.StatusBadRequest},
+		{name: "Excessive-Width", path: "/images/a.png?w=5000", status: http.StatusBadRequest},
+		{name: "Excessive-Quality", path: "/images/a.png?q=101", status: http.StatusBadRequest},
+		{name: "Custom-Maximum", settings: func(o *imaging.Options) { o.Width = 50 }, path: "/images/a.png?w=100", status: http.StatusBadRequest},
+		{name: "Non-Image", path: "/images/text.txt?w=100", status: http.StatusOK, body: "source", content: "text/plain"},
+		{name: "Not-Found", path: "/images/missing.png?w=100", status: http.StatusNotFound},
+		{name: "Unsupported", path: "/images/a.png?q=13", status: http.StatusOK, body: "source", content: "image/png", calls: 1},
+		{name: "Oversized-Source", settings: func(o *imaging.Options) { o.Maximum = 4 }, path: "/images/a.png?w=100", status: http.StatusOK, body: "source", content: "image/png"},
+		{name: "Conditional-Source", path: "/images/a.png?w=100", headers: map[string]string{"If-None-Match": `"other"`, "Range": "bytes=0-1"}, status: http.StatusOK, body: "source?w=100&h=0&q=0&f=", content: "image/png", calls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int64
+
+			server := httptest.NewServer(imaging.New().Settings(func(o *imaging.Options) {
+				o.Transformer = transformer(&calls)
+			}, tt.settings).Handler(handler))
+
+			defer server.Close()
+
+			request, e := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			body, _ := io.ReadAll(response.Body)
+
+			if response.StatusCode != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, tt.status)
+			}
+
+			if v := response.Header.Get("X-Conditional"); v != "" {
+				t.Errorf("Conditional Source Request = %s\n    - Expectation = %s", v, "")
+			}
+
+			if tt.status == http.StatusOK {
+				if string(body) != tt.body {
+					t.Errorf("Body = %s\n    - Expectation = %s", body, tt.body)
+				}
+
+				if v := response.Header.Get("Content-Type"); v != tt.content {
+					t.Errorf("Content-Type = %s\n    - Expectation = %s", v, tt.content)
+				}
+			}
+
+			if v := calls.Load(); v != tt.calls {
+				t.Errorf("Transformations = %d\n    - Expectation = %d", v, tt.calls)
+			}
+		})
+	}
+
+	t.Run("Cache", func(t *testing.T) {
+		var calls atomic.Int64
+
+		server := httptest.NewServer(imaging.New().Settings(func(o *imaging.Options) {
+			o.Transformer = transformer(&calls)
+		}).Handler(handler))
+
+		defer server.Close()
+
+		get := func(path string, headers map[string]string) *http.Response {
+			request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			for k, v := range headers {
+				request.Header.Set(k, v)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+
+			return response
+		}
+
+		first := get("/images/a.png?w=100", nil)
+		get("/images/a.png?w=100", nil)
+
+		if v := calls.Load(); v != 1 {
+			t.Errorf("Cached Transformations = %d\n    - Expectation = %d", v, 1)
+		}
+
+		get("/images/a.png?w=200", nil)
+
+		if v := calls.Load(); v != 2 {
+			t.Errorf("Distinct Parameters Transformations = %d\n    - Expectation = %d", v, 2)
+		}
+
+		get("/images/unversioned.png?w=100", nil)
+		get("/images/unversioned.png?w=100", nil)
+
+		if v := calls.Load(); v != 4 {
+			t.Errorf("Unversioned Transformations = %d\n    - Expectation = %d", v, 4)
+		}
+
+		etag := first.Header.Get("ETag")
+		if etag == "" || etag == `"source"` {
+			t.Fatalf("ETag = %s\n    - Expectation = A Derived ETag", etag)
+		}
+
+		if v := first.Header.Get("Vary"); v != "Accept" {
+			t.Errorf("Vary = %s\n    - Expectation = %s", v, "Accept")
+		}
+
+		if v := get("/images/a.png?w=100", map[string]string{"If-None-Match": etag}).StatusCode; v != http.StatusNotModified {
+			t.Errorf("Conditional Status = %d\n    - Expectation = %d", v, http.StatusNotModified)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		var parameters *imaging.Parameters
+
+		server := httptest.NewServer(imaging.New().Settings(func(o *imaging.Options) {
+			o.Transformer = imaging.TransformerFunc(func(ctx context.Context, mediatype string, src []byte, p imaging.Parameters) ([]byte, string, error) {
+				return src, mediatype, nil
+			})
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parameters = imaging.Value(r.Context())
+		})))
+
+		defer server.Close()
+
+		request, e := http.NewRequest(http.MethodGet, server.URL+"/images/a.png?w=10&h=20", nil)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Request: %v", e)
+		}
+
+		request.Header.Set("Accept", "image/webp")
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		expectation := imaging.Parameters{Width: 10, Height: 20, Format: "image/webp"}
+		if parameters == nil || *parameters != expectation {
+			t.Errorf("Value = %v\n    - Expectation = %v", parameters, expectation)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := imaging.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
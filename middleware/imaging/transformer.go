@@ -0,0 +1,70 @@
+package imaging
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported may be returned by a [Transformer] unable to transform the source image (e.g. an unsupported media type); the
+// source image is then served as is.
+var ErrUnsupported = errors.New("imaging: unsupported transformation")
+
+// Parameters represents an image transformation's parameters.
+type Parameters struct {
+	// Width represents the requested width, in pixels. Zero preserves the source's aspect ratio, or its width if [Parameters.Height]
+	// is also zero.
+	Width int `json:"width,omitempty"`
+
+	// Height represents the requested height, in pixels. Zero preserves the source's aspect ratio, or its height if [Parameters.Width]
+	// is also zero.
+	Height int `json:"height,omitempty"`
+
+	// Quality represents the requested encoding quality, between 1 and 100. Zero represents the [Transformer]'s default quality.
+	Quality int `json:"quality,omitempty"`
+
+	// Format represents the negotiated output media type (e.g. "image/webp"). Empty preserves the source's media type.
+	Format string `json:"format,omitempty"`
+}
+
+// Zero reports whether the parameters request no transformation.
+func (p Parameters) Zero() bool {
+	return p == Parameters{}
+}
+
+// String returns the parameters' canonical representation, e.g. "w=320&h=0&q=80&f=image/webp".
+func (p Parameters) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("w=" + strconv.Itoa(p.Width))
+	builder.WriteString("&h=" + strconv.Itoa(p.Height))
+	builder.WriteString("&q=" + strconv.Itoa(p.Quality))
+	builder.WriteString("&f=" + p.Format)
+
+	return builder.String()
+}
+
+// Image represents a transformed image.
+type Image struct {
+	// Type represents the image's media type.
+	Type string
+
+	// Body represents the image's encoded bytes.
+	Body []byte
+}
+
+// Transformer represents an image transformer.
+type Transformer interface {
+	// Transform returns the source image, of the given media type, transformed per the parameters, and the result's media type.
+	// Returning an error - e.g. [ErrUnsupported] - causes the source image to be served as is.
+	Transform(ctx context.Context, mediatype string, src []byte, p Parameters) ([]byte, string, error)
+}
+
+// TransformerFunc is an adapter allowing the use of ordinary functions as a [Transformer].
+type TransformerFunc func(ctx context.Context, mediatype string, src []byte, p Parameters) ([]byte, string, error)
+
+// Transform calls fn(ctx, mediatype, src, p).
+func (fn TransformerFunc) Transform(ctx context.Context, mediatype string, src []byte, p Parameters) ([]byte, string, error) {
+	return fn(ctx, mediatype, src, p)
+}
@@ -0,0 +1,8 @@
+// Package keepalive provides middleware tuning HTTP/1.x connection reuse per client class: the response's Connection and
+// Keep-Alive headers are derived from the [Class] of the requesting client - e.g. crawlers' connections are closed after their
+// response, while trusted internal clients are hinted a long-lived connection.
+//
+// Under pressure (see [Options.Pressure]), the connections of sheddable classes are closed after their response, such that idle
+// keep-alive connections are shed before any request is rejected. HTTP/2 and HTTP/3 responses are unaffected, as connection-specific
+// headers are prohibited by both protocols.
+package keepalive
@@ -0,0 +1,53 @@
+package keepalive_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/keepalive"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(keepalive.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Class: %s\n", keepalive.Value(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Keep-Alive: %s\n", response.Header.Get("Keep-Alive"))
+
+	// Output:
+	// Class: internal
+	// Keep-Alive: timeout=300, max=1000
+}
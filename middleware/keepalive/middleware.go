@@ -0,0 +1,204 @@
+package keepalive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "keepalive"
+
+// Class represents a client class, as determined by [Options.Classify].
+type Class string
+
+const (
+	// ClassDefault represents clients of no particular class.
+	ClassDefault Class = "default"
+
+	// ClassBot represents automated clients - e.g. crawlers, and monitoring probes.
+	ClassBot Class = "bot"
+
+	// ClassInternal represents trusted, internal clients - e.g. services within the same network.
+	ClassInternal Class = "internal"
+)
+
+// Policy represents a client class's connection reuse policy.
+type Policy struct {
+	// Close closes the connection after the response ("Connection: close").
+	Close bool
+
+	// Timeout represents the Keep-Alive header's "timeout" hint. Zero omits the hint.
+	Timeout time.Duration
+
+	// Max represents the Keep-Alive header's "max" hint - the number of requests the connection may serve. Zero omits the hint.
+	Max int
+}
+
+// bots represents the default expression matching automated clients' user-agents.
+var bots = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|curl|wget|python-requests|go-http-client|monitor|probe`)
+
+// Options represents the configuration settings for the [Keepalive] middleware component.
+type Options struct {
+	// Classify returns the request's client [Class]. Classes without a [Options.Policies] entry are served with the
+	// [ClassDefault] policy. Defaults to a function classifying loopback and private-network remote addresses as [ClassInternal],
+	// and user-agents matching common crawlers and HTTP libraries as [ClassBot].
+	Classify func(r *http.Request) Class
+
+	// Policies represents the per-class connection reuse policies. Defaults to closing [ClassBot] connections, hinting a
+	// 5 minute, 1000 request keep-alive to [ClassInternal] clients, and leaving [ClassDefault] responses unchanged.
+	Policies map[Class]Policy
+
+	// Pressure reports whether the server is under pressure - e.g. the throttle middleware's Pressure method. While true, the
+	// connections of [Options.Shed] classes are closed after their response. Defaults to nil - the server is never under pressure.
+	Pressure func() bool
+
+	// Shed represents the classes whose connections are closed under [Options.Pressure]. Defaults to [ClassBot] and [ClassDefault].
+	Shed []Class
+}
+
+// Keepalive represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Keepalive struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// classify is the default [Options.Classify] function.
+func classify(r *http.Request) Class {
+	host, _, e := net.SplitHostPort(r.RemoteAddr)
+	if e != nil {
+		host = r.RemoteAddr
+	}
+
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate()) {
+		return ClassInternal
+	}
+
+	if bots.MatchString(r.UserAgent()) {
+		return ClassBot
+	}
+
+	return ClassDefault
+}
+
+// policies returns the default [Options.Policies] value.
+func policies() map[Class]Policy {
+	return map[Class]Policy{
+		ClassDefault:  {},
+		ClassBot:      {Close: true},
+		ClassInternal: {Timeout: time.Minute * 5, Max: 1000},
+	}
+}
+
+// Settings applies configuration functions to modify the [Keepalive] middleware's [Options] and returns the updated middleware instance.
+func (x *Keepalive) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Classify: classify,
+			Policies: policies(),
+			Pressure: nil,
+			Shed:     []Class{ClassBot, ClassDefault},
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Classify == nil {
+		x.options.Classify = classify
+	}
+
+	if x.options.Policies == nil {
+		slog.Warn("Invalid Keep-Alive Policies Specified - Using Default Policies")
+
+		x.options.Policies = policies()
+	}
+
+	return x
+}
+
+// policy returns the request's client class, and its applicable policy.
+func (x *Keepalive) policy(r *http.Request) (Class, Policy) {
+	class := x.options.Classify(r)
+
+	policy, ok := x.options.Policies[class]
+	if !(ok) {
+		policy = x.options.Policies[ClassDefault]
+	}
+
+	if !(policy.Close) && x.options.Pressure != nil && slices.Contains(x.options.Shed, class) && x.options.Pressure() {
+		policy = Policy{Close: true}
+	}
+
+	return class, policy
+}
+
+// Handler sets the Connection and Keep-Alive response headers according to the client class's [Policy], prior to the next handler,
+// and stores the class in the request context. Only HTTP/1.x responses are affected; a handler can override either header for a
+// particular response.
+func (x *Keepalive) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class, policy := x.policy(r)
+
+		if r.ProtoMajor == 1 {
+			switch {
+			case policy.Close:
+				w.Header().Set("Connection", "close")
+			case policy.Timeout > 0 || policy.Max > 0:
+				var hints []string
+				if policy.Timeout > 0 {
+					hints = append(hints, fmt.Sprintf("timeout=%d", int(policy.Timeout.Seconds())))
+				}
+
+				if policy.Max > 0 {
+					hints = append(hints, fmt.Sprintf("max=%d", policy.Max))
+				}
+
+				w.Header().Set("Connection", "keep-alive")
+				w.Header().Set("Keep-Alive", strings.Join(hints, ", "))
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, class)))
+	})
+}
+
+// New creates a new instance of the [Keepalive] middleware, implementing [middleware.Configurable]. If [Keepalive.Settings] isn't called,
+// then the [Keepalive.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Keepalive)
+}
+
+// Value retrieves the request's client [Class] from the provided context. An empty value is returned if the [Keepalive]
+// middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value Class) {
+	if v, ok := ctx.Value(key).(Class); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Keepalive] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Keepalive)(nil)
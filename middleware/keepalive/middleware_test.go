@@ -0,0 +1,127 @@
+package keepalive_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/keepalive"
+)
+
+func Test(t *testing.T) {
+	var pressure atomic.Bool
+
+	tests := []struct {
+		name       string
+		settings   func(o *keepalive.Options)
+		request    func(r *http.Request)
+		pressure   bool
+		class      keepalive.Class
+		connection string
+		keepalive  string
+	}{
+		{
+			name:  "Default",
+			class: keepalive.ClassDefault,
+		},
+		{
+			name: "Bot",
+			request: func(r *http.Request) {
+				r.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)")
+			},
+			class:      keepalive.ClassBot,
+			connection: "close",
+		},
+		{
+			name: "Internal",
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:54321"
+			},
+			class:      keepalive.ClassInternal,
+			connection: "keep-alive",
+			keepalive:  "timeout=300, max=1000",
+		},
+		{
+			name:       "Pressure-Sheds-Default",
+			pressure:   true,
+			class:      keepalive.ClassDefault,
+			connection: "close",
+		},
+		{
+			name: "Pressure-Retains-Internal",
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:54321"
+			},
+			pressure:   true,
+			class:      keepalive.ClassInternal,
+			connection: "keep-alive",
+			keepalive:  "timeout=300, max=1000",
+		},
+		{
+			name: "HTTP2",
+			request: func(r *http.Request) {
+				r.Header.Set("User-Agent", "curl/8.0.0")
+				r.ProtoMajor, r.ProtoMinor = 2, 0
+			},
+			class: keepalive.ClassBot,
+		},
+		{
+			name: "Custom-Classifier",
+			settings: func(o *keepalive.Options) {
+				o.Classify = func(r *http.Request) keepalive.Class {
+					return keepalive.Class("partner")
+				}
+
+				o.Policies[keepalive.Class("partner")] = keepalive.Policy{Max: 50}
+			},
+			class:      keepalive.Class("partner"),
+			connection: "keep-alive",
+			keepalive:  "max=50",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pressure.Store(tt.pressure)
+
+			var class keepalive.Class
+
+			handler := keepalive.New().Settings(func(o *keepalive.Options) {
+				o.Pressure = pressure.Load
+			}, tt.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				class = keepalive.Value(r.Context())
+
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.request != nil {
+				tt.request(request)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if class != tt.class {
+				t.Errorf("Class = %s\n    - Expectation = %s", class, tt.class)
+			}
+
+			if v := recorder.Header().Get("Connection"); v != tt.connection {
+				t.Errorf("Connection = %s\n    - Expectation = %s", v, tt.connection)
+			}
+
+			if v := recorder.Header().Get("Keep-Alive"); v != tt.keepalive {
+				t.Errorf("Keep-Alive = %s\n    - Expectation = %s", v, tt.keepalive)
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := keepalive.Value(context.Background()); v != "" {
+			t.Errorf("Value = %s\n    - Expectation = %s", v, "an empty class")
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Package leak provides development-mode middleware detecting common request-lifecycle bugs: goroutines that outlive their
+// request without a detached context, and response writes following the handler's return.
+//
+// Goroutines started through [Go] are tracked explicitly, and report their origin; goroutines started with go statements are
+// tracked through goroutine profiler labels. Findings are logged, and optionally passed to a hook - e.g. to fail a staging test
+// suite.
+//
+// The middleware captures a goroutine profile per request, and isn't intended for production use. [Go] is safe to use in
+// production, where it's equivalent to a go statement.
+package leak
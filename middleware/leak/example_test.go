@@ -0,0 +1,69 @@
+package leak_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/leak"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	findings := make(chan leak.Finding, 1)
+
+	middleware.Add(leak.New().Settings(func(o *leak.Options) {
+		o.Grace = 10 * time.Millisecond
+		o.Hook = func(finding leak.Finding) { findings <- finding }
+	}).Handler)
+
+	release := make(chan struct{})
+
+	defer close(release)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		// The goroutine uses the request's context, rather than a detached one, yet outlives the request.
+		leak.Go(r.Context(), func(ctx context.Context) {
+			<-release
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	finding := <-findings
+
+	fmt.Println("Kind:", finding.Kind)
+	fmt.Println("Count:", finding.Count)
+
+	// Output:
+	// Kind: goroutine
+	// Count: 1
+}
@@ -0,0 +1,365 @@
+package leak
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Go] can the context's value be derived.
+const key keyer = "leak"
+
+// label represents the goroutine profiler label identifying goroutines spawned while serving a request.
+const label = "leak-request"
+
+// ErrWriteAfterReturn is returned for response writes following the handler's return.
+var ErrWriteAfterReturn = errors.New("response written after handler returned")
+
+// Kind represents a [Finding]'s category.
+type Kind string
+
+const (
+	// Goroutine represents a goroutine that outlived its request without a detached context.
+	Goroutine Kind = "goroutine"
+
+	// Write represents a response write following the handler's return.
+	Write Kind = "write-after-return"
+)
+
+// Finding represents a detected misuse.
+type Finding struct {
+	// Kind represents the finding's category.
+	Kind Kind
+
+	// Method represents the request's method.
+	Method string
+
+	// Path represents the request url's path.
+	Path string
+
+	// Origin represents the misuse's source location (e.g. "main.handler (/srv/main.go:42)"), if known.
+	Origin string
+
+	// Count represents the number of goroutines sharing the [Finding.Origin]. Always 1 for [Write] findings.
+	Count int
+}
+
+// Options represents the configuration settings for the [Leak] middleware component.
+type Options struct {
+	// Grace represents the duration after a request completes before outliving goroutines are reported, allowing for goroutines
+	// that are momentarily finishing. Defaults to 100 milliseconds.
+	Grace time.Duration
+
+	// Labels enables tracking goroutines started with go statements - rather than [Go] - through goroutine profiler labels.
+	// Because the goroutine profile is captured per request, Labels should only be enabled in development and staging
+	// environments. Defaults to true.
+	Labels bool
+
+	// Level specifies the log level used to record findings. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Hook optionally receives every finding, e.g. to fail a test suite. Defaults to nil.
+	Hook func(finding Finding)
+}
+
+// Leak represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Leak struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	sequence atomic.Uint64
+}
+
+// Settings applies configuration functions to modify the [Leak] middleware's [Options] and returns the updated middleware instance.
+func (x *Leak) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Grace:  100 * time.Millisecond,
+			Labels: true,
+			Level:  slog.LevelWarn,
+			Hook:   nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Grace < 0 {
+		slog.Warn("Invalid Leak Grace Specified - Using Default Grace")
+
+		x.options.Grace = 100 * time.Millisecond
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	return x
+}
+
+// report logs the finding, and passes it to the [Options.Hook].
+func (x *Leak) report(ctx context.Context, finding Finding) {
+	message := "Goroutine Outlived Request"
+	if finding.Kind == Write {
+		message = "Response Written After Handler Return"
+	}
+
+	slog.Log(ctx, x.options.Level.Level(), message, slog.String("method", finding.Method), slog.String("path", finding.Path), slog.String("origin", finding.Origin), slog.Int("count", finding.Count))
+
+	if x.options.Hook != nil {
+		x.options.Hook(finding)
+	}
+}
+
+// tracker represents a request's goroutines started through [Go].
+type tracker struct {
+	mutex sync.Mutex
+	live  map[uint64]string // live maps a goroutine's sequence to its origin.
+	next  uint64
+}
+
+// labeled returns the goroutines carrying the request's profiler label, grouped by their entry function.
+func labeled(id string) map[string]int {
+	var buffer bytes.Buffer
+
+	pprof.Lookup("goroutine").WriteTo(&buffer, 1)
+
+	needle := strconv.Quote(label) + ":" + strconv.Quote(id)
+
+	groups := make(map[string]int)
+
+	// Profile entries are separated by blank lines: a "<count> @ <addresses>" line, an optional "# labels: {...}" line, and
+	// "#\t<pc>\t<function>\t<file>:<line>" frames - the last being the goroutine's entry function.
+	for _, entry := range strings.Split(buffer.String(), "\n\n") {
+		if !(strings.Contains(entry, needle)) {
+			continue
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(entry))
+
+		count, origin := 0, ""
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if fields := strings.Fields(line); count == 0 && len(fields) > 1 && fields[1] == "@" {
+				count, _ = strconv.Atoi(fields[0])
+			} else if fields := strings.Split(line, "\t"); len(fields) == 4 && fields[0] == "#" {
+				origin = fmt.Sprintf("%s (%s)", fields[2], strings.TrimSpace(fields[3]))
+			}
+		}
+
+		groups[origin] += count
+	}
+
+	return groups
+}
+
+// writer reports, and discards, response writes following the handler's return.
+type writer struct {
+	http.ResponseWriter
+
+	leak     *Leak
+	request  *http.Request
+	returned atomic.Bool
+}
+
+// late reports whether the handler returned, reporting the caller's write.
+func (w *writer) late() bool {
+	if !(w.returned.Load()) {
+		return false
+	}
+
+	origin := ""
+	if pc, file, line, ok := runtime.Caller(2); ok {
+		origin = fmt.Sprintf("%s (%s:%d)", runtime.FuncForPC(pc).Name(), file, line)
+	}
+
+	w.leak.report(w.request.Context(), Finding{Kind: Write, Method: w.request.Method, Path: w.request.URL.Path, Origin: origin, Count: 1})
+
+	return true
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.late() {
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.late() {
+		return 0, ErrWriteAfterReturn
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher]. Following the handler's return, the flush is reported, and discarded.
+func (w *writer) Flush() {
+	if w.late() {
+		return
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. Following the handler's return, the hijack is reported, and fails with
+// [ErrWriteAfterReturn].
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.late() {
+		return nil, nil, ErrWriteAfterReturn
+	}
+
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController]. Following the handler's return,
+// nil is returned, such that [http.ResponseController] calls fail.
+func (w *writer) Unwrap() http.ResponseWriter {
+	if w.late() {
+		return nil
+	}
+
+	return w.ResponseWriter
+}
+
+// Handler tracks goroutines spawned while serving each request - through [Go], and, if [Options.Labels] is enabled, through
+// goroutine profiler labels - and reports those outliving the request by more than [Options.Grace]. Response writes following
+// the handler's return are reported and discarded.
+func (x *Leak) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The request's context is always cancelable, such that [Go] can distinguish detached contexts - and is canceled
+		// upon the handler's return, as with net/http servers.
+		ctx, cancel := context.WithCancel(r.Context())
+
+		defer cancel()
+
+		id := strconv.FormatUint(x.sequence.Add(1), 10)
+
+		t := &tracker{live: make(map[uint64]string)}
+
+		wrapper := &writer{ResponseWriter: w, leak: x, request: r}
+
+		serve := func(ctx context.Context) {
+			next.ServeHTTP(wrapper, r.WithContext(context.WithValue(ctx, key, t)))
+		}
+
+		if x.options.Labels {
+			pprof.Do(ctx, pprof.Labels(label, id), serve)
+		} else {
+			serve(ctx)
+		}
+
+		wrapper.returned.Store(true)
+
+		detached := middleware.Detach(ctx)
+
+		time.AfterFunc(x.options.Grace, func() {
+			t.mutex.Lock()
+
+			origins := make(map[string]int)
+			for _, origin := range t.live {
+				origins[origin]++
+			}
+
+			t.mutex.Unlock()
+
+			if x.options.Labels {
+				for origin, count := range labeled(id) {
+					origins[origin] += count
+				}
+			}
+
+			for origin, count := range origins {
+				x.report(detached, Finding{Kind: Goroutine, Method: r.Method, Path: r.URL.Path, Origin: origin, Count: count})
+			}
+		})
+	})
+}
+
+// Go starts fn in a new goroutine, tracked by the request's [Leak] middleware. Goroutines started with a detached context - see
+// [middleware.Detach] - are expected to outlive the request, and aren't tracked; all others are reported if they outlive the
+// request. If the [Leak] middleware isn't enabled for the particular caller's chain, Go is equivalent to a go statement, so
+// handlers can use it irrespective of the environment.
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	t, ok := ctx.Value(key).(*tracker)
+	if !(ok) {
+		go fn(ctx)
+
+		return
+	}
+
+	// Goroutines started through Go are tracked explicitly, rather than through profiler labels.
+	unlabeled := func() { pprof.SetGoroutineLabels(context.Background()) }
+
+	if ctx.Done() == nil {
+		go func() {
+			unlabeled()
+
+			fn(ctx)
+		}()
+
+		return
+	}
+
+	origin := ""
+	if pc, file, line, ok := runtime.Caller(1); ok {
+		origin = fmt.Sprintf("%s (%s:%d)", runtime.FuncForPC(pc).Name(), file, line)
+	}
+
+	t.mutex.Lock()
+
+	sequence := t.next
+	t.next++
+	t.live[sequence] = origin
+
+	t.mutex.Unlock()
+
+	go func() {
+		unlabeled()
+
+		defer func() {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+
+			delete(t.live, sequence)
+		}()
+
+		fn(ctx)
+	}()
+}
+
+// New creates a new instance of the [Leak] middleware, implementing [middleware.Configurable]. If [Leak.Settings] isn't called,
+// then the [Leak.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Leak)
+}
+
+// Runtime assurance that [Leak] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Leak)(nil)
@@ -0,0 +1,133 @@
+package leak_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/leak"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(w http.ResponseWriter, r *http.Request, release <-chan struct{})
+		kind    leak.Kind
+		origin  string
+	}{
+		{
+			name: "Tracked-Goroutine",
+			handler: func(w http.ResponseWriter, r *http.Request, release <-chan struct{}) {
+				leak.Go(r.Context(), func(ctx context.Context) { <-release })
+			},
+			kind:   leak.Goroutine,
+			origin: "leak_test.Test",
+		},
+		{
+			name: "Labeled-Goroutine",
+			handler: func(w http.ResponseWriter, r *http.Request, release <-chan struct{}) {
+				go func() { <-release }()
+			},
+			kind:   leak.Goroutine,
+			origin: "leak_test.Test",
+		},
+		{
+			name: "Detached-Goroutine",
+			handler: func(w http.ResponseWriter, r *http.Request, release <-chan struct{}) {
+				leak.Go(middleware.Detach(r.Context()), func(ctx context.Context) { <-release })
+			},
+			kind: "",
+		},
+		{
+			name: "Completed-Goroutine",
+			handler: func(w http.ResponseWriter, r *http.Request, release <-chan struct{}) {
+				var group sync.WaitGroup
+
+				group.Add(1)
+
+				leak.Go(r.Context(), func(ctx context.Context) { group.Done() })
+
+				group.Wait()
+			},
+			kind: "",
+		},
+		{
+			name: "Write-After-Return",
+			handler: func(w http.ResponseWriter, r *http.Request, release <-chan struct{}) {
+				go func() {
+					<-release
+
+					if _, e := w.Write([]byte("late")); !(errors.Is(e, leak.ErrWriteAfterReturn)) {
+						panic("unexpected write error")
+					}
+				}()
+			},
+			kind:   leak.Write,
+			origin: "leak_test.Test",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings := make(chan leak.Finding, 4)
+
+			release := make(chan struct{})
+
+			handler := leak.New().Settings(func(o *leak.Options) {
+				o.Grace = 10 * time.Millisecond
+				o.Hook = func(finding leak.Finding) { findings <- finding }
+			}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				test.handler(w, r, release)
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/example", nil))
+
+			if test.kind == leak.Write {
+				close(release)
+			} else {
+				defer close(release)
+			}
+
+			select {
+			case finding := <-findings:
+				if finding.Kind != test.kind {
+					t.Errorf("Kind = %s\n    - Expectation = %s", finding.Kind, test.kind)
+				}
+
+				if !(strings.Contains(finding.Origin, test.origin)) {
+					t.Errorf("Origin = %s\n    - Expectation = %s", finding.Origin, test.origin)
+				}
+
+				if finding.Method != http.MethodGet || finding.Path != "/example" {
+					t.Errorf("Request = %s %s\n    - Expectation = %s %s", finding.Method, finding.Path, http.MethodGet, "/example")
+				}
+
+				if finding.Count != 1 {
+					t.Errorf("Count = %d\n    - Expectation = %d", finding.Count, 1)
+				}
+			case <-time.After(250 * time.Millisecond):
+				if test.kind != "" {
+					t.Errorf("Expected %s Finding Wasn't Reported", test.kind)
+				}
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		done := make(chan struct{})
+
+		leak.Go(context.Background(), func(ctx context.Context) { close(done) })
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Errorf("Untracked Goroutine Wasn't Started")
+		}
+	})
+}
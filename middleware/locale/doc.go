@@ -0,0 +1,7 @@
+// Package locale provides middleware resolving a request's locale - from a cookie, a query parameter, or the Accept-Language header,
+// in a configurable priority - and normalizing it against the application's supported locales.
+//
+// Candidate tags are canonicalized (e.g. "pt_br" becomes "pt-BR") and matched exactly, then by base language: a request for
+// "fr-CA" matches a supported "fr", and a request for "fr" matches a supported "fr-FR". Requests without a supported candidate
+// receive [Options.Default]. The resolved locale is available via [Value], and optionally echoed in a Content-Language response header.
+package locale
@@ -0,0 +1,59 @@
+package locale_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/locale"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(locale.New().Settings(func(o *locale.Options) {
+		o.Locales = []string{"en-US", "de-DE", "fr"}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		v := locale.Value(r.Context())
+
+		fmt.Printf("Locale: %s, Source: %s\n", v.Locale, v.Source)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Accept-Language", "de-AT, de;q=0.9, en;q=0.8")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Content-Language: %s\n", response.Header.Get("Content-Language"))
+
+	// Output:
+	// Locale: de-DE, Source: header
+	// Content-Language: de-DE
+}
@@ -0,0 +1,298 @@
+package locale
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "locale"
+
+// Source represents a locale source.
+type Source string
+
+const (
+	// Cookie represents the [Options.Cookie] cookie source - typically an explicit selection in the client's interface.
+	Cookie Source = "cookie"
+
+	// Query represents the [Options.Query] query parameter source (e.g. "?locale=de").
+	Query Source = "query"
+
+	// Header represents the Accept-Language header source.
+	Header Source = "header"
+
+	// Default represents the [Options.Default] fallback; it isn't a configurable source.
+	Default Source = "default"
+)
+
+// Valuer is the context return type relating to the [Locale] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Locale represents the request's resolved locale - one of [Options.Locales], or [Options.Default].
+	Locale string `json:"locale"`
+
+	// Source represents the source the [Valuer.Locale] was resolved from.
+	Source Source `json:"source"`
+}
+
+// Options represents the configuration settings for the [Locale] middleware component.
+type Options struct {
+	// Locales represents the application's supported locales (e.g. "en", "fr-FR"). Defaults to a slice containing "en".
+	Locales []string
+
+	// Default represents the locale of requests without a supported candidate. Defaults to the first of [Options.Locales].
+	Default string
+
+	// Sources represents the locale sources, in priority order. Defaults to [Query], [Cookie], then [Header].
+	Sources []Source
+
+	// Cookie represents the [Cookie] source's cookie name. Defaults to "locale".
+	Cookie string
+
+	// Query represents the [Query] source's query parameter name. Defaults to "locale".
+	Query string
+
+	// Language specifies whether the resolved locale is set as the response's Content-Language header. Defaults to true.
+	Language bool
+}
+
+// Locale represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Locale struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Locale] middleware's [Options] and returns the updated middleware instance.
+func (l *Locale) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if l.options == nil {
+		l.options = &Options{
+			Locales:  []string{"en"},
+			Default:  "",
+			Sources:  []Source{Query, Cookie, Header},
+			Cookie:   "locale",
+			Query:    "locale",
+			Language: true,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(l.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	locales := make([]string, 0, len(l.options.Locales))
+	for _, locale := range l.options.Locales {
+		if v, ok := canonicalize(locale); ok {
+			locales = append(locales, v)
+		} else {
+			slog.Warn("Invalid Locale Specified - Ignoring Locale", slog.String("locale", locale))
+		}
+	}
+
+	if len(locales) == 0 {
+		slog.Warn("Invalid Locale Locales Specified - Using Default Locales")
+
+		locales = []string{"en"}
+	}
+
+	l.options.Locales = locales
+
+	if v, ok := match(l.options.Default, l.options.Locales); ok {
+		l.options.Default = v
+	} else {
+		if l.options.Default != "" {
+			slog.Warn("Invalid Locale Default Specified - Using Default Locale")
+		}
+
+		l.options.Default = l.options.Locales[0]
+	}
+
+	return l
+}
+
+// canonicalize normalizes a BCP 47 language tag's separators and casing (e.g. "pt_br" becomes "pt-BR", and "zh-hant-tw" becomes
+// "zh-Hant-TW"). The boolean reports whether the tag is well-formed.
+func canonicalize(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "" || len(tag) > 35 {
+		return "", false
+	}
+
+	subtags := strings.Split(tag, "-")
+	for index, subtag := range subtags {
+		if subtag == "" || len(subtag) > 8 || strings.IndexFunc(subtag, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		}) >= 0 {
+			return "", false
+		}
+
+		switch {
+		case index == 0:
+			if len(subtag) < 2 || len(subtag) > 3 || strings.IndexFunc(subtag, func(r rune) bool { return r >= '0' && r <= '9' }) >= 0 {
+				return "", false
+			}
+
+			subtags[index] = strings.ToLower(subtag)
+		case len(subtag) == 4 && index == 1:
+			subtags[index] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2:
+			subtags[index] = strings.ToUpper(subtag)
+		default:
+			subtags[index] = strings.ToLower(subtag)
+		}
+	}
+
+	return strings.Join(subtags, "-"), true
+}
+
+// match returns the supported locale matching the tag: exactly, by the tag's base language (e.g. "fr-CA" matches "fr"), or by a
+// supported locale's base language (e.g. "fr" matches "fr-FR") - in that order.
+func match(tag string, supported []string) (string, bool) {
+	tag, ok := canonicalize(tag)
+	if !(ok) {
+		return "", false
+	}
+
+	for _, locale := range supported {
+		if locale == tag {
+			return locale, true
+		}
+	}
+
+	base, _, _ := strings.Cut(tag, "-")
+	for _, locale := range supported {
+		if locale == base {
+			return locale, true
+		}
+	}
+
+	for _, locale := range supported {
+		if prefix, _, _ := strings.Cut(locale, "-"); prefix == base {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+// preferences returns the Accept-Language header's tags, in order of preference. Wildcards, and tags with a zero quality, are omitted.
+func preferences(header string) []string {
+	type tag struct {
+		value   string
+		quality float64
+	}
+
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		value, parameters, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		quality := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(parameters), "q="); found {
+			if q, e := strconv.ParseFloat(v, 64); e == nil {
+				quality = q
+			}
+		}
+
+		if value = strings.TrimSpace(value); value != "" && value != "*" && quality > 0 {
+			tags = append(tags, tag{value: value, quality: quality})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	values := make([]string, 0, len(tags))
+	for _, t := range tags {
+		values = append(values, t.value)
+	}
+
+	return values
+}
+
+// candidates returns the source's candidate tags, in order of preference.
+func (l *Locale) candidates(r *http.Request, source Source) []string {
+	switch source {
+	case Cookie:
+		if cookie, e := r.Cookie(l.options.Cookie); e == nil && cookie.Value != "" {
+			return []string{cookie.Value}
+		}
+	case Query:
+		if v := r.URL.Query().Get(l.options.Query); v != "" {
+			return []string{v}
+		}
+	case Header:
+		return preferences(r.Header.Get("Accept-Language"))
+	}
+
+	return nil
+}
+
+// Handler resolves the request's locale from the [Options.Sources], in priority order, normalized against the [Options.Locales],
+// and stores it in the request context. Requests without a supported candidate receive the [Options.Default] locale.
+func (l *Locale) Handler(next http.Handler) http.Handler {
+	l.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Locale: l.options.Default, Source: Default}
+
+	resolution:
+		for _, source := range l.options.Sources {
+			for _, candidate := range l.candidates(r, source) {
+				if v, ok := match(candidate, l.options.Locales); ok {
+					valuer.Locale, valuer.Source = v, source
+
+					break resolution
+				}
+			}
+		}
+
+		for _, source := range l.options.Sources {
+			switch source {
+			case Cookie:
+				w.Header().Add("Vary", "Cookie")
+			case Header:
+				w.Header().Add("Vary", "Accept-Language")
+			}
+		}
+
+		if l.options.Language {
+			w.Header().Set("Content-Language", valuer.Locale)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Locale] middleware, implementing [middleware.Configurable]. If [Locale.Settings] isn't called,
+// then the [Locale.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Locale)
+}
+
+// Value retrieves the request's resolved locale from the provided context. If a nil value is returned, it can be assumed that the
+// [Locale] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Locale] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Locale)(nil)
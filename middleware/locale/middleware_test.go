@@ -0,0 +1,98 @@
+package locale_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/locale"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := locale.Value(r.Context())
+
+		w.Header().Set("X-Locale", v.Locale)
+		w.Header().Set("X-Source", string(v.Source))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	supported := func(o *locale.Options) {
+		o.Locales = []string{"en-US", "fr", "pt_br", "zh-hant-tw"}
+	}
+
+	tests := []struct {
+		name     string
+		options  func(o *locale.Options)
+		path     string
+		cookie   string
+		language string
+		locale   string
+		source   locale.Source
+		header   string
+	}{
+		{name: "Default", path: "/", locale: "en", source: locale.Default, header: "en"},
+		{name: "Default-First-Supported", options: supported, path: "/", locale: "en-US", source: locale.Default, header: "en-US"},
+		{name: "Default-Specified", options: func(o *locale.Options) { supported(o); o.Default = "FR" }, path: "/", locale: "fr", source: locale.Default, header: "fr"},
+		{name: "Header", options: supported, path: "/", language: "de;q=0.9, fr-CA, en;q=0.5", locale: "fr", source: locale.Header, header: "fr"},
+		{name: "Header-Quality", options: supported, path: "/", language: "en;q=0.5, pt-BR;q=0.8", locale: "pt-BR", source: locale.Header, header: "pt-BR"},
+		{name: "Header-Base-Match", options: supported, path: "/", language: "en", locale: "en-US", source: locale.Header, header: "en-US"},
+		{name: "Header-Wildcard", options: supported, path: "/", language: "*", locale: "en-US", source: locale.Default, header: "en-US"},
+		{name: "Header-Script", options: supported, path: "/", language: "zh-Hant-TW", locale: "zh-Hant-TW", source: locale.Header, header: "zh-Hant-TW"},
+		{name: "Cookie", options: supported, path: "/", cookie: "pt_BR", language: "fr", locale: "pt-BR", source: locale.Cookie, header: "pt-BR"},
+		{name: "Query", options: supported, path: "/?locale=fr", cookie: "pt-BR", language: "en", locale: "fr", source: locale.Query, header: "fr"},
+		{name: "Query-Invalid", options: supported, path: "/?locale=../etc", cookie: "pt-BR", locale: "pt-BR", source: locale.Cookie, header: "pt-BR"},
+		{name: "Priority", options: func(o *locale.Options) {
+			supported(o)
+			o.Sources = []locale.Source{locale.Header, locale.Query}
+		}, path: "/?locale=fr", cookie: "pt-BR", language: "en-US", locale: "en-US", source: locale.Header, header: "en-US"},
+		{name: "Language-Disabled", options: func(o *locale.Options) { o.Language = false }, path: "/", locale: "en", source: locale.Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.cookie != "" {
+				request.AddCookie(&http.Cookie{Name: "locale", Value: tt.cookie})
+			}
+
+			if tt.language != "" {
+				request.Header.Set("Accept-Language", tt.language)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			locale.New().Settings(tt.options).Handler(handler).ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Locale"); v != tt.locale {
+				t.Errorf("Locale = %s\n    - Expectation = %s", v, tt.locale)
+			}
+
+			if v := recorder.Header().Get("X-Source"); v != string(tt.source) {
+				t.Errorf("Source = %s\n    - Expectation = %s", v, tt.source)
+			}
+
+			if v := recorder.Header().Get("Content-Language"); v != tt.header {
+				t.Errorf("Content-Language = %s\n    - Expectation = %s", v, tt.header)
+			}
+		})
+	}
+
+	t.Run("Vary", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		locale.New().Handler(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Values("Vary"); len(v) != 2 || v[0] != "Cookie" || v[1] != "Accept-Language" {
+			t.Errorf("Vary = %v\n    - Expectation = %v", v, []string{"Cookie", "Accept-Language"})
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := locale.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
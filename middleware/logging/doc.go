@@ -0,0 +1,3 @@
+// Package logging provides access-logging middleware, recording a structured [log/slog] message per request with configurable
+// fields, query parameter redaction, status-class log levels, and sampling of successful responses.
+package logging
@@ -0,0 +1,60 @@
+package logging_test
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/logging"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	// Remove the time attribute for a deterministic example.
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+
+		return a
+	}}))
+
+	middleware.Add(logging.New().Settings(func(o *logging.Options) {
+		o.Logger = logger
+		o.Fields = []logging.Field{logging.Method, logging.Path, logging.Query, logging.Status, logging.Bytes}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello World"))
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/search?q=example&token=secret", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	// Output: level=INFO msg="HTTP Request" method=GET path=/search query="q=example&token=%5Bredacted%5D" status=200 bytes=11
+}
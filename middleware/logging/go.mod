@@ -0,0 +1,17 @@
+module github.com/poly-gun/go-middleware/middleware/logging
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace github.com/poly-gun/go-middleware/middleware/rip => ../rip
+
+require github.com/poly-gun/go-middleware/middleware/rip v0.0.3
+
+replace github.com/poly-gun/go-middleware/middleware/requestid => ../requestid
+
+require github.com/poly-gun/go-middleware/middleware/requestid v0.0.0
+
+replace github.com/poly-gun/go-middleware/middleware/telemetrics => ../telemetrics
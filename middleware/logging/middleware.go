@@ -0,0 +1,253 @@
+package logging
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/failure"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/rip"
+)
+
+// Field represents an access log attribute. See [Options.Fields].
+type Field string
+
+const (
+	// Method represents the request's method, logged as "method".
+	Method Field = "method"
+
+	// Path represents the request url's path, logged as "path".
+	Path Field = "path"
+
+	// Query represents the request url's query, with [Options.Redact] parameters redacted, logged as "query".
+	Query Field = "query"
+
+	// Status represents the response's status code, logged as "status".
+	Status Field = "status"
+
+	// Bytes represents the number of response body bytes written, logged as "bytes".
+	Bytes Field = "bytes"
+
+	// Duration represents the time taken to serve the request, logged as "duration".
+	Duration Field = "duration"
+
+	// IP represents the client's address - see [rip.Value] - logged as "ip".
+	IP Field = "ip"
+
+	// UserAgent represents the request's User-Agent header, logged as "user-agent".
+	UserAgent Field = "user-agent"
+
+	// RequestID represents the request's identifier - see [requestid.Value] - logged as "request-id".
+	RequestID Field = "request-id"
+
+	// Pattern represents the [Options.Mux] pattern matching the request (e.g. "GET /users/{id}"), logged as "pattern". The
+	// attribute is omitted if [Options.Mux] is nil, or no pattern matches.
+	Pattern Field = "pattern"
+)
+
+// Fields returns all supported [Field] values.
+func Fields() []Field {
+	return []Field{Method, Path, Pattern, Query, Status, Bytes, Duration, IP, UserAgent, RequestID}
+}
+
+// Options represents the configuration settings for the [Logging] middleware component.
+type Options struct {
+	// Logger represents the access log's destination. Defaults to [slog.Default].
+	Logger *slog.Logger
+
+	// Message represents the access log's message. Defaults to "HTTP Request".
+	Message string
+
+	// Fields represents the logged attributes. Defaults to [Fields].
+	Fields []Field
+
+	// Redact represents query parameter names, matched case-insensitively, whose values are replaced with "[redacted]". Defaults
+	// to common credential parameter names (e.g. "token", "password").
+	Redact []string
+
+	// Levels maps a status class (e.g. 4 for 4xx responses) to its log level. Unmapped classes are logged at [slog.LevelInfo].
+	// Defaults to [slog.LevelWarn] for 4xx, and [slog.LevelError] for 5xx responses.
+	Levels map[int]slog.Level
+
+	// Classify logs the failure class of error responses - see failure.FromStatus - as the "class" attribute, deriving the record's
+	// log level from the class (see failure.Class.Level) rather than [Options.Levels]. Defaults to false.
+	Classify bool
+
+	// Sample represents the percentage (0 - 100) of 2xx responses logged; all other responses are always logged. Defaults to 100.
+	Sample float64
+
+	// Mux represents an optional [http.ServeMux] whose matched pattern is logged as the [Pattern] field, such that access logs
+	// can be aggregated by route template. Defaults to nil.
+	Mux *http.ServeMux
+}
+
+// Logging represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Logging struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Logging] middleware's [Options] and returns the updated middleware instance.
+func (x *Logging) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Logger:  nil,
+			Message: "HTTP Request",
+			Fields:  Fields(),
+			Redact:  []string{"token", "access_token", "refresh_token", "id_token", "password", "secret", "api_key", "key", "signature"},
+			Levels: map[int]slog.Level{
+				4: slog.LevelWarn,
+				5: slog.LevelError,
+			},
+			Classify: false,
+			Sample:   100,
+			Mux:      nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Message == "" {
+		x.options.Message = "HTTP Request"
+	}
+
+	if x.options.Sample < 0 || x.options.Sample > 100 {
+		slog.Warn("Invalid Logging Sample Specified - Using Default Sample")
+
+		x.options.Sample = 100
+	}
+
+	return x
+}
+
+// query returns the url's query, with [Options.Redact] parameters' values redacted.
+func (x *Logging) query(u *url.URL) string {
+	if u.RawQuery == "" {
+		return ""
+	}
+
+	values, e := url.ParseQuery(u.RawQuery)
+	if e != nil {
+		return "[unparseable]"
+	}
+
+	for k := range values {
+		if slices.ContainsFunc(x.options.Redact, func(v string) bool { return strings.EqualFold(k, v) }) {
+			for index := range values[k] {
+				values[k][index] = "[redacted]"
+			}
+		}
+	}
+
+	return values.Encode()
+}
+
+// Handler logs a structured access log message, with the configured [Options.Fields], once the next handler returns. The
+// [Logging] middleware should be chained after the rip and requestid middleware, such that their values are available.
+func (x *Logging) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		start := time.Now()
+
+		recorder := middleware.Wrap(w)
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.Status()
+
+		if status >= 200 && status < 300 && x.options.Sample < 100 && rand.Float64()*100 >= x.options.Sample {
+			return
+		}
+
+		level, found := x.options.Levels[status/100]
+		if !(found) {
+			level = slog.LevelInfo
+		}
+
+		class := failure.FromStatus(status)
+		if x.options.Classify && class != "" {
+			level = class.Level()
+		}
+
+		logger := x.options.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+
+		if !(logger.Enabled(ctx, level)) {
+			return
+		}
+
+		attributes := make([]slog.Attr, 0, len(x.options.Fields))
+		for _, field := range x.options.Fields {
+			switch field {
+			case Method:
+				attributes = append(attributes, slog.String(string(field), r.Method))
+			case Path:
+				attributes = append(attributes, slog.String(string(field), r.URL.Path))
+			case Pattern:
+				if x.options.Mux == nil {
+					continue
+				}
+
+				if _, pattern := x.options.Mux.Handler(r); pattern != "" {
+					attributes = append(attributes, slog.String(string(field), pattern))
+				}
+			case Query:
+				attributes = append(attributes, slog.String(string(field), x.query(r.URL)))
+			case Status:
+				attributes = append(attributes, slog.Int(string(field), status))
+			case Bytes:
+				attributes = append(attributes, slog.Int64(string(field), recorder.Bytes()))
+			case Duration:
+				attributes = append(attributes, slog.Duration(string(field), time.Since(start)))
+			case IP:
+				ip := rip.Value(ctx)
+				if ip == "" {
+					ip = r.RemoteAddr
+					if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+						ip = v
+					}
+				}
+
+				attributes = append(attributes, slog.String(string(field), ip))
+			case UserAgent:
+				attributes = append(attributes, slog.String(string(field), r.UserAgent()))
+			case RequestID:
+				attributes = append(attributes, slog.String(string(field), requestid.Value(ctx)))
+			}
+		}
+
+		if x.options.Classify && class != "" {
+			attributes = append(attributes, slog.String("class", string(class)))
+		}
+
+		logger.LogAttrs(ctx, level, x.options.Message, attributes...)
+	})
+}
+
+// New creates a new instance of the [Logging] middleware, implementing [middleware.Configurable]. If [Logging.Settings] isn't
+// called, then the [Logging.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Logging)
+}
+
+// Runtime assurance that [Logging] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Logging)(nil)
@@ -0,0 +1,104 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/logging"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/rip"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, "Not Found", http.StatusNotFound)
+		case "/failure":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte("Hello World"))
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", handler)
+
+	tests := []struct {
+		name     string
+		target   string
+		settings func(o *logging.Options)
+		logged   bool
+		level    string
+		fields   map[string]interface{}
+	}{
+		{name: "Success", target: "/", settings: nil, logged: true, level: "INFO", fields: map[string]interface{}{"method": "GET", "path": "/", "status": 200.0, "bytes": 11.0, "ip": "192.0.2.1", "user-agent": "Testing-Agent", "request-id": "testing-request-id"}},
+		{name: "Client-Error", target: "/missing", settings: nil, logged: true, level: "WARN", fields: map[string]interface{}{"status": 404.0}},
+		{name: "Server-Error", target: "/failure", settings: nil, logged: true, level: "ERROR", fields: map[string]interface{}{"status": 500.0, "bytes": 0.0}},
+		{name: "Redacted-Query", target: "/?page=2&Token=secret&password=secret", settings: nil, logged: true, level: "INFO", fields: map[string]interface{}{"query": "Token=%5Bredacted%5D&page=2&password=%5Bredacted%5D"}},
+		{name: "Selected-Fields", target: "/", settings: func(o *logging.Options) { o.Fields = []logging.Field{logging.Status} }, logged: true, level: "INFO", fields: map[string]interface{}{"status": 200.0, "method": nil, "path": nil}},
+		{name: "Custom-Level", target: "/", settings: func(o *logging.Options) { o.Levels = map[int]slog.Level{2: slog.LevelDebug} }, logged: true, level: "DEBUG", fields: map[string]interface{}{"status": 200.0}},
+		{name: "Sampled-Success", target: "/", settings: func(o *logging.Options) { o.Sample = 0 }, logged: false},
+		{name: "Pattern", target: "/users/42", settings: func(o *logging.Options) { o.Mux = mux }, logged: true, level: "INFO", fields: map[string]interface{}{"path": "/users/42", "pattern": "GET /users/{id}"}},
+		{name: "Unmatched-Pattern", target: "/", settings: func(o *logging.Options) { o.Mux = mux }, logged: true, level: "INFO", fields: map[string]interface{}{"path": "/", "pattern": nil}},
+		{name: "Sampled-Error", target: "/missing", settings: func(o *logging.Options) { o.Sample = 0 }, logged: true, level: "WARN", fields: map[string]interface{}{"status": 404.0}},
+		{name: "Classified-Client-Error", target: "/missing", settings: func(o *logging.Options) { o.Classify = true }, logged: true, level: "INFO", fields: map[string]interface{}{"status": 404.0, "class": "client"}},
+		{name: "Classified-Server-Error", target: "/failure", settings: func(o *logging.Options) { o.Classify = true }, logged: true, level: "ERROR", fields: map[string]interface{}{"status": 500.0, "class": "internal"}},
+		{name: "Classified-Success", target: "/", settings: func(o *logging.Options) { o.Classify = true }, logged: true, level: "INFO", fields: map[string]interface{}{"status": 200.0, "class": nil}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+
+			logger := slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			settings := func(o *logging.Options) {
+				o.Logger = logger
+
+				if test.settings != nil {
+					test.settings(o)
+				}
+			}
+
+			chain := rip.New().Handler(requestid.New().Handler(logging.New().Settings(settings).Handler(handler)))
+
+			request := httptest.NewRequest(http.MethodGet, test.target, nil)
+			request.Header.Set("User-Agent", "Testing-Agent")
+			request.Header.Set("X-Request-ID", "testing-request-id")
+
+			chain.ServeHTTP(httptest.NewRecorder(), request)
+
+			if !(test.logged) {
+				if buffer.Len() != 0 {
+					t.Errorf("Unexpected Log Message: %s", buffer.String())
+				}
+
+				return
+			}
+
+			var message map[string]interface{}
+			if e := json.Unmarshal(buffer.Bytes(), &message); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Log Message: %v", e)
+			}
+
+			if message["msg"] != "HTTP Request" {
+				t.Errorf("Message = %v\n    - Expectation = %s", message["msg"], "HTTP Request")
+			}
+
+			if message["level"] != test.level {
+				t.Errorf("Level = %v\n    - Expectation = %s", message["level"], test.level)
+			}
+
+			for k, expectation := range test.fields {
+				if v := message[k]; v != expectation {
+					t.Errorf("%s = %v\n    - Expectation = %v", k, v, expectation)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package maintenance provides maintenance-mode middleware, answering requests with a [net/http.StatusServiceUnavailable] response,
+// a templated body, and a Retry-After header while maintenance is active.
+//
+// Maintenance is toggled at runtime - either through the [Maintenance] instance's [Maintenance.Enable] and [Maintenance.Disable]
+// methods (an atomic switch), or through an [Options.Active] callback (e.g. backed by a feature flag, or a file's presence).
+// Configured paths - e.g. health checks, and admin endpoints - remain available throughout; see [Options.Exempt].
+package maintenance
@@ -0,0 +1,64 @@
+package maintenance_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/maintenance"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	instance := maintenance.New()
+
+	middleware.Add(instance.Settings(func(o *maintenance.Options) {
+		o.Exempt = append(o.Exempt, "/admin/")
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /orders", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	instance.Enable()
+
+	for _, path := range []string{"/orders", "/healthz"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("%s: %d (Retry-After: %q)\n", path, response.StatusCode, response.Header.Get("Retry-After"))
+	}
+
+	// Output:
+	// /orders: 503 (Retry-After: "300")
+	// /healthz: 200 (Retry-After: "")
+}
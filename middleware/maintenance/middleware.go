@@ -0,0 +1,203 @@
+package maintenance
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "maintenance"
+
+// Options represents the configuration settings for the [Maintenance] middleware component.
+type Options struct {
+	// Active optionally reports whether maintenance is active (e.g. backed by a feature flag), superseding the [Maintenance.Enable]
+	// switch. Defaults to nil - the switch determines whether maintenance is active.
+	Active func() bool
+
+	// Exempt represents the paths served throughout maintenance. Entries ending in "/" match the path's subtree; other entries
+	// match exactly. Defaults to common health check paths: "/health", "/healthz", "/livez", and "/readyz".
+	Exempt []string
+
+	// Bypass optionally reports whether a request is served throughout maintenance - e.g. an operator's request, carrying an
+	// admin token. Defaults to nil.
+	Bypass func(r *http.Request) bool
+
+	// Retry represents the Retry-After header's value. Defaults to 5 minutes.
+	Retry time.Duration
+
+	// Message represents the maintenance page's message. Defaults to "Service Under Maintenance".
+	Message string
+
+	// Template represents the maintenance page's template, executed with a [Page]. Defaults to a minimal HTML document.
+	Template *template.Template
+
+	// ContentType represents the maintenance page's Content-Type. Defaults to "text/html; charset=utf-8".
+	ContentType string
+}
+
+// Maintenance represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Maintenance struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	active atomic.Bool
+}
+
+// Settings applies configuration functions to modify the [Maintenance] middleware's [Options] and returns the updated middleware instance.
+func (x *Maintenance) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Active:      nil,
+			Exempt:      []string{"/health", "/healthz", "/livez", "/readyz"},
+			Bypass:      nil,
+			Retry:       time.Minute * 5,
+			Message:     "Service Under Maintenance",
+			Template:    page,
+			ContentType: "text/html; charset=utf-8",
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Retry <= 0 {
+		slog.Warn("Invalid Maintenance Retry Specified - Using Default Retry")
+
+		x.options.Retry = time.Minute * 5
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = "Service Under Maintenance"
+	}
+
+	if x.options.Template == nil {
+		x.options.Template = page
+	}
+
+	if x.options.ContentType == "" {
+		x.options.ContentType = "text/html; charset=utf-8"
+	}
+
+	return x
+}
+
+// Enable activates maintenance. It's safe for concurrent use.
+func (x *Maintenance) Enable() {
+	if !(x.active.Swap(true)) {
+		slog.Info("Maintenance Mode Enabled")
+	}
+}
+
+// Disable deactivates maintenance. It's safe for concurrent use.
+func (x *Maintenance) Disable() {
+	if x.active.Swap(false) {
+		slog.Info("Maintenance Mode Disabled")
+	}
+}
+
+// Active reports whether maintenance is active - according to [Options.Active], if configured, otherwise the [Maintenance.Enable] switch.
+func (x *Maintenance) Active() bool {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Active != nil {
+		return x.options.Active()
+	}
+
+	return x.active.Load()
+}
+
+// exempt reports whether the request is served throughout maintenance.
+func (x *Maintenance) exempt(r *http.Request) bool {
+	for _, path := range x.options.Exempt {
+		if r.URL.Path == path || (strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path)) {
+			return true
+		}
+	}
+
+	return x.options.Bypass != nil && x.options.Bypass(r)
+}
+
+// Handler answers requests with a [http.StatusServiceUnavailable] response while maintenance is active, unless they're exempt. The
+// maintenance state is stored in the request context, such that exempt handlers (e.g. a readiness check) can report it.
+func (x *Maintenance) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		active := x.Active()
+		if !(active) || x.exempt(r) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, active)))
+
+			return
+		}
+
+		seconds := int64((x.options.Retry + time.Second - 1) / time.Second)
+
+		var buffer bytes.Buffer
+		if e := x.options.Template.Execute(&buffer, Page{Message: x.options.Message, Retry: x.options.Retry, Seconds: seconds}); e != nil {
+			slog.ErrorContext(ctx, "Unable to Execute Maintenance Template", slog.String("error", e.Error()))
+
+			buffer.Reset()
+			buffer.WriteString(x.options.Message)
+
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", x.options.ContentType)
+		}
+
+		reject.Record(ctx, reject.Reason{Subsystem: "maintenance", Code: "maintenance-active", Status: http.StatusServiceUnavailable})
+
+		w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		if r.Method != http.MethodHead {
+			w.Write(buffer.Bytes())
+		}
+	})
+}
+
+// New creates a new instance of the [Maintenance] middleware, implementing [middleware.Configurable]. If [Maintenance.Settings] isn't
+// called, then the [Maintenance.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to call [Maintenance.Enable], and [Maintenance.Disable].
+func New() *Maintenance {
+	return new(Maintenance)
+}
+
+// Value reports whether maintenance was active when the request was received - only exempt requests reach their handler during
+// maintenance. False is returned if the [Maintenance] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value bool) {
+	if v, ok := ctx.Value(key).(bool); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Maintenance] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Maintenance)(nil)
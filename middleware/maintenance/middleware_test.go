@@ -0,0 +1,130 @@
+package maintenance_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/maintenance"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if maintenance.Value(r.Context()) {
+			w.Header().Set("X-Maintenance", "true")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name        string
+		settings    func(o *maintenance.Options)
+		enabled     bool
+		path        string
+		headers     map[string]string
+		status      int
+		retry       string
+		body        string
+		maintenance bool
+	}{
+		{name: "Inactive", path: "/", status: http.StatusOK},
+		{name: "Active", enabled: true, path: "/orders", status: http.StatusServiceUnavailable, retry: "300", body: "Service Under Maintenance"},
+		{name: "Exempt-Health", enabled: true, path: "/healthz", status: http.StatusOK, maintenance: true},
+		{name: "Exempt-Subtree", settings: func(o *maintenance.Options) { o.Exempt = []string{"/admin/"} }, enabled: true, path: "/admin/settings", status: http.StatusOK, maintenance: true},
+		{name: "Exempt-Exact", settings: func(o *maintenance.Options) { o.Exempt = []string{"/admin"} }, enabled: true, path: "/admin/settings", status: http.StatusServiceUnavailable, retry: "300"},
+		{
+			name: "Bypass",
+			settings: func(o *maintenance.Options) {
+				o.Bypass = func(r *http.Request) bool { return r.Header.Get("X-Admin-Token") == "secret" }
+			},
+			enabled:     true,
+			path:        "/orders",
+			headers:     map[string]string{"X-Admin-Token": "secret"},
+			status:      http.StatusOK,
+			maintenance: true,
+		},
+		{name: "Callback", settings: func(o *maintenance.Options) { o.Active = func() bool { return true } }, path: "/", status: http.StatusServiceUnavailable, retry: "300"},
+		{name: "Callback-Supersedes-Switch", settings: func(o *maintenance.Options) { o.Active = func() bool { return false } }, enabled: true, path: "/", status: http.StatusOK},
+		{
+			name: "Template",
+			settings: func(o *maintenance.Options) {
+				o.Retry = time.Millisecond * 1500
+				o.Message = "Upgrading"
+				o.ContentType = "application/json"
+				o.Template = template.Must(template.New("json").Parse(`{"message": "{{.Message}}", "retry": {{.Seconds}}}`))
+			},
+			enabled: true,
+			path:    "/",
+			status:  http.StatusServiceUnavailable,
+			retry:   "2",
+			body:    `{"message": "Upgrading", "retry": 2}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := maintenance.New()
+			if test.enabled {
+				instance.Enable()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			for k, v := range test.headers {
+				request.Header.Set(k, v)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			instance.Settings(test.settings).Handler(handler).ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("Retry-After"); v != test.retry {
+				t.Errorf("Retry-After = %s\n    - Expectation = %s", v, test.retry)
+			}
+
+			if v := recorder.Body.String(); !(strings.Contains(v, test.body)) {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+
+			if v := recorder.Header().Get("X-Maintenance") == "true"; v != test.maintenance {
+				t.Errorf("Maintenance = %t\n    - Expectation = %t", v, test.maintenance)
+			}
+		})
+	}
+
+	t.Run("Toggle", func(t *testing.T) {
+		instance := maintenance.New()
+
+		handler := instance.Handler(handler)
+
+		for _, enabled := range []bool{true, false} {
+			if enabled {
+				instance.Enable()
+			} else {
+				instance.Disable()
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if expectation := map[bool]int{true: http.StatusServiceUnavailable, false: http.StatusOK}[enabled]; recorder.Code != expectation {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, expectation)
+			}
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := maintenance.Value(context.Background()); v {
+			t.Errorf("Value = %t\n    - Expectation = %t", v, false)
+		}
+	})
+}
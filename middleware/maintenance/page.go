@@ -0,0 +1,33 @@
+package maintenance
+
+import (
+	"html/template"
+	"time"
+)
+
+// Page represents the maintenance template's data. See [Options.Template].
+type Page struct {
+	// Message represents the [Options.Message].
+	Message string
+
+	// Retry represents the [Options.Retry] duration.
+	Retry time.Duration
+
+	// Seconds represents the Retry-After header's value, in whole seconds.
+	Seconds int64
+}
+
+// page represents the default maintenance HTML document.
+var page = template.Must(template.New("maintenance").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex, nofollow">
+<title>{{.Message}}</title>
+</head>
+<body>
+<h1>{{.Message}}</h1>
+<p>We're performing scheduled maintenance. Please try again in {{.Retry}}.</p>
+</body>
+</html>
+`))
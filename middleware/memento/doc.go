@@ -0,0 +1,8 @@
+// Package memento provides middleware implementing Memento-style (RFC 7089) datetime negotiation, for APIs exposing historical
+// resource states.
+//
+// A request's Accept-Datetime header is parsed, validated, and clamped to the supported range - bounded by an optional earliest
+// state or retention window, and the current time. Handlers serve the resource state at the resolved timestamp (see [Value]),
+// optionally refining it to the actual datetime of the served revision via [Valuer.Resolve]. Datetime-negotiated responses
+// include a Memento-Datetime header, and every response varies on Accept-Datetime.
+package memento
@@ -0,0 +1,58 @@
+package memento_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/memento"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(memento.New().Settings(func(o *memento.Options) {
+		o.Earliest = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Resolved: %s\n", memento.Value(r.Context()).Resolved().Format(time.DateOnly))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Accept-Datetime", "Thu, 31 May 2007 20:35:00 GMT")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Memento-Datetime: %s\n", response.Header.Get("Memento-Datetime"))
+
+	// Output:
+	// Resolved: 2020-01-01
+	// Memento-Datetime: Wed, 01 Jan 2020 00:00:00 GMT
+}
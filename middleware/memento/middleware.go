@@ -0,0 +1,276 @@
+package memento
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "memento"
+
+// Valuer is the context return type relating to the [Memento] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	mutex sync.Mutex
+
+	requested time.Time
+	resolved  time.Time
+	clamped   bool
+}
+
+// Requested returns the request's parsed Accept-Datetime header. A zero value represents a request for the resource's current state.
+func (v *Valuer) Requested() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.requested
+}
+
+// Resolved returns the timestamp of the resource state to serve - the requested timestamp, clamped to the supported range. A
+// zero value represents the resource's current state.
+func (v *Valuer) Resolved() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.resolved
+}
+
+// Clamped reports whether the requested timestamp fell outside the supported range.
+func (v *Valuer) Clamped() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.clamped
+}
+
+// MarshalJSON encodes the [Valuer] with RFC 3339 timestamps. Zero timestamps - representing the resource's current state -
+// are omitted.
+func (v *Valuer) MarshalJSON() ([]byte, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	value := struct {
+		Requested string `json:"requested,omitempty"`
+		Resolved  string `json:"resolved,omitempty"`
+		Clamped   bool   `json:"clamped"`
+	}{Clamped: v.clamped}
+
+	if !(v.requested.IsZero()) {
+		value.Requested = v.requested.UTC().Format(time.RFC3339)
+	}
+
+	if !(v.resolved.IsZero()) {
+		value.Resolved = v.resolved.UTC().Format(time.RFC3339)
+	}
+
+	return json.Marshal(value)
+}
+
+// Resolve overrides the resolved timestamp with the actual datetime of the served resource state (e.g. the nearest preceding
+// revision), as emitted in the response's Memento-Datetime header. It must be called before the response's header(s) are written.
+func (v *Valuer) Resolve(t time.Time) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.resolved = t.UTC().Truncate(time.Second)
+}
+
+// Options represents the configuration settings for the [Memento] middleware component.
+type Options struct {
+	// Earliest represents the earliest supported resource state. Earlier requested timestamps are clamped. A zero value imposes no
+	// lower bound. Defaults to the zero value.
+	Earliest time.Time
+
+	// Window optionally restricts the supported range to the given duration preceding the current time (e.g. a retention period),
+	// in addition to [Options.Earliest]. A zero value imposes no such bound. Defaults to zero.
+	Window time.Duration
+
+	// Strict disables clamping; requested timestamps outside the supported range receive a [http.StatusNotAcceptable] response.
+	// Defaults to false.
+	Strict bool
+
+	// Clock returns the current time, the latest supported resource state. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Memento represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Memento struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Memento] middleware's [Options] and returns the updated middleware instance.
+func (m *Memento) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if m.options == nil {
+		m.options = &Options{
+			Earliest: time.Time{},
+			Window:   0,
+			Strict:   false,
+			Clock:    time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(m.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if m.options.Window < 0 {
+		slog.Warn("Invalid Memento Window Specified - Using Default Window")
+
+		m.options.Window = 0
+	}
+
+	if m.options.Clock == nil {
+		m.options.Clock = time.Now
+	}
+
+	return m
+}
+
+// bounds returns the supported range of resource states.
+func (m *Memento) bounds() (earliest, latest time.Time) {
+	latest = m.options.Clock().UTC()
+	earliest = m.options.Earliest
+
+	if m.options.Window > 0 {
+		if v := latest.Add(-m.options.Window); v.After(earliest) {
+			earliest = v
+		}
+	}
+
+	return earliest, latest
+}
+
+// writer emits the Memento-Datetime response header upon writing the response's header(s).
+type writer struct {
+	http.ResponseWriter
+
+	valuer  *Valuer
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) {
+		w.written = true
+
+		if v := w.valuer.Resolved(); !(v.IsZero()) && w.ResponseWriter.Header().Get("Memento-Datetime") == "" {
+			w.ResponseWriter.Header().Set("Memento-Datetime", v.Format(http.TimeFormat))
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], emitting the Memento-Datetime header before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler negotiates the request's Accept-Datetime header, clamping the requested timestamp to the supported range, and stores
+// the resolved timestamp in the request context. Responses vary on Accept-Datetime, and datetime-negotiated responses include a
+// Memento-Datetime header. Malformed headers receive a [http.StatusBadRequest] response.
+func (m *Memento) Handler(next http.Handler) http.Handler {
+	m.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		w.Header().Add("Vary", "Accept-Datetime")
+
+		valuer := new(Valuer)
+
+		if header := r.Header.Get("Accept-Datetime"); header != "" {
+			requested, e := http.ParseTime(header)
+			if e != nil {
+				http.Error(w, "Invalid Accept-Datetime Header", http.StatusBadRequest)
+
+				return
+			}
+
+			valuer.requested = requested.UTC()
+			valuer.resolved = valuer.requested
+
+			earliest, latest := m.bounds()
+
+			switch {
+			case !(earliest.IsZero()) && valuer.resolved.Before(earliest):
+				valuer.resolved, valuer.clamped = earliest.Truncate(time.Second), true
+			case valuer.resolved.After(latest):
+				valuer.resolved, valuer.clamped = latest.Truncate(time.Second), true
+			}
+
+			if valuer.clamped && m.options.Strict {
+				http.Error(w, "Accept-Datetime Outside Supported Range", http.StatusNotAcceptable)
+
+				return
+			}
+		}
+
+		next.ServeHTTP(&writer{ResponseWriter: w, valuer: valuer}, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Memento] middleware, implementing [middleware.Configurable]. If [Memento.Settings] isn't
+// called, then the [Memento.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Memento)
+}
+
+// Value retrieves the request's negotiated [Valuer] from the provided context. If a nil value is returned, it can be assumed that
+// the [Memento] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Memento] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Memento)(nil)
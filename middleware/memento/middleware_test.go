@@ -0,0 +1,123 @@
+package memento_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/memento"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := memento.Value(r.Context())
+
+		w.Header().Set("X-Clamped", strconv.FormatBool(v.Clamped()))
+
+		if r.URL.Query().Has("revision") {
+			v.Resolve(time.Date(2020, 6, 1, 12, 0, 0, 500, time.UTC))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		options func(o *memento.Options)
+		target  string
+		header  string
+		status  int
+		memento string
+		clamped bool
+	}{
+		{name: "Absent", target: "/", status: http.StatusOK},
+		{name: "Valid", target: "/", header: "Thu, 31 May 2007 20:35:00 GMT", status: http.StatusOK, memento: "Thu, 31 May 2007 20:35:00 GMT"},
+		{name: "Valid-RFC850", target: "/", header: "Thursday, 31-May-07 20:35:00 GMT", status: http.StatusOK, memento: "Thu, 31 May 2007 20:35:00 GMT"},
+		{name: "Invalid", target: "/", header: "yesterday", status: http.StatusBadRequest},
+		{name: "Future-Clamped", target: "/", header: "Fri, 01 Jan 2100 00:00:00 GMT", status: http.StatusOK, memento: "Thu, 01 Jan 2026 00:00:00 GMT", clamped: true},
+		{name: "Earliest-Clamped", options: func(o *memento.Options) {
+			o.Earliest = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+		}, target: "/", header: "Thu, 31 May 2007 20:35:00 GMT", status: http.StatusOK, memento: "Fri, 01 Jan 2010 00:00:00 GMT", clamped: true},
+		{name: "Window-Clamped", options: func(o *memento.Options) {
+			o.Window = 24 * time.Hour
+		}, target: "/", header: "Thu, 31 May 2007 20:35:00 GMT", status: http.StatusOK, memento: "Wed, 31 Dec 2025 00:00:00 GMT", clamped: true},
+		{name: "Strict", options: func(o *memento.Options) {
+			o.Strict = true
+			o.Earliest = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+		}, target: "/", header: "Thu, 31 May 2007 20:35:00 GMT", status: http.StatusNotAcceptable},
+		{name: "Resolved-Revision", target: "/?revision", header: "Mon, 01 Jun 2020 18:00:00 GMT", status: http.StatusOK, memento: "Mon, 01 Jun 2020 12:00:00 GMT"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := memento.New().Settings(func(o *memento.Options) {
+				o.Clock = func() time.Time { return now }
+			}, test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, test.target, nil)
+			if test.header != "" {
+				request.Header.Set("Accept-Datetime", test.header)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("Vary"); v != "Accept-Datetime" {
+				t.Errorf("Vary = %s\n    - Expectation = %s", v, "Accept-Datetime")
+			}
+
+			if test.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("Memento-Datetime"); v != test.memento {
+				t.Errorf("Memento-Datetime = %s\n    - Expectation = %s", v, test.memento)
+			}
+
+			if v := recorder.Header().Get("X-Clamped"); v != strconv.FormatBool(test.clamped) {
+				t.Errorf("Clamped = %s\n    - Expectation = %t", v, test.clamped)
+			}
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		valuer := new(memento.Valuer)
+
+		for _, expectation := range []string{`{"clamped":false}`, `{"resolved":"2024-01-02T03:04:05Z","clamped":false}`} {
+			serialized, e := json.Marshal(valuer)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+			}
+
+			if string(serialized) != expectation {
+				t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+			}
+
+			valuer.Resolve(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := memento.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := new(memento.Valuer)
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := memento.Value(ctx); v != expectation {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
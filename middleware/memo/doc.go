@@ -0,0 +1,8 @@
+// Package memo provides middleware storing a per-request memoization [Store] in the request context, such that multiple
+// components within a single request - e.g. authentication lookups, tenant configuration, or feature flags - can share
+// expensive lookups without a global cache.
+//
+// [Do] memoizes a lookup with single-flight semantics: concurrent callers of the same key wait for, and share, the first
+// caller's result. [Get] and [Set] provide typed access to memoized values. Memoized values live only as long as the request;
+// without the [Memo] middleware in the chain, lookups are performed for every call.
+package memo
@@ -0,0 +1,65 @@
+package memo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/memo"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(memo.New().Handler)
+
+	lookups := 0
+
+	tenant := func(ctx context.Context) (string, error) {
+		return memo.Do(ctx, "tenant", func() (string, error) {
+			lookups++ // e.g. a database query.
+
+			return "tenant-a", nil
+		})
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		for range 3 {
+			tenant(r.Context())
+		}
+
+		fmt.Printf("Lookup(s): %d\n", lookups)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Lookup(s): 1
+}
@@ -0,0 +1,166 @@
+package memo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// store returns the context's [Store] without logging its absence, as memoization is optional.
+func store(ctx context.Context) *Store {
+	if v, ok := ctx.Value(key).(*Store); ok {
+		return v
+	}
+
+	return nil
+}
+
+// cast returns the memoized value as T.
+func cast[T any](ctx context.Context, name string, value interface{}) (T, bool) {
+	typecast, ok := value.(T)
+	if !(ok) && value != nil {
+		var zero T
+
+		slog.WarnContext(ctx, "Memoized Value Type Mismatch", slog.String("key", name), slog.String("type", fmt.Sprintf("%T", value)), slog.String("expectation", fmt.Sprintf("%T", zero)))
+	}
+
+	return typecast, ok
+}
+
+// Get returns the value memoized for the key, and whether it's present. A lookup in flight (see [Do]) is waited for, unless the
+// context is done. Values of a type other than T are reported as absent.
+func Get[T any](ctx context.Context, name string) (T, bool) {
+	var zero T
+
+	s := store(ctx)
+	if s == nil {
+		return zero, false
+	}
+
+	s.mutex.Lock()
+	current, found := s.entries[name]
+	s.mutex.Unlock()
+
+	if !(found) {
+		return zero, false
+	}
+
+	select {
+	case <-current.done:
+	case <-ctx.Done():
+		return zero, false
+	}
+
+	if current.e != nil {
+		return zero, false
+	}
+
+	return cast[T](ctx, name, current.value)
+}
+
+// Set memoizes the value for the key, superseding any previously memoized value. Set is a no-op without a [Store] in the
+// context, or once the [Options.Limit] is reached.
+func Set[T any](ctx context.Context, name string, value T) {
+	s := store(ctx)
+	if s == nil {
+		return
+	}
+
+	current := &entry{done: make(chan struct{}), value: value}
+	close(current.done)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, found := s.entries[name]; !(found) && len(s.entries) >= s.limit {
+		slog.DebugContext(ctx, "Memo Limit Reached - Value Not Memoized", slog.String("key", name))
+
+		return
+	}
+
+	s.entries[name] = current
+}
+
+// Do returns the value memoized for the key, or calls fn to look it up. Concurrent callers of the same key wait for the first
+// caller's lookup, and share its result - including its error. Failed lookups aren't memoized, such that subsequent calls retry.
+// Without a [Store] in the context, fn is called directly.
+func Do[T any](ctx context.Context, name string, fn func() (T, error)) (T, error) {
+	s := store(ctx)
+	if s == nil {
+		return fn()
+	}
+
+	s.mutex.Lock()
+
+	if current, found := s.entries[name]; found {
+		s.mutex.Unlock()
+
+		select {
+		case <-current.done:
+		case <-ctx.Done():
+			var zero T
+
+			return zero, ctx.Err()
+		}
+
+		if current.e != nil {
+			var zero T
+
+			return zero, current.e
+		}
+
+		if value, ok := cast[T](ctx, name, current.value); ok || current.value == nil {
+			return value, nil
+		}
+
+		return fn()
+	}
+
+	if len(s.entries) >= s.limit {
+		s.mutex.Unlock()
+
+		slog.DebugContext(ctx, "Memo Limit Reached - Value Not Memoized", slog.String("key", name))
+
+		return fn()
+	}
+
+	current := &entry{done: make(chan struct{})}
+	s.entries[name] = current
+
+	s.mutex.Unlock()
+
+	completed := false
+
+	defer func() {
+		if !(completed) {
+			// fn panicked; release waiting callers, and discard the entry.
+			current.e = fmt.Errorf("memo: lookup of %q panicked", name)
+
+			s.mutex.Lock()
+			if s.entries[name] == current {
+				delete(s.entries, name)
+			}
+			s.mutex.Unlock()
+
+			close(current.done)
+		}
+	}()
+
+	value, e := fn()
+
+	completed = true
+
+	current.value, current.e = value, e
+
+	if e != nil {
+		s.mutex.Lock()
+		if s.entries[name] == current {
+			delete(s.entries, name)
+		}
+		s.mutex.Unlock()
+	}
+
+	close(current.done)
+
+	return value, e
+}
@@ -0,0 +1,109 @@
+package memo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "memo"
+
+// entry represents a memoized value, or a lookup in flight.
+type entry struct {
+	done  chan struct{}
+	value interface{}
+	e     error
+}
+
+// Store represents a request's memoized values, and is safe for concurrent use. See [Get], [Set], and [Do].
+type Store struct {
+	mutex   sync.Mutex
+	entries map[string]*entry
+	limit   int
+}
+
+// Len returns the number of memoized values, including lookups in flight.
+func (s *Store) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.entries)
+}
+
+// Options represents the configuration settings for the [Memo] middleware component.
+type Options struct {
+	// Limit represents the maximum number of memoized values per request. Once reached, lookups of new keys are performed without
+	// memoization. Defaults to 1024.
+	Limit int
+}
+
+// Memo represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Memo struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Memo] middleware's [Options] and returns the updated middleware instance.
+func (x *Memo) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Limit: 1024,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Memo Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1024
+	}
+
+	return x
+}
+
+// Handler stores an empty [Store] in the request context, and forwards the request to the next handler in the chain.
+func (x *Memo) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store := &Store{entries: make(map[string]*entry), limit: x.options.Limit}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, store)))
+	})
+}
+
+// New creates a new instance of the [Memo] middleware, implementing [middleware.Configurable]. If [Memo.Settings] isn't called,
+// then the [Memo.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Memo)
+}
+
+// Value retrieves the request's [Store] from the provided context. If a nil value is returned, it can be assumed that the
+// [Memo] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (store *Store) {
+	if v, ok := ctx.Value(key).(*Store); ok {
+		store = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Memo] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Memo)(nil)
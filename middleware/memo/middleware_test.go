@@ -0,0 +1,156 @@
+package memo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/memo"
+)
+
+// serve calls fn with a request context carrying a [memo.Store].
+func serve(t *testing.T, settings func(o *memo.Options), fn func(ctx context.Context)) {
+	t.Helper()
+
+	handler := memo.New().Settings(settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fn(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func Test(t *testing.T) {
+	t.Run("Get-Set", func(t *testing.T) {
+		serve(t, nil, func(ctx context.Context) {
+			if _, found := memo.Get[string](ctx, "tenant"); found {
+				t.Errorf("Found = %v\n    - Expectation = %v", found, false)
+			}
+
+			memo.Set(ctx, "tenant", "tenant-a")
+
+			if v, found := memo.Get[string](ctx, "tenant"); !(found) || v != "tenant-a" {
+				t.Errorf("Value = %s\n    - Expectation = %s", v, "tenant-a")
+			}
+
+			if _, found := memo.Get[int](ctx, "tenant"); found {
+				t.Errorf("Type Mismatch Reported as Found")
+			}
+		})
+	})
+
+	t.Run("Single-Flight", func(t *testing.T) {
+		serve(t, nil, func(ctx context.Context) {
+			var calls atomic.Int64
+
+			release := make(chan struct{})
+
+			var group sync.WaitGroup
+			for range 8 {
+				group.Add(1)
+
+				go func() {
+					defer group.Done()
+
+					v, e := memo.Do(ctx, "flags", func() ([]string, error) {
+						calls.Add(1)
+
+						<-release
+
+						return []string{"beta"}, nil
+					})
+
+					if e != nil || len(v) != 1 || v[0] != "beta" {
+						t.Errorf("Value = %v, %v\n    - Expectation = %v", v, e, []string{"beta"})
+					}
+				}()
+			}
+
+			close(release)
+
+			group.Wait()
+
+			if v := calls.Load(); v != 1 {
+				t.Errorf("Calls = %d\n    - Expectation = %d", v, 1)
+			}
+
+			if v, found := memo.Get[[]string](ctx, "flags"); !(found) || len(v) != 1 {
+				t.Errorf("Value = %v\n    - Expectation = %v", v, []string{"beta"})
+			}
+		})
+	})
+
+	t.Run("Errors-Not-Memoized", func(t *testing.T) {
+		serve(t, nil, func(ctx context.Context) {
+			failure := errors.New("lookup failure")
+
+			if _, e := memo.Do(ctx, "user", func() (int, error) { return 0, failure }); !(errors.Is(e, failure)) {
+				t.Errorf("Error = %v\n    - Expectation = %v", e, failure)
+			}
+
+			v, e := memo.Do(ctx, "user", func() (int, error) { return 42, nil })
+			if e != nil || v != 42 {
+				t.Errorf("Value = %d, %v\n    - Expectation = %d", v, e, 42)
+			}
+		})
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		serve(t, nil, func(ctx context.Context) {
+			func() {
+				defer func() { recover() }()
+
+				memo.Do(ctx, "panic", func() (int, error) { panic("lookup panic") })
+			}()
+
+			v, e := memo.Do(ctx, "panic", func() (int, error) { return 1, nil })
+			if e != nil || v != 1 {
+				t.Errorf("Value = %d, %v\n    - Expectation = %d", v, e, 1)
+			}
+		})
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		serve(t, func(o *memo.Options) {
+			o.Limit = 1
+		}, func(ctx context.Context) {
+			memo.Set(ctx, "a", 1)
+			memo.Set(ctx, "b", 2)
+
+			if _, found := memo.Get[int](ctx, "b"); found {
+				t.Errorf("Value Memoized Beyond Limit")
+			}
+
+			if v := memo.Value(ctx).Len(); v != 1 {
+				t.Errorf("Len = %d\n    - Expectation = %d", v, 1)
+			}
+		})
+	})
+
+	t.Run("Without-Middleware", func(t *testing.T) {
+		var calls int
+
+		for range 2 {
+			memo.Do(context.Background(), "key", func() (int, error) {
+				calls++
+
+				return calls, nil
+			})
+		}
+
+		if calls != 2 {
+			t.Errorf("Calls = %d\n    - Expectation = %d", calls, 2)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		serve(t, nil, func(ctx context.Context) {
+			if v := memo.Value(ctx); v == nil {
+				t.Errorf("Value = %v\n    - Expectation = %s", v, "*memo.Store")
+			}
+		})
+	})
+}
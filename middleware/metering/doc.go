@@ -0,0 +1,15 @@
+// Package metering provides middleware that records billable units per request, attributed to a subject (e.g. an API key or
+// tenant). Usage is aggregated in memory and periodically flushed to a pluggable [Sink].
+//
+// Each request defaults to a single unit. Handlers can override a request's units (e.g. the number of rows exported) via the
+// [Meter] retrieved from [Value].
+//
+// Delivery is at-least-once: a batch whose [Sink.Write] fails is merged back into the pending aggregates and retried on the next
+// flush. Sinks should therefore tolerate duplicate deliveries - see [Usage.Start] for a candidate idempotency key.
+//
+// Usage is additionally totaled per subject across flush windows - available via [Metering.Report], or as JSON via
+// [Metering.Endpoint], e.g. as a per-tenant usage report for billing integrations. The report's distinct subjects are bounded by
+// [Options.Limit], as subjects are commonly client-provided.
+//
+// When the after middleware is chained before the [Metering] middleware, usage is recorded once the response was written.
+package metering
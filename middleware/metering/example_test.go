@@ -0,0 +1,69 @@
+package metering_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/metering"
+)
+
+func Example() {
+	meter := metering.New()
+
+	meter.Settings(func(o *metering.Options) {
+		o.Sink = metering.SinkFunc(func(ctx context.Context, usage []metering.Usage) error {
+			for _, v := range usage {
+				fmt.Printf("Subject: %s, Units: %d, Requests: %d\n", v.Subject, v.Units, v.Requests)
+			}
+
+			return nil
+		})
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(meter.Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /export", func(w http.ResponseWriter, r *http.Request) {
+		// Bill the request by the number of exported rows, rather than the default single unit.
+		metering.Value(r.Context()).Set(250)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/export", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-API-Key", "customer-1")
+
+	response, e := server.Client().Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Close flushes any pending usage - typically called during graceful shutdown.
+	if e := meter.Close(context.Background()); e != nil {
+		panic(e)
+	}
+
+	// Output:
+	// Subject: customer-1, Units: 250, Requests: 1
+}
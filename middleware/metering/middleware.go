@@ -0,0 +1,409 @@
+package metering
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/after"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "metering"
+
+const (
+	defaultInterval = time.Minute
+	defaultSubject  = "anonymous"
+	defaultLimit    = 1000
+	defaultOverflow = "other"
+)
+
+// Meter represents a request's billable units. A [Meter] is safe for concurrent use.
+type Meter struct {
+	units atomic.Int64
+}
+
+// Set overrides the request's billable units.
+func (m *Meter) Set(units int64) {
+	m.units.Store(units)
+}
+
+// Units returns the request's current billable units.
+func (m *Meter) Units() int64 {
+	return m.units.Load()
+}
+
+// Options represents the configuration settings for the [Metering] middleware component.
+type Options struct {
+	// Sink represents the usage destination. A nil value discards flushed usage. Defaults to nil.
+	Sink Sink
+
+	// Subject returns the billable subject of a request. An empty return value attributes usage to "anonymous". Defaults to
+	// a function returning the "X-API-Key" request header.
+	Subject func(r *http.Request) string
+
+	// Units represents the default billable units of a request. Defaults to 1.
+	Units int64
+
+	// Billable reports whether a response, given its status, is billable. A nil value bills every response. Defaults to a function
+	// excluding server errors (5xx).
+	Billable func(status int) bool
+
+	// Interval represents the duration between periodic flushes. Defaults to one minute.
+	Interval time.Duration
+
+	// Timeout represents the maximum duration of a periodic flush's [Sink.Write]. A value of zero disables the timeout. Defaults to zero.
+	Timeout time.Duration
+
+	// Limit represents the maximum number of distinct subjects in the cumulative [Metering.Report]. Once exceeded, usage of
+	// previously unseen subjects is reported under [Options.Overflow]; usage flushed to the [Options.Sink] remains attributed to
+	// its subject. Defaults to 1000.
+	Limit int
+
+	// Overflow represents the subject reported for usage exceeding [Options.Limit]. Defaults to "other".
+	Overflow string
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Metering represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+//
+// Unlike most middleware, callers should retain the [Metering] instance returned by [New] to call [Metering.Close] on shutdown.
+type Metering struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex   sync.Mutex
+	pending map[string]*Usage
+	totals  map[string]*Usage
+
+	start sync.Once
+	stop  sync.Once
+	done  chan struct{}
+}
+
+// Settings applies configuration functions to modify the [Metering] middleware's [Options] and returns the updated middleware instance.
+func (m *Metering) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if m.options == nil {
+		m.options = &Options{
+			Sink: nil,
+			Subject: func(r *http.Request) string {
+				return r.Header.Get("X-API-Key")
+			},
+			Units: 1,
+			Billable: func(status int) bool {
+				return status < http.StatusInternalServerError
+			},
+			Interval: defaultInterval,
+			Timeout:  0,
+			Limit:    defaultLimit,
+			Overflow: defaultOverflow,
+			Clock:    time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(m.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if m.options.Interval <= 0 {
+		slog.Warn("Invalid Metering Interval Specified - Using Default Interval")
+
+		m.options.Interval = defaultInterval
+	}
+
+	if m.options.Limit <= 0 {
+		slog.Warn("Invalid Metering Limit Specified - Using Default Limit")
+
+		m.options.Limit = defaultLimit
+	}
+
+	if m.options.Overflow == "" {
+		m.options.Overflow = defaultOverflow
+	}
+
+	if m.options.Clock == nil {
+		m.options.Clock = time.Now
+	}
+
+	return m
+}
+
+// record aggregates a request's usage - pending delivery, and in the cumulative totals, enforcing the cardinality limit.
+func (m *Metering) record(subject string, units int64) {
+	now := m.options.Clock()
+
+	request := Usage{Units: units, Requests: 1, Start: now, End: now}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.pending == nil {
+		m.pending = make(map[string]*Usage)
+	}
+
+	usage, ok := m.pending[subject]
+	if !(ok) {
+		usage = &Usage{Subject: subject}
+
+		m.pending[subject] = usage
+	}
+
+	usage.merge(request)
+
+	if m.totals == nil {
+		m.totals = make(map[string]*Usage)
+	}
+
+	total, ok := m.totals[subject]
+	if !(ok) {
+		if len(m.totals) >= m.options.Limit {
+			subject = m.options.Overflow
+		}
+
+		if total, ok = m.totals[subject]; !(ok) {
+			total = &Usage{Subject: subject}
+
+			m.totals[subject] = total
+		}
+	}
+
+	total.merge(request)
+}
+
+// Report returns a snapshot of the cumulative usage of each subject, ordered by subject. Unlike flushed usage, the totals span
+// every flush window since the instance's first request, or its last reset. If reset is true, the totals are cleared.
+func (m *Metering) Report(reset bool) []Usage {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	report := make([]Usage, 0, len(m.totals))
+	for _, usage := range m.totals {
+		report = append(report, *usage)
+	}
+
+	if reset {
+		m.totals = nil
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Subject < report[j].Subject })
+
+	return report
+}
+
+// Endpoint returns an [http.Handler] responding with [Metering.Report] as JSON - e.g. for billing integrations. A "subject" query
+// parameter restricts the report to a single subject (e.g. a tenant); otherwise, a "reset" query parameter of "true" clears the
+// totals.
+func (m *Metering) Endpoint() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject := r.URL.Query().Get("subject")
+
+		reset, _ := strconv.ParseBool(r.URL.Query().Get("reset"))
+
+		report := m.Report(reset && subject == "")
+		if subject != "" {
+			filtered := make([]Usage, 0, 1)
+			for _, usage := range report {
+				if usage.Subject == subject {
+					filtered = append(filtered, usage)
+				}
+			}
+
+			report = filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+// Flush delivers all pending usage to the [Options.Sink]. On error, the batch is merged back into the pending usage for redelivery.
+func (m *Metering) Flush(ctx context.Context) error {
+	m.Settings() // Ensure the options field isn't nil.
+
+	m.mutex.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.mutex.Unlock()
+
+	if len(pending) == 0 || m.options.Sink == nil {
+		return nil
+	}
+
+	batch := make([]Usage, 0, len(pending))
+	for _, usage := range pending {
+		batch = append(batch, *usage)
+	}
+
+	sort.Slice(batch, func(i, j int) bool { return batch[i].Subject < batch[j].Subject })
+
+	if e := m.options.Sink.Write(ctx, batch); e != nil {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		if m.pending == nil {
+			m.pending = make(map[string]*Usage, len(batch))
+		}
+
+		for _, usage := range batch {
+			if existing, ok := m.pending[usage.Subject]; ok {
+				existing.merge(usage)
+			} else {
+				copied := usage
+
+				m.pending[usage.Subject] = &copied
+			}
+		}
+
+		return e
+	}
+
+	return nil
+}
+
+// loop periodically flushes pending usage until [Metering.Close] is called.
+func (m *Metering) loop(done <-chan struct{}) {
+	ticker := time.NewTicker(m.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ctx := context.Background()
+
+			var cancel context.CancelFunc = func() {}
+			if m.options.Timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, m.options.Timeout)
+			}
+
+			if e := m.Flush(ctx); e != nil {
+				slog.WarnContext(ctx, "Unable to Flush Metering Usage - Retrying on Next Interval", slog.String("error", e.Error()))
+			}
+
+			cancel()
+		}
+	}
+}
+
+// channel returns the instance's lazily-initialized done channel.
+func (m *Metering) channel() chan struct{} {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.done == nil {
+		m.done = make(chan struct{})
+	}
+
+	return m.done
+}
+
+// Close stops periodic flushing, and performs a final [Metering.Flush].
+func (m *Metering) Close(ctx context.Context) error {
+	done := m.channel()
+
+	m.stop.Do(func() { close(done) })
+
+	return m.Flush(ctx)
+}
+
+// Handler meters each request's billable units, and stores the request's [Meter] in the request context. It forwards the request
+// to the next handler in the chain.
+func (m *Metering) Handler(next http.Handler) http.Handler {
+	m.Settings() // Ensure the options field isn't nil.
+
+	if m.options.Sink == nil {
+		slog.Warn("Metering Sink Unspecified - Flushed Usage Will be Discarded")
+	}
+
+	m.start.Do(func() {
+		go m.loop(m.channel())
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		meter := new(Meter)
+		meter.Set(m.options.Units)
+
+		ctx := context.WithValue(r.Context(), key, meter)
+
+		recorder := middleware.Wrap(w)
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		status := recorder.Status()
+
+		if m.options.Billable != nil && !(m.options.Billable(status)) {
+			return
+		}
+
+		subject := ""
+		if m.options.Subject != nil {
+			subject = m.options.Subject(r)
+		}
+
+		if subject == "" {
+			subject = defaultSubject
+		}
+
+		units := meter.Units()
+
+		// Record usage off the request's latency path when the after middleware is chained before the [Metering] middleware.
+		deferred := after.Defer(ctx, func(ctx context.Context) error {
+			m.record(subject, units)
+
+			return nil
+		})
+
+		if !(deferred) {
+			m.record(subject, units)
+		}
+	})
+}
+
+// New creates a new instance of the [Metering] middleware, implementing [middleware.Configurable]. If [Metering.Settings] isn't called,
+// then the [Metering.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() *Metering {
+	return new(Metering)
+}
+
+// Value retrieves the request's [Meter] from the provided context. If a nil value is returned, it can be assumed that the
+// [Metering] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (meter *Meter) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Meter); ok {
+		meter = v
+	} else if test, valid := ctx.Value(t).(*Meter); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		meter = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Metering] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Metering)(nil)
@@ -0,0 +1,287 @@
+package metering_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/metering"
+)
+
+// sink records delivered batches, failing the first n deliveries.
+type sink struct {
+	mutex    sync.Mutex
+	failures int
+	batches  [][]metering.Usage
+}
+
+func (s *sink) Write(ctx context.Context, usage []metering.Usage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+
+		return errors.New("unavailable")
+	}
+
+	s.batches = append(s.batches, usage)
+
+	return nil
+}
+
+func Test(t *testing.T) {
+	// handler overrides the request's units when the "units" query parameter is present, and responds with the "status" query parameter.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("units"); v != "" {
+			units, _ := strconv.ParseInt(v, 10, 64)
+
+			metering.Value(r.Context()).Set(units)
+		}
+
+		status := http.StatusOK
+		if v := r.URL.Query().Get("status"); v != "" {
+			status, _ = strconv.Atoi(v)
+		}
+
+		w.WriteHeader(status)
+	})
+
+	// evaluate issues a request for each query against the server, using the provided API key.
+	evaluate := func(t *testing.T, server *httptest.Server, api string, queries ...string) {
+		t.Helper()
+
+		for _, query := range queries {
+			request, e := http.NewRequest(http.MethodGet, server.URL+"/?"+query, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			if api != "" {
+				request.Header.Set("X-API-Key", api)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			response.Body.Close()
+		}
+	}
+
+	t.Run("Aggregation", func(t *testing.T) {
+		destination := new(sink)
+
+		instance := metering.New()
+		server := httptest.NewServer(instance.Settings(func(o *metering.Options) {
+			o.Sink = destination
+			o.Interval = time.Hour
+		}).Handler(handler))
+
+		defer server.Close()
+
+		evaluate(t, server, "key-a", "", "units=25", "status=503")
+		evaluate(t, server, "key-b", "status=404")
+		evaluate(t, server, "", "")
+
+		if e := instance.Close(context.Background()); e != nil {
+			t.Fatalf("Unexpected Error While Closing: %v", e)
+		}
+
+		if len(destination.batches) != 1 {
+			t.Fatalf("Batches = %d\n    - Expectation = %d", len(destination.batches), 1)
+		}
+
+		expectations := []metering.Usage{
+			{Subject: "anonymous", Units: 1, Requests: 1},
+			{Subject: "key-a", Units: 26, Requests: 2},
+			{Subject: "key-b", Units: 1, Requests: 1},
+		}
+
+		batch := destination.batches[0]
+		if len(batch) != len(expectations) {
+			t.Fatalf("Usage = %+v\n    - Expectation = %+v", batch, expectations)
+		}
+
+		for index, expectation := range expectations {
+			usage := batch[index]
+			if usage.Subject != expectation.Subject || usage.Units != expectation.Units || usage.Requests != expectation.Requests {
+				t.Errorf("Usage = %+v\n    - Expectation = %+v", usage, expectation)
+			}
+
+			if usage.Start.IsZero() || usage.End.Before(usage.Start) {
+				t.Errorf("Invalid Usage Window: %v - %v", usage.Start, usage.End)
+			}
+		}
+	})
+
+	t.Run("At-Least-Once-Delivery", func(t *testing.T) {
+		destination := &sink{failures: 1}
+
+		instance := metering.New()
+		server := httptest.NewServer(instance.Settings(func(o *metering.Options) {
+			o.Sink = destination
+			o.Interval = time.Hour
+		}).Handler(handler))
+
+		defer server.Close()
+
+		evaluate(t, server, "key-a", "units=10")
+
+		if e := instance.Flush(context.Background()); e == nil {
+			t.Fatalf("Expected an Error From the Failing Sink")
+		}
+
+		evaluate(t, server, "key-a", "units=5")
+
+		if e := instance.Close(context.Background()); e != nil {
+			t.Fatalf("Unexpected Error While Closing: %v", e)
+		}
+
+		if len(destination.batches) != 1 || destination.batches[0][0].Units != 15 || destination.batches[0][0].Requests != 2 {
+			t.Errorf("Batches = %+v\n    - Expectation = [[{Subject: key-a, Units: 15, Requests: 2}]]", destination.batches)
+		}
+	})
+
+	t.Run("Periodic-Flush", func(t *testing.T) {
+		delivered := make(chan []metering.Usage, 1)
+
+		instance := metering.New()
+		server := httptest.NewServer(instance.Settings(func(o *metering.Options) {
+			o.Sink = metering.SinkFunc(func(ctx context.Context, usage []metering.Usage) error {
+				select {
+				case delivered <- usage:
+				default:
+				}
+
+				return nil
+			})
+			o.Interval = time.Millisecond * 10
+		}).Handler(handler))
+
+		defer server.Close()
+		defer instance.Close(context.Background())
+
+		evaluate(t, server, "key-a", "")
+
+		select {
+		case usage := <-delivered:
+			if usage[0].Subject != "key-a" {
+				t.Errorf("Subject = %s\n    - Expectation = %s", usage[0].Subject, "key-a")
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Timeout Waiting for Periodic Flush")
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		destination := new(sink)
+
+		instance := metering.New()
+		server := httptest.NewServer(instance.Settings(func(o *metering.Options) {
+			o.Sink = destination
+			o.Interval = time.Hour
+			o.Limit = 2
+		}).Handler(handler))
+
+		defer server.Close()
+
+		evaluate(t, server, "tenant-a", "units=10")
+
+		if e := instance.Flush(context.Background()); e != nil {
+			t.Fatalf("Unexpected Error While Flushing: %v", e)
+		}
+
+		evaluate(t, server, "tenant-a", "units=5")
+		evaluate(t, server, "tenant-b", "", "")
+		evaluate(t, server, "spoofed-1", "")
+		evaluate(t, server, "spoofed-2", "")
+
+		if e := instance.Close(context.Background()); e != nil {
+			t.Fatalf("Unexpected Error While Closing: %v", e)
+		}
+
+		// The report totals usage across flush windows, collapsing subjects beyond the limit.
+		expectations := []metering.Usage{
+			{Subject: "other", Units: 2, Requests: 2},
+			{Subject: "tenant-a", Units: 15, Requests: 2},
+			{Subject: "tenant-b", Units: 2, Requests: 2},
+		}
+
+		report := instance.Report(true)
+		if len(report) != len(expectations) {
+			t.Fatalf("Report = %+v\n    - Expectation = %+v", report, expectations)
+		}
+
+		for index, expectation := range expectations {
+			if usage := report[index]; usage.Subject != expectation.Subject || usage.Units != expectation.Units || usage.Requests != expectation.Requests {
+				t.Errorf("Usage = %+v\n    - Expectation = %+v", usage, expectation)
+			}
+		}
+
+		// Flushed usage remains attributed to its subject.
+		if batch := destination.batches[len(destination.batches)-1]; len(batch) != 4 {
+			t.Errorf("Batch = %+v\n    - Expectation = %d subjects", batch, 4)
+		}
+
+		if report := instance.Report(false); len(report) != 0 {
+			t.Errorf("Report = %+v\n    - Expectation = %s", report, "an empty report following a reset")
+		}
+	})
+
+	t.Run("Endpoint", func(t *testing.T) {
+		instance := metering.New()
+		server := httptest.NewServer(instance.Settings(func(o *metering.Options) {
+			o.Interval = time.Hour
+		}).Handler(handler))
+
+		defer server.Close()
+		defer instance.Close(context.Background())
+
+		evaluate(t, server, "tenant-a", "units=3")
+		evaluate(t, server, "tenant-b", "")
+
+		recorder := httptest.NewRecorder()
+
+		instance.Endpoint().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/usage?subject=tenant-a&reset=true", nil))
+
+		if v := recorder.Header().Get("Content-Type"); v != "application/json" {
+			t.Errorf("Content-Type = %s\n    - Expectation = %s", v, "application/json")
+		}
+
+		var report []metering.Usage
+		if e := json.NewDecoder(recorder.Body).Decode(&report); e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+		}
+
+		if len(report) != 1 || report[0].Subject != "tenant-a" || report[0].Units != 3 {
+			t.Errorf("Report = %+v\n    - Expectation = %s", report, "tenant-a's usage of 3 units")
+		}
+
+		// A single subject's report doesn't reset the totals.
+		if report := instance.Report(false); len(report) != 2 {
+			t.Errorf("Report = %+v\n    - Expectation = %d subjects", report, 2)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := metering.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		meter := new(metering.Meter)
+		meter.Set(3)
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", meter)
+		if v := metering.Value(ctx); v == nil || v.Units() != 3 {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
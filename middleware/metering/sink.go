@@ -0,0 +1,55 @@
+package metering
+
+import (
+	"context"
+	"time"
+)
+
+// Usage represents the aggregated usage of a single subject over a flush window.
+type Usage struct {
+	// Subject represents the attributed API key, tenant, or other billable subject.
+	Subject string `json:"subject"`
+
+	// Units represents the sum of billable units.
+	Units int64 `json:"units"`
+
+	// Requests represents the number of metered requests.
+	Requests int64 `json:"requests"`
+
+	// Start represents the time of the window's first metered request.
+	Start time.Time `json:"start"`
+
+	// End represents the time of the window's last metered request.
+	End time.Time `json:"end"`
+}
+
+// merge combines usage of the same subject.
+func (u *Usage) merge(other Usage) {
+	u.Units += other.Units
+	u.Requests += other.Requests
+
+	if u.Start.IsZero() || (!(other.Start.IsZero()) && other.Start.Before(u.Start)) {
+		u.Start = other.Start
+	}
+
+	if other.End.After(u.End) {
+		u.End = other.End
+	}
+}
+
+// Sink represents a usage destination (e.g. a billing system's ingestion API).
+type Sink interface {
+	// Write delivers a batch of usage. A non-nil error results in the batch's redelivery on a subsequent flush.
+	Write(ctx context.Context, usage []Usage) error
+}
+
+// SinkFunc is an adapter allowing the use of ordinary functions as a [Sink].
+type SinkFunc func(ctx context.Context, usage []Usage) error
+
+// Write calls f(ctx, usage).
+func (f SinkFunc) Write(ctx context.Context, usage []Usage) error {
+	return f(ctx, usage)
+}
+
+// Runtime assurance that [SinkFunc] satisfies the [Sink] interface.
+var _ Sink = SinkFunc(nil)
@@ -0,0 +1,17 @@
+// Package minify provides inline response minification middleware, rewriting textual responses - e.g. HTML, CSS, JavaScript,
+// and JSON - through a [Minifier] selected by the response's media type.
+//
+// Only a [JSON] compactor is built in; other languages are supported through a [Minifier] wrapping a third-party minifier:
+//
+//	minify.New().Settings(func(o *minify.Options) {
+//		o.Minifiers["text/html"] = minify.MinifierFunc(func(mediatype string, src []byte) ([]byte, error) {
+//			return m.Bytes(mediatype, src) // e.g. github.com/tdewolff/minify
+//		})
+//	})
+//
+// Responses within the configured size range are buffered, minified, and written with an updated Content-Length; a strong ETag is
+// weakened, as the minified representation is semantically - but not byte-for-byte - equivalent. Streamed (flushed), partial,
+// encoded, and "Cache-Control: no-transform" responses are written as is.
+//
+// The compress middleware should precede the [Minify] middleware in the chain, such that the minified response is compressed.
+package minify
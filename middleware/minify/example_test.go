@@ -0,0 +1,62 @@
+package minify_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/minify"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(minify.New().Settings(func(o *minify.Options) {
+		o.Minimum = 0
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{\n    \"users\": [\n        \"Jane Doe\",\n        \"John Doe\"\n    ]\n}\n"))
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/users", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	body, e := io.ReadAll(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading response body: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("Body: %s\n", body)
+
+	// Output:
+	// Body: {"users":["Jane Doe","John Doe"]}
+}
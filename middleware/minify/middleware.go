@@ -0,0 +1,297 @@
+package minify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "minify"
+
+// Valuer is the context return type relating to the [Minify] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	skip atomic.Bool
+}
+
+// Skip exempts the request's response from minification - e.g. for a signed payload, whose exact bytes matter.
+func (v *Valuer) Skip() {
+	v.skip.Store(true)
+}
+
+// Options represents the configuration settings for the [Minify] middleware component.
+type Options struct {
+	// Minifiers maps media types to their [Minifier]. Keys ending in "/*" match the type's subtypes, and keys beginning with "*+"
+	// match structured syntax suffixes; exact keys take precedence. Defaults to [JSON] for "application/json" and "*+json".
+	Minifiers map[string]Minifier
+
+	// Minimum represents the minimum response body size, in bytes, for minification. Defaults to 256.
+	Minimum int
+
+	// Maximum represents the maximum response body size, in bytes, for minification; larger responses are streamed as is, such that
+	// the buffer is bounded. Defaults to 1 MiB.
+	Maximum int
+}
+
+// Minify represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Minify struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Minify] middleware's [Options] and returns the updated middleware instance.
+func (x *Minify) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Minifiers: map[string]Minifier{
+				"application/json": JSON,
+				"*+json":           JSON,
+			},
+			Minimum: 256,
+			Maximum: 1 << 20,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Minimum < 0 {
+		slog.Warn("Invalid Minify Minimum Specified - Using Default Minimum")
+
+		x.options.Minimum = 256
+	}
+
+	if x.options.Maximum <= 0 || x.options.Maximum < x.options.Minimum {
+		slog.Warn("Invalid Minify Maximum Specified - Using Default Maximum")
+
+		x.options.Maximum = max(1<<20, x.options.Minimum)
+	}
+
+	return x
+}
+
+// minifier returns the content type's [Minifier], if any, and its media type.
+func (x *Minify) minifier(content string) (Minifier, string) {
+	mediatype, _, e := mime.ParseMediaType(content)
+	if e != nil {
+		return nil, ""
+	}
+
+	if m, ok := x.options.Minifiers[mediatype]; ok {
+		return m, mediatype
+	}
+
+	for pattern, m := range x.options.Minifiers {
+		switch {
+		case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediatype, strings.TrimSuffix(pattern, "*")):
+			return m, mediatype
+		case strings.HasPrefix(pattern, "*+") && strings.HasSuffix(mediatype, pattern[1:]):
+			return m, mediatype
+		}
+	}
+
+	return nil, ""
+}
+
+// writer buffers the response, up to [Options.Maximum] bytes, until the handler returns; thereafter, the response is minified
+// or written as is.
+type writer struct {
+	http.ResponseWriter
+
+	minify *Minify
+	valuer *Valuer
+
+	status      int
+	written     bool // written represents whether the handler wrote the response's header(s).
+	passthrough bool // passthrough represents whether the buffered response was forwarded as is.
+	buffer      bytes.Buffer
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written, w.status = true, status
+
+	// Informational responses aren't buffered.
+	if status >= 100 && status < 200 {
+		w.written = false
+
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.passthrough {
+		return w.ResponseWriter.Write(p)
+	}
+
+	n, _ := w.buffer.Write(p)
+	if w.buffer.Len() > w.minify.options.Maximum {
+		if e := w.forward(); e != nil {
+			return 0, e
+		}
+	}
+
+	return n, nil
+}
+
+// forward writes the response's header(s), and its buffered body, as is; subsequent writes pass through.
+func (w *writer) forward() error {
+	w.passthrough = true
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	defer w.buffer.Reset()
+
+	_, e := w.ResponseWriter.Write(w.buffer.Bytes())
+
+	return e
+}
+
+// Flush forwards the response as is - streamed responses aren't minified - and flushes the underlying [http.ResponseWriter].
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !(w.passthrough) {
+		w.forward()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// eligible reports whether the buffered response may be minified, returning its [Minifier] and media type.
+func (w *writer) eligible() (Minifier, string) {
+	header := w.ResponseWriter.Header()
+
+	switch {
+	case w.valuer.skip.Load():
+	case w.buffer.Len() < w.minify.options.Minimum:
+	case w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent:
+	case header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "":
+	case strings.Contains(strings.ToLower(strings.Join(header.Values("Cache-Control"), ",")), "no-transform"):
+	default:
+		return w.minify.minifier(header.Get("Content-Type"))
+	}
+
+	return nil, ""
+}
+
+// close finalizes the response, minifying the buffered body if it's eligible.
+func (w *writer) close(r *http.Request) {
+	if !(w.written) || w.passthrough {
+		return
+	}
+
+	m, mediatype := w.eligible()
+	if m == nil {
+		w.forward()
+
+		return
+	}
+
+	minified, e := m.Minify(mediatype, w.buffer.Bytes())
+	if e != nil {
+		slog.DebugContext(r.Context(), "Unable to Minify Response - Writing Response As Is", slog.String("path", r.URL.Path), slog.String("type", mediatype), slog.String("error", e.Error()))
+
+		w.forward()
+
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+
+	if etag := header.Get("ETag"); etag != "" && !(strings.HasPrefix(etag, "W/")) {
+		header.Set("ETag", "W/"+etag)
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(minified)))
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(minified)
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler buffers responses, and minifies eligible responses - those within the [Options.Minimum] and [Options.Maximum] size range,
+// whose media type has a [Minifier] - once the next handler returns. HEAD requests are forwarded unchanged.
+func (x *Minify) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		valuer := new(Valuer)
+
+		r = r.WithContext(context.WithValue(r.Context(), key, valuer))
+
+		if r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		wrapper := &writer{ResponseWriter: w, minify: x, valuer: valuer}
+
+		defer wrapper.close(r)
+
+		next.ServeHTTP(wrapper, r)
+	})
+}
+
+// New creates a new instance of the [Minify] middleware, implementing [middleware.Configurable]. If [Minify.Settings] isn't called,
+// then the [Minify.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Minify)
+}
+
+// Value retrieves the request's minification [Valuer] from the provided context. If a nil value is returned, it can be assumed that
+// the [Minify] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Minify Valuer Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Minify] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Minify)(nil)
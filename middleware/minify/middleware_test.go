@@ -0,0 +1,140 @@
+package minify_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/compress"
+	"github.com/poly-gun/go-middleware/middleware/minify"
+)
+
+func Test(t *testing.T) {
+	document := "{\n    \"users\": [\n        {\"id\": 1, \"name\": \"Jane Doe\"},\n        {\"id\": 2, \"name\": \"John Doe\"}\n    ],\n    \"padding\": \"" + strings.Repeat("x", 256) + "\"\n}\n"
+	compact := `{"users":[{"id":1,"name":"Jane Doe"},{"id":2,"name":"John Doe"}],"padding":"` + strings.Repeat("x", 256) + `"}`
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+
+		switch r.URL.Path {
+		case "/small":
+			w.Write([]byte("{ \"ok\": true }"))
+			return
+		case "/problem":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+		case "/no-transform":
+			w.Header().Set("Cache-Control", "public, no-transform")
+		case "/skip":
+			minify.Value(r.Context()).Skip()
+		case "/invalid":
+			w.Write([]byte("{ \"truncated\": " + strings.Repeat(" ", 256)))
+			return
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		case "/streamed":
+			w.Write([]byte(document[:16]))
+			http.NewResponseController(w).Flush()
+			w.Write([]byte(document[16:]))
+			return
+		}
+
+		w.Write([]byte(document))
+	})
+
+	tests := []struct {
+		name     string
+		options  func(o *minify.Options)
+		path     string
+		status   int
+		body     string
+		etag     string
+		minified bool
+	}{
+		{name: "Minified", path: "/", status: http.StatusOK, body: compact, etag: `W/"v1"`, minified: true},
+		{name: "Structured-Suffix", path: "/problem", status: http.StatusBadRequest, body: compact, etag: `W/"v1"`, minified: true},
+		{name: "Below-Minimum", path: "/small", status: http.StatusOK, body: "{ \"ok\": true }", etag: `"v1"`},
+		{name: "Above-Maximum", options: func(o *minify.Options) { o.Maximum = 300 }, path: "/", status: http.StatusOK, body: document, etag: `"v1"`},
+		{name: "No-Transform", path: "/no-transform", status: http.StatusOK, body: document, etag: `"v1"`},
+		{name: "Skipped", path: "/skip", status: http.StatusOK, body: document, etag: `"v1"`},
+		{name: "Minifier-Error", path: "/invalid", status: http.StatusOK, body: "{ \"truncated\": " + strings.Repeat(" ", 256), etag: `"v1"`},
+		{name: "Unregistered-Type", path: "/text", status: http.StatusOK, body: document, etag: `"v1"`},
+		{name: "Streamed", path: "/streamed", status: http.StatusOK, body: document, etag: `"v1"`},
+		{
+			name: "Custom-Minifier",
+			options: func(o *minify.Options) {
+				o.Minifiers["text/*"] = minify.MinifierFunc(func(mediatype string, src []byte) ([]byte, error) {
+					return bytes.Join(bytes.Fields(src), nil), nil
+				})
+			},
+			path:     "/text",
+			status:   http.StatusOK,
+			body:     strings.Join(strings.Fields(document), ""),
+			etag:     `W/"v1"`,
+			minified: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			minify.New().Settings(test.options).Handler(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			if recorder.Code != test.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Body.String(); v != test.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+
+			if v := recorder.Header().Get("ETag"); v != test.etag {
+				t.Errorf("ETag = %s\n    - Expectation = %s", v, test.etag)
+			}
+
+			if v := recorder.Header().Get("Content-Length"); test.minified && v != strconv.Itoa(len(test.body)) {
+				t.Errorf("Content-Length = %s\n    - Expectation = %d", v, len(test.body))
+			}
+		})
+	}
+
+	t.Run("Compressed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		compress.New().Settings(func(o *compress.Options) { o.Minimum = 0 }).Handler(minify.New().Handler(handler)).ServeHTTP(recorder, request)
+
+		if v := recorder.Header().Get("Content-Encoding"); v != "gzip" {
+			t.Fatalf("Content-Encoding = %s\n    - Expectation = %s", v, "gzip")
+		}
+
+		reader, e := gzip.NewReader(recorder.Body)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+		}
+
+		if body, _ := io.ReadAll(reader); string(body) != compact {
+			t.Errorf("Body = %s\n    - Expectation = %s", string(body), compact)
+		}
+
+		if v := recorder.Header().Get("ETag"); v != `W/"v1"` {
+			t.Errorf("ETag = %s\n    - Expectation = %s", v, `W/"v1"`)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := minify.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package minify
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Minifier represents a media type's minifier.
+type Minifier interface {
+	// Minify returns the minified representation of src, of the given media type (e.g. "text/html"). An error causes the response
+	// to be written as is.
+	Minify(mediatype string, src []byte) ([]byte, error)
+}
+
+// MinifierFunc is an adapter allowing the use of ordinary functions as a [Minifier].
+type MinifierFunc func(mediatype string, src []byte) ([]byte, error)
+
+// Minify calls fn(mediatype, src).
+func (fn MinifierFunc) Minify(mediatype string, src []byte) ([]byte, error) {
+	return fn(mediatype, src)
+}
+
+// JSON represents the built-in JSON compactor, removing insignificant whitespace - see [json.Compact].
+var JSON Minifier = MinifierFunc(func(mediatype string, src []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	if e := json.Compact(&buffer, src); e != nil {
+		return nil, e
+	}
+
+	return buffer.Bytes(), nil
+})
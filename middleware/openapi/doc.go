@@ -0,0 +1,31 @@
+// Package openapi provides OpenAPI 3 validation middleware. Requests are matched to the loaded [Specification]'s operations, and
+// their path, query, header, and cookie parameters - alongside their JSON body - are validated against the operation's schemas.
+//
+// The specification is loaded once, at startup:
+//
+//	data, e := os.ReadFile("openapi.json")
+//	if e != nil {
+//		panic(e)
+//	}
+//
+//	specification, e := openapi.Load(data)
+//	if e != nil {
+//		panic(e)
+//	}
+//
+//	openapi.New().Settings(func(o *openapi.Options) {
+//		o.Specification = specification
+//		o.Prefix = "/api/v1"
+//		o.Responses = openapi.Logged
+//	})
+//
+// Invalid requests receive a JSON [net/http.StatusBadRequest] response listing every [Violation]. Valid requests' matched operation -
+// its "operationId", and path template - is stored in the request context for logging, and metrics; see [Value].
+//
+// Responses are optionally validated against the operation's documented responses: [Logged] logs violations, whereas [Enforced]
+// replaces violating responses with a [net/http.StatusInternalServerError] response.
+//
+// Only JSON documents, local "#/components/..." references, and a subset of the schema keywords are supported: "type", "nullable",
+// "enum", "properties", "required", "additionalProperties", "items", the numeric, length, and item bounds, "pattern", "allOf",
+// "anyOf", and "oneOf". Path templates must span whole segments (e.g. "/users/{id}").
+package openapi
@@ -0,0 +1,72 @@
+package openapi_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/openapi"
+)
+
+func Example() {
+	specification, e := openapi.Load([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Example", "version": "1.0.0"},
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"operationId": "getUser",
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "minimum": 1}}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`))
+
+	if e != nil {
+		e = fmt.Errorf("unexpected error while loading specification: %w", e)
+
+		panic(e)
+	}
+
+	middleware := middleware.New()
+
+	middleware.Add(openapi.New().Settings(func(o *openapi.Options) {
+		o.Specification = specification
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "operation %s", openapi.Value(r.Context()).OperationID)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/users/42", "/users/0"} {
+		response, e := client.Get(server.URL + path)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		content, _ := io.ReadAll(response.Body)
+
+		response.Body.Close()
+
+		fmt.Println(response.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	// Output:
+	// 200 operation getUser
+	// 400 {"title":"Validation Failed","status":400,"violations":[{"location":"path","field":"id","message":"must be at least 1"}]}
+}
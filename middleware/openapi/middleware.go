@@ -0,0 +1,501 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "openapi"
+
+// Mode represents the [OpenAPI] middleware's response validation mode.
+type Mode int
+
+const (
+	// Disabled doesn't validate responses.
+	Disabled Mode = iota
+
+	// Logged validates responses, logging violations while forwarding responses as is. Suited to debugging, and staging.
+	Logged
+
+	// Enforced validates responses, replacing violating responses with a [http.StatusInternalServerError] response detailing every
+	// [Violation]. Suited to development, and contract testing.
+	Enforced
+)
+
+// Valuer is the context return type relating to the [OpenAPI] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// OperationID represents the matched operation's "operationId". Empty if the operation doesn't declare one.
+	OperationID string `json:"operation-id"`
+
+	// Method represents the matched operation's method.
+	Method string `json:"method"`
+
+	// Path represents the matched operation's path template (e.g. "/users/{id}").
+	Path string `json:"path"`
+
+	// Parameters represents the request's path parameters, keyed by template name.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Options represents the configuration settings for the [OpenAPI] middleware component.
+type Options struct {
+	// Specification represents the loaded OpenAPI document - see [Load]. Requests are forwarded unvalidated if nil. Defaults to nil.
+	Specification *Specification
+
+	// Prefix represents the base path stripped from request paths prior to matching them against the specification's paths (e.g.
+	// "/api/v1"). Requests outside the prefix are forwarded unvalidated. Defaults to an empty string.
+	Prefix string
+
+	// Reject reports whether requests not matching a specification operation receive a [http.StatusNotFound], or
+	// [http.StatusMethodNotAllowed], response - rather than being forwarded unvalidated. Defaults to false.
+	Reject bool
+
+	// Responses represents the response validation [Mode]. Validating responses requires buffering them. Defaults to [Disabled].
+	Responses Mode
+
+	// Limit represents the maximum request body size, in bytes. Larger bodies receive a [http.StatusRequestEntityTooLarge] response.
+	// Defaults to 1 MiB.
+	Limit int64
+}
+
+// OpenAPI represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type OpenAPI struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [OpenAPI] middleware's [Options] and returns the updated middleware instance.
+func (x *OpenAPI) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Responses: Disabled,
+			Limit:     1 << 20,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid OpenAPI Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1 << 20
+	}
+
+	if x.options.Responses < Disabled || x.options.Responses > Enforced {
+		slog.Warn("Invalid OpenAPI Responses Mode Specified - Using Default Mode")
+
+		x.options.Responses = Disabled
+	}
+
+	x.options.Prefix = strings.TrimSuffix(x.options.Prefix, "/")
+
+	return x
+}
+
+// negotiate returns the content's media type matching the content type - exactly, by its "type/*" range, or by "*/*".
+func negotiate(content map[string]*media, contenttype string) (string, *media, bool) {
+	mediatype, _, _ := mime.ParseMediaType(contenttype)
+
+	major, _, _ := strings.Cut(mediatype, "/")
+
+	for _, candidate := range []string{mediatype, major + "/*", "*/*"} {
+		if v, found := content[candidate]; found {
+			return mediatype, v, true
+		}
+	}
+
+	return mediatype, nil, false
+}
+
+// isjson reports whether the media type is JSON.
+func isjson(mediatype string) bool {
+	return mediatype == "application/json" || strings.HasSuffix(mediatype, "+json")
+}
+
+// decode decodes the JSON data, rejecting trailing data.
+func decode(data []byte) (value any, e error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if e = decoder.Decode(&value); e != nil {
+		return nil, fmt.Errorf("invalid json: %w", e)
+	}
+
+	if decoder.More() {
+		return nil, errors.New("invalid json: unexpected data after top-level value")
+	}
+
+	return value, nil
+}
+
+// validate validates the request's parameters against the operation's.
+func (op *operation) validate(r *http.Request, captures map[string]string) (violations []Violation) {
+	query := r.URL.Query()
+
+	for _, p := range op.parameters {
+		var values []string
+
+		switch p.In {
+		case "path":
+			if v, found := captures[p.Name]; found {
+				values = []string{v}
+			}
+		case "query":
+			values = query[p.Name]
+		case "header":
+			values = r.Header.Values(p.Name)
+		case "cookie":
+			if cookie, e := r.Cookie(p.Name); e == nil {
+				values = []string{cookie.Value}
+			}
+		}
+
+		if len(values) == 0 {
+			if p.Required {
+				violations = append(violations, Violation{Location: p.In, Field: p.Name, Message: "is required"})
+			}
+
+			continue
+		}
+
+		explode := p.In == "query" || p.In == "cookie"
+		if p.Explode != nil {
+			explode = *p.Explode
+		}
+
+		violations = append(violations, p.Schema.validate(p.Schema.coerce(values, explode), p.In, p.Name)...)
+	}
+
+	return
+}
+
+// body reads, and validates, the request's body against the operation's request body.
+func (x *OpenAPI) body(w http.ResponseWriter, r *http.Request, op *operation) (raw []byte, violations []Violation, status int, e error) {
+	if r.Body != nil {
+		raw, e = io.ReadAll(http.MaxBytesReader(w, r.Body, x.options.Limit))
+		if e != nil {
+			if v := new(http.MaxBytesError); errors.As(e, &v) {
+				return nil, nil, http.StatusRequestEntityTooLarge, e
+			}
+
+			return nil, nil, http.StatusBadRequest, e
+		}
+	}
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		if op.body.Required {
+			violations = append(violations, Violation{Location: "body", Message: "is required"})
+		}
+
+		return raw, violations, http.StatusOK, nil
+	}
+
+	if len(op.body.Content) == 0 {
+		return raw, nil, http.StatusOK, nil
+	}
+
+	mediatype, media, found := negotiate(op.body.Content, r.Header.Get("Content-Type"))
+	if !(found) {
+		return nil, nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported media type %q", mediatype)
+	}
+
+	if media == nil || media.Schema == nil || !(isjson(mediatype)) {
+		return raw, nil, http.StatusOK, nil
+	}
+
+	value, e := decode(raw)
+	if e != nil {
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	return raw, media.Schema.validate(value, "body", ""), http.StatusOK, nil
+}
+
+// response validates the buffered response against the operation's responses.
+func (op *operation) response(status int, header http.Header, content []byte) (violations []Violation) {
+	if len(op.responses) == 0 {
+		return nil
+	}
+
+	var documented *response
+	for _, candidate := range []string{strconv.Itoa(status), strconv.Itoa(status/100) + "XX", "DEFAULT"} {
+		if v, found := op.responses[candidate]; found {
+			documented = v
+
+			break
+		}
+	}
+
+	if documented == nil {
+		return []Violation{{Location: "response", Field: "status", Message: fmt.Sprintf("status %d is undocumented", status)}}
+	}
+
+	if len(content) == 0 || len(documented.Content) == 0 {
+		return nil
+	}
+
+	mediatype, media, found := negotiate(documented.Content, header.Get("Content-Type"))
+	if !(found) {
+		return []Violation{{Location: "response", Field: "Content-Type", Message: fmt.Sprintf("media type %q is undocumented", mediatype)}}
+	}
+
+	if media == nil || media.Schema == nil || !(isjson(mediatype)) {
+		return nil
+	}
+
+	value, e := decode(content)
+	if e != nil {
+		return []Violation{{Location: "response", Message: e.Error()}}
+	}
+
+	return media.Schema.validate(value, "response", "")
+}
+
+// write writes the JSON response, detailing every [Violation].
+func write(w http.ResponseWriter, status int, title string, violations []Violation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Location != violations[j].Location {
+			return violations[i].Location < violations[j].Location
+		}
+
+		return violations[i].Field < violations[j].Field
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(struct {
+		Title      string      `json:"title"`
+		Status     int         `json:"status"`
+		Violations []Violation `json:"violations,omitempty"`
+	}{Title: title, Status: status, Violations: violations})
+}
+
+// failure records the request's rejection, and writes its JSON response.
+func failure(w http.ResponseWriter, r *http.Request, status int, code, title string, violations []Violation) {
+	reject.Record(r.Context(), reject.Reason{Subsystem: "openapi", Code: code, Status: status})
+
+	write(w, status, title, violations)
+}
+
+type writer struct {
+	http.ResponseWriter
+
+	status   int
+	written  bool // written represents whether the handler wrote the response's header(s).
+	streamed bool // streamed represents whether the handler flushed the response, forwarding it unvalidated.
+	buffer   bytes.Buffer
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written, w.status = true, status
+
+	// Informational responses aren't buffered.
+	if status >= 100 && status < 200 {
+		w.written = false
+
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.streamed {
+		return w.ResponseWriter.Write(p)
+	}
+
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.buffer.Write(p)
+}
+
+// Flush writes the buffered response unvalidated - streamed responses aren't validated - and flushes the underlying
+// [http.ResponseWriter].
+func (w *writer) Flush() {
+	if !(w.streamed) {
+		w.streamed, w.written = true, true
+
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(w.buffer.Bytes())
+
+		w.buffer.Reset()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. A hijacked connection's response isn't validated.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, e := http.NewResponseController(w.ResponseWriter).Hijack()
+	if e == nil {
+		w.streamed = true
+	}
+
+	return conn, rw, e
+}
+
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler matches requests to the [Options.Specification]'s operations, validating their path, query, header, and cookie parameters,
+// and their JSON body. Invalid requests receive a JSON [http.StatusBadRequest] response detailing every [Violation]; valid requests
+// are forwarded with the matched operation stored in the request context - see [Value]. Responses are validated according to
+// [Options.Responses].
+func (x *OpenAPI) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Specification == nil {
+		slog.Warn("OpenAPI Specification Not Specified - Requests Won't Be Validated")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		specification := x.options.Specification
+		if specification == nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		path, found := strings.CutPrefix(r.URL.Path, x.options.Prefix)
+		if !(found) || (path != "" && !(strings.HasPrefix(path, "/"))) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := r.Context()
+
+		op, captures, allowed := specification.match(r.Method, "/"+strings.TrimPrefix(path, "/"))
+		if op == nil {
+			switch {
+			case !(x.options.Reject):
+				next.ServeHTTP(w, r)
+			case len(allowed) > 0:
+				w.Header().Set("Allow", strings.Join(allowed, ", "))
+
+				failure(w, r, http.StatusMethodNotAllowed, "method-not-allowed", http.StatusText(http.StatusMethodNotAllowed), nil)
+			default:
+				failure(w, r, http.StatusNotFound, "operation-not-found", http.StatusText(http.StatusNotFound), nil)
+			}
+
+			return
+		}
+
+		valuer := &Valuer{OperationID: op.id, Method: op.method, Path: op.path, Parameters: captures}
+
+		violations := op.validate(r, captures)
+
+		if op.body != nil {
+			raw, invalid, status, e := x.body(w, r, op)
+			if e != nil {
+				slog.DebugContext(ctx, "Invalid Request Body", slog.String("operation", op.id), slog.String("error", e.Error()), slog.Int("status", status))
+
+				failure(w, r, status, "validation-failed", e.Error(), nil)
+
+				return
+			}
+
+			violations = append(violations, invalid...)
+
+			r.Body = io.NopCloser(bytes.NewReader(raw))
+		}
+
+		if len(violations) > 0 {
+			slog.DebugContext(ctx, "Request Validation Failed", slog.String("operation", op.id), slog.Int("violations", len(violations)))
+
+			failure(w, r, http.StatusBadRequest, "validation-failed", "Validation Failed", violations)
+
+			return
+		}
+
+		r = r.WithContext(context.WithValue(ctx, key, valuer))
+
+		if x.options.Responses == Disabled {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		buffer := &writer{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(buffer, r)
+
+		if buffer.streamed {
+			slog.DebugContext(ctx, "Streamed Response - Skipping Response Validation", slog.String("operation", op.id))
+
+			return
+		}
+
+		if violations := op.response(buffer.status, w.Header(), buffer.buffer.Bytes()); len(violations) > 0 {
+			slog.WarnContext(ctx, "Response Validation Failed", slog.String("operation", op.id), slog.Int("status", buffer.status), slog.Any("violations", violations))
+
+			if x.options.Responses == Enforced {
+				for _, header := range []string{"Content-Length", "Content-Encoding", "ETag", "Last-Modified"} {
+					w.Header().Del(header)
+				}
+
+				write(w, http.StatusInternalServerError, "Response Validation Failed", violations)
+
+				return
+			}
+		}
+
+		w.WriteHeader(buffer.status)
+		w.Write(buffer.buffer.Bytes())
+	})
+}
+
+// New creates a new instance of the [OpenAPI] middleware, implementing [middleware.Configurable]. If [OpenAPI.Settings] isn't called,
+// then the [OpenAPI.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(OpenAPI)
+}
+
+// Value retrieves the request's matched operation from the provided context. If a nil value is returned, it can be assumed that the
+// request didn't match an operation, or that the [OpenAPI] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "OpenAPI Valuer Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [OpenAPI] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*OpenAPI)(nil)
@@ -0,0 +1,378 @@
+package openapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/openapi"
+)
+
+const document = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Users", "version": "1.0.0"},
+	"paths": {
+		"/users": {
+			"get": {
+				"operationId": "listUsers",
+				"parameters": [
+					{"name": "page", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+					{"name": "sort", "in": "query", "schema": {"type": "string", "enum": ["name", "age"]}},
+					{"name": "ids", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "integer"}, "maxItems": 2}},
+					{"$ref": "#/components/parameters/Tenant"}
+				],
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}}}}}
+			},
+			"post": {
+				"operationId": "createUser",
+				"requestBody": {"$ref": "#/components/requestBodies/User"},
+				"responses": {"201": {"description": "Created"}, "4XX": {"description": "Client Error"}}
+			}
+		},
+		"/users/me": {
+			"get": {"operationId": "getCurrentUser", "responses": {"default": {"description": "OK"}}}
+		},
+		"/users/{id}": {
+			"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "exclusiveMinimum": true, "minimum": 0}}],
+			"get": {"operationId": "getUser", "responses": {"200": {"$ref": "#/components/responses/User"}}},
+			"delete": {"operationId": "deleteUser", "responses": {"204": {"description": "Deleted"}}}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["name", "email"],
+				"additionalProperties": false,
+				"properties": {
+					"id": {"type": "integer"},
+					"name": {"type": "string", "minLength": 1, "maxLength": 16},
+					"email": {"type": "string", "pattern": "^[^@\\s]+@[^@\\s]+$"},
+					"age": {"type": "integer", "nullable": true, "minimum": 0},
+					"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+					"address": {"$ref": "#/components/schemas/Address"},
+					"contact": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+				}
+			},
+			"Address": {"type": "object", "required": ["city"], "properties": {"city": {"type": "string"}}}
+		},
+		"parameters": {
+			"Tenant": {"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string", "maxLength": 8}}
+		},
+		"requestBodies": {
+			"User": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+		},
+		"responses": {
+			"User": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+		}
+	}
+}`
+
+func Test(t *testing.T) {
+	specification, e := openapi.Load([]byte(document))
+	if e != nil {
+		t.Fatalf("Unexpected Error While Loading Specification: %v", e)
+	}
+
+	if specification.Title != "Users" || specification.Version != "1.0.0" {
+		t.Errorf("Specification = %s %s\n    - Expectation = %s", specification.Title, specification.Version, "Users 1.0.0")
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := openapi.Value(r.Context()); v != nil {
+			w.Header().Set("X-Operation", v.OperationID)
+			w.Header().Set("X-Path", v.Path)
+			w.Header().Set("X-ID", v.Parameters["id"])
+		}
+
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if len(body) == 0 {
+				t.Errorf("Body = %s\n    - Expectation = %s", body, "the restored request body")
+			}
+
+			w.WriteHeader(http.StatusCreated)
+		}
+	})
+
+	server := httptest.NewServer(openapi.New().Settings(func(o *openapi.Options) {
+		o.Specification = specification
+		o.Prefix = "/api/"
+		o.Limit = 512
+	}).Handler(handler))
+
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		body       string
+		status     int
+		operation  string
+		template   string
+		id         string
+		violations []openapi.Violation
+	}{
+		{name: "Outside-Prefix", method: http.MethodGet, path: "/health", status: http.StatusOK},
+		{name: "Unmatched", method: http.MethodGet, path: "/api/orders", status: http.StatusOK},
+		{name: "Query-Valid", method: http.MethodGet, path: "/api/users?page=2&sort=name&ids=1,2", headers: map[string]string{"X-Tenant": "acme"}, status: http.StatusOK, operation: "listUsers", template: "/users"},
+		{
+			name:   "Query-Invalid",
+			method: http.MethodGet,
+			path:   "/api/users?page=0&sort=email&ids=1,a,3",
+			status: http.StatusBadRequest,
+			violations: []openapi.Violation{
+				{Location: "header", Field: "X-Tenant", Message: "is required"},
+				{Location: "query", Field: "ids", Message: "must be at most 2 items"},
+				{Location: "query", Field: "ids[1]", Message: "must be an integer"},
+				{Location: "query", Field: "page", Message: "must be at least 1"},
+				{Location: "query", Field: "sort", Message: "must be one of [name, age]"},
+			},
+		},
+		{name: "Path-Valid", method: http.MethodGet, path: "/api/users/42", status: http.StatusOK, operation: "getUser", template: "/users/{id}", id: "42"},
+		{name: "Path-Concrete", method: http.MethodGet, path: "/api/users/me", status: http.StatusOK, operation: "getCurrentUser", template: "/users/me"},
+		{name: "Path-Invalid", method: http.MethodDelete, path: "/api/users/0", status: http.StatusBadRequest, violations: []openapi.Violation{{Location: "path", Field: "id", Message: "must be greater than 0"}}},
+		{name: "Path-Type", method: http.MethodGet, path: "/api/users/abc", status: http.StatusBadRequest, violations: []openapi.Violation{{Location: "path", Field: "id", Message: "must be an integer"}}},
+		{
+			name:      "Body-Valid",
+			method:    http.MethodPost,
+			path:      "/api/users",
+			headers:   map[string]string{"Content-Type": "application/json"},
+			body:      `{"name": "Jane", "email": "jane@example.com", "age": null, "tags": ["a"], "address": {"city": "Oslo"}, "contact": 5}`,
+			status:    http.StatusCreated,
+			operation: "createUser",
+			template:  "/users",
+		},
+		{
+			name:    "Body-Invalid",
+			method:  http.MethodPost,
+			path:    "/api/users",
+			headers: map[string]string{"Content-Type": "application/json"},
+			body:    `{"name": "", "email": "jane", "age": 1.5, "tags": ["a", 2, "c"], "address": {}, "contact": true, "admin": true}`,
+			status:  http.StatusBadRequest,
+			violations: []openapi.Violation{
+				{Location: "body", Field: "address.city", Message: "is required"},
+				{Location: "body", Field: "admin", Message: "is not allowed"},
+				{Location: "body", Field: "age", Message: "must be an integer"},
+				{Location: "body", Field: "contact", Message: "must match exactly one schema"},
+				{Location: "body", Field: "email", Message: `must match the pattern "^[^@\\s]+@[^@\\s]+$"`},
+				{Location: "body", Field: "name", Message: "must be at least 1 characters"},
+				{Location: "body", Field: "tags", Message: "must be at most 2 items"},
+				{Location: "body", Field: "tags[1]", Message: "must be a string"},
+			},
+		},
+		{name: "Body-Root", method: http.MethodPost, path: "/api/users", headers: map[string]string{"Content-Type": "application/json"}, body: `[]`, status: http.StatusBadRequest, violations: []openapi.Violation{{Location: "body", Message: "must be an object"}}},
+		{name: "Body-Missing", method: http.MethodPost, path: "/api/users", headers: map[string]string{"Content-Type": "application/json"}, status: http.StatusBadRequest, violations: []openapi.Violation{{Location: "body", Message: "is required"}}},
+		{name: "Body-Malformed", method: http.MethodPost, path: "/api/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{"name": `, status: http.StatusBadRequest},
+		{name: "Body-Media-Type", method: http.MethodPost, path: "/api/users", headers: map[string]string{"Content-Type": "text/plain"}, body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "Body-Too-Large", method: http.MethodPost, path: "/api/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{"name": "` + strings.Repeat("x", 1024) + `"}`, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, e := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, tt.status)
+			}
+
+			if v := response.Header.Get("X-Operation"); v != tt.operation {
+				t.Errorf("X-Operation = %s\n    - Expectation = %s", v, tt.operation)
+			}
+
+			if v := response.Header.Get("X-Path"); v != tt.template {
+				t.Errorf("X-Path = %s\n    - Expectation = %s", v, tt.template)
+			}
+
+			if v := response.Header.Get("X-ID"); v != tt.id {
+				t.Errorf("X-ID = %s\n    - Expectation = %s", v, tt.id)
+			}
+
+			if tt.violations == nil {
+				return
+			}
+
+			var body struct {
+				Violations []openapi.Violation `json:"violations"`
+			}
+
+			if e := json.NewDecoder(response.Body).Decode(&body); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+			}
+
+			if len(body.Violations) != len(tt.violations) {
+				t.Fatalf("Violations = %+v\n    - Expectation = %+v", body.Violations, tt.violations)
+			}
+
+			for index := range tt.violations {
+				if body.Violations[index] != tt.violations[index] {
+					t.Errorf("Violation = %+v\n    - Expectation = %+v", body.Violations[index], tt.violations[index])
+				}
+			}
+		})
+	}
+
+	t.Run("Reject", func(t *testing.T) {
+		server := httptest.NewServer(openapi.New().Settings(func(o *openapi.Options) {
+			o.Specification = specification
+			o.Reject = true
+		}).Handler(handler))
+
+		defer server.Close()
+
+		matrix := []struct {
+			method string
+			path   string
+			status int
+			allow  string
+		}{
+			{method: http.MethodGet, path: "/orders", status: http.StatusNotFound},
+			{method: http.MethodPut, path: "/users/42", status: http.StatusMethodNotAllowed, allow: "DELETE, GET"},
+			{method: http.MethodGet, path: "/users/42", status: http.StatusOK},
+		}
+
+		for _, matrix := range matrix {
+			request, e := http.NewRequest(matrix.method, server.URL+matrix.path, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			response.Body.Close()
+
+			if response.StatusCode != matrix.status {
+				t.Errorf("%s %s Status = %d\n    - Expectation = %d", matrix.method, matrix.path, response.StatusCode, matrix.status)
+			}
+
+			if v := response.Header.Get("Allow"); v != matrix.allow {
+				t.Errorf("Allow = %s\n    - Expectation = %s", v, matrix.allow)
+			}
+		}
+	})
+
+	t.Run("Responses", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/users/1":
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": 1, "name": "Jane", "email": "jane@example.com"}`))
+			case "/users/2":
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "2", "name": "Jane"}`))
+			case "/users/3":
+				w.WriteHeader(http.StatusTeapot)
+			}
+		})
+
+		matrix := []struct {
+			name       string
+			mode       openapi.Mode
+			path       string
+			status     int
+			violations []openapi.Violation
+		}{
+			{name: "Enforced-Valid", mode: openapi.Enforced, path: "/users/1", status: http.StatusOK},
+			{
+				name:   "Enforced-Invalid",
+				mode:   openapi.Enforced,
+				path:   "/users/2",
+				status: http.StatusInternalServerError,
+				violations: []openapi.Violation{
+					{Location: "response", Field: "email", Message: "is required"},
+					{Location: "response", Field: "id", Message: "must be an integer"},
+				},
+			},
+			{name: "Enforced-Undocumented", mode: openapi.Enforced, path: "/users/3", status: http.StatusInternalServerError, violations: []openapi.Violation{{Location: "response", Field: "status", Message: "status 418 is undocumented"}}},
+			{name: "Logged", mode: openapi.Logged, path: "/users/2", status: http.StatusOK},
+			{name: "Disabled", mode: openapi.Disabled, path: "/users/3", status: http.StatusTeapot},
+		}
+
+		for _, matrix := range matrix {
+			t.Run(matrix.name, func(t *testing.T) {
+				server := httptest.NewServer(openapi.New().Settings(func(o *openapi.Options) {
+					o.Specification = specification
+					o.Responses = matrix.mode
+				}).Handler(handler))
+
+				defer server.Close()
+
+				response, e := server.Client().Get(server.URL + matrix.path)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Response: %v", e)
+				}
+
+				defer response.Body.Close()
+
+				if response.StatusCode != matrix.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, matrix.status)
+				}
+
+				var body struct {
+					Violations []openapi.Violation `json:"violations"`
+				}
+
+				json.NewDecoder(response.Body).Decode(&body)
+
+				if len(body.Violations) != len(matrix.violations) {
+					t.Fatalf("Violations = %+v\n    - Expectation = %+v", body.Violations, matrix.violations)
+				}
+
+				for index := range matrix.violations {
+					if body.Violations[index] != matrix.violations[index] {
+						t.Errorf("Violation = %+v\n    - Expectation = %+v", body.Violations[index], matrix.violations[index])
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		matrix := []struct {
+			name     string
+			document string
+		}{
+			{name: "Malformed", document: `{"openapi": `},
+			{name: "Version", document: `{"swagger": "2.0", "paths": {}}`},
+			{name: "Reference", document: `{"openapi": "3.1.0", "paths": {"/users": {"get": {"responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Missing"}}}}}}}}}`},
+			{name: "External-Reference", document: `{"openapi": "3.1.0", "paths": {"/users": {"get": {"parameters": [{"$ref": "common.json#/Tenant"}]}}}}`},
+			{name: "Pattern", document: `{"openapi": "3.1.0", "components": {"schemas": {"Name": {"type": "string", "pattern": "("}}}}`},
+			{name: "Location", document: `{"openapi": "3.1.0", "paths": {"/users": {"get": {"parameters": [{"name": "id", "in": "body"}]}}}}`},
+		}
+
+		for _, matrix := range matrix {
+			if _, e := openapi.Load([]byte(matrix.document)); e == nil {
+				t.Errorf("%s Error = %v\n    - Expectation = %s", matrix.name, e, "a non-nil error")
+			}
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := openapi.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,368 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// types represents a schema's "type" - a single type (OpenAPI 3.0), or a list of types (OpenAPI 3.1).
+type types []string
+
+func (t *types) UnmarshalJSON(data []byte) error {
+	var single string
+	if e := json.Unmarshal(data, &single); e == nil {
+		*t = types{single}
+
+		return nil
+	}
+
+	var multiple []string
+	if e := json.Unmarshal(data, &multiple); e != nil {
+		return fmt.Errorf("openapi: invalid schema type %s", data)
+	}
+
+	*t = multiple
+
+	return nil
+}
+
+// includes reports whether the types include the named type.
+func (t types) includes(name string) bool {
+	for _, v := range t {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// primary returns the first non-null type, if any.
+func (t types) primary() string {
+	for _, v := range t {
+		if v != "null" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// matches reports whether the decoded JSON value is of one of the types.
+func (t types) matches(value any) bool {
+	for _, name := range t {
+		switch v := value.(type) {
+		case string:
+			if name == "string" {
+				return true
+			}
+		case json.Number:
+			if name == "number" || (name == "integer" && integral(v)) {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case []any:
+			if name == "array" {
+				return true
+			}
+		case map[string]any:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// describe returns the types' human-readable description (e.g. "an integer", "a string or an array").
+func (t types) describe() string {
+	articles := map[string]string{"integer": "an integer", "array": "an array", "object": "an object"}
+
+	descriptions := make([]string, 0, len(t))
+	for _, name := range t {
+		if name == "null" {
+			continue
+		}
+
+		description, found := articles[name]
+		if !(found) {
+			description = "a " + name
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	return strings.Join(descriptions, " or ")
+}
+
+// integral reports whether the number is an integer.
+func integral(number json.Number) bool {
+	if _, e := number.Int64(); e == nil {
+		return true
+	}
+
+	n, e := number.Float64()
+
+	return e == nil && !(math.IsInf(n, 0)) && n == math.Trunc(n)
+}
+
+// schema represents the supported subset of an OpenAPI schema object.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 types              `json:"type"`
+	Nullable             bool               `json:"nullable"`
+	Enum                 []any              `json:"enum"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	ExclusiveMinimum     json.RawMessage    `json:"exclusiveMinimum"`
+	ExclusiveMaximum     json.RawMessage    `json:"exclusiveMaximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	Pattern              string             `json:"pattern"`
+	AllOf                []*schema          `json:"allOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+	OneOf                []*schema          `json:"oneOf"`
+
+	target           *schema        // target represents the schema's resolved "$ref".
+	pattern          *regexp.Regexp // pattern represents the compiled "pattern".
+	additional       *schema        // additional represents the "additionalProperties" schema, if any.
+	closed           bool           // closed represents whether "additionalProperties" is false.
+	exclusiveMinimum bool
+	exclusiveMaximum bool
+}
+
+// resolved follows the schema's references.
+func (s *schema) resolved() *schema {
+	for s != nil && s.target != nil {
+		s = s.target
+	}
+
+	return s
+}
+
+// join returns the field's child path.
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+
+	return field + "." + name
+}
+
+// enumerated reports whether the value equals one of the enumeration's values.
+func enumerated(enumeration []any, value any) bool {
+	for _, candidate := range enumeration {
+		if x, ok := candidate.(json.Number); ok {
+			if y, ok := value.(json.Number); ok {
+				a, _ := x.Float64()
+				b, _ := y.Float64()
+				if a == b {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate returns the decoded JSON value's - decoded with [json.Decoder.UseNumber] - violations of the schema.
+func (s *schema) validate(value any, location, field string) (violations []Violation) {
+	s = s.resolved()
+	if s == nil {
+		return nil
+	}
+
+	violation := func(format string, arguments ...any) {
+		violations = append(violations, Violation{Location: location, Field: field, Message: fmt.Sprintf(format, arguments...)})
+	}
+
+	if value == nil {
+		if !(s.Nullable || s.Type.includes("null") || len(s.Type) == 0) {
+			violation("must not be null")
+		}
+
+		return
+	}
+
+	if len(s.Type) > 0 && !(s.Type.matches(value)) {
+		violation("must be %s", s.Type.describe())
+
+		return
+	}
+
+	if len(s.Enum) > 0 && !(enumerated(s.Enum, value)) {
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, fmt.Sprint(v))
+		}
+
+		violation("must be one of [%s]", strings.Join(values, ", "))
+	}
+
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			violation("must be at least %d characters", *s.MinLength)
+		}
+
+		if s.MaxLength != nil && length > *s.MaxLength {
+			violation("must be at most %d characters", *s.MaxLength)
+		}
+
+		if s.pattern != nil && !(s.pattern.MatchString(v)) {
+			violation("must match the pattern %q", s.Pattern)
+		}
+	case json.Number:
+		n, _ := v.Float64()
+
+		switch {
+		case s.Minimum != nil && s.exclusiveMinimum && n <= *s.Minimum:
+			violation("must be greater than %s", format(*s.Minimum))
+		case s.Minimum != nil && n < *s.Minimum:
+			violation("must be at least %s", format(*s.Minimum))
+		}
+
+		switch {
+		case s.Maximum != nil && s.exclusiveMaximum && n >= *s.Maximum:
+			violation("must be less than %s", format(*s.Maximum))
+		case s.Maximum != nil && n > *s.Maximum:
+			violation("must be at most %s", format(*s.Maximum))
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			violation("must be at least %d items", *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			violation("must be at most %d items", *s.MaxItems)
+		}
+
+		if s.Items != nil {
+			for index, item := range v {
+				violations = append(violations, s.Items.validate(item, location, fmt.Sprintf("%s[%d]", field, index))...)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, found := v[name]; !(found) {
+				violations = append(violations, Violation{Location: location, Field: join(field, name), Message: "is required"})
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			switch property, found := s.Properties[name]; {
+			case found:
+				violations = append(violations, property.validate(v[name], location, join(field, name))...)
+			case s.closed:
+				violations = append(violations, Violation{Location: location, Field: join(field, name), Message: "is not allowed"})
+			case s.additional != nil:
+				violations = append(violations, s.additional.validate(v[name], location, join(field, name))...)
+			}
+		}
+	}
+
+	for _, composite := range s.AllOf {
+		violations = append(violations, composite.validate(value, location, field)...)
+	}
+
+	if len(s.AnyOf) > 0 && s.matching(s.AnyOf, value) == 0 {
+		violation("must match at least one schema")
+	}
+
+	if len(s.OneOf) > 0 && s.matching(s.OneOf, value) != 1 {
+		violation("must match exactly one schema")
+	}
+
+	return
+}
+
+// matching returns the number of schemas the value satisfies.
+func (s *schema) matching(schemas []*schema, value any) (count int) {
+	for _, candidate := range schemas {
+		if len(candidate.validate(value, "", "")) == 0 {
+			count++
+		}
+	}
+
+	return
+}
+
+// coerce converts a parameter's raw string values into the schema's type - numbers as [json.Number], and booleans as bool - such
+// that they're validated as their decoded JSON equivalent. Values not conforming to the type are returned as strings.
+func (s *schema) coerce(values []string, explode bool) any {
+	s = s.resolved()
+
+	scalar := func(s *schema, value string) any {
+		switch s.resolved().Type.primary() {
+		case "integer", "number":
+			if n, e := strconv.ParseFloat(value, 64); e == nil && !(math.IsNaN(n) || math.IsInf(n, 0)) {
+				return json.Number(value)
+			}
+		case "boolean":
+			if value == "true" || value == "false" {
+				return value == "true"
+			}
+		}
+
+		return value
+	}
+
+	if s == nil {
+		return values[0]
+	}
+
+	if s.Type.primary() != "array" {
+		return scalar(s, values[0])
+	}
+
+	if !(explode) && len(values) == 1 {
+		values = strings.Split(values[0], ",")
+	}
+
+	items := make([]any, 0, len(values))
+	for _, value := range values {
+		if s.Items == nil {
+			items = append(items, value)
+		} else {
+			items = append(items, scalar(s.Items, value))
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,445 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Specification represents a loaded OpenAPI 3 document - see [Load]. A Specification is immutable, and safe for concurrent use.
+type Specification struct {
+	// Title represents the document's "info.title".
+	Title string
+
+	// Version represents the document's "info.version".
+	Version string
+
+	operations []*operation // operations represents the document's operations, ordered by matching precedence.
+}
+
+// Violation represents a value's failed schema constraint.
+type Violation struct {
+	// Location represents the value's location: "path", "query", "header", "cookie", "body", or "response".
+	Location string `json:"location"`
+
+	// Field represents the parameter's name, or the body field's path (e.g. "address.city", "tags[1]"). Empty for the body itself.
+	Field string `json:"field"`
+
+	// Message represents the constraint's failure.
+	Message string `json:"message"`
+}
+
+// operation represents a path's method, as resolved from the document.
+type operation struct {
+	id         string
+	method     string
+	path       string
+	segments   []string
+	parameters []*parameter
+	body       *body
+	responses  map[string]*response
+}
+
+type parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Explode  *bool   `json:"explode"`
+	Schema   *schema `json:"schema"`
+}
+
+type media struct {
+	Schema *schema `json:"schema"`
+}
+
+type body struct {
+	Ref      string            `json:"$ref"`
+	Required bool              `json:"required"`
+	Content  map[string]*media `json:"content"`
+}
+
+type response struct {
+	Ref     string            `json:"$ref"`
+	Content map[string]*media `json:"content"`
+}
+
+type components struct {
+	Schemas       map[string]*schema    `json:"schemas"`
+	Parameters    map[string]*parameter `json:"parameters"`
+	RequestBodies map[string]*body      `json:"requestBodies"`
+	Responses     map[string]*response  `json:"responses"`
+}
+
+type document struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components components                            `json:"components"`
+}
+
+type definition struct {
+	OperationID string               `json:"operationId"`
+	Parameters  []*parameter         `json:"parameters"`
+	RequestBody *body                `json:"requestBody"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+// methods represents a path item's operation fields.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// unmarshal decodes the JSON data, decoding numbers as [json.Number].
+func unmarshal(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(v)
+}
+
+// reference returns the component's name from a local reference (e.g. "#/components/schemas/User").
+func reference(ref, kind string) (string, error) {
+	name, found := strings.CutPrefix(ref, "#/components/"+kind+"/")
+	if !(found) || name == "" {
+		return "", fmt.Errorf("openapi: unsupported reference %q", ref)
+	}
+
+	return name, nil
+}
+
+// resolver resolves a document's references, and compiles its schemas.
+type resolver struct {
+	components components
+	visited    map[*schema]bool
+}
+
+func (r *resolver) schema(s *schema) error {
+	if s == nil || r.visited[s] {
+		return nil
+	}
+
+	r.visited[s] = true
+
+	if s.Ref != "" {
+		name, e := reference(s.Ref, "schemas")
+		if e != nil {
+			return e
+		}
+
+		target, found := r.components.Schemas[name]
+		if !(found) {
+			return fmt.Errorf("openapi: unresolved reference %q", s.Ref)
+		}
+
+		s.target = target
+
+		return r.schema(target)
+	}
+
+	if e := s.compile(); e != nil {
+		return e
+	}
+
+	children := []*schema{s.Items, s.additional}
+	for _, composition := range [][]*schema{s.AllOf, s.AnyOf, s.OneOf} {
+		children = append(children, composition...)
+	}
+
+	for _, property := range s.Properties {
+		children = append(children, property)
+	}
+
+	for _, child := range children {
+		if e := r.schema(child); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) parameter(p *parameter) (*parameter, error) {
+	if p.Ref != "" {
+		name, e := reference(p.Ref, "parameters")
+		if e != nil {
+			return nil, e
+		}
+
+		target, found := r.components.Parameters[name]
+		if !(found) || target.Ref != "" {
+			return nil, fmt.Errorf("openapi: unresolved reference %q", p.Ref)
+		}
+
+		p = target
+	}
+
+	switch p.In {
+	case "path":
+		p.Required = true
+	case "query", "header", "cookie":
+	default:
+		return nil, fmt.Errorf("openapi: parameter %q has an invalid location %q", p.Name, p.In)
+	}
+
+	return p, r.schema(p.Schema)
+}
+
+func (r *resolver) content(content map[string]*media) error {
+	for _, media := range content {
+		if media != nil {
+			if e := r.schema(media.Schema); e != nil {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) body(b *body) (*body, error) {
+	if b.Ref != "" {
+		name, e := reference(b.Ref, "requestBodies")
+		if e != nil {
+			return nil, e
+		}
+
+		target, found := r.components.RequestBodies[name]
+		if !(found) || target.Ref != "" {
+			return nil, fmt.Errorf("openapi: unresolved reference %q", b.Ref)
+		}
+
+		b = target
+	}
+
+	return b, r.content(b.Content)
+}
+
+func (r *resolver) response(v *response) (*response, error) {
+	if v.Ref != "" {
+		name, e := reference(v.Ref, "responses")
+		if e != nil {
+			return nil, e
+		}
+
+		target, found := r.components.Responses[name]
+		if !(found) || target.Ref != "" {
+			return nil, fmt.Errorf("openapi: unresolved reference %q", v.Ref)
+		}
+
+		v = target
+	}
+
+	return v, r.content(v.Content)
+}
+
+// segments splits the path into its segments.
+func segments(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
+
+// template reports whether the path segment is a template expression (e.g. "{id}").
+func template(segment string) bool {
+	return len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// precedes reports whether operation a's path takes matching precedence over b's: concrete segments precede templated ones.
+func precedes(a, b *operation) bool {
+	for index := 0; index < len(a.segments) && index < len(b.segments); index++ {
+		if x, y := template(a.segments[index]), template(b.segments[index]); x != y {
+			return !(x)
+		}
+	}
+
+	if a.path != b.path {
+		return a.path < b.path
+	}
+
+	return a.method < b.method
+}
+
+// Load parses a JSON-encoded OpenAPI 3 document, resolving its local "#/components/..." references. YAML documents must be
+// converted to JSON beforehand.
+func Load(data []byte) (*Specification, error) {
+	var d document
+	if e := unmarshal(data, &d); e != nil {
+		return nil, fmt.Errorf("openapi: invalid specification: %w", e)
+	}
+
+	if !(strings.HasPrefix(d.OpenAPI, "3.")) {
+		return nil, fmt.Errorf("openapi: unsupported specification version %q", d.OpenAPI)
+	}
+
+	r := &resolver{components: d.Components, visited: make(map[*schema]bool)}
+
+	for _, s := range d.Components.Schemas {
+		if e := r.schema(s); e != nil {
+			return nil, e
+		}
+	}
+
+	specification := &Specification{Title: d.Info.Title, Version: d.Info.Version}
+
+	for path, item := range d.Paths {
+		var shared []*parameter
+		if raw, found := item["parameters"]; found {
+			if e := unmarshal(raw, &shared); e != nil {
+				return nil, fmt.Errorf("openapi: invalid parameters for path %q: %w", path, e)
+			}
+		}
+
+		for _, method := range methods {
+			raw, found := item[method]
+			if !(found) {
+				continue
+			}
+
+			var def definition
+			if e := unmarshal(raw, &def); e != nil {
+				return nil, fmt.Errorf("openapi: invalid operation %s %s: %w", strings.ToUpper(method), path, e)
+			}
+
+			op := &operation{id: def.OperationID, method: strings.ToUpper(method), path: path, segments: segments(path), responses: make(map[string]*response)}
+
+			// Operation-level parameters override path-level parameters of the same name, and location.
+			overridden := make(map[string]bool)
+			for _, p := range append(def.Parameters, shared...) {
+				resolved, e := r.parameter(p)
+				if e != nil {
+					return nil, e
+				}
+
+				if identity := resolved.In + ":" + resolved.Name; !(overridden[identity]) {
+					overridden[identity] = true
+
+					op.parameters = append(op.parameters, resolved)
+				}
+			}
+
+			if def.RequestBody != nil {
+				resolved, e := r.body(def.RequestBody)
+				if e != nil {
+					return nil, e
+				}
+
+				op.body = resolved
+			}
+
+			for status, v := range def.Responses {
+				resolved, e := r.response(v)
+				if e != nil {
+					return nil, e
+				}
+
+				op.responses[strings.ToUpper(status)] = resolved
+			}
+
+			specification.operations = append(specification.operations, op)
+		}
+	}
+
+	sort.Slice(specification.operations, func(i, j int) bool {
+		return precedes(specification.operations[i], specification.operations[j])
+	})
+
+	return specification, nil
+}
+
+// match returns the operation matching the method, and path, alongside its path parameters. If no operation matches, allowed
+// represents the methods of the path's operations, if any.
+func (s *Specification) match(method, path string) (matched *operation, parameters map[string]string, allowed []string) {
+	requested := segments(path)
+
+	var template string
+
+	for _, op := range s.operations {
+		if template != "" && op.path != template {
+			continue
+		}
+
+		captures, ok := op.capture(requested)
+		if !(ok) {
+			continue
+		}
+
+		template = op.path
+
+		if op.method == method {
+			return op, captures, nil
+		}
+
+		allowed = append(allowed, op.method)
+	}
+
+	return nil, nil, allowed
+}
+
+// capture matches the requested path's segments against the operation's, returning the path's template values.
+func (op *operation) capture(requested []string) (map[string]string, bool) {
+	if len(requested) != len(op.segments) {
+		return nil, false
+	}
+
+	captures := make(map[string]string)
+
+	for index, segment := range op.segments {
+		switch {
+		case template(segment):
+			if requested[index] == "" {
+				return nil, false
+			}
+
+			captures[segment[1:len(segment)-1]] = requested[index]
+		case segment != requested[index]:
+			return nil, false
+		}
+	}
+
+	return captures, true
+}
+
+// compile resolves the schema's "pattern", "additionalProperties", and exclusive bounds.
+func (s *schema) compile() (e error) {
+	if s.Pattern != "" {
+		if s.pattern, e = regexp.Compile(s.Pattern); e != nil {
+			return fmt.Errorf("openapi: invalid pattern %q: %w", s.Pattern, e)
+		}
+	}
+
+	switch raw := bytes.TrimSpace(s.AdditionalProperties); {
+	case len(raw) == 0, string(raw) == "true":
+	case string(raw) == "false":
+		s.closed = true
+	default:
+		s.additional = new(schema)
+		if e = unmarshal(raw, s.additional); e != nil {
+			return fmt.Errorf("openapi: invalid additionalProperties: %w", e)
+		}
+	}
+
+	// OpenAPI 3.0 defines exclusive bounds as booleans, OpenAPI 3.1 as numbers.
+	for _, bound := range []struct {
+		raw       json.RawMessage
+		value     **float64
+		exclusive *bool
+	}{{s.ExclusiveMinimum, &s.Minimum, &s.exclusiveMinimum}, {s.ExclusiveMaximum, &s.Maximum, &s.exclusiveMaximum}} {
+		switch raw := bytes.TrimSpace(bound.raw); {
+		case len(raw) == 0, string(raw) == "false":
+		case string(raw) == "true":
+			*bound.exclusive = true
+		default:
+			var v float64
+			if e = json.Unmarshal(raw, &v); e != nil {
+				return fmt.Errorf("openapi: invalid exclusive bound: %w", e)
+			}
+
+			*bound.value, *bound.exclusive = &v, true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+// Package override provides an operator escape hatch for targeted troubleshooting: a signed, internal-only request header that
+// adjusts selected per-request middleware options - raising the request's timeout, forcing its sampling decision, or bypassing
+// caches.
+//
+// Override header values are issued via [Sign], and are only honored when:
+//
+//   - The request originates from a trusted (by default, loopback or private-network) client.
+//   - The HMAC-SHA256 signature verifies against [Options.Secret].
+//   - The override hasn't expired, and its expiry is within [Options.Lifetime].
+//   - Every adjusted setting is [Options.Allowed], and bounded by [Options.Limit].
+//
+// Every override - applied or rejected - is recorded in an audit trail: a log record including the operator's subject and reason,
+// and optionally the [Options.Audit] hook. Rejected overrides are otherwise ignored, and the header is always removed from the
+// request.
+package override
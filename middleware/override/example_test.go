@@ -0,0 +1,64 @@
+package override_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/override"
+)
+
+func Example() {
+	secret := []byte("secret")
+
+	middleware := middleware.New()
+
+	middleware.Add(override.New().Settings(func(o *override.Options) {
+		o.Secret = secret
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		if value := override.Value(r.Context()); value != nil {
+			fmt.Printf("Subject: %s, Reason: %s, Timeout: %s\n", value.Subject, value.Reason, value.Timeout)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	value, e := override.Sign(secret, &override.Override{Timeout: time.Minute * 2, Subject: "operator", Reason: "INC-1234", Expires: time.Now().Add(time.Minute * 5)})
+	if e != nil {
+		e = fmt.Errorf("unexpected error while signing override: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-Middleware-Override", value)
+
+	response, e := server.Client().Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Subject: operator, Reason: INC-1234, Timeout: 2m0s
+}
@@ -0,0 +1,15 @@
+module github.com/poly-gun/go-middleware/middleware/override
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace github.com/poly-gun/go-middleware/middleware/sampling => ../sampling
+
+replace github.com/poly-gun/go-middleware/middleware/telemetrics => ../telemetrics
+
+require github.com/poly-gun/go-middleware/middleware/sampling v0.0.0
+
+require github.com/poly-gun/go-middleware/middleware/telemetrics v0.0.8 // indirect
@@ -0,0 +1,277 @@
+package override
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/sampling"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "override"
+
+// Options represents the configuration settings for the [Middleware] middleware component.
+type Options struct {
+	// Secret represents the HMAC-SHA256 key authenticating override header values - see [Sign]. A nil or empty value disables
+	// the middleware's behavior. Defaults to nil.
+	Secret []byte
+
+	// Header represents the request header conveying the signed override. The header is always removed from the request prior to
+	// the next handler. Defaults to "X-Middleware-Override".
+	Header string
+
+	// Trusted reports whether the request originates from an internal client permitted to convey overrides. Defaults to a
+	// function trusting loopback and private-network remote addresses.
+	Trusted func(r *http.Request) bool
+
+	// Allowed represents the overridable settings. Overrides adjusting any other setting are rejected. Defaults to
+	// [SettingTimeout], [SettingSample], and [SettingBypass].
+	Allowed []Setting
+
+	// Limit represents the maximum overridden timeout. Defaults to 10 minutes.
+	Limit time.Duration
+
+	// Lifetime represents the maximum duration until an override's expiry, bounding the validity of leaked header values.
+	// Defaults to 15 minutes.
+	Lifetime time.Duration
+
+	// Level specifies the log level of the audit trail's records. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Audit optionally receives every override - with a nil error when applied, or the reason for its rejection - for an external
+	// audit trail, in addition to the log record. Defaults to nil.
+	Audit func(r *http.Request, override *Override, e error)
+}
+
+// Middleware represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Middleware struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// internal reports whether the request's remote address is a loopback or private-network address.
+func internal(r *http.Request) bool {
+	host, _, e := net.SplitHostPort(r.RemoteAddr)
+	if e != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
+
+// Settings applies configuration functions to modify the [Middleware] middleware's [Options] and returns the updated middleware instance.
+func (x *Middleware) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Secret:   nil,
+			Header:   "X-Middleware-Override",
+			Trusted:  internal,
+			Allowed:  []Setting{SettingTimeout, SettingSample, SettingBypass},
+			Limit:    time.Minute * 10,
+			Lifetime: time.Minute * 15,
+			Level:    slog.LevelWarn,
+			Audit:    nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Header == "" {
+		slog.Warn("Invalid Override Header Specified - Using Default Header")
+
+		x.options.Header = "X-Middleware-Override"
+	}
+
+	if x.options.Trusted == nil {
+		x.options.Trusted = internal
+	}
+
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Override Limit Specified - Using Default Limit")
+
+		x.options.Limit = time.Minute * 10
+	}
+
+	if x.options.Lifetime <= 0 {
+		slog.Warn("Invalid Override Lifetime Specified - Using Default Lifetime")
+
+		x.options.Lifetime = time.Minute * 15
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	return x
+}
+
+// evaluate verifies the request's override against the configured policy.
+func (x *Middleware) evaluate(r *http.Request, value string) (*Override, error) {
+	if !(x.options.Trusted(r)) {
+		return nil, ErrUntrusted
+	}
+
+	override, e := verify(x.options.Secret, value)
+	if e != nil {
+		return nil, e
+	}
+
+	if now := time.Now(); !(override.Expires.After(now)) || override.Expires.Sub(now) > x.options.Lifetime {
+		return override, ErrExpired
+	}
+
+	for _, setting := range override.Settings() {
+		if !(slices.Contains(x.options.Allowed, setting)) {
+			return override, fmt.Errorf("%w: %s", ErrSetting, setting)
+		}
+	}
+
+	if override.Timeout > x.options.Limit {
+		return override, fmt.Errorf("%w: timeout exceeds %s", ErrSetting, x.options.Limit)
+	}
+
+	return override, nil
+}
+
+// audit records the override's outcome.
+func (x *Middleware) audit(r *http.Request, override *Override, e error) {
+	attributes := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("remote-address", r.RemoteAddr),
+	}
+
+	if override != nil {
+		settings := make([]string, 0, 3)
+		for _, setting := range override.Settings() {
+			settings = append(settings, string(setting))
+		}
+
+		attributes = append(attributes, slog.String("subject", override.Subject), slog.String("reason", override.Reason), slog.Any("settings", settings), slog.Time("expires", override.Expires))
+	}
+
+	if e != nil {
+		attributes = append(attributes, slog.String("error", e.Error()))
+
+		slog.LogAttrs(r.Context(), x.options.Level.Level(), "Middleware Override Rejected", attributes...)
+	} else {
+		slog.LogAttrs(r.Context(), x.options.Level.Level(), "Middleware Override Applied", attributes...)
+	}
+
+	if x.options.Audit != nil {
+		x.options.Audit(r, override, e)
+	}
+}
+
+// Handler verifies, audits, and applies the request's signed override header. Overrides are only accepted from trusted clients,
+// with a valid, unexpired signature, adjusting allowed settings; rejected overrides are audited, then ignored - the request is
+// served as if the header were absent. An applied override:
+//
+//   - Is stored in the request context - see [Value] and [Timeout].
+//   - Forces the sampling middleware's decision (if chained before the [Middleware]) for [SettingSample].
+//   - Adds "Cache-Control: no-cache" to the request for [SettingBypass], such that downstream caches revalidate.
+func (x *Middleware) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if len(x.options.Secret) == 0 {
+		slog.Warn("Override Secret Unspecified - Overrides Disabled")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(x.options.Header)
+		if value == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		// The override must never reach upstream services, nor application code.
+		r = r.Clone(r.Context())
+		r.Header.Del(x.options.Header)
+
+		if len(x.options.Secret) == 0 {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		override, e := x.evaluate(r, value)
+
+		x.audit(r, override, e)
+
+		if e != nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), key, override)
+
+		if override.Sample {
+			if decision := sampling.Value(ctx); decision != nil {
+				decision.Force()
+			}
+		}
+
+		if override.Bypass {
+			r.Header.Set("Cache-Control", "no-cache")
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Middleware] middleware, implementing [middleware.Configurable]. If [Middleware.Settings]
+// isn't called, then the [Middleware.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Middleware)
+}
+
+// Timeout returns the request's overridden timeout, or zero if the request carries no applied [SettingTimeout] override. It's
+// suitable as the timeout middleware's Override option, provided the [Middleware] precedes the timeout middleware in the chain.
+func Timeout(r *http.Request) time.Duration {
+	if override, ok := r.Context().Value(key).(*Override); ok {
+		return override.Timeout
+	}
+
+	return 0
+}
+
+// Value retrieves the request's applied [Override] from the provided context. If a nil value is returned, the request carries
+// no applied override, or the [Middleware] isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Override) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Override); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Override); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Request Override Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Middleware] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Middleware)(nil)
@@ -0,0 +1,260 @@
+package override_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/override"
+	"github.com/poly-gun/go-middleware/middleware/sampling"
+)
+
+var secret = []byte("secret")
+
+func sign(t *testing.T, secret []byte, o *override.Override) string {
+	t.Helper()
+
+	value, e := override.Sign(secret, o)
+	if e != nil {
+		t.Fatalf("Unexpected Error While Signing Override: %v", e)
+	}
+
+	return value
+}
+
+func Test(t *testing.T) {
+	expires := time.Now().Add(time.Minute * 5)
+
+	tests := []struct {
+		name     string
+		remote   string
+		value    func(t *testing.T) string
+		settings func(o *override.Options)
+		expected error
+	}{
+		{
+			name:   "Applied",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Timeout: time.Minute, Sample: true, Bypass: true, Subject: "operator", Reason: "INC-1", Expires: expires})
+			},
+			expected: nil,
+		},
+		{
+			name:   "Private-Network-Client",
+			remote: "10.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Sample: true, Subject: "operator", Expires: expires})
+			},
+			expected: nil,
+		},
+		{
+			name:   "Untrusted-Client",
+			remote: "203.0.113.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Sample: true, Subject: "operator", Expires: expires})
+			},
+			expected: override.ErrUntrusted,
+		},
+		{
+			name:   "Invalid-Signature",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, []byte("invalid"), &override.Override{Sample: true, Subject: "operator", Expires: expires})
+			},
+			expected: override.ErrSignature,
+		},
+		{
+			name:   "Tampered-Payload",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				value := sign(t, secret, &override.Override{Sample: true, Subject: "operator", Expires: expires})
+				_, signature, _ := strings.Cut(value, ".")
+				payload, _, _ := strings.Cut(sign(t, secret, &override.Override{Timeout: time.Minute, Subject: "operator", Expires: expires}), ".")
+
+				return payload + "." + signature
+			},
+			expected: override.ErrSignature,
+		},
+		{
+			name:   "Malformed",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return "malformed"
+			},
+			expected: override.ErrMalformed,
+		},
+		{
+			name:   "Missing-Subject",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Sample: true, Expires: expires})
+			},
+			expected: override.ErrMalformed,
+		},
+		{
+			name:   "Expired",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Sample: true, Subject: "operator", Expires: time.Now().Add(-time.Minute)})
+			},
+			expected: override.ErrExpired,
+		},
+		{
+			name:   "Excessive-Lifetime",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Sample: true, Subject: "operator", Expires: time.Now().Add(time.Hour * 24)})
+			},
+			expected: override.ErrExpired,
+		},
+		{
+			name:   "Disallowed-Setting",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Bypass: true, Subject: "operator", Expires: expires})
+			},
+			settings: func(o *override.Options) {
+				o.Allowed = []override.Setting{override.SettingSample}
+			},
+			expected: override.ErrSetting,
+		},
+		{
+			name:   "Excessive-Timeout",
+			remote: "127.0.0.1:8080",
+			value: func(t *testing.T) string {
+				return sign(t, secret, &override.Override{Timeout: time.Hour, Subject: "operator", Expires: expires})
+			},
+			expected: override.ErrSetting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audited bool
+			var err error
+
+			handler := override.New().Settings(func(o *override.Options) {
+				o.Secret = secret
+				o.Audit = func(r *http.Request, o *override.Override, e error) {
+					audited = true
+					err = e
+				}
+			}, tt.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if v := r.Header.Get("X-Middleware-Override"); v != "" {
+					t.Errorf("Override Header Forwarded to Next Handler")
+				}
+
+				if value := override.Value(r.Context()); (value != nil) != (tt.expected == nil) {
+					t.Errorf("Applied = %v\n    - Expectation = %v", value != nil, tt.expected == nil)
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.RemoteAddr = tt.remote
+			request.Header.Set("X-Middleware-Override", tt.value(t))
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+			}
+
+			if !(audited) {
+				t.Errorf("Override Wasn't Audited")
+			}
+
+			if !(errors.Is(err, tt.expected)) {
+				t.Errorf("Error = %v\n    - Expectation = %v", err, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Settings", func(t *testing.T) {
+		var bypass string
+		var sampled bool
+		var duration time.Duration
+
+		handler := sampling.New().Settings(func(o *sampling.Options) {
+			o.Percentage = 0
+		}).Handler(override.New().Settings(func(o *override.Options) {
+			o.Secret = secret
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bypass = r.Header.Get("Cache-Control")
+			sampled = sampling.Sampled(r.Context())
+			duration = override.Timeout(r)
+		})))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = "127.0.0.1:8080"
+		request.Header.Set("X-Middleware-Override", sign(t, secret, &override.Override{Timeout: time.Minute, Sample: true, Bypass: true, Subject: "operator", Expires: time.Now().Add(time.Minute)}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if bypass != "no-cache" {
+			t.Errorf("Cache-Control = %s\n    - Expectation = %s", bypass, "no-cache")
+		}
+
+		if !(sampled) {
+			t.Errorf("Sampled = %v\n    - Expectation = %v", sampled, true)
+		}
+
+		if duration != time.Minute {
+			t.Errorf("Timeout = %s\n    - Expectation = %s", duration, time.Minute)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		var audited bool
+
+		handler := override.New().Settings(func(o *override.Options) {
+			o.Audit = func(r *http.Request, o *override.Override, e error) {
+				audited = true
+			}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if v := r.Header.Get("X-Middleware-Override"); v != "" {
+				t.Errorf("Override Header Forwarded to Next Handler")
+			}
+
+			if override.Value(r.Context()) != nil {
+				t.Errorf("Override Applied Without a Secret")
+			}
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = "127.0.0.1:8080"
+		request.Header.Set("X-Middleware-Override", sign(t, nil, &override.Override{Sample: true, Subject: "operator", Expires: time.Now().Add(time.Minute)}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if audited {
+			t.Errorf("Override Audited Without a Secret")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		t.Run("Unit-Testing-Key", func(t *testing.T) {
+			expectation := &override.Override{Subject: "operator"}
+
+			ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+
+			if v := override.Value(ctx); v != expectation {
+				t.Errorf("Value = %v\n    - Expectation = %v", v, expectation)
+			}
+		})
+
+		t.Run("Absent", func(t *testing.T) {
+			if v := override.Value(context.Background()); v != nil {
+				t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+			}
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package override
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Setting represents an overridable, per-request option. See [Options.Allowed].
+type Setting string
+
+const (
+	// SettingTimeout represents a raised request timeout - see [Timeout].
+	SettingTimeout Setting = "timeout"
+
+	// SettingSample represents a forced (debug) sampling decision.
+	SettingSample Setting = "sample"
+
+	// SettingBypass represents a cache bypass.
+	SettingBypass Setting = "bypass"
+)
+
+var (
+	// ErrUntrusted is reported for overrides received from an untrusted client. See [Options.Trusted].
+	ErrUntrusted = errors.New("override received from untrusted client")
+
+	// ErrMalformed is reported for override header values that can't be decoded.
+	ErrMalformed = errors.New("malformed override")
+
+	// ErrSignature is reported for overrides with an invalid signature.
+	ErrSignature = errors.New("invalid override signature")
+
+	// ErrExpired is reported for expired overrides, or overrides whose expiry exceeds [Options.Lifetime].
+	ErrExpired = errors.New("expired override")
+
+	// ErrSetting is reported for overrides adjusting a setting absent from [Options.Allowed], or exceeding its bounds.
+	ErrSetting = errors.New("disallowed override setting")
+)
+
+// Override represents a verified, per-request adjustment of selected middleware options, as conveyed by the signed override
+// header. See [Sign] for producing header values.
+type Override struct {
+	// Timeout represents the raised request timeout. Zero retains the configured timeout.
+	Timeout time.Duration `json:"-"`
+
+	// Sample forces the request's sampling decision.
+	Sample bool `json:"sample,omitempty"`
+
+	// Bypass bypasses caches for the request.
+	Bypass bool `json:"bypass,omitempty"`
+
+	// Subject represents the operator issuing the override, recorded in the audit trail.
+	Subject string `json:"subject"`
+
+	// Reason represents the override's justification (e.g. an incident identifier), recorded in the audit trail.
+	Reason string `json:"reason,omitempty"`
+
+	// Expires represents the override's expiry.
+	Expires time.Time `json:"-"`
+}
+
+// wire represents the override header's JSON payload.
+type wire struct {
+	Override
+
+	Timeout string `json:"timeout,omitempty"`
+	Expires int64  `json:"expires"`
+}
+
+// Settings returns the override's adjusted settings.
+func (o *Override) Settings() []Setting {
+	var settings []Setting
+	if o.Timeout > 0 {
+		settings = append(settings, SettingTimeout)
+	}
+
+	if o.Sample {
+		settings = append(settings, SettingSample)
+	}
+
+	if o.Bypass {
+		settings = append(settings, SettingBypass)
+	}
+
+	return settings
+}
+
+// signature returns the payload's HMAC-SHA256 signature.
+func signature(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
+
+// Sign returns the override header value conveying the override, signed with the secret: the base64url-encoded JSON payload and
+// its HMAC-SHA256 signature, separated by a period. Operators' tooling can use Sign to issue overrides.
+func Sign(secret []byte, override *Override) (string, error) {
+	value := wire{Override: *override, Expires: override.Expires.Unix()}
+	if override.Timeout > 0 {
+		value.Timeout = override.Timeout.String()
+	}
+
+	buffer, e := json.Marshal(value)
+	if e != nil {
+		return "", e
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(buffer)
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature(secret, payload)), nil
+}
+
+// verify decodes, and verifies the signature of, an override header value.
+func verify(secret []byte, value string) (*Override, error) {
+	payload, signed, found := strings.Cut(value, ".")
+	if !(found) {
+		return nil, ErrMalformed
+	}
+
+	digest, e := base64.RawURLEncoding.DecodeString(signed)
+	if e != nil {
+		return nil, ErrMalformed
+	}
+
+	if !(hmac.Equal(digest, signature(secret, payload))) {
+		return nil, ErrSignature
+	}
+
+	buffer, e := base64.RawURLEncoding.DecodeString(payload)
+	if e != nil {
+		return nil, ErrMalformed
+	}
+
+	var decoded wire
+	if e := json.Unmarshal(buffer, &decoded); e != nil {
+		return nil, ErrMalformed
+	}
+
+	override := decoded.Override
+	override.Expires = time.Unix(decoded.Expires, 0)
+
+	if decoded.Timeout != "" {
+		if override.Timeout, e = time.ParseDuration(decoded.Timeout); e != nil || override.Timeout <= 0 {
+			return nil, ErrMalformed
+		}
+	}
+
+	if override.Subject == "" {
+		return nil, ErrMalformed
+	}
+
+	return &override, nil
+}
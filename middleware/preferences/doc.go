@@ -0,0 +1,8 @@
+// Package preferences provides middleware resolving a client's display preferences - time zone, unit system, and currency - into
+// a typed context value, so handlers format responses consistently.
+//
+// Each preference is sourced from a cookie (typically an explicit selection in the client's interface), then a request header
+// (e.g. a time zone reported by the browser's Intl API), then a configured default. Time zones are validated against the IANA
+// time zone database, and loaded as [time.Location] values; applications deployed without system time zone data should import
+// the [time/tzdata] package.
+package preferences
@@ -0,0 +1,58 @@
+package preferences_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/preferences"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(preferences.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		v := preferences.Value(r.Context())
+
+		timestamp := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC).In(v.Location)
+
+		fmt.Printf("Time: %s, Units: %s, Currency: %s\n", timestamp.Format(time.DateTime), v.Units, v.Currency)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-Timezone", "Asia/Tokyo")
+	request.AddCookie(&http.Cookie{Name: "currency", Value: "JPY"})
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Time: 2026-01-01 21:00:00, Units: metric, Currency: JPY
+}
@@ -0,0 +1,276 @@
+package preferences
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "preferences"
+
+// Units represents a unit system.
+type Units string
+
+const (
+	// Metric represents the metric (SI) unit system.
+	Metric Units = "metric"
+
+	// Imperial represents the imperial (US customary) unit system.
+	Imperial Units = "imperial"
+)
+
+// Valuer is the context return type relating to the [Preferences] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Timezone represents the client's IANA time zone database name (e.g. "America/New_York").
+	Timezone string `json:"timezone"`
+
+	// Location represents the loaded [Valuer.Timezone] location, for formatting times via [time.Time.In].
+	Location *time.Location `json:"-"`
+
+	// Units represents the client's unit system.
+	Units Units `json:"units"`
+
+	// Currency represents the client's ISO 4217 currency code (e.g. "EUR").
+	Currency string `json:"currency"`
+}
+
+// Preference represents a preference's sources and fallback. A preference cookie - typically set by an explicit selection in the
+// client's interface - takes precedence over a preference header.
+type Preference struct {
+	// Header represents the request header carrying the preference. An empty value disables the header source.
+	Header string
+
+	// Cookie represents the cookie carrying the preference. An empty value disables the cookie source.
+	Cookie string
+
+	// Default represents the preference's value when absent from, or invalid in, the request.
+	Default string
+}
+
+// Options represents the configuration settings for the [Preferences] middleware component.
+type Options struct {
+	// Timezone represents the time zone preference. Values must be valid IANA time zone database names. Defaults to the
+	// "X-Timezone" header, "tz" cookie, and "UTC".
+	Timezone Preference
+
+	// Units represents the unit system preference. Values must be [Metric] or [Imperial], case-insensitively. Defaults to the
+	// "X-Units" header, "units" cookie, and [Metric].
+	Units Preference
+
+	// Currency represents the currency preference. Values must be three-letter ISO 4217 codes, case-insensitively. Defaults to the
+	// "X-Currency" header, "currency" cookie, and "USD".
+	Currency Preference
+
+	// Currencies optionally restricts the accepted currency codes (e.g. to the currencies an application supports). An empty
+	// slice accepts any well-formed code. Defaults to an empty slice.
+	Currencies []string
+
+	// Level specifies the log level used to record invalid preference values. A value of nil disables logging. Defaults to
+	// [slog.LevelDebug].
+	Level slog.Leveler
+}
+
+// Preferences represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Preferences struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	locations sync.Map // locations caches loaded [time.Location] values by name.
+}
+
+// Settings applies configuration functions to modify the [Preferences] middleware's [Options] and returns the updated middleware instance.
+func (p *Preferences) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if p.options == nil {
+		p.options = &Options{
+			Timezone:   Preference{Header: "X-Timezone", Cookie: "tz", Default: "UTC"},
+			Units:      Preference{Header: "X-Units", Cookie: "units", Default: string(Metric)},
+			Currency:   Preference{Header: "X-Currency", Cookie: "currency", Default: "USD"},
+			Currencies: []string{},
+			Level:      slog.LevelDebug,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(p.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if _, ok := p.location(p.options.Timezone.Default); !(ok) {
+		slog.Warn("Invalid Preferences Timezone Default Specified - Using Default Timezone")
+
+		p.options.Timezone.Default = "UTC"
+	}
+
+	if _, ok := units(p.options.Units.Default); !(ok) {
+		slog.Warn("Invalid Preferences Units Default Specified - Using Default Units")
+
+		p.options.Units.Default = string(Metric)
+	}
+
+	if _, ok := p.currency(p.options.Currency.Default); !(ok) {
+		slog.Warn("Invalid Preferences Currency Default Specified - Using Default Currency")
+
+		p.options.Currency.Default = "USD"
+	}
+
+	return p
+}
+
+// location loads, and caches, the named IANA time zone. The process-dependent "Local" zone, and the empty name, are invalid.
+func (p *Preferences) location(name string) (*time.Location, bool) {
+	if name == "" || name == "Local" || len(name) > 64 || strings.Contains(name, "..") {
+		return nil, false
+	}
+
+	if v, ok := p.locations.Load(name); ok {
+		return v.(*time.Location), true
+	}
+
+	location, e := time.LoadLocation(name)
+	if e != nil {
+		return nil, false
+	}
+
+	p.locations.Store(name, location)
+
+	return location, true
+}
+
+// units normalizes and validates a unit system.
+func units(value string) (Units, bool) {
+	switch v := Units(strings.ToLower(strings.TrimSpace(value))); v {
+	case Metric, Imperial:
+		return v, true
+	}
+
+	return "", false
+}
+
+// currency normalizes and validates a currency code.
+func (p *Preferences) currency(value string) (string, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) != 3 || strings.IndexFunc(value, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return "", false
+	}
+
+	if len(p.options.Currencies) == 0 {
+		return value, true
+	}
+
+	for _, v := range p.options.Currencies {
+		if strings.EqualFold(v, value) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// resolve returns the preference's candidate values, in precedence order.
+func resolve(r *http.Request, preference Preference) []string {
+	candidates := make([]string, 0, 2)
+
+	if preference.Cookie != "" {
+		if cookie, e := r.Cookie(preference.Cookie); e == nil && cookie.Value != "" {
+			candidates = append(candidates, cookie.Value)
+		}
+	}
+
+	if preference.Header != "" {
+		if v := r.Header.Get(preference.Header); v != "" {
+			candidates = append(candidates, v)
+		}
+	}
+
+	return candidates
+}
+
+// invalid logs an invalid preference value.
+func (p *Preferences) invalid(ctx context.Context, preference, value string) {
+	if v := p.options.Level; v != nil {
+		slog.Log(ctx, v.Level(), "Invalid Client Preference", slog.String("preference", preference), slog.String("value", value))
+	}
+}
+
+// Handler resolves the client's display preferences - time zone, unit system, and currency - from the request's cookies and
+// headers, validating each value, and stores the typed preferences in the request context. Invalid values fall back to the next
+// source, then the preference's default.
+func (p *Preferences) Handler(next http.Handler) http.Handler {
+	p.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Timezone: p.options.Timezone.Default, Units: Units(p.options.Units.Default), Currency: p.options.Currency.Default}
+		valuer.Location, _ = p.location(valuer.Timezone)
+
+		for _, candidate := range resolve(r, p.options.Timezone) {
+			if location, ok := p.location(candidate); ok {
+				valuer.Timezone, valuer.Location = candidate, location
+				break
+			}
+
+			p.invalid(ctx, "timezone", candidate)
+		}
+
+		for _, candidate := range resolve(r, p.options.Units) {
+			if v, ok := units(candidate); ok {
+				valuer.Units = v
+				break
+			}
+
+			p.invalid(ctx, "units", candidate)
+		}
+
+		for _, candidate := range resolve(r, p.options.Currency) {
+			if v, ok := p.currency(candidate); ok {
+				valuer.Currency = v
+				break
+			}
+
+			p.invalid(ctx, "currency", candidate)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Preferences] middleware, implementing [middleware.Configurable]. If [Preferences.Settings]
+// isn't called, then the [Preferences.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Preferences)
+}
+
+// Value retrieves the request's resolved display preferences from the provided context. If a nil value is returned, it can be
+// assumed that the [Preferences] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Preferences] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Preferences)(nil)
@@ -0,0 +1,108 @@
+package preferences_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/poly-gun/go-middleware/middleware/preferences"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := preferences.Value(r.Context())
+
+		w.Header().Set("X-Timezone", v.Timezone)
+		w.Header().Set("X-Location", v.Location.String())
+		w.Header().Set("X-Units", string(v.Units))
+		w.Header().Set("X-Currency", v.Currency)
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		options  func(o *preferences.Options)
+		header   http.Header
+		cookies  []*http.Cookie
+		timezone string
+		units    preferences.Units
+		currency string
+	}{
+		{name: "Defaults", timezone: "UTC", units: preferences.Metric, currency: "USD"},
+		{name: "Headers", header: http.Header{"X-Timezone": {"America/New_York"}, "X-Units": {"Imperial"}, "X-Currency": {"eur"}}, timezone: "America/New_York", units: preferences.Imperial, currency: "EUR"},
+		{name: "Cookies", cookies: []*http.Cookie{{Name: "tz", Value: "Europe/Berlin"}, {Name: "units", Value: "metric"}, {Name: "currency", Value: "GBP"}}, timezone: "Europe/Berlin", units: preferences.Metric, currency: "GBP"},
+		{name: "Cookie-Precedence", header: http.Header{"X-Timezone": {"America/New_York"}}, cookies: []*http.Cookie{{Name: "tz", Value: "Asia/Tokyo"}}, timezone: "Asia/Tokyo", units: preferences.Metric, currency: "USD"},
+		{name: "Invalid-Cookie-Fallback", header: http.Header{"X-Timezone": {"America/New_York"}}, cookies: []*http.Cookie{{Name: "tz", Value: "Mars/Olympus_Mons"}}, timezone: "America/New_York", units: preferences.Metric, currency: "USD"},
+		{name: "Invalid-Values", header: http.Header{"X-Timezone": {"Local"}, "X-Units": {"furlongs"}, "X-Currency": {"US$"}}, timezone: "UTC", units: preferences.Metric, currency: "USD"},
+		{name: "Invalid-Traversal", header: http.Header{"X-Timezone": {"../../etc/passwd"}}, timezone: "UTC", units: preferences.Metric, currency: "USD"},
+		{name: "Currencies-Allowlist", options: func(o *preferences.Options) {
+			o.Currencies = []string{"USD", "EUR"}
+		}, header: http.Header{"X-Currency": {"JPY"}}, timezone: "UTC", units: preferences.Metric, currency: "USD"},
+		{name: "Custom-Defaults", options: func(o *preferences.Options) {
+			o.Timezone.Default = "Europe/Paris"
+			o.Units.Default = "imperial"
+			o.Currency.Default = "CAD"
+		}, timezone: "Europe/Paris", units: preferences.Imperial, currency: "CAD"},
+		{name: "Invalid-Defaults", options: func(o *preferences.Options) {
+			o.Timezone.Default = "Nowhere"
+			o.Units.Default = "cubits"
+			o.Currency.Default = "dollars"
+		}, timezone: "UTC", units: preferences.Metric, currency: "USD"},
+		{name: "Disabled-Source", options: func(o *preferences.Options) {
+			o.Timezone.Header = ""
+		}, header: http.Header{"X-Timezone": {"America/New_York"}}, timezone: "UTC", units: preferences.Metric, currency: "USD"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := preferences.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range test.header {
+				request.Header[k] = v
+			}
+
+			for _, cookie := range test.cookies {
+				request.AddCookie(cookie)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Timezone"); v != test.timezone {
+				t.Errorf("Timezone = %s\n    - Expectation = %s", v, test.timezone)
+			}
+
+			if v := recorder.Header().Get("X-Location"); v != test.timezone {
+				t.Errorf("Location = %s\n    - Expectation = %s", v, test.timezone)
+			}
+
+			if v := preferences.Units(recorder.Header().Get("X-Units")); v != test.units {
+				t.Errorf("Units = %s\n    - Expectation = %s", v, test.units)
+			}
+
+			if v := recorder.Header().Get("X-Currency"); v != test.currency {
+				t.Errorf("Currency = %s\n    - Expectation = %s", v, test.currency)
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := preferences.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &preferences.Valuer{Timezone: "UTC", Location: time.UTC, Units: preferences.Metric, Currency: "USD"}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := preferences.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
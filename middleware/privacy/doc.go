@@ -0,0 +1,6 @@
+// Package privacy provides middleware honoring the Do-Not-Track (DNT) and Global Privacy Control (Sec-GPC) request headers.
+//
+// The request's privacy signals are exposed via context, for analytics emitters and experimentation frameworks to consult. For
+// opted-out requests, the middleware also removes analytics and A/B assignment cookies from the response, strips high-entropy
+// User-Agent client hints from the request, and drops the Accept-CH and Critical-CH response headers soliciting them.
+package privacy
@@ -0,0 +1,59 @@
+package privacy_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/privacy"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(privacy.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		if v := privacy.Value(r.Context()); v.OptOut {
+			fmt.Println("Analytics Suppressed")
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: "_ga", Value: "GA1.1.1"})
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Sec-GPC", "1")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Cookies: %d\n", len(response.Cookies()))
+
+	// Output:
+	// Analytics Suppressed
+	// Cookies: 0
+}
@@ -0,0 +1,230 @@
+package privacy
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "privacy"
+
+// Valuer is the context return type relating to the [Privacy] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// DNT reports whether the request carried a Do-Not-Track signal ("DNT: 1").
+	DNT bool `json:"dnt"`
+
+	// GPC reports whether the request carried a Global Privacy Control signal ("Sec-GPC: 1").
+	GPC bool `json:"gpc"`
+
+	// OptOut reports whether the request carried an honored privacy signal - per [Options.DNT] and [Options.GPC] - for which
+	// analytics, fingerprinting, and experimentation should be suppressed.
+	OptOut bool `json:"opt-out"`
+}
+
+// Options represents the configuration settings for the [Privacy] middleware component.
+type Options struct {
+	// DNT enables honoring the Do-Not-Track request header. Defaults to true.
+	DNT bool
+
+	// GPC enables honoring the Global Privacy Control (Sec-GPC) request header. Defaults to true.
+	GPC bool
+
+	// Cookies represents the names of analytics and A/B assignment cookies removed from opted-out requests' responses. A trailing
+	// "*" matches cookie names by prefix. Defaults to common analytics and experimentation cookies (e.g. "_ga*", "_fbp").
+	Cookies []string
+
+	// Fingerprinting enables removing high-entropy User-Agent client hint request headers from opted-out requests, and the
+	// Accept-CH and Critical-CH response headers soliciting them. Defaults to true.
+	Fingerprinting bool
+}
+
+// Privacy represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Privacy struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Privacy] middleware's [Options] and returns the updated middleware instance.
+func (p *Privacy) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if p.options == nil {
+		p.options = &Options{
+			DNT:            true,
+			GPC:            true,
+			Cookies:        []string{"_ga*", "_gid", "_gat*", "_gcl_*", "_fbp", "_hj*", "ajs_*", "mp_*", "optimizely*", "_vwo*", "ab_*"},
+			Fingerprinting: true,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(p.options)
+		}
+	}
+
+	return p
+}
+
+// signal reports whether the privacy header's value expresses an opt-out.
+func signal(value string) bool {
+	return strings.TrimSpace(value) == "1"
+}
+
+// suppressed reports whether the named cookie matches the [Options.Cookies] patterns.
+func (p *Privacy) suppressed(name string) bool {
+	for _, pattern := range p.options.Cookies {
+		if prefix, found := strings.CutSuffix(pattern, "*"); found {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hints represents the low-entropy User-Agent client hints, sent by default and retained for opted-out requests.
+var hints = map[string]struct{}{
+	"Sec-Ch-Ua":          {},
+	"Sec-Ch-Ua-Mobile":   {},
+	"Sec-Ch-Ua-Platform": {},
+}
+
+// writer removes suppressed cookies and client hint solicitations upon writing the response's header(s).
+type writer struct {
+	http.ResponseWriter
+
+	privacy *Privacy
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) {
+		w.written = true
+
+		header := w.ResponseWriter.Header()
+
+		if cookies := header.Values("Set-Cookie"); len(cookies) > 0 {
+			header.Del("Set-Cookie")
+
+			for _, cookie := range cookies {
+				name, _, _ := strings.Cut(cookie, "=")
+				if w.privacy.suppressed(strings.TrimSpace(name)) {
+					continue
+				}
+
+				header.Add("Set-Cookie", cookie)
+			}
+		}
+
+		if w.privacy.options.Fingerprinting {
+			header.Del("Accept-CH")
+			header.Del("Critical-CH")
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], removing suppressed cookies and client hint solicitations before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler detects the request's Do-Not-Track and Global Privacy Control signals and stores them in the request context, allowing
+// analytics emitters and experimentation frameworks to opt out. For requests carrying an honored signal, analytics and A/B
+// assignment cookies are removed from the response, and - if enabled - client hint fingerprinting surfaces are suppressed.
+func (p *Privacy) Handler(next http.Handler) http.Handler {
+	p.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		w.Header().Add("Vary", "DNT, Sec-GPC")
+
+		valuer := &Valuer{DNT: signal(r.Header.Get("DNT")), GPC: signal(r.Header.Get("Sec-GPC"))}
+		valuer.OptOut = (p.options.DNT && valuer.DNT) || (p.options.GPC && valuer.GPC)
+
+		r = r.WithContext(context.WithValue(ctx, key, valuer))
+
+		if !(valuer.OptOut) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if p.options.Fingerprinting {
+			r.Header = r.Header.Clone()
+
+			for name := range r.Header {
+				if _, low := hints[name]; !(low) && strings.HasPrefix(name, "Sec-Ch-Ua-") {
+					r.Header.Del(name)
+				}
+			}
+		}
+
+		next.ServeHTTP(&writer{ResponseWriter: w, privacy: p}, r)
+	})
+}
+
+// New creates a new instance of the [Privacy] middleware, implementing [middleware.Configurable]. If [Privacy.Settings] isn't
+// called, then the [Privacy.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Privacy)
+}
+
+// Value retrieves the request's privacy signals from the provided context. If a nil value is returned, it can be assumed that
+// the [Privacy] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Privacy] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Privacy)(nil)
@@ -0,0 +1,125 @@
+package privacy_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/privacy"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := privacy.Value(r.Context())
+
+		w.Header().Set("X-DNT", strconv.FormatBool(v.DNT))
+		w.Header().Set("X-GPC", strconv.FormatBool(v.GPC))
+		w.Header().Set("X-Opt-Out", strconv.FormatBool(v.OptOut))
+		w.Header().Set("X-Client-Hints", r.Header.Get("Sec-CH-UA-Full-Version-List")+"|"+r.Header.Get("Sec-CH-UA-Platform"))
+
+		w.Header().Set("Accept-CH", "Sec-CH-UA-Full-Version-List")
+
+		http.SetCookie(w, &http.Cookie{Name: "_ga", Value: "GA1.1.1"})
+		http.SetCookie(w, &http.Cookie{Name: "ab_checkout", Value: "b"})
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s"})
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		options func(o *privacy.Options)
+		header  http.Header
+		dnt     bool
+		gpc     bool
+		optout  bool
+		cookies []string
+		hints   string
+		accept  string
+	}{
+		{name: "No-Signal", dnt: false, gpc: false, optout: false, cookies: []string{"_ga", "ab_checkout", "session"}, hints: "Chromium;v=\"126.0.0.0\"|\"Linux\"", accept: "Sec-CH-UA-Full-Version-List"},
+		{name: "DNT", header: http.Header{"Dnt": {"1"}}, dnt: true, optout: true, cookies: []string{"session"}, hints: "|\"Linux\"", accept: ""},
+		{name: "GPC", header: http.Header{"Sec-Gpc": {"1"}}, gpc: true, optout: true, cookies: []string{"session"}, hints: "|\"Linux\"", accept: ""},
+		{name: "DNT-Disabled", options: func(o *privacy.Options) {
+			o.DNT = false
+		}, header: http.Header{"Dnt": {"1"}}, dnt: true, optout: false, cookies: []string{"_ga", "ab_checkout", "session"}, hints: "Chromium;v=\"126.0.0.0\"|\"Linux\"", accept: "Sec-CH-UA-Full-Version-List"},
+		{name: "DNT-Zero", header: http.Header{"Dnt": {"0"}}, cookies: []string{"_ga", "ab_checkout", "session"}, hints: "Chromium;v=\"126.0.0.0\"|\"Linux\"", accept: "Sec-CH-UA-Full-Version-List"},
+		{name: "Custom-Cookies", options: func(o *privacy.Options) {
+			o.Cookies = []string{"session"}
+		}, header: http.Header{"Sec-Gpc": {"1"}}, gpc: true, optout: true, cookies: []string{"_ga", "ab_checkout"}, hints: "|\"Linux\"", accept: ""},
+		{name: "Fingerprinting-Disabled", options: func(o *privacy.Options) {
+			o.Fingerprinting = false
+		}, header: http.Header{"Sec-Gpc": {"1"}}, gpc: true, optout: true, cookies: []string{"session"}, hints: "Chromium;v=\"126.0.0.0\"|\"Linux\"", accept: "Sec-CH-UA-Full-Version-List"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := privacy.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Sec-CH-UA-Full-Version-List", "Chromium;v=\"126.0.0.0\"")
+			request.Header.Set("Sec-CH-UA-Platform", "\"Linux\"")
+			for k, v := range test.header {
+				request.Header[k] = v
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-DNT"); v != strconv.FormatBool(test.dnt) {
+				t.Errorf("DNT = %s\n    - Expectation = %t", v, test.dnt)
+			}
+
+			if v := recorder.Header().Get("X-GPC"); v != strconv.FormatBool(test.gpc) {
+				t.Errorf("GPC = %s\n    - Expectation = %t", v, test.gpc)
+			}
+
+			if v := recorder.Header().Get("X-Opt-Out"); v != strconv.FormatBool(test.optout) {
+				t.Errorf("Opt-Out = %s\n    - Expectation = %t", v, test.optout)
+			}
+
+			var cookies []string
+			for _, cookie := range recorder.Result().Cookies() {
+				cookies = append(cookies, cookie.Name)
+			}
+
+			if !(slices.Equal(cookies, test.cookies)) {
+				t.Errorf("Cookies = %v\n    - Expectation = %v", cookies, test.cookies)
+			}
+
+			if v := recorder.Header().Get("X-Client-Hints"); v != test.hints {
+				t.Errorf("Client-Hints = %s\n    - Expectation = %s", v, test.hints)
+			}
+
+			if v := recorder.Header().Get("Accept-CH"); v != test.accept {
+				t.Errorf("Accept-CH = %s\n    - Expectation = %s", v, test.accept)
+			}
+
+			if v := recorder.Header().Get("Vary"); v != "DNT, Sec-GPC" {
+				t.Errorf("Vary = %s\n    - Expectation = %s", v, "DNT, Sec-GPC")
+			}
+
+			if request.Header.Get("Sec-CH-UA-Full-Version-List") == "" {
+				t.Errorf("Unexpected Mutation of Caller's Request Header(s)")
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := privacy.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &privacy.Valuer{DNT: true, OptOut: true}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := privacy.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
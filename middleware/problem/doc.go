@@ -0,0 +1,9 @@
+// Package problem provides structured error-handling middleware, converting handler errors into RFC 9457 (formerly RFC 7807)
+// "application/problem+json" responses.
+//
+// Handlers report errors either through the request context's collector ([Set]), or by returning them from a [HandlerFunc]. Once
+// the handler returns, the error is converted into a [Problem] through an ordered mapping table ([Options.Mappings]) from error
+// values or types to status codes; errors that are a [*Problem] - or implement [Statuser], as the failure package's classified
+// errors do - carry their own status. Retriable failures' responses include a Retry-After header. Every problem
+// includes the request's correlation identifier, derived from the telemetrics middleware by default.
+package problem
@@ -0,0 +1,64 @@
+package problem_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/problem"
+)
+
+func Example() {
+	var ErrNotFound = errors.New("user not found")
+
+	middleware := middleware.New()
+
+	middleware.Add(problem.New().Settings(func(o *problem.Options) {
+		o.Mappings = append(o.Mappings, problem.Mapping{Match: problem.Is(ErrNotFound), Status: http.StatusNotFound})
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /users/{id}", problem.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("user %s: %w", r.PathValue("id"), ErrNotFound)
+	}))
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/users/1", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	body, e := io.ReadAll(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading response body: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("Content-Type: %s\n", response.Header.Get("Content-Type"))
+	fmt.Printf("%s", body)
+
+	// Output:
+	// Content-Type: application/problem+json
+	// {"type":"about:blank","title":"Not Found","status":404,"detail":"user 1: user not found","instance":"/users/1"}
+}
@@ -0,0 +1,330 @@
+package problem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/failure"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "problem"
+
+// Options represents the configuration settings for the [Middleware] middleware component.
+type Options struct {
+	// Mappings represents the error-to-problem mapping table, evaluated in order; the first matching entry determines the problem's
+	// status, type, and title. Unmatched errors implementing [Statuser] use their own status; all others are mapped to
+	// [http.StatusInternalServerError]. Defaults to mapping [context.DeadlineExceeded] to [http.StatusGatewayTimeout], and
+	// [*http.MaxBytesError] to [http.StatusRequestEntityTooLarge].
+	Mappings []Mapping
+
+	// Detail includes the error's message as the problem's detail for client-error (4xx) statuses. Server-error messages are never
+	// included, as they may expose implementation details; a [*Problem]'s own detail is always retained. Defaults to true.
+	Detail bool
+
+	// Correlation returns the request's correlation identifier, included in every problem. Defaults to a function returning the
+	// telemetrics middleware's trace identifier, or its "X-Correlation-ID" or "X-Request-ID" header value.
+	Correlation func(r *http.Request) string
+
+	// Level optionally overrides the log level of server-error (5xx) problems' records. Defaults to nil - the level of the error's
+	// [failure.Class].
+	Level slog.Leveler
+}
+
+// Middleware represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Middleware struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// correlation is the default [Options.Correlation] function.
+func correlation(r *http.Request) string {
+	if v := telemetrics.Value(r.Context()); v != nil {
+		if v.Trace != nil {
+			return v.Trace.TraceID
+		}
+
+		for _, name := range []string{"X-Correlation-ID", "X-Request-ID"} {
+			if value := v.Headers.Get(name); value != "" {
+				return value
+			}
+		}
+	}
+
+	return ""
+}
+
+// mappings returns the default [Options.Mappings] value.
+func mappings() []Mapping {
+	return []Mapping{
+		{Match: Is(context.DeadlineExceeded), Status: http.StatusGatewayTimeout},
+		{Match: As[*http.MaxBytesError](), Status: http.StatusRequestEntityTooLarge},
+	}
+}
+
+// Settings applies configuration functions to modify the [Middleware] middleware's [Options] and returns the updated middleware instance.
+func (x *Middleware) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Mappings:    mappings(),
+			Detail:      true,
+			Correlation: correlation,
+			Level:       nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Correlation == nil {
+		x.options.Correlation = correlation
+	}
+
+	return x
+}
+
+// Convert returns the [Problem] representing the error, for the provided request, according to the middleware's [Options].
+func (x *Middleware) Convert(r *http.Request, e error) *Problem {
+	x.Settings() // Ensure the options field isn't nil.
+
+	var problem *Problem
+
+	var target *Problem
+	if errors.As(e, &target) {
+		clone := *target
+		problem = &clone
+	} else {
+		problem = &Problem{Status: http.StatusInternalServerError, cause: e}
+
+		var statuser Statuser
+
+		matched := false
+		for _, mapping := range x.options.Mappings {
+			if mapping.Match != nil && mapping.Match(e) {
+				problem.Status, problem.Type, problem.Title = mapping.Status, mapping.Type, mapping.Title
+				matched = true
+
+				break
+			}
+		}
+
+		if !(matched) && errors.As(e, &statuser) {
+			if status := statuser.StatusCode(); status >= 400 && status <= 599 {
+				problem.Status = status
+			}
+		}
+
+		if x.options.Detail && problem.Status < 500 {
+			problem.Detail = e.Error()
+		}
+	}
+
+	if problem.Status < 400 || problem.Status > 599 {
+		problem.Status = http.StatusInternalServerError
+	}
+
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+
+	if problem.Instance == "" {
+		problem.Instance = r.URL.Path
+	}
+
+	if problem.Correlation == "" {
+		problem.Correlation = x.options.Correlation(r)
+	}
+
+	return problem
+}
+
+// write writes the problem as an "application/problem+json" response, discarding header(s) set by the failing handler.
+func (x *Middleware) write(w http.ResponseWriter, r *http.Request, e error) {
+	problem := x.Convert(r, e)
+
+	if problem.Status >= 500 {
+		level := failure.Classify(e).Level()
+		if x.options.Level != nil {
+			level = x.options.Level.Level()
+		}
+
+		slog.Log(r.Context(), level, "Request Problem", slog.Int("status", problem.Status), slog.String("path", r.URL.Path), slog.String("correlation-id", problem.Correlation), slog.String("error", e.Error()))
+	}
+
+	header := w.Header()
+	for k := range header {
+		if k != "Vary" {
+			header.Del(k)
+		}
+	}
+
+	header.Set("Content-Type", "application/problem+json")
+	header.Set("Cache-Control", "no-store")
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	// Retriable failures (e.g. throttling, or a dependency's failure) advertise when the client may retry.
+	failure.Header(header, e)
+
+	w.WriteHeader(problem.Status)
+
+	json.NewEncoder(w).Encode(problem)
+}
+
+// collector records the request's error. See [Set].
+type collector struct {
+	mutex sync.Mutex
+	e     error
+}
+
+// writer tracks whether the response's header(s) were written, such that a problem isn't written over a partial response.
+type writer struct {
+	http.ResponseWriter
+
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if status >= 200 {
+		w.written = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.written = true
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher]. A flushed response is considered written, such that a problem isn't written over it.
+func (w *writer) Flush() {
+	w.written = true
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. A hijacked connection is considered written, such that a problem isn't written to it.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, e := http.NewResponseController(w.ResponseWriter).Hijack()
+	if e == nil {
+		w.written = true
+	}
+
+	return conn, rw, e
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler stores an error collector in the request context. Once the next handler returns, an error recorded through [Set] - or
+// returned by a [HandlerFunc] - is converted into an "application/problem+json" response: see [Middleware.Convert]. If the handler
+// already wrote its response, the error is logged, then discarded.
+func (x *Middleware) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := new(collector)
+
+		r = r.WithContext(context.WithValue(r.Context(), key, c))
+
+		wrapper := &writer{ResponseWriter: w}
+
+		next.ServeHTTP(wrapper, r)
+
+		c.mutex.Lock()
+		e := c.e
+		c.mutex.Unlock()
+
+		if e == nil {
+			return
+		}
+
+		if wrapper.written {
+			slog.WarnContext(r.Context(), "Problem Discarded - Response Already Written", slog.String("path", r.URL.Path), slog.String("error", e.Error()))
+
+			return
+		}
+
+		x.write(w, r, e)
+	})
+}
+
+// New creates a new instance of the [Middleware] middleware, implementing [middleware.Configurable]. If [Middleware.Settings] isn't called,
+// then the [Middleware.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Middleware)
+}
+
+// Set records the request's error, to be written as a problem once the handler returns. Only the first error is retained. False is
+// returned if the [Middleware] isn't enabled for the particular caller's chain - the caller remains responsible for its response.
+func Set(ctx context.Context, e error) bool {
+	c, ok := ctx.Value(key).(*collector)
+	if !(ok) || e == nil {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.e == nil {
+		c.e = e
+	}
+
+	return true
+}
+
+// HandlerFunc adapts an error-returning function to an [http.Handler]. A returned error is recorded through [Set]; if the
+// [Middleware] isn't enabled for the particular caller's chain, the problem is written immediately, using default [Options].
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e := fn(w, r)
+	if e == nil {
+		return
+	}
+
+	if !(Set(r.Context(), e)) {
+		new(Middleware).write(w, r, e)
+	}
+}
+
+// Value retrieves the request's recorded error from the provided context. A nil value is returned if no error was recorded, or
+// if the [Middleware] isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value error) {
+	if v, ok := ctx.Value(key).(*collector); ok {
+		v.mutex.Lock()
+		value = v.e
+		v.mutex.Unlock()
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Problem Collector Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Middleware] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Middleware)(nil)
@@ -0,0 +1,252 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/failure"
+	"github.com/poly-gun/go-middleware/middleware/problem"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// ErrNotFound represents a mapped, sentinel error.
+var ErrNotFound = errors.New("resource not found")
+
+// conflict represents an error conveying its own status code.
+type conflict struct{}
+
+func (conflict) Error() string   { return "resource version conflict" }
+func (conflict) StatusCode() int { return http.StatusConflict }
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings func(o *problem.Options)
+		handler  problem.HandlerFunc
+		status   int
+		title    string
+		detail   string
+		kind     string
+	}{
+		{
+			name: "Unmapped",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return errors.New("database password is hunter2")
+			},
+			status: http.StatusInternalServerError,
+			title:  "Internal Server Error",
+		},
+		{
+			name: "Mapped",
+			settings: func(o *problem.Options) {
+				o.Mappings = append(o.Mappings, problem.Mapping{Match: problem.Is(ErrNotFound), Status: http.StatusNotFound, Type: "https://example.com/problems/not-found"})
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return fmt.Errorf("user 1: %w", ErrNotFound)
+			},
+			status: http.StatusNotFound,
+			title:  "Not Found",
+			detail: "user 1: resource not found",
+			kind:   "https://example.com/problems/not-found",
+		},
+		{
+			name: "Mapped-Type",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				_, e := http.MaxBytesReader(w, r.Body, 1).Read(make([]byte, 8))
+
+				return e
+			},
+			status: http.StatusRequestEntityTooLarge,
+			title:  "Request Entity Too Large",
+			detail: "http: request body too large",
+		},
+		{
+			name: "Statuser",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return conflict{}
+			},
+			status: http.StatusConflict,
+			title:  "Conflict",
+			detail: "resource version conflict",
+		},
+		{
+			name: "Problem",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return fmt.Errorf("wrapped: %w", &problem.Problem{Status: http.StatusPaymentRequired, Title: "Quota Exhausted", Detail: "upgrade your plan"})
+			},
+			status: http.StatusPaymentRequired,
+			title:  "Quota Exhausted",
+			detail: "upgrade your plan",
+		},
+		{
+			name: "Detail-Disabled",
+			settings: func(o *problem.Options) {
+				o.Detail = false
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return conflict{}
+			},
+			status: http.StatusConflict,
+			title:  "Conflict",
+		},
+		{
+			name: "Collector",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Set("X-Discarded", "true")
+
+				problem.Set(r.Context(), conflict{})
+				problem.Set(r.Context(), errors.New("ignored"))
+
+				return nil
+			},
+			status: http.StatusConflict,
+			title:  "Conflict",
+			detail: "resource version conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := telemetrics.New().Handler(problem.New().Settings(tt.settings).Handler(tt.handler))
+
+			request := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader("payload"))
+			request.Header.Set("X-Request-ID", "correlation")
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if v := recorder.Header().Get("Content-Type"); v != "application/problem+json" {
+				t.Errorf("Content-Type = %s\n    - Expectation = %s", v, "application/problem+json")
+			}
+
+			if v := recorder.Header().Get("X-Discarded"); v != "" {
+				t.Errorf("X-Discarded = %s\n    - Expectation = %s", v, "")
+			}
+
+			var body map[string]interface{}
+			if e := json.Unmarshal(recorder.Body.Bytes(), &body); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Response Body: %v", e)
+			}
+
+			kind := tt.kind
+			if kind == "" {
+				kind = "about:blank"
+			}
+
+			expectations := map[string]interface{}{
+				"type":           kind,
+				"title":          tt.title,
+				"status":         float64(tt.status),
+				"instance":       "/users/1",
+				"correlation-id": "correlation",
+			}
+
+			if tt.detail != "" {
+				expectations["detail"] = tt.detail
+			}
+
+			for k, expectation := range expectations {
+				if v := body[k]; v != expectation {
+					t.Errorf("%s = %v\n    - Expectation = %v", k, v, expectation)
+				}
+			}
+
+			if _, ok := body["detail"]; ok && tt.detail == "" {
+				t.Errorf("Unexpected Detail = %v", body["detail"])
+			}
+		})
+	}
+
+	t.Run("Retriable-Failure", func(t *testing.T) {
+		handler := problem.New().Handler(problem.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return failure.Dependency(errors.New("upstream unavailable"), time.Second*30)
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+
+		if v := recorder.Header().Get("Retry-After"); v != "30" {
+			t.Errorf("Retry-After = %s\n    - Expectation = %s", v, "30")
+		}
+	})
+
+	t.Run("Response-Already-Written", func(t *testing.T) {
+		handler := problem.New().Handler(problem.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusAccepted)
+
+			return conflict{}
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusAccepted {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusAccepted)
+		}
+	})
+
+	t.Run("Flushed", func(t *testing.T) {
+		handler := problem.New().Handler(problem.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			if e := http.NewResponseController(w).Flush(); e != nil {
+				return e
+			}
+
+			return conflict{}
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Body.String(); v != "" {
+			t.Errorf("Body = %s\n    - Expectation = %s", v, "an empty body")
+		}
+	})
+
+	t.Run("Without-Middleware", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		problem.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return conflict{}
+		}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("Extensions", func(t *testing.T) {
+		buffer, e := json.Marshal(&problem.Problem{Type: "about:blank", Title: "Bad Request", Status: 400, Extensions: map[string]interface{}{"fields": []string{"name"}, "status": 999}})
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Problem: %v", e)
+		}
+
+		if expectation := `{"fields":["name"],"status":400,"title":"Bad Request","type":"about:blank"}`; string(buffer) != expectation {
+			t.Errorf("Problem = %s\n    - Expectation = %s", string(buffer), expectation)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := problem.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
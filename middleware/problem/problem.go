@@ -0,0 +1,125 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Problem represents an RFC 9457 (formerly RFC 7807) problem details object. A *Problem is an error, such that handlers can
+// return, or [Set], a fully-specified problem; other errors are converted through the middleware's [Options.Mappings].
+type Problem struct {
+	// Type represents a URI identifying the problem type. Defaults to "about:blank".
+	Type string `json:"type"`
+
+	// Title represents a short, human-readable summary of the problem type. Defaults to [http.StatusText] of [Problem.Status].
+	Title string `json:"title"`
+
+	// Status represents the response's status code.
+	Status int `json:"status"`
+
+	// Detail represents a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance represents a URI identifying this occurrence of the problem. Defaults to the request's path.
+	Instance string `json:"instance,omitempty"`
+
+	// Correlation represents the request's correlation identifier - see [Options.Correlation].
+	Correlation string `json:"correlation-id,omitempty"`
+
+	// Extensions represents additional members, serialized alongside the standard members. Extensions never replace a
+	// standard member.
+	Extensions map[string]interface{} `json:"-"`
+
+	// cause represents the error the problem was derived from.
+	cause error
+}
+
+// Error returns the problem's detail, or its title if the detail is empty.
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+
+	return p.Title
+}
+
+// Unwrap returns the error the problem was derived from, if any.
+func (p *Problem) Unwrap() error {
+	return p.cause
+}
+
+// MarshalJSON encodes the problem's standard members, and its [Problem.Extensions].
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type alias Problem // alias sheds the MarshalJSON method, avoiding recursion.
+
+	standard, e := json.Marshal(alias(p))
+	if e != nil || len(p.Extensions) == 0 {
+		return standard, e
+	}
+
+	members := make(map[string]json.RawMessage, len(p.Extensions)+6)
+	for k, v := range p.Extensions {
+		buffer, e := json.Marshal(v)
+		if e != nil {
+			return nil, e
+		}
+
+		members[k] = buffer
+	}
+
+	var fields map[string]json.RawMessage
+	if e := json.Unmarshal(standard, &fields); e != nil {
+		return nil, e
+	}
+
+	for k, v := range fields {
+		members[k] = v
+	}
+
+	return json.Marshal(members)
+}
+
+// Status returns a [Problem] of the given status, wrapping the optional cause. The title defaults to the status's [http.StatusText].
+func Status(status int, detail string, cause error) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, cause: cause}
+}
+
+// Mapping represents an entry of the error-to-problem mapping table. See [Options.Mappings].
+type Mapping struct {
+	// Match reports whether the mapping applies to the error. See [Is] and [As].
+	Match func(e error) bool
+
+	// Status represents the problem's status code.
+	Status int
+
+	// Type represents the problem's type URI. Defaults to "about:blank".
+	Type string
+
+	// Title represents the problem's title. Defaults to [http.StatusText] of [Mapping.Status].
+	Title string
+}
+
+// Is returns a [Mapping.Match] function matching errors that [errors.Is] the target.
+func Is(target error) func(e error) bool {
+	return func(e error) bool {
+		return errors.Is(e, target)
+	}
+}
+
+// As returns a [Mapping.Match] function matching errors whose chain contains an error of type T - see [errors.As].
+func As[T error]() func(e error) bool {
+	return func(e error) bool {
+		var target T
+
+		return errors.As(e, &target)
+	}
+}
+
+// Statuser is implemented by errors conveying their own status code. Such errors are mapped to a problem of their status, unless
+// an [Options.Mappings] entry matches first.
+type Statuser interface {
+	error
+
+	StatusCode() int
+}
@@ -0,0 +1,6 @@
+// Package propagation provides middleware that copies a configurable allowlist of inbound request headers into a context bag,
+// and a [Transport] that applies the bag onto all outbound requests made with the inbound request's context.
+//
+// This is the general form of the header propagation service meshes (e.g. Istio) require of applications - forwarding b3, or
+// other trace-related, headers from inbound to outbound requests - with per-header transform hooks.
+package propagation
@@ -0,0 +1,72 @@
+package propagation_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/propagation"
+)
+
+func Example() {
+	// upstream represents a downstream service called while handling an inbound request.
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Upstream X-B3-TraceId: %s\n", r.Header.Get("X-B3-TraceId"))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer upstream.Close()
+
+	// client applies the propagated header(s) onto every outbound request made with an inbound request's context.
+	client := &http.Client{Transport: &propagation.Transport{}}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		request, e := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		if e != nil {
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(propagation.New().Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-B3-TraceId", "80f198ee56343ba864fe8b2a57d3eff7")
+
+	response, e := server.Client().Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Upstream X-B3-TraceId: 80f198ee56343ba864fe8b2a57d3eff7
+}
@@ -0,0 +1,192 @@
+package propagation
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "propagation"
+
+// Options represents the configuration settings for the [Propagation] middleware component.
+type Options struct {
+	// Headers represents the allowlist of inbound header(s) to propagate.
+	//
+	//	- The casings of these values are ignored.
+	//
+	// Default(s) - the header(s) Istio requires applications to propagate:
+	//
+	//	- "x-request-id"
+	//	- "traceparent"
+	//	- "tracestate"
+	//	- "x-cloud-trace-context"
+	//	- "grpc-trace-bin"
+	//	- "x-b3-traceid"
+	//	- "x-b3-spanid"
+	//	- "x-b3-parentspanid"
+	//	- "x-b3-sampled"
+	//	- "x-b3-flags"
+	//	- "b3"
+	//	- "x-ot-span-context"
+	//	- "sw8"
+	Headers []string
+
+	// Transforms represents optional, per-header hooks applied to each propagated value prior to storage. A hook returning an
+	// empty string drops the value. The casings of the map's keys are ignored.
+	Transforms map[string]func(value string) string
+
+	// Level specifies the log level used to record propagated header(s). A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+}
+
+// Propagation represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Propagation struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Propagation] middleware's [Options] and returns the updated middleware instance.
+func (p *Propagation) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if p.options == nil {
+		p.options = &Options{
+			Headers: []string{
+				"x-request-id",
+				"traceparent",
+				"tracestate",
+				"x-cloud-trace-context",
+				"grpc-trace-bin",
+				"x-b3-traceid",
+				"x-b3-spanid",
+				"x-b3-parentspanid",
+				"x-b3-sampled",
+				"x-b3-flags",
+				"b3",
+				"x-ot-span-context",
+				"sw8",
+			},
+			Transforms: map[string]func(value string) string{},
+			Level:      nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(p.options)
+		}
+	}
+
+	return p
+}
+
+// Handler stores the allowlisted inbound request header(s) in the request context. It forwards the request to the next handler in the chain.
+func (p *Propagation) Handler(next http.Handler) http.Handler {
+	p.Settings() // Ensure the options field isn't nil.
+
+	// Canonicalize the transform keys once, rather than per request.
+	transforms := make(map[string]func(value string) string, len(p.options.Transforms))
+	for k, v := range p.options.Transforms {
+		if v != nil {
+			transforms[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		bag := http.Header{}
+		for _, header := range p.options.Headers {
+			k := http.CanonicalHeaderKey(header)
+			if _, found := bag[k]; found {
+				continue
+			}
+
+			for _, value := range r.Header.Values(k) {
+				if transform, ok := transforms[k]; ok {
+					value = transform(value)
+				}
+
+				if value != "" {
+					bag.Add(k, value)
+				}
+			}
+		}
+
+		if v := p.options.Level; v != nil && len(bag) > 0 {
+			slog.Log(ctx, v.Level(), "Propagation Middleware Header(s)", slog.Any("headers", bag))
+		}
+
+		ctx = context.WithValue(ctx, key, bag)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Propagation] middleware, implementing [middleware.Configurable]. If [Propagation.Settings] isn't called,
+// then the [Propagation.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Propagation)
+}
+
+// Value retrieves the propagated [http.Header] bag from the provided context. If a nil value is returned, it can be assumed that the
+// [Propagation] middleware isn't enabled for the particular caller's chain. Callers must treat the returned header as read-only.
+func Value(ctx context.Context) (headers http.Header) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(http.Header); ok {
+		headers = v
+	} else if test, valid := ctx.Value(t).(http.Header); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		headers = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Transport is an [http.RoundTripper] that applies the propagated header bag, stored in an outbound request's context, onto the
+// outbound request. Header(s) already set on the outbound request take precedence.
+type Transport struct {
+	// Base represents the underlying [http.RoundTripper]. Defaults to [http.DefaultTransport].
+	Base http.RoundTripper
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	bag, ok := request.Context().Value(key).(http.Header)
+	if !(ok) || len(bag) == 0 {
+		return base.RoundTrip(request)
+	}
+
+	// A RoundTripper mustn't modify the caller's request.
+	clone := request.Clone(request.Context())
+	for k, values := range bag {
+		if _, found := clone.Header[k]; found {
+			continue
+		}
+
+		clone.Header[k] = append([]string(nil), values...)
+	}
+
+	return base.RoundTrip(clone)
+}
+
+// Runtime assurance that [Propagation] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Propagation)(nil)
+
+// Runtime assurance that [Transport] satisfies the [http.RoundTripper] interface.
+var _ http.RoundTripper = (*Transport)(nil)
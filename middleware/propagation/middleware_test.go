@@ -0,0 +1,147 @@
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/propagation"
+)
+
+func Test(t *testing.T) {
+	// upstream echoes the received trace-related header(s) back as response header(s).
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, k := range []string{"X-B3-Traceid", "B3", "X-Request-Id", "Authorization", "Tracestate"} {
+			if v := r.Header.Values(k); len(v) > 0 {
+				w.Header()["Echo-"+k] = v
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer upstream.Close()
+
+	client := &http.Client{Transport: &propagation.Transport{Base: upstream.Client().Transport}}
+
+	// handler issues an outbound request using the inbound request's context, and relays the upstream's echoed header(s).
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, e := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		if e != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		request.Header.Set("X-Request-ID", "outbound-override")
+
+		response, e := client.Do(request)
+		if e != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		response.Body.Close()
+
+		for k, v := range response.Header {
+			if strings.HasPrefix(k, "Echo-") {
+				w.Header()[k] = v
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		server := httptest.NewServer(propagation.New().Handler(handler))
+
+		defer server.Close()
+
+		request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		request.Header.Set("x-b3-traceid", "80f198ee56343ba864fe8b2a57d3eff7")
+		request.Header.Set("b3", "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1")
+		request.Header.Set("X-Request-ID", "inbound")
+		request.Header.Set("Authorization", "Bearer secret")
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		expectations := map[string]string{
+			"Echo-X-B3-Traceid":  "80f198ee56343ba864fe8b2a57d3eff7",
+			"Echo-B3":            "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1",
+			"Echo-X-Request-Id":  "outbound-override",
+			"Echo-Authorization": "",
+		}
+
+		for k, expectation := range expectations {
+			if v := response.Header.Get(k); v != expectation {
+				t.Errorf("Header (%s) = %q\n    - Expectation = %q", k, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Allowlist-And-Transforms", func(t *testing.T) {
+		server := httptest.NewServer(propagation.New().Settings(func(o *propagation.Options) {
+			o.Headers = []string{"Authorization", "tracestate"}
+			o.Transforms = map[string]func(value string) string{
+				"authorization": func(value string) string { return "" },
+				"TRACESTATE":    func(value string) string { return value + ",vendor=service" },
+			}
+		}).Handler(handler))
+
+		defer server.Close()
+
+		request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		request.Header.Set("x-b3-traceid", "80f198ee56343ba864fe8b2a57d3eff7")
+		request.Header.Set("Authorization", "Bearer secret")
+		request.Header.Set("tracestate", "rojo=00f067aa0ba902b7")
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		expectations := map[string]string{
+			"Echo-X-B3-Traceid":  "",
+			"Echo-Authorization": "",
+			"Echo-Tracestate":    "rojo=00f067aa0ba902b7,vendor=service",
+		}
+
+		for k, expectation := range expectations {
+			if v := response.Header.Get(k); v != expectation {
+				t.Errorf("Header (%s) = %q\n    - Expectation = %q", k, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Transport-Without-Context", func(t *testing.T) {
+		response, e := client.Get(upstream.URL)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusNoContent)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := propagation.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", http.Header{"B3": {"0"}})
+		if v := propagation.Value(ctx); v.Get("B3") != "0" {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
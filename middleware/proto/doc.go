@@ -0,0 +1,10 @@
+// Package proto provides middleware that enforces HTTPS for configured routes, including behind TLS-terminating proxies.
+//
+// A request is considered secure when it's received over TLS, or when a trusted proxy reports "https" via the X-Forwarded-Proto
+// header (or the "proto" parameter of an RFC 7239 Forwarded header). Insecure requests are redirected to their HTTPS equivalent,
+// or rejected.
+//
+// Forwarding header(s) - Forwarded, X-Forwarded-Proto, and X-Forwarded-Host - are only honored when the connection's peer
+// address is a trusted proxy. Otherwise the header(s) are removed from the request, or the request is rejected, preventing clients
+// from spoofing the scheme or host observed by downstream handlers.
+package proto
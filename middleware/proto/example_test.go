@@ -0,0 +1,63 @@
+package proto_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/proto"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(proto.New().Settings(func(o *proto.Options) {
+		o.Proxies = []string{"127.0.0.1"} // The TLS-terminating proxy's address.
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Scheme: %s\n", proto.Value(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	for _, scheme := range []string{"https", "http"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("X-Forwarded-Proto", scheme)
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Scheme: https
+	// Status: 200
+	// Status: 308
+}
@@ -0,0 +1,231 @@
+package proto
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "proto"
+
+// headers represents the forwarding header(s) only honored from trusted proxies.
+var headers = []string{"Forwarded", "X-Forwarded-Proto", "X-Forwarded-Host"}
+
+// Options represents the configuration settings for the [Proto] middleware component.
+type Options struct {
+	// Match reports whether HTTPS is required for the request. A nil value requires HTTPS for every request. Defaults to nil.
+	Match func(r *http.Request) bool
+
+	// Proxies represents the trusted proxy IP addresses and CIDR ranges (e.g. "10.0.0.0/8") whose forwarding header(s) are
+	// honored. Defaults to an empty slice - no proxy is trusted.
+	Proxies []string
+
+	// Redirect enables redirecting insecure requests to their HTTPS equivalent with [http.StatusPermanentRedirect], which
+	// preserves the request's method and body. When disabled, insecure requests are rejected with [http.StatusForbidden].
+	// Defaults to true.
+	Redirect bool
+
+	// Reject enables rejecting, with [http.StatusBadRequest], requests carrying forwarding header(s) from untrusted peers. When
+	// disabled, the header(s) are removed from the request. Defaults to false.
+	Reject bool
+
+	// Level specifies the log level used to record insecure and spoofed requests. A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+}
+
+// Proto represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Proto struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Proto] middleware's [Options] and returns the updated middleware instance.
+func (p *Proto) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if p.options == nil {
+		p.options = &Options{
+			Match:    nil,
+			Proxies:  []string{},
+			Redirect: true,
+			Reject:   false,
+			Level:    nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(p.options)
+		}
+	}
+
+	return p
+}
+
+// prefixes parses the trusted proxy addresses and ranges, skipping invalid entries.
+func (p *Proto) prefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(p.options.Proxies))
+	for _, proxy := range p.options.Proxies {
+		if prefix, e := netip.ParsePrefix(proxy); e == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if address, e := netip.ParseAddr(proxy); e == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(address.Unmap(), address.Unmap().BitLen()))
+		} else {
+			slog.Warn("Invalid Proto Trusted Proxy Specified - Skipping Entry", slog.String("proxy", proxy))
+		}
+	}
+
+	return prefixes
+}
+
+// trusted reports whether the request's peer address is within a trusted proxy prefix.
+func trusted(prefixes []netip.Prefix, r *http.Request) bool {
+	host, _, e := net.SplitHostPort(r.RemoteAddr)
+	if e != nil {
+		host = r.RemoteAddr
+	}
+
+	address, e := netip.ParseAddr(host)
+	if e != nil {
+		return false
+	}
+
+	address = address.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scheme returns the request's effective scheme. Forwarding header(s) are only evaluated if the peer is trusted.
+func scheme(r *http.Request, trusted bool) string {
+	if r.TLS != nil {
+		return "https"
+	}
+
+	if trusted {
+		if v := r.Header.Get("X-Forwarded-Proto"); v != "" {
+			v, _, _ = strings.Cut(v, ",")
+
+			return strings.ToLower(strings.TrimSpace(v))
+		}
+
+		element, _, _ := strings.Cut(r.Header.Get("Forwarded"), ",")
+		for _, pair := range strings.Split(element, ";") {
+			if name, value, found := strings.Cut(strings.TrimSpace(pair), "="); found && strings.EqualFold(name, "proto") {
+				return strings.ToLower(strings.Trim(value, "\""))
+			}
+		}
+	}
+
+	return "http"
+}
+
+// Handler enforces HTTPS for matching requests, and guards forwarding header(s) against spoofing. It stores the request's effective
+// scheme in the request context, and forwards the request to the next handler in the chain.
+func (p *Proto) Handler(next http.Handler) http.Handler {
+	p.Settings() // Ensure the options field isn't nil.
+
+	prefixes := p.prefixes()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		proxied := trusted(prefixes, r)
+
+		if !(proxied) {
+			var spoofed bool
+			for _, header := range headers {
+				if _, found := r.Header[header]; found {
+					spoofed = true
+				}
+			}
+
+			if spoofed {
+				if v := p.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Forwarding Header(s) Received From Untrusted Peer", slog.String("remote-address", r.RemoteAddr), slog.Bool("rejected", p.options.Reject))
+				}
+
+				if p.options.Reject {
+					http.Error(w, "Forwarding Header(s) Not Permitted", http.StatusBadRequest)
+
+					return
+				}
+
+				// A handler mustn't modify the caller's request.
+				r = r.Clone(ctx)
+				for _, header := range headers {
+					r.Header.Del(header)
+				}
+			}
+		}
+
+		protocol := scheme(r, proxied)
+
+		if protocol != "https" && (p.options.Match == nil || p.options.Match(r)) {
+			if v := p.options.Level; v != nil {
+				slog.Log(ctx, v.Level(), "Insecure Request to HTTPS-Only Route", slog.String("url", r.URL.String()), slog.Bool("redirected", p.options.Redirect))
+			}
+
+			if !(p.options.Redirect) {
+				http.Error(w, "HTTPS Required", http.StatusForbidden)
+
+				return
+			}
+
+			host := r.Host
+			if v := r.Header.Get("X-Forwarded-Host"); proxied && v != "" {
+				host, _, _ = strings.Cut(v, ",")
+
+				host = strings.TrimSpace(host)
+			}
+
+			http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+
+			return
+		}
+
+		ctx = context.WithValue(ctx, key, protocol)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Proto] middleware, implementing [middleware.Configurable]. If [Proto.Settings] isn't called,
+// then the [Proto.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Proto)
+}
+
+// Value retrieves the request's effective scheme ("http" or "https") from the provided context. If an empty string is returned,
+// it can be assumed that the [Proto] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (scheme string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		scheme = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		scheme = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Proto] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Proto)(nil)
@@ -0,0 +1,104 @@
+package proto_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/proto"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Scheme", proto.Value(r.Context()))
+		w.Header().Set("X-Observed-Forwarded-Proto", r.Header.Get("X-Forwarded-Proto"))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		options  func(o *proto.Options)
+		remote   string
+		tls      bool
+		header   http.Header
+		status   int
+		location string
+		scheme   string
+		observed string
+	}{
+		{name: "TLS", remote: "192.0.2.1:1234", tls: true, status: http.StatusOK, scheme: "https"},
+		{name: "Insecure-Redirect", remote: "192.0.2.1:1234", status: http.StatusPermanentRedirect, location: "https://service.example/path?q=1"},
+		{name: "Insecure-Reject", options: func(o *proto.Options) { o.Redirect = false }, remote: "192.0.2.1:1234", status: http.StatusForbidden},
+		{name: "Trusted-Proxy", remote: "10.1.2.3:1234", header: http.Header{"X-Forwarded-Proto": {"https"}}, status: http.StatusOK, scheme: "https", observed: "https"},
+		{name: "Trusted-Proxy-Forwarded", remote: "10.1.2.3:1234", header: http.Header{"Forwarded": {"for=192.0.2.1;proto=https"}}, status: http.StatusOK, scheme: "https"},
+		{name: "Trusted-Proxy-Host", remote: "10.1.2.3:1234", header: http.Header{"X-Forwarded-Proto": {"http"}, "X-Forwarded-Host": {"public.example"}}, status: http.StatusPermanentRedirect, location: "https://public.example/path?q=1"},
+		{name: "Trusted-Proxy-Address", remote: "[::ffff:172.16.0.9]:1234", header: http.Header{"X-Forwarded-Proto": {"https"}}, status: http.StatusOK, scheme: "https", observed: "https"},
+		{name: "Untrusted-Spoof-Stripped", remote: "192.0.2.1:1234", tls: true, header: http.Header{"X-Forwarded-Proto": {"http"}}, status: http.StatusOK, scheme: "https", observed: ""},
+		{name: "Untrusted-Spoof-Insecure", remote: "192.0.2.1:1234", header: http.Header{"X-Forwarded-Proto": {"https"}}, status: http.StatusPermanentRedirect, location: "https://service.example/path?q=1"},
+		{name: "Untrusted-Spoof-Rejected", options: func(o *proto.Options) { o.Reject = true }, remote: "192.0.2.1:1234", header: http.Header{"X-Forwarded-Host": {"evil.example"}}, status: http.StatusBadRequest},
+		{name: "Unmatched-Route", options: func(o *proto.Options) {
+			o.Match = func(r *http.Request) bool { return strings.HasPrefix(r.URL.Path, "/admin") }
+		}, remote: "192.0.2.1:1234", status: http.StatusOK, scheme: "http"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := proto.New().Settings(func(o *proto.Options) {
+				o.Proxies = []string{"10.0.0.0/8", "172.16.0.9", "invalid"}
+			}, test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodPost, "http://service.example/path?q=1", nil)
+			request.RemoteAddr = test.remote
+
+			if test.tls {
+				request.TLS = &tls.ConnectionState{}
+			}
+
+			for k, v := range test.header {
+				request.Header[k] = v
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("Location"); v != test.location {
+				t.Errorf("Location = %s\n    - Expectation = %s", v, test.location)
+			}
+
+			if v := recorder.Header().Get("X-Scheme"); v != test.scheme {
+				t.Errorf("Scheme = %s\n    - Expectation = %s", v, test.scheme)
+			}
+
+			if v := recorder.Header().Get("X-Observed-Forwarded-Proto"); v != test.observed {
+				t.Errorf("Observed X-Forwarded-Proto = %s\n    - Expectation = %s", v, test.observed)
+			}
+
+			// The caller's request mustn't be modified.
+			for k, v := range test.header {
+				if request.Header.Get(k) != v[0] {
+					t.Errorf("Unexpected Modification of the Caller's Request Header (%s)", k)
+				}
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := proto.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "https")
+		if v := proto.Value(ctx); v != "https" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
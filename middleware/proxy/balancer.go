@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"net/http"
+	"sync/atomic"
+)
+
+// Balancer represents a pluggable load balancing strategy, selecting an upstream among a route's available upstreams.
+type Balancer interface {
+	// Select returns the request's upstream. The upstreams are available, and never empty.
+	Select(r *http.Request, upstreams []*Upstream) *Upstream
+}
+
+// BalancerFunc is an adapter allowing the use of ordinary functions as a [Balancer].
+type BalancerFunc func(r *http.Request, upstreams []*Upstream) *Upstream
+
+// Select calls f(r, upstreams).
+func (f BalancerFunc) Select(r *http.Request, upstreams []*Upstream) *Upstream {
+	return f(r, upstreams)
+}
+
+// roundrobin selects upstreams in turn.
+type roundrobin struct {
+	counter atomic.Uint64
+}
+
+func (b *roundrobin) Select(_ *http.Request, upstreams []*Upstream) *Upstream {
+	if len(upstreams) == 0 {
+		return nil
+	}
+
+	return upstreams[(b.counter.Add(1)-1)%uint64(len(upstreams))]
+}
+
+// RoundRobin returns a [Balancer] selecting upstreams in turn. Each route requires its own instance.
+func RoundRobin() Balancer {
+	return new(roundrobin)
+}
+
+// lowest returns the upstreams of the lowest cost. Each upstream's cost is evaluated once, as concurrent requests change in-flight
+// counts, and latencies, between evaluations.
+func lowest(upstreams []*Upstream, cost func(upstream *Upstream) int64) []*Upstream {
+	costs := make([]int64, len(upstreams))
+	for index, upstream := range upstreams {
+		costs[index] = cost(upstream)
+	}
+
+	least := costs[0]
+	for _, cost := range costs[1:] {
+		least = min(least, cost)
+	}
+
+	candidates := make([]*Upstream, 0, len(upstreams))
+	for index, upstream := range upstreams {
+		if costs[index] == least {
+			candidates = append(candidates, upstream)
+		}
+	}
+
+	return candidates
+}
+
+// LeastConnections returns a [Balancer] selecting the upstream with the fewest in-flight requests; ties resolve in turn.
+func LeastConnections() Balancer {
+	tiebreaker := new(roundrobin)
+
+	return BalancerFunc(func(r *http.Request, upstreams []*Upstream) *Upstream {
+		return tiebreaker.Select(r, lowest(upstreams, (*Upstream).Active))
+	})
+}
+
+// EWMA returns a [Balancer] selecting the upstream with the lowest latency EWMA, weighted by its in-flight requests. Upstreams
+// without an observed latency are preferred, such that new upstreams are probed; ties resolve in turn.
+func EWMA() Balancer {
+	tiebreaker := new(roundrobin)
+
+	return BalancerFunc(func(r *http.Request, upstreams []*Upstream) *Upstream {
+		cost := func(upstream *Upstream) int64 {
+			return int64(upstream.Latency()) * (upstream.Active() + 1)
+		}
+
+		return tiebreaker.Select(r, lowest(upstreams, cost))
+	})
+}
+
+// Hash returns a [Balancer] selecting each key's upstream by rendezvous (highest random weight) hashing - e.g. keyed by the affinity
+// middleware's key - such that a key's requests reach the same upstream while it's available. Requests without a key are balanced
+// in turn.
+func Hash(key func(r *http.Request) string) Balancer {
+	fallback := new(roundrobin)
+
+	return BalancerFunc(func(r *http.Request, upstreams []*Upstream) *Upstream {
+		k := ""
+		if key != nil {
+			k = key(r)
+		}
+
+		if k == "" {
+			return fallback.Select(r, upstreams)
+		}
+
+		var selection *Upstream
+		var weight uint64
+		for _, upstream := range upstreams {
+			hash := sha256.Sum256([]byte(k + "\x00" + upstream.URL.String()))
+			if v := binary.BigEndian.Uint64(hash[:8]); selection == nil || v > weight {
+				selection, weight = upstream, v
+			}
+		}
+
+		return selection
+	})
+}
@@ -0,0 +1,13 @@
+// Package proxy provides reverse-proxying middleware, balancing each route's requests among multiple upstreams.
+//
+// Requests matching a route's path prefix are proxied to one of its available upstreams, as selected by the route's [Balancer]:
+// [RoundRobin], [LeastConnections], [EWMA] latency, [Hash] (e.g. keyed by the affinity middleware's key), or a custom [BalancerFunc].
+//
+// Upstream availability combines active health checks - periodic GET requests to [Check.Path], requiring [Check.Rise] consecutive
+// successes to recover, and [Check.Fall] consecutive failures to fail - with passive failure detection: [Options.Failures]
+// consecutive transport errors, or gateway error statuses, eject an upstream for [Options.Ejection]. Per-upstream statistics are
+// available via [Proxy.Metrics].
+//
+// Each route may declare a [Rewrite]: path prefix stripping and adding, regular expression path rewrites, request and response
+// [Headers] renames, removals, and additions, and a Host override. Routes, and their rewrites, are hot-reloadable via [Proxy.Reload].
+package proxy
@@ -0,0 +1,72 @@
+package proxy_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/proxy"
+)
+
+func Example() {
+	chain := middleware.New()
+
+	upstreams := make([]string, 0, 2)
+	for _, name := range []string{"a", "b"} {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Printf("Upstream %s: %s\n", name, r.URL.Path)
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		defer upstream.Close()
+
+		upstreams = append(upstreams, upstream.URL)
+	}
+
+	instance := proxy.New()
+
+	defer instance.Close()
+
+	chain.Add(instance.Settings(func(o *proxy.Options) {
+		o.Routes = []proxy.Route{{Prefix: "/api/", Upstreams: upstreams, Balancer: proxy.RoundRobin()}}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Local: %s\n", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(chain.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/api/users", "/api/orders", "/index.html"} {
+		response, e := client.Get(server.URL + path)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+	}
+
+	for _, metrics := range instance.Metrics() {
+		fmt.Printf("Route: %s, Requests: %d, Available: %t\n", metrics.Route, metrics.Requests, metrics.Available)
+	}
+
+	// Output:
+	// Upstream a: /api/users
+	// Upstream b: /api/orders
+	// Local: /index.html
+	// Route: /api/, Requests: 1, Available: true
+	// Route: /api/, Requests: 1, Available: true
+}
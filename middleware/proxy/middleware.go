@@ -0,0 +1,505 @@
+package proxy
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "proxy"
+
+// Valuer is the context return type relating to the [Proxy] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Route represents the matched route's prefix.
+	Route string `json:"route"`
+
+	// Upstream represents the selected upstream's base URL.
+	Upstream string `json:"upstream"`
+}
+
+// Route represents a proxied path prefix, and its upstreams.
+type Route struct {
+	// Prefix represents the route's URL path prefix (e.g. "/api/"). Requests match the longest matching prefix.
+	Prefix string
+
+	// Upstreams represents the route's upstream base URLs (e.g. "http://10.0.0.1:8080").
+	Upstreams []string
+
+	// Balancer represents the route's load balancing strategy. Defaults to [RoundRobin].
+	Balancer Balancer
+
+	// Rewrite represents the route's request, and response, transformations. A nil value proxies requests unmodified.
+	Rewrite *Rewrite
+}
+
+// Check represents the active health checking configuration.
+type Check struct {
+	// Path represents the upstreams' health check path, requested via GET; 2xx responses are healthy. An empty value disables active
+	// health checks, considering every upstream healthy.
+	Path string
+
+	// Interval represents the duration between health checks.
+	Interval time.Duration
+
+	// Timeout represents each health check's timeout.
+	Timeout time.Duration
+
+	// Rise represents the number of consecutive successful health checks marking an unhealthy upstream healthy.
+	Rise int
+
+	// Fall represents the number of consecutive failed health checks marking a healthy upstream unhealthy.
+	Fall int
+}
+
+// Options represents the configuration settings for the [Proxy] middleware component.
+type Options struct {
+	// Routes represents the proxied routes. Requests matching no route are forwarded to the next handler. Routes can be replaced at
+	// runtime via [Proxy.Reload]. Defaults to an empty slice.
+	Routes []Route
+
+	// Transport represents the proxied requests', and health checks', transport. Defaults to [http.DefaultTransport].
+	Transport http.RoundTripper
+
+	// Check represents the active health checking configuration. Defaults to a disabled [Check], with a 10 second interval, a 2
+	// second timeout, a rise of 2, and a fall of 3.
+	Check Check
+
+	// Failures represents the number of consecutive failed requests - transport errors, and gateway error statuses - ejecting an
+	// upstream for the [Options.Ejection] duration. A value of zero disables passive failure detection. Defaults to 5.
+	Failures int
+
+	// Ejection represents the duration an upstream is ejected for, following [Options.Failures] consecutive failed requests.
+	// Defaults to 30 seconds.
+	Ejection time.Duration
+}
+
+// pool represents a route's upstreams.
+type pool struct {
+	prefix    string
+	upstreams []*Upstream
+	balancer  Balancer
+	rewrite   *Rewrite
+}
+
+// Proxy represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Proxy struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex sync.Mutex
+	pools []*pool
+
+	start sync.Once
+	stop  sync.Once
+	done  chan struct{}
+}
+
+// Settings applies configuration functions to modify the [Proxy] middleware's [Options] and returns the updated middleware instance.
+func (p *Proxy) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if p.options == nil {
+		p.options = &Options{
+			Routes:    []Route{},
+			Transport: http.DefaultTransport,
+			Check: Check{
+				Path:     "",
+				Interval: 10 * time.Second,
+				Timeout:  2 * time.Second,
+				Rise:     2,
+				Fall:     3,
+			},
+			Failures: 5,
+			Ejection: 30 * time.Second,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(p.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if p.options.Transport == nil {
+		slog.Warn("Invalid Proxy Transport Specified - Using Default Transport")
+
+		p.options.Transport = http.DefaultTransport
+	}
+
+	if p.options.Check.Interval <= 0 {
+		slog.Warn("Invalid Proxy Check Interval Specified - Using Default Interval")
+
+		p.options.Check.Interval = 10 * time.Second
+	}
+
+	if p.options.Check.Timeout <= 0 {
+		slog.Warn("Invalid Proxy Check Timeout Specified - Using Default Timeout")
+
+		p.options.Check.Timeout = 2 * time.Second
+	}
+
+	if p.options.Check.Rise <= 0 {
+		slog.Warn("Invalid Proxy Check Rise Specified - Using Default Rise")
+
+		p.options.Check.Rise = 2
+	}
+
+	if p.options.Check.Fall <= 0 {
+		slog.Warn("Invalid Proxy Check Fall Specified - Using Default Fall")
+
+		p.options.Check.Fall = 3
+	}
+
+	if p.options.Failures < 0 {
+		slog.Warn("Invalid Proxy Failures Specified - Using Default Failures")
+
+		p.options.Failures = 5
+	}
+
+	if p.options.Ejection <= 0 {
+		slog.Warn("Invalid Proxy Ejection Specified - Using Default Ejection")
+
+		p.options.Ejection = 30 * time.Second
+	}
+
+	return p
+}
+
+// upstream returns the upstream of the base URL, reverse-proxying requests to it.
+func (p *Proxy) upstream(target *url.URL) *Upstream {
+	u := &Upstream{URL: target, healthy: true}
+
+	u.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			rewrite, _ := r.In.Context().Value(rules).(*Rewrite)
+			if rewrite != nil {
+				rewrite.request(r)
+			}
+
+			r.SetURL(target)
+			r.SetXForwarded()
+
+			if rewrite != nil {
+				rewrite.headers(r)
+			}
+		},
+		ModifyResponse: func(response *http.Response) error {
+			if rewrite, _ := response.Request.Context().Value(rules).(*Rewrite); rewrite != nil {
+				rewrite.Response.apply(response.Header)
+			}
+
+			return nil
+		},
+		Transport: p.options.Transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, e error) {
+			slog.WarnContext(r.Context(), "Unable to Proxy Request", slog.String("upstream", target.String()), slog.String("error", e.Error()))
+
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	return u
+}
+
+// build constructs the routes' pools, ordered by descending prefix length. Upstreams of the current pools are reused, by route
+// prefix and URL, such that their health and load statistics persist across a [Proxy.Reload].
+func (p *Proxy) build(routes []Route) []*pool {
+	p.mutex.Lock()
+	existing := make(map[string]*Upstream)
+	for _, v := range p.pools {
+		for _, u := range v.upstreams {
+			existing[v.prefix+"\x00"+u.URL.String()] = u
+		}
+	}
+	p.mutex.Unlock()
+
+	pools := make([]*pool, 0, len(routes))
+	for _, route := range routes {
+		if !(strings.HasPrefix(route.Prefix, "/")) {
+			slog.Warn("Invalid Proxy Route Prefix Specified - Ignoring Route", slog.String("prefix", route.Prefix))
+
+			continue
+		}
+
+		v := &pool{prefix: route.Prefix, balancer: route.Balancer, rewrite: route.Rewrite}
+		if v.balancer == nil {
+			v.balancer = RoundRobin()
+		}
+
+		for _, upstream := range route.Upstreams {
+			target, e := url.Parse(upstream)
+			if e != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+				slog.Warn("Invalid Proxy Upstream Specified - Ignoring Upstream", slog.String("prefix", route.Prefix), slog.String("upstream", upstream))
+
+				continue
+			}
+
+			if u, found := existing[route.Prefix+"\x00"+target.String()]; found {
+				v.upstreams = append(v.upstreams, u)
+
+				continue
+			}
+
+			v.upstreams = append(v.upstreams, p.upstream(target))
+		}
+
+		if len(v.upstreams) == 0 {
+			slog.Warn("Proxy Route Without Valid Upstreams - Ignoring Route", slog.String("prefix", route.Prefix))
+
+			continue
+		}
+
+		pools = append(pools, v)
+	}
+
+	sort.SliceStable(pools, func(i, j int) bool { return len(pools[i].prefix) > len(pools[j].prefix) })
+
+	return pools
+}
+
+// match returns the request's route pool, or nil.
+func (p *Proxy) match(path string) *pool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, v := range p.pools {
+		if strings.HasPrefix(path, v.prefix) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// ping performs a single health check of the upstream.
+func (p *Proxy) ping(ctx context.Context, u *Upstream) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.options.Check.Timeout)
+	defer cancel()
+
+	request, e := http.NewRequestWithContext(ctx, http.MethodGet, u.URL.JoinPath(p.options.Check.Path).String(), nil)
+	if e != nil {
+		return false
+	}
+
+	response, e := p.options.Transport.RoundTrip(request)
+	if e != nil {
+		return false
+	}
+
+	response.Body.Close()
+
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
+
+// Probe performs a single round of active health checks, concurrently, updating the upstreams' health states.
+func (p *Proxy) Probe(ctx context.Context) {
+	p.mutex.Lock()
+	pools := p.pools
+	p.mutex.Unlock()
+
+	var group sync.WaitGroup
+	for _, v := range pools {
+		for _, u := range v.upstreams {
+			group.Add(1)
+
+			go func(prefix string, u *Upstream) {
+				defer group.Done()
+
+				healthy := p.ping(ctx, u)
+				if u.check(healthy, p.options.Check.Rise, p.options.Check.Fall) {
+					slog.InfoContext(ctx, "Proxy Upstream Health Changed", slog.String("route", prefix), slog.String("upstream", u.URL.String()), slog.Bool("healthy", healthy))
+				}
+			}(v.prefix, u)
+		}
+	}
+
+	group.Wait()
+}
+
+// loop periodically performs health checks until [Proxy.Close] is called.
+func (p *Proxy) loop(done <-chan struct{}) {
+	ticker := time.NewTicker(p.options.Check.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			p.Probe(context.Background())
+		}
+	}
+}
+
+// channel returns the instance's lazily-initialized done channel.
+func (p *Proxy) channel() chan struct{} {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.done == nil {
+		p.done = make(chan struct{})
+	}
+
+	return p.done
+}
+
+// Close stops active health checking.
+func (p *Proxy) Close() {
+	done := p.channel()
+
+	p.stop.Do(func() { close(done) })
+}
+
+// Metrics returns a snapshot of every upstream's statistics - e.g. for a health, or metrics, endpoint.
+func (p *Proxy) Metrics() []Metrics {
+	p.mutex.Lock()
+	pools := p.pools
+	p.mutex.Unlock()
+
+	now := time.Now()
+
+	var metrics []Metrics
+	for _, v := range pools {
+		for _, u := range v.upstreams {
+			metrics = append(metrics, Metrics{
+				Route:     v.prefix,
+				Upstream:  u.URL.String(),
+				Available: u.Available(now),
+				Active:    u.Active(),
+				Requests:  u.requests.Load(),
+				Failures:  u.failures.Load(),
+				Latency:   u.Latency(),
+			})
+		}
+	}
+
+	return metrics
+}
+
+// Reload atomically replaces the proxied routes - e.g. following a configuration file change. In-flight requests complete against
+// their previously matched route, and unchanged upstreams retain their health and load statistics.
+func (p *Proxy) Reload(routes []Route) {
+	p.Settings() // Ensure the options field isn't nil.
+
+	pools := p.build(routes)
+
+	p.mutex.Lock()
+	p.options.Routes = routes
+	p.pools = pools
+	p.mutex.Unlock()
+}
+
+// Handler reverse-proxies requests matching an [Options.Routes] prefix to one of the route's available upstreams, selected by the
+// route's [Balancer]. Requests for a route without an available upstream receive a [http.StatusServiceUnavailable] response; other
+// requests are forwarded to the next handler.
+func (p *Proxy) Handler(next http.Handler) http.Handler {
+	p.Settings() // Ensure the options field isn't nil.
+
+	p.Reload(p.options.Routes)
+
+	if p.options.Check.Path != "" {
+		p.start.Do(func() {
+			go p.loop(p.channel())
+		})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		route := p.match(r.URL.Path)
+		if route == nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		now := time.Now()
+
+		available := make([]*Upstream, 0, len(route.upstreams))
+		for _, u := range route.upstreams {
+			if u.Available(now) {
+				available = append(available, u)
+			}
+		}
+
+		if len(available) == 0 {
+			reject.Record(ctx, reject.Reason{Subsystem: "proxy", Code: "no-available-upstream", Status: http.StatusServiceUnavailable})
+
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		u := route.balancer.Select(r, available)
+		if u == nil {
+			u = available[0]
+		}
+
+		ctx = context.WithValue(ctx, key, &Valuer{Route: route.prefix, Upstream: u.URL.String()})
+		if route.rewrite != nil {
+			ctx = context.WithValue(ctx, rules, route.rewrite)
+		}
+
+		recorder := middleware.Wrap(w)
+
+		u.active.Add(1)
+
+		// The reverse proxy panics with [http.ErrAbortHandler] when copying the upstream's response fails; the in-flight count, and
+		// the outcome, are recorded regardless - aborted requests count as failures - before re-panicking.
+		defer func() {
+			u.active.Add(-1)
+
+			aborted := recover()
+
+			status := recorder.Status()
+			failed := aborted != nil || status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+
+			if u.observe(time.Since(now), failed, p.options.Failures, p.options.Ejection, time.Now()) {
+				slog.WarnContext(ctx, "Proxy Upstream Ejected", slog.String("route", route.prefix), slog.String("upstream", u.URL.String()), slog.Duration("duration", p.options.Ejection))
+			}
+
+			if aborted != nil {
+				panic(aborted)
+			}
+		}()
+
+		u.proxy.ServeHTTP(recorder, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Proxy] middleware, implementing [middleware.Configurable]. If [Proxy.Settings] isn't called,
+// then the [Proxy.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to call [Proxy.Metrics], [Proxy.Reload], or [Proxy.Close].
+func New() *Proxy {
+	return new(Proxy)
+}
+
+// Value retrieves the request's proxy [Valuer] from the provided context. If a nil value is returned, it can be assumed that the
+// request wasn't proxied by the [Proxy] middleware.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Proxy] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Proxy)(nil)
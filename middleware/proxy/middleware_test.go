@@ -0,0 +1,462 @@
+package proxy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/proxy"
+)
+
+// backend represents a test upstream, counting its requests.
+type backend struct {
+	*httptest.Server
+
+	requests atomic.Int64
+	healthy  atomic.Bool
+	failing  atomic.Bool
+}
+
+func start(t *testing.T, name string) *backend {
+	b := new(backend)
+	b.healthy.Store(true)
+
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			if !(b.healthy.Load()) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+
+			return
+		}
+
+		b.requests.Add(1)
+
+		if b.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("X-Backend", name)
+		w.Header().Set("X-Forwarded-Path", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(b.Close)
+
+	return b
+}
+
+func Test(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Backend", "next")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return recorder
+	}
+
+	t.Run("Routing", func(t *testing.T) {
+		api, admin := start(t, "api"), start(t, "admin")
+
+		h := proxy.New().Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{
+				{Prefix: "/api/", Upstreams: []string{api.URL}},
+				{Prefix: "/api/admin/", Upstreams: []string{admin.URL}},
+				{Prefix: "invalid", Upstreams: []string{api.URL}},
+				{Prefix: "/empty/", Upstreams: []string{"ftp://example.com"}},
+			}
+		}).Handler(next)
+
+		for path, expectation := range map[string]string{"/api/users": "api", "/api/admin/users": "admin", "/other": "next", "/empty/": "next"} {
+			recorder := serve(h, path)
+			if v := recorder.Header().Get("X-Backend"); v != expectation {
+				t.Errorf("X-Backend (%s) = %s\n    - Expectation = %s", path, v, expectation)
+			}
+		}
+
+		if v := serve(h, "/api/users").Header().Get("X-Forwarded-Path"); v != "/api/users" {
+			t.Errorf("Path = %s\n    - Expectation = %s", v, "/api/users")
+		}
+	})
+
+	t.Run("Round-Robin", func(t *testing.T) {
+		a, b := start(t, "a"), start(t, "b")
+
+		h := proxy.New().Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL, b.URL}}}
+		}).Handler(next)
+
+		for range 10 {
+			serve(h, "/")
+		}
+
+		if a.requests.Load() != 5 || b.requests.Load() != 5 {
+			t.Errorf("Requests = %d, %d\n    - Expectation = %d, %d", a.requests.Load(), b.requests.Load(), 5, 5)
+		}
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		a, b := start(t, "a"), start(t, "b")
+
+		h := proxy.New().Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL, b.URL}, Balancer: proxy.Hash(func(r *http.Request) string { return "client" })}}
+		}).Handler(next)
+
+		for range 10 {
+			serve(h, "/")
+		}
+
+		if total := a.requests.Load() + b.requests.Load(); a.requests.Load() != total && b.requests.Load() != total {
+			t.Errorf("Requests = %d, %d\n    - Expectation = %s", a.requests.Load(), b.requests.Load(), "a single upstream")
+		}
+	})
+
+	t.Run("Passive-Failure-Detection", func(t *testing.T) {
+		a, b := start(t, "a"), start(t, "b")
+		a.failing.Store(true)
+
+		instance := proxy.New()
+
+		h := instance.Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL, b.URL}}}
+			o.Failures = 2
+			o.Ejection = time.Minute
+		}).Handler(next)
+
+		for range 10 {
+			serve(h, "/")
+		}
+
+		// Round-robin alternates until the failing upstream's second consecutive failure ejects it.
+		if a.requests.Load() != 2 || b.requests.Load() != 8 {
+			t.Errorf("Requests = %d, %d\n    - Expectation = %d, %d", a.requests.Load(), b.requests.Load(), 2, 8)
+		}
+
+		metrics := instance.Metrics()
+		if len(metrics) != 2 || metrics[0].Available || metrics[0].Failures != 2 || !(metrics[1].Available) || metrics[1].Requests != 8 {
+			t.Errorf("Metrics = %+v\n    - Expectation = %s", metrics, "an ejected, failing upstream, and an available upstream")
+		}
+	})
+
+	t.Run("Active-Health-Checks", func(t *testing.T) {
+		a, b := start(t, "a"), start(t, "b")
+
+		instance := proxy.New()
+
+		h := instance.Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL, b.URL}}}
+			o.Check = proxy.Check{Path: "/healthz", Interval: time.Hour, Timeout: time.Second, Rise: 2, Fall: 1}
+		}).Handler(next)
+
+		defer instance.Close()
+
+		a.healthy.Store(false)
+		instance.Probe(context.Background())
+
+		for range 4 {
+			if v := serve(h, "/").Header().Get("X-Backend"); v != "b" {
+				t.Fatalf("X-Backend = %s\n    - Expectation = %s", v, "b")
+			}
+		}
+
+		a.healthy.Store(true)
+		instance.Probe(context.Background())
+
+		if metrics := instance.Metrics(); metrics[0].Available {
+			t.Errorf("Available = %t\n    - Expectation = %t (a single success is below the rise)", metrics[0].Available, false)
+		}
+
+		instance.Probe(context.Background())
+
+		if metrics := instance.Metrics(); !(metrics[0].Available) {
+			t.Errorf("Available = %t\n    - Expectation = %t", metrics[0].Available, true)
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		a := start(t, "a")
+		a.healthy.Store(false)
+
+		instance := proxy.New()
+
+		h := instance.Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL}}}
+			o.Check = proxy.Check{Path: "/healthz", Interval: time.Hour, Timeout: time.Second, Rise: 1, Fall: 1}
+		}).Handler(next)
+
+		defer instance.Close()
+
+		instance.Probe(context.Background())
+
+		if recorder := serve(h, "/"); recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("Aborted", func(t *testing.T) {
+		instance := proxy.New()
+
+		h := instance.Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{"http://10.0.0.1:8080"}}}
+			o.Transport = roundtripper(func(r *http.Request) (*http.Response, error) {
+				body := io.NopCloser(iotest.ErrReader(errors.New("connection reset")))
+
+				return &http.Response{StatusCode: http.StatusOK, Body: body, Header: http.Header{}, Request: r}, nil
+			})
+		}).Handler(next)
+
+		// The reverse proxy only aborts requests served by an [http.Server].
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request = request.WithContext(context.WithValue(request.Context(), http.ServerContextKey, &http.Server{}))
+
+		func() {
+			defer func() {
+				if v := recover(); v != http.ErrAbortHandler {
+					t.Errorf("Panic = %v\n    - Expectation = %v", v, http.ErrAbortHandler)
+				}
+			}()
+
+			h.ServeHTTP(httptest.NewRecorder(), request)
+		}()
+
+		if metrics := instance.Metrics(); metrics[0].Active != 0 || metrics[0].Failures != 1 {
+			t.Errorf("Active, Failures = %d, %d\n    - Expectation = %d, %d", metrics[0].Active, metrics[0].Failures, 0, 1)
+		}
+	})
+
+	t.Run("Transport-Error", func(t *testing.T) {
+		a := start(t, "a")
+		a.Close()
+
+		h := proxy.New().Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL}}}
+		}).Handler(next)
+
+		if recorder := serve(h, "/"); recorder.Code != http.StatusBadGateway {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusBadGateway)
+		}
+	})
+
+	t.Run("Rewrite", func(t *testing.T) {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Forwarded-Path", r.URL.Path)
+			w.Header().Set("X-Forwarded-Host", r.Host)
+			w.Header().Set("X-Forwarded-Tenant", r.Header.Get("X-Tenant"))
+			w.Header().Set("X-Forwarded-Cookie", r.Header.Get("Cookie"))
+			w.Header().Set("X-Forwarded-Version", r.Header.Get("X-Version"))
+			w.Header().Set("Server", "upstream")
+			w.Header().Set("X-Internal", "true")
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		t.Cleanup(upstream.Close)
+
+		h := proxy.New().Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{
+				{Prefix: "/", Upstreams: []string{upstream.URL}},
+				{Prefix: "/api/", Upstreams: []string{upstream.URL}, Rewrite: &proxy.Rewrite{
+					Strip:       "/api",
+					Pattern:     regexp.MustCompile(`^/users/(?P<id>[0-9]+)$`),
+					Replacement: "/accounts/${id}",
+					Prefix:      "/v2/",
+					Host:        "internal.example.com",
+					Request: proxy.Headers{
+						Rename: map[string]string{"X-Organization": "X-Tenant"},
+						Remove: []string{"Cookie"},
+						Add:    map[string]string{"X-Version": "2"},
+					},
+					Response: proxy.Headers{
+						Rename: map[string]string{"X-Internal": "X-Upstream-Internal"},
+						Remove: []string{"Server"},
+						Add:    map[string]string{"X-Proxied": "true"},
+					},
+				}},
+			}
+		}).Handler(next)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/users/42", nil)
+		request.Header.Set("X-Organization", "acme")
+		request.Header.Set("Cookie", "session=secret")
+
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, request)
+
+		for name, expectation := range map[string]string{
+			"X-Forwarded-Path":    "/v2/accounts/42",
+			"X-Forwarded-Host":    "internal.example.com",
+			"X-Forwarded-Tenant":  "acme",
+			"X-Forwarded-Cookie":  "",
+			"X-Forwarded-Version": "2",
+			"Server":              "",
+			"X-Internal":          "",
+			"X-Upstream-Internal": "true",
+			"X-Proxied":           "true",
+		} {
+			if v := recorder.Header().Get(name); v != expectation {
+				t.Errorf("%s = %s\n    - Expectation = %s", name, v, expectation)
+			}
+		}
+
+		if v := serve(h, "/users/42").Header().Get("X-Forwarded-Path"); v != "/users/42" {
+			t.Errorf("Path (Unmatched Route) = %s\n    - Expectation = %s", v, "/users/42")
+		}
+
+		if v := serve(h, "/api").Header().Get("X-Forwarded-Path"); v != "/api" {
+			t.Errorf("Path (Unmatched Prefix) = %s\n    - Expectation = %s", v, "/api")
+		}
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		a, b := start(t, "a"), start(t, "b")
+
+		instance := proxy.New()
+
+		h := instance.Settings(func(o *proxy.Options) {
+			o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{a.URL}}}
+		}).Handler(next)
+
+		serve(h, "/users")
+
+		instance.Reload([]proxy.Route{
+			{Prefix: "/", Upstreams: []string{a.URL}},
+			{Prefix: "/api/", Upstreams: []string{b.URL}, Rewrite: &proxy.Rewrite{Strip: "/api"}},
+		})
+
+		recorder := serve(h, "/api/users")
+		if v := recorder.Header().Get("X-Backend"); v != "b" {
+			t.Errorf("X-Backend = %s\n    - Expectation = %s", v, "b")
+		}
+
+		if v := recorder.Header().Get("X-Forwarded-Path"); v != "/users" {
+			t.Errorf("Path = %s\n    - Expectation = %s", v, "/users")
+		}
+
+		for _, metrics := range instance.Metrics() {
+			if metrics.Upstream == a.URL && metrics.Requests != 1 {
+				t.Errorf("Requests (Retained) = %d\n    - Expectation = %d", metrics.Requests, 1)
+			}
+		}
+
+		instance.Reload(nil)
+
+		if v := serve(h, "/api/users").Header().Get("X-Backend"); v != "next" {
+			t.Errorf("X-Backend = %s\n    - Expectation = %s", v, "next")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := proxy.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+
+	t.Run("Balancers", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		upstreams := func(n int) []*proxy.Upstream {
+			var v []*proxy.Upstream
+
+			routes := make([]string, 0, n)
+			for index := range n {
+				routes = append(routes, fmt.Sprintf("http://10.0.0.%d:8080", index+1))
+			}
+
+			// Upstreams are only exposed to balancers; capture them through a balancer function.
+			capture := proxy.BalancerFunc(func(r *http.Request, upstreams []*proxy.Upstream) *proxy.Upstream {
+				v = upstreams
+
+				return upstreams[0]
+			})
+
+			p := proxy.New()
+			p.Settings(func(o *proxy.Options) {
+				o.Routes = []proxy.Route{{Prefix: "/", Upstreams: routes, Balancer: capture}}
+				o.Transport = roundtripper(func(r *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}, Request: r}, nil
+				})
+			}).Handler(nil).ServeHTTP(httptest.NewRecorder(), request)
+
+			return v
+		}
+
+		t.Run("Least-Connections", func(t *testing.T) {
+			v := upstreams(3)
+
+			balancer := proxy.LeastConnections()
+
+			seen := make(map[*proxy.Upstream]bool)
+			for range 3 {
+				seen[balancer.Select(request, v)] = true
+			}
+
+			if len(seen) != 3 {
+				t.Errorf("Selections = %d\n    - Expectation = %d (ties resolve in turn)", len(seen), 3)
+			}
+		})
+
+		t.Run("EWMA", func(t *testing.T) {
+			v := upstreams(2)
+
+			// The first upstream's latency was observed by the capturing request; the unobserved upstream is preferred.
+			if selection := proxy.EWMA().Select(request, v); selection != v[1] {
+				t.Errorf("Selection = %s\n    - Expectation = %s", selection.URL, v[1].URL)
+			}
+		})
+
+		t.Run("Concurrent", func(t *testing.T) {
+			v := upstreams(3)
+
+			for name, balancer := range map[string]proxy.Balancer{"Least-Connections": proxy.LeastConnections(), "EWMA": proxy.EWMA()} {
+				h := proxy.New().Settings(func(o *proxy.Options) {
+					o.Routes = []proxy.Route{{Prefix: "/", Upstreams: []string{v[0].URL.String(), v[1].URL.String(), v[2].URL.String()}, Balancer: balancer}}
+					o.Transport = roundtripper(func(r *http.Request) (*http.Response, error) {
+						return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}, Request: r}, nil
+					})
+				}).Handler(nil)
+
+				var group sync.WaitGroup
+				for range 8 {
+					group.Add(1)
+
+					go func() {
+						defer group.Done()
+
+						for range 250 {
+							if recorder := serve(h, "/"); recorder.Code != http.StatusOK {
+								t.Errorf("Status (%s) = %d\n    - Expectation = %d", name, recorder.Code, http.StatusOK)
+
+								return
+							}
+						}
+					}()
+				}
+
+				group.Wait()
+			}
+		})
+	})
+}
+
+// roundtripper is an adapter allowing the use of ordinary functions as an [http.RoundTripper].
+type roundtripper func(r *http.Request) (*http.Response, error)
+
+func (f roundtripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
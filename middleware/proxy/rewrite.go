@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+)
+
+// rules is the package's unexported context key, carrying the matched route's [Rewrite] to its upstream's reverse proxy.
+const rules keyer = "proxy-rewrite"
+
+// Headers represents a set of header transformations, applied in order: renames, removals, then additions.
+type Headers struct {
+	// Rename represents the headers to rename, keyed by their current name (e.g. {"X-User": "X-Upstream-User"}).
+	Rename map[string]string
+
+	// Remove represents the headers to remove (e.g. "Cookie", or "Server").
+	Remove []string
+
+	// Add represents the headers to set, replacing any existing values.
+	Add map[string]string
+}
+
+// apply transforms the header.
+func (h *Headers) apply(header http.Header) {
+	for from, to := range h.Rename {
+		if values := header.Values(from); len(values) > 0 {
+			header.Del(from)
+			header[http.CanonicalHeaderKey(to)] = values
+		}
+	}
+
+	for _, name := range h.Remove {
+		header.Del(name)
+	}
+
+	for name, value := range h.Add {
+		header.Set(name, value)
+	}
+}
+
+// Rewrite represents a route's declarative request, and response, transformations. Path transformations are applied in order:
+// [Rewrite.Strip], [Rewrite.Pattern], then [Rewrite.Prefix] - before the path is joined to the upstream's base URL path.
+type Rewrite struct {
+	// Strip represents a path prefix removed from proxied requests (e.g. "/api" proxies "/api/users" as "/users").
+	Strip string
+
+	// Pattern represents a regular expression replaced, in proxied requests' paths, by [Rewrite.Replacement].
+	Pattern *regexp.Regexp
+
+	// Replacement represents the [Rewrite.Pattern] replacement, supporting capture group expansion (e.g. "/users/${id}").
+	Replacement string
+
+	// Prefix represents a path prefix added to proxied requests (e.g. "/v2").
+	Prefix string
+
+	// Host represents the proxied requests' Host header override. An empty value uses the upstream's host.
+	Host string
+
+	// Request represents the proxied requests' header transformations, applied after the X-Forwarded headers are set.
+	Request Headers
+
+	// Response represents the upstreams' response header transformations.
+	Response Headers
+}
+
+// path rewrites the request URL path.
+func (rewrite *Rewrite) path(path string) string {
+	if rewrite.Strip != "" && strings.HasPrefix(path, rewrite.Strip) {
+		path = strings.TrimPrefix(path, rewrite.Strip)
+		if !(strings.HasPrefix(path, "/")) {
+			path = "/" + path
+		}
+	}
+
+	if rewrite.Pattern != nil {
+		path = rewrite.Pattern.ReplaceAllString(path, rewrite.Replacement)
+	}
+
+	if rewrite.Prefix != "" {
+		path = strings.TrimSuffix(rewrite.Prefix, "/") + path
+	}
+
+	return path
+}
+
+// request rewrites the outbound request, prior to [httputil.ProxyRequest.SetURL].
+func (rewrite *Rewrite) request(r *httputil.ProxyRequest) {
+	if path := rewrite.path(r.Out.URL.Path); path != r.Out.URL.Path {
+		r.Out.URL.Path = path
+		r.Out.URL.RawPath = ""
+	}
+}
+
+// headers rewrites the outbound request's headers, and host, following [httputil.ProxyRequest.SetURL].
+func (rewrite *Rewrite) headers(r *httputil.ProxyRequest) {
+	if rewrite.Host != "" {
+		r.Out.Host = rewrite.Host
+	}
+
+	rewrite.Request.apply(r.Out.Header)
+}
@@ -0,0 +1,134 @@
+package proxy
+
+import (
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// smoothing represents the latency EWMA's smoothing factor: the weight of each new observation.
+const smoothing = 0.3
+
+// Upstream represents a route's upstream server, and its health and load statistics.
+type Upstream struct {
+	// URL represents the upstream's base URL (e.g. "http://10.0.0.1:8080").
+	URL *url.URL
+
+	proxy *httputil.ReverseProxy
+
+	active   atomic.Int64
+	requests atomic.Uint64
+	failures atomic.Uint64
+
+	mutex    sync.Mutex
+	healthy  bool          // healthy represents the upstream's active health check state.
+	rise     int           // rise represents the number of consecutive successful health checks.
+	fall     int           // fall represents the number of consecutive failed health checks.
+	errors   int           // errors represents the number of consecutive failed requests.
+	ejected  time.Time     // ejected represents the time until which the upstream is ejected by passive failure detection.
+	latency  time.Duration // latency represents the upstream's latency EWMA.
+	observed bool          // observed reports whether a latency was observed.
+}
+
+// Available reports whether the upstream is healthy, and isn't ejected by passive failure detection.
+func (u *Upstream) Available(now time.Time) bool {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.healthy && !(now.Before(u.ejected))
+}
+
+// Active returns the upstream's number of in-flight requests.
+func (u *Upstream) Active() int64 {
+	return u.active.Load()
+}
+
+// Latency returns the upstream's exponentially weighted moving average latency, or zero if no request was observed.
+func (u *Upstream) Latency() time.Duration {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.latency
+}
+
+// observe records a proxied request's outcome, ejecting the upstream once failures consecutive requests failed.
+func (u *Upstream) observe(duration time.Duration, failed bool, failures int, ejection time.Duration, now time.Time) (ejected bool) {
+	u.requests.Add(1)
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.observed {
+		u.latency = time.Duration(smoothing*float64(duration) + (1-smoothing)*float64(u.latency))
+	} else {
+		u.latency, u.observed = duration, true
+	}
+
+	if !(failed) {
+		u.errors = 0
+
+		return false
+	}
+
+	u.failures.Add(1)
+
+	if u.errors++; failures > 0 && u.errors >= failures {
+		u.errors, u.ejected = 0, now.Add(ejection)
+
+		return true
+	}
+
+	return false
+}
+
+// check records an active health check's outcome, returning whether the upstream's health state changed.
+func (u *Upstream) check(success bool, rise, fall int) (changed bool) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if success {
+		u.rise, u.fall = u.rise+1, 0
+		if !(u.healthy) && u.rise >= rise {
+			u.healthy = true
+
+			return true
+		}
+
+		return false
+	}
+
+	u.rise, u.fall = 0, u.fall+1
+	if u.healthy && u.fall >= fall {
+		u.healthy = false
+
+		return true
+	}
+
+	return false
+}
+
+// Metrics represents a snapshot of an upstream's statistics.
+type Metrics struct {
+	// Route represents the upstream's route prefix.
+	Route string `json:"route"`
+
+	// Upstream represents the upstream's base URL.
+	Upstream string `json:"upstream"`
+
+	// Available reports whether the upstream is healthy, and isn't ejected.
+	Available bool `json:"available"`
+
+	// Active represents the upstream's number of in-flight requests.
+	Active int64 `json:"active"`
+
+	// Requests represents the cumulative number of requests proxied to the upstream.
+	Requests uint64 `json:"requests"`
+
+	// Failures represents the cumulative number of failed requests - transport errors, and gateway error statuses.
+	Failures uint64 `json:"failures"`
+
+	// Latency represents the upstream's latency EWMA.
+	Latency time.Duration `json:"latency"`
+}
@@ -0,0 +1,8 @@
+// Package ratelimit provides per-key rate limiting middleware, supporting the token bucket and sliding window algorithms.
+//
+// Requests are keyed by client address (see [IP]), a request header (see [Header]), or a custom extractor. Requests exceeding
+// the key's quota receive a [http.StatusTooManyRequests] response with a Retry-After header; permitted requests' remaining quota
+// is exposed via context, and - by default - the RateLimit-Limit, RateLimit-Remaining, and RateLimit-Reset response headers.
+//
+// Limiter state is held in-process; deployments with multiple replicas limit each replica independently.
+package ratelimit
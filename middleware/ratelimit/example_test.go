@@ -0,0 +1,59 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/ratelimit"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(ratelimit.New().Settings(func(o *ratelimit.Options) {
+		o.Algorithm = ratelimit.SlidingWindow
+		o.Limit = 2
+		o.Window = time.Hour
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for range 3 {
+		request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d, Remaining: %s\n", response.StatusCode, response.Header.Get("RateLimit-Remaining"))
+	}
+
+	// Output:
+	// Status: 200, Remaining: 1
+	// Status: 200, Remaining: 0
+	// Status: 429, Remaining: 0
+}
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Algorithm represents a rate limiting algorithm.
+type Algorithm string
+
+const (
+	// TokenBucket represents the token bucket algorithm: tokens refill continuously at [Options.Limit] per [Options.Window], up
+	// to [Options.Burst], and each request consumes a token. Bursts are permitted up to the bucket's capacity.
+	TokenBucket Algorithm = "token-bucket"
+
+	// SlidingWindow represents the sliding window counter algorithm: requests are counted per fixed window, and the previous
+	// window's count is weighted by its overlap with the trailing [Options.Window], smoothing fixed-window boundary bursts.
+	SlidingWindow Algorithm = "sliding-window"
+)
+
+// decision represents a limiter's verdict for a single request.
+type decision struct {
+	allowed   bool
+	remaining int
+	retry     time.Duration // retry represents the duration until the next request would be allowed; zero if allowed.
+	reset     time.Duration // reset represents the duration until the quota is fully restored.
+}
+
+// state represents a single key's limiter state.
+type state struct {
+	// tokens and last represent the token bucket's state.
+	tokens float64
+	last   time.Time
+
+	// start, current, and previous represent the sliding window's state.
+	start    time.Time
+	current  int
+	previous int
+
+	seen time.Time
+}
+
+// bucket applies the token bucket algorithm.
+func (s *state) bucket(now time.Time, limit, burst int, window time.Duration) decision {
+	rate := float64(limit) / window.Seconds() // rate represents tokens per second.
+
+	if s.last.IsZero() {
+		s.tokens = float64(burst)
+	} else if elapsed := now.Sub(s.last).Seconds(); elapsed > 0 {
+		s.tokens = math.Min(float64(burst), s.tokens+elapsed*rate)
+	}
+
+	s.last = now
+
+	seconds := func(v float64) time.Duration {
+		return time.Duration(math.Ceil(v * float64(time.Second)))
+	}
+
+	if s.tokens < 1 {
+		return decision{allowed: false, remaining: 0, retry: seconds((1 - s.tokens) / rate), reset: seconds((float64(burst) - s.tokens) / rate)}
+	}
+
+	s.tokens--
+
+	return decision{allowed: true, remaining: int(s.tokens), reset: seconds((float64(burst) - s.tokens) / rate)}
+}
+
+// slide applies the sliding window counter algorithm.
+func (s *state) slide(now time.Time, limit int, window time.Duration) decision {
+	start := now.Truncate(window)
+
+	switch {
+	case s.start.Equal(start):
+	case s.start.Add(window).Equal(start):
+		s.start, s.previous, s.current = start, s.current, 0
+	default:
+		s.start, s.previous, s.current = start, 0, 0
+	}
+
+	elapsed := now.Sub(start)
+	weight := 1 - (float64(elapsed) / float64(window))
+
+	estimate := int(math.Floor(float64(s.previous)*weight)) + s.current
+
+	reset := window - elapsed
+	if s.current > 0 {
+		reset += window
+	}
+
+	if estimate >= limit {
+		// The previous window's weight decays linearly; wait until enough of it has elapsed to admit a request - within the
+		// current window if its own count permits, otherwise within the next.
+		retry := window - elapsed
+		if s.current < limit && s.previous > 0 {
+			target := float64(limit-s.current) / float64(s.previous)
+			retry = max(time.Duration((1-target)*float64(window))-elapsed, 0)
+		} else if s.current >= limit {
+			retry += time.Duration((1 - float64(limit)/float64(s.current)) * float64(window))
+		}
+
+		return decision{allowed: false, remaining: 0, retry: max(retry, time.Millisecond), reset: reset}
+	}
+
+	s.current++
+
+	return decision{allowed: true, remaining: limit - estimate - 1, reset: reset}
+}
+
+// store represents the per-key limiter states, evicting keys idle for longer than twice the window.
+type store struct {
+	mutex  sync.Mutex
+	states map[string]*state
+	sweep  time.Time
+}
+
+// take evaluates, and records, a request for the key.
+func (s *store) take(key string, now time.Time, o *Options) decision {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.states == nil {
+		s.states = make(map[string]*state)
+		s.sweep = now
+	}
+
+	if now.Sub(s.sweep) > o.Window {
+		for k, v := range s.states {
+			if now.Sub(v.seen) > 2*o.Window {
+				delete(s.states, k)
+			}
+		}
+
+		s.sweep = now
+	}
+
+	v, ok := s.states[key]
+	if !(ok) {
+		v = new(state)
+		s.states[key] = v
+	}
+
+	v.seen = now
+
+	if o.Algorithm == SlidingWindow {
+		return v.slide(now, o.Limit, o.Window)
+	}
+
+	return v.bucket(now, o.Limit, o.Burst, o.Window)
+}
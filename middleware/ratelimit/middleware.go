@@ -0,0 +1,236 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/rip"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "ratelimit"
+
+// Valuer is the context return type relating to the [Ratelimit] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Key represents the request's rate limiting key.
+	Key string `json:"key"`
+
+	// Limit represents the number of requests permitted per [Options.Window].
+	Limit int `json:"limit"`
+
+	// Remaining represents the key's remaining quota, following the request.
+	Remaining int `json:"remaining"`
+
+	// Reset represents the duration until the key's quota is fully restored.
+	Reset time.Duration `json:"reset"`
+}
+
+// Options represents the configuration settings for the [Ratelimit] middleware component.
+type Options struct {
+	// Algorithm represents the rate limiting algorithm. Defaults to [TokenBucket].
+	Algorithm Algorithm
+
+	// Limit represents the number of requests permitted per [Options.Window], per key. Defaults to 100.
+	Limit int
+
+	// Window represents the rate limiting period. Defaults to one minute.
+	Window time.Duration
+
+	// Burst represents the [TokenBucket] algorithm's capacity - the maximum number of requests permitted in quick succession.
+	// Ignored by the [SlidingWindow] algorithm. Defaults to [Options.Limit].
+	Burst int
+
+	// Key returns the request's rate limiting key. Requests with an empty key aren't rate limited. See [IP] and [Header] for
+	// common extractors. Defaults to [IP].
+	Key func(r *http.Request) string
+
+	// Headers enables the RateLimit-Limit, RateLimit-Remaining, and RateLimit-Reset response headers. Defaults to true.
+	Headers bool
+
+	// Status represents the rate-limited response's status code. Defaults to [http.StatusTooManyRequests].
+	Status int
+
+	// Message represents the rate-limited response's body. Defaults to [http.StatusText] of [Options.Status].
+	Message string
+
+	// Clock returns the current time. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// IP returns the request's client address - the [rip] middleware's value, falling back to the request's remote address.
+func IP(r *http.Request) string {
+	if v := rip.Value(r.Context()); v != "" {
+		return v
+	}
+
+	if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+		return v
+	}
+
+	return r.RemoteAddr
+}
+
+// Header returns a key extractor returning the named request header's value (e.g. an API key).
+func Header(name string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// Ratelimit represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Ratelimit struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	store store
+}
+
+// Settings applies configuration functions to modify the [Ratelimit] middleware's [Options] and returns the updated middleware instance.
+func (x *Ratelimit) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Algorithm: TokenBucket,
+			Limit:     100,
+			Window:    time.Minute,
+			Burst:     0,
+			Key:       IP,
+			Headers:   true,
+			Status:    http.StatusTooManyRequests,
+			Message:   "",
+			Clock:     time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Algorithm != TokenBucket && x.options.Algorithm != SlidingWindow {
+		slog.Warn("Invalid Ratelimit Algorithm Specified - Using Default Algorithm")
+
+		x.options.Algorithm = TokenBucket
+	}
+
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Ratelimit Limit Specified - Using Default Limit")
+
+		x.options.Limit = 100
+	}
+
+	if x.options.Window <= 0 {
+		slog.Warn("Invalid Ratelimit Window Specified - Using Default Window")
+
+		x.options.Window = time.Minute
+	}
+
+	if x.options.Burst <= 0 {
+		x.options.Burst = x.options.Limit
+	}
+
+	if x.options.Key == nil {
+		x.options.Key = IP
+	}
+
+	if x.options.Status < 400 || x.options.Status > 599 {
+		slog.Warn("Invalid Ratelimit Status Specified - Using Default Status")
+
+		x.options.Status = http.StatusTooManyRequests
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = http.StatusText(x.options.Status)
+	}
+
+	if x.options.Clock == nil {
+		x.options.Clock = time.Now
+	}
+
+	return x
+}
+
+// seconds returns the duration in whole seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// Handler rate limits requests per [Options.Key], responding to requests exceeding the quota with an [Options.Status] response
+// and a Retry-After header. The key's remaining quota is stored in the request context.
+func (x *Ratelimit) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		k := x.options.Key(r)
+		if k == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		verdict := x.store.take(k, x.options.Clock(), x.options)
+
+		if x.options.Headers {
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(x.options.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(verdict.remaining))
+			w.Header().Set("RateLimit-Reset", seconds(verdict.reset))
+		}
+
+		if !(verdict.allowed) {
+			slog.DebugContext(ctx, "Rate Limit Exceeded", slog.String("key", k), slog.Duration("retry", verdict.retry))
+
+			w.Header().Set("Retry-After", seconds(verdict.retry))
+
+			reject.Record(ctx, reject.Reason{Subsystem: "ratelimit", Code: "limit-exceeded", Status: x.options.Status})
+
+			http.Error(w, x.options.Message, x.options.Status)
+
+			return
+		}
+
+		valuer := &Valuer{Key: k, Limit: x.options.Limit, Remaining: verdict.remaining, Reset: verdict.reset}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Ratelimit] middleware, implementing [middleware.Configurable]. If [Ratelimit.Settings]
+// isn't called, then the [Ratelimit.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Ratelimit)
+}
+
+// Value retrieves the request's remaining rate limiting quota from the provided context. If a nil value is returned, it can be
+// assumed that the [Ratelimit] middleware isn't enabled for the particular caller's chain, or that the request wasn't rate limited.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Ratelimit] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Ratelimit)(nil)
@@ -0,0 +1,181 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/ratelimit"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := ratelimit.Value(r.Context()); v != nil {
+			w.Header().Set("X-Remaining", strconv.Itoa(v.Remaining))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	type step struct {
+		advance   time.Duration // advance represents the clock's advancement preceding the request.
+		status    int
+		remaining string
+		retry     string
+	}
+
+	tests := []struct {
+		name    string
+		options func(o *ratelimit.Options)
+		steps   []step
+	}{
+		{name: "Token-Bucket", options: func(o *ratelimit.Options) {
+			o.Limit, o.Window = 2, time.Minute
+		}, steps: []step{
+			{status: http.StatusOK, remaining: "1"},
+			{status: http.StatusOK, remaining: "0"},
+			{status: http.StatusTooManyRequests, retry: "30"},
+			{advance: 30 * time.Second, status: http.StatusOK, remaining: "0"},
+			{advance: time.Minute, status: http.StatusOK, remaining: "1"},
+		}},
+		{name: "Token-Bucket-Burst", options: func(o *ratelimit.Options) {
+			o.Limit, o.Window, o.Burst = 1, time.Second, 3
+		}, steps: []step{
+			{status: http.StatusOK, remaining: "2"},
+			{status: http.StatusOK, remaining: "1"},
+			{status: http.StatusOK, remaining: "0"},
+			{status: http.StatusTooManyRequests, retry: "1"},
+		}},
+		{name: "Sliding-Window", options: func(o *ratelimit.Options) {
+			o.Algorithm, o.Limit, o.Window = ratelimit.SlidingWindow, 4, time.Minute
+		}, steps: []step{
+			{status: http.StatusOK, remaining: "3"},
+			{status: http.StatusOK, remaining: "2"},
+			{status: http.StatusOK, remaining: "1"},
+			{status: http.StatusOK, remaining: "0"},
+			{status: http.StatusTooManyRequests, retry: "60"},
+			// The previous window's four requests are weighted 75% at 15 seconds into the next window.
+			{advance: 75 * time.Second, status: http.StatusOK, remaining: "0"},
+			// Beyond 15 seconds, the previous window's decaying weight admits one additional request.
+			{status: http.StatusTooManyRequests, retry: "1"},
+			{advance: time.Second, status: http.StatusOK, remaining: "0"},
+			{status: http.StatusTooManyRequests, retry: "14"},
+			{advance: 3 * time.Minute, status: http.StatusOK, remaining: "3"},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			m := ratelimit.New().Settings(test.options, func(o *ratelimit.Options) {
+				o.Clock = func() time.Time { return now }
+			}).Handler(handler)
+
+			for index, step := range test.steps {
+				now = now.Add(step.advance)
+
+				recorder := httptest.NewRecorder()
+
+				m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				if recorder.Code != step.status {
+					t.Fatalf("Step %d: Status = %d\n    - Expectation = %d", index, recorder.Code, step.status)
+				}
+
+				if v := recorder.Header().Get("X-Remaining"); v != step.remaining {
+					t.Errorf("Step %d: Remaining = %s\n    - Expectation = %s", index, v, step.remaining)
+				}
+
+				if v := recorder.Header().Get("Retry-After"); v != step.retry {
+					t.Errorf("Step %d: Retry-After = %s\n    - Expectation = %s", index, v, step.retry)
+				}
+			}
+		})
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		m := ratelimit.New().Settings(func(o *ratelimit.Options) {
+			o.Limit = 1
+			o.Key = ratelimit.Header("X-API-Key")
+		}).Handler(handler)
+
+		tests := []struct {
+			key    string
+			status int
+		}{
+			{key: "alpha", status: http.StatusOK},
+			{key: "alpha", status: http.StatusTooManyRequests},
+			{key: "beta", status: http.StatusOK},
+			{key: "", status: http.StatusOK},
+			{key: "", status: http.StatusOK},
+		}
+
+		for index, test := range tests {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("X-API-Key", test.key)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Request %d: Status = %d\n    - Expectation = %d", index, recorder.Code, test.status)
+			}
+		}
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		m := ratelimit.New().Settings(func(o *ratelimit.Options) {
+			o.Limit = 10
+		}).Handler(handler)
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		expectations := map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "9", "RateLimit-Reset": "6"}
+		for header, expectation := range expectations {
+			if v := recorder.Header().Get(header); v != expectation {
+				t.Errorf("%s = %s\n    - Expectation = %s", header, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Reject-Reason", func(t *testing.T) {
+		m := reject.New().Settings(func(o *reject.Options) {
+			o.Expose = func(r *http.Request) bool { return true }
+		}).Handler(ratelimit.New().Settings(func(o *ratelimit.Options) {
+			o.Limit = 1
+		}).Handler(handler))
+
+		var recorder *httptest.ResponseRecorder
+		for range 2 {
+			recorder = httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		}
+
+		if v := recorder.Header().Get("X-Reject-Reason"); v != "ratelimit/limit-exceeded" {
+			t.Errorf("X-Reject-Reason = %s\n    - Expectation = %s", v, "ratelimit/limit-exceeded")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := ratelimit.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &ratelimit.Valuer{Key: "192.0.2.1", Limit: 100, Remaining: 99, Reset: time.Second}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := ratelimit.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,6 @@
+// Package recover provides panic recovery middleware, converting handler panics into structured log messages and consistent
+// error responses - plain text, JSON, or RFC 9457 problem details.
+//
+// Recovered panics are logged with the goroutine's stack trace and, if the [telemetrics] middleware precedes it in the chain,
+// the request's telemetry headers, so that panics can be correlated with their traces.
+package recover
@@ -0,0 +1,66 @@
+package recover_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/recover"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(recover.New().Settings(func(o *recover.Options) {
+		o.Format = recover.Problem
+		o.Stack = false
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		var m map[string]string
+
+		m["key"] = "value" // Assignment to a nil map panics.
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	body, e := io.ReadAll(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading response body: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("%d %s\n%s", response.StatusCode, response.Header.Get("Content-Type"), body)
+
+	// Output:
+	// 500 application/problem+json
+	// {"detail":"Internal Server Error","instance":"/","status":500,"title":"Internal Server Error","type":"about:blank"}
+}
@@ -0,0 +1,241 @@
+package recover
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// Format represents the recovered response's body format.
+type Format string
+
+const (
+	// Text represents a plain-text response body.
+	Text Format = "text"
+
+	// JSON represents an "application/json" response body of the form {"error": "...", "status": 500}.
+	JSON Format = "json"
+
+	// Problem represents an RFC 9457 "application/problem+json" response body.
+	Problem Format = "problem"
+)
+
+// Options represents the configuration settings for the [Recover] middleware component.
+type Options struct {
+	// Format represents the recovered response's body format. Defaults to [Text].
+	Format Format
+
+	// Status represents the recovered response's status code. Defaults to [http.StatusInternalServerError].
+	Status int
+
+	// Message represents the recovered response's error message. Defaults to [http.StatusText] of [Options.Status].
+	Message string
+
+	// Stack enables including the goroutine's stack trace in the panic's log message. Defaults to true.
+	Stack bool
+
+	// Level specifies the log level used to record recovered panics. Defaults to [slog.LevelError].
+	Level slog.Leveler
+
+	// Hook optionally receives every recovered panic's value (e.g. for error reporting), prior to the response being written.
+	// Defaults to nil.
+	Hook func(r *http.Request, value interface{}, stack []byte)
+}
+
+// Recover represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Recover struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Recover] middleware's [Options] and returns the updated middleware instance.
+func (x *Recover) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Format:  Text,
+			Status:  http.StatusInternalServerError,
+			Message: "",
+			Stack:   true,
+			Level:   slog.LevelError,
+			Hook:    nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Format != Text && x.options.Format != JSON && x.options.Format != Problem {
+		slog.Warn("Invalid Recover Format Specified - Using Default Format")
+
+		x.options.Format = Text
+	}
+
+	if x.options.Status < 500 || x.options.Status > 599 {
+		slog.Warn("Invalid Recover Status Specified - Using Default Status")
+
+		x.options.Status = http.StatusInternalServerError
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = http.StatusText(x.options.Status)
+	}
+
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelError
+	}
+
+	return x
+}
+
+// writer tracks whether the response's header(s) were written, such that a recovered response isn't written over a partial one.
+type writer struct {
+	http.ResponseWriter
+
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if status >= 200 {
+		w.written = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.written = true
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher]. A flushed response is considered written, such that a recovered response isn't written
+// over it.
+func (w *writer) Flush() {
+	w.written = true
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. A hijacked connection is considered written, such that a recovered response isn't
+// written to it.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, e := http.NewResponseController(w.ResponseWriter).Hijack()
+	if e == nil {
+		w.written = true
+	}
+
+	return conn, rw, e
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// respond writes the recovered response.
+func (x *Recover) respond(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+
+	// Discard header(s) set by the panicking handler, which may not describe the recovered response.
+	for k := range header {
+		if k != "Vary" {
+			header.Del(k)
+		}
+	}
+
+	header.Set("Cache-Control", "no-store")
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	switch x.options.Format {
+	case JSON:
+		header.Set("Content-Type", "application/json")
+		w.WriteHeader(x.options.Status)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": x.options.Message, "status": x.options.Status})
+	case Problem:
+		header.Set("Content-Type", "application/problem+json")
+		w.WriteHeader(x.options.Status)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"type": "about:blank", "title": http.StatusText(x.options.Status), "status": x.options.Status, "detail": x.options.Message, "instance": r.URL.Path})
+	default:
+		http.Error(w, x.options.Message, x.options.Status)
+	}
+}
+
+// Handler recovers panics raised by the next handler in the chain, logging the panic - with its stack trace and the request's
+// [telemetrics] headers - and writing an [Options.Status] response in the configured [Options.Format]. If the response was
+// already partially written, the connection is aborted instead. Panics with [http.ErrAbortHandler] are re-raised.
+func (x *Recover) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		wrapper := &writer{ResponseWriter: w}
+
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			if e, ok := value.(error); ok && errors.Is(e, http.ErrAbortHandler) {
+				panic(value)
+			}
+
+			stack := debug.Stack()
+
+			attributes := []slog.Attr{
+				slog.String("panic", fmt.Sprint(value)),
+				slog.String("method", r.Method),
+				slog.String("url", r.URL.String()),
+			}
+
+			if v := telemetrics.Value(ctx); v != nil {
+				attributes = append(attributes, slog.Any("telemetry", v.Headers))
+			}
+
+			if x.options.Stack {
+				attributes = append(attributes, slog.String("stack", string(stack)))
+			}
+
+			slog.LogAttrs(ctx, x.options.Level.Level(), "Recovered Handler Panic", attributes...)
+
+			if x.options.Hook != nil {
+				x.options.Hook(r, value, stack)
+			}
+
+			if wrapper.written {
+				// The response's status has already been sent; abort the connection so the client observes a failure.
+				panic(http.ErrAbortHandler)
+			}
+
+			x.respond(w, r)
+		}()
+
+		next.ServeHTTP(wrapper, r)
+	})
+}
+
+// New creates a new instance of the [Recover] middleware, implementing [middleware.Configurable]. If [Recover.Settings] isn't
+// called, then the [Recover.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Recover)
+}
+
+// Runtime assurance that [Recover] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Recover)(nil)
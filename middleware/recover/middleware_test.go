@@ -0,0 +1,145 @@
+package recover_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/recover"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+func Test(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+
+		panic("unexpected state")
+	})
+
+	tests := []struct {
+		name    string
+		options func(o *recover.Options)
+		status  int
+		content string
+		body    string
+	}{
+		{name: "Text", status: http.StatusInternalServerError, content: "text/plain; charset=utf-8", body: "Internal Server Error\n"},
+		{name: "JSON", options: func(o *recover.Options) {
+			o.Format = recover.JSON
+		}, status: http.StatusInternalServerError, content: "application/json", body: `{"error":"Internal Server Error","status":500}` + "\n"},
+		{name: "Problem", options: func(o *recover.Options) {
+			o.Format = recover.Problem
+			o.Status = http.StatusServiceUnavailable
+			o.Message = "Temporarily Unavailable"
+		}, status: http.StatusServiceUnavailable, content: "application/problem+json", body: `{"detail":"Temporarily Unavailable","instance":"/resource","status":503,"title":"Service Unavailable","type":"about:blank"}` + "\n"},
+		{name: "Invalid-Status", options: func(o *recover.Options) {
+			o.Status = http.StatusOK
+		}, status: http.StatusInternalServerError, content: "text/plain; charset=utf-8", body: "Internal Server Error\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := recover.New().Settings(test.options).Handler(panicking)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("Content-Type"); v != test.content {
+				t.Errorf("Content-Type = %s\n    - Expectation = %s", v, test.content)
+			}
+
+			if v := recorder.Body.String(); v != test.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+		})
+	}
+
+	t.Run("Logging", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&buffer, nil)))
+		defer slog.SetDefault(previous)
+
+		var hooked interface{}
+
+		m := telemetrics.New().Handler(recover.New().Settings(func(o *recover.Options) {
+			o.Hook = func(r *http.Request, value interface{}, stack []byte) {
+				hooked = value
+			}
+		}).Handler(panicking))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("X-Request-ID", "request-identifier")
+
+		m.ServeHTTP(httptest.NewRecorder(), request)
+
+		var message map[string]interface{}
+		if e := json.Unmarshal(buffer.Bytes(), &message); e != nil {
+			t.Fatalf("Unexpected Error While Unmarshalling Log Message: %v", e)
+		}
+
+		if v := message["panic"]; v != "unexpected state" {
+			t.Errorf("Panic = %v\n    - Expectation = %s", v, "unexpected state")
+		}
+
+		if v, ok := message["stack"].(string); !(ok) || !(strings.Contains(v, "runtime/debug.Stack")) {
+			t.Errorf("Missing Stack Trace")
+		}
+
+		if v, ok := message["telemetry"].(map[string]interface{}); !(ok) || v["X-Request-Id"] == nil {
+			t.Errorf("Missing Telemetry Header(s): %v", message["telemetry"])
+		}
+
+		if hooked != "unexpected state" {
+			t.Errorf("Hook Value = %v\n    - Expectation = %s", hooked, "unexpected state")
+		}
+	})
+
+	t.Run("Aborted", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			handler http.HandlerFunc
+		}{
+			{name: "Partial-Response", handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "64")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("partial"))
+
+				panic("unexpected state")
+			}},
+			{name: "Abort-Handler", handler: func(w http.ResponseWriter, r *http.Request) {
+				panic(http.ErrAbortHandler)
+			}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				server := httptest.NewServer(recover.New().Handler(test.handler))
+
+				defer server.Close()
+
+				response, e := server.Client().Get(server.URL)
+				if e == nil {
+					_, e = io.ReadAll(response.Body)
+
+					response.Body.Close()
+				}
+
+				if e == nil {
+					t.Errorf("Expected Aborted Connection")
+				}
+			})
+		}
+	})
+}
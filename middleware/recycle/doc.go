@@ -0,0 +1,4 @@
+// Package recycle provides middleware for gracefully rebalancing long-lived keep-alive connections ahead of a deployment. Once
+// signaled, a ramping percentage of responses include a "Connection: close" header - which, for HTTP/2 connections, the standard
+// library's server translates into a graceful GOAWAY - prompting clients and load balancers to re-establish connections elsewhere.
+package recycle
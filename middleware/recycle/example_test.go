@@ -0,0 +1,56 @@
+package recycle_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/recycle"
+)
+
+func Example() {
+	// signal is typically raised by a SIGTERM handler, or a deployment hook, ahead of server shutdown.
+	signal := new(recycle.Signal)
+
+	middleware := middleware.New()
+
+	middleware.Add(recycle.New().Settings(func(o *recycle.Options) {
+		o.Signal = signal
+		o.Percentage = 100
+		o.Ramp = 0
+		o.Duration = time.Minute
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	for range 2 {
+		response, e := client.Get(server.URL)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Connection Close: %v\n", response.Close)
+
+		signal.Raise()
+	}
+
+	// Output:
+	// Connection Close: false
+	// Connection Close: true
+}
@@ -0,0 +1,200 @@
+package recycle
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "recycle"
+
+const (
+	defaultPercentage = 50
+	defaultRamp       = time.Second * 30
+	defaultDuration   = time.Minute * 2
+)
+
+// Signal represents a deployment-imminent signal shared between the caller and the [Recycle] middleware. The zero value is ready for use.
+type Signal struct {
+	raised atomic.Int64
+}
+
+// Raise marks the signal as raised, starting the [Recycle] middleware's ramp. Subsequent calls are no-ops until [Signal.Reset] is called.
+func (s *Signal) Raise() {
+	s.raised.CompareAndSwap(0, time.Now().UnixNano())
+}
+
+// Reset clears a raised signal.
+func (s *Signal) Reset() {
+	s.raised.Store(0)
+}
+
+// Raised returns the time the signal was raised, and whether it's currently raised.
+func (s *Signal) Raised() (time.Time, bool) {
+	if v := s.raised.Load(); v != 0 {
+		return time.Unix(0, v), true
+	}
+
+	return time.Time{}, false
+}
+
+// Options represents the configuration settings for the [Recycle] middleware component.
+type Options struct {
+	// Signal represents the signal that activates connection recycling. A nil value disables the middleware's behavior.
+	Signal *Signal
+
+	// Percentage represents the maximum percentage (0 - 100) of responses that include the "Connection: close" header. Defaults to 50.
+	Percentage float64
+
+	// Ramp represents the duration, after the [Signal] is raised, over which the applied percentage linearly increases to [Options.Percentage]. Defaults to 30 seconds.
+	Ramp time.Duration
+
+	// Duration represents the total duration, after the [Signal] is raised, in which responses are subject to recycling. Defaults to two minutes.
+	Duration time.Duration
+
+	// Level specifies the log level used to record recycled connections. A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+
+	// Random returns a pseudo-random number in [0.0, 1.0), and is overwritable for testing purposes. Defaults to [rand.Float64].
+	Random func() float64
+}
+
+// Recycle represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Recycle struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Recycle] middleware's [Options] and returns the updated middleware instance.
+func (c *Recycle) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if c.options == nil {
+		c.options = &Options{
+			Signal:     nil,
+			Percentage: defaultPercentage,
+			Ramp:       defaultRamp,
+			Duration:   defaultDuration,
+			Level:      nil,
+			Clock:      time.Now,
+			Random:     rand.Float64,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(c.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if c.options.Percentage < 0 || c.options.Percentage > 100 {
+		slog.Warn("Invalid Recycle Percentage Specified - Using Default Percentage")
+
+		c.options.Percentage = defaultPercentage
+	}
+
+	if c.options.Ramp < 0 {
+		c.options.Ramp = 0
+	}
+
+	if c.options.Duration <= 0 {
+		slog.Warn("Invalid Recycle Duration Specified - Using Default Duration")
+
+		c.options.Duration = defaultDuration
+	}
+
+	if c.options.Clock == nil {
+		c.options.Clock = time.Now
+	}
+
+	if c.options.Random == nil {
+		c.options.Random = rand.Float64
+	}
+
+	return c
+}
+
+// percentage returns the percentage of responses to recycle at the given time, accounting for the configured ramp and duration.
+func (c *Recycle) percentage(now time.Time) float64 {
+	if c.options.Signal == nil {
+		return 0
+	}
+
+	raised, ok := c.options.Signal.Raised()
+	if !(ok) {
+		return 0
+	}
+
+	elapsed := now.Sub(raised)
+	switch {
+	case elapsed < 0 || elapsed > c.options.Duration:
+		return 0
+	case c.options.Ramp == 0 || elapsed >= c.options.Ramp:
+		return c.options.Percentage
+	default:
+		return c.options.Percentage * (float64(elapsed) / float64(c.options.Ramp))
+	}
+}
+
+// Handler applies the recycling middleware to the provided HTTP handler, setting a "Connection: close" response header on a percentage of responses once signaled.
+func (c *Recycle) Handler(next http.Handler) http.Handler {
+	c.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var recycled bool
+		if percentage := c.percentage(c.options.Clock()); percentage > 0 && (c.options.Random()*100) < percentage {
+			recycled = true
+
+			w.Header().Set("Connection", "close")
+
+			if v := c.options.Level; v != nil {
+				slog.Log(ctx, v.Level(), "Recycle Middleware - Closing Connection", slog.String("remote-address", r.RemoteAddr), slog.Float64("percentage", percentage))
+			}
+		}
+
+		ctx = context.WithValue(ctx, key, recycled)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Recycle] middleware, implementing [middleware.Configurable]. If [Recycle.Settings] isn't called,
+// then the [Recycle.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Recycle)
+}
+
+// Value retrieves a boolean from the provided context, indicating whether the request's connection is marked for closure by the [Recycle] middleware.
+func Value(ctx context.Context) (recycled bool) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(bool); ok {
+		recycled = v
+	} else if test, valid := ctx.Value(t).(bool); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		recycled = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Recycle] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Recycle)(nil)
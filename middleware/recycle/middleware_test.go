@@ -0,0 +1,103 @@
+package recycle_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/recycle"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if recycle.Value(r.Context()) {
+			w.Header().Set("X-Recycled", "true")
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	// offset returns a clock relative to the signal's raise time.
+	offset := func(signal *recycle.Signal, duration time.Duration) func() time.Time {
+		return func() time.Time {
+			raised, _ := signal.Raised()
+
+			return raised.Add(duration)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		raise      bool
+		offset     time.Duration
+		random     float64
+		connection string
+	}{
+		{name: "Not-Signaled", raise: false, random: 0, connection: ""},
+		{name: "Signaled-Mid-Ramp-Selected", raise: true, offset: time.Second * 15, random: 0.20, connection: "close"},
+		{name: "Signaled-Mid-Ramp-Unselected", raise: true, offset: time.Second * 15, random: 0.30, connection: ""},
+		{name: "Signaled-Post-Ramp-Selected", raise: true, offset: time.Second * 45, random: 0.45, connection: "close"},
+		{name: "Signaled-Post-Duration", raise: true, offset: time.Minute * 5, random: 0, connection: ""},
+	}
+
+	for _, matrix := range tests {
+		t.Run(matrix.name, func(t *testing.T) {
+			signal := new(recycle.Signal)
+			if matrix.raise {
+				signal.Raise()
+			}
+
+			server := httptest.NewServer(recycle.New().Settings(func(o *recycle.Options) {
+				o.Signal = signal
+				o.Percentage = 50
+				o.Ramp = time.Second * 30
+				o.Duration = time.Minute * 2
+				o.Clock = offset(signal, matrix.offset)
+				o.Random = func() float64 { return matrix.random }
+			}).Handler(handler))
+
+			defer server.Close()
+
+			response, e := server.Client().Get(server.URL)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			// The client's transport consumes the "Connection" header; response.Close reflects its presence.
+			if recycled := response.Close; recycled != (matrix.connection == "close") {
+				t.Errorf("Connection Closed = %v\n    - Expectation = %q", recycled, matrix.connection)
+			}
+
+			if recycled := response.Header.Get("X-Recycled") == "true"; recycled != (matrix.connection == "close") {
+				t.Errorf("Context Value = %v\n    - Expectation = %q", recycled, matrix.connection)
+			}
+		})
+	}
+
+	t.Run("Signal-Reset", func(t *testing.T) {
+		signal := new(recycle.Signal)
+
+		signal.Raise()
+		signal.Reset()
+
+		if _, raised := signal.Raised(); raised {
+			t.Errorf("Expected Signal to Be Reset")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if recycle.Value(context.Background()) {
+			t.Errorf("Unexpected True Default Context Value")
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", true)
+		if !(recycle.Value(ctx)) {
+			t.Errorf("Expected User-Specified Context Value")
+		}
+	})
+}
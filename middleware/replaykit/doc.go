@@ -0,0 +1,17 @@
+// Package replaykit provides a traffic recording middleware and a player for replaying recorded traffic against a handler
+// or url, enabling regression testing of middleware chains and handlers with real traffic shapes.
+//
+// # Format
+//
+// Recordings are written as JSON Lines: one JSON-encoded [Record] per line, in the order requests completed. Each record
+// includes the request's arrival time, method, request-uri, host, header(s), and body; and - optionally - the response's
+// status code, header(s), and body. Bodies are base64-encoded (the default encoding of []byte values in [encoding/json]),
+// and truncated to [Options.Limit] bytes. Sensitive header values are replaced with [Redacted] prior to serialization.
+//
+//	{"time":"2024-01-01T00:00:00Z","method":"POST","uri":"/v1/users?page=1","host":"example.com","header":{"Content-Type":["application/json"]},"body":"eyJrZXkiOiJ2YWx1ZSJ9","response":{"status":201,"header":{},"body":null}}
+//
+// # HAR
+//
+// Recordings may additionally be exported as HTTP Archive (HAR) 1.2 documents through [Export], and HAR documents - such as
+// sessions captured through a browser's developer tools - imported through [Import] for replay through a middleware chain.
+package replaykit
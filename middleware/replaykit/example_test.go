@@ -0,0 +1,69 @@
+package replaykit_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/replaykit"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(r.URL.Path))
+		return
+	})
+
+	// recording is typically an *os.File.
+	var recording bytes.Buffer
+
+	middleware := middleware.New()
+
+	middleware.Add(replaykit.New().Settings(func(o *replaykit.Options) {
+		o.Writer = &recording
+		o.Responses = true
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	response, e := server.Client().Get(server.URL + "/recorded")
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	records, e := replaykit.Read(&recording)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading recording: %w", e)
+
+		panic(e)
+	}
+
+	// Replay the recorded traffic against the in-process mux.
+	player := &replaykit.Player{Handler: mux}
+
+	responses, e := player.Play(context.Background(), records)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while replaying: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("Recorded: %d %s\n", records[0].Response.Status, records[0].Response.Body)
+	fmt.Printf("Replayed: %d %s\n", responses[0].Status, responses[0].Body)
+
+	// Output:
+	// Recorded: 200 /recorded
+	// Replayed: 200 /recorded
+}
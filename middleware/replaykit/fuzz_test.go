@@ -0,0 +1,48 @@
+package replaykit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/replaykit"
+)
+
+func FuzzRead(f *testing.F) {
+	f.Add([]byte(`{"time":"2024-01-01T00:00:00Z","method":"GET","uri":"/","host":"example.com","header":{},"body":null}` + "\n"))
+	f.Add([]byte("\n\n{}\n"))
+	f.Add([]byte(`{"body":"not-base64"}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		records, e := replaykit.Read(bytes.NewReader(data))
+		if e != nil {
+			return
+		}
+
+		// Any successfully read recording must survive a HAR export.
+		if e := replaykit.Export(new(bytes.Buffer), records); e != nil {
+			t.Logf("Unable to Export Read Records: %v", e)
+		}
+	})
+}
+
+func FuzzImport(f *testing.F) {
+	f.Add([]byte(`{"log":{"version":"1.2","entries":[{"request":{"method":"GET","url":"https://example.com/?q=1","headers":[{"name":":path","value":"/"}]},"response":{"status":200,"content":{"text":"e30=","encoding":"base64"}}}]}}`))
+	f.Add([]byte(`{"log":{"version":"1.2","entries":[{"request":{"url":"%zz"}}]}}`))
+	f.Add([]byte(`{"log":{"version":"1.2","entries":[{"request":{"postData":{"text":"x","encoding":"gzip"}}}]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		records, e := replaykit.Import(bytes.NewReader(data))
+		if e != nil {
+			return
+		}
+
+		var buffer bytes.Buffer
+		if e := replaykit.Export(&buffer, records); e != nil {
+			return
+		}
+
+		if _, e := replaykit.Import(&buffer); e != nil {
+			t.Errorf("Unable to Re-Import Exported HAR: %v", e)
+		}
+	})
+}
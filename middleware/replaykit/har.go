@@ -0,0 +1,276 @@
+package replaykit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// HAR represents the root of an HTTP Archive (HAR) 1.2 document. Only the fields relevant to recording and replaying traffic
+// are modeled; unknown fields are ignored during [Import].
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog represents a HAR document's "log" object.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator represents a HAR document's "creator" object.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry represents a single request-response pair.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest represents a HAR entry's "request" object.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse represents a HAR entry's "response" object.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue represents a HAR name-value pair, as used by headers, cookies, and query strings.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData represents a HAR request's "postData" object. Non-UTF-8 bodies are base64-encoded and flagged through the
+// non-standard "encoding" field, mirroring [HARContent.Encoding].
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARContent represents a HAR response's "content" object.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings represents a HAR entry's "timings" object. Recordings don't capture phase timings, so exported values are zero.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Export writes the records as a HAR 1.2 document. Records without a recorded response are exported with a zero status, which
+// HAR consumers conventionally treat as an aborted request.
+func Export(writer io.Writer, records []Record) error {
+	document := HAR{
+		Log: HARLog{
+			Version: "1.2",
+			Creator: HARCreator{Name: "replaykit", Version: "1.0"},
+			Entries: make([]HAREntry, 0, len(records)),
+		},
+	}
+
+	for index := range records {
+		record := &records[index]
+
+		host := record.Host
+		if host == "" {
+			host = "localhost"
+		}
+
+		target, e := url.Parse("http://" + host + record.URI)
+		if e != nil {
+			return fmt.Errorf("invalid record url (%d): %w", index, e)
+		}
+
+		entry := HAREntry{
+			StartedDateTime: record.Time,
+			Request: HARRequest{
+				Method:      record.Method,
+				URL:         target.String(),
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []HARNameValue{},
+				Headers:     pairs(record.Header),
+				QueryString: pairs(target.Query()),
+				HeadersSize: -1,
+				BodySize:    len(record.Body),
+			},
+			Response: HARResponse{
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []HARNameValue{},
+				Headers:     []HARNameValue{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+		}
+
+		if len(record.Body) > 0 {
+			text, encoding := encode(record.Body)
+
+			entry.Request.PostData = &HARPostData{MimeType: record.Header.Get("Content-Type"), Text: text, Encoding: encoding}
+		}
+
+		if response := record.Response; response != nil {
+			text, encoding := encode(response.Body)
+
+			entry.Response.Status = response.Status
+			entry.Response.StatusText = http.StatusText(response.Status)
+			entry.Response.Headers = pairs(response.Header)
+			entry.Response.BodySize = len(response.Body)
+			entry.Response.Content = HARContent{Size: len(response.Body), MimeType: response.Header.Get("Content-Type"), Text: text, Encoding: encoding}
+		}
+
+		document.Log.Entries = append(document.Log.Entries, entry)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+// Import decodes a HAR 1.2 document into records suitable for [Player.Play], such as sessions captured through a browser's developer tools.
+func Import(reader io.Reader) ([]Record, error) {
+	var document HAR
+	if e := json.NewDecoder(reader).Decode(&document); e != nil {
+		return nil, fmt.Errorf("invalid har document: %w", e)
+	}
+
+	if document.Log.Version == "" {
+		return nil, errors.New("invalid har document: missing log version")
+	}
+
+	records := make([]Record, 0, len(document.Log.Entries))
+	for index := range document.Log.Entries {
+		entry := &document.Log.Entries[index]
+
+		target, e := url.Parse(entry.Request.URL)
+		if e != nil {
+			return nil, fmt.Errorf("invalid har entry url (%d): %w", index, e)
+		}
+
+		record := Record{
+			Time:   entry.StartedDateTime,
+			Method: entry.Request.Method,
+			URI:    target.RequestURI(),
+			Host:   target.Host,
+			Header: header(entry.Request.Headers),
+		}
+
+		if data := entry.Request.PostData; data != nil {
+			if record.Body, e = decode(data.Text, data.Encoding); e != nil {
+				return nil, fmt.Errorf("invalid har entry post data (%d): %w", index, e)
+			}
+		}
+
+		if entry.Response.Status != 0 {
+			body, e := decode(entry.Response.Content.Text, entry.Response.Content.Encoding)
+			if e != nil {
+				return nil, fmt.Errorf("invalid har entry response content (%d): %w", index, e)
+			}
+
+			record.Response = &Response{Status: entry.Response.Status, Header: header(entry.Response.Headers), Body: body}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// pairs converts a multi-value map (e.g. [http.Header] or [url.Values]) into HAR name-value pairs.
+func pairs(values map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	// Sort the names to ensure exports are deterministic.
+	sort.Strings(names)
+
+	result := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range values[name] {
+			result = append(result, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	return result
+}
+
+// header converts HAR name-value pairs into an [http.Header]. HTTP/2 pseudo-headers (e.g. ":authority") are skipped.
+func header(values []HARNameValue) http.Header {
+	result := http.Header{}
+	for _, pair := range values {
+		if pair.Name == "" || pair.Name[0] == ':' {
+			continue
+		}
+
+		result.Add(pair.Name, pair.Value)
+	}
+
+	return result
+}
+
+// encode returns the HAR text representation of a body, and its encoding.
+func encode(body []byte) (text string, encoding string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decode reverses [encode].
+func decode(text string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		if text == "" {
+			return nil, nil
+		}
+
+		return []byte(text), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(text)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
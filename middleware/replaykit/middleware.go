@@ -0,0 +1,296 @@
+package replaykit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// Redacted represents the replacement value for sanitized header values.
+const Redacted = "[REDACTED]"
+
+const defaultLimit = 1024 * 64
+
+// Record represents a single recorded request, and optionally its response. See the package documentation for the serialized format.
+type Record struct {
+	Time     time.Time   `json:"time"`
+	Method   string      `json:"method"`
+	URI      string      `json:"uri"`
+	Host     string      `json:"host"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	Response *Response   `json:"response,omitempty"`
+}
+
+// Response represents a recorded, or replayed, response.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// Options represents the configuration settings for the [Recorder] middleware component.
+type Options struct {
+	// Writer represents the recording's destination - typically an [os.File]. Writes are serialized by the middleware. A nil value disables recording.
+	Writer io.Writer
+
+	// Responses specifies whether responses are recorded alongside their requests. Defaults to false.
+	Responses bool
+
+	// Redactions represents the header(s) whose values are replaced with [Redacted]. The casings of these values are ignored.
+	//
+	// Default(s):
+	//
+	//	- "Authorization"
+	//	- "Proxy-Authorization"
+	//	- "Cookie"
+	//	- "Set-Cookie"
+	//	- "X-Api-Key"
+	Redactions []string
+
+	// Limit represents the maximum number of body bytes recorded per request and response. Defaults to 64 KiB.
+	Limit int
+
+	// Sanitize represents an optional, user-provided function that's called with each record prior to serialization, enabling
+	// redaction of sensitive url or body content.
+	Sanitize func(record *Record)
+}
+
+// Recorder represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Recorder struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex sync.Mutex
+}
+
+// Settings applies configuration functions to modify the [Recorder] middleware's [Options] and returns the updated middleware instance.
+func (rc *Recorder) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if rc.options == nil {
+		rc.options = &Options{
+			Writer:     nil,
+			Responses:  false,
+			Redactions: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+			Limit:      defaultLimit,
+			Sanitize:   nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(rc.options)
+		}
+	}
+
+	if rc.options.Limit < 0 {
+		rc.options.Limit = 0
+	}
+
+	return rc
+}
+
+// redact returns a copy of the header with all configured redaction values replaced.
+func (rc *Recorder) redact(header http.Header) http.Header {
+	clone := header.Clone()
+	if clone == nil {
+		clone = http.Header{}
+	}
+
+	for _, name := range rc.options.Redactions {
+		k := http.CanonicalHeaderKey(name)
+		if values, ok := clone[k]; ok {
+			for index := range values {
+				values[index] = Redacted
+			}
+		}
+	}
+
+	return clone
+}
+
+// write serializes the record as a single JSON line.
+func (rc *Recorder) write(record *Record) error {
+	buffer, e := json.Marshal(record)
+	if e != nil {
+		return e
+	}
+
+	buffer = append(buffer, '\n')
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	_, e = rc.options.Writer.Write(buffer)
+
+	return e
+}
+
+// Handler records each request, and optionally its response, to the configured [Options.Writer] once the next handler returns.
+func (rc *Recorder) Handler(next http.Handler) http.Handler {
+	rc.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if rc.options.Writer == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		record := &Record{
+			Time:   time.Now().UTC(),
+			Method: r.Method,
+			URI:    r.URL.RequestURI(),
+			Host:   r.Host,
+			Header: rc.redact(r.Header),
+		}
+
+		// Capture the request body as the next handler consumes it, avoiding any additional buffering of the full body.
+		body := &capture{limit: rc.options.Limit}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &reader{ReadCloser: r.Body, capture: body}
+		}
+
+		var output http.ResponseWriter = w
+		var response *writer
+		if rc.options.Responses {
+			response = &writer{ResponseWriter: w, capture: &capture{limit: rc.options.Limit}}
+
+			output = response
+		}
+
+		defer func() {
+			record.Body = body.Bytes()
+
+			if response != nil {
+				record.Response = &Response{
+					Status: response.status,
+					Header: rc.redact(response.header),
+					Body:   response.capture.Bytes(),
+				}
+
+				if record.Response.Status == 0 {
+					record.Response.Status = http.StatusOK
+				}
+			}
+
+			if rc.options.Sanitize != nil {
+				rc.options.Sanitize(record)
+			}
+
+			if e := rc.write(record); e != nil {
+				slog.ErrorContext(ctx, "Unable to Write Replay Record", slog.String("error", e.Error()))
+			}
+		}()
+
+		next.ServeHTTP(output, r)
+	})
+}
+
+// New creates a new instance of the [Recorder] middleware, implementing [middleware.Configurable]. If [Recorder.Settings] isn't called,
+// then the [Recorder.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Recorder)
+}
+
+// capture is a size-bounded byte buffer.
+type capture struct {
+	bytes.Buffer
+
+	limit int
+}
+
+// Write appends up to the capture's remaining limit, always reporting success.
+func (c *capture) Write(p []byte) (int, error) {
+	if remaining := c.limit - c.Len(); remaining > 0 {
+		c.Buffer.Write(p[:min(len(p), remaining)])
+	}
+
+	return len(p), nil
+}
+
+// Bytes returns the captured bytes, or nil if nothing was captured.
+func (c *capture) Bytes() []byte {
+	if c.Len() == 0 {
+		return nil
+	}
+
+	return bytes.Clone(c.Buffer.Bytes())
+}
+
+// reader tees a request body into a [capture] as it's read.
+type reader struct {
+	io.ReadCloser
+
+	capture *capture
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, e := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.capture.Write(p[:n])
+	}
+
+	return n, e
+}
+
+// writer tees a response into a [capture], recording its status code and header(s).
+type writer struct {
+	http.ResponseWriter
+
+	capture *capture
+	status  int
+	header  http.Header
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.capture.Write(p)
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], recording the response's status and header(s) before flushing them.
+func (w *writer) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Recorder] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Recorder)(nil)
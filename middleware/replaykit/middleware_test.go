@@ -0,0 +1,227 @@
+package replaykit_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/replaykit"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("echo: "))
+		w.Write(body)
+		return
+	})
+
+	record := func(t *testing.T, configuration func(o *replaykit.Options)) []replaykit.Record {
+		var buffer bytes.Buffer
+
+		server := httptest.NewServer(replaykit.New().Settings(func(o *replaykit.Options) {
+			o.Writer = &buffer
+
+			configuration(o)
+		}).Handler(handler))
+
+		defer server.Close()
+
+		for _, payload := range []string{"first", "second"} {
+			request, e := http.NewRequest(http.MethodPost, server.URL+"/v1/echo?key=value", strings.NewReader(payload))
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			request.Header.Set("Authorization", "Bearer secret")
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		records, e := replaykit.Read(&buffer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Reading Records: %v", e)
+		}
+
+		return records
+	}
+
+	t.Run("Record-Requests", func(t *testing.T) {
+		records := record(t, func(o *replaykit.Options) {})
+
+		if len(records) != 2 {
+			t.Fatalf("Records = %d\n    - Expectation = %d", len(records), 2)
+		}
+
+		if v := records[0]; v.Method != http.MethodPost || v.URI != "/v1/echo?key=value" || string(v.Body) != "first" {
+			t.Errorf("Unexpected Record: %+v", v)
+		}
+
+		if v := records[0].Header.Get("Authorization"); v != replaykit.Redacted {
+			t.Errorf("Authorization = %q\n    - Expectation = %q", v, replaykit.Redacted)
+		}
+
+		if records[0].Response != nil {
+			t.Errorf("Unexpected Recorded Response: %+v", records[0].Response)
+		}
+	})
+
+	t.Run("Record-Responses-With-Limit-And-Sanitizer", func(t *testing.T) {
+		records := record(t, func(o *replaykit.Options) {
+			o.Responses = true
+			o.Limit = 4
+			o.Sanitize = func(record *replaykit.Record) {
+				record.URI = strings.Split(record.URI, "?")[0]
+			}
+		})
+
+		response := records[1].Response
+		if response == nil {
+			t.Fatalf("Expected Recorded Response")
+		}
+
+		if response.Status != http.StatusCreated || string(response.Body) != "echo" {
+			t.Errorf("Unexpected Recorded Response: %d %q", response.Status, response.Body)
+		}
+
+		if v := response.Header.Get("Set-Cookie"); v != replaykit.Redacted {
+			t.Errorf("Set-Cookie = %q\n    - Expectation = %q", v, replaykit.Redacted)
+		}
+
+		if string(records[1].Body) != "seco" || records[1].URI != "/v1/echo" {
+			t.Errorf("Unexpected Sanitized Record: %+v", records[1])
+		}
+	})
+
+	t.Run("Play-Handler", func(t *testing.T) {
+		records := record(t, func(o *replaykit.Options) {})
+
+		player := &replaykit.Player{Handler: handler}
+
+		responses, e := player.Play(context.Background(), records)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Replaying: %v", e)
+		}
+
+		if len(responses) != 2 || string(responses[1].Body) != "echo: second" {
+			t.Errorf("Unexpected Replayed Responses: %+v", responses)
+		}
+	})
+
+	t.Run("Play-URL-With-Timing", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+
+		defer server.Close()
+
+		now := time.Now()
+		records := []replaykit.Record{
+			{Time: now, Method: http.MethodPost, URI: "/", Body: []byte("a")},
+			{Time: now.Add(time.Millisecond * 200), Method: http.MethodPost, URI: "/", Body: []byte("b")},
+		}
+
+		player := &replaykit.Player{URL: server.URL, Client: server.Client(), Speed: 2}
+
+		start := time.Now()
+
+		responses, e := player.Play(context.Background(), records)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Replaying: %v", e)
+		}
+
+		if elapsed := time.Since(start); elapsed < time.Millisecond*100 {
+			t.Errorf("Replay Ignored Timing: %s", elapsed)
+		}
+
+		if len(responses) != 2 || responses[0].Status != http.StatusCreated || string(responses[1].Body) != "echo: b" {
+			t.Errorf("Unexpected Replayed Responses: %+v", responses)
+		}
+	})
+
+	t.Run("Play-Missing-Target", func(t *testing.T) {
+		if _, e := new(replaykit.Player).Play(context.Background(), nil); e == nil {
+			t.Errorf("Expected Missing Target Error")
+		}
+	})
+
+	t.Run("Read-Invalid", func(t *testing.T) {
+		if _, e := replaykit.Read(strings.NewReader("{}\n\nnot-json\n")); e == nil {
+			t.Errorf("Expected Invalid Record Error")
+		}
+	})
+
+	t.Run("HAR-Round-Trip", func(t *testing.T) {
+		records := record(t, func(o *replaykit.Options) { o.Responses = true })
+
+		// Include a binary body to ensure base64 encoding is applied.
+		records[1].Body = []byte{0xff, 0xfe, 0x00}
+
+		var buffer bytes.Buffer
+		if e := replaykit.Export(&buffer, records); e != nil {
+			t.Fatalf("Unexpected Error While Exporting HAR: %v", e)
+		}
+
+		imported, e := replaykit.Import(&buffer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Importing HAR: %v", e)
+		}
+
+		if len(imported) != len(records) {
+			t.Fatalf("Imported Records = %d\n    - Expectation = %d", len(imported), len(records))
+		}
+
+		for index := range records {
+			original, v := records[index], imported[index]
+
+			if v.Method != original.Method || v.URI != original.URI || v.Host != original.Host || !(bytes.Equal(v.Body, original.Body)) {
+				t.Errorf("Imported Record = %+v\n    - Expectation = %+v", v, original)
+			}
+
+			if v.Response == nil || v.Response.Status != original.Response.Status || !(bytes.Equal(v.Response.Body, original.Response.Body)) {
+				t.Errorf("Imported Response = %+v\n    - Expectation = %+v", v.Response, original.Response)
+			}
+		}
+	})
+
+	t.Run("HAR-Import-Browser-Capture", func(t *testing.T) {
+		const document = `{"log":{"version":"1.2","creator":{"name":"browser","version":"1"},"entries":[{"startedDateTime":"2024-01-01T00:00:00.000Z","time":12.5,"request":{"method":"GET","url":"https://example.com/v1/users?page=2","httpVersion":"h2","headers":[{"name":":authority","value":"example.com"},{"name":"accept","value":"application/json"}],"queryString":[],"cookies":[],"headersSize":-1,"bodySize":0},"response":{"status":200,"statusText":"OK","httpVersion":"h2","headers":[],"cookies":[],"content":{"size":2,"mimeType":"application/json","text":"e30=","encoding":"base64"},"redirectURL":"","headersSize":-1,"bodySize":-1},"cache":{},"timings":{"send":0,"wait":10,"receive":2.5}}]}}`
+
+		records, e := replaykit.Import(strings.NewReader(document))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Importing HAR: %v", e)
+		}
+
+		if len(records) != 1 {
+			t.Fatalf("Imported Records = %d\n    - Expectation = %d", len(records), 1)
+		}
+
+		v := records[0]
+		if v.URI != "/v1/users?page=2" || v.Host != "example.com" || v.Header.Get("Accept") != "application/json" || v.Header.Get(":authority") != "" {
+			t.Errorf("Unexpected Imported Record: %+v", v)
+		}
+
+		if v.Response == nil || string(v.Response.Body) != "{}" {
+			t.Errorf("Unexpected Imported Response: %+v", v.Response)
+		}
+	})
+
+	t.Run("HAR-Import-Invalid", func(t *testing.T) {
+		if _, e := replaykit.Import(strings.NewReader(`{"log":{}}`)); e == nil {
+			t.Errorf("Expected Missing Version Error")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package replaykit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// Read decodes all JSON Lines [Record] values from the provided reader. Empty lines are skipped.
+func Read(reader io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var record Record
+		if e := json.Unmarshal(scanner.Bytes(), &record); e != nil {
+			return nil, fmt.Errorf("invalid record (line %d): %w", line, e)
+		}
+
+		records = append(records, record)
+	}
+
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+
+	return records, nil
+}
+
+// Player replays recorded [Record] values against an in-process [http.Handler], or a remote url.
+type Player struct {
+	// Handler represents an in-process handler - typically a middleware chain - to replay records against. Takes precedence over [Player.URL].
+	Handler http.Handler
+
+	// URL represents the base url (scheme and authority) replayed requests are sent to when [Player.Handler] is nil.
+	URL string
+
+	// Client represents the [http.Client] used for [Player.URL] replays. Defaults to [http.DefaultClient].
+	Client *http.Client
+
+	// Speed controls the replay's timing relative to the records' original arrival times. A value of 1 preserves the original pacing,
+	// 2 replays twice as fast, and 0 (the default) replays as fast as possible.
+	Speed float64
+}
+
+// Play replays the records in order, returning the observed responses. Replay stops at the first transport error, or once the context is canceled.
+func (p *Player) Play(ctx context.Context, records []Record) ([]Response, error) {
+	if p.Handler == nil && p.URL == "" {
+		return nil, errors.New("player requires either a handler or url")
+	}
+
+	responses := make([]Response, 0, len(records))
+
+	start := time.Now()
+	for index := range records {
+		record := &records[index]
+
+		if p.Speed > 0 && index > 0 {
+			offset := time.Duration(float64(record.Time.Sub(records[0].Time)) / p.Speed)
+
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return responses, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		if e := ctx.Err(); e != nil {
+			return responses, e
+		}
+
+		response, e := p.play(ctx, record)
+		if e != nil {
+			return responses, fmt.Errorf("unable to replay record (%d): %w", index, e)
+		}
+
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+// play replays a single record.
+func (p *Player) play(ctx context.Context, record *Record) (*Response, error) {
+	target := record.URI
+	if p.Handler == nil {
+		target = strings.TrimSuffix(p.URL, "/") + record.URI
+	}
+
+	request, e := http.NewRequestWithContext(ctx, record.Method, target, bytes.NewReader(record.Body))
+	if e != nil {
+		return nil, e
+	}
+
+	request.Header = record.Header.Clone()
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+
+	if record.Host != "" {
+		request.Host = record.Host
+	}
+
+	if p.Handler != nil {
+		request.RemoteAddr = "127.0.0.1:0"
+
+		recorder := httptest.NewRecorder()
+
+		p.Handler.ServeHTTP(recorder, request)
+
+		return &Response{Status: recorder.Code, Header: recorder.Header(), Body: recorder.Body.Bytes()}, nil
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		return nil, e
+	}
+
+	defer response.Body.Close()
+
+	body, e := io.ReadAll(response.Body)
+	if e != nil {
+		return nil, e
+	}
+
+	return &Response{Status: response.StatusCode, Header: response.Header, Body: body}, nil
+}
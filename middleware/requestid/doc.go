@@ -0,0 +1,7 @@
+// Package requestid provides middleware for ensuring every request carries an identifier - accepting the client's
+// "X-Request-ID" header, or generating a [UUID] or [ULID] in its absence - stored in the request context and optionally echoed
+// as a response header.
+//
+// The middleware should be chained before the telemetrics middleware, such that generated identifiers are captured as
+// telemetry header(s).
+package requestid
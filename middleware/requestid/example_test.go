@@ -0,0 +1,59 @@
+package requestid_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	// The request-id middleware is added before telemetrics, such that generated identifiers are captured, too.
+	middleware.Add(requestid.New().Handler)
+	middleware.Add(telemetrics.New().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		fmt.Println("Captured:", telemetrics.Value(ctx).Headers.Get("X-Request-ID") == requestid.Value(ctx))
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("X-Request-ID", "example-request-id")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	fmt.Println("Echoed:", response.Header.Get("X-Request-ID"))
+
+	// Output:
+	// Captured: true
+	// Echoed: example-request-id
+}
@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+// crockford represents Crockford's base32 alphabet, as used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// UUID generates a random, RFC 9562 version 4 UUID (e.g. "0f8fad5b-d9cb-469f-a165-70867728950e").
+func UUID() string {
+	var b [16]byte
+
+	rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4.
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10.
+
+	var buffer [36]byte
+
+	hex.Encode(buffer[0:8], b[0:4])
+	buffer[8] = '-'
+	hex.Encode(buffer[9:13], b[4:6])
+	buffer[13] = '-'
+	hex.Encode(buffer[14:18], b[6:8])
+	buffer[18] = '-'
+	hex.Encode(buffer[19:23], b[8:10])
+	buffer[23] = '-'
+	hex.Encode(buffer[24:], b[10:])
+
+	return string(buffer[:])
+}
+
+// ULID generates a lexicographically sortable identifier (e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV"), composed of a 48-bit millisecond
+// timestamp and 80 random bits.
+func ULID() string {
+	var b [16]byte
+
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+
+	rand.Read(b[6:])
+
+	// Encode the 128 bits as 26 base32 characters, the leading character carrying the 3 most significant bits.
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+
+	var buffer [26]byte
+	for index := len(buffer) - 1; index >= 0; index-- {
+		buffer[index] = crockford[lo&0x1f]
+
+		lo = (lo >> 5) | (hi << 59)
+		hi >>= 5
+	}
+
+	return string(buffer[:])
+}
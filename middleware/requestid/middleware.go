@@ -0,0 +1,153 @@
+package requestid
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "request-id"
+
+// Options represents the configuration settings for the [RequestID] middleware component.
+type Options struct {
+	// Header represents the request header carrying the request's identifier, and, if [Options.Echo] is enabled, the response
+	// header echoing it. Defaults to "X-Request-ID".
+	Header string
+
+	// Generator generates identifiers for requests without a valid [Options.Header]. See [UUID] and [ULID]. Defaults to [UUID].
+	Generator func() string
+
+	// Trust enables accepting the client-provided [Options.Header] value; otherwise, an identifier is always generated. Defaults
+	// to true.
+	Trust bool
+
+	// Limit represents the maximum length of an accepted, client-provided identifier. Longer identifiers, or those containing
+	// characters other than printable ASCII, are replaced with a generated one. Defaults to 128.
+	Limit int
+
+	// Echo enables setting the request's identifier as an [Options.Header] response header. Defaults to true.
+	Echo bool
+}
+
+// RequestID represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type RequestID struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [RequestID] middleware's [Options] and returns the updated middleware instance.
+func (x *RequestID) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Header:    "X-Request-ID",
+			Generator: UUID,
+			Trust:     true,
+			Limit:     128,
+			Echo:      true,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Header == "" {
+		slog.Warn("Invalid Request-ID Header Specified - Using Default Header")
+
+		x.options.Header = "X-Request-ID"
+	}
+
+	if x.options.Generator == nil {
+		slog.Warn("Invalid Request-ID Generator Specified - Using Default Generator")
+
+		x.options.Generator = UUID
+	}
+
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Request-ID Limit Specified - Using Default Limit")
+
+		x.options.Limit = 128
+	}
+
+	return x
+}
+
+// valid reports whether a client-provided identifier is acceptable.
+func (x *RequestID) valid(value string) bool {
+	if value == "" || len(value) > x.options.Limit {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		if c := value[index]; c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Handler ensures every request carries an identifier - accepting a valid, client-provided [Options.Header] if trusted, and
+// generating one otherwise - storing it in the request context and the request's [Options.Header]. Because the request header
+// is updated, middleware chained after [RequestID] (e.g. telemetrics) observes generated identifiers, too.
+func (x *RequestID) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		value := r.Header.Get(x.options.Header)
+		if !(x.options.Trust) || !(x.valid(value)) {
+			value = x.options.Generator()
+
+			// Avoid mutating the caller's request; downstream handlers receive the updated header(s).
+			r = r.Clone(ctx)
+
+			r.Header.Set(x.options.Header, value)
+		}
+
+		if x.options.Echo {
+			w.Header().Set(x.options.Header, value)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, value)))
+	})
+}
+
+// New creates a new instance of the [RequestID] middleware, implementing [middleware.Configurable]. If [RequestID.Settings] isn't
+// called, then the [RequestID.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(RequestID)
+}
+
+// Value retrieves the request's identifier from the provided context. If an empty string is returned, it can be assumed that the
+// [RequestID] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [RequestID] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*RequestID)(nil)
@@ -0,0 +1,112 @@
+package requestid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/requestid"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+func Test(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulid := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+
+	tests := []struct {
+		name        string
+		header      string
+		settings    func(o *requestid.Options)
+		expectation *regexp.Regexp
+		echo        bool
+	}{
+		{name: "Generated-UUID", header: "", settings: nil, expectation: uuid, echo: true},
+		{name: "Generated-ULID", header: "", settings: func(o *requestid.Options) { o.Generator = requestid.ULID }, expectation: ulid, echo: true},
+		{name: "Client-Provided", header: "client-request-id", settings: nil, expectation: regexp.MustCompile(`^client-request-id$`), echo: true},
+		{name: "Untrusted", header: "client-request-id", settings: func(o *requestid.Options) { o.Trust = false }, expectation: uuid, echo: true},
+		{name: "Invalid-Characters", header: "client request id", settings: nil, expectation: uuid, echo: true},
+		{name: "Exceeds-Limit", header: strings.Repeat("a", 129), settings: nil, expectation: uuid, echo: true},
+		{name: "Echo-Disabled", header: "", settings: func(o *requestid.Options) { o.Echo = false }, expectation: uuid, echo: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var value, header string
+
+			handler := requestid.New().Settings(test.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				value = requestid.Value(r.Context())
+				header = r.Header.Get("X-Request-ID")
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				request.Header.Set("X-Request-ID", test.header)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if !(test.expectation.MatchString(value)) {
+				t.Errorf("Value = %s\n    - Expectation = %s", value, test.expectation)
+			}
+
+			if header != value {
+				t.Errorf("Request Header = %s\n    - Expectation = %s", header, value)
+			}
+
+			if v := request.Header.Get("X-Request-ID"); v != test.header {
+				t.Errorf("Caller's Request Header = %s\n    - Expectation = %s", v, test.header)
+			}
+
+			echo := recorder.Header().Get("X-Request-ID")
+			if test.echo && echo != value {
+				t.Errorf("Response Header = %s\n    - Expectation = %s", echo, value)
+			} else if !(test.echo) && echo != "" {
+				t.Errorf("Unexpected Response Header: %s", echo)
+			}
+		})
+	}
+
+	t.Run("ULID-Ordering", func(t *testing.T) {
+		previous := requestid.ULID()
+		for range 10 {
+			current := requestid.ULID()
+			if current[:10] < previous[:10] {
+				t.Errorf("ULID Timestamp = %s\n    - Expectation = >= %s", current[:10], previous[:10])
+			}
+
+			previous = current
+		}
+	})
+
+	t.Run("Telemetrics", func(t *testing.T) {
+		var value string
+		var headers http.Header
+
+		handler := requestid.New().Handler(telemetrics.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value = requestid.Value(r.Context())
+			headers = telemetrics.Value(r.Context()).Headers
+		})))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := headers.Get("X-Request-ID"); v == "" || v != value {
+			t.Errorf("Telemetry Header = %s\n    - Expectation = %s", v, value)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := requestid.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "testing-request-id")
+		if v := requestid.Value(ctx); v != "testing-request-id" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+// Package resumable provides middleware implementing a subset of the [tus] resumable upload protocol (version 1.0.0) over a
+// pluggable [Store]: the core protocol (HEAD offset discovery, and PATCH appends), along with the creation, checksum, expiration,
+// and termination extensions.
+//
+// Uploads are owned by a tenant - by default, the subject of the token verified by the [authentication] middleware, which should
+// precede the [Resumable] middleware in the chain. Requests for another tenant's upload are answered as if the upload didn't exist.
+// Per-tenant limits - the maximum size of an upload, and the tenant's total storage - are resolved through [Options.Quota], such
+// that they can be sourced from any quota system.
+//
+// Requests to the upload path that aren't part of the protocol (e.g. a GET of an upload) are forwarded to the next handler, with
+// the upload available through [Value].
+//
+// [tus]: https://tus.io/protocols/resumable-upload
+// [authentication]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/authentication
+package resumable
@@ -0,0 +1,80 @@
+package resumable_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/resumable"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(resumable.New().Settings(func(o *resumable.Options) {
+		o.Store = new(resumable.Memory)
+		o.Tenant = func(r *http.Request) string {
+			return r.Header.Get("X-API-Key")
+		}
+
+		o.Complete = func(ctx context.Context, upload *resumable.Upload) error {
+			fmt.Printf("Completed %s (%d Bytes)\n", upload.Metadata["filename"], upload.Length)
+
+			return nil
+		}
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(http.NotFoundHandler()))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	// exchange performs a tus protocol request.
+	exchange := func(method, url string, headers map[string]string, body string) *http.Response {
+		request, e := http.NewRequest(method, url, strings.NewReader(body))
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("Tus-Resumable", "1.0.0")
+		request.Header.Set("X-API-Key", "tenant")
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		return response
+	}
+
+	creation := exchange(http.MethodPost, server.URL+"/files/", map[string]string{"Upload-Length": "11", "Upload-Metadata": "filename aGVsbG8udHh0"}, "")
+
+	fmt.Printf("Creation: %d\n", creation.StatusCode)
+
+	location := server.URL + creation.Header.Get("Location")
+
+	for _, chunk := range []struct{ offset, body string }{{"0", "hello"}, {"5", " world"}} {
+		response := exchange(http.MethodPatch, location, map[string]string{"Content-Type": "application/offset+octet-stream", "Upload-Offset": chunk.offset}, chunk.body)
+
+		fmt.Printf("Append: %d (Offset %s)\n", response.StatusCode, response.Header.Get("Upload-Offset"))
+	}
+
+	// Output:
+	// Creation: 201
+	// Append: 204 (Offset 5)
+	// Completed hello.txt (11 Bytes)
+	// Append: 204 (Offset 11)
+}
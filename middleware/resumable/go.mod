@@ -0,0 +1,13 @@
+module github.com/poly-gun/go-middleware/middleware/resumable
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
+
+replace github.com/poly-gun/go-middleware/middleware/authentication => ../authentication
+
+require github.com/poly-gun/go-middleware/middleware/authentication v0.0.7
+
+require github.com/golang-jwt/jwt/v5 v5.2.1
@@ -0,0 +1,721 @@
+package resumable
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "resumable"
+
+// version represents the supported tus protocol version.
+const version = "1.0.0"
+
+// StatusChecksumMismatch represents the tus checksum extension's status code, for appends whose checksum doesn't match.
+const StatusChecksumMismatch = 460
+
+// algorithms represents the supported checksum algorithms.
+var algorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// Quota represents a tenant's upload limits. A zero field is unlimited.
+type Quota struct {
+	// Size represents the maximum length, in bytes, of an upload. A zero value falls back to [Options.Size].
+	Size int64
+
+	// Storage represents the maximum combined length, in bytes, of the tenant's uploads - see [Store.Usage].
+	Storage int64
+}
+
+// Options represents the configuration settings for the [Resumable] middleware component.
+type Options struct {
+	// Store represents the uploads' storage backend. A nil value rejects every protocol request with a [http.StatusInternalServerError]
+	// response. Defaults to nil.
+	Store Store
+
+	// Path represents the path prefix the protocol is served under: uploads are created with a POST to the path, and addressed as
+	// the path followed by their identifier. Defaults to "/files/".
+	Path string
+
+	// Tenant returns the request's tenant, which owns the uploads it creates. An empty return value attributes the request to
+	// "anonymous". Defaults to a function returning the subject of the token verified by the authentication middleware.
+	Tenant func(r *http.Request) string
+
+	// Quota optionally returns the tenant's upload limits - e.g. as sourced from a quota, or billing, system. Creations exceeding
+	// either limit receive a [http.StatusRequestEntityTooLarge] response. Defaults to nil - every tenant is limited by [Options.Size].
+	Quota func(ctx context.Context, tenant string) (Quota, error)
+
+	// Size represents the maximum length, in bytes, of an upload (advertised as "Tus-Max-Size"). Defaults to 1 GiB.
+	Size int64
+
+	// Buffer represents the maximum size, in bytes, of an append carrying an "Upload-Checksum" header. Such appends are buffered in
+	// memory, such that a mismatching chunk is never written. Defaults to 32 MiB.
+	Buffer int64
+
+	// Expiration represents the duration, after an upload's creation, after which an incomplete upload is discarded. Defaults to 24 hours.
+	Expiration time.Duration
+
+	// Complete is optionally called once an upload's final byte is received. An error is reported to the client as a
+	// [http.StatusInternalServerError] response; the client may then retry its final (empty) append. Defaults to nil.
+	Complete func(ctx context.Context, upload *Upload) error
+
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Resumable represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Resumable struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	locks locks
+}
+
+// tenant is the default [Options.Tenant] function.
+func tenant(r *http.Request) string {
+	if v := authentication.Value(r.Context()); v != nil && v.Token != nil && v.Token.Claims != nil {
+		if subject, e := v.Token.Claims.GetSubject(); e == nil {
+			return subject
+		}
+	}
+
+	return ""
+}
+
+// Settings applies configuration functions to modify the [Resumable] middleware's [Options] and returns the updated middleware instance.
+func (x *Resumable) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Store:      nil,
+			Path:       "/files/",
+			Tenant:     tenant,
+			Quota:      nil,
+			Size:       1 << 30,
+			Buffer:     32 << 20,
+			Expiration: time.Hour * 24,
+			Complete:   nil,
+			Clock:      time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if !(strings.HasPrefix(x.options.Path, "/")) {
+		slog.Warn("Invalid Resumable Path Specified - Using Default Path")
+
+		x.options.Path = "/files/"
+	} else if !(strings.HasSuffix(x.options.Path, "/")) {
+		x.options.Path += "/"
+	}
+
+	if x.options.Tenant == nil {
+		x.options.Tenant = tenant
+	}
+
+	if x.options.Size <= 0 {
+		slog.Warn("Invalid Resumable Size Specified - Using Default Size")
+
+		x.options.Size = 1 << 30
+	}
+
+	if x.options.Buffer <= 0 {
+		slog.Warn("Invalid Resumable Buffer Specified - Using Default Buffer")
+
+		x.options.Buffer = 32 << 20
+	}
+
+	if x.options.Expiration <= 0 {
+		slog.Warn("Invalid Resumable Expiration Specified - Using Default Expiration")
+
+		x.options.Expiration = time.Hour * 24
+	}
+
+	if x.options.Clock == nil {
+		x.options.Clock = time.Now
+	}
+
+	return x
+}
+
+// lock represents a reference-counted [locks] entry.
+type lock struct {
+	sync.Mutex
+
+	references int
+}
+
+// locks serializes operations on the same key (an upload, or a tenant), without retaining entries for idle keys.
+type locks struct {
+	mutex   sync.Mutex
+	entries map[string]*lock
+}
+
+// acquire locks the key, returning its release function. If wait is false and the key is already locked, false is returned.
+func (l *locks) acquire(key string, wait bool) (release func(), ok bool) {
+	l.mutex.Lock()
+	if l.entries == nil {
+		l.entries = make(map[string]*lock)
+	}
+
+	entry, found := l.entries[key]
+	if !(found) {
+		entry = new(lock)
+		l.entries[key] = entry
+	}
+
+	entry.references++
+	l.mutex.Unlock()
+
+	dereference := func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		if entry.references--; entry.references == 0 {
+			delete(l.entries, key)
+		}
+	}
+
+	if wait {
+		entry.Lock()
+	} else if !(entry.TryLock()) {
+		dereference()
+
+		return nil, false
+	}
+
+	return func() {
+		entry.Unlock()
+
+		dereference()
+	}, true
+}
+
+// identifier returns a random, 32-character hex upload identifier.
+func identifier() string {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+
+	return hex.EncodeToString(buffer)
+}
+
+// metadata parses an "Upload-Metadata" header value: comma-separated pairs of a key, and an optional base64-encoded value.
+func metadata(value string) (map[string]string, bool) {
+	if strings.TrimSpace(value) == "" {
+		return nil, true
+	}
+
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, false
+		}
+
+		var decoded []byte
+		if len(fields) == 2 {
+			v, e := base64.StdEncoding.DecodeString(fields[1])
+			if e != nil {
+				return nil, false
+			}
+
+			decoded = v
+		}
+
+		pairs[fields[0]] = string(decoded)
+	}
+
+	return pairs, true
+}
+
+// encode formats the metadata as an "Upload-Metadata" header value.
+func encode(pairs map[string]string) string {
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if pairs[k] == "" {
+			values = append(values, k)
+		} else {
+			values = append(values, k+" "+base64.StdEncoding.EncodeToString([]byte(pairs[k])))
+		}
+	}
+
+	return strings.Join(values, ",")
+}
+
+// reject writes a protocol error response, recording the rejection's reason.
+func (x *Resumable) reject(w http.ResponseWriter, r *http.Request, status int, code string) {
+	reject.Record(r.Context(), reject.Reason{Subsystem: "resumable", Code: code, Status: status})
+
+	message := http.StatusText(status)
+	if status == StatusChecksumMismatch {
+		message = "Checksum Mismatch"
+	}
+
+	http.Error(w, message, status)
+}
+
+// upload returns the tenant's upload identified by id. An unknown upload, or another tenant's upload, is reported as
+// [http.StatusNotFound]; an expired, incomplete upload is discarded, and reported as [http.StatusGone].
+func (x *Resumable) upload(r *http.Request, id string) (*Upload, int) {
+	ctx := r.Context()
+
+	upload, e := x.options.Store.Info(ctx, id)
+	if errors.Is(e, ErrNotFound) {
+		return nil, http.StatusNotFound
+	} else if e != nil {
+		slog.ErrorContext(ctx, "Unable to Retrieve Resumable Upload", slog.String("id", id), slog.String("error", e.Error()))
+
+		return nil, http.StatusInternalServerError
+	}
+
+	if upload.Tenant != x.tenant(r) {
+		return nil, http.StatusNotFound
+	}
+
+	if !(upload.Complete()) && !(x.options.Clock().Before(upload.Expires)) {
+		if e := x.options.Store.Delete(ctx, id); e != nil {
+			slog.WarnContext(ctx, "Unable to Delete Expired Resumable Upload", slog.String("id", id), slog.String("error", e.Error()))
+		}
+
+		return nil, http.StatusGone
+	}
+
+	return upload, http.StatusOK
+}
+
+// tenant returns the request's tenant. See [Options.Tenant].
+func (x *Resumable) tenant(r *http.Request) string {
+	if v := x.options.Tenant(r); v != "" {
+		return v
+	}
+
+	return "anonymous"
+}
+
+// complete calls the [Options.Complete] hook, if the upload is complete.
+func (x *Resumable) complete(ctx context.Context, upload *Upload) error {
+	if !(upload.Complete()) || x.options.Complete == nil {
+		return nil
+	}
+
+	return x.options.Complete(ctx, upload)
+}
+
+// capabilities writes the protocol's capabilities, in response to an OPTIONS request.
+func (x *Resumable) capabilities(w http.ResponseWriter) {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	w.Header().Set("Tus-Version", version)
+	w.Header().Set("Tus-Extension", "creation,checksum,expiration,termination")
+	w.Header().Set("Tus-Max-Size", strconv.FormatInt(x.options.Size, 10))
+	w.Header().Set("Tus-Checksum-Algorithm", strings.Join(names, ","))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// create implements the creation extension.
+func (x *Resumable) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	length, e := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if e != nil || length < 0 {
+		x.reject(w, r, http.StatusBadRequest, "length-invalid")
+
+		return
+	}
+
+	pairs, valid := metadata(r.Header.Get("Upload-Metadata"))
+	if !(valid) {
+		x.reject(w, r, http.StatusBadRequest, "metadata-invalid")
+
+		return
+	}
+
+	owner := x.tenant(r)
+
+	quota := Quota{Size: x.options.Size}
+	if x.options.Quota != nil {
+		if quota, e = x.options.Quota(ctx, owner); e != nil {
+			slog.ErrorContext(ctx, "Unable to Resolve Resumable Upload Quota", slog.String("tenant", owner), slog.String("error", e.Error()))
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		} else if quota.Size <= 0 {
+			quota.Size = x.options.Size
+		}
+	}
+
+	if length > quota.Size {
+		x.reject(w, r, http.StatusRequestEntityTooLarge, "size-exceeded")
+
+		return
+	}
+
+	// Serialize the tenant's creations, such that concurrent creations can't jointly exceed its storage quota.
+	release, _ := x.locks.acquire("tenant:"+owner, true)
+
+	defer release()
+
+	if quota.Storage > 0 {
+		usage, e := x.options.Store.Usage(ctx, owner, x.options.Clock())
+		if e != nil {
+			slog.ErrorContext(ctx, "Unable to Retrieve Resumable Upload Usage", slog.String("tenant", owner), slog.String("error", e.Error()))
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		if usage+length > quota.Storage {
+			x.reject(w, r, http.StatusRequestEntityTooLarge, "quota-exceeded")
+
+			return
+		}
+	}
+
+	now := x.options.Clock()
+
+	upload := &Upload{ID: identifier(), Tenant: owner, Length: length, Metadata: pairs, Created: now, Expires: now.Add(x.options.Expiration)}
+	if e := x.options.Store.Create(ctx, upload); e != nil {
+		slog.ErrorContext(ctx, "Unable to Create Resumable Upload", slog.String("tenant", owner), slog.String("error", e.Error()))
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	if e := x.complete(ctx, upload); e != nil {
+		slog.ErrorContext(ctx, "Unable to Complete Resumable Upload", slog.String("id", upload.ID), slog.String("error", e.Error()))
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Location", x.options.Path+upload.ID)
+	w.Header().Set("Upload-Expires", upload.Expires.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// head implements offset discovery.
+func (x *Resumable) head(w http.ResponseWriter, r *http.Request, id string) {
+	upload, status := x.upload(r, id)
+	if upload == nil {
+		w.WriteHeader(status)
+
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+
+	if len(upload.Metadata) > 0 {
+		w.Header().Set("Upload-Metadata", encode(upload.Metadata))
+	}
+
+	if !(upload.Complete()) {
+		w.Header().Set("Upload-Expires", upload.Expires.UTC().Format(http.TimeFormat))
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// patch implements appends, including the checksum extension.
+func (x *Resumable) patch(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	if r.Header.Get("Content-Type") != "application/offset+octet-stream" {
+		x.reject(w, r, http.StatusUnsupportedMediaType, "content-type-invalid")
+
+		return
+	}
+
+	offset, e := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if e != nil || offset < 0 {
+		x.reject(w, r, http.StatusBadRequest, "offset-invalid")
+
+		return
+	}
+
+	var checksum hash.Hash
+	var digest []byte
+	if v := r.Header.Get("Upload-Checksum"); v != "" {
+		name, encoded, _ := strings.Cut(v, " ")
+
+		constructor, supported := algorithms[name]
+		if !(supported) {
+			x.reject(w, r, http.StatusBadRequest, "checksum-unsupported")
+
+			return
+		}
+
+		if digest, e = base64.StdEncoding.DecodeString(encoded); e != nil {
+			x.reject(w, r, http.StatusBadRequest, "checksum-invalid")
+
+			return
+		}
+
+		checksum = constructor()
+	}
+
+	release, ok := x.locks.acquire("upload:"+id, false)
+	if !(ok) {
+		x.reject(w, r, http.StatusLocked, "upload-locked")
+
+		return
+	}
+
+	defer release()
+
+	upload, status := x.upload(r, id)
+	if upload == nil {
+		w.WriteHeader(status)
+
+		return
+	}
+
+	if offset != upload.Offset {
+		x.reject(w, r, http.StatusConflict, "offset-mismatch")
+
+		return
+	}
+
+	remaining := upload.Length - upload.Offset
+	if r.ContentLength > remaining {
+		x.reject(w, r, http.StatusRequestEntityTooLarge, "length-exceeded")
+
+		return
+	}
+
+	var body io.Reader = io.LimitReader(r.Body, remaining)
+	if checksum != nil {
+		code := "length-exceeded"
+		if remaining > x.options.Buffer {
+			body, code = io.LimitReader(r.Body, x.options.Buffer), "buffer-exceeded"
+		}
+
+		var buffer bytes.Buffer
+		if _, e := io.Copy(io.MultiWriter(&buffer, checksum), body); e != nil {
+			x.reject(w, r, http.StatusBadRequest, "body-unreadable")
+
+			return
+		}
+
+		if excess, _ := r.Body.Read(make([]byte, 1)); excess > 0 {
+			x.reject(w, r, http.StatusRequestEntityTooLarge, code)
+
+			return
+		}
+
+		if !(bytes.Equal(checksum.Sum(nil), digest)) {
+			x.reject(w, r, StatusChecksumMismatch, "checksum-mismatch")
+
+			return
+		}
+
+		body = &buffer
+	}
+
+	n, e := x.options.Store.Append(ctx, id, offset, body)
+
+	upload.Offset += n
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+
+	if e != nil {
+		slog.WarnContext(ctx, "Resumable Upload Append Interrupted", slog.String("id", id), slog.Int64("offset", upload.Offset), slog.String("error", e.Error()))
+
+		if errors.Is(e, ErrOffset) {
+			x.reject(w, r, http.StatusConflict, "offset-mismatch")
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	if excess, _ := r.Body.Read(make([]byte, 1)); excess > 0 {
+		x.reject(w, r, http.StatusRequestEntityTooLarge, "length-exceeded")
+
+		return
+	}
+
+	if e := x.complete(ctx, upload); e != nil {
+		slog.ErrorContext(ctx, "Unable to Complete Resumable Upload", slog.String("id", id), slog.String("error", e.Error()))
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	if !(upload.Complete()) {
+		w.Header().Set("Upload-Expires", upload.Expires.UTC().Format(http.TimeFormat))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// terminate implements the termination extension.
+func (x *Resumable) terminate(w http.ResponseWriter, r *http.Request, id string) {
+	release, ok := x.locks.acquire("upload:"+id, false)
+	if !(ok) {
+		x.reject(w, r, http.StatusLocked, "upload-locked")
+
+		return
+	}
+
+	defer release()
+
+	if upload, status := x.upload(r, id); upload == nil {
+		w.WriteHeader(status)
+
+		return
+	}
+
+	if e := x.options.Store.Delete(r.Context(), id); e != nil {
+		slog.ErrorContext(r.Context(), "Unable to Delete Resumable Upload", slog.String("id", id), slog.String("error", e.Error()))
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Handler serves the tus protocol's requests under [Options.Path]. Other requests under the path - e.g. a GET of an upload - are
+// forwarded to the next handler, with the tenant's upload (if any) stored in the request context; requests outside of the path are
+// forwarded unchanged.
+func (x *Resumable) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collection := strings.TrimSuffix(x.options.Path, "/")
+
+		var id string
+		switch {
+		case r.URL.Path == collection || r.URL.Path == x.options.Path:
+		case strings.HasPrefix(r.URL.Path, x.options.Path) && !(strings.Contains(strings.TrimPrefix(r.URL.Path, x.options.Path), "/")):
+			id = strings.TrimPrefix(r.URL.Path, x.options.Path)
+		default:
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		protocol := r.Method == http.MethodOptions || (id == "" && r.Method == http.MethodPost) || (id != "" && (r.Method == http.MethodHead || r.Method == http.MethodPatch || r.Method == http.MethodDelete))
+		if !(protocol) {
+			if id != "" && x.options.Store != nil {
+				if upload, _ := x.upload(r, id); upload != nil {
+					r = r.WithContext(context.WithValue(r.Context(), key, upload))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Set("Tus-Resumable", version)
+
+		if r.Method == http.MethodOptions {
+			x.capabilities(w)
+
+			return
+		}
+
+		if r.Header.Get("Tus-Resumable") != version {
+			w.Header().Set("Tus-Version", version)
+
+			x.reject(w, r, http.StatusPreconditionFailed, "version-unsupported")
+
+			return
+		}
+
+		if x.options.Store == nil {
+			slog.ErrorContext(r.Context(), "Resumable Upload Store Not Configured")
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			x.create(w, r)
+		case http.MethodHead:
+			x.head(w, r, id)
+		case http.MethodPatch:
+			x.patch(w, r, id)
+		case http.MethodDelete:
+			x.terminate(w, r, id)
+		}
+	})
+}
+
+// New creates a new instance of the [Resumable] middleware, implementing [middleware.Configurable]. If [Resumable.Settings] isn't called,
+// then the [Resumable.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Resumable)
+}
+
+// Value retrieves the request's [Upload] from the provided context - see [Resumable.Handler]. A nil value is returned if the request
+// doesn't address one of the tenant's uploads, or if the [Resumable] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Upload) {
+	if v, ok := ctx.Value(key).(*Upload); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Resumable Upload Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Resumable] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Resumable)(nil)
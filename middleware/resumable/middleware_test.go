@@ -0,0 +1,259 @@
+package resumable_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/poly-gun/go-middleware/middleware/authentication"
+	"github.com/poly-gun/go-middleware/middleware/resumable"
+)
+
+// exchange performs a request against the handler, on behalf of the tenant.
+func exchange(handler http.Handler, tenant, method, path string, headers map[string]string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Tus-Resumable", "1.0.0")
+	request.Header.Set("X-Tenant", tenant)
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	if body != "" {
+		request.Header.Set("Content-Type", "application/offset+octet-stream")
+	}
+
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+// checksum returns the "Upload-Checksum" header value of the content.
+func checksum(content string) string {
+	digest := sha1.Sum([]byte(content))
+
+	return "sha1 " + base64.StdEncoding.EncodeToString(digest[:])
+}
+
+func Test(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// setup returns the middleware's handler, its store, and the uploads the handler received.
+	setup := func(settings func(o *resumable.Options)) (http.Handler, *resumable.Memory, chan *resumable.Upload) {
+		store := new(resumable.Memory)
+		completed := make(chan *resumable.Upload, 1)
+
+		handler := resumable.New().Settings(func(o *resumable.Options) {
+			o.Store = store
+			o.Tenant = func(r *http.Request) string { return r.Header.Get("X-Tenant") }
+			o.Clock = func() time.Time { return now }
+			o.Complete = func(ctx context.Context, upload *resumable.Upload) error {
+				completed <- upload
+				return nil
+			}
+		}, settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if upload := resumable.Value(r.Context()); upload != nil {
+				w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+			}
+
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+		return handler, store, completed
+	}
+
+	t.Run("Protocol", func(t *testing.T) {
+		handler, store, completed := setup(nil)
+
+		options := exchange(handler, "acme", http.MethodOptions, "/files/", nil, "")
+		if v := options.Header().Get("Tus-Extension"); v != "creation,checksum,expiration,termination" {
+			t.Errorf("Tus-Extension = %s\n    - Expectation = %s", v, "creation,checksum,expiration,termination")
+		}
+
+		creation := exchange(handler, "acme", http.MethodPost, "/files", map[string]string{"Upload-Length": "11", "Upload-Metadata": "filename aGVsbG8udHh0,private"}, "")
+		if creation.Code != http.StatusCreated {
+			t.Fatalf("Creation Status = %d\n    - Expectation = %d", creation.Code, http.StatusCreated)
+		}
+
+		location := creation.Header().Get("Location")
+		if !(strings.HasPrefix(location, "/files/")) {
+			t.Fatalf("Location = %s\n    - Expectation = /files/*", location)
+		}
+
+		steps := []struct {
+			name    string
+			method  string
+			headers map[string]string
+			body    string
+			status  int
+			offset  string
+		}{
+			{name: "Offset", method: http.MethodHead, status: http.StatusOK, offset: "0"},
+			{name: "Append", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "0", "Upload-Checksum": checksum("hello")}, body: "hello", status: http.StatusNoContent, offset: "5"},
+			{name: "Offset-Conflict", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "0"}, body: "hello", status: http.StatusConflict},
+			{name: "Checksum-Mismatch", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "5", "Upload-Checksum": checksum("other")}, body: " world", status: resumable.StatusChecksumMismatch},
+			{name: "Checksum-Unsupported", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "5", "Upload-Checksum": "crc32 AAAAAA=="}, body: " world", status: http.StatusBadRequest},
+			{name: "Offset-Unchanged", method: http.MethodHead, status: http.StatusOK, offset: "5"},
+			{name: "Excess", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "5"}, body: " world, again", status: http.StatusRequestEntityTooLarge},
+			{name: "Final-Append", method: http.MethodPatch, headers: map[string]string{"Upload-Offset": "5"}, body: " world", status: http.StatusNoContent, offset: "11"},
+			{name: "Passthrough", method: http.MethodGet, status: http.StatusTeapot, offset: "11"},
+		}
+
+		for _, step := range steps {
+			recorder := exchange(handler, "acme", step.method, location, step.headers, step.body)
+			if recorder.Code != step.status {
+				t.Fatalf("%s Status = %d\n    - Expectation = %d", step.name, recorder.Code, step.status)
+			}
+
+			if v := recorder.Header().Get("Upload-Offset"); step.offset != "" && v != step.offset {
+				t.Errorf("%s Upload-Offset = %s\n    - Expectation = %s", step.name, v, step.offset)
+			}
+		}
+
+		select {
+		case upload := <-completed:
+			if upload.Metadata["filename"] != "hello.txt" {
+				t.Errorf("Metadata = %v\n    - Expectation = %s", upload.Metadata, "hello.txt")
+			}
+		default:
+			t.Fatalf("Complete Wasn't Called")
+		}
+
+		if data, _ := store.Data(strings.TrimPrefix(location, "/files/")); string(data) != "hello world" {
+			t.Errorf("Data = %q\n    - Expectation = %q", string(data), "hello world")
+		}
+	})
+
+	t.Run("Version-Unsupported", func(t *testing.T) {
+		handler, _, _ := setup(nil)
+
+		recorder := exchange(handler, "acme", http.MethodPost, "/files/", map[string]string{"Upload-Length": "1", "Tus-Resumable": "0.2.2"}, "")
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusPreconditionFailed)
+		}
+	})
+
+	t.Run("Tenant-Isolation", func(t *testing.T) {
+		handler, _, _ := setup(nil)
+
+		location := exchange(handler, "acme", http.MethodPost, "/files/", map[string]string{"Upload-Length": "5"}, "").Header().Get("Location")
+
+		for _, method := range []string{http.MethodHead, http.MethodDelete} {
+			if recorder := exchange(handler, "initech", method, location, nil, ""); recorder.Code != http.StatusNotFound {
+				t.Errorf("%s Status = %d\n    - Expectation = %d", method, recorder.Code, http.StatusNotFound)
+			}
+		}
+
+		if recorder := exchange(handler, "initech", http.MethodPatch, location, map[string]string{"Upload-Offset": "0"}, "hello"); recorder.Code != http.StatusNotFound {
+			t.Errorf("PATCH Status = %d\n    - Expectation = %d", recorder.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("Quota", func(t *testing.T) {
+		handler, _, _ := setup(func(o *resumable.Options) {
+			o.Quota = func(ctx context.Context, tenant string) (resumable.Quota, error) {
+				return resumable.Quota{Size: 10, Storage: 15}, nil
+			}
+		})
+
+		tests := []struct {
+			length string
+			status int
+		}{
+			{length: "11", status: http.StatusRequestEntityTooLarge},
+			{length: "10", status: http.StatusCreated},
+			{length: "5", status: http.StatusCreated},
+			{length: "1", status: http.StatusRequestEntityTooLarge},
+		}
+
+		for _, test := range tests {
+			if recorder := exchange(handler, "acme", http.MethodPost, "/files/", map[string]string{"Upload-Length": test.length}, ""); recorder.Code != test.status {
+				t.Errorf("Status (%s) = %d\n    - Expectation = %d", test.length, recorder.Code, test.status)
+			}
+		}
+
+		if recorder := exchange(handler, "initech", http.MethodPost, "/files/", map[string]string{"Upload-Length": "10"}, ""); recorder.Code != http.StatusCreated {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusCreated)
+		}
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		handler, store, _ := setup(func(o *resumable.Options) {
+			o.Expiration = time.Hour
+		})
+
+		location := exchange(handler, "acme", http.MethodPost, "/files/", map[string]string{"Upload-Length": "5"}, "").Header().Get("Location")
+
+		now = now.Add(time.Hour)
+
+		defer func() { now = now.Add(-time.Hour) }()
+
+		if recorder := exchange(handler, "acme", http.MethodHead, location, nil, ""); recorder.Code != http.StatusGone {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusGone)
+		}
+
+		if _, found := store.Data(strings.TrimPrefix(location, "/files/")); found {
+			t.Errorf("Expired Upload Wasn't Deleted")
+		}
+	})
+
+	t.Run("Termination", func(t *testing.T) {
+		handler, _, _ := setup(nil)
+
+		location := exchange(handler, "acme", http.MethodPost, "/files/", map[string]string{"Upload-Length": "5"}, "").Header().Get("Location")
+
+		if recorder := exchange(handler, "acme", http.MethodDelete, location, nil, ""); recorder.Code != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusNoContent)
+		}
+
+		if recorder := exchange(handler, "acme", http.MethodHead, location, nil, ""); recorder.Code != http.StatusNotFound {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("Authenticated-Tenant", func(t *testing.T) {
+		store := new(resumable.Memory)
+
+		handler := authentication.New().Settings(func(o *authentication.Options) {
+			o.Verification = func(ctx context.Context, token string) (*jwt.Token, error) {
+				return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-42"}), nil
+			}
+		}).Handler(resumable.New().Settings(func(o *resumable.Options) {
+			o.Store = store
+		}).Handler(http.NotFoundHandler()))
+
+		request := httptest.NewRequest(http.MethodPost, "/files/", nil)
+		request.Header.Set("Tus-Resumable", "1.0.0")
+		request.Header.Set("Upload-Length", "5")
+		request.Header.Set("Authorization", "Bearer token")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		upload, e := store.Info(context.Background(), strings.TrimPrefix(recorder.Header().Get("Location"), "/files/"))
+		if e != nil {
+			t.Fatalf("Unexpected Error While Retrieving Upload: %v", e)
+		}
+
+		if upload.Tenant != "user-42" {
+			t.Errorf("Tenant = %s\n    - Expectation = %s", upload.Tenant, "user-42")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := resumable.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package resumable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by a [Store] for unknown uploads.
+	ErrNotFound = errors.New("resumable: upload not found")
+
+	// ErrOffset is returned by a [Store] when an append's offset doesn't match the upload's current offset.
+	ErrOffset = errors.New("resumable: offset mismatch")
+)
+
+// Upload represents a resumable upload's state.
+type Upload struct {
+	// ID represents the upload's unique identifier.
+	ID string `json:"id"`
+
+	// Tenant represents the upload's owner. See [Options.Tenant].
+	Tenant string `json:"tenant"`
+
+	// Length represents the upload's total size, in bytes.
+	Length int64 `json:"length"`
+
+	// Offset represents the number of bytes received.
+	Offset int64 `json:"offset"`
+
+	// Metadata represents the upload's decoded "Upload-Metadata" key-value pairs.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Created represents the upload's creation time.
+	Created time.Time `json:"created"`
+
+	// Expires represents the time after which an incomplete upload is discarded.
+	Expires time.Time `json:"expires"`
+}
+
+// Complete reports whether every byte of the upload was received.
+func (u *Upload) Complete() bool {
+	return u.Offset == u.Length
+}
+
+// Store represents a resumable upload's storage backend.
+type Store interface {
+	// Create persists a new, empty upload.
+	Create(ctx context.Context, upload *Upload) error
+
+	// Info returns the upload identified by id, or [ErrNotFound].
+	Info(ctx context.Context, id string) (*Upload, error)
+
+	// Append writes the body at the upload's offset, which must equal the upload's current offset (otherwise [ErrOffset] is returned),
+	// and returns the number of bytes written. Bytes written before a failure (e.g. a client disconnect) must be retained, and
+	// counted, such that the client can resume from the new offset.
+	Append(ctx context.Context, id string, offset int64, body io.Reader) (n int64, e error)
+
+	// Delete removes the upload identified by id, and its data.
+	Delete(ctx context.Context, id string) error
+
+	// Usage returns the combined length, in bytes, of the tenant's uploads - excluding incomplete uploads expired as of now.
+	Usage(ctx context.Context, tenant string, now time.Time) (int64, error)
+}
+
+// object represents a [Memory] upload.
+type object struct {
+	upload Upload
+	data   bytes.Buffer
+}
+
+// Memory is an in-memory [Store], suitable for tests and development. The zero value is ready for use.
+type Memory struct {
+	mutex   sync.Mutex
+	objects map[string]*object
+}
+
+func (m *Memory) Create(ctx context.Context, upload *Upload) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.objects == nil {
+		m.objects = make(map[string]*object)
+	}
+
+	m.objects[upload.ID] = &object{upload: *upload}
+
+	return nil
+}
+
+func (m *Memory) Info(ctx context.Context, id string) (*Upload, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	o, ok := m.objects[id]
+	if !(ok) {
+		return nil, ErrNotFound
+	}
+
+	upload := o.upload
+
+	return &upload, nil
+}
+
+func (m *Memory) Append(ctx context.Context, id string, offset int64, body io.Reader) (int64, error) {
+	m.mutex.Lock()
+	o, ok := m.objects[id]
+	current := o != nil && offset == o.upload.Offset
+	m.mutex.Unlock()
+
+	if !(ok) {
+		return 0, ErrNotFound
+	} else if !(current) {
+		return 0, ErrOffset
+	}
+
+	var buffer bytes.Buffer
+	n, e := io.Copy(&buffer, body)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	o.data.Write(buffer.Bytes())
+	o.upload.Offset += n
+
+	return n, e
+}
+
+func (m *Memory) Delete(ctx context.Context, id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.objects, id)
+
+	return nil
+}
+
+func (m *Memory) Usage(ctx context.Context, tenant string, now time.Time) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var usage int64
+	for _, o := range m.objects {
+		if o.upload.Tenant == tenant && (o.upload.Complete() || now.Before(o.upload.Expires)) {
+			usage += o.upload.Length
+		}
+	}
+
+	return usage, nil
+}
+
+// Data returns the received bytes of the upload identified by id.
+func (m *Memory) Data(id string) ([]byte, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	o, ok := m.objects[id]
+	if !(ok) {
+		return nil, false
+	}
+
+	return bytes.Clone(o.data.Bytes()), true
+}
+
+// Runtime assurance that [Memory] satisfies [Store] requirement(s).
+var _ Store = (*Memory)(nil)
@@ -0,0 +1,39 @@
+package rip_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/rip"
+)
+
+func FuzzHandler(f *testing.F) {
+	f.Add("X-Forwarded-For", "203.0.113.195, 70.41.3.18, 150.172.238.178")
+	f.Add("X-Forwarded-For", " 2001:db8:85a3:8d3:1319:8a2e:370:7348 ,")
+	f.Add("True-Client-IP", "203.0.113.195")
+	f.Add("X-Real-IP", ",,,")
+	f.Add("Forwarded", "for=192.0.2.60;proto=http;by=203.0.113.43, for=\"_hidden\"")
+
+	f.Fuzz(func(t *testing.T, header string, value string) {
+		var result string
+
+		handler := rip.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result = rip.Value(r.Context())
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header[http.CanonicalHeaderKey(header)] = []string{value}
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if strings.Contains(result, ",") {
+			t.Errorf("Resolved Address Contains a List Separator: %q", result)
+		}
+
+		if result != strings.TrimSpace(result) {
+			t.Errorf("Resolved Address Contains Surrounding Whitespace: %q", result)
+		}
+	})
+}
@@ -3,7 +3,9 @@ package rip
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 
 	"github.com/poly-gun/go-middleware"
@@ -15,6 +17,9 @@ type keyer string
 // key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
 const key keyer = "real-ip"
 
+// resolution is the package's unexported context key for the full resolution chain. Only through the use of [Resolution] can the context's value be derived.
+const resolution keyer = "real-ip-resolution"
+
 const (
 	trueClientIP  = "True-Client-IP"
 	xForwardedFor = "X-Forwarded-For"
@@ -26,6 +31,23 @@ type Options struct {
 	// Level specifies whether a log message should be logged in the [Server] middleware component's [Server.Handler] function. Default is nil. A value of nil
 	// causes the [Server.Handler] to skip logging of the ip-related header(s), entirely. See the [slog.Leveler] interface for additional information.
 	Level slog.Leveler
+
+	// ProxyProtocol specifies whether the connection's remote address should be preferred over any request header(s). Enable only when
+	// the server's listener parses PROXY protocol headers (e.g. the listener/proxyproto package's Listener), as the connection's remote address is otherwise the nearest proxy's address.
+	// When the server assigns [listener.ConnContext], the underlying connection's address is used; otherwise, [http.Request.RemoteAddr].
+	// Defaults to false.
+	ProxyProtocol bool
+
+	// Trusted represents the trusted proxies' addresses or CIDR prefixes (e.g. "10.0.0.0/8"). If configured, client-address
+	// header(s) are only honored for requests whose immediate peer ([http.Request.RemoteAddr]) is a trusted proxy; list headers
+	// ("Forwarded" and "X-Forwarded-For") are parsed right-to-left, skipping trusted hops, such that client-appended (spoofed)
+	// addresses are ignored. Otherwise, every header is trusted as-is, and client addresses are spoofable. Invalid values are
+	// ignored. Defaults to an empty slice.
+	Trusted []string
+
+	// Headers represents the client-address header(s), in order of precedence. Defaults to "Forwarded", "True-Client-IP",
+	// "X-Forwarded-For", and "X-Real-IP".
+	Headers []string
 }
 
 // Server represents a middleware component that applies configurable [Options] settings to HTTP requests. It
@@ -34,13 +56,18 @@ type Server struct {
 	middleware.Configurable[Options]
 
 	options *Options
+
+	trust []netip.Prefix
 }
 
 // Settings applies configuration functions to modify the [Server] middleware's [Options] and returns the updated middleware instance.
 func (s *Server) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
 	if s.options == nil {
 		s.options = &Options{
-			Level: nil,
+			Level:         nil,
+			ProxyProtocol: false,
+			Trusted:       []string{},
+			Headers:       []string{"Forwarded", trueClientIP, xForwardedFor, xRealIP},
 		}
 	}
 
@@ -50,6 +77,30 @@ func (s *Server) Settings(configuration ...func(o *Options)) middleware.Configur
 		}
 	}
 
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if len(s.options.Headers) == 0 {
+		slog.Warn("Invalid Real-IP Headers Specified - Using Default Headers")
+
+		s.options.Headers = []string{"Forwarded", trueClientIP, xForwardedFor, xRealIP}
+	}
+
+	s.trust = make([]netip.Prefix, 0, len(s.options.Trusted))
+	for _, value := range s.options.Trusted {
+		prefix, e := netip.ParsePrefix(strings.TrimSpace(value))
+		if e != nil {
+			address, e := netip.ParseAddr(strings.TrimSpace(value))
+			if e != nil {
+				slog.Warn("Invalid Real-IP Trusted Proxy Specified - Ignoring Value", slog.String("value", value))
+
+				continue
+			}
+
+			prefix = netip.PrefixFrom(address.Unmap(), address.Unmap().BitLen())
+		}
+
+		s.trust = append(s.trust, prefix.Masked())
+	}
+
 	return s
 }
 
@@ -60,29 +111,19 @@ func (s *Server) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		var value string
+		// Evaluate the client-address sources in order of precedence: the connection (proxy protocol), the [Options.Headers]
+		// - by default, "Forwarded" (RFC 7239), "True-Client-IP", "X-Forwarded-For", and "X-Real-IP" - and the remote address.
+		valuer := s.resolve(r)
 
-		switch {
-		case r.Header.Get(trueClientIP) != "":
-			value = r.Header.Get(trueClientIP)
-		case r.Header.Get(xForwardedFor) != "":
-			value = r.Header.Get(xForwardedFor)
-		case r.Header.Get(xRealIP) != "":
-			value = r.Header.Get(xRealIP)
-		}
-
-		if strings.Contains(value, ",") {
-			values := strings.Split(value, ",")
-
-			value = values[0]
-		}
+		value := valuer.Address
 
 		if v := s.options.Level; v != nil && value != "" {
-			slog.Log(ctx, v.Level(), "X-Real-IP Middleware", slog.String("value", value))
+			slog.Log(ctx, v.Level(), "X-Real-IP Middleware", slog.String("value", value), slog.String("source", valuer.Source))
 		}
 
-		// Store user agent in the context.
+		// Store the resolved address, and its resolution chain, in the context.
 		ctx = context.WithValue(ctx, key, value)
+		ctx = context.WithValue(ctx, resolution, valuer)
 
 		// Pass the request along with the new context.
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -112,5 +153,38 @@ func Value(ctx context.Context) (agent string) {
 	return
 }
 
+// Lookup retrieves the resolved client address from the provided context without logging its absence - e.g. for other middleware
+// optionally integrating with the [Server] middleware. The boolean reports whether an address was resolved.
+func Lookup(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(key).(string)
+
+	return v, ok && v != ""
+}
+
+// Addr retrieves the resolved client address from the provided context, parsed as a [net.IP]. If a nil value is returned, the
+// address couldn't be resolved, or it can be assumed that the [Server] middleware isn't enabled for the particular caller's chain.
+func Addr(ctx context.Context) net.IP {
+	if v, ok := ctx.Value(key).(string); ok {
+		return net.ParseIP(v)
+	}
+
+	slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+
+	return nil
+}
+
+// Resolution retrieves a [Valuer] pointer representing the full client-address resolution chain, useful for debugging which source
+// an address was derived from. If a nil value is returned, it can be assumed that the [Server] middleware isn't enabled for the
+// particular caller's chain.
+func Resolution(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(resolution).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(resolution)), slog.Any("value", ctx.Value(resolution)))
+	}
+
+	return
+}
+
 // Runtime assurance that [Server] satisfies [middleware.Configurable] requirement(s).
 var _ middleware.Configurable[Options] = (*Server)(nil)
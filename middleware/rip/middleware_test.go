@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -185,6 +186,27 @@ func Test(t *testing.T) {
 			}
 		})
 
+		t.Run("Lookup-No-Log-Message", func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{
+				AddSource:   true,
+				Level:       slog.LevelDebug,
+				ReplaceAttr: nil,
+			}))
+
+			slog.SetDefault(logger)
+
+			if v, ok := rip.Lookup(context.Background()); ok || v != "" {
+				t.Errorf("Lookup = %s, %t\n    - Expectation = %s, %t", v, ok, "", false)
+			}
+
+			if buffer.String() != "" {
+				t.Errorf("Unexpected Log Message: %s", buffer.String())
+			}
+		})
+
 		t.Run("Context-Key-Value-No-Log-Message", func(t *testing.T) {
 			t.Parallel()
 
@@ -252,4 +274,151 @@ func Test(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("Resolution", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			options   func(o *rip.Options)
+			headers   map[string]string
+			address   string
+			source    string
+			evaluated int
+		}{
+			{
+				name:      "Forwarded-Precedence",
+				headers:   map[string]string{"Forwarded": "for=\"[2001:db8:cafe::17]:4711\";proto=https, for=192.0.2.43", "X-Forwarded-For": "203.0.113.1"},
+				address:   "2001:db8:cafe::17",
+				source:    rip.SourceForwarded,
+				evaluated: 4,
+			},
+			{
+				name:      "Forwarded-Obfuscated-Fallback",
+				headers:   map[string]string{"Forwarded": "for=_hidden;by=_proxy", "X-Forwarded-For": "203.0.113.1, 10.0.0.1"},
+				address:   "203.0.113.1",
+				source:    rip.SourceXForwardedFor,
+				evaluated: 4,
+			},
+			{
+				name:      "True-Client-IP-Precedence",
+				headers:   map[string]string{"True-Client-IP": "198.51.100.2", "X-Real-IP": "198.51.100.3"},
+				address:   "198.51.100.2",
+				source:    rip.SourceTrueClientIP,
+				evaluated: 4,
+			},
+			{
+				name:      "Proxy-Protocol-Connection",
+				options:   func(o *rip.Options) { o.ProxyProtocol = true },
+				headers:   map[string]string{"X-Forwarded-For": "203.0.113.1"},
+				address:   "127.0.0.1",
+				source:    rip.SourceConnection,
+				evaluated: 5,
+			},
+			{
+				name:      "Remote-Address-Fallback",
+				address:   "127.0.0.1",
+				source:    rip.SourceRemoteAddress,
+				evaluated: 5,
+			},
+			{
+				name:      "Trusted-Right-To-Left",
+				options:   func(o *rip.Options) { o.Trusted = []string{"127.0.0.1", "10.0.0.0/8"} },
+				headers:   map[string]string{"X-Forwarded-For": "198.51.100.1, 203.0.113.9, 10.0.0.2"},
+				address:   "203.0.113.9",
+				source:    rip.SourceXForwardedFor,
+				evaluated: 4,
+			},
+			{
+				name:      "Trusted-Every-Hop",
+				options:   func(o *rip.Options) { o.Trusted = []string{"127.0.0.0/8", "10.0.0.0/8"} },
+				headers:   map[string]string{"X-Forwarded-For": "10.0.0.5, 10.0.0.2"},
+				address:   "10.0.0.5",
+				source:    rip.SourceXForwardedFor,
+				evaluated: 4,
+			},
+			{
+				name:      "Trusted-Forwarded",
+				options:   func(o *rip.Options) { o.Trusted = []string{"127.0.0.1"} },
+				headers:   map[string]string{"Forwarded": "for=198.51.100.1, for=\"[2001:db8::7]:443\""},
+				address:   "2001:db8::7",
+				source:    rip.SourceForwarded,
+				evaluated: 4,
+			},
+			{
+				name:      "Untrusted-Peer",
+				options:   func(o *rip.Options) { o.Trusted = []string{"10.0.0.0/8"} },
+				headers:   map[string]string{"X-Forwarded-For": "203.0.113.1", "True-Client-IP": "203.0.113.2"},
+				address:   "127.0.0.1",
+				source:    rip.SourceRemoteAddress,
+				evaluated: 5,
+			},
+			{
+				name:      "Trusted-Invalid-Address",
+				options:   func(o *rip.Options) { o.Trusted = []string{"127.0.0.1"}; o.Headers = []string{"X-Real-IP"} },
+				headers:   map[string]string{"X-Real-IP": "invalid"},
+				address:   "127.0.0.1",
+				source:    rip.SourceRemoteAddress,
+				evaluated: 2,
+			},
+			{
+				name:      "Header-Precedence",
+				options:   func(o *rip.Options) { o.Headers = []string{"X-Real-IP", "X-Forwarded-For"} },
+				headers:   map[string]string{"X-Forwarded-For": "203.0.113.1", "X-Real-IP": "203.0.113.2"},
+				address:   "203.0.113.2",
+				source:    rip.SourceXRealIP,
+				evaluated: 2,
+			},
+		}
+
+		for _, matrix := range tests {
+			t.Run(matrix.name, func(t *testing.T) {
+				var valuer *rip.Valuer
+				var value string
+				var addr net.IP
+
+				server := httptest.NewServer(rip.New().Settings(matrix.options).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					valuer = rip.Resolution(r.Context())
+					value = rip.Value(r.Context())
+					addr = rip.Addr(r.Context())
+				})))
+
+				defer server.Close()
+
+				request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Request: %v", e)
+				}
+
+				for k, v := range matrix.headers {
+					request.Header.Set(k, v)
+				}
+
+				response, e := server.Client().Do(request)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Response: %v", e)
+				}
+
+				response.Body.Close()
+
+				if valuer == nil {
+					t.Fatalf("Expected Non-Nil Resolution Context Value")
+				}
+
+				if valuer.Address != matrix.address || value != matrix.address {
+					t.Errorf("Address = %q (Value = %q)\n    - Expectation = %q", valuer.Address, value, matrix.address)
+				}
+
+				if addr == nil || addr.String() != matrix.address {
+					t.Errorf("Addr = %v\n    - Expectation = %s", addr, matrix.address)
+				}
+
+				if valuer.Source != matrix.source {
+					t.Errorf("Source = %q\n    - Expectation = %q", valuer.Source, matrix.source)
+				}
+
+				if len(valuer.Chain) != matrix.evaluated {
+					t.Errorf("Chain = %+v\n    - Expectation = %d Step(s)", valuer.Chain, matrix.evaluated)
+				}
+			})
+		}
+	})
 }
@@ -0,0 +1,234 @@
+package rip
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/poly-gun/go-middleware/listener"
+)
+
+// Source(s) represent the origin of a resolved client address. See [Step.Source].
+const (
+	SourceConnection    = "connection"
+	SourceForwarded     = "forwarded"
+	SourceTrueClientIP  = "true-client-ip"
+	SourceXForwardedFor = "x-forwarded-for"
+	SourceXRealIP       = "x-real-ip"
+	SourceRemoteAddress = "remote-address"
+)
+
+// Step represents a single evaluated source in the client-address resolution chain.
+type Step struct {
+	// Source represents the evaluated source (e.g. [SourceForwarded]).
+	Source string `json:"source"`
+
+	// Value represents the source's raw value. Empty if the source wasn't present.
+	Value string `json:"value,omitempty"`
+
+	// Address represents the address derived from [Step.Value]. Empty if no address could be derived.
+	Address string `json:"address,omitempty"`
+}
+
+// Valuer is the context return type relating to the full client-address resolution. See the [Resolution] function for additional details.
+type Valuer struct {
+	// Address represents the resolved client address - equivalent to [Value].
+	Address string `json:"address"`
+
+	// Source represents the [Step.Source] the address was resolved from. Empty if no address was resolved.
+	Source string `json:"source"`
+
+	// Chain represents every evaluated source, in order of precedence, for debugging purposes.
+	Chain []Step `json:"chain"`
+}
+
+// host strips any port and surrounding brackets from an address (e.g. "[2001:db8::1]:443" or "192.0.2.1:80").
+func host(address string) string {
+	address = strings.TrimSpace(address)
+	if v, _, e := net.SplitHostPort(address); e == nil {
+		return v
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+}
+
+// first returns the first element of a comma-separated header value.
+func first(value string) string {
+	if strings.Contains(value, ",") {
+		values := strings.Split(value, ",")
+
+		value = values[0]
+	}
+
+	// Proxies commonly separate list elements with ", " - surrounding whitespace is never part of an address.
+	return strings.TrimSpace(value)
+}
+
+// forwarded returns the "for" parameter of the first element of an RFC 7239 "Forwarded" header value. Obfuscated identifiers
+// (e.g. "_hidden") and the "unknown" identifier don't represent addresses, and result in an empty string.
+func forwarded(value string) string {
+	element := first(value)
+
+	for _, pair := range strings.Split(element, ";") {
+		name, parameter, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !(found) || !(strings.EqualFold(name, "for")) {
+			continue
+		}
+
+		parameter = strings.Trim(parameter, "\"")
+		if parameter == "" || strings.EqualFold(parameter, "unknown") || strings.HasPrefix(parameter, "_") {
+			return ""
+		}
+
+		return host(parameter)
+	}
+
+	return ""
+}
+
+// elements returns every element of the header's comma-separated values, in order.
+func elements(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			result = append(result, strings.TrimSpace(element))
+		}
+	}
+
+	return result
+}
+
+// trusted reports whether the address belongs to any of the trusted proxy prefixes.
+func (s *Server) trusted(address string) bool {
+	ip, e := netip.ParseAddr(host(address))
+	if e != nil {
+		return false
+	}
+
+	ip = ip.Unmap()
+	for _, prefix := range s.trust {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rightmost returns the rightmost address not belonging to a trusted proxy - i.e. the address appended by the nearest trusted
+// proxy - or the leftmost address if every hop is trusted. Invalid addresses end the evaluation, as hops to their left can't be
+// attributed to a trusted proxy.
+func (s *Server) rightmost(addresses []string) string {
+	for index := len(addresses) - 1; index >= 0; index-- {
+		address := addresses[index]
+
+		ip, e := netip.ParseAddr(address)
+		if e != nil {
+			return ""
+		}
+
+		if !(s.trusted(address)) || index == 0 {
+			return ip.Unmap().String()
+		}
+	}
+
+	return ""
+}
+
+// header returns the source, and derivation function, of a client-address header, as evaluated by the [Server.resolve] function.
+func (s *Server) header(r *http.Request, name string) (source string, value string, derive func(string) string) {
+	canonical := http.CanonicalHeaderKey(name)
+	values := r.Header.Values(canonical)
+	value = strings.Join(values, ", ")
+
+	switch canonical {
+	case "Forwarded":
+		source, derive = SourceForwarded, forwarded
+		if len(s.trust) > 0 {
+			derive = func(string) string {
+				var addresses []string
+				for _, element := range elements(values) {
+					addresses = append(addresses, forwarded(element))
+				}
+
+				return s.rightmost(addresses)
+			}
+		}
+	case xForwardedFor:
+		source, derive = SourceXForwardedFor, first
+		if len(s.trust) > 0 {
+			derive = func(string) string {
+				return s.rightmost(elements(values))
+			}
+		}
+	case http.CanonicalHeaderKey(trueClientIP):
+		source, derive = SourceTrueClientIP, first
+	case http.CanonicalHeaderKey(xRealIP):
+		source, derive = SourceXRealIP, first
+	default:
+		source, derive = strings.ToLower(canonical), first
+	}
+
+	if len(s.trust) > 0 && (canonical != "Forwarded" && canonical != xForwardedFor) {
+		// Single-address headers must be a valid address, as set by the nearest trusted proxy.
+		derive = func(value string) string {
+			if ip, e := netip.ParseAddr(first(value)); e == nil {
+				return ip.Unmap().String()
+			}
+
+			return ""
+		}
+	}
+
+	return source, value, derive
+}
+
+// resolve evaluates each client-address source in order of precedence, returning the full resolution chain. If
+// [Options.Trusted] is configured, header(s) are only evaluated for requests whose immediate peer is a trusted proxy. The
+// request's remote address is evaluated last, and only if no other source resolved an address.
+func (s *Server) resolve(r *http.Request) *Valuer {
+	valuer := &Valuer{Chain: []Step{}}
+
+	evaluate := func(source string, value string, derive func(string) string) {
+		step := Step{Source: source, Value: value}
+		if value != "" && derive != nil {
+			step.Address = derive(value)
+		}
+
+		if valuer.Source == "" && step.Address != "" {
+			valuer.Address = step.Address
+			valuer.Source = source
+		}
+
+		valuer.Chain = append(valuer.Chain, step)
+	}
+
+	// A proxy-protocol listener rewrites the connection's remote address to the original client's address.
+	if s.options.ProxyProtocol {
+		value := r.RemoteAddr
+		if c := listener.Conn(r.Context()); c != nil && c.RemoteAddr() != nil {
+			value = c.RemoteAddr().String()
+		}
+
+		evaluate(SourceConnection, value, host)
+	}
+
+	// Header(s) from an untrusted peer are recorded, but never derive an address.
+	spoofable := len(s.trust) > 0 && !(s.trusted(r.RemoteAddr))
+
+	for _, name := range s.options.Headers {
+		source, value, derive := s.header(r, name)
+		if spoofable {
+			derive = nil
+		}
+
+		evaluate(source, value, derive)
+	}
+
+	if valuer.Source == "" {
+		evaluate(SourceRemoteAddress, r.RemoteAddr, host)
+	}
+
+	return valuer
+}
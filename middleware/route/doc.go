@@ -0,0 +1,7 @@
+// Package route provides middleware that resolves a request's route template (e.g. "GET /users/{id}") and stores it in the
+// request's context, for use as a low-cardinality label by metrics, logging, and SLO-related middleware(s).
+//
+// Templates are resolved from a user-provided callback, then from an [http.ServeMux]'s registered patterns. Requests without a
+// template fall back to their normalized path - with identifier-like segments (integers, UUIDs, and long hex strings) collapsed -
+// and once the number of distinct fallback paths exceeds a configurable limit, unknown paths collapse to a single overflow value.
+package route
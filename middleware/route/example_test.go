@@ -0,0 +1,43 @@
+package route_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Example() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Route: %s\n", route.Value(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	middleware := middleware.New()
+
+	middleware.Add(route.New().Settings(func(o *route.Options) {
+		o.Mux = mux
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	response, e := server.Client().Get(server.URL + "/users/8f6d2c1e")
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Route: GET /users/{id}
+}
@@ -0,0 +1,217 @@
+package route
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "route"
+
+const (
+	defaultLimit    = 100
+	defaultOverflow = "other"
+)
+
+// Options represents the configuration settings for the [Route] middleware component.
+type Options struct {
+	// Resolver represents an optional callback returning the request's route template. An empty return value falls through
+	// to [Options.Mux]. Defaults to nil.
+	Resolver func(r *http.Request) string
+
+	// Mux represents an optional [http.ServeMux] whose matched pattern (e.g. "GET /users/{id}") is used as the route template.
+	// Requests that don't match a registered pattern fall through to path normalization. Defaults to nil.
+	Mux *http.ServeMux
+
+	// Normalize enables collapsing identifier-like path segments (integers, UUIDs, and hex strings of 16 or more characters)
+	// into "{id}" for requests without a resolved template. Defaults to true.
+	Normalize bool
+
+	// Limit represents the maximum number of distinct fallback (unresolved) paths tracked. Once exceeded, previously unseen
+	// paths resolve to [Options.Overflow]. Defaults to 100.
+	Limit int
+
+	// Overflow represents the route value assigned to paths exceeding [Options.Limit]. Defaults to "other".
+	Overflow string
+}
+
+// Route represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Route struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex    sync.Mutex
+	seen     map[string]struct{}
+	exceeded bool
+}
+
+// Settings applies configuration functions to modify the [Route] middleware's [Options] and returns the updated middleware instance.
+func (x *Route) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Resolver:  nil,
+			Mux:       nil,
+			Normalize: true,
+			Limit:     defaultLimit,
+			Overflow:  defaultOverflow,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Route Limit Specified - Using Default Limit")
+
+		x.options.Limit = defaultLimit
+	}
+
+	if x.options.Overflow == "" {
+		x.options.Overflow = defaultOverflow
+	}
+
+	return x
+}
+
+// identifier reports whether a path segment resembles an identifier.
+func identifier(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	digits := true
+	for _, c := range segment {
+		switch {
+		case c >= '0' && c <= '9':
+		case c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			digits = false
+		case c == '-' && len(segment) == 36:
+			digits = false
+		default:
+			return false
+		}
+	}
+
+	return digits || len(segment) >= 16
+}
+
+// normalize collapses identifier-like segments of the provided path.
+func normalize(path string) string {
+	segments := strings.Split(path, "/")
+	for index, segment := range segments {
+		if identifier(segment) {
+			segments[index] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// fallback returns the route value for a request without a resolved template, enforcing the cardinality limit.
+func (x *Route) fallback(ctx context.Context, path string) string {
+	if x.options.Normalize {
+		path = normalize(path)
+	}
+
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if x.seen == nil {
+		x.seen = make(map[string]struct{})
+	}
+
+	if _, found := x.seen[path]; found {
+		return path
+	}
+
+	if len(x.seen) >= x.options.Limit {
+		if !(x.exceeded) {
+			x.exceeded = true
+
+			slog.WarnContext(ctx, "Route Cardinality Limit Exceeded - Collapsing Unknown Path(s)", slog.Int("limit", x.options.Limit), slog.String("overflow", x.options.Overflow))
+		}
+
+		return x.options.Overflow
+	}
+
+	x.seen[path] = struct{}{}
+
+	return path
+}
+
+// resolve returns the request's route value.
+func (x *Route) resolve(r *http.Request) string {
+	if x.options.Resolver != nil {
+		if v := x.options.Resolver(r); v != "" {
+			return v
+		}
+	}
+
+	if x.options.Mux != nil {
+		if _, pattern := x.options.Mux.Handler(r); pattern != "" {
+			return pattern
+		}
+	}
+
+	return x.fallback(r.Context(), r.URL.Path)
+}
+
+// Handler resolves the request's route template, and stores it in the request context. It forwards the request to the next handler in the chain.
+func (x *Route) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), key, x.resolve(r))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Route] middleware, implementing [middleware.Configurable]. If [Route.Settings] isn't called,
+// then the [Route.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Route)
+}
+
+// Value retrieves the request's route template from the provided context. If an empty string is returned, it can be assumed that
+// the [Route] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (route string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		route = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		route = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Lookup retrieves the request's route template from the provided context without logging its absence - e.g. for other middleware
+// optionally integrating with the [Route] middleware. The boolean reports whether a route template was resolved.
+func Lookup(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(key).(string)
+
+	return v, ok && v != ""
+}
+
+// Runtime assurance that [Route] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Route)(nil)
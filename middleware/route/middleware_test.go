@@ -0,0 +1,154 @@
+package route_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/route"
+)
+
+func Test(t *testing.T) {
+	// handler writes the request's route value as the response body.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		io.WriteString(w, route.Value(r.Context()))
+	})
+
+	// evaluate issues a request for each path against the server, returning the resolved route value(s).
+	evaluate := func(t *testing.T, server *httptest.Server, paths ...string) []string {
+		t.Helper()
+
+		values := make([]string, 0, len(paths))
+		for _, path := range paths {
+			response, e := server.Client().Get(server.URL + path)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			body, e := io.ReadAll(response.Body)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Reading Response Body: %v", e)
+			}
+
+			response.Body.Close()
+
+			values = append(values, string(body))
+		}
+
+		return values
+	}
+
+	t.Run("Mux-Pattern", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("GET /users/{id}", handler)
+
+		server := httptest.NewServer(route.New().Settings(func(o *route.Options) { o.Mux = mux }).Handler(mux))
+
+		defer server.Close()
+
+		if v := evaluate(t, server, "/users/12345")[0]; v != "GET /users/{id}" {
+			t.Errorf("Route = %s\n    - Expectation = %s", v, "GET /users/{id}")
+		}
+	})
+
+	t.Run("Resolver-Precedence", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/", handler)
+
+		server := httptest.NewServer(route.New().Settings(func(o *route.Options) {
+			o.Mux = mux
+			o.Resolver = func(r *http.Request) string {
+				if r.URL.Path == "/custom" {
+					return "custom"
+				}
+
+				return ""
+			}
+		}).Handler(mux))
+
+		defer server.Close()
+
+		values := evaluate(t, server, "/custom", "/fallthrough")
+		if values[0] != "custom" || values[1] != "/" {
+			t.Errorf("Route(s) = %v\n    - Expectation = %v", values, []string{"custom", "/"})
+		}
+	})
+
+	t.Run("Normalization", func(t *testing.T) {
+		server := httptest.NewServer(route.New().Handler(handler))
+
+		defer server.Close()
+
+		expectations := map[string]string{
+			"/orders/42":         "/orders/{id}",
+			"/orders/42/items/7": "/orders/{id}/items/{id}",
+			"/sessions/3fa85f64-5717-4562-b3fc-2c963f66afa6": "/sessions/{id}",
+			"/blobs/80f198ee56343ba864fe8b2a57d3eff7":        "/blobs/{id}",
+			"/static/cafe": "/static/cafe",
+			"/health":      "/health",
+			"/users/3fa85f64-5717-4562-b3fc-2c963f66afa6/profile": "/users/{id}/profile",
+		}
+
+		for path, expectation := range expectations {
+			if v := evaluate(t, server, path)[0]; v != expectation {
+				t.Errorf("Route (%s) = %s\n    - Expectation = %s", path, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Normalization-Disabled", func(t *testing.T) {
+		server := httptest.NewServer(route.New().Settings(func(o *route.Options) { o.Normalize = false }).Handler(handler))
+
+		defer server.Close()
+
+		if v := evaluate(t, server, "/orders/42")[0]; v != "/orders/42" {
+			t.Errorf("Route = %s\n    - Expectation = %s", v, "/orders/42")
+		}
+	})
+
+	t.Run("Cardinality-Limit", func(t *testing.T) {
+		server := httptest.NewServer(route.New().Settings(func(o *route.Options) {
+			o.Limit = 3
+			o.Overflow = "overflow"
+		}).Handler(handler))
+
+		defer server.Close()
+
+		paths := make([]string, 0, 5)
+		for index := range 5 {
+			paths = append(paths, fmt.Sprintf("/page-%c", 'a'+index))
+		}
+
+		values := evaluate(t, server, append(paths, paths[0])...)
+
+		expectations := []string{"/page-a", "/page-b", "/page-c", "overflow", "overflow", "/page-a"}
+		for index := range expectations {
+			if values[index] != expectations[index] {
+				t.Errorf("Route(s) = %v\n    - Expectation = %v", values, expectations)
+				break
+			}
+		}
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		if v, ok := route.Lookup(context.Background()); ok || v != "" {
+			t.Errorf("Lookup = %s, %t\n    - Expectation = %s, %t", v, ok, "", false)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := route.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "GET /")
+		if v := route.Value(ctx); v != "GET /" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
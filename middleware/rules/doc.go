@@ -0,0 +1,17 @@
+// Package rules provides a lightweight allow/deny rules engine middleware, evaluating ordered rules built on existing context
+// values - the client address ([rip]), user-agent, country, tenant, method, and path.
+//
+// Rules are evaluated in order. Tag rules annotate the request and continue evaluation; the first matching allow, deny, or
+// challenge rule ends evaluation. Requests without a matching terminal rule receive the configured default action.
+//
+// Rule sets are held by an [Engine], which can be loaded from JSON configuration and hot-reloaded - either programmatically via
+// [Engine.Update], or by polling a file via [Engine.Watch]. For example:
+//
+//	[
+//	    {"name": "tag-bots", "action": "tag", "agents": ["(?i)bot|crawler"], "tags": ["bot"]},
+//	    {"name": "office", "action": "allow", "addresses": ["203.0.113.0/24"]},
+//	    {"name": "admin", "action": "deny", "paths": ["^/admin(/|$)"]}
+//	]
+//
+// [rip]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/rip
+package rules
@@ -0,0 +1,73 @@
+package rules_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/rules"
+)
+
+func Example() {
+	engine := new(rules.Engine)
+
+	if e := engine.Load(strings.NewReader(`[
+		{"name": "tag-bots", "action": "tag", "agents": ["(?i)bot"], "tags": ["bot"]},
+		{"name": "admin", "action": "deny", "paths": ["^/admin(/|$)"]}
+	]`)); e != nil {
+		e = fmt.Errorf("unexpected error while loading rules: %w", e)
+
+		panic(e)
+	}
+
+	middleware := middleware.New()
+
+	middleware.Add(rules.New().Settings(func(o *rules.Options) {
+		o.Engine = engine
+		o.Agent = func(r *http.Request) string { return r.UserAgent() }
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Tags: %v\n", rules.Value(r.Context()).Tags)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/", "/admin"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("User-Agent", "Examplebot/1.0")
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Tags: [bot]
+	// Status: 200
+	// Status: 403
+}
@@ -0,0 +1,228 @@
+package rules
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/rip"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "rules"
+
+// Valuer is the context return type relating to the [Rules] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Rule represents the name of the matched terminal rule. Empty if no terminal rule matched.
+	Rule string `json:"rule,omitempty"`
+
+	// Action represents the request's effective [Action] - the matched terminal rule's, or [Options.Default].
+	Action Action `json:"action"`
+
+	// Tags represents the tag(s) applied by every matched [ActionTag] rule, in evaluation order.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Options represents the configuration settings for the [Rules] middleware component.
+type Options struct {
+	// Engine represents the rule set evaluated for every request. A nil value disables the middleware's behavior. Defaults to nil.
+	Engine *Engine
+
+	// Default represents the [Action] applied when no terminal rule matches. Only [ActionAllow] and [ActionDeny] are considered
+	// valid. Defaults to [ActionAllow].
+	Default Action
+
+	// Client returns the request's client address. Defaults to a function returning the [rip] middleware's resolved address - if
+	// chained - falling back to the host component of [http.Request.RemoteAddr].
+	//
+	// [rip]: https://pkg.go.dev/github.com/poly-gun/go-middleware/middleware/rip
+	Client func(r *http.Request) string
+
+	// Agent returns the request's user-agent. Defaults to [http.Request.UserAgent].
+	Agent func(r *http.Request) string
+
+	// Country returns the request's ISO 3166-1 alpha-2 country code - typically derived from a geolocation database, or an edge
+	// proxy's header. A nil value never matches [Rule.Countries]. Defaults to nil.
+	Country func(r *http.Request) string
+
+	// Tenant returns the request's tenant identifier. A nil value never matches [Rule.Tenants]. Defaults to nil.
+	Tenant func(r *http.Request) string
+
+	// Challenge represents the handler serving [ActionChallenge] requests. A nil value forwards challenged requests to the next
+	// handler, deferring to a downstream middleware inspecting [Valuer.Action]. Defaults to nil.
+	Challenge http.Handler
+
+	// Status represents the response status code written for denied requests. Defaults to [http.StatusForbidden].
+	Status int
+
+	// Message represents the plain-text response body written for denied requests.
+	Message string
+
+	// Level specifies the log level used to record matched terminal rules. A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+}
+
+// Rules represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Rules struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Rules] middleware's [Options] and returns the updated middleware instance.
+func (x *Rules) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Engine:  nil,
+			Default: ActionAllow,
+			Client: func(r *http.Request) string {
+				if v, ok := rip.Lookup(r.Context()); ok {
+					return v
+				}
+
+				if v, _, e := net.SplitHostPort(r.RemoteAddr); e == nil {
+					return v
+				}
+
+				return r.RemoteAddr
+			},
+			Agent: func(r *http.Request) string {
+				return r.UserAgent()
+			},
+			Country:   nil,
+			Tenant:    nil,
+			Challenge: nil,
+			Status:    http.StatusForbidden,
+			Message:   "Forbidden",
+			Level:     nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Default != ActionAllow && x.options.Default != ActionDeny {
+		slog.Warn("Invalid Rules Default Action Specified - Using Default Action")
+
+		x.options.Default = ActionAllow
+	}
+
+	if x.options.Status < 400 || x.options.Status > 599 {
+		slog.Warn("Invalid Rules Status Specified - Using Default Status")
+
+		x.options.Status = http.StatusForbidden
+	}
+
+	return x
+}
+
+// attributes derives the request's attributes from the configured sources.
+func (x *Rules) attributes(r *http.Request) *attributes {
+	a := &attributes{method: r.Method, path: r.URL.Path}
+
+	if x.options.Client != nil {
+		if address, e := netip.ParseAddr(x.options.Client(r)); e == nil {
+			a.address = address.Unmap()
+		}
+	}
+
+	if x.options.Agent != nil {
+		a.agent = x.options.Agent(r)
+	}
+
+	if x.options.Country != nil {
+		a.country = x.options.Country(r)
+	}
+
+	if x.options.Tenant != nil {
+		a.tenant = x.options.Tenant(r)
+	}
+
+	return a
+}
+
+// Handler evaluates the [Options.Engine]'s rules in order, applying the first matching terminal rule's [Action]. It stores the
+// evaluation's [Valuer] in the request context, and forwards admitted requests to the next handler in the chain.
+func (x *Rules) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Engine == nil {
+		slog.Warn("Rules Middleware Engine Unspecified - Rule Evaluation Disabled")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer := &Valuer{Action: x.options.Default}
+
+		if x.options.Engine != nil {
+			rule, tags := x.options.Engine.evaluate(x.attributes(r))
+
+			valuer.Tags = tags
+
+			if rule != nil {
+				valuer.Rule = rule.Name
+				valuer.Action = rule.Action
+
+				if v := x.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Matched Rule", slog.String("rule", rule.Name), slog.String("action", string(rule.Action)), slog.String("url", r.URL.String()))
+				}
+			}
+		}
+
+		ctx = context.WithValue(ctx, key, valuer)
+
+		switch valuer.Action {
+		case ActionDeny:
+			http.Error(w, x.options.Message, x.options.Status)
+
+			return
+		case ActionChallenge:
+			if x.options.Challenge != nil {
+				x.options.Challenge.ServeHTTP(w, r.WithContext(ctx))
+
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// New creates a new instance of the [Rules] middleware, implementing [middleware.Configurable]. If [Rules.Settings] isn't called,
+// then the [Rules.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Rules)
+}
+
+// Value retrieves the request's rule evaluation from the provided context. If a nil value is returned, it can be assumed that the
+// [Rules] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Rules] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Rules)(nil)
@@ -0,0 +1,239 @@
+package rules_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/rules"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := rules.Value(r.Context())
+
+		w.Header().Set("X-Rule", v.Rule)
+		w.Header().Set("X-Action", string(v.Action))
+		w.Header().Set("X-Tags", strings.Join(v.Tags, ","))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	engine := new(rules.Engine)
+
+	if e := engine.Update([]rules.Rule{
+		{Name: "bots", Action: rules.ActionTag, Agents: []string{"(?i)bot"}, Tags: []string{"bot"}},
+		{Name: "office", Action: rules.ActionAllow, Addresses: []string{"203.0.113.0/24"}},
+		{Name: "embargo", Action: rules.ActionDeny, Countries: []string{"xx"}},
+		{Name: "suspicious", Action: rules.ActionChallenge, Agents: []string{"(?i)curl"}, Methods: []string{"post"}},
+		{Name: "admin", Action: rules.ActionDeny, Paths: []string{"^/admin(/|$)"}},
+		{Name: "tenant", Action: rules.ActionDeny, Tenants: []string{"suspended"}},
+	}); e != nil {
+		t.Fatalf("Unexpected Error While Updating Rules: %v", e)
+	}
+
+	tests := []struct {
+		name    string
+		options func(o *rules.Options)
+		method  string
+		path    string
+		remote  string
+		agent   string
+		country string
+		tenant  string
+		status  int
+		rule    string
+		action  rules.Action
+		tags    string
+	}{
+		{name: "Default-Allow", path: "/", remote: "192.0.2.1:1234", status: http.StatusOK, action: rules.ActionAllow},
+		{name: "Default-Deny", options: func(o *rules.Options) { o.Default = rules.ActionDeny }, path: "/", remote: "192.0.2.1:1234", status: http.StatusForbidden},
+		{name: "Address-Allow", path: "/admin", remote: "203.0.113.9:1234", status: http.StatusOK, rule: "office", action: rules.ActionAllow},
+		{name: "Path-Deny", path: "/admin/users", remote: "192.0.2.1:1234", status: http.StatusForbidden},
+		{name: "Path-Unmatched", path: "/administrator", remote: "192.0.2.1:1234", status: http.StatusOK, action: rules.ActionAllow},
+		{name: "Country-Deny", path: "/", remote: "192.0.2.1:1234", country: "XX", status: http.StatusForbidden},
+		{name: "Tenant-Deny", path: "/", remote: "192.0.2.1:1234", tenant: "suspended", status: http.StatusForbidden},
+		{name: "Tag-Continues", path: "/", remote: "192.0.2.1:1234", agent: "Googlebot/2.1", status: http.StatusOK, action: rules.ActionAllow, tags: "bot"},
+		{name: "Tag-Terminal", path: "/", remote: "203.0.113.9:1234", agent: "Googlebot/2.1", status: http.StatusOK, rule: "office", action: rules.ActionAllow, tags: "bot"},
+		{name: "Challenge-Forwarded", method: http.MethodPost, path: "/", remote: "192.0.2.1:1234", agent: "curl/8.0", status: http.StatusOK, rule: "suspicious", action: rules.ActionChallenge},
+		{name: "Challenge-Handler", options: func(o *rules.Options) {
+			o.Challenge = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		}, method: http.MethodPost, path: "/", remote: "192.0.2.1:1234", agent: "curl/8.0", status: http.StatusUnauthorized},
+		{name: "Challenge-Method-Unmatched", path: "/", remote: "192.0.2.1:1234", agent: "curl/8.0", status: http.StatusOK, action: rules.ActionAllow},
+		{name: "Custom-Status", options: func(o *rules.Options) { o.Status = http.StatusNotFound }, path: "/admin", remote: "192.0.2.1:1234", status: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := rules.New().Settings(func(o *rules.Options) {
+				o.Engine = engine
+				o.Agent = func(r *http.Request) string { return r.Header.Get("User-Agent") }
+				o.Country = func(r *http.Request) string { return r.Header.Get("X-Country") }
+				o.Tenant = func(r *http.Request) string { return r.Header.Get("X-Tenant") }
+			}, test.options).Handler(handler)
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			request := httptest.NewRequest(method, test.path, nil)
+			request.RemoteAddr = test.remote
+			request.Header.Set("User-Agent", test.agent)
+			request.Header.Set("X-Country", test.country)
+			request.Header.Set("X-Tenant", test.tenant)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if test.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("X-Rule"); v != test.rule {
+				t.Errorf("Rule = %s\n    - Expectation = %s", v, test.rule)
+			}
+
+			if v := rules.Action(recorder.Header().Get("X-Action")); v != test.action {
+				t.Errorf("Action = %s\n    - Expectation = %s", v, test.action)
+			}
+
+			if v := recorder.Header().Get("X-Tags"); v != test.tags {
+				t.Errorf("Tags = %s\n    - Expectation = %s", v, test.tags)
+			}
+		})
+	}
+
+	t.Run("Engine", func(t *testing.T) {
+		t.Run("Invalid-Update", func(t *testing.T) {
+			e := new(rules.Engine)
+
+			for _, rule := range []rules.Rule{
+				{Name: "action", Action: "block"},
+				{Name: "address", Action: rules.ActionDeny, Addresses: []string{"invalid"}},
+				{Name: "agent", Action: rules.ActionDeny, Agents: []string{"("}},
+				{Name: "path", Action: rules.ActionDeny, Paths: []string{"["}},
+			} {
+				if v := e.Update([]rules.Rule{rule}); v == nil {
+					t.Errorf("Expected Error for Invalid Rule (%s)", rule.Name)
+				}
+			}
+		})
+
+		t.Run("Load", func(t *testing.T) {
+			e := new(rules.Engine)
+
+			if v := e.Load(strings.NewReader(`[{"name": "deny", "action": "deny"}]`)); v != nil {
+				t.Fatalf("Unexpected Error While Loading Rules: %v", v)
+			}
+
+			if v := e.Load(strings.NewReader(`[{"name": "deny", "action": "deny", "unknown": true}]`)); v == nil {
+				t.Errorf("Expected Error for Unknown Field")
+			}
+
+			m := rules.New().Settings(func(o *rules.Options) { o.Engine = e }).Handler(handler)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if recorder.Code != http.StatusForbidden {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusForbidden)
+			}
+		})
+
+		t.Run("Watch", func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+
+			if e := os.WriteFile(path, []byte(`[{"name": "first", "action": "allow"}]`), 0o600); e != nil {
+				t.Fatalf("Unexpected Error While Writing Rules: %v", e)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			e := new(rules.Engine)
+			if v := e.Watch(ctx, path, 10*time.Millisecond); v != nil {
+				t.Fatalf("Unexpected Error While Watching Rules: %v", v)
+			}
+
+			m := rules.New().Settings(func(o *rules.Options) { o.Engine = e }).Handler(handler)
+
+			rule := func() string {
+				recorder := httptest.NewRecorder()
+
+				m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				return recorder.Header().Get("X-Rule")
+			}
+
+			if v := rule(); v != "first" {
+				t.Fatalf("Rule = %s\n    - Expectation = %s", v, "first")
+			}
+
+			// Ensure the revision's modification time differs from the original's.
+			modified := time.Now().Add(time.Second)
+
+			if v := os.WriteFile(path, []byte(`[{"name": "second", "action": "allow"}]`), 0o600); v != nil {
+				t.Fatalf("Unexpected Error While Writing Rules: %v", v)
+			} else if v := os.Chtimes(path, modified, modified); v != nil {
+				t.Fatalf("Unexpected Error While Modifying Rules: %v", v)
+			}
+
+			deadline := time.Now().Add(2 * time.Second)
+			for rule() != "second" {
+				if time.Now().After(deadline) {
+					t.Fatalf("Rules Not Reloaded Before Deadline")
+				}
+
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if v := e.Watch(ctx, filepath.Join(t.TempDir(), "missing.json"), time.Second); v == nil {
+				t.Errorf("Expected Error for Missing Rules File")
+			}
+
+			if v := new(rules.Engine).Watch(ctx, path, 0); v != nil {
+				t.Errorf("Unexpected Error While Watching Rules With a Non-Positive Interval: %v", v)
+			}
+		})
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		m := rules.New().Handler(handler)
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := rules.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &rules.Valuer{Rule: "test", Action: rules.ActionAllow}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := rules.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
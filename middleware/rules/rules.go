@@ -0,0 +1,327 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Action represents a [Rule]'s outcome.
+type Action string
+
+const (
+	// ActionAllow admits the request, ending evaluation.
+	ActionAllow Action = "allow"
+
+	// ActionDeny rejects the request, ending evaluation.
+	ActionDeny Action = "deny"
+
+	// ActionChallenge challenges the request, ending evaluation. See [Options.Challenge].
+	ActionChallenge Action = "challenge"
+
+	// ActionTag adds the rule's [Rule.Tags] to the request's [Valuer], and continues evaluation.
+	ActionTag Action = "tag"
+)
+
+// Rule represents a single, ordered rule. A rule matches a request when every non-empty predicate matches; values within a
+// predicate are alternatives (i.e. any value may match). A rule without predicates matches every request.
+type Rule struct {
+	// Name identifies the rule in logs and the request's [Valuer].
+	Name string `json:"name"`
+
+	// Action represents the rule's outcome when matched.
+	Action Action `json:"action"`
+
+	// Addresses represents client IP addresses and CIDR ranges (e.g. "203.0.113.0/24").
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Countries represents ISO 3166-1 alpha-2 country codes, matched case-insensitively. See [Options.Country].
+	Countries []string `json:"countries,omitempty"`
+
+	// Agents represents regular expressions matched against the request's user-agent.
+	Agents []string `json:"agents,omitempty"`
+
+	// Tenants represents tenant identifiers. See [Options.Tenant].
+	Tenants []string `json:"tenants,omitempty"`
+
+	// Methods represents HTTP methods, matched case-insensitively.
+	Methods []string `json:"methods,omitempty"`
+
+	// Paths represents regular expressions matched against the request's URL path.
+	Paths []string `json:"paths,omitempty"`
+
+	// Tags represents the tag(s) applied by an [ActionTag] rule.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// attributes represents the request attributes rules are evaluated against.
+type attributes struct {
+	address netip.Addr
+	country string
+	agent   string
+	tenant  string
+	method  string
+	path    string
+}
+
+// compiled represents a parsed [Rule].
+type compiled struct {
+	rule      Rule
+	prefixes  []netip.Prefix
+	countries map[string]struct{}
+	agents    []*regexp.Regexp
+	tenants   map[string]struct{}
+	methods   map[string]struct{}
+	paths     []*regexp.Regexp
+}
+
+// set returns a set of the provided values, optionally uppercased.
+func set(values []string, upper bool) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	result := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if upper {
+			v = strings.ToUpper(v)
+		}
+
+		result[v] = struct{}{}
+	}
+
+	return result
+}
+
+// expressions compiles the provided regular expressions.
+func expressions(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expression, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, e
+		}
+
+		result = append(result, expression)
+	}
+
+	return result, nil
+}
+
+// compile validates and parses a [Rule].
+func compile(rule Rule) (*compiled, error) {
+	switch rule.Action {
+	case ActionAllow, ActionDeny, ActionChallenge, ActionTag:
+	default:
+		return nil, fmt.Errorf("rule %q: invalid action %q", rule.Name, rule.Action)
+	}
+
+	c := &compiled{
+		rule:      rule,
+		countries: set(rule.Countries, true),
+		tenants:   set(rule.Tenants, false),
+		methods:   set(rule.Methods, true),
+	}
+
+	for _, address := range rule.Addresses {
+		if prefix, e := netip.ParsePrefix(address); e == nil {
+			c.prefixes = append(c.prefixes, prefix.Masked())
+		} else if v, e := netip.ParseAddr(address); e == nil {
+			c.prefixes = append(c.prefixes, netip.PrefixFrom(v.Unmap(), v.Unmap().BitLen()))
+		} else {
+			return nil, fmt.Errorf("rule %q: invalid address %q", rule.Name, address)
+		}
+	}
+
+	var e error
+	if c.agents, e = expressions(rule.Agents); e != nil {
+		return nil, fmt.Errorf("rule %q: invalid agent expression: %w", rule.Name, e)
+	}
+
+	if c.paths, e = expressions(rule.Paths); e != nil {
+		return nil, fmt.Errorf("rule %q: invalid path expression: %w", rule.Name, e)
+	}
+
+	return c, nil
+}
+
+// matches reports whether any expression matches the value.
+func matches(expressions []*regexp.Regexp, value string) bool {
+	for _, expression := range expressions {
+		if expression.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match reports whether the compiled rule matches the request's attributes.
+func (c *compiled) match(a *attributes) bool {
+	if len(c.prefixes) > 0 {
+		matched := false
+		for _, prefix := range c.prefixes {
+			if a.address.IsValid() && prefix.Contains(a.address) {
+				matched = true
+				break
+			}
+		}
+
+		if !(matched) {
+			return false
+		}
+	}
+
+	if _, found := c.countries[strings.ToUpper(a.country)]; c.countries != nil && !(found) {
+		return false
+	}
+
+	if _, found := c.tenants[a.tenant]; c.tenants != nil && !(found) {
+		return false
+	}
+
+	if _, found := c.methods[strings.ToUpper(a.method)]; c.methods != nil && !(found) {
+		return false
+	}
+
+	if len(c.agents) > 0 && !(matches(c.agents, a.agent)) {
+		return false
+	}
+
+	if len(c.paths) > 0 && !(matches(c.paths, a.path)) {
+		return false
+	}
+
+	return true
+}
+
+// Engine represents a hot-reloadable, ordered set of rules. An [Engine] is safe for concurrent use; the zero value contains no rules.
+type Engine struct {
+	rules atomic.Pointer[[]*compiled]
+}
+
+// Update validates and atomically replaces the engine's rules. On error, the engine's existing rules are retained.
+func (x *Engine) Update(rules []Rule) error {
+	set := make([]*compiled, 0, len(rules))
+	for _, rule := range rules {
+		c, e := compile(rule)
+		if e != nil {
+			return e
+		}
+
+		set = append(set, c)
+	}
+
+	x.rules.Store(&set)
+
+	return nil
+}
+
+// Load decodes a JSON array of [Rule] values from the reader, and updates the engine's rules. See [Engine.Update].
+func (x *Engine) Load(reader io.Reader) error {
+	var rules []Rule
+
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	if e := decoder.Decode(&rules); e != nil {
+		return fmt.Errorf("unable to decode rules: %w", e)
+	}
+
+	return x.Update(rules)
+}
+
+// file loads the rules at path.
+func (x *Engine) file(path string) error {
+	f, e := os.Open(path)
+	if e != nil {
+		return e
+	}
+
+	defer f.Close()
+
+	return x.Load(f)
+}
+
+// Watch loads the JSON rules file at path, then polls the file's modification time at the provided interval - reloading the
+// rules upon change - until the context is cancelled. An error is returned only if the initial load fails; subsequent invalid
+// revisions are logged, and the engine's existing rules are retained. A non-positive interval defaults to 5 seconds.
+func (x *Engine) Watch(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		slog.WarnContext(ctx, "Invalid Rules Watch Interval Specified - Using Default Interval")
+
+		interval = 5 * time.Second
+	}
+
+	info, e := os.Stat(path)
+	if e != nil {
+		return e
+	}
+
+	if e := x.file(path); e != nil {
+		return e
+	}
+
+	modified := info.ModTime()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, e := os.Stat(path)
+				if e != nil || info.ModTime().Equal(modified) {
+					continue
+				}
+
+				modified = info.ModTime()
+
+				if e := x.file(path); e != nil {
+					slog.WarnContext(ctx, "Unable to Reload Rules - Retaining Existing Rules", slog.String("path", path), slog.String("error", e.Error()))
+
+					continue
+				}
+
+				slog.InfoContext(ctx, "Reloaded Rules", slog.String("path", path))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// evaluate returns the first matching terminal rule - nil if none matched - and the tags of all preceding matching tag rules.
+func (x *Engine) evaluate(a *attributes) (terminal *Rule, tags []string) {
+	pointer := x.rules.Load()
+	if pointer == nil {
+		return nil, nil
+	}
+
+	for _, c := range *pointer {
+		if !(c.match(a)) {
+			continue
+		}
+
+		if c.rule.Action == ActionTag {
+			tags = append(tags, c.rule.Tags...)
+
+			continue
+		}
+
+		return &c.rule, tags
+	}
+
+	return nil, tags
+}
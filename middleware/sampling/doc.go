@@ -0,0 +1,12 @@
+// Package sampling provides middleware that computes a single, effective sampling decision per request. Tracing, debug logging,
+// and capture-related middleware(s) are expected to consult the decision via [Value], rather than each applying their own rules.
+//
+// A decision is derived, in order of precedence, from:
+//
+//   - The upstream caller's sampled flag (W3C, B3, X-Ray, Datadog, or New Relic trace-context header(s)).
+//   - The first matching [Rule].
+//   - The default [Options.Percentage].
+//
+// Unsampled requests are force-sampled once the response's status satisfies [Options.Force] (server errors, by default). Consumers
+// evaluating the decision after the downstream handler returns therefore observe the final decision.
+package sampling
@@ -0,0 +1,55 @@
+package sampling_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/sampling"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(sampling.New().Settings(func(o *sampling.Options) {
+		o.Percentage = 0
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		decision := sampling.Value(r.Context())
+
+		fmt.Printf("Sampled: %v, Reason: %s\n", decision.Sampled(), decision.Reason())
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	// The upstream caller's sampled flag takes precedence over the local percentage.
+	request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	response, e := server.Client().Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Sampled: true, Reason: upstream
+}
@@ -0,0 +1,279 @@
+package sampling
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "sampling"
+
+const defaultPercentage = 100
+
+// Reason(s) represent why a [Decision] was made. See [Decision.Reason].
+const (
+	ReasonUpstream   = "upstream"
+	ReasonRule       = "rule"
+	ReasonPercentage = "percentage"
+	ReasonForced     = "forced"
+)
+
+// Rule represents a local sampling rule. See [Options.Rules].
+type Rule struct {
+	// Match reports whether the rule applies to the request. A nil value never matches.
+	Match func(r *http.Request) bool
+
+	// Percentage represents the percentage (0 - 100) of matching requests to sample.
+	Percentage float64
+}
+
+// Decision represents a request's effective sampling decision. A [Decision] is safe for concurrent use.
+type Decision struct {
+	mutex   sync.RWMutex
+	sampled bool
+	reason  string
+}
+
+// Sampled reports whether the request is sampled.
+func (d *Decision) Sampled() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.sampled
+}
+
+// Reason returns the reason for the decision (e.g. [ReasonUpstream]).
+func (d *Decision) Reason() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.reason
+}
+
+// MarshalJSON encodes the [Decision] as {"sampled": bool, "reason": "..."}.
+func (d *Decision) MarshalJSON() ([]byte, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return json.Marshal(struct {
+		Sampled bool   `json:"sampled"`
+		Reason  string `json:"reason,omitempty"`
+	}{Sampled: d.sampled, Reason: d.reason})
+}
+
+// Force upgrades the decision to sampled, with [ReasonForced] as its reason. Handlers can call Force to retain a request deemed
+// noteworthy (e.g. a slow query). Already-sampled decisions are unaffected.
+func (d *Decision) Force() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !(d.sampled) {
+		d.sampled = true
+		d.reason = ReasonForced
+	}
+}
+
+// Options represents the configuration settings for the [Sampling] middleware component.
+type Options struct {
+	// Upstream enables honoring the caller's sampled flag, as parsed by [telemetrics.Parse]. Deferred upstream decisions fall
+	// through to local rules. Defaults to true.
+	Upstream bool
+
+	// Rules represents local sampling rules, evaluated in order. The first matching rule's percentage is applied in place of
+	// [Options.Percentage]. Defaults to an empty slice.
+	Rules []Rule
+
+	// Percentage represents the percentage (0 - 100) of requests to sample when no upstream decision or [Rule] applies. Defaults to 100.
+	Percentage float64
+
+	// Force reports whether an unsampled request should be force-sampled given its response status. A value of nil disables
+	// forced sampling. Defaults to a function returning true for server errors (5xx).
+	Force func(status int) bool
+
+	// Level specifies the log level used to record sampling decisions. A value of nil disables logging. Defaults to nil.
+	Level slog.Leveler
+
+	// Random returns a pseudo-random number in [0.0, 1.0), and is overwritable for testing purposes. Defaults to [rand.Float64].
+	Random func() float64
+}
+
+// Sampling represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Sampling struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Sampling] middleware's [Options] and returns the updated middleware instance.
+func (s *Sampling) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if s.options == nil {
+		s.options = &Options{
+			Upstream:   true,
+			Rules:      []Rule{},
+			Percentage: defaultPercentage,
+			Force: func(status int) bool {
+				return status >= http.StatusInternalServerError
+			},
+			Level:  nil,
+			Random: rand.Float64,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(s.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if s.options.Percentage < 0 || s.options.Percentage > 100 {
+		slog.Warn("Invalid Sampling Percentage Specified - Using Default Percentage")
+
+		s.options.Percentage = defaultPercentage
+	}
+
+	if s.options.Random == nil {
+		s.options.Random = rand.Float64
+	}
+
+	return s
+}
+
+// decide computes the request's initial sampling decision.
+func (s *Sampling) decide(r *http.Request) *Decision {
+	if s.options.Upstream {
+		if trace := telemetrics.Parse(r.Header); trace != nil && trace.Sampled != nil {
+			return &Decision{sampled: *trace.Sampled, reason: ReasonUpstream}
+		}
+	}
+
+	for _, rule := range s.options.Rules {
+		if rule.Match != nil && rule.Match(r) {
+			return &Decision{sampled: s.options.Random()*100 < rule.Percentage, reason: ReasonRule}
+		}
+	}
+
+	return &Decision{sampled: s.options.Random()*100 < s.options.Percentage, reason: ReasonPercentage}
+}
+
+// Handler computes the request's [Decision], and stores it in the request context. It forwards the request to the next handler in the chain.
+func (s *Sampling) Handler(next http.Handler) http.Handler {
+	s.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		decision := s.decide(r)
+
+		ctx = context.WithValue(ctx, key, decision)
+
+		if s.options.Force != nil && !(decision.Sampled()) {
+			w = &writer{ResponseWriter: w, decision: decision, force: s.options.Force}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		if v := s.options.Level; v != nil {
+			slog.Log(ctx, v.Level(), "Sampling Decision", slog.Bool("sampled", decision.Sampled()), slog.String("reason", decision.Reason()))
+		}
+	})
+}
+
+// New creates a new instance of the [Sampling] middleware, implementing [middleware.Configurable]. If [Sampling.Settings] isn't called,
+// then the [Sampling.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Sampling)
+}
+
+// Value retrieves the request's [Decision] from the provided context. If a nil value is returned, it can be assumed that the
+// [Sampling] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (decision *Decision) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Decision); ok {
+		decision = v
+	} else if test, valid := ctx.Value(t).(*Decision); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		decision = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Sampled is a convenience function reporting whether the request associated with the provided context is sampled. Requests
+// without a [Decision] (i.e. the [Sampling] middleware isn't enabled) are considered sampled.
+func Sampled(ctx context.Context) bool {
+	if v, ok := ctx.Value(key).(*Decision); ok {
+		return v.Sampled()
+	}
+
+	return true
+}
+
+// writer force-samples the request's [Decision] once the response's status is written.
+type writer struct {
+	http.ResponseWriter
+
+	decision *Decision
+	force    func(status int) bool
+	written  bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) {
+		w.written = true
+
+		if w.force(status) {
+			w.decision.Force()
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], evaluating the response's status for force-sampling before flushing its header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Runtime assurance that [Sampling] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Sampling)(nil)
@@ -0,0 +1,174 @@
+package sampling_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/sampling"
+)
+
+func Test(t *testing.T) {
+	// handler relays the request's decision via response header(s).
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision := sampling.Value(r.Context())
+		if decision == nil {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+
+		w.Header().Set("X-Sampled", strconv.FormatBool(decision.Sampled()))
+		w.Header().Set("X-Reason", decision.Reason())
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// evaluate issues a request, with the provided header(s), against the middleware.
+	evaluate := func(t *testing.T, m http.Handler, header http.Header) *http.Response {
+		t.Helper()
+
+		server := httptest.NewServer(m)
+
+		defer server.Close()
+
+		request, e := http.NewRequest(http.MethodGet, server.URL+"/", nil)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Request: %v", e)
+		}
+
+		for k, v := range header {
+			request.Header[k] = v
+		}
+
+		response, e := server.Client().Do(request)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Generating Response: %v", e)
+		}
+
+		response.Body.Close()
+
+		return response
+	}
+
+	const trace = "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1"
+
+	tests := []struct {
+		name    string
+		options func(o *sampling.Options)
+		header  http.Header
+		sampled string
+		reason  string
+		random  float64
+	}{
+		{name: "Default", sampled: "true", reason: sampling.ReasonPercentage},
+		{name: "Upstream-Sampled", header: http.Header{"B3": {trace + "-1"}}, options: func(o *sampling.Options) { o.Percentage = 0 }, sampled: "true", reason: sampling.ReasonUpstream},
+		{name: "Upstream-Unsampled", header: http.Header{"B3": {trace + "-0"}}, sampled: "false", reason: sampling.ReasonUpstream},
+		{name: "Upstream-Deferred", header: http.Header{"B3": {trace}}, options: func(o *sampling.Options) { o.Percentage = 0 }, sampled: "false", reason: sampling.ReasonPercentage},
+		{name: "Upstream-Disabled", header: http.Header{"B3": {trace + "-0"}}, options: func(o *sampling.Options) { o.Upstream = false }, sampled: "true", reason: sampling.ReasonPercentage},
+		{name: "Percentage", options: func(o *sampling.Options) { o.Percentage = 25 }, random: 0.3, sampled: "false", reason: sampling.ReasonPercentage},
+		{
+			name: "Rule",
+			options: func(o *sampling.Options) {
+				o.Percentage = 0
+				o.Rules = []sampling.Rule{
+					{Match: nil, Percentage: 0},
+					{Match: func(r *http.Request) bool { return strings.HasPrefix(r.URL.Path, "/") }, Percentage: 50},
+				}
+			},
+			random:  0.2,
+			sampled: "true",
+			reason:  sampling.ReasonRule,
+		},
+		{name: "Invalid-Percentage", options: func(o *sampling.Options) { o.Percentage = 150 }, random: 0.99, sampled: "true", reason: sampling.ReasonPercentage},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := sampling.New().Settings(func(o *sampling.Options) {
+				o.Random = func() float64 { return test.random }
+			}, test.options).Handler(handler)
+
+			response := evaluate(t, m, test.header)
+
+			if v := response.Header.Get("X-Sampled"); v != test.sampled {
+				t.Errorf("Sampled = %s\n    - Expectation = %s", v, test.sampled)
+			}
+
+			if v := response.Header.Get("X-Reason"); v != test.reason {
+				t.Errorf("Reason = %s\n    - Expectation = %s", v, test.reason)
+			}
+		})
+	}
+
+	t.Run("Force-On-Error", func(t *testing.T) {
+		decisions := make(chan *sampling.Decision, 1)
+
+		m := sampling.New().Settings(func(o *sampling.Options) { o.Percentage = 0 }).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decisions <- sampling.Value(r.Context())
+
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+
+		evaluate(t, m, nil)
+
+		decision := <-decisions
+		if !(decision.Sampled()) || decision.Reason() != sampling.ReasonForced {
+			t.Errorf("Decision = (%t, %s)\n    - Expectation = (true, %s)", decision.Sampled(), decision.Reason(), sampling.ReasonForced)
+		}
+	})
+
+	t.Run("Force-Disabled", func(t *testing.T) {
+		decisions := make(chan *sampling.Decision, 1)
+
+		m := sampling.New().Settings(func(o *sampling.Options) { o.Percentage = 0; o.Force = nil }).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decisions <- sampling.Value(r.Context())
+
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		evaluate(t, m, nil)
+
+		if decision := <-decisions; decision.Sampled() {
+			t.Errorf("Unexpected Forced Decision: %s", decision.Reason())
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		decision := new(sampling.Decision)
+
+		for _, expectation := range []string{`{"sampled":false}`, `{"sampled":true,"reason":"forced"}`} {
+			serialized, e := json.Marshal(decision)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Encoding Decision: %v", e)
+			}
+
+			if string(serialized) != expectation {
+				t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+			}
+
+			decision.Force()
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := sampling.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		if !(sampling.Sampled(context.Background())) {
+			t.Errorf("Expected Requests Without a Decision to be Sampled")
+		}
+
+		decision := new(sampling.Decision)
+		decision.Force()
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", decision)
+		if v := sampling.Value(ctx); v != decision || !(v.Sampled()) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
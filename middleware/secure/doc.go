@@ -0,0 +1,8 @@
+// Package secure provides middleware setting security-related response headers - Strict-Transport-Security,
+// Content-Security-Policy, X-Content-Type-Options, Referrer-Policy, X-Frame-Options, and Permissions-Policy - with sensible
+// defaults.
+//
+// Each header is configurable through its [Options] field, and disabled by an empty value. Headers are set prior to the next
+// handler, such that handlers can override any header for a particular response. A report-only mode
+// ([Options.ReportOnly]) emits the policy as Content-Security-Policy-Report-Only, for evaluating a policy without enforcing it.
+package secure
@@ -0,0 +1,55 @@
+package secure_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/secure"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(secure.New().Settings(func(o *secure.Options) {
+		o.ContentSecurityPolicy = "default-src 'self'"
+		o.ReportOnly = true
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Content-Security-Policy-Report-Only: %s\n", response.Header.Get("Content-Security-Policy-Report-Only"))
+	fmt.Printf("X-Frame-Options: %s\n", response.Header.Get("X-Frame-Options"))
+
+	// Output:
+	// Content-Security-Policy-Report-Only: default-src 'self'
+	// X-Frame-Options: DENY
+}
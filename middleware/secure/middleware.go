@@ -0,0 +1,139 @@
+package secure
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// Options represents the configuration settings for the [Secure] middleware component. An empty header value disables the
+// respective header.
+type Options struct {
+	// StrictTransportSecurity represents the Strict-Transport-Security header's value. The header is only set for requests
+	// received over TLS, or forwarded with an "X-Forwarded-Proto: https" header, as browsers ignore it otherwise. Defaults to
+	// "max-age=31536000; includeSubDomains".
+	StrictTransportSecurity string
+
+	// ContentSecurityPolicy represents the Content-Security-Policy header's value. Defaults to "default-src 'self';
+	// base-uri 'self'; object-src 'none'; frame-ancestors 'none'".
+	ContentSecurityPolicy string
+
+	// ReportOnly emits [Options.ContentSecurityPolicy] as the Content-Security-Policy-Report-Only header, such that violations
+	// are reported, but not enforced. Defaults to false.
+	ReportOnly bool
+
+	// Report represents the URI violation reports are sent to, appended to [Options.ContentSecurityPolicy] as its report-uri
+	// directive. Defaults to an empty string.
+	Report string
+
+	// ContentTypeOptions represents the X-Content-Type-Options header's value. Defaults to "nosniff".
+	ContentTypeOptions string
+
+	// ReferrerPolicy represents the Referrer-Policy header's value. Defaults to "strict-origin-when-cross-origin".
+	ReferrerPolicy string
+
+	// FrameOptions represents the X-Frame-Options header's value. Defaults to "DENY".
+	FrameOptions string
+
+	// PermissionsPolicy represents the Permissions-Policy header's value. Defaults to "camera=(), geolocation=(), microphone=()".
+	PermissionsPolicy string
+}
+
+// Secure represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Secure struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Secure] middleware's [Options] and returns the updated middleware instance.
+func (x *Secure) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			StrictTransportSecurity: "max-age=31536000; includeSubDomains",
+			ContentSecurityPolicy:   "default-src 'self'; base-uri 'self'; object-src 'none'; frame-ancestors 'none'",
+			ReportOnly:              false,
+			Report:                  "",
+			ContentTypeOptions:      "nosniff",
+			ReferrerPolicy:          "strict-origin-when-cross-origin",
+			FrameOptions:            "DENY",
+			PermissionsPolicy:       "camera=(), geolocation=(), microphone=()",
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.ReportOnly && x.options.ContentSecurityPolicy == "" {
+		slog.Warn("Secure Report-Only Mode Specified Without a Content-Security-Policy - Report-Only Mode Disabled")
+	}
+
+	return x
+}
+
+// policy returns the Content-Security-Policy header's key and value.
+func (x *Secure) policy() (string, string) {
+	k := "Content-Security-Policy"
+	if x.options.ReportOnly {
+		k = "Content-Security-Policy-Report-Only"
+	}
+
+	value := x.options.ContentSecurityPolicy
+	if value != "" && x.options.Report != "" {
+		value = strings.TrimSuffix(strings.TrimSpace(value), ";") + "; report-uri " + x.options.Report
+	}
+
+	return k, value
+}
+
+// secure reports whether the request was received over TLS, directly or via a TLS-terminating proxy.
+func secure(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// Handler sets the configured security header(s) on the response, and forwards the request to the next handler in the chain.
+func (x *Secure) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	k, policy := x.policy()
+
+	headers := [][2]string{
+		{k, policy},
+		{"X-Content-Type-Options", x.options.ContentTypeOptions},
+		{"Referrer-Policy", x.options.ReferrerPolicy},
+		{"X-Frame-Options", x.options.FrameOptions},
+		{"Permissions-Policy", x.options.PermissionsPolicy},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+
+		for _, pair := range headers {
+			if pair[1] != "" {
+				header.Set(pair[0], pair[1])
+			}
+		}
+
+		if x.options.StrictTransportSecurity != "" && secure(r) {
+			header.Set("Strict-Transport-Security", x.options.StrictTransportSecurity)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// New creates a new instance of the [Secure] middleware, implementing [middleware.Configurable]. If [Secure.Settings] isn't called,
+// then the [Secure.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Secure)
+}
+
+// Runtime assurance that [Secure] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Secure)(nil)
@@ -0,0 +1,116 @@
+package secure_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/secure"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings func(o *secure.Options)
+		request  func(r *http.Request)
+		expected map[string]string
+	}{
+		{
+			name: "Defaults",
+			expected: map[string]string{
+				"Strict-Transport-Security":           "",
+				"Content-Security-Policy":             "default-src 'self'; base-uri 'self'; object-src 'none'; frame-ancestors 'none'",
+				"Content-Security-Policy-Report-Only": "",
+				"X-Content-Type-Options":              "nosniff",
+				"Referrer-Policy":                     "strict-origin-when-cross-origin",
+				"X-Frame-Options":                     "DENY",
+				"Permissions-Policy":                  "camera=(), geolocation=(), microphone=()",
+			},
+		},
+		{
+			name: "TLS",
+			request: func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{}
+			},
+			expected: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			},
+		},
+		{
+			name: "Forwarded-TLS",
+			request: func(r *http.Request) {
+				r.Header.Set("X-Forwarded-Proto", "https")
+			},
+			expected: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			},
+		},
+		{
+			name: "Report-Only",
+			settings: func(o *secure.Options) {
+				o.ContentSecurityPolicy = "default-src 'self';"
+				o.ReportOnly = true
+				o.Report = "/csp-reports"
+			},
+			expected: map[string]string{
+				"Content-Security-Policy":             "",
+				"Content-Security-Policy-Report-Only": "default-src 'self'; report-uri /csp-reports",
+			},
+		},
+		{
+			name: "Overrides",
+			settings: func(o *secure.Options) {
+				o.StrictTransportSecurity = "max-age=63072000; includeSubDomains; preload"
+				o.FrameOptions = "SAMEORIGIN"
+				o.PermissionsPolicy = ""
+			},
+			request: func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{}
+			},
+			expected: map[string]string{
+				"Strict-Transport-Security": "max-age=63072000; includeSubDomains; preload",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Permissions-Policy":        "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := secure.New().Settings(tt.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.request != nil {
+				tt.request(request)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			for k, expectation := range tt.expected {
+				if v := recorder.Header().Get(k); v != expectation {
+					t.Errorf("%s = %s\n    - Expectation = %s", k, v, expectation)
+				}
+			}
+		})
+	}
+
+	t.Run("Handler-Precedence", func(t *testing.T) {
+		handler := secure.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Get("X-Frame-Options"); v != "SAMEORIGIN" {
+			t.Errorf("X-Frame-Options = %s\n    - Expectation = %s", v, "SAMEORIGIN")
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"sync"
 
 	"github.com/poly-gun/go-middleware"
 )
@@ -27,6 +28,15 @@ type Options struct {
 	// Warnings specifies whether a warning log message should be logged in the [Service] middleware component's [Service.Handler] function. Defaults to true. Warnings are only emitted
 	// if the [Options.Name] or [Options.Header] values contain an empty string, and therefore will skip updating any response header(s).
 	Warnings bool
+
+	// Stack optionally returns the service's middleware chain [middleware.Manifest] - typically [middleware.Middleware.Manifest] - from
+	// which an "X-Middleware-Stack" response header is derived, identifying the chain's composition and its middleware's versions for
+	// fleet-wide audits. The manifest is evaluated once, upon the first request. Defaults to nil, omitting the header.
+	Stack func() middleware.Manifest
+
+	// Digest specifies whether the "X-Middleware-Stack" header's value is the composition's hash - see [middleware.Manifest.Digest] -
+	// rather than its short list of middleware and versions - see [middleware.Manifest.Stack]. Defaults to false.
+	Digest bool
 }
 
 // Service represents a middleware component that applies configurable [Options] settings to HTTP requests. It
@@ -44,6 +54,8 @@ func (s *Service) Settings(configuration ...func(o *Options)) middleware.Configu
 			Header:   "X-Service-Name",
 			Name:     "",
 			Warnings: true,
+			Stack:    nil,
+			Digest:   false,
 		}
 	}
 
@@ -60,9 +72,17 @@ func (s *Service) Settings(configuration ...func(o *Options)) middleware.Configu
 func (s *Service) Handler(next http.Handler) http.Handler {
 	s.Settings() // Ensure the options field isn't nil.
 
+	composition := sync.OnceValue(func() string {
+		return stack(s.options)
+	})
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		if value := composition(); value != "" {
+			w.Header().Set("X-Middleware-Stack", value)
+		}
+
 		// Update the request context with the applicable key-value pair(s).
 		{
 			ctx = context.WithValue(ctx, key, s.options.Name)
@@ -84,6 +104,20 @@ func (s *Service) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// stack returns the "X-Middleware-Stack" header's value, or an empty string if [Options.Stack] isn't specified.
+func stack(options *Options) string {
+	if options.Stack == nil {
+		return ""
+	}
+
+	manifest := options.Stack()
+	if options.Digest {
+		return manifest.Digest()
+	}
+
+	return manifest.Stack()
+}
+
 // New creates a new instance of the [Service] middleware, implementing [middleware.Configurable]. If [Service.Settings] isn't called,
 // then the [Service.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
 func New() middleware.Configurable[Options] {
@@ -7,8 +7,10 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/poly-gun/go-middleware"
 	"github.com/poly-gun/go-middleware/middleware/service"
 )
 
@@ -164,6 +166,39 @@ func Test(t *testing.T) {
 			}
 		})
 
+		t.Run("Successful-Middleware-Stack-Headers", func(t *testing.T) {
+			const header = "X-Middleware-Stack"
+
+			for _, digest := range []bool{false, true} {
+				chain := middleware.New()
+
+				chain.Use(service.New().Settings(func(o *service.Options) {
+					o.Name = "Test-Service"
+					o.Stack = chain.Manifest
+					o.Digest = digest
+				}))
+
+				expectation := chain.Manifest().Stack()
+				if digest {
+					expectation = chain.Manifest().Digest()
+				}
+
+				server := httptest.NewServer(chain.Handler(handler))
+
+				response, e := server.Client().Get(server.URL)
+				if e != nil {
+					t.Fatalf("Unexpected Error While Generating Response: %v", e)
+				}
+
+				response.Body.Close()
+				server.Close()
+
+				if v := response.Header.Get(header); v != expectation || !(strings.HasPrefix(v, "service")) && !(digest) {
+					t.Errorf("%s = %s\n    - Expectation = %s", header, v, expectation)
+				}
+			}
+		})
+
 		t.Run("No-Emitted-Warning", func(t *testing.T) {
 			t.Parallel()
 
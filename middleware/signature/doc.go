@@ -0,0 +1,13 @@
+// Package signature provides middleware verifying HMAC request signatures, as produced by the client middleware's Signature
+// propagator (e.g. for service-to-service calls, or webhooks). Requests carry the following header(s):
+//
+//   - "X-Signature-Key-ID": the identifier of the signing secret - optional, if an unidentified secret is configured.
+//   - "X-Signature-Timestamp": the signing time, in unix seconds.
+//   - "X-Content-SHA256": the body's hex-encoded SHA-256 digest.
+//   - "X-Signature": "v1=", followed by the hex-encoded HMAC-SHA256 of the newline-delimited timestamp, method, request URI, and
+//     body digest.
+//
+// Timestamps must fall within [Options.Skew] of the server's clock, and each signature is claimed in [Options.Nonces] until its
+// timestamp leaves the window, such that captured requests can't be replayed. Multi-instance deployments should provide a shared
+// [Nonces] implementation. The verified key identifier is available via [Value].
+package signature
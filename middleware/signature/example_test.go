@@ -0,0 +1,83 @@
+package signature_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/signature"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	secret := []byte("secret") // Typically loaded from a secret store.
+
+	middleware.Add(signature.New().Settings(func(o *signature.Options) {
+		o.Keys = map[string][]byte{"billing": secret}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /webhooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Verified Key ID: %s\n", signature.Value(r.Context()).ID)
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	body := `{"event":"invoice.paid"}`
+
+	request, e := http.NewRequest(http.MethodPost, server.URL+"/webhooks", strings.NewReader(body))
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	// Sign the request - as the client middleware's Signature propagator does.
+	digest := sha256.Sum256([]byte(body))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "\n" + http.MethodPost + "\n" + "/webhooks" + "\n" + hex.EncodeToString(digest[:])))
+
+	request.Header.Set("X-Signature-Key-ID", "billing")
+	request.Header.Set("X-Signature-Timestamp", timestamp)
+	request.Header.Set("X-Content-SHA256", hex.EncodeToString(digest[:]))
+	request.Header.Set("X-Signature", "v1="+hex.EncodeToString(mac.Sum(nil)))
+
+	for range 2 {
+		replay := request.Clone(request.Context())
+		replay.Body, _ = request.GetBody()
+
+		response, e := client.Do(replay)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Verified Key ID: billing
+	// Status: 204
+	// Status: 401
+}
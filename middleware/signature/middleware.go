@@ -0,0 +1,251 @@
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "signature"
+
+// ErrReplayed is the error representing a previously claimed signature.
+var ErrReplayed = errors.New("signature replayed")
+
+// Valuer is the context return type relating to the [Signature] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// ID represents the verified signature's key identifier. Empty for the unidentified key.
+	ID string `json:"id"`
+
+	// Timestamp represents the verified signature's signing time.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Options represents the configuration settings for the [Signature] middleware component.
+type Options struct {
+	// Keys represents the signing secrets, by key identifier. The empty identifier's secret verifies requests without an
+	// "X-Signature-Key-ID" header. Rotate secrets by adding a new identifier, then retiring the old one. Defaults to an empty map,
+	// rejecting every request.
+	Keys map[string][]byte
+
+	// Skew represents the maximum difference between a signature's timestamp and the server's clock. Defaults to 5 minutes.
+	Skew time.Duration
+
+	// Nonces represents the replay protection store. A nil value disables replay protection. A [Memory] store without a
+	// [Memory.Clock] adopts [Options.Clock]. Defaults to an in-process [Memory] store of up to 65536 nonces.
+	Nonces Nonces
+
+	// Limit represents the maximum request body size, in bytes, digested for verification. Larger requests receive a
+	// [http.StatusRequestEntityTooLarge] response. Defaults to 1 MiB.
+	Limit int64
+
+	// Clock returns the current time. Defaults to [time.Now].
+	Clock func() time.Time
+}
+
+// Signature represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Signature struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Signature] middleware's [Options] and returns the updated middleware instance.
+func (s *Signature) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if s.options == nil {
+		s.options = &Options{
+			Keys:   map[string][]byte{},
+			Skew:   5 * time.Minute,
+			Nonces: NewMemory(65536),
+			Limit:  1 << 20,
+			Clock:  time.Now,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(s.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if s.options.Skew <= 0 {
+		slog.Warn("Invalid Signature Skew Specified - Using Default Skew")
+
+		s.options.Skew = 5 * time.Minute
+	}
+
+	if s.options.Limit <= 0 {
+		slog.Warn("Invalid Signature Limit Specified - Using Default Limit")
+
+		s.options.Limit = 1 << 20
+	}
+
+	if s.options.Clock == nil {
+		slog.Warn("Invalid Signature Clock Specified - Using Default Clock")
+
+		s.options.Clock = time.Now
+	}
+
+	return s
+}
+
+// failure represents a verification failure's reject code, and response status.
+type failure struct {
+	code   string
+	status int
+}
+
+// verify verifies the request's signature, returning its [Valuer], and the request with its body restored.
+func (s *Signature) verify(r *http.Request) (*Valuer, *http.Request, *failure) {
+	timestamp, signature := r.Header.Get("X-Signature-Timestamp"), r.Header.Get("X-Signature")
+	if timestamp == "" || signature == "" {
+		return nil, r, &failure{code: "signature-missing", status: http.StatusUnauthorized}
+	}
+
+	id := r.Header.Get("X-Signature-Key-ID")
+
+	secret, found := s.options.Keys[id]
+	if !(found) || len(secret) == 0 {
+		return nil, r, &failure{code: "signature-unknown-key", status: http.StatusUnauthorized}
+	}
+
+	seconds, e := strconv.ParseInt(timestamp, 10, 64)
+	if e != nil {
+		return nil, r, &failure{code: "signature-malformed", status: http.StatusUnauthorized}
+	}
+
+	signed, now := time.Unix(seconds, 0), s.options.Clock()
+	if signed.Before(now.Add(-s.options.Skew)) || signed.After(now.Add(s.options.Skew)) {
+		return nil, r, &failure{code: "signature-expired", status: http.StatusUnauthorized}
+	}
+
+	digest := sha256.New()
+	if r.Body != nil && r.Body != http.NoBody {
+		body, e := io.ReadAll(io.LimitReader(r.Body, s.options.Limit+1))
+		r.Body.Close()
+		if e != nil {
+			return nil, r, &failure{code: "signature-body-unreadable", status: http.StatusBadRequest}
+		}
+
+		if int64(len(body)) > s.options.Limit {
+			return nil, r, &failure{code: "signature-body-too-large", status: http.StatusRequestEntityTooLarge}
+		}
+
+		digest.Write(body)
+
+		r = r.Clone(r.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	sum := hex.EncodeToString(digest.Sum(nil))
+	if !(hmac.Equal([]byte(strings.ToLower(r.Header.Get("X-Content-SHA256"))), []byte(sum))) {
+		return nil, r, &failure{code: "signature-digest-mismatch", status: http.StatusUnauthorized}
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "\n" + r.Method + "\n" + r.URL.RequestURI() + "\n" + sum))
+
+	if !(hmac.Equal([]byte(signature), []byte("v1="+hex.EncodeToString(mac.Sum(nil))))) {
+		return nil, r, &failure{code: "signature-invalid", status: http.StatusUnauthorized}
+	}
+
+	return &Valuer{ID: id, Timestamp: signed}, r, nil
+}
+
+// claim claims the verified signature in [Options.Nonces], until its timestamp leaves the skew window.
+func (s *Signature) claim(ctx context.Context, valuer *Valuer, signature string) error {
+	if s.options.Nonces == nil {
+		return nil
+	}
+
+	claimed, e := s.options.Nonces.Claim(ctx, valuer.ID+":"+signature, valuer.Timestamp.Add(s.options.Skew))
+	if e != nil {
+		return e
+	}
+
+	if !(claimed) {
+		return ErrReplayed
+	}
+
+	return nil
+}
+
+// Handler verifies each request's signature - its key, timestamp, body digest, and HMAC - and claims it for replay protection,
+// storing the verified key identifier in the request context. Requests failing verification receive a [http.StatusUnauthorized]
+// response.
+func (s *Signature) Handler(next http.Handler) http.Handler {
+	s.Settings() // Ensure the options field isn't nil.
+
+	if len(s.options.Keys) == 0 {
+		slog.Warn("Signature Keys Unspecified - Rejecting Every Request")
+	}
+
+	if m, ok := s.options.Nonces.(*Memory); ok && m.Clock == nil {
+		m.Clock = s.options.Clock
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		valuer, r, failed := s.verify(r)
+		if failed == nil {
+			if e := s.claim(ctx, valuer, r.Header.Get("X-Signature")); errors.Is(e, ErrReplayed) {
+				failed = &failure{code: "signature-replayed", status: http.StatusUnauthorized}
+			} else if e != nil {
+				slog.ErrorContext(ctx, "Unable to Claim Signature Nonce", slog.String("error", e.Error()))
+
+				failed = &failure{code: "signature-nonce-unavailable", status: http.StatusServiceUnavailable}
+			}
+		}
+
+		if failed != nil {
+			reject.Record(ctx, reject.Reason{Subsystem: "signature", Code: failed.code, Status: failed.status})
+
+			slog.DebugContext(ctx, "Request Signature Verification Failed", slog.String("reason", failed.code))
+
+			http.Error(w, http.StatusText(failed.status), failed.status)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Signature] middleware, implementing [middleware.Configurable]. If [Signature.Settings] isn't
+// called, then the [Signature.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Signature)
+}
+
+// Value retrieves the request's verified signature [Valuer] from the provided context. If a nil value is returned, it can be
+// assumed that the [Signature] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Signature] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Signature)(nil)
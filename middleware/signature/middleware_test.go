@@ -0,0 +1,206 @@
+package signature_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/signature"
+)
+
+// sign signs the request as the client middleware's Signature propagator does.
+func sign(r *http.Request, id string, secret []byte, body string, timestamp time.Time) {
+	digest := sha256.Sum256([]byte(body))
+	sum := hex.EncodeToString(digest[:])
+
+	seconds := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(seconds + "\n" + r.Method + "\n" + r.URL.RequestURI() + "\n" + sum))
+
+	if id != "" {
+		r.Header.Set("X-Signature-Key-ID", id)
+	}
+
+	r.Header.Set("X-Signature-Timestamp", seconds)
+	r.Header.Set("X-Content-SHA256", sum)
+	r.Header.Set("X-Signature", "v1="+hex.EncodeToString(mac.Sum(nil)))
+}
+
+// failing represents a [signature.Nonces] implementation that's unavailable.
+type failing struct{}
+
+func (failing) Claim(context.Context, string, time.Time) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func Test(t *testing.T) {
+	secret, other := []byte("secret"), []byte("other")
+
+	now := time.Unix(1_800_000_000, 0)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Key-ID", signature.Value(r.Context()).ID)
+		w.Header().Set("X-Body", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	options := func(o *signature.Options) {
+		o.Keys = map[string][]byte{"key-1": secret, "": other}
+		o.Clock = func() time.Time { return now }
+	}
+
+	tests := []struct {
+		name    string
+		options func(o *signature.Options)
+		prepare func(r *http.Request)
+		status  int
+		id      string
+	}{
+		{name: "Valid", prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now) }, status: http.StatusOK, id: "key-1"},
+		{name: "Valid-Unidentified", prepare: func(r *http.Request) { sign(r, "", other, `{"id":1}`, now) }, status: http.StatusOK},
+		{name: "Valid-Skewed", prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now.Add(-4*time.Minute)) }, status: http.StatusOK, id: "key-1"},
+		{name: "Missing", prepare: func(r *http.Request) {}, status: http.StatusUnauthorized},
+		{name: "Unknown-Key", prepare: func(r *http.Request) { sign(r, "key-2", secret, `{"id":1}`, now) }, status: http.StatusUnauthorized},
+		{name: "Wrong-Secret", prepare: func(r *http.Request) { sign(r, "key-1", other, `{"id":1}`, now) }, status: http.StatusUnauthorized},
+		{name: "Expired", prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now.Add(-6*time.Minute)) }, status: http.StatusUnauthorized},
+		{name: "Future", prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now.Add(6*time.Minute)) }, status: http.StatusUnauthorized},
+		{name: "Malformed-Timestamp", prepare: func(r *http.Request) {
+			sign(r, "key-1", secret, `{"id":1}`, now)
+			r.Header.Set("X-Signature-Timestamp", "now")
+		}, status: http.StatusUnauthorized},
+		{name: "Tampered-Body", prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":2}`, now) }, status: http.StatusUnauthorized},
+		{name: "Tampered-Digest", prepare: func(r *http.Request) {
+			sign(r, "key-1", secret, `{"id":2}`, now)
+
+			digest := sha256.Sum256([]byte(`{"id":1}`))
+			r.Header.Set("X-Content-SHA256", hex.EncodeToString(digest[:]))
+		}, status: http.StatusUnauthorized},
+		{name: "Limit", options: func(o *signature.Options) { options(o); o.Limit = 4 }, prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now) }, status: http.StatusRequestEntityTooLarge},
+		{name: "Nonces-Unavailable", options: func(o *signature.Options) { options(o); o.Nonces = failing{} }, prepare: func(r *http.Request) { sign(r, "key-1", secret, `{"id":1}`, now) }, status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.options == nil {
+				tt.options = options
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/orders?id=1", strings.NewReader(`{"id":1}`))
+
+			tt.prepare(request)
+
+			recorder := httptest.NewRecorder()
+
+			signature.New().Settings(tt.options).Handler(handler).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("X-Key-ID"); v != tt.id {
+				t.Errorf("Key ID = %s\n    - Expectation = %s", v, tt.id)
+			}
+
+			if v := recorder.Header().Get("X-Body"); v != `{"id":1}` {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, `{"id":1}`)
+			}
+		})
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		h := signature.New().Settings(options).Handler(handler)
+
+		request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		sign(request, "key-1", secret, "", now)
+
+		for index, expectation := range []int{http.StatusOK, http.StatusUnauthorized} {
+			replay := request.Clone(context.Background())
+
+			recorder := httptest.NewRecorder()
+
+			h.ServeHTTP(recorder, replay)
+
+			if recorder.Code != expectation {
+				t.Errorf("Status (%d) = %d\n    - Expectation = %d", index, recorder.Code, expectation)
+			}
+		}
+	})
+
+	t.Run("Replay-Disabled", func(t *testing.T) {
+		h := signature.New().Settings(options, func(o *signature.Options) { o.Nonces = nil }).Handler(handler)
+
+		request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		sign(request, "key-1", secret, "", now)
+
+		for range 2 {
+			recorder := httptest.NewRecorder()
+
+			h.ServeHTTP(recorder, request.Clone(context.Background()))
+
+			if recorder.Code != http.StatusOK {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+			}
+		}
+	})
+
+	t.Run("Replay-Large-Skew", func(t *testing.T) {
+		current := now
+
+		h := signature.New().Settings(options, func(o *signature.Options) {
+			o.Skew = 2 * time.Hour
+			o.Clock = func() time.Time { return current }
+		}).Handler(handler)
+
+		request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		sign(request, "key-1", secret, "", now)
+
+		for index, advance := range []time.Duration{0, 90 * time.Minute} {
+			current = current.Add(advance)
+
+			recorder := httptest.NewRecorder()
+
+			h.ServeHTTP(recorder, request.Clone(context.Background()))
+
+			if expectation := []int{http.StatusOK, http.StatusUnauthorized}[index]; recorder.Code != expectation {
+				t.Errorf("Status (%s) = %d\n    - Expectation = %d", current.Sub(now), recorder.Code, expectation)
+			}
+		}
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		nonces := signature.NewMemory(16)
+
+		expiry := time.Now().Add(time.Minute)
+
+		if claimed, _ := nonces.Claim(context.Background(), "nonce", expiry); !(claimed) {
+			t.Errorf("Claimed = %t\n    - Expectation = %t", claimed, true)
+		}
+
+		if claimed, _ := nonces.Claim(context.Background(), "nonce", expiry); claimed {
+			t.Errorf("Claimed = %t\n    - Expectation = %t", claimed, false)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := signature.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
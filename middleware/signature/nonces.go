@@ -0,0 +1,70 @@
+package signature
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware/cache"
+)
+
+// Nonces represents a replay protection store of claimed signatures.
+type Nonces interface {
+	// Claim records the nonce until the expiry, reporting whether it was previously unclaimed. Implementations shared between
+	// instances must claim atomically (e.g. Redis' SET with NX, and PXAT).
+	Claim(ctx context.Context, nonce string, expiry time.Time) (bool, error)
+}
+
+// Memory represents an in-process [Nonces] implementation, suitable for single-instance deployments. Once full, the least recently
+// claimed nonces are evicted - see [cache.Cache] - so capacity should exceed the number of requests signed within twice the
+// [Options.Skew].
+type Memory struct {
+	// Clock returns the current time, and is overwritable for testing purposes. Defaults to the [Signature] middleware's
+	// [Options.Clock], or [time.Now] if used standalone.
+	Clock func() time.Time
+
+	mutex sync.Mutex
+	cache *cache.Cache[string, struct{}]
+}
+
+// NewMemory returns an in-process [Nonces] implementation holding up to capacity nonces.
+func NewMemory(capacity int) *Memory {
+	m := new(Memory)
+
+	// Each nonce is retained until its own expiry - however distant, such that [Options.Skew] is never clamped.
+	m.cache = &cache.Cache[string, struct{}]{Capacity: capacity, TTL: time.Duration(math.MaxInt64), Clock: m.now}
+
+	return m
+}
+
+// now returns the current time.
+func (m *Memory) now() time.Time {
+	if m.Clock != nil {
+		return m.Clock()
+	}
+
+	return time.Now()
+}
+
+// Claim records the nonce until the expiry, reporting whether it was previously unclaimed.
+func (m *Memory) Claim(_ context.Context, nonce string, expiry time.Time) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, found := m.cache.Get(nonce); found {
+		return false, nil
+	}
+
+	ttl := expiry.Sub(m.now())
+	if ttl <= 0 {
+		return true, nil // The nonce has already expired; its signature is outside the skew window.
+	}
+
+	m.cache.SetTTL(nonce, struct{}{}, ttl)
+
+	return true, nil
+}
+
+// Runtime assurance that [Memory] satisfies [Nonces] requirement(s).
+var _ Nonces = (*Memory)(nil)
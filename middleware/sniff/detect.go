@@ -0,0 +1,109 @@
+package sniff
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Detectable body formats' media types.
+const (
+	JSON      = "application/json"
+	XML       = "application/xml"
+	Form      = "application/x-www-form-urlencoded"
+	Multipart = "multipart/form-data"
+)
+
+// detect returns the content type of the peeked body prefix - including a multipart body's boundary parameter - or an empty
+// string if the format is unrecognized. Complete reports whether the prefix represents the entire body.
+func detect(prefix []byte, complete bool) string {
+	trimmed := bytes.TrimLeftFunc(prefix, unicode.IsSpace)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch trimmed[0] {
+	case '{', '[':
+		if complete && !(json.Valid(trimmed)) {
+			return ""
+		}
+
+		return JSON
+	case '<':
+		if bytes.HasPrefix(trimmed, []byte("<?xml")) || (len(trimmed) > 1 && (unicode.IsLetter(rune(trimmed[1])) || trimmed[1] == '_')) {
+			return XML
+		}
+
+		return ""
+	}
+
+	if boundary, found := multipart(prefix); found {
+		return mime.FormatMediaType(Multipart, map[string]string{"boundary": boundary})
+	}
+
+	if form(prefix, complete) {
+		return Form
+	}
+
+	return ""
+}
+
+// multipart returns the boundary of a multipart body - whose first line is the "--"-prefixed boundary delimiter, followed by a
+// part's headers.
+func multipart(prefix []byte) (boundary string, found bool) {
+	line, remainder, found := bytes.Cut(prefix, []byte("\r\n"))
+	if !(found) || !(bytes.HasPrefix(line, []byte("--"))) || len(line) <= 2 || len(line) > 72 {
+		return "", false
+	}
+
+	if !(bytes.HasPrefix(bytes.ToLower(remainder), []byte("content-"))) {
+		return "", false
+	}
+
+	return string(line[2:]), true
+}
+
+// form reports whether the body prefix is a URL-encoded form: one or more "key=value" pairs without whitespace.
+func form(prefix []byte, complete bool) bool {
+	value := string(prefix)
+	if !(complete) {
+		// Exclude a trailing, potentially truncated pair.
+		if index := strings.LastIndexByte(value, '&'); index > 0 {
+			value = value[:index]
+		}
+	}
+
+	if !(strings.Contains(value, "=")) || strings.ContainsFunc(value, unicode.IsSpace) {
+		return false
+	}
+
+	for _, pair := range strings.Split(value, "&") {
+		if key, _, found := strings.Cut(pair, "="); !(found) || key == "" {
+			return false
+		}
+	}
+
+	_, e := url.ParseQuery(value)
+
+	return e == nil
+}
+
+// family returns the detectable format a media type belongs to (e.g. "application/problem+json" belongs to [JSON]), or an empty
+// string.
+func family(mediatype string) string {
+	switch {
+	case mediatype == JSON || strings.HasSuffix(mediatype, "+json"):
+		return JSON
+	case mediatype == XML || mediatype == "text/xml" || strings.HasSuffix(mediatype, "+xml"):
+		return XML
+	case mediatype == Form:
+		return Form
+	case mediatype == Multipart:
+		return Multipart
+	}
+
+	return ""
+}
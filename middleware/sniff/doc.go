@@ -0,0 +1,9 @@
+// Package sniff provides middleware detecting a request body's actual format - JSON, XML, URL-encoded form, or multipart - when
+// the request's Content-Type header is missing or generic (e.g. "application/octet-stream").
+//
+// Detected content types can replace the request's header for downstream handlers, which is helpful behind broken legacy
+// clients. Requests declaring a specific content type are verified against the body's format, and mismatches are logged, but
+// never rewritten.
+//
+// Only a bounded prefix of the body is inspected; the complete body remains available to downstream handlers.
+package sniff
@@ -0,0 +1,56 @@
+package sniff_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/sniff"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(sniff.New().Settings(func(o *sniff.Options) {
+		o.Level = nil
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Content-Type: %s\n", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	// A legacy client posting JSON without a Content-Type header.
+	request, e := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"key": "value"}`))
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Content-Type", "application/octet-stream")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	// Output:
+	// Content-Type: application/json
+}
@@ -0,0 +1,223 @@
+package sniff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "sniff"
+
+// Valuer is the context return type relating to the [Sniff] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Declared represents the request's original Content-Type header value.
+	Declared string `json:"declared"`
+
+	// Detected represents the detected content type, including a multipart body's boundary parameter. Empty if the body's format
+	// wasn't recognized, or the body wasn't inspected.
+	Detected string `json:"detected,omitempty"`
+
+	// Rewritten reports whether the request's Content-Type header was replaced with the detected content type.
+	Rewritten bool `json:"rewritten"`
+
+	// Mismatch reports whether the declared, specific content type disagrees with the detected format.
+	Mismatch bool `json:"mismatch"`
+}
+
+// Options represents the configuration settings for the [Sniff] middleware component.
+type Options struct {
+	// Peek represents the maximum number of request body bytes inspected. Defaults to 512.
+	Peek int
+
+	// Generic represents the declared media types considered generic, whose requests' bodies are sniffed. Requests without a
+	// Content-Type header are always sniffed. Defaults to "application/octet-stream", "binary/octet-stream", and
+	// "application/unknown".
+	Generic []string
+
+	// Rewrite enables replacing a missing or generic Content-Type header with the detected content type for downstream handlers.
+	// Defaults to true.
+	Rewrite bool
+
+	// Verify enables sniffing requests with a specific Content-Type header, recording mismatches between the declared and detected
+	// formats. Mismatched headers are never rewritten. Defaults to true.
+	Verify bool
+
+	// Level specifies the log level used to record rewrites and mismatches. A value of nil disables logging. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+}
+
+// Sniff represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Sniff struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Sniff] middleware's [Options] and returns the updated middleware instance.
+func (s *Sniff) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if s.options == nil {
+		s.options = &Options{
+			Peek:    512,
+			Generic: []string{"application/octet-stream", "binary/octet-stream", "application/unknown"},
+			Rewrite: true,
+			Verify:  true,
+			Level:   slog.LevelWarn,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(s.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if s.options.Peek <= 0 {
+		slog.Warn("Invalid Sniff Peek Specified - Using Default Peek")
+
+		s.options.Peek = 512
+	}
+
+	return s
+}
+
+// body restores a partially consumed request body.
+type body struct {
+	io.Reader
+	io.Closer
+}
+
+// generic reports whether the declared media type is missing or generic.
+func (s *Sniff) generic(mediatype string) bool {
+	if mediatype == "" {
+		return true
+	}
+
+	for _, v := range s.options.Generic {
+		if strings.EqualFold(v, mediatype) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peek reads up to [Options.Peek] bytes of the request's body, restoring the body for downstream handlers. Complete reports
+// whether the returned prefix represents the entire body.
+func (s *Sniff) peek(r *http.Request) (prefix []byte, complete bool, e error) {
+	prefix = make([]byte, s.options.Peek)
+
+	n, e := io.ReadFull(r.Body, prefix)
+	prefix = prefix[:n]
+
+	r.Body = body{Reader: io.MultiReader(bytes.NewReader(prefix), r.Body), Closer: r.Body}
+
+	switch {
+	case errors.Is(e, io.EOF), errors.Is(e, io.ErrUnexpectedEOF):
+		return prefix, true, nil
+	case e != nil:
+		return nil, false, e
+	}
+
+	return prefix, false, nil
+}
+
+// Handler detects the request body's format when the request's Content-Type header is missing or generic - optionally rewriting
+// the header - and verifies specific Content-Type headers against the body's format. It stores the request's [Valuer] in the
+// request context.
+func (s *Sniff) Handler(next http.Handler) http.Handler {
+	s.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		declared := r.Header.Get("Content-Type")
+
+		valuer := &Valuer{Declared: declared}
+
+		mediatype, _, _ := mime.ParseMediaType(declared)
+
+		generic := s.generic(mediatype)
+
+		if r.Body == nil || r.Body == http.NoBody || !(generic || s.options.Verify) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+
+			return
+		}
+
+		prefix, complete, e := s.peek(r)
+		if e != nil {
+			slog.WarnContext(ctx, "Unable to Read Request Body for Sniffing", slog.String("error", e.Error()))
+
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+			return
+		}
+
+		valuer.Detected = detect(prefix, complete)
+
+		if valuer.Detected != "" {
+			detected, _, _ := mime.ParseMediaType(valuer.Detected)
+
+			switch {
+			case generic && s.options.Rewrite:
+				// The request's header is cloned, ensuring the caller's request isn't modified.
+				r = r.Clone(ctx)
+				r.Header.Set("Content-Type", valuer.Detected)
+
+				valuer.Rewritten = true
+
+				if v := s.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Rewrote Request Content-Type", slog.String("declared", declared), slog.String("detected", valuer.Detected), slog.String("url", r.URL.String()))
+				}
+			case !(generic) && family(mediatype) != detected:
+				valuer.Mismatch = true
+
+				if v := s.options.Level; v != nil {
+					slog.Log(ctx, v.Level(), "Request Content-Type Mismatch", slog.String("declared", declared), slog.String("detected", valuer.Detected), slog.String("url", r.URL.String()))
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Sniff] middleware, implementing [middleware.Configurable]. If [Sniff.Settings] isn't called,
+// then the [Sniff.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Sniff)
+}
+
+// Value retrieves the request's sniffing result from the provided context. If a nil value is returned, it can be assumed that
+// the [Sniff] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Sniff] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Sniff)(nil)
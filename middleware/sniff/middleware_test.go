@@ -0,0 +1,125 @@
+package sniff_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/sniff"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := sniff.Value(r.Context())
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-Detected", v.Detected)
+		w.Header().Set("X-Rewritten", strconv.FormatBool(v.Rewritten))
+		w.Header().Set("X-Mismatch", strconv.FormatBool(v.Mismatch))
+		w.Header().Set("X-Body", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	multipart := "--boundary-123\r\nContent-Disposition: form-data; name=\"field\"\r\n\r\nvalue\r\n--boundary-123--\r\n"
+
+	tests := []struct {
+		name      string
+		options   func(o *sniff.Options)
+		declared  string
+		body      string
+		header    string
+		detected  string
+		rewritten bool
+		mismatch  bool
+	}{
+		{name: "JSON-Missing", body: `  {"key": "value"}`, header: sniff.JSON, detected: sniff.JSON, rewritten: true},
+		{name: "JSON-Array", declared: "application/octet-stream", body: `[1, 2, 3]`, header: sniff.JSON, detected: sniff.JSON, rewritten: true},
+		{name: "JSON-Invalid", body: `{not json`, detected: ""},
+		{name: "JSON-Truncated", options: func(o *sniff.Options) { o.Peek = 4 }, body: `{"key": "value"}`, header: sniff.JSON, detected: sniff.JSON, rewritten: true},
+		{name: "XML-Declaration", body: `<?xml version="1.0"?><root/>`, header: sniff.XML, detected: sniff.XML, rewritten: true},
+		{name: "XML-Element", declared: "Application/Octet-Stream", body: `<root><child/></root>`, header: sniff.XML, detected: sniff.XML, rewritten: true},
+		{name: "Form", body: `name=value&other=a%20b`, header: sniff.Form, detected: sniff.Form, rewritten: true},
+		{name: "Form-Truncated", options: func(o *sniff.Options) { o.Peek = 12 }, body: `name=value&other=a%20b`, header: sniff.Form, detected: sniff.Form, rewritten: true},
+		{name: "Multipart", body: multipart, header: "multipart/form-data; boundary=boundary-123", detected: "multipart/form-data; boundary=boundary-123", rewritten: true},
+		{name: "Plain-Text", body: "hello, world", detected: ""},
+		{name: "Binary", body: "\x89PNG\r\n\x1a\n", detected: ""},
+		{name: "Rewrite-Disabled", options: func(o *sniff.Options) { o.Rewrite = false }, body: `{"key": "value"}`, detected: sniff.JSON},
+		{name: "Specific-Match", declared: "application/problem+json", body: `{"title": "x"}`, header: "application/problem+json", detected: sniff.JSON},
+		{name: "Specific-Mismatch", declared: "application/json", body: `<root/>`, header: "application/json", detected: sniff.XML, mismatch: true},
+		{name: "Specific-Unverified", options: func(o *sniff.Options) { o.Verify = false }, declared: "application/json", body: `<root/>`, header: "application/json"},
+		{name: "Specific-Unrecognized", declared: "image/png", body: "\x89PNG\r\n\x1a\n", header: "image/png"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := sniff.New().Settings(func(o *sniff.Options) { o.Level = nil }, test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			if test.declared != "" {
+				request.Header.Set("Content-Type", test.declared)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Content-Type"); v != test.header {
+				t.Errorf("Content-Type = %s\n    - Expectation = %s", v, test.header)
+			}
+
+			if v := recorder.Header().Get("X-Detected"); v != test.detected {
+				t.Errorf("Detected = %s\n    - Expectation = %s", v, test.detected)
+			}
+
+			if v := recorder.Header().Get("X-Rewritten"); v != strconv.FormatBool(test.rewritten) {
+				t.Errorf("Rewritten = %s\n    - Expectation = %t", v, test.rewritten)
+			}
+
+			if v := recorder.Header().Get("X-Mismatch"); v != strconv.FormatBool(test.mismatch) {
+				t.Errorf("Mismatch = %s\n    - Expectation = %t", v, test.mismatch)
+			}
+
+			if v := recorder.Header().Get("X-Body"); v != test.body {
+				t.Errorf("Body = %q\n    - Expectation = %q", v, test.body)
+			}
+
+			// The caller's request mustn't be modified.
+			if v := request.Header.Get("Content-Type"); v != test.declared {
+				t.Errorf("Unexpected Modification of the Caller's Content-Type = %s\n    - Expectation = %s", v, test.declared)
+			}
+		})
+	}
+
+	t.Run("Empty-Body", func(t *testing.T) {
+		m := sniff.New().Handler(handler)
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if v := recorder.Header().Get("X-Detected"); v != "" {
+			t.Errorf("Detected = %s\n    - Expectation = %s", v, "")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := sniff.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &sniff.Valuer{Detected: sniff.JSON, Rewritten: true}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := sniff.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
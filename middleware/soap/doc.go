@@ -0,0 +1,10 @@
+// Package soap provides middleware hosting legacy SOAP endpoints behind a modern middleware chain, keeping authentication,
+// logging, and other cross-cutting concerns consistent.
+//
+// SOAP requests - identified by a "text/xml" (SOAP 1.1) or "application/soap+xml" (SOAP 1.2) content type - are routed to the
+// handler registered for the request's action (the SOAPAction header, or the content type's action parameter), falling back to
+// the envelope body's operation name. Unroutable and malformed requests receive SOAP fault envelopes, and operation handlers'
+// plain error responses are converted to faults. Handlers can write typed faults via [Write].
+//
+// Non-SOAP requests are forwarded to the next handler in the chain.
+package soap
@@ -0,0 +1,65 @@
+package soap_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/soap"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(soap.New().Settings(func(o *soap.Options) {
+		o.Operations = map[string]http.Handler{
+			"GetQuote": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Printf("Operation: %s\n", soap.Value(r.Context()).Operation)
+
+				w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+				w.WriteHeader(http.StatusOK)
+				return
+			}),
+		}
+	}).Handler)
+
+	server := httptest.NewServer(middleware.Handler(http.NotFoundHandler()))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, operation := range []string{"GetQuote", "Unknown"} {
+		body := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><` + operation + `/></soap:Body></soap:Envelope>`
+
+		request, e := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("Content-Type", "text/xml; charset=utf-8")
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		content, _ := io.ReadAll(response.Body)
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d, Fault: %t\n", response.StatusCode, strings.Contains(string(content), "<faultcode>soap:Client</faultcode>"))
+	}
+
+	// Output:
+	// Operation: GetQuote
+	// Status: 200, Fault: false
+	// Status: 500, Fault: true
+}
@@ -0,0 +1,171 @@
+package soap
+
+import (
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// Version represents a SOAP protocol version.
+type Version string
+
+const (
+	// Version11 represents SOAP 1.1, identified by a "text/xml" content type and SOAPAction header.
+	Version11 Version = "1.1"
+
+	// Version12 represents SOAP 1.2, identified by an "application/soap+xml" content type and optional action parameter.
+	Version12 Version = "1.2"
+)
+
+// Envelope namespaces.
+const (
+	Namespace11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	Namespace12 = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// Code represents a SOAP fault code, expressed in SOAP 1.1 terms. SOAP 1.2 envelopes translate [CodeClient] and [CodeServer]
+// to "Sender" and "Receiver", respectively.
+type Code string
+
+const (
+	// CodeClient represents a fault caused by the request's content.
+	CodeClient Code = "Client"
+
+	// CodeServer represents a fault caused by the server's processing.
+	CodeServer Code = "Server"
+
+	// CodeVersionMismatch represents a request with an unexpected envelope namespace.
+	CodeVersionMismatch Code = "VersionMismatch"
+
+	// CodeMustUnderstand represents a request with a mandatory, unprocessed header entry.
+	CodeMustUnderstand Code = "MustUnderstand"
+)
+
+// Fault represents a SOAP fault, and satisfies the error interface. See [Write].
+type Fault struct {
+	// Code represents the fault's code. Defaults to [CodeServer] when empty.
+	Code Code
+
+	// String represents the fault's human-readable explanation.
+	String string
+
+	// Actor optionally identifies the fault's source (SOAP 1.1 faultactor; SOAP 1.2 Role).
+	Actor string
+
+	// Detail optionally represents application-specific error information, written as escaped text.
+	Detail string
+}
+
+// Error returns the fault's explanation.
+func (f *Fault) Error() string {
+	return string(f.code()) + ": " + f.String
+}
+
+// code returns the fault's code, defaulting to [CodeServer].
+func (f *Fault) code() Code {
+	if f.Code == "" {
+		return CodeServer
+	}
+
+	return f.Code
+}
+
+// status returns the HTTP status code of the fault's response. SOAP 1.1 faults are always returned with a 500 status; SOAP 1.2
+// sender faults are returned with a 400 status.
+func (f *Fault) status(version Version) int {
+	if version == Version12 && f.code() == CodeClient {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// envelope11 represents a SOAP 1.1 fault envelope.
+type envelope11 struct {
+	XMLName xml.Name `xml:"soap:Envelope"`
+	Space   string   `xml:"xmlns:soap,attr"`
+	Fault   struct {
+		Code   string `xml:"faultcode"`
+		String string `xml:"faultstring"`
+		Actor  string `xml:"faultactor,omitempty"`
+		Detail string `xml:"detail,omitempty"`
+	} `xml:"soap:Body>soap:Fault"`
+}
+
+// envelope12 represents a SOAP 1.2 fault envelope.
+type envelope12 struct {
+	XMLName xml.Name `xml:"env:Envelope"`
+	Space   string   `xml:"xmlns:env,attr"`
+	Fault   struct {
+		Code   string `xml:"env:Code>env:Value"`
+		Reason struct {
+			Language string `xml:"xml:lang,attr"`
+			Text     string `xml:",chardata"`
+		} `xml:"env:Reason>env:Text"`
+		Role   string `xml:"env:Role,omitempty"`
+		Detail string `xml:"env:Detail,omitempty"`
+	} `xml:"env:Body>env:Fault"`
+}
+
+// marshal returns the fault's envelope for the provided version, and the envelope's content type.
+func (f *Fault) marshal(version Version) (content string, body []byte) {
+	var v any
+
+	switch version {
+	case Version12:
+		e := envelope12{Space: Namespace12}
+
+		code := f.code()
+		switch code {
+		case CodeClient:
+			code = "Sender"
+		case CodeServer:
+			code = "Receiver"
+		}
+
+		e.Fault.Code = "env:" + string(code)
+		e.Fault.Reason.Language = "en"
+		e.Fault.Reason.Text = f.String
+		e.Fault.Role = f.Actor
+		e.Fault.Detail = f.Detail
+
+		content, v = "application/soap+xml; charset=utf-8", e
+	default:
+		e := envelope11{Space: Namespace11}
+
+		e.Fault.Code = "soap:" + string(f.code())
+		e.Fault.String = f.String
+		e.Fault.Actor = f.Actor
+		e.Fault.Detail = f.Detail
+
+		content, v = "text/xml; charset=utf-8", e
+	}
+
+	body, _ = xml.Marshal(v) // The envelope types are always marshalable.
+
+	return content, append([]byte(xml.Header), body...)
+}
+
+// Write writes a SOAP fault envelope for the provided error, in the request's SOAP version. A [*Fault] error is written as-is;
+// other errors are logged, and written as a generic [CodeServer] fault to avoid exposing internal details.
+func Write(w http.ResponseWriter, r *http.Request, e error) {
+	fault := new(Fault)
+	if !(errors.As(e, &fault)) {
+		slog.ErrorContext(r.Context(), "Unhandled SOAP Operation Error", slog.String("error", e.Error()))
+
+		fault = &Fault{Code: CodeServer, String: http.StatusText(http.StatusInternalServerError)}
+	}
+
+	version := Version11
+	if v := Value(r.Context()); v != nil {
+		version = v.Version
+	}
+
+	content, body := fault.marshal(version)
+
+	w.Header().Set("Content-Type", content)
+	w.Header().Del("Content-Length")
+	w.WriteHeader(fault.status(version))
+	w.Write(body)
+}
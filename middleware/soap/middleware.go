@@ -0,0 +1,328 @@
+package soap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "soap"
+
+// Valuer is the context return type relating to the [SOAP] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Version represents the request's SOAP version.
+	Version Version `json:"version"`
+
+	// Action represents the request's SOAPAction header (SOAP 1.1), or the content type's action parameter (SOAP 1.2), unquoted.
+	Action string `json:"action,omitempty"`
+
+	// Operation represents the local name of the envelope body's first element.
+	Operation string `json:"operation"`
+}
+
+// Options represents the configuration settings for the [SOAP] middleware component.
+type Options struct {
+	// Operations maps SOAP actions and operation names to their handlers. A request's action is matched first, then its
+	// operation name. Defaults to an empty map.
+	Operations map[string]http.Handler
+
+	// Match optionally restricts the requests handled as SOAP requests (e.g. to a legacy endpoint's path), in addition to the
+	// Content-Type header check. A nil value handles every POST request with a SOAP content type. Defaults to nil.
+	Match func(r *http.Request) bool
+
+	// Limit represents the maximum SOAP request body size, in bytes. Defaults to 1 MiB.
+	Limit int64
+
+	// Convert enables converting an operation handler's non-XML error responses (status codes of 400 or above) into SOAP fault
+	// envelopes. Client-error messages are retained; server-error messages are replaced with the status text. Defaults to true.
+	Convert bool
+}
+
+// SOAP represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type SOAP struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [SOAP] middleware's [Options] and returns the updated middleware instance.
+func (s *SOAP) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if s.options == nil {
+		s.options = &Options{
+			Operations: map[string]http.Handler{},
+			Match:      nil,
+			Limit:      1 << 20,
+			Convert:    true,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(s.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if s.options.Limit <= 0 {
+		slog.Warn("Invalid SOAP Limit Specified - Using Default Limit")
+
+		s.options.Limit = 1 << 20
+	}
+
+	return s
+}
+
+// version returns the request's SOAP version and action, derived from its Content-Type and SOAPAction headers.
+func version(r *http.Request) (v Version, action string, ok bool) {
+	mediatype, parameters, e := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if e != nil {
+		return "", "", false
+	}
+
+	switch mediatype {
+	case "text/xml":
+		return Version11, strings.Trim(r.Header.Get("SOAPAction"), `"`), true
+	case "application/soap+xml":
+		return Version12, strings.Trim(parameters["action"], `"`), true
+	}
+
+	return "", "", false
+}
+
+// operation returns the local name of the envelope body's first element, validating the envelope's namespace against the
+// request's version.
+func operation(data []byte, v Version) (string, *Fault) {
+	namespace := Namespace11
+	if v == Version12 {
+		namespace = Namespace12
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	depth := 0
+	for {
+		token, e := decoder.Token()
+		if e != nil {
+			return "", &Fault{Code: CodeClient, String: "Malformed SOAP Envelope"}
+		}
+
+		switch t := token.(type) {
+		case xml.Directive:
+			return "", &Fault{Code: CodeClient, String: "Document Type Declarations Are Prohibited"}
+		case xml.StartElement:
+			depth++
+
+			switch depth {
+			case 1:
+				if t.Name.Local != "Envelope" {
+					return "", &Fault{Code: CodeClient, String: "Missing SOAP Envelope"}
+				} else if t.Name.Space != namespace {
+					return "", &Fault{Code: CodeVersionMismatch, String: "Unexpected SOAP Envelope Namespace"}
+				}
+			case 2:
+				if t.Name.Local != "Body" || t.Name.Space != namespace {
+					// Skip the envelope's header, and any other non-body element.
+					if e := decoder.Skip(); e != nil {
+						return "", &Fault{Code: CodeClient, String: "Malformed SOAP Envelope"}
+					}
+
+					depth--
+				}
+			case 3:
+				return t.Name.Local, nil
+			}
+		case xml.EndElement:
+			if depth--; depth < 2 {
+				return "", &Fault{Code: CodeClient, String: "Missing SOAP Body Operation"}
+			}
+		}
+	}
+}
+
+// writer converts an operation handler's non-XML error responses into SOAP fault envelopes.
+type writer struct {
+	http.ResponseWriter
+
+	status  int
+	wrote   bool
+	fault   bool
+	message bytes.Buffer
+}
+
+// WriteHeader intercepts non-XML error responses.
+func (w *writer) WriteHeader(status int) {
+	if w.wrote {
+		return
+	}
+
+	w.wrote = true
+
+	mediatype, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if status >= 400 && !(mediatype == "text/xml" || mediatype == "application/xml" || strings.HasSuffix(mediatype, "+xml")) {
+		w.status, w.fault = status, true
+
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write discards, but retains the leading bytes of, intercepted responses' bodies.
+func (w *writer) Write(b []byte) (int, error) {
+	if !(w.wrote) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.fault {
+		if remaining := 512 - w.message.Len(); remaining > 0 {
+			w.message.Write(b[:min(len(b), remaining)])
+		}
+
+		return len(b), nil
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements [http.Flusher]. Intercepted responses aren't flushed - their fault envelope is written once the operation's
+// handler returns.
+func (w *writer) Flush() {
+	if !(w.wrote) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !(w.fault) {
+		http.NewResponseController(w.ResponseWriter).Flush()
+	}
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler routes SOAP requests - identified by their Content-Type header - to the [Options.Operations] handler registered for
+// the request's action or operation name, and writes SOAP fault envelopes for unroutable requests. Non-SOAP requests are forwarded
+// to the next handler in the chain.
+func (s *SOAP) Handler(next http.Handler) http.Handler {
+	s.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		v, action, ok := version(r)
+		if !(ok) || r.Method != http.MethodPost || (s.options.Match != nil && !(s.options.Match(r))) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		valuer := &Valuer{Version: v, Action: action}
+
+		r = r.WithContext(context.WithValue(ctx, key, valuer))
+
+		data, e := io.ReadAll(http.MaxBytesReader(w, r.Body, s.options.Limit))
+		if e != nil {
+			message := "Unable to Read SOAP Request"
+			if v := new(http.MaxBytesError); errors.As(e, &v) {
+				message = "SOAP Request Too Large"
+			}
+
+			Write(w, r, &Fault{Code: CodeClient, String: message})
+
+			return
+		}
+
+		var fault *Fault
+		if valuer.Operation, fault = operation(data, v); fault != nil {
+			Write(w, r, fault)
+
+			return
+		}
+
+		handler, found := s.options.Operations[action]
+		if !(found) || action == "" {
+			handler, found = s.options.Operations[valuer.Operation]
+		}
+
+		if !(found) {
+			Write(w, r, &Fault{Code: CodeClient, String: "Unknown SOAP Operation: " + valuer.Operation})
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		if !(s.options.Convert) {
+			handler.ServeHTTP(w, r)
+
+			return
+		}
+
+		wrapper := &writer{ResponseWriter: w}
+
+		handler.ServeHTTP(wrapper, r)
+
+		if wrapper.fault {
+			fault := &Fault{Code: CodeServer, String: http.StatusText(wrapper.status)}
+			if wrapper.status < 500 {
+				fault.Code = CodeClient
+
+				if message := strings.TrimSpace(wrapper.message.String()); message != "" {
+					fault.String = message
+				}
+			}
+
+			Write(w, r, fault)
+		}
+	})
+}
+
+// New creates a new instance of the [SOAP] middleware, implementing [middleware.Configurable]. If [SOAP.Settings] isn't called,
+// then the [SOAP.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(SOAP)
+}
+
+// Value retrieves the request's SOAP metadata from the provided context. If a nil value is returned, it can be assumed that the
+// [SOAP] middleware isn't enabled for the particular caller's chain, or that the request isn't a SOAP request.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [SOAP] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*SOAP)(nil)
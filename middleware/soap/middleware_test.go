@@ -0,0 +1,209 @@
+package soap_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/soap"
+)
+
+// envelope wraps the operation element in a SOAP envelope of the provided namespace.
+func envelope(namespace, operation string) string {
+	return fmt.Sprintf(`<?xml version="1.0"?><s:Envelope xmlns:s="%s"><s:Header><h:Token xmlns:h="urn:example">abc</h:Token></s:Header><s:Body>%s</s:Body></s:Envelope>`, namespace, operation)
+}
+
+// fault represents the decodable subset of SOAP 1.1 and 1.2 fault envelopes.
+type fault struct {
+	Code11   string `xml:"Body>Fault>faultcode"`
+	String11 string `xml:"Body>Fault>faultstring"`
+	Code12   string `xml:"Body>Fault>Code>Value"`
+	Reason12 string `xml:"Body>Fault>Reason>Text"`
+}
+
+func Test(t *testing.T) {
+	operation := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := soap.Value(r.Context())
+
+			body, _ := io.ReadAll(r.Body)
+
+			w.Header().Set("X-Handler", name)
+			w.Header().Set("X-Operation", v.Operation)
+			w.Header().Set("X-Action", v.Action)
+			w.Header().Set("X-Body-Readable", fmt.Sprintf("%t", len(body) > 0))
+			w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	operations := map[string]http.Handler{
+		"GetQuote":                      operation("get-quote"),
+		"http://example.com/PlaceOrder": operation("place-order"),
+		"Invalid": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Invalid Symbol", http.StatusBadRequest)
+		}),
+		"Failure": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "database password is hunter2", http.StatusInternalServerError)
+		}),
+		"Typed": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			soap.Write(w, r, &soap.Fault{Code: soap.CodeClient, String: "Insufficient Funds", Detail: "balance=0"})
+		}),
+		"Untyped": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			soap.Write(w, r, errors.New("internal detail"))
+		}),
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", "next")
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		content   string
+		action    string
+		body      string
+		status    int
+		handler   string
+		operation string
+		code      string
+		message   string
+	}{
+		{name: "Non-SOAP", method: http.MethodPost, content: "application/json", body: `{}`, status: http.StatusOK, handler: "next"},
+		{name: "Non-POST", method: http.MethodGet, content: "text/xml", status: http.StatusOK, handler: "next"},
+		{name: "Operation-11", method: http.MethodPost, content: "text/xml; charset=utf-8", body: envelope(soap.Namespace11, `<m:GetQuote xmlns:m="urn:example"><m:Symbol>X</m:Symbol></m:GetQuote>`), status: http.StatusOK, handler: "get-quote", operation: "GetQuote"},
+		{name: "Operation-12", method: http.MethodPost, content: "application/soap+xml; charset=utf-8", body: envelope(soap.Namespace12, `<GetQuote/>`), status: http.StatusOK, handler: "get-quote", operation: "GetQuote"},
+		{name: "Action-11", method: http.MethodPost, content: "text/xml", action: `"http://example.com/PlaceOrder"`, body: envelope(soap.Namespace11, `<Order/>`), status: http.StatusOK, handler: "place-order", operation: "Order"},
+		{name: "Action-12", method: http.MethodPost, content: `application/soap+xml; action="http://example.com/PlaceOrder"`, body: envelope(soap.Namespace12, `<Order/>`), status: http.StatusOK, handler: "place-order", operation: "Order"},
+		{name: "Action-Unregistered", method: http.MethodPost, content: "text/xml", action: `"urn:unknown"`, body: envelope(soap.Namespace11, `<GetQuote/>`), status: http.StatusOK, handler: "get-quote", operation: "GetQuote"},
+		{name: "Unknown-Operation-11", method: http.MethodPost, content: "text/xml", body: envelope(soap.Namespace11, `<Unknown/>`), status: http.StatusInternalServerError, code: "soap:Client", message: "Unknown SOAP Operation: Unknown"},
+		{name: "Unknown-Operation-12", method: http.MethodPost, content: "application/soap+xml", body: envelope(soap.Namespace12, `<Unknown/>`), status: http.StatusBadRequest, code: "env:Sender", message: "Unknown SOAP Operation: Unknown"},
+		{name: "Version-Mismatch", method: http.MethodPost, content: "text/xml", body: envelope(soap.Namespace12, `<GetQuote/>`), status: http.StatusInternalServerError, code: "soap:VersionMismatch"},
+		{name: "Malformed", method: http.MethodPost, content: "text/xml", body: `<s:Envelope`, status: http.StatusInternalServerError, code: "soap:Client", message: "Malformed SOAP Envelope"},
+		{name: "Missing-Envelope", method: http.MethodPost, content: "text/xml", body: `<GetQuote/>`, status: http.StatusInternalServerError, code: "soap:Client", message: "Missing SOAP Envelope"},
+		{name: "Empty-Body", method: http.MethodPost, content: "text/xml", body: envelope(soap.Namespace11, ``), status: http.StatusInternalServerError, code: "soap:Client", message: "Missing SOAP Body Operation"},
+		{name: "Doctype", method: http.MethodPost, content: "text/xml", body: `<!DOCTYPE x [<!ENTITY e "e">]>` + envelope(soap.Namespace11, `<GetQuote/>`)[len(`<?xml version="1.0"?>`):], status: http.StatusInternalServerError, code: "soap:Client"},
+		{name: "Converted-Client-Error", method: http.MethodPost, content: "application/soap+xml", body: envelope(soap.Namespace12, `<Invalid/>`), status: http.StatusBadRequest, code: "env:Sender", message: "Invalid Symbol"},
+		{name: "Converted-Server-Error", method: http.MethodPost, content: "text/xml", body: envelope(soap.Namespace11, `<Failure/>`), status: http.StatusInternalServerError, code: "soap:Server", message: "Internal Server Error"},
+		{name: "Typed-Fault", method: http.MethodPost, content: "text/xml", body: envelope(soap.Namespace11, `<Typed/>`), status: http.StatusInternalServerError, code: "soap:Client", message: "Insufficient Funds"},
+		{name: "Untyped-Fault", method: http.MethodPost, content: "application/soap+xml", body: envelope(soap.Namespace12, `<Untyped/>`), status: http.StatusInternalServerError, code: "env:Receiver", message: "Internal Server Error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := soap.New().Settings(func(o *soap.Options) { o.Operations = operations }).Handler(next)
+
+			request := httptest.NewRequest(test.method, "/service", strings.NewReader(test.body))
+			request.Header.Set("Content-Type", test.content)
+			if test.action != "" {
+				request.Header.Set("SOAPAction", test.action)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if test.code == "" {
+				if v := recorder.Header().Get("X-Handler"); v != test.handler {
+					t.Errorf("Handler = %s\n    - Expectation = %s", v, test.handler)
+				}
+
+				if v := recorder.Header().Get("X-Operation"); v != test.operation {
+					t.Errorf("Operation = %s\n    - Expectation = %s", v, test.operation)
+				}
+
+				if v := recorder.Header().Get("X-Body-Readable"); test.handler != "next" && v != "true" {
+					t.Errorf("Expected Readable Request Body")
+				}
+
+				return
+			}
+
+			var f fault
+			if e := xml.Unmarshal(recorder.Body.Bytes(), &f); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Fault: %v\n%s", e, recorder.Body.String())
+			}
+
+			code, message := f.Code11, f.String11
+			if strings.HasPrefix(test.content, "application/soap+xml") {
+				code, message = f.Code12, f.Reason12
+			}
+
+			if code != test.code {
+				t.Errorf("Fault Code = %s\n    - Expectation = %s", code, test.code)
+			}
+
+			if test.message != "" && message != test.message {
+				t.Errorf("Fault Message = %s\n    - Expectation = %s", message, test.message)
+			}
+
+			if strings.Contains(recorder.Body.String(), "hunter2") || strings.Contains(recorder.Body.String(), "internal detail") {
+				t.Errorf("Unexpected Internal Error Detail in Fault: %s", recorder.Body.String())
+			}
+		})
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		m := soap.New().Settings(func(o *soap.Options) {
+			o.Operations = operations
+			o.Match = func(r *http.Request) bool { return r.URL.Path == "/legacy" }
+		}).Handler(next)
+
+		request := httptest.NewRequest(http.MethodPost, "/modern", strings.NewReader(`<document/>`))
+		request.Header.Set("Content-Type", "text/xml")
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		if v := recorder.Header().Get("X-Handler"); v != "next" {
+			t.Errorf("Handler = %s\n    - Expectation = %s", v, "next")
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		m := soap.New().Settings(func(o *soap.Options) {
+			o.Operations = operations
+			o.Limit = 16
+		}).Handler(next)
+
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(envelope(soap.Namespace11, `<GetQuote/>`)))
+		request.Header.Set("Content-Type", "text/xml")
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		if !(strings.Contains(recorder.Body.String(), "SOAP Request Too Large")) {
+			t.Errorf("Unexpected Response: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := soap.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &soap.Valuer{Version: soap.Version11, Operation: "GetQuote"}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := soap.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+// Package strict provides development-mode middleware detecting [net/http.ResponseWriter] misuse - double WriteHeader calls,
+// writes following a timeout's context cancellation, header mutations following the response's header(s) being written, and
+// Content-Length mismatches - logging each violation's source location.
+//
+// The middleware is intended for development and test environments; disabling it through [Options.Enabled] removes it from
+// the chain entirely.
+package strict
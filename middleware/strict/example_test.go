@@ -0,0 +1,56 @@
+package strict_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/strict"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(strict.New().Settings(func(o *strict.Options) {
+		// Only enable the middleware outside of production.
+		o.Enabled = os.Getenv("ENVIRONMENT") != "production"
+		o.Hook = func(violation strict.Violation) {
+			fmt.Printf("%s: %s\n", violation.Kind, violation.Detail)
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		// The status was already sent; the error status has no effect.
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+	request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	// Output: double-write-header: status 500 following status 200
+}
@@ -0,0 +1,324 @@
+package strict
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// Kind represents a [Violation]'s category.
+type Kind string
+
+const (
+	// DoubleWriteHeader represents a WriteHeader call following the response's header(s) being written.
+	DoubleWriteHeader Kind = "double-write-header"
+
+	// WriteAfterCancel represents a response write following the request context's cancellation (e.g. a timeout).
+	WriteAfterCancel Kind = "write-after-cancel"
+
+	// HeaderMutation represents a response header modified after the response's header(s) were written, which has no effect.
+	HeaderMutation Kind = "header-mutation"
+
+	// ContentLength represents a response body whose size differs from its declared Content-Length header.
+	ContentLength Kind = "content-length"
+)
+
+// Violation represents a detected response writer misuse.
+type Violation struct {
+	// Kind represents the violation's category.
+	Kind Kind
+
+	// Method represents the request's method.
+	Method string
+
+	// Path represents the request url's path.
+	Path string
+
+	// Origin represents the violating call's source location (e.g. "main.handler (/srv/main.go:42)"), if known.
+	Origin string
+
+	// Detail describes the violation (e.g. "status 500 following status 200").
+	Detail string
+}
+
+// Options represents the configuration settings for the [Strict] middleware component.
+type Options struct {
+	// Enabled enables the middleware. When disabled - e.g. in production - [Strict.Handler] returns the next handler as is,
+	// without overhead. Defaults to true.
+	Enabled bool
+
+	// Stack enables including the goroutine's stack trace in violations' log messages. Defaults to false.
+	Stack bool
+
+	// Level specifies the log level used to record violations. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Hook optionally receives every violation, e.g. to fail a test suite. Defaults to nil.
+	Hook func(violation Violation)
+}
+
+// Strict represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Strict struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [Strict] middleware's [Options] and returns the updated middleware instance.
+func (x *Strict) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Enabled: true,
+			Stack:   false,
+			Level:   slog.LevelWarn,
+			Hook:    nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Level == nil {
+		x.options.Level = slog.LevelWarn
+	}
+
+	return x
+}
+
+// origin returns the first caller outside of this package, net/http, and the runtime.
+func origin() string {
+	pcs := make([]uintptr, 32)
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+
+		internal := strings.HasPrefix(frame.Function, "github.com/poly-gun/go-middleware/middleware/strict.") || strings.HasPrefix(frame.Function, "net/http.") || strings.HasPrefix(frame.Function, "runtime.")
+		if !(internal) && frame.Function != "" {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+		}
+
+		if !(more) {
+			return ""
+		}
+	}
+}
+
+// writer detects, and reports, response writer misuse.
+type writer struct {
+	http.ResponseWriter
+
+	strict  *Strict
+	request *http.Request
+
+	status   int
+	written  bool
+	snapshot http.Header
+	declared int64 // declared represents the response's Content-Length header, or -1 if absent.
+	bytes    int64
+
+	reported map[Kind]bool // reported records the violation kinds reported, such that each is reported at most once.
+}
+
+// report logs the violation, and passes it to the [Options.Hook].
+func (w *writer) report(kind Kind, detail string) {
+	if w.reported[kind] {
+		return
+	}
+
+	w.reported[kind] = true
+
+	violation := Violation{Kind: kind, Method: w.request.Method, Path: w.request.URL.Path, Origin: origin(), Detail: detail}
+
+	attributes := []slog.Attr{
+		slog.String("kind", string(violation.Kind)),
+		slog.String("method", violation.Method),
+		slog.String("path", violation.Path),
+		slog.String("origin", violation.Origin),
+		slog.String("detail", violation.Detail),
+	}
+
+	if w.strict.options.Stack {
+		attributes = append(attributes, slog.String("stack", string(debug.Stack())))
+	}
+
+	slog.LogAttrs(w.request.Context(), w.strict.options.Level.Level(), "Response Writer Violation", attributes...)
+
+	if w.strict.options.Hook != nil {
+		w.strict.options.Hook(violation)
+	}
+}
+
+// mutated reports the response's header(s) being modified after they were written. Declared trailers are excluded.
+func (w *writer) mutated() {
+	if !(w.written) || w.reported[HeaderMutation] {
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+
+	trailers := make(map[string]bool)
+	for _, v := range w.snapshot.Values("Trailer") {
+		for _, name := range strings.Split(v, ",") {
+			trailers[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+		}
+	}
+
+	keys := make([]string, 0, len(header)+len(w.snapshot))
+	for k := range header {
+		keys = append(keys, k)
+	}
+
+	for k := range w.snapshot {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if strings.HasPrefix(k, http.TrailerPrefix) || trailers[k] {
+			continue
+		}
+
+		if !(slices.Equal(header[k], w.snapshot[k])) {
+			w.report(HeaderMutation, fmt.Sprintf("header %q modified after the response's header(s) were written", k))
+
+			return
+		}
+	}
+}
+
+// canceled reports writes following the request context's cancellation.
+func (w *writer) canceled() {
+	if e := w.request.Context().Err(); e != nil {
+		w.report(WriteAfterCancel, fmt.Sprintf("response written after the request's context was canceled: %s", e))
+	}
+}
+
+func (w *writer) WriteHeader(status int) {
+	w.canceled()
+
+	if status >= 100 && status < 200 && !(w.written) {
+		w.ResponseWriter.WriteHeader(status)
+
+		return
+	}
+
+	if w.written {
+		w.mutated()
+
+		w.report(DoubleWriteHeader, fmt.Sprintf("status %d following status %d", status, w.status))
+
+		w.ResponseWriter.WriteHeader(status)
+
+		return
+	}
+
+	w.written, w.status = true, status
+
+	w.declared = -1
+	if v := w.ResponseWriter.Header().Get("Content-Length"); v != "" {
+		if length, e := strconv.ParseInt(v, 10, 64); e == nil {
+			w.declared = length
+		}
+	}
+
+	w.snapshot = w.ResponseWriter.Header().Clone()
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.canceled()
+		w.mutated()
+	}
+
+	w.bytes += int64(len(p))
+
+	if w.declared >= 0 && w.bytes > w.declared {
+		w.report(ContentLength, fmt.Sprintf("wrote at least %d bytes for a declared Content-Length of %d", w.bytes, w.declared))
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// finish reports violations detectable only once the handler returns.
+func (w *writer) finish() {
+	if !(w.written) {
+		return
+	}
+
+	w.mutated()
+
+	bodiless := w.request.Method == http.MethodHead || w.status == http.StatusNoContent || w.status == http.StatusNotModified
+	if w.declared >= 0 && w.bytes < w.declared && !(bodiless) {
+		w.report(ContentLength, fmt.Sprintf("wrote %d bytes for a declared Content-Length of %d", w.bytes, w.declared))
+	}
+}
+
+// Flush implements [http.Flusher], snapshotting the response's header(s), and declared Content-Length, before flushing them.
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler wraps each request's [http.ResponseWriter], reporting double WriteHeader calls, writes following the request
+// context's cancellation, header mutations following the response's header(s) being written, and Content-Length mismatches.
+// The [Strict] middleware should be chained last - after e.g. timeout middleware - such that it observes the handler's
+// context and writes directly. If [Options.Enabled] is false, the next handler is returned as is.
+func (x *Strict) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if !(x.options.Enabled) {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wrapper := &writer{ResponseWriter: w, strict: x, request: r, declared: -1, reported: make(map[Kind]bool)}
+
+		defer wrapper.finish()
+
+		next.ServeHTTP(wrapper, r)
+	})
+}
+
+// New creates a new instance of the [Strict] middleware, implementing [middleware.Configurable]. If [Strict.Settings] isn't
+// called, then the [Strict.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Strict)
+}
+
+// Runtime assurance that [Strict] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Strict)(nil)
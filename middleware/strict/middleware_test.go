@@ -0,0 +1,158 @@
+package strict_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/strict"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		handler    func(w http.ResponseWriter, r *http.Request)
+		violations []strict.Kind
+		origin     bool
+	}{
+		{
+			name:   "Compliant",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "5")
+				w.Header().Set("Trailer", "X-Checksum")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("Hello"))
+				w.Header().Set("X-Checksum", "abc")
+			},
+			violations: nil,
+		},
+		{
+			name:   "Double-Write-Header",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			violations: []strict.Kind{strict.DoubleWriteHeader},
+			origin:     true,
+		},
+		{
+			name:   "Implicit-Double-Write-Header",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("Hello"))
+				w.WriteHeader(http.StatusNotFound)
+			},
+			violations: []strict.Kind{strict.DoubleWriteHeader},
+			origin:     true,
+		},
+		{
+			name:   "Informational-Status",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusEarlyHints)
+				w.WriteHeader(http.StatusOK)
+			},
+			violations: nil,
+		},
+		{
+			name:   "Header-Mutation",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Cache-Control", "no-store")
+				w.Write([]byte("Hello"))
+			},
+			violations: []strict.Kind{strict.HeaderMutation},
+			origin:     true,
+		},
+		{
+			name:   "Content-Length-Exceeded",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "2")
+				w.Write([]byte("Hello"))
+			},
+			violations: []strict.Kind{strict.ContentLength},
+			origin:     true,
+		},
+		{
+			name:   "Content-Length-Short",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "10")
+				w.Write([]byte("Hello"))
+			},
+			violations: []strict.Kind{strict.ContentLength},
+		},
+		{
+			name:   "Content-Length-Head",
+			method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "10")
+				w.WriteHeader(http.StatusOK)
+			},
+			violations: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var violations []strict.Violation
+
+			handler := strict.New().Settings(func(o *strict.Options) {
+				o.Hook = func(violation strict.Violation) { violations = append(violations, violation) }
+			}).Handler(http.HandlerFunc(test.handler))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, "/example", nil))
+
+			if len(violations) != len(test.violations) {
+				t.Fatalf("Violations = %v\n    - Expectation = %v", violations, test.violations)
+			}
+
+			for index := range violations {
+				if violations[index].Kind != test.violations[index] {
+					t.Errorf("Kind = %s\n    - Expectation = %s", violations[index].Kind, test.violations[index])
+				}
+
+				if test.origin && !(strings.Contains(violations[index].Origin, "strict_test.Test")) {
+					t.Errorf("Origin = %s\n    - Expectation = %s", violations[index].Origin, "strict_test.Test")
+				}
+			}
+		})
+	}
+
+	t.Run("Canceled-Request", func(t *testing.T) {
+		var violations []strict.Violation
+
+		handler := strict.New().Settings(func(o *strict.Options) {
+			o.Hook = func(violation strict.Violation) { violations = append(violations, violation) }
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("Hello"))
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		cancel()
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/example", nil).WithContext(ctx))
+
+		if len(violations) != 1 || violations[0].Kind != strict.WriteAfterCancel {
+			t.Errorf("Violations = %v\n    - Expectation = [%s]", violations, strict.WriteAfterCancel)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+		handler := strict.New().Settings(func(o *strict.Options) { o.Enabled = false }).Handler(next)
+
+		if _, ok := handler.(http.HandlerFunc); !(ok) {
+			t.Errorf("Disabled Middleware Didn't Return the Next Handler")
+		}
+	})
+}
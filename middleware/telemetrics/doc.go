@@ -8,6 +8,18 @@
 //   - Zipkin
 //   - Otel
 //   - AWS X-Ray
+//   - Datadog
+//   - New Relic
+//
+// Trace-context header(s) - W3C "traceparent", Zipkin B3 (single "b3" and multi-header), AWS X-Ray, Datadog, and New Relic - are
+// additionally parsed into a structured [Trace], which can be re-emitted in a supported [Format] for interoperability across
+// heterogeneous meshes.
+//
+// Captured header(s) default to [ProfileAll]. Named profiles - [ProfileMinimal], [ProfileW3C], [ProfileB3], [ProfileAWS], and
+// [ProfileIstio] - can be composed via [Options.Profiles] to capture only the relevant trace systems' header(s).
+//
+// A request's [Valuer] is shared by every downstream consumer, and must be treated as read-only. Its headers are copied from
+// the request, such that later request mutations aren't observed; consumers requiring a modifiable copy should use [Valuer.Clone].
 //
 // The package additionally provides middleware for adding request-specific route context.
 package telemetrics
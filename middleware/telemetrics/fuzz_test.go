@@ -0,0 +1,74 @@
+package telemetrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+)
+
+// token reports whether value is a non-empty header name of alphanumeric characters and hyphens.
+func token(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, character := range value {
+		if !(character == '-' || (character >= '0' && character <= '9') || (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FuzzExclusions asserts the properties of [telemetrics.Options.Additions] and [telemetrics.Options.Exclusions] interactions,
+// irrespective of casing and duplication: an addition is captured exactly once, unless it's excluded.
+func FuzzExclusions(f *testing.F) {
+	f.Add("X-Tenant", "x-tenant")
+	f.Add("x-tenant", "X-TENANT")
+	f.Add("X-Tenant", "X-Other")
+	f.Add("portal", "PORTAL")
+	f.Add("PORTAL", "x-other")
+	f.Add("User-Agent", "user-agent")
+
+	f.Fuzz(func(t *testing.T, addition string, exclusion string) {
+		if !(token(addition)) || !(token(exclusion)) {
+			t.Skip()
+		}
+
+		handler := telemetrics.New().Settings(func(o *telemetrics.Options) {
+			o.Additions = []string{addition, strings.ToUpper(addition), strings.ToLower(addition)}
+			o.Exclusions = []string{strings.ToUpper(exclusion)}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := telemetrics.Value(r.Context())
+
+			excluded := strings.EqualFold(addition, exclusion)
+			values := v.Values(addition)
+
+			switch {
+			case excluded && len(values) != 0:
+				t.Errorf("Excluded Header Captured: %s = %v", addition, values)
+			case !(excluded) && len(values) != 1:
+				t.Errorf("%s Values = %v\n    - Expectation = %v", addition, values, []string{"value"})
+			}
+
+			for k := range v.Headers {
+				if k != http.CanonicalHeaderKey(k) {
+					t.Errorf("Non-Canonical Header Key: %s", k)
+				}
+
+				if strings.EqualFold(k, exclusion) {
+					t.Errorf("Excluded Header Captured: %s", k)
+				}
+			}
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(addition, "value")
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+	})
+}
@@ -2,12 +2,14 @@ package telemetrics
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"slices"
 	"strings"
 
 	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/pii"
 )
 
 // keyer is a private string type, unexported to ensure the context, constant key is always unique.
@@ -16,38 +18,32 @@ type keyer string
 // key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
 const key keyer = "telemetrics"
 
-// merge accepts any number of []string arguments and returns a slice of unique strings.
-func merge(slices ...[]string) []string {
-	unique := make(map[string]bool)
-	for _, slice := range slices {
-		for _, str := range slice {
-			unique[str] = true
-		}
+// canonicalize returns the canonical form of every header in the given slices - ordered by first occurrence, without duplicates,
+// and excluding blank values, or any header whose canonical form is among the exclusions.
+func canonicalize(exclusions []string, headers ...[]string) []string {
+	excluded := make(map[string]struct{}, len(exclusions))
+	for _, header := range exclusions {
+		excluded[http.CanonicalHeaderKey(strings.TrimSpace(header))] = struct{}{}
 	}
 
-	// Convert map keys back to a slice
-	result := make([]string, 0, len(unique))
-	for str := range unique {
-		result = append(result, str)
-	}
+	var result []string
 
-	return result
-}
+	unique := make(map[string]struct{})
+	for _, slice := range headers {
+		for _, header := range slice {
+			k := http.CanonicalHeaderKey(strings.TrimSpace(header))
+			if k == "" {
+				continue
+			}
 
-// remove removes any string from "source" that exists in "removals".
-func remove(source []string, removals []string) []string {
-	// First, convert "removals" into a set (map with empty struct values).
-	negations := make(map[string]struct{}, len(removals))
-	for _, s := range removals {
-		negations[s] = struct{}{}
-	}
+			if _, found := excluded[k]; found {
+				continue
+			}
 
-	// Build a new slice containing only those items from "source"
-	// that aren't in "negations".
-	var result []string
-	for _, s := range source {
-		if _, found := negations[s]; !(found) {
-			result = append(result, s)
+			if _, found := unique[k]; !(found) {
+				unique[k] = struct{}{}
+				result = append(result, k)
+			}
 		}
 	}
 
@@ -56,12 +52,74 @@ func remove(source []string, removals []string) []string {
 
 // Valuer is the context return type relating to the [Telemetry] middleware. See the [Value] function for additional details.
 type Valuer struct {
-	// Headers retrieves a [http.Header] pointer representing [Telemetry] related headers.
+	// Headers retrieves a [http.Header] pointer representing [Telemetry] related headers. The map is shared by every consumer of
+	// the request's [Valuer], and must be treated as read-only: use [Valuer.Values] for copy-on-read access, or [Valuer.Clone]
+	// prior to any modification.
 	Headers http.Header `json:"headers"`
 
 	// Path represents the request url's path component a part of its URI. This value is useful for telemetry-related implementations that
 	// wish to provide additional information or context in spans for logging or event-related purposes.
 	Path string `json:"path"`
+
+	// Pattern represents the [Options.Mux] pattern matching the request (e.g. "GET /users/{id}"), suitable for aggregating
+	// telemetry by route template. Empty if [Options.Mux] is nil, or no pattern matches.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Trace represents the structured trace identifier parsed from the request's trace-context header(s). A nil value represents
+	// a request without a valid, recognized trace-context header. See [Parse] for the order of precedence.
+	Trace *Trace `json:"trace,omitempty"`
+}
+
+// credentials redacts credential header(s) from serialized [Valuer] values, retaining all other header(s) as-is.
+var credentials = &pii.Sanitizer{
+	Fields: map[string]pii.Policy{
+		"authorization":           pii.PolicyRedact,
+		"proxy-authorization":     pii.PolicyRedact,
+		"cookie":                  pii.PolicyRedact,
+		"set-cookie":              pii.PolicyRedact,
+		"jwt":                     pii.PolicyRedact,
+		"x-testing-authorization": pii.PolicyRedact,
+		"x-amzn-security-token":   pii.PolicyRedact,
+	},
+	Default: pii.PolicyAllow,
+}
+
+// MarshalJSON encodes the [Valuer] using its stable field names. Credential header values (e.g. Authorization, Cookie) are
+// replaced with "[REDACTED]", such that a serialized [Valuer] is safe to log; the header names are retained.
+func (v Valuer) MarshalJSON() ([]byte, error) {
+	type alias Valuer // alias sheds the MarshalJSON method, avoiding recursion.
+
+	value := alias(v)
+	value.Headers = credentials.Header(v.Headers)
+
+	return json.Marshal(value)
+}
+
+// Values returns a copy of the values associated with the given header key, which is canonicalized. Modifying the returned
+// slice doesn't affect the [Valuer].
+func (v *Valuer) Values(key string) []string {
+	return slices.Clone(v.Headers.Values(key))
+}
+
+// Clone returns a deep copy of the [Valuer], whose headers and trace can be modified without affecting the request's [Valuer],
+// nor any other consumer's.
+func (v *Valuer) Clone() *Valuer {
+	if v == nil {
+		return nil
+	}
+
+	clone := &Valuer{Headers: v.Headers.Clone(), Path: v.Path, Pattern: v.Pattern}
+
+	if v.Trace != nil {
+		trace := *v.Trace
+		if v.Trace.Sampled != nil {
+			trace.Sampled = sampled(*v.Trace.Sampled)
+		}
+
+		clone.Trace = &trace
+	}
+
+	return clone
 }
 
 // Options represents the configuration settings for the [Server] middleware component, including customizable server and header options.
@@ -93,6 +151,7 @@ type Options struct {
 	// 	- "x-b3-parentspanid"
 	// 	- "x-b3-sampled"
 	// 	- "x-b3-flags"
+	// 	- "b3"
 	// 	- "x-ot-span-context"
 	// 	- "x-api-version"
 	// 	- "x-testing-authorization"
@@ -116,8 +175,19 @@ type Options struct {
 	// 	- "x-amzn-security-token"
 	// 	- "x-amzn-cf-id"
 	// 	- "x-amzn-cf-identity"
+	// 	- "x-datadog-trace-id"
+	// 	- "x-datadog-parent-id"
+	// 	- "x-datadog-sampling-priority"
+	// 	- "x-datadog-origin"
+	// 	- "x-datadog-tags"
+	// 	- "newrelic"
 	Headers []string
 
+	// Profiles optionally selects, and composes, named [Profile] header sets (e.g. [ProfileW3C] and [ProfileAWS]). When non-empty,
+	// the union of the profiles' header(s) supersedes [Options.Headers]; [Options.Additions] and [Options.Exclusions] still apply.
+	// Defaults to nil (use [Options.Headers]).
+	Profiles []Profile
+
 	// Additions specifies additional headers to include with [Options.Headers]. Users looking to configure extra headers, without having to respecify the [Options.Headers] defaults,
 	// are encouraged to use Extra.
 	//
@@ -131,6 +201,18 @@ type Options struct {
 
 	// Debug enables log messages relating to identified [Telemetry] request headers. Defaults to false.
 	Debug bool
+
+	// Format specifies the canonical trace-context [Format]. When set, and a trace was parsed from the request, the trace's canonical
+	// header(s) are added to [Valuer.Headers] if not already present - converting, for example, an inbound "b3" header into a
+	// "traceparent" header for downstream propagation. Defaults to an empty string (no conversion).
+	Format Format
+
+	// Mux represents an optional [http.ServeMux] whose matched pattern is stored as [Valuer.Pattern]. Defaults to nil.
+	Mux *http.ServeMux
+
+	// Sanitizer optionally masks personally identifiable information in the [Valuer.Headers] and [Valuer.Path] values before
+	// they're stored in the request context. Trace-context parsing is unaffected. Defaults to nil (no sanitization).
+	Sanitizer *pii.Sanitizer
 }
 
 // Telemetry represents a middleware component that applies configurable [Options] settings to HTTP requests. It
@@ -145,55 +227,14 @@ type Telemetry struct {
 func (t *Telemetry) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
 	if t.options == nil {
 		t.options = &Options{
-			Headers: []string{
-				"portal",
-				"device",
-				"user",
-				"travel",
-				"traceparent",
-				"tracestate",
-				"x-cloud-trace-context",
-				"sw8",
-				"user-agent",
-				"cookie",
-				"authorization",
-				"jwt",
-				"true-client-ip",
-				"x-forwarded-for",
-				"x-real-ip",
-				"x-request-id",
-				"x-b3-traceid",
-				"x-b3-spanid",
-				"x-b3-parentspanid",
-				"x-b3-sampled",
-				"x-b3-flags",
-				"x-ot-span-context",
-				"x-api-version",
-				"x-testing-authorization",
-				"x-service-name",
-				"x-service-version",
-				"x-server-name",
-				"x-amzn-trace-id",
-				"x-amzn-parentspan-id",
-				"x-amzn-sampled",
-				"x-amzn-flags",
-				"x-amzn-correlation-id",
-				"x-amzn-trace-context",
-				"x-amzn-parentspan-context",
-				"x-amzn-sampled-context",
-				"x-amzn-correlation-context",
-				"x-amzn-trace-source",
-				"x-amzn-parentspan-source",
-				"x-amzn-sampled-source",
-				"x-amzn-correlation-source",
-				"x-amzn-date",
-				"x-amzn-security-token",
-				"x-amzn-cf-id",
-				"x-amzn-cf-identity",
-			},
+			Headers:    ProfileAll.Headers(),
+			Profiles:   nil,
 			Additions:  []string{},
 			Exclusions: []string{},
 			Debug:      false,
+			Format:     "",
+			Mux:        nil,
+			Sanitizer:  nil,
 		}
 	}
 
@@ -203,6 +244,17 @@ func (t *Telemetry) Settings(configuration ...func(o *Options)) middleware.Confi
 		}
 	}
 
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	t.options.Profiles = slices.DeleteFunc(slices.Clone(t.options.Profiles), func(profile Profile) bool {
+		if !(profile.Valid()) {
+			slog.Warn("Invalid Telemetry Profile Specified - Ignoring Profile", slog.String("profile", string(profile)))
+
+			return true
+		}
+
+		return false
+	})
+
 	return t
 }
 
@@ -210,43 +262,27 @@ func (t *Telemetry) Settings(configuration ...func(o *Options)) middleware.Confi
 func (t *Telemetry) Handler(next http.Handler) http.Handler {
 	t.Settings() // Ensure the options field isn't nil.
 
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		// Merge the default headers + any additions.
-		configuration := slices.Clone(merge(t.options.Headers, t.options.Additions))
-
-		// Typecast all headers in the configuration slices to a more simple form.
-		for index := 0; index < len(configuration); index++ {
-			value := strings.ToLower(configuration[index])
-			configuration[index] = value
+	// Canonicalize the default headers, any additions, and exclusions once, such that differently-cased, or duplicate, entries
+	// can neither slip past an exclusion nor be captured twice.
+	headers := t.options.Headers
+	if len(t.options.Profiles) > 0 {
+		headers = nil
+		for _, profile := range t.options.Profiles {
+			headers = append(headers, profile.Headers()...)
 		}
+	}
 
-		// Typecast all headers in the exclusions array to a more simple form.
-		exclusions := slices.Clone(t.options.Exclusions)
-		for index := 0; index < len(exclusions); index++ {
-			value := strings.ToLower(exclusions[index])
-			exclusions[index] = value
-		}
+	configuration := canonicalize(t.options.Exclusions, headers, t.options.Additions)
 
-		// Remove all headers defined in exclusions from the configuration.
-		configuration = remove(configuration, exclusions)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
 
 		// Establish the final headers that will be stored in context.
 		headers := http.Header{}
 
-		// Iterate through the list of the configuration headers, and then do a http.Header lookup (case-insensitive) for the key.
-		for index := range configuration {
-			header := configuration[index]
-
-			k := http.CanonicalHeaderKey(header)
-			v := slices.Clone(r.Header.Values(header))
-
-			_, found := headers[k]
-			if (found) || (v != nil && len(v) > 0) {
-				for _, value := range v {
-					headers.Add(k, value)
-				}
+		for _, k := range configuration {
+			if v := r.Header.Values(k); len(v) > 0 {
+				headers[k] = slices.Clone(v)
 			}
 		}
 
@@ -254,6 +290,26 @@ func (t *Telemetry) Handler(next http.Handler) http.Handler {
 		valuer := Valuer{
 			Headers: headers,
 			Path:    r.URL.Path,
+			Trace:   Parse(r.Header),
+		}
+
+		if t.options.Mux != nil {
+			_, valuer.Pattern = t.options.Mux.Handler(r)
+		}
+
+		// Emit the trace's canonical header(s), if configured, without overwriting any existing header(s).
+		if valuer.Trace != nil && t.options.Format != "" {
+			for k, v := range valuer.Trace.Header(t.options.Format) {
+				if _, found := headers[k]; !(found) {
+					headers[k] = v
+				}
+			}
+		}
+
+		// Mask personally identifiable information before any sink receives the valuer.
+		if t.options.Sanitizer != nil {
+			valuer.Headers = t.options.Sanitizer.Header(valuer.Headers)
+			valuer.Path = t.options.Sanitizer.String("path", valuer.Path)
 		}
 
 		// Cast the valuer context value to a pointer to provide additional information whether the middleware was enabled.
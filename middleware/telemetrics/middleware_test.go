@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/poly-gun/go-middleware/middleware/telemetrics"
+	"github.com/poly-gun/go-middleware/pii"
 )
 
 // id creates a random 128-bit ID (16 bytes), and returns it as a lowercase hex-encoded string.
@@ -210,6 +211,376 @@ func Test(t *testing.T) {
 				t.Errorf("Unexpected X-Amzn-Trace-ID Header")
 			}
 		})
+
+		t.Run("Sanitized-Server-Headers", func(t *testing.T) {
+			sanitizer := pii.New()
+
+			m := telemetrics.New().Settings(func(o *telemetrics.Options) {
+				o.Sanitizer = sanitizer
+			}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				v := telemetrics.Value(r.Context())
+
+				w.Header().Set("X-User", v.Headers.Get("User"))
+				w.Header().Set("X-Authorization", v.Headers.Get("Authorization"))
+				w.Header().Set("X-Path", v.Path)
+
+				if v.Trace == nil {
+					t.Errorf("Unexpected Nil Trace")
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/users/alice@example.com", nil)
+			request.Header.Set("User", "alice@example.com")
+			request.Header.Set("Authorization", "Bearer token")
+			request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			expectations := map[string]string{"X-User": "[REDACTED:email]", "X-Authorization": "[REDACTED]", "X-Path": "/users/[REDACTED:email]"}
+			for header, expectation := range expectations {
+				if v := recorder.Header().Get(header); v != expectation {
+					t.Errorf("%s = %s\n    - Expectation = %s", header, v, expectation)
+				}
+			}
+
+			if v := sanitizer.Detections()["email"]; v != 2 {
+				t.Errorf("Email Detections = %d\n    - Expectation = %d", v, 2)
+			}
+		})
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		const (
+			trace  = "80f198ee56343ba864fe8b2a57d3eff7"
+			span   = "e457b5a2e4d86bd1"
+			parent = "05e3ac9a4f6e3b90"
+		)
+
+		t.Run("Parsing", func(t *testing.T) {
+			tests := []struct {
+				name   string
+				header http.Header
+				format telemetrics.Format
+				debug  bool
+			}{
+				{name: "W3C", header: http.Header{"Traceparent": {"00-" + trace + "-" + span + "-01"}}, format: telemetrics.FormatW3C},
+				{name: "B3-Single", header: http.Header{"B3": {trace + "-" + span + "-1-" + parent}}, format: telemetrics.FormatB3},
+				{name: "B3-Single-Debug", header: http.Header{"B3": {trace + "-" + span + "-d"}}, format: telemetrics.FormatB3, debug: true},
+				{name: "B3-Multi", header: http.Header{"X-B3-Traceid": {trace}, "X-B3-Spanid": {span}, "X-B3-Sampled": {"1"}}, format: telemetrics.FormatB3Multi},
+				{name: "X-Ray", header: http.Header{"X-Amzn-Trace-Id": {"Root=1-80f198ee-56343ba864fe8b2a57d3eff7;Parent=" + span + ";Sampled=1"}}, format: telemetrics.FormatXRay},
+				{name: "Datadog", header: http.Header{"X-Datadog-Trace-Id": {"7277407061855694839"}, "X-Datadog-Parent-Id": {"16453819474850114513"}, "X-Datadog-Sampling-Priority": {"2"}, "X-Datadog-Tags": {"_dd.p.dm=-1,_dd.p.tid=80f198ee56343ba8"}}, format: telemetrics.FormatDatadog},
+				{name: "New-Relic", header: http.Header{"Newrelic": {"eyJ2IjpbMCwxXSwiZCI6eyJ0eSI6IkFwcCIsImFjIjoiMSIsImFwIjoiMiIsImlkIjoiZTQ1N2I1YTJlNGQ4NmJkMSIsInRyIjoiODBmMTk4ZWU1NjM0M2JhODY0ZmU4YjJhNTdkM2VmZjciLCJzYSI6dHJ1ZSwicHIiOjEuMiwidGkiOjF9fQ=="}}, format: telemetrics.FormatNewRelic},
+			}
+
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					v := telemetrics.Parse(test.header)
+					if v == nil {
+						t.Fatalf("Unexpected Nil Trace For Header(s): %v", test.header)
+					}
+
+					if v.TraceID != trace || v.SpanID != span {
+						t.Errorf("Trace = %s-%s\n    - Expectation = %s-%s", v.TraceID, v.SpanID, trace, span)
+					}
+
+					if v.Format != test.format {
+						t.Errorf("Format = %s\n    - Expectation = %s", v.Format, test.format)
+					}
+
+					if v.Sampled == nil || !(*v.Sampled) {
+						t.Errorf("Expected a Positive Sampling Decision")
+					}
+
+					if v.Debug != test.debug {
+						t.Errorf("Debug = %t\n    - Expectation = %t", v.Debug, test.debug)
+					}
+				})
+			}
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			for _, header := range []http.Header{
+				{"Traceparent": {"00-00000000000000000000000000000000-" + span + "-01"}},
+				{"B3": {"0"}},
+				{"B3": {trace + "-" + span + "-x"}},
+				{"X-B3-Traceid": {trace}},
+				{"X-Amzn-Trace-Id": {"Root=1-80f198ee;Parent=" + span}},
+				{"X-Datadog-Trace-Id": {"-1"}, "X-Datadog-Parent-Id": {"1"}},
+				{"X-Datadog-Trace-Id": {"1"}},
+				{"Newrelic": {"not-base64"}},
+			} {
+				if v := telemetrics.Parse(header); v != nil {
+					t.Errorf("Unexpected Trace For Invalid Header(s) (%v): %+v", header, v)
+				}
+			}
+		})
+
+		t.Run("B3-64-Bit-Trace-Identifier", func(t *testing.T) {
+			v, ok := telemetrics.ParseB3("56343ba864fe8b2a-" + span)
+			if !(ok) {
+				t.Fatalf("Unexpected Invalid B3 Header")
+			}
+
+			if expectation := "000000000000000056343ba864fe8b2a"; v.TraceID != expectation {
+				t.Errorf("Trace-ID = %s\n    - Expectation = %s", v.TraceID, expectation)
+			}
+
+			if v.Sampled != nil {
+				t.Errorf("Expected a Deferred Sampling Decision")
+			}
+		})
+
+		t.Run("Conversion", func(t *testing.T) {
+			v, _ := telemetrics.ParseB3(trace + "-" + span + "-1-" + parent)
+
+			expectations := map[telemetrics.Format]map[string]string{
+				telemetrics.FormatW3C:     {"Traceparent": "00-" + trace + "-" + span + "-01"},
+				telemetrics.FormatB3:      {"B3": trace + "-" + span + "-1-" + parent},
+				telemetrics.FormatB3Multi: {"X-B3-Traceid": trace, "X-B3-Spanid": span, "X-B3-Parentspanid": parent, "X-B3-Sampled": "1"},
+				telemetrics.FormatXRay:    {"X-Amzn-Trace-Id": "Root=1-80f198ee-56343ba864fe8b2a57d3eff7;Parent=" + span + ";Sampled=1"},
+				telemetrics.FormatDatadog: {"X-Datadog-Trace-Id": "7277407061855694839", "X-Datadog-Parent-Id": "16453819474850114513", "X-Datadog-Tags": "_dd.p.tid=80f198ee56343ba8", "X-Datadog-Sampling-Priority": "1"},
+			}
+
+			for format, headers := range expectations {
+				header := v.Header(format)
+				for k, expectation := range headers {
+					if value := header.Get(k); value != expectation {
+						t.Errorf("Format (%s), Header (%s) = %s\n    - Expectation = %s", format, k, value, expectation)
+					}
+				}
+
+				// Round-trip the emitted header(s) back into the structured model.
+				if round := telemetrics.Parse(header); round == nil || round.TraceID != trace || round.SpanID != span {
+					t.Errorf("Format (%s) Round-Trip = %+v", format, round)
+				}
+			}
+		})
+
+		t.Run("Invalid-Trace-Identifier", func(t *testing.T) {
+			for name, v := range map[string]*telemetrics.Trace{
+				"Zero-Value": {},
+				"Short":      {TraceID: "80f198ee", SpanID: span},
+				"Non-Hex":    {TraceID: "80f198ee56343ba864fe8b2a57d3effz", SpanID: span},
+			} {
+				for _, format := range []telemetrics.Format{telemetrics.FormatW3C, telemetrics.FormatB3, telemetrics.FormatB3Multi, telemetrics.FormatXRay, telemetrics.FormatDatadog} {
+					if header := v.Header(format); len(header) != 0 {
+						t.Errorf("Trace (%s), Format (%s) Header = %v\n    - Expectation = %s", name, format, header, "an empty header")
+					}
+				}
+			}
+		})
+
+		t.Run("Canonical-Format", func(t *testing.T) {
+			server := httptest.NewServer(telemetrics.New().Settings(func(o *telemetrics.Options) {
+				o.Format = telemetrics.FormatW3C
+			}).Handler(handler))
+
+			defer server.Close()
+
+			request, e := http.NewRequest(http.MethodGet, server.URL, nil)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			request.Header.Set("b3", trace+"-"+span+"-1")
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			var body map[string]*telemetrics.Valuer
+			if e := json.NewDecoder(response.Body).Decode(&body); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+			}
+
+			valuer := body["telemetry-context"]
+			if valuer == nil || valuer.Trace == nil {
+				t.Fatalf("Expected a Non-Nil Trace: %v", body)
+			}
+
+			if v, expectation := valuer.Headers.Get("Traceparent"), "00-"+trace+"-"+span+"-01"; v != expectation {
+				t.Errorf("Traceparent = %s\n    - Expectation = %s", v, expectation)
+			}
+
+			if v := valuer.Headers.Get("B3"); v != trace+"-"+span+"-1" {
+				t.Errorf("Expected the Inbound B3 Header to be Retained: %s", v)
+			}
+		})
+	})
+
+	t.Run("Profiles", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			options  func(o *telemetrics.Options)
+			captured []string
+			omitted  []string
+		}{
+			{
+				name: "Composed",
+				options: func(o *telemetrics.Options) {
+					o.Profiles = []telemetrics.Profile{telemetrics.ProfileW3C, telemetrics.ProfileAWS}
+				},
+				captured: []string{"Traceparent", "X-Amzn-Trace-Id"},
+				omitted:  []string{"Authorization", "X-B3-Traceid", "Portal", "X-Request-Id"},
+			},
+			{
+				name: "Additions-Exclusions",
+				options: func(o *telemetrics.Options) {
+					o.Profiles = []telemetrics.Profile{telemetrics.ProfileMinimal}
+					o.Additions = []string{"Portal"}
+					o.Exclusions = []string{"USER-AGENT"}
+				},
+				captured: []string{"Traceparent", "X-Request-Id", "Portal"},
+				omitted:  []string{"User-Agent", "Authorization", "X-Amzn-Trace-Id"},
+			},
+			{
+				name:     "Istio",
+				options:  func(o *telemetrics.Options) { o.Profiles = []telemetrics.Profile{telemetrics.ProfileIstio} },
+				captured: []string{"Traceparent", "X-Request-Id", "X-B3-Traceid"},
+				omitted:  []string{"Authorization", "X-Amzn-Trace-Id", "User-Agent"},
+			},
+			{
+				name:     "Invalid-Profile",
+				options:  func(o *telemetrics.Options) { o.Profiles = []telemetrics.Profile{"unknown"} },
+				captured: []string{"Traceparent", "Authorization", "Portal", "X-Amzn-Trace-Id"},
+			},
+		}
+
+		for _, matrix := range tests {
+			t.Run(matrix.name, func(t *testing.T) {
+				var headers http.Header
+
+				m := telemetrics.New().Settings(matrix.options).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					headers = telemetrics.Value(r.Context()).Headers
+				}))
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				for _, header := range []string{"Traceparent", "X-Amzn-Trace-Id", "Authorization", "X-B3-Traceid", "Portal", "X-Request-Id", "User-Agent"} {
+					request.Header.Set(header, "value")
+				}
+
+				m.ServeHTTP(httptest.NewRecorder(), request)
+
+				for _, header := range matrix.captured {
+					if headers.Get(header) == "" {
+						t.Errorf("Missing Captured %s Header", header)
+					}
+				}
+
+				for _, header := range matrix.omitted {
+					if v := headers.Get(header); v != "" {
+						t.Errorf("Unexpected Captured %s Header: %s", header, v)
+					}
+				}
+			})
+		}
+
+		if headers := telemetrics.ProfileAll.Headers(); len(headers) == 0 || telemetrics.Profile("unknown").Headers() != nil {
+			t.Errorf("Unexpected Profile Headers: %v", headers)
+		}
+	})
+
+	t.Run("Pattern", func(t *testing.T) {
+		mux := http.NewServeMux()
+
+		m := telemetrics.New().Settings(func(o *telemetrics.Options) { o.Mux = mux }).Handler(mux)
+
+		mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			v := telemetrics.Value(r.Context())
+
+			w.Header().Set("X-Path", v.Path)
+			w.Header().Set("X-Pattern", v.Pattern)
+			w.Header().Set("X-Clone-Pattern", v.Clone().Pattern)
+		})
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+		expectations := map[string]string{"X-Path": "/users/42", "X-Pattern": "GET /users/{id}", "X-Clone-Pattern": "GET /users/{id}"}
+		for header, expectation := range expectations {
+			if v := recorder.Header().Get(header); v != expectation {
+				t.Errorf("%s = %s\n    - Expectation = %s", header, v, expectation)
+			}
+		}
+	})
+
+	t.Run("Aliasing", func(t *testing.T) {
+		m := telemetrics.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := telemetrics.Value(r.Context())
+
+			// Mutations of the request's header(s), copied values, and clones mustn't be observed by the request's valuer.
+			r.Header.Set("Portal", "request")
+
+			if values := v.Values("Portal"); len(values) == 1 {
+				values[0] = "values"
+			}
+
+			clone := v.Clone()
+			clone.Headers.Set("Portal", "clone")
+			clone.Path = "/clone"
+			*clone.Trace.Sampled = false
+			clone.Trace.TraceID = "clone"
+
+			w.Header().Set("X-Portal", v.Headers.Get("Portal"))
+			w.Header().Set("X-Path", v.Path)
+			w.Header().Set("X-Sampled", fmt.Sprint(*v.Trace.Sampled))
+			w.Header().Set("X-Trace", v.Trace.TraceID)
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		request.Header.Set("Portal", "web")
+		request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, request)
+
+		expectations := map[string]string{"X-Portal": "web", "X-Path": "/orders", "X-Sampled": "true", "X-Trace": "4bf92f3577b34da6a3ce929d0e0e4736"}
+		for header, expectation := range expectations {
+			if v := recorder.Header().Get(header); v != expectation {
+				t.Errorf("%s = %s\n    - Expectation = %s", header, v, expectation)
+			}
+		}
+
+		if v := (*telemetrics.Valuer)(nil).Clone(); v != nil {
+			t.Errorf("Unexpected Non-Nil Clone: %v", v)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		valuer := &telemetrics.Valuer{
+			Headers: http.Header{"Authorization": {"Bearer secret"}, "Cookie": {"session=secret"}, "Portal": {"web"}},
+			Path:    "/orders",
+		}
+
+		serialized, e := json.Marshal(valuer)
+		if e != nil {
+			t.Fatalf("Unexpected Error While Encoding Valuer: %v", e)
+		}
+
+		if expectation := `{"headers":{"Authorization":["[REDACTED]"],"Cookie":["[REDACTED]"],"Portal":["web"]},"path":"/orders"}`; string(serialized) != expectation {
+			t.Errorf("JSON = %s\n    - Expectation = %s", serialized, expectation)
+		}
+
+		if valuer.Headers.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Valuer Headers Mutated During Encoding: %v", valuer.Headers)
+		}
+
+		sampled := true
+		valuer = &telemetrics.Valuer{Path: "/", Trace: &telemetrics.Trace{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: &sampled, Format: telemetrics.FormatW3C}}
+		if serialized, _ = json.Marshal(valuer); string(serialized) != `{"headers":null,"path":"/","trace":{"trace-id":"4bf92f3577b34da6a3ce929d0e0e4736","span-id":"00f067aa0ba902b7","sampled":true,"format":"w3c"}}` {
+			t.Errorf("Unexpected JSON: %s", serialized)
+		}
 	})
 
 	t.Run("Context", func(t *testing.T) {
@@ -242,7 +613,7 @@ func Test(t *testing.T) {
 			value := telemetrics.Value(ctx)
 
 			if value != &v {
-				t.Errorf("Unexpected Context Value Received: %v, Expected: %s", value, v)
+				t.Errorf("Unexpected Context Value Received: %v, Expected: %v", value, v)
 			}
 
 			t.Logf("Successful User-Provided Value Received = %v", value)
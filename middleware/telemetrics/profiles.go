@@ -0,0 +1,149 @@
+package telemetrics
+
+import (
+	"slices"
+)
+
+// Profile represents a named set of telemetry-related header(s). Profiles are composable - see [Options.Profiles].
+type Profile string
+
+const (
+	// ProfileMinimal represents the request identifier, user agent, and W3C Trace Context header(s).
+	ProfileMinimal Profile = "minimal"
+
+	// ProfileW3C represents the W3C Trace Context "traceparent" and "tracestate" header(s).
+	ProfileW3C Profile = "w3c"
+
+	// ProfileB3 represents the Zipkin B3 single and multi-header format header(s).
+	ProfileB3 Profile = "b3"
+
+	// ProfileAWS represents the AWS X-Ray, and related "X-Amzn-*", header(s).
+	ProfileAWS Profile = "aws"
+
+	// ProfileIstio represents the header(s) Istio's Envoy sidecars propagate for distributed tracing.
+	ProfileIstio Profile = "istio"
+
+	// ProfileAll represents every header of the default [Options.Headers], including authentication, client, and service-specific
+	// header(s).
+	ProfileAll Profile = "all"
+)
+
+// profiles maps each [Profile] to its lowercase header(s).
+var profiles = map[Profile][]string{
+	ProfileMinimal: {
+		"x-request-id",
+		"user-agent",
+		"traceparent",
+		"tracestate",
+	},
+	ProfileW3C: {
+		"traceparent",
+		"tracestate",
+	},
+	ProfileB3: {
+		"b3",
+		"x-b3-traceid",
+		"x-b3-spanid",
+		"x-b3-parentspanid",
+		"x-b3-sampled",
+		"x-b3-flags",
+	},
+	ProfileAWS: {
+		"x-amzn-trace-id",
+		"x-amzn-parentspan-id",
+		"x-amzn-sampled",
+		"x-amzn-flags",
+		"x-amzn-correlation-id",
+		"x-amzn-trace-context",
+		"x-amzn-parentspan-context",
+		"x-amzn-sampled-context",
+		"x-amzn-correlation-context",
+		"x-amzn-trace-source",
+		"x-amzn-parentspan-source",
+		"x-amzn-sampled-source",
+		"x-amzn-correlation-source",
+		"x-amzn-date",
+		"x-amzn-security-token",
+		"x-amzn-cf-id",
+		"x-amzn-cf-identity",
+	},
+	ProfileIstio: {
+		"x-request-id",
+		"traceparent",
+		"tracestate",
+		"x-cloud-trace-context",
+		"sw8",
+		"x-b3-traceid",
+		"x-b3-spanid",
+		"x-b3-parentspanid",
+		"x-b3-sampled",
+		"x-b3-flags",
+		"b3",
+		"x-ot-span-context",
+	},
+	ProfileAll: {
+		"portal",
+		"device",
+		"user",
+		"travel",
+		"traceparent",
+		"tracestate",
+		"x-cloud-trace-context",
+		"sw8",
+		"user-agent",
+		"cookie",
+		"authorization",
+		"jwt",
+		"true-client-ip",
+		"x-forwarded-for",
+		"x-real-ip",
+		"x-request-id",
+		"x-b3-traceid",
+		"x-b3-spanid",
+		"x-b3-parentspanid",
+		"x-b3-sampled",
+		"x-b3-flags",
+		"b3",
+		"x-ot-span-context",
+		"x-api-version",
+		"x-testing-authorization",
+		"x-service-name",
+		"x-service-version",
+		"x-server-name",
+		"x-amzn-trace-id",
+		"x-amzn-parentspan-id",
+		"x-amzn-sampled",
+		"x-amzn-flags",
+		"x-amzn-correlation-id",
+		"x-amzn-trace-context",
+		"x-amzn-parentspan-context",
+		"x-amzn-sampled-context",
+		"x-amzn-correlation-context",
+		"x-amzn-trace-source",
+		"x-amzn-parentspan-source",
+		"x-amzn-sampled-source",
+		"x-amzn-correlation-source",
+		"x-amzn-date",
+		"x-amzn-security-token",
+		"x-amzn-cf-id",
+		"x-amzn-cf-identity",
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-origin",
+		"x-datadog-tags",
+		"newrelic",
+	},
+}
+
+// Headers returns a copy of the profile's lowercase header(s). An unknown profile returns nil.
+func (p Profile) Headers() []string {
+	return slices.Clone(profiles[p])
+}
+
+// Valid reports whether the profile is a known [Profile].
+func (p Profile) Valid() bool {
+	_, found := profiles[p]
+
+	return found
+}
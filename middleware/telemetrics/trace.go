@@ -0,0 +1,422 @@
+package telemetrics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Format represents a trace-context header format. See [Trace.Header] for each format's emitted header(s).
+type Format string
+
+const (
+	// FormatW3C represents the W3C Trace Context "traceparent" header.
+	FormatW3C Format = "w3c"
+
+	// FormatB3 represents the Zipkin B3 single "b3" header.
+	FormatB3 Format = "b3"
+
+	// FormatB3Multi represents the Zipkin B3 multi-header format ("X-B3-TraceId", "X-B3-SpanId", etc.).
+	FormatB3Multi Format = "b3-multi"
+
+	// FormatXRay represents the AWS X-Ray "X-Amzn-Trace-Id" header.
+	FormatXRay Format = "x-ray"
+
+	// FormatDatadog represents the Datadog "X-Datadog-Trace-Id", "X-Datadog-Parent-Id", and "X-Datadog-Sampling-Priority" header(s).
+	FormatDatadog Format = "datadog"
+
+	// FormatNewRelic represents the New Relic "newrelic" distributed-tracing header. The format is parse-only: its payload requires
+	// account-specific field(s) the [Trace] model doesn't retain, and [Trace.Header] returns an empty header for it.
+	FormatNewRelic Format = "newrelic"
+)
+
+// Trace represents a structured, format-agnostic trace identifier. Identifiers are normalized to lowercase hex, with 64-bit trace
+// identifiers left-padded to 128 bits.
+type Trace struct {
+	// TraceID represents the 32-character, lowercase hex trace identifier.
+	TraceID string `json:"trace-id"`
+
+	// SpanID represents the 16-character, lowercase hex identifier of the caller's span - the parent of any span created by the server.
+	SpanID string `json:"span-id"`
+
+	// ParentSpanID represents the caller's parent span identifier, if the format conveyed one.
+	ParentSpanID string `json:"parent-span-id,omitempty"`
+
+	// Sampled represents the upstream sampling decision. A nil value represents a deferred decision.
+	Sampled *bool `json:"sampled,omitempty"`
+
+	// Debug represents the B3 debug flag, which implies a positive sampling decision.
+	Debug bool `json:"debug,omitempty"`
+
+	// Format represents the format the trace was parsed from.
+	Format Format `json:"format"`
+}
+
+// hexadecimal reports whether value is non-empty, lowercase-insensitive hex of the given length, and not entirely zeros.
+func hexadecimal(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+
+	zeros := true
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		default:
+			return false
+		}
+
+		if c != '0' {
+			zeros = false
+		}
+	}
+
+	return !(zeros)
+}
+
+// pad normalizes a 64-bit or 128-bit hex trace identifier to 128-bit lowercase hex.
+func pad(value string) (string, bool) {
+	switch {
+	case hexadecimal(value, 32):
+		return strings.ToLower(value), true
+	case hexadecimal(value, 16):
+		return "0000000000000000" + strings.ToLower(value), true
+	}
+
+	return "", false
+}
+
+// sampled returns a pointer to the provided boolean.
+func sampled(v bool) *bool {
+	return &v
+}
+
+// ParseTraceparent parses a W3C Trace Context "traceparent" header value (e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+func ParseTraceparent(value string) (*Trace, bool) {
+	partials := strings.Split(strings.TrimSpace(value), "-")
+	if len(partials) < 4 || len(partials[0]) != 2 || partials[0] == "ff" || (partials[0] == "00" && len(partials) != 4) {
+		return nil, false
+	}
+
+	if !(hexadecimal(partials[1], 32)) || !(hexadecimal(partials[2], 16)) || len(partials[3]) != 2 {
+		return nil, false
+	}
+
+	var flags byte
+	if _, e := fmt.Sscanf(partials[3], "%02x", &flags); e != nil {
+		return nil, false
+	}
+
+	return &Trace{
+		TraceID: strings.ToLower(partials[1]),
+		SpanID:  strings.ToLower(partials[2]),
+		Sampled: sampled(flags&0x01 == 0x01),
+		Format:  FormatW3C,
+	}, true
+}
+
+// ParseB3 parses a Zipkin B3 single header value ("{TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}", where the last two fields are optional).
+// Sampling-only values (e.g. "0") don't convey identifiers, and aren't considered valid traces.
+func ParseB3(value string) (*Trace, bool) {
+	partials := strings.Split(strings.TrimSpace(value), "-")
+	if len(partials) < 2 || len(partials) > 4 {
+		return nil, false
+	}
+
+	trace := &Trace{Format: FormatB3}
+
+	var ok bool
+	if trace.TraceID, ok = pad(partials[0]); !(ok) || !(hexadecimal(partials[1], 16)) {
+		return nil, false
+	}
+
+	trace.SpanID = strings.ToLower(partials[1])
+
+	if len(partials) > 2 {
+		switch partials[2] {
+		case "1":
+			trace.Sampled = sampled(true)
+		case "0":
+			trace.Sampled = sampled(false)
+		case "d":
+			trace.Sampled = sampled(true)
+			trace.Debug = true
+		default:
+			return nil, false
+		}
+	}
+
+	if len(partials) > 3 {
+		if !(hexadecimal(partials[3], 16)) {
+			return nil, false
+		}
+
+		trace.ParentSpanID = strings.ToLower(partials[3])
+	}
+
+	return trace, true
+}
+
+// ParseB3Multi parses the Zipkin B3 multi-header format.
+func ParseB3Multi(header http.Header) (*Trace, bool) {
+	trace := &Trace{Format: FormatB3Multi}
+
+	var ok bool
+	if trace.TraceID, ok = pad(header.Get("X-B3-TraceId")); !(ok) {
+		return nil, false
+	}
+
+	if span := header.Get("X-B3-SpanId"); hexadecimal(span, 16) {
+		trace.SpanID = strings.ToLower(span)
+	} else {
+		return nil, false
+	}
+
+	if parent := header.Get("X-B3-ParentSpanId"); hexadecimal(parent, 16) {
+		trace.ParentSpanID = strings.ToLower(parent)
+	}
+
+	switch strings.ToLower(header.Get("X-B3-Sampled")) {
+	case "1", "true":
+		trace.Sampled = sampled(true)
+	case "0", "false":
+		trace.Sampled = sampled(false)
+	}
+
+	if header.Get("X-B3-Flags") == "1" {
+		trace.Debug = true
+		trace.Sampled = sampled(true)
+	}
+
+	return trace, true
+}
+
+// ParseXRay parses an AWS X-Ray "X-Amzn-Trace-Id" header value (e.g. "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1").
+func ParseXRay(value string) (*Trace, bool) {
+	trace := &Trace{Format: FormatXRay}
+
+	for _, field := range strings.Split(value, ";") {
+		k, v, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !(found) {
+			continue
+		}
+
+		switch strings.ToLower(k) {
+		case "root":
+			partials := strings.Split(v, "-")
+			if len(partials) != 3 || partials[0] != "1" || len(partials[1]) != 8 || !(hexadecimal(partials[1]+partials[2], 32)) {
+				return nil, false
+			}
+
+			trace.TraceID = strings.ToLower(partials[1] + partials[2])
+		case "parent":
+			if !(hexadecimal(v, 16)) {
+				return nil, false
+			}
+
+			trace.SpanID = strings.ToLower(v)
+		case "sampled":
+			switch v {
+			case "1":
+				trace.Sampled = sampled(true)
+			case "0":
+				trace.Sampled = sampled(false)
+			}
+		}
+	}
+
+	if trace.TraceID == "" || trace.SpanID == "" {
+		return nil, false
+	}
+
+	return trace, true
+}
+
+// ParseDatadog parses the Datadog header(s), whose identifiers are unsigned, 64-bit decimal integers. The upper 64 bits of a 128-bit
+// trace identifier are derived from the "_dd.p.tid" tag of the "X-Datadog-Tags" header, if present.
+func ParseDatadog(header http.Header) (*Trace, bool) {
+	identifier, e := strconv.ParseUint(header.Get("X-Datadog-Trace-Id"), 10, 64)
+	if e != nil || identifier == 0 {
+		return nil, false
+	}
+
+	span, e := strconv.ParseUint(header.Get("X-Datadog-Parent-Id"), 10, 64)
+	if e != nil || span == 0 {
+		return nil, false
+	}
+
+	upper := "0000000000000000"
+	for _, tag := range strings.Split(header.Get("X-Datadog-Tags"), ",") {
+		if k, v, found := strings.Cut(strings.TrimSpace(tag), "="); found && k == "_dd.p.tid" && hexadecimal(v, 16) {
+			upper = strings.ToLower(v)
+		}
+	}
+
+	trace := &Trace{
+		TraceID: fmt.Sprintf("%s%016x", upper, identifier),
+		SpanID:  fmt.Sprintf("%016x", span),
+		Format:  FormatDatadog,
+	}
+
+	// Priorities of -1 (user-reject) and 0 (auto-reject) drop the trace; 1 (auto-keep) and 2 (user-keep) sample it.
+	if v, e := strconv.Atoi(header.Get("X-Datadog-Sampling-Priority")); e == nil {
+		trace.Sampled = sampled(v > 0)
+	}
+
+	return trace, true
+}
+
+// ParseNewRelic parses a New Relic "newrelic" header value - a base64-encoded JSON payload whose "d" object conveys the trace
+// identifier ("tr"), span identifier ("id"), and sampling decision ("sa").
+func ParseNewRelic(value string) (*Trace, bool) {
+	decoded, e := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if e != nil {
+		return nil, false
+	}
+
+	var payload struct {
+		Data struct {
+			Trace   string `json:"tr"`
+			Span    string `json:"id"`
+			Sampled *bool  `json:"sa"`
+		} `json:"d"`
+	}
+
+	if e := json.Unmarshal(decoded, &payload); e != nil {
+		return nil, false
+	}
+
+	trace := &Trace{Sampled: payload.Data.Sampled, Format: FormatNewRelic}
+
+	var ok bool
+	if trace.TraceID, ok = pad(payload.Data.Trace); !(ok) || !(hexadecimal(payload.Data.Span, 16)) {
+		return nil, false
+	}
+
+	trace.SpanID = strings.ToLower(payload.Data.Span)
+
+	return trace, true
+}
+
+// Parse derives a [Trace] from the provided header(s), evaluating formats in the following order of precedence: W3C "traceparent",
+// B3 single "b3", B3 multi-header, AWS X-Ray, Datadog, and New Relic. A nil value is returned if no format could be parsed.
+func Parse(header http.Header) *Trace {
+	if v := header.Get("Traceparent"); v != "" {
+		if trace, ok := ParseTraceparent(v); ok {
+			return trace
+		}
+	}
+
+	if v := header.Get("B3"); v != "" {
+		if trace, ok := ParseB3(v); ok {
+			return trace
+		}
+	}
+
+	if trace, ok := ParseB3Multi(header); ok {
+		return trace
+	}
+
+	if v := header.Get("X-Amzn-Trace-Id"); v != "" {
+		if trace, ok := ParseXRay(v); ok {
+			return trace
+		}
+	}
+
+	if trace, ok := ParseDatadog(header); ok {
+		return trace
+	}
+
+	if v := header.Get("Newrelic"); v != "" {
+		if trace, ok := ParseNewRelic(v); ok {
+			return trace
+		}
+	}
+
+	return nil
+}
+
+// Header returns the trace encoded in the given format, enabling conversion between formats for heterogeneous meshes. An empty
+// header is returned for unknown formats, and for traces without a valid [Trace.TraceID] (e.g. a zero-value [Trace]).
+func (t *Trace) Header(format Format) http.Header {
+	header := http.Header{}
+
+	if !(hexadecimal(t.TraceID, 32)) {
+		return header
+	}
+
+	switch format {
+	case FormatW3C:
+		flags := "00"
+		if t.Sampled != nil && *t.Sampled {
+			flags = "01"
+		}
+
+		header.Set("Traceparent", fmt.Sprintf("00-%s-%s-%s", t.TraceID, t.SpanID, flags))
+	case FormatB3:
+		value := t.TraceID + "-" + t.SpanID
+		switch {
+		case t.Debug:
+			value += "-d"
+		case t.Sampled != nil && *t.Sampled:
+			value += "-1"
+		case t.Sampled != nil:
+			value += "-0"
+		}
+
+		if t.ParentSpanID != "" && (t.Debug || t.Sampled != nil) {
+			value += "-" + t.ParentSpanID
+		}
+
+		header.Set("B3", value)
+	case FormatB3Multi:
+		header.Set("X-B3-TraceId", t.TraceID)
+		header.Set("X-B3-SpanId", t.SpanID)
+
+		if t.ParentSpanID != "" {
+			header.Set("X-B3-ParentSpanId", t.ParentSpanID)
+		}
+
+		switch {
+		case t.Debug:
+			header.Set("X-B3-Flags", "1")
+		case t.Sampled != nil && *t.Sampled:
+			header.Set("X-B3-Sampled", "1")
+		case t.Sampled != nil:
+			header.Set("X-B3-Sampled", "0")
+		}
+	case FormatXRay:
+		value := fmt.Sprintf("Root=1-%s-%s;Parent=%s", t.TraceID[:8], t.TraceID[8:], t.SpanID)
+		if t.Sampled != nil {
+			if *t.Sampled {
+				value += ";Sampled=1"
+			} else {
+				value += ";Sampled=0"
+			}
+		}
+
+		header.Set("X-Amzn-Trace-Id", value)
+	case FormatDatadog:
+		identifier, _ := strconv.ParseUint(t.TraceID[16:], 16, 64)
+		span, _ := strconv.ParseUint(t.SpanID, 16, 64)
+
+		header.Set("X-Datadog-Trace-Id", strconv.FormatUint(identifier, 10))
+		header.Set("X-Datadog-Parent-Id", strconv.FormatUint(span, 10))
+
+		if upper := t.TraceID[:16]; upper != "0000000000000000" {
+			header.Set("X-Datadog-Tags", "_dd.p.tid="+upper)
+		}
+
+		switch {
+		case t.Sampled != nil && *t.Sampled:
+			header.Set("X-Datadog-Sampling-Priority", "1")
+		case t.Sampled != nil:
+			header.Set("X-Datadog-Sampling-Priority", "0")
+		}
+	}
+
+	return header
+}
@@ -0,0 +1,13 @@
+// Package throttle provides concurrency limiting, and load shedding, middleware.
+//
+// The number of in-flight requests is capped globally, or per key (e.g. per client address, or API key - see [Options.Key]).
+// Requests exceeding the cap wait in a bounded queue for up to [Options.Wait]; once the queue is full, or a queued request's wait
+// budget is exhausted, the request is shed with a [net/http.StatusServiceUnavailable] response and a Retry-After header.
+//
+// Unlike the ratelimit middleware, which bounds request rates, the [Throttle] middleware bounds concurrency - protecting slow
+// handlers, and their dependencies, from overload regardless of the request rate. Live counts are available through
+// [Throttle.Counts] (e.g. for health endpoints), and [Throttle.Pressure] can signal overload to other components, such as the
+// keepalive middleware.
+//
+// Limiter state is held in-process; deployments with multiple replicas limit each replica independently.
+package throttle
@@ -0,0 +1,60 @@
+package throttle_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/throttle"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	instance := throttle.New()
+
+	middleware.Add(instance.Settings(func(o *throttle.Options) {
+		o.Limit = 50
+		o.Queue = 100
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		counts := instance.Counts()
+
+		fmt.Printf("In-Flight: %d, Queued: %d\n", counts.InFlight, counts.Queued)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/health", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Status: %d\n", response.StatusCode)
+
+	// Output:
+	// In-Flight: 1, Queued: 0
+	// Status: 200
+}
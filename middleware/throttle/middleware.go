@@ -0,0 +1,314 @@
+package throttle
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "throttle"
+
+// Valuer is the context return type relating to the [Throttle] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Key represents the request's concurrency limiting key. An empty key represents the global limit.
+	Key string `json:"key"`
+
+	// Waited represents the duration the request was queued for, prior to its admission.
+	Waited time.Duration `json:"waited"`
+}
+
+// Counts represents a snapshot of the [Throttle] middleware's live, and cumulative, counts.
+type Counts struct {
+	// InFlight represents the number of requests currently being served.
+	InFlight int `json:"in-flight"`
+
+	// Queued represents the number of requests currently waiting for admission.
+	Queued int `json:"queued"`
+
+	// Keys represents the number of keys with in-flight, or queued, requests.
+	Keys int `json:"keys"`
+
+	// Admitted represents the cumulative number of admitted requests.
+	Admitted uint64 `json:"admitted"`
+
+	// Shed represents the cumulative number of shed requests.
+	Shed uint64 `json:"shed"`
+}
+
+// Options represents the configuration settings for the [Throttle] middleware component.
+type Options struct {
+	// Limit represents the maximum number of in-flight requests, per key. Defaults to 100.
+	Limit int
+
+	// Queue represents the maximum number of requests waiting for admission, per key. A value of zero disables queueing - requests
+	// exceeding [Options.Limit] are shed immediately. Defaults to 100.
+	Queue int
+
+	// Wait represents the maximum duration a request waits in the queue before it's shed. Defaults to 5 seconds.
+	Wait time.Duration
+
+	// Key optionally returns the request's concurrency limiting key (e.g. the client's address, or API key), such that each key is
+	// limited independently. Defaults to nil - all requests share a single, global limit.
+	Key func(r *http.Request) string
+
+	// Retry represents the Retry-After header value of shed requests. Defaults to one second.
+	Retry time.Duration
+
+	// Message represents the shed response's body. Defaults to [http.StatusText] of [http.StatusServiceUnavailable].
+	Message string
+}
+
+// gate represents a key's admission state.
+type gate struct {
+	slots      chan struct{}
+	queued     int
+	references int
+}
+
+// Throttle represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Throttle struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex    sync.Mutex
+	gates    map[string]*gate
+	admitted uint64
+	shed     uint64
+}
+
+// Settings applies configuration functions to modify the [Throttle] middleware's [Options] and returns the updated middleware instance.
+func (x *Throttle) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Limit:   100,
+			Queue:   100,
+			Wait:    time.Second * 5,
+			Key:     nil,
+			Retry:   time.Second,
+			Message: http.StatusText(http.StatusServiceUnavailable),
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Throttle Limit Specified - Using Default Limit")
+
+		x.options.Limit = 100
+	}
+
+	if x.options.Queue < 0 {
+		slog.Warn("Invalid Throttle Queue Specified - Using Default Queue")
+
+		x.options.Queue = 100
+	}
+
+	if x.options.Wait <= 0 {
+		slog.Warn("Invalid Throttle Wait Specified - Using Default Wait")
+
+		x.options.Wait = time.Second * 5
+	}
+
+	if x.options.Retry <= 0 {
+		slog.Warn("Invalid Throttle Retry Specified - Using Default Retry")
+
+		x.options.Retry = time.Second
+	}
+
+	if x.options.Message == "" {
+		x.options.Message = http.StatusText(http.StatusServiceUnavailable)
+	}
+
+	return x
+}
+
+// reference returns the key's gate, creating it if necessary. Each reference must be paired with a call to [Throttle.dereference].
+func (x *Throttle) reference(k string) *gate {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if x.gates == nil {
+		x.gates = make(map[string]*gate)
+	}
+
+	g, ok := x.gates[k]
+	if !(ok) {
+		g = &gate{slots: make(chan struct{}, x.options.Limit)}
+		x.gates[k] = g
+	}
+
+	g.references++
+
+	return g
+}
+
+// dereference releases a reference to the key's gate, discarding idle gates.
+func (x *Throttle) dereference(k string, g *gate) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if g.references--; g.references == 0 {
+		delete(x.gates, k)
+	}
+}
+
+// admit waits for one of the gate's slots, returning false if the request is shed - or its context is canceled - first.
+func (x *Throttle) admit(ctx context.Context, g *gate) bool {
+	select {
+	case g.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	x.mutex.Lock()
+	if g.queued >= x.options.Queue {
+		x.mutex.Unlock()
+
+		return false
+	}
+
+	g.queued++
+	x.mutex.Unlock()
+
+	defer func() {
+		x.mutex.Lock()
+		g.queued--
+		x.mutex.Unlock()
+	}()
+
+	timer := time.NewTimer(x.options.Wait)
+
+	defer timer.Stop()
+
+	select {
+	case g.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Counts returns a snapshot of the middleware's live, and cumulative, counts - e.g. for a health, or metrics, endpoint.
+func (x *Throttle) Counts() Counts {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	counts := Counts{Keys: len(x.gates), Admitted: x.admitted, Shed: x.shed}
+	for _, g := range x.gates {
+		counts.InFlight += len(g.slots)
+		counts.Queued += g.queued
+	}
+
+	return counts
+}
+
+// Pressure reports whether any request is currently queued - i.e. whether at least one key is at its concurrency limit. It's
+// suitable as the keepalive middleware's pressure signal.
+func (x *Throttle) Pressure() bool {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	for _, g := range x.gates {
+		if g.queued > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handler admits requests while their key has fewer than [Options.Limit] in-flight requests, queueing excess requests for up to
+// [Options.Wait]. Requests that can't be queued, or exhaust their wait budget, receive a [http.StatusServiceUnavailable] response
+// and a Retry-After header. Requests whose context is canceled while queued are abandoned without a response.
+func (x *Throttle) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var k string
+		if x.options.Key != nil {
+			k = x.options.Key(r)
+		}
+
+		g := x.reference(k)
+
+		defer x.dereference(k, g)
+
+		start := time.Now()
+
+		if !(x.admit(ctx, g)) {
+			if ctx.Err() != nil {
+				slog.DebugContext(ctx, "Queued Request Canceled", slog.String("key", k))
+
+				return
+			}
+
+			x.mutex.Lock()
+			x.shed++
+			x.mutex.Unlock()
+
+			slog.DebugContext(ctx, "Request Shed", slog.String("key", k), slog.Duration("waited", time.Since(start)))
+
+			reject.Record(ctx, reject.Reason{Subsystem: "throttle", Code: "load-shed", Status: http.StatusServiceUnavailable})
+
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((x.options.Retry+time.Second-1)/time.Second), 10))
+
+			http.Error(w, x.options.Message, http.StatusServiceUnavailable)
+
+			return
+		}
+
+		defer func() { <-g.slots }()
+
+		x.mutex.Lock()
+		x.admitted++
+		x.mutex.Unlock()
+
+		valuer := &Valuer{Key: k, Waited: time.Since(start)}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Throttle] middleware, implementing [middleware.Configurable]. If [Throttle.Settings] isn't called,
+// then the [Throttle.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+//
+// Callers should retain the returned instance to call [Throttle.Counts], or [Throttle.Pressure].
+func New() *Throttle {
+	return new(Throttle)
+}
+
+// Value retrieves the request's admission [Valuer] from the provided context. If a nil value is returned, it can be assumed that
+// the [Throttle] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Throttle] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Throttle)(nil)
@@ -0,0 +1,174 @@
+package throttle_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/poly-gun/go-middleware/middleware/throttle"
+)
+
+// eventually polls the condition until it's satisfied, or a second elapses.
+func eventually(condition func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if condition() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func Test(t *testing.T) {
+	t.Run("Queue-And-Shed", func(t *testing.T) {
+		release := make(chan struct{})
+
+		instance := throttle.New()
+
+		handler := instance.Settings(func(o *throttle.Options) {
+			o.Limit = 1
+			o.Queue = 1
+			o.Wait = time.Second * 5
+			o.Retry = time.Millisecond * 1500
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		recorders := make(chan *httptest.ResponseRecorder, 2)
+		for range 2 {
+			go func() {
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				recorders <- recorder
+			}()
+		}
+
+		if !(eventually(func() bool { counts := instance.Counts(); return counts.InFlight == 1 && counts.Queued == 1 })) {
+			t.Fatalf("Counts = %+v\n    - Expectation = 1 In-Flight, 1 Queued", instance.Counts())
+		}
+
+		if !(instance.Pressure()) {
+			t.Errorf("Pressure = false\n    - Expectation = true")
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+
+		if v := recorder.Header().Get("Retry-After"); v != "2" {
+			t.Errorf("Retry-After = %s\n    - Expectation = %s", v, "2")
+		}
+
+		close(release)
+
+		for range 2 {
+			if v := (<-recorders).Code; v != http.StatusNoContent {
+				t.Errorf("Status = %d\n    - Expectation = %d", v, http.StatusNoContent)
+			}
+		}
+
+		if counts := instance.Counts(); counts != (throttle.Counts{Admitted: 2, Shed: 1}) {
+			t.Errorf("Counts = %+v\n    - Expectation = %+v", counts, throttle.Counts{Admitted: 2, Shed: 1})
+		}
+	})
+
+	t.Run("Wait-Exhausted", func(t *testing.T) {
+		release := make(chan struct{})
+
+		defer close(release)
+
+		instance := throttle.New()
+
+		handler := instance.Settings(func(o *throttle.Options) {
+			o.Limit = 1
+			o.Wait = time.Millisecond * 10
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+
+		go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !(eventually(func() bool { return instance.Counts().InFlight == 1 })) {
+			t.Fatalf("Counts = %+v\n    - Expectation = 1 In-Flight", instance.Counts())
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("Per-Key", func(t *testing.T) {
+		release := make(chan struct{})
+
+		defer close(release)
+
+		instance := throttle.New()
+
+		handler := instance.Settings(func(o *throttle.Options) {
+			o.Limit = 1
+			o.Queue = 0
+			o.Key = func(r *http.Request) string { return r.Header.Get("X-API-Key") }
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Block") != "" {
+				<-release
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		blocking := httptest.NewRequest(http.MethodGet, "/", nil)
+		blocking.Header.Set("X-API-Key", "acme")
+		blocking.Header.Set("X-Block", "true")
+
+		go handler.ServeHTTP(httptest.NewRecorder(), blocking)
+
+		if !(eventually(func() bool { return instance.Counts().InFlight == 1 })) {
+			t.Fatalf("Counts = %+v\n    - Expectation = 1 In-Flight", instance.Counts())
+		}
+
+		tests := []struct {
+			key    string
+			status int
+		}{
+			{key: "acme", status: http.StatusServiceUnavailable},
+			{key: "initech", status: http.StatusNoContent},
+		}
+
+		for _, test := range tests {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("X-API-Key", test.key)
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Status (%s) = %d\n    - Expectation = %d", test.key, recorder.Code, test.status)
+			}
+		}
+
+		if instance.Pressure() {
+			t.Errorf("Pressure = true\n    - Expectation = false")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := throttle.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -2,4 +2,7 @@
 // timeout limits on processing HTTP requests in a web server.
 // It allows developers to configure request timeouts to ensure
 // that requests do not run indefinitely, improving server reliability.
+//
+// Similar to [http.TimeoutHandler], responses are buffered until the handler returns, such that a timed-out request receives
+// exactly one [http.StatusGatewayTimeout] response, and a slow handler's late writes are discarded rather than racing it.
 package timeout
@@ -1,10 +1,12 @@
 package timeout
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/poly-gun/go-middleware"
@@ -18,6 +20,10 @@ const key keyer = "timeout"
 
 const defaultTimeoutDuration = time.Second * 30
 
+// ErrWriteAfterReturn is returned for writes to a response after the [Timeout] middleware wrote it - e.g. by a goroutine
+// outliving its handler.
+var ErrWriteAfterReturn = errors.New("timeout: write after response was written")
+
 // Options defines configurable settings for timeout behaviors, including response header customization and operation timeout durations.
 type Options struct {
 	// Timeout represents the duration to wait before considering an operation as timed out. If unspecified, or a negative value,
@@ -27,6 +33,19 @@ type Options struct {
 	// Header represents an optional response-header key. Setting the [Options.Header] to an empty string will prevent
 	// the response from including the Header key-value. By default, the Header is set to "X-Timeout".
 	Header string
+
+	// Message represents the timed-out response's plain-text body. Defaults to [http.StatusText] of [http.StatusGatewayTimeout].
+	Message string
+
+	// Concurrent runs the next handler in its own goroutine, such that the timed-out response is written as soon as the deadline
+	// elapses - irrespective of whether the handler observes its context's cancellation. A panicking handler's value is re-raised
+	// in the serving goroutine. If false, the handler runs in the serving goroutine, and the timed-out response is written once it
+	// returns. Defaults to true.
+	Concurrent bool
+
+	// Override optionally returns a per-request timeout superseding [Options.Timeout] - e.g. the override middleware's Timeout
+	// function. A non-positive return value retains [Options.Timeout]. Defaults to nil.
+	Override func(r *http.Request) time.Duration
 }
 
 // Timeout represents a middleware component that applies configurable timeout settings to HTTP requests. It
@@ -41,8 +60,10 @@ type Timeout struct {
 func (t *Timeout) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
 	if t.options == nil {
 		t.options = &Options{
-			Header:  "X-Timeout",
-			Timeout: defaultTimeoutDuration,
+			Header:     "X-Timeout",
+			Timeout:    defaultTimeoutDuration,
+			Message:    http.StatusText(http.StatusGatewayTimeout),
+			Concurrent: true,
 		}
 	}
 
@@ -59,37 +80,195 @@ func (t *Timeout) Settings(configuration ...func(o *Options)) middleware.Configu
 		t.options.Timeout = defaultTimeoutDuration
 	}
 
+	if t.options.Message == "" {
+		t.options.Message = http.StatusText(http.StatusGatewayTimeout)
+	}
+
 	return t
 }
 
-// Handler applies timeout middleware to the provided HTTP handler, enforcing a request timeout and adding optional timeout metadata to the response.
+// writer buffers the next handler's response, such that exactly one response - either the handler's, or the timed-out
+// response - is written to the underlying [http.ResponseWriter]. Writes after the response was written are discarded.
+//
+// The writer intentionally doesn't implement Unwrap, nor [http.Flusher]: flushing a partial response would defeat the buffer.
+type writer struct {
+	mutex sync.Mutex
+
+	w      http.ResponseWriter
+	header http.Header
+	buffer bytes.Buffer
+	status int
+
+	timedout bool
+	closed   bool
+}
+
+func (w *writer) Header() http.Header {
+	return w.header
+}
+
+func (w *writer) WriteHeader(status int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed || w.status != 0 || status < 200 {
+		return
+	}
+
+	w.status = status
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.timedout {
+		return 0, http.ErrHandlerTimeout
+	} else if w.closed {
+		return 0, ErrWriteAfterReturn
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.buffer.Write(p)
+}
+
+// flush writes the buffered response, unless the response was already written.
+func (w *writer) flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	w.closed = true
+
+	header := w.w.Header()
+	clear(header)
+	for k, v := range w.header {
+		header[k] = v
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.w.WriteHeader(w.status)
+	w.w.Write(w.buffer.Bytes())
+}
+
+// timeout discards the buffered response and writes the timed-out response, unless the response was already written.
+func (w *writer) timeout(message string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	w.closed = true
+	w.timedout = true
+	w.buffer.Reset()
+
+	http.Error(w.w, message, http.StatusGatewayTimeout)
+}
+
+// abandon discards the buffered response without writing one (e.g. the client disconnected, or the handler panicked).
+func (w *writer) abandon() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	w.timedout = true
+	w.buffer.Reset()
+}
+
+// Handler applies timeout middleware to the provided HTTP handler, enforcing a request timeout and adding optional timeout metadata
+// to the response. The next handler's response is buffered: if the deadline elapses first, the buffer is discarded, and a
+// [http.StatusGatewayTimeout] response with the [Options.Message] body is written instead. Exactly one response is written; the
+// handler's subsequent writes fail with [http.ErrHandlerTimeout]. Streaming responses (e.g. via [http.ResponseController.Flush])
+// aren't supported.
 func (t *Timeout) Handler(next http.Handler) http.Handler {
 	t.Settings() // Ensure the options field isn't nil.
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		duration := t.options.Timeout
+		if t.options.Override != nil {
+			if v := t.options.Override(r); v > 0 {
+				duration = v
+			}
+		}
+
 		// Update the request context with the applicable key-value pair(s).
-		ctx = context.WithValue(ctx, key, t.options.Timeout)
+		ctx = context.WithValue(ctx, key, duration)
 
 		// Set the response headers according to the specification.
 		if t.options.Header != "" {
-			value := t.options.Timeout.String()
+			value := duration.String()
 
 			w.Header().Set(http.CanonicalHeaderKey(t.options.Header), value)
 		}
 
-		ctx, cancel := context.WithTimeout(ctx, t.options.Timeout)
-		defer func() {
-			cancel()
-			e := ctx.Err()
-			if errors.Is(e, context.DeadlineExceeded) {
-				w.WriteHeader(http.StatusGatewayTimeout)
+		ctx, cancel := context.WithTimeout(ctx, duration)
+		defer cancel()
+
+		wrapper := &writer{w: w, header: w.Header().Clone()}
+
+		r = r.WithContext(ctx)
+
+		if !(t.options.Concurrent) {
+			next.ServeHTTP(wrapper, r)
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				wrapper.timeout(t.options.Message)
+
 				return
 			}
+
+			wrapper.flush()
+
+			return
+		}
+
+		done := make(chan struct{})
+		panics := make(chan interface{}, 1)
+
+		go func() {
+			defer func() {
+				if value := recover(); value != nil {
+					panics <- value
+
+					return
+				}
+
+				close(done)
+			}()
+
+			next.ServeHTTP(wrapper, r)
 		}()
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+		select {
+		case value := <-panics:
+			wrapper.abandon()
+
+			panic(value)
+		case <-done:
+			wrapper.flush()
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				wrapper.timeout(t.options.Message)
+
+				return
+			}
+
+			// The client disconnected; there's no one to respond to.
+			wrapper.abandon()
+		}
 	})
 }
 
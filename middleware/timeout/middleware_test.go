@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -148,6 +150,35 @@ func Test(t *testing.T) {
 				}
 			}),
 		},
+		{
+			name:       "Late-Write-Discarded",
+			middleware: timeout.New().Settings(func(options *timeout.Options) { options.Timeout = time.Millisecond * 100 }).Handler,
+			status:     504,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The handler ignores its context's cancellation.
+				time.Sleep(time.Millisecond * 300)
+
+				w.WriteHeader(http.StatusOK)
+			}),
+		},
+		{
+			name:       "Synchronous-Late-Write-Discarded",
+			middleware: timeout.New().Settings(func(options *timeout.Options) { options.Timeout = time.Millisecond * 100; options.Concurrent = false }).Handler,
+			status:     504,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Millisecond * 300)
+
+				w.WriteHeader(http.StatusOK)
+			}),
+		},
+		{
+			name:       "Synchronous-Successful-Response",
+			middleware: timeout.New().Settings(func(options *timeout.Options) { options.Concurrent = false }).Handler,
+			status:     201,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			}),
+		},
 	}
 
 	for _, matrix := range tests {
@@ -180,6 +211,143 @@ func Test(t *testing.T) {
 		})
 	}
 
+	t.Run("Buffered-Response", func(t *testing.T) {
+		t.Parallel()
+
+		errs := make(chan error, 1)
+		handler := timeout.New().Settings(func(options *timeout.Options) {
+			options.Timeout = time.Millisecond * 100
+			options.Message = "Deadline Exceeded"
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Handler", "true")
+			w.Write([]byte("partial"))
+
+			<-r.Context().Done()
+
+			time.Sleep(time.Millisecond * 50)
+
+			_, e := w.Write([]byte("late"))
+
+			errs <- e
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if e := <-errs; !(errors.Is(e, http.ErrHandlerTimeout)) {
+			t.Errorf("Late Write Error = %v\n    - Expectation = %v", e, http.ErrHandlerTimeout)
+		}
+
+		if recorder.Code != http.StatusGatewayTimeout {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusGatewayTimeout)
+		}
+
+		if body := strings.TrimSpace(recorder.Body.String()); body != "Deadline Exceeded" {
+			t.Errorf("Body = %s\n    - Expectation = %s", body, "Deadline Exceeded")
+		}
+
+		if v := recorder.Header().Get("X-Handler"); v != "" {
+			t.Errorf("Unexpected Handler Header in Timed-Out Response: %s", v)
+		}
+
+		if v := recorder.Header().Get("X-Timeout"); v != "100ms" {
+			t.Errorf("X-Timeout = %s\n    - Expectation = %s", v, "100ms")
+		}
+	})
+
+	t.Run("Handler-Response", func(t *testing.T) {
+		t.Parallel()
+
+		handler := timeout.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Handler", "true")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte("accepted"))
+		}))
+
+		recorder := httptest.NewRecorder()
+		recorder.Header().Set("X-Upstream", "true")
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusAccepted || recorder.Body.String() != "accepted" {
+			t.Errorf("Response = %d %s\n    - Expectation = %d %s", recorder.Code, recorder.Body.String(), http.StatusAccepted, "accepted")
+		}
+
+		for _, header := range []string{"X-Handler", "X-Upstream", "X-Timeout"} {
+			if recorder.Header().Get(header) == "" {
+				t.Errorf("Missing %s Response Header", header)
+			}
+		}
+	})
+
+	t.Run("Panic-Propagation", func(t *testing.T) {
+		t.Parallel()
+
+		handler := timeout.New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+
+			panic("handler failure")
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		func() {
+			defer func() {
+				if value := recover(); value != "handler failure" {
+					t.Errorf("Recovered = %v\n    - Expectation = %v", value, "handler failure")
+				}
+			}()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+
+		if recorder.Flushed || recorder.Body.Len() != 0 {
+			t.Errorf("Unexpected Response Written by a Panicking Handler")
+		}
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		t.Parallel()
+
+		handler := timeout.New().Settings(func(o *timeout.Options) {
+			o.Timeout = time.Millisecond * 10
+			o.Override = func(r *http.Request) time.Duration {
+				if r.Header.Get("X-Debug") != "" {
+					return time.Second
+				}
+
+				return 0
+			}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(timeout.Value(r.Context()).String()))
+		}))
+
+		for _, debug := range []bool{false, true} {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if debug {
+				request.Header.Set("X-Debug", "true")
+			}
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			expectation := "10ms"
+			if debug {
+				expectation = "1s"
+			}
+
+			if v := recorder.Body.String(); v != expectation {
+				t.Errorf("Timeout = %s\n    - Expectation = %s", v, expectation)
+			}
+
+			if v := recorder.Header().Get("X-Timeout"); v != expectation {
+				t.Errorf("X-Timeout = %s\n    - Expectation = %s", v, expectation)
+			}
+		}
+	})
+
 	t.Run("Context", func(t *testing.T) {
 		t.Run("Default", func(t *testing.T) {
 			t.Parallel()
@@ -0,0 +1,63 @@
+package translate
+
+import (
+	"container/list"
+	"sync"
+)
+
+// entry represents a cached translation.
+type entry struct {
+	key   [3]string
+	value []byte
+}
+
+// cache represents a bounded, least-recently-used cache of translations, keyed by route, locale, and content hash.
+type cache struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[[3]string]*list.Element
+}
+
+// get returns the cached translation, marking it as recently used.
+func (c *cache) get(key [3]string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !(ok) {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*entry).value, true
+}
+
+// put stores the translation, evicting the least-recently-used translation when at capacity.
+func (c *cache) put(key [3]string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.order = list.New()
+		c.entries = make(map[[3]string]*list.Element)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+
+		c.order.Remove(oldest)
+
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
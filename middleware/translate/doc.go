@@ -0,0 +1,7 @@
+// Package translate provides middleware passing opted-in routes' textual responses through a pluggable translation (or
+// transformation) [Provider], based on the request's negotiated locale.
+//
+// Eligible responses - successful, unencoded, textual responses within the buffer limit - are buffered, then translated. Results
+// are cached by route, locale, and the response body's SHA-256 hash, so repeated responses only reach the provider once. Requests
+// negotiating the source locale, and provider failures, result in the untranslated response.
+package translate
@@ -0,0 +1,72 @@
+package translate_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/translate"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(translate.New().Settings(func(o *translate.Options) {
+		o.Provider = translate.ProviderFunc(func(ctx context.Context, locale, content string, body []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(body), "Hello", "Bonjour")), nil
+		})
+
+		o.Match = func(r *http.Request) bool {
+			return strings.HasPrefix(r.URL.Path, "/greeting")
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /greeting", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Hello, World!"))
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	request, e := http.NewRequest(http.MethodGet, server.URL+"/greeting", nil)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Accept-Language", "fr-FR,fr;q=0.9,en;q=0.8")
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	defer response.Body.Close()
+
+	body, e := io.ReadAll(response.Body)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while reading response body: %w", e)
+
+		panic(e)
+	}
+
+	fmt.Printf("%s (%s)\n", body, response.Header.Get("Content-Language"))
+
+	// Output:
+	// Bonjour, World! (fr-FR)
+}
@@ -0,0 +1,374 @@
+package translate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "translate"
+
+// Provider represents a pluggable translation (or transformation) provider.
+type Provider interface {
+	// Translate returns the body translated into the locale. The content type identifies the body's format (e.g. "text/html"),
+	// allowing providers to preserve markup.
+	Translate(ctx context.Context, locale, content string, body []byte) ([]byte, error)
+}
+
+// ProviderFunc is an adapter allowing the use of ordinary functions as a [Provider].
+type ProviderFunc func(ctx context.Context, locale, content string, body []byte) ([]byte, error)
+
+// Translate calls f(ctx, locale, content, body).
+func (f ProviderFunc) Translate(ctx context.Context, locale, content string, body []byte) ([]byte, error) {
+	return f(ctx, locale, content, body)
+}
+
+// Options represents the configuration settings for the [Translate] middleware component.
+type Options struct {
+	// Provider represents the translation provider. A nil value disables the middleware's behavior. Defaults to nil.
+	Provider Provider
+
+	// Match determines whether a route opts in to translation. A nil value opts in no routes, disabling the middleware's behavior.
+	// Defaults to nil.
+	Match func(r *http.Request) bool
+
+	// Source represents the responses' original locale. Requests negotiating the source locale, or its base language, aren't
+	// translated. Defaults to "en".
+	Source string
+
+	// Locale returns the request's negotiated locale. Defaults to a function selecting the request's most preferred Accept-Language
+	// tag, restricted to [Options.Locales] if specified.
+	Locale func(r *http.Request) string
+
+	// Locales optionally restricts the default [Options.Locale] negotiation to the supported locales. Defaults to an empty slice.
+	Locales []string
+
+	// Route returns the request's route, partitioning the translation cache. Defaults to a function returning the request's URL path.
+	Route func(r *http.Request) string
+
+	// Limit represents the maximum buffered response body size, in bytes. Larger responses are written untranslated. Defaults to 1 MiB.
+	Limit int
+
+	// Capacity represents the maximum number of cached translations. Defaults to 1024.
+	Capacity int
+}
+
+// Translate represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Translate struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	cache *cache
+}
+
+// Settings applies configuration functions to modify the [Translate] middleware's [Options] and returns the updated middleware instance.
+func (t *Translate) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if t.options == nil {
+		t.options = &Options{
+			Provider: nil,
+			Match:    nil,
+			Source:   "en",
+			Locale:   nil,
+			Locales:  []string{},
+			Route: func(r *http.Request) string {
+				return r.URL.Path
+			},
+			Limit:    1 << 20,
+			Capacity: 1024,
+		}
+
+		t.options.Locale = func(r *http.Request) string {
+			return negotiate(r.Header.Get("Accept-Language"), t.options.Locales)
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(t.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if t.options.Limit <= 0 {
+		slog.Warn("Invalid Translate Limit Specified - Using Default Limit")
+
+		t.options.Limit = 1 << 20
+	}
+
+	if t.options.Capacity <= 0 {
+		slog.Warn("Invalid Translate Capacity Specified - Using Default Capacity")
+
+		t.options.Capacity = 1024
+	}
+
+	return t
+}
+
+// negotiate returns the Accept-Language header's most preferred tag, restricted to the supported locales if non-empty. Supported
+// locales match tags exactly, or by base language (e.g. "fr-CA" matches a supported "fr").
+func negotiate(header string, supported []string) string {
+	type tag struct {
+		value   string
+		quality float64
+	}
+
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		value, parameters, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		quality := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(parameters), "q="); found {
+			if q, e := strconv.ParseFloat(v, 64); e == nil {
+				quality = q
+			}
+		}
+
+		if value = strings.TrimSpace(value); value != "" && value != "*" && quality > 0 {
+			tags = append(tags, tag{value: value, quality: quality})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	for _, t := range tags {
+		if len(supported) == 0 {
+			return t.value
+		}
+
+		base, _, _ := strings.Cut(t.value, "-")
+		for _, locale := range supported {
+			if strings.EqualFold(locale, t.value) || strings.EqualFold(locale, base) {
+				return locale
+			}
+		}
+	}
+
+	return ""
+}
+
+// source reports whether the locale is the source locale, or shares its base language.
+func (t *Translate) source(locale string) bool {
+	base, _, _ := strings.Cut(locale, "-")
+	source, _, _ := strings.Cut(t.options.Source, "-")
+
+	return strings.EqualFold(base, source)
+}
+
+// textual reports whether the content type represents a translatable, textual format.
+func textual(content string) bool {
+	mediatype, _, e := mime.ParseMediaType(content)
+	if e != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mediatype, "text/") || mediatype == "application/json" || mediatype == "application/xml" || strings.HasSuffix(mediatype, "+json") || strings.HasSuffix(mediatype, "+xml")
+}
+
+// writer buffers eligible responses for translation, passing ineligible and oversized responses through.
+type writer struct {
+	http.ResponseWriter
+
+	limit    int
+	status   int
+	wrote    bool
+	buffered bool
+	buffer   bytes.Buffer
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.wrote {
+		return
+	}
+
+	w.wrote, w.status = true, status
+
+	header := w.ResponseWriter.Header()
+	if status == http.StatusOK && header.Get("Content-Encoding") == "" && textual(header.Get("Content-Type")) {
+		w.buffered = true
+
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.wrote) {
+		if w.ResponseWriter.Header().Get("Content-Type") == "" {
+			w.ResponseWriter.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !(w.buffered) {
+		return w.ResponseWriter.Write(p)
+	}
+
+	if w.buffer.Len()+len(p) > w.limit {
+		// The response exceeds the buffer's limit; write it untranslated.
+		w.buffered = false
+
+		w.ResponseWriter.WriteHeader(w.status)
+
+		if _, e := w.ResponseWriter.Write(w.buffer.Bytes()); e != nil {
+			return 0, e
+		}
+
+		w.buffer.Reset()
+
+		return w.ResponseWriter.Write(p)
+	}
+
+	return w.buffer.Write(p)
+}
+
+// Flush writes any buffered response untranslated - streamed responses aren't translated - and flushes the underlying
+// [http.ResponseWriter].
+func (w *writer) Flush() {
+	if !(w.wrote) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffered {
+		w.buffered = false
+
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(w.buffer.Bytes())
+
+		w.buffer.Reset()
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler passes opted-in routes' buffered, textual responses through the [Options.Provider], translating them into the request's
+// negotiated locale. Translations are cached by route, locale, and content hash, bounding provider calls; provider failures
+// result in the untranslated response. The negotiated locale is stored in the request context.
+func (t *Translate) Handler(next http.Handler) http.Handler {
+	t.Settings() // Ensure the options field isn't nil.
+
+	if t.options.Provider == nil || t.options.Match == nil {
+		slog.Warn("Translate Middleware Provider or Match Unspecified - Translation Disabled")
+	}
+
+	t.cache = &cache{capacity: t.options.Capacity}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		locale := ""
+		if t.options.Locale != nil {
+			locale = t.options.Locale(r)
+		}
+
+		ctx = context.WithValue(ctx, key, locale)
+
+		if t.options.Provider == nil || t.options.Match == nil || !(t.options.Match(r)) {
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+
+		if locale == "" || t.source(locale) {
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			return
+		}
+
+		capture := &writer{ResponseWriter: w, limit: t.options.Limit}
+
+		next.ServeHTTP(capture, r.WithContext(ctx))
+
+		if !(capture.buffered) {
+			return
+		}
+
+		body := capture.buffer.Bytes()
+		content := w.Header().Get("Content-Type")
+
+		digest := sha256.Sum256(body)
+
+		k := [3]string{t.options.Route(r), locale, hex.EncodeToString(digest[:])}
+
+		translated, cached := t.cache.get(k)
+		if !(cached) {
+			var e error
+			if translated, e = t.options.Provider.Translate(ctx, locale, content, body); e != nil {
+				slog.WarnContext(ctx, "Unable to Translate Response - Writing Untranslated Response", slog.String("locale", locale), slog.String("error", e.Error()))
+
+				translated = nil
+			} else {
+				t.cache.put(k, translated)
+			}
+		}
+
+		if translated != nil {
+			body = translated
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Del("ETag")
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(capture.status)
+		w.Write(body)
+	})
+}
+
+// New creates a new instance of the [Translate] middleware, implementing [middleware.Configurable]. If [Translate.Settings] isn't
+// called, then the [Translate.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Translate)
+}
+
+// Value retrieves the request's negotiated locale from the provided context. If an empty string is returned, it can be assumed
+// that the [Translate] middleware isn't enabled for the particular caller's chain, or that no locale was negotiated.
+func Value(ctx context.Context) (value string) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(string); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(string); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Translate] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Translate)(nil)
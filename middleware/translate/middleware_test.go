@@ -0,0 +1,162 @@
+package translate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/translate"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Locale", translate.Value(r.Context()))
+
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`{"message":"hello"}`))
+		case "/binary":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("hello"))
+		case "/error":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("hello"))
+		case "/large":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(strings.Repeat("hello", 4)))
+			w.Write([]byte(strings.Repeat("hello", 4)))
+		default:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte("hello"))
+		}
+	})
+
+	provider := translate.ProviderFunc(func(ctx context.Context, locale, content string, body []byte) ([]byte, error) {
+		if locale == "xx" {
+			return nil, errors.New("unsupported locale")
+		}
+
+		return []byte(strings.ReplaceAll(string(body), "hello", "bonjour")), nil
+	})
+
+	everything := func(r *http.Request) bool { return true }
+
+	tests := []struct {
+		name     string
+		options  func(o *translate.Options)
+		path     string
+		language string
+		locale   string
+		body     string
+		content  string
+	}{
+		{name: "Disabled", path: "/", language: "fr", locale: "fr", body: "hello"},
+		{name: "Translated", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/", language: "fr-CA,fr;q=0.9,en;q=0.5", locale: "fr-CA", body: "bonjour", content: "fr-CA"},
+		{name: "Quality-Preference", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/", language: "en;q=0.4,de;q=0.8", locale: "de", body: "bonjour", content: "de"},
+		{name: "Supported-Locales", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+			o.Locales = []string{"es", "fr"}
+		}, path: "/", language: "de,fr-BE;q=0.5", locale: "fr", body: "bonjour", content: "fr"},
+		{name: "Source-Locale", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/", language: "en-GB", locale: "en-GB", body: "hello"},
+		{name: "Not-Opted-In", options: func(o *translate.Options) {
+			o.Provider = provider
+			o.Match = func(r *http.Request) bool { return strings.HasPrefix(r.URL.Path, "/docs") }
+		}, path: "/", language: "fr", locale: "fr", body: "hello"},
+		{name: "JSON", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/json", language: "fr", locale: "fr", body: `{"message":"bonjour"}`, content: "fr"},
+		{name: "Non-Textual", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/binary", language: "fr", locale: "fr", body: "hello"},
+		{name: "Error-Response", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/error", language: "fr", locale: "fr", body: "hello"},
+		{name: "Limit-Exceeded", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+			o.Limit = 32
+		}, path: "/large", language: "fr", locale: "fr", body: strings.Repeat("hello", 8)},
+		{name: "Provider-Failure", options: func(o *translate.Options) {
+			o.Provider, o.Match = provider, everything
+		}, path: "/", language: "xx", locale: "xx", body: "hello"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := translate.New().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			request.Header.Set("Accept-Language", test.language)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Locale"); v != test.locale {
+				t.Errorf("Locale = %s\n    - Expectation = %s", v, test.locale)
+			}
+
+			if v := recorder.Body.String(); v != test.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+
+			if v := recorder.Header().Get("Content-Language"); v != test.content {
+				t.Errorf("Content-Language = %s\n    - Expectation = %s", v, test.content)
+			}
+
+			if test.content != "" && recorder.Header().Get("ETag") != "" {
+				t.Errorf("Unexpected ETag on Translated Response: %s", recorder.Header().Get("ETag"))
+			}
+		})
+	}
+
+	t.Run("Cache", func(t *testing.T) {
+		var calls atomic.Int32
+
+		counter := translate.ProviderFunc(func(ctx context.Context, locale, content string, body []byte) ([]byte, error) {
+			calls.Add(1)
+
+			return provider(ctx, locale, content, body)
+		})
+
+		m := translate.New().Settings(func(o *translate.Options) {
+			o.Provider, o.Match = counter, everything
+			o.Capacity = 1
+		}).Handler(handler)
+
+		for _, language := range []string{"fr", "fr", "de", "de", "fr"} {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Accept-Language", language)
+
+			m.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		// "fr" and "de" are each translated once, then "fr" again after its eviction.
+		if v := calls.Load(); v != 3 {
+			t.Errorf("Provider Calls = %d\n    - Expectation = %d", v, 3)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := translate.Value(context.Background()); v != "" {
+			t.Errorf("Unexpected Non-Empty Context Value: %s", v)
+		}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", "fr")
+		if v := translate.Value(ctx); v != "fr" {
+			t.Errorf("Invalid Context Value: %s", v)
+		}
+	})
+}
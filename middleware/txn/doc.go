@@ -0,0 +1,8 @@
+// Package txn provides per-request unit-of-work middleware, such as a database transaction scoped to the request's lifetime.
+//
+// A unit is begun through a user-provided function - see [Database] for [sql.DB] transactions - stored in context for handlers,
+// committed for successful responses, and rolled back for error responses or handler panics. Units are finalized before the
+// response's header(s) are sent, so a failed commit is reported to the client rather than masked by a successful status.
+//
+// Units implementing [Savepointer] additionally support nested savepoints through [Savepoint].
+package txn
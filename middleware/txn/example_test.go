@@ -0,0 +1,82 @@
+package txn_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/txn"
+)
+
+// ledger represents an example unit of work.
+type ledger struct {
+	entries []string
+}
+
+func (l *ledger) Commit(ctx context.Context) error {
+	fmt.Printf("Committed %d Entries\n", len(l.entries))
+
+	return nil
+}
+
+func (l *ledger) Rollback(ctx context.Context) error {
+	fmt.Printf("Rolled Back %d Entries\n", len(l.entries))
+
+	return nil
+}
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(txn.New[*ledger]().Settings(func(o *txn.Options[*ledger]) {
+		o.Begin = func(ctx context.Context, readonly bool) (*ledger, error) {
+			return new(ledger), nil
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /entries", func(w http.ResponseWriter, r *http.Request) {
+		l := txn.Value[*ledger](r.Context())
+
+		l.entries = append(l.entries, "debit", "credit")
+
+		if r.URL.Query().Has("invalid") {
+			http.Error(w, "Unbalanced Entries", http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, path := range []string{"/entries", "/entries?invalid"} {
+		request, e := http.NewRequest(http.MethodPost, server.URL+path, nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+	}
+
+	// Output:
+	// Committed 2 Entries
+	// Rolled Back 2 Entries
+}
@@ -0,0 +1,260 @@
+package txn
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "txn"
+
+// Options represents the configuration settings for the [Txn] middleware component.
+type Options[T Unit] struct {
+	// Begin begins a request's unit of work, read-only as determined by [Options.ReadOnly]. A nil value disables the middleware's
+	// behavior. See [Database] for [sql.DB] transactions. Defaults to nil.
+	Begin func(ctx context.Context, readonly bool) (T, error)
+
+	// ReadOnly determines whether the request's unit of work is read-only. Defaults to a function returning true for GET, HEAD,
+	// and OPTIONS requests.
+	ReadOnly func(r *http.Request) bool
+
+	// Success determines whether a response's status commits the unit of work; otherwise, it's rolled back. Defaults to a
+	// function returning true for 2xx status codes.
+	Success func(status int) bool
+
+	// Status represents the response's status code when the unit of work can't be begun or committed. Defaults to
+	// [http.StatusServiceUnavailable].
+	Status int
+}
+
+// Txn represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Txn[T Unit] struct {
+	middleware.Configurable[Options[T]]
+
+	options *Options[T]
+}
+
+// Settings applies configuration functions to modify the [Txn] middleware's [Options] and returns the updated middleware instance.
+func (x *Txn[T]) Settings(configuration ...func(o *Options[T])) middleware.Configurable[Options[T]] {
+	if x.options == nil {
+		x.options = &Options[T]{
+			Begin: nil,
+			ReadOnly: func(r *http.Request) bool {
+				return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+			},
+			Success: func(status int) bool {
+				return status >= 200 && status < 300
+			},
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.ReadOnly == nil {
+		x.options.ReadOnly = func(r *http.Request) bool { return false }
+	}
+
+	if x.options.Success == nil {
+		slog.Warn("Invalid Txn Success Function Specified - Using Default Success Function")
+
+		x.options.Success = func(status int) bool { return status >= 200 && status < 300 }
+	}
+
+	if x.options.Status < 500 || x.options.Status > 599 {
+		slog.Warn("Invalid Txn Status Specified - Using Default Status")
+
+		x.options.Status = http.StatusServiceUnavailable
+	}
+
+	return x
+}
+
+// writer finalizes the unit of work upon the response's header(s) being written - prior to the client receiving them - so a
+// failed commit can still be reported to the client.
+type writer[T Unit] struct {
+	http.ResponseWriter
+
+	txn      *Txn[T]
+	unit     T
+	ctx      context.Context
+	written  bool
+	failed   bool
+	finished bool
+}
+
+// finish commits, or rolls back, the unit of work, reporting whether the commit (if any) succeeded.
+func (w *writer[T]) finish(commit bool) bool {
+	if w.finished {
+		return true
+	}
+
+	w.finished = true
+
+	if commit {
+		if e := w.unit.Commit(w.ctx); e != nil {
+			slog.ErrorContext(w.ctx, "Unable to Commit Unit of Work", slog.String("error", e.Error()))
+
+			return false
+		}
+
+		return true
+	}
+
+	if e := w.unit.Rollback(w.ctx); e != nil {
+		slog.WarnContext(w.ctx, "Unable to Roll Back Unit of Work", slog.String("error", e.Error()))
+	}
+
+	return true
+}
+
+func (w *writer[T]) WriteHeader(status int) {
+	if w.written || status < 200 {
+		if !(w.written) {
+			w.ResponseWriter.WriteHeader(status)
+		}
+
+		return
+	}
+
+	w.written = true
+
+	if !(w.finish(w.txn.options.Success(status))) {
+		w.failed = true
+
+		http.Error(w.ResponseWriter, http.StatusText(w.txn.options.Status), w.txn.options.Status)
+
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer[T]) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.failed {
+		// Discard the body of a response whose unit of work failed to commit.
+		return len(p), nil
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], finalizing the unit of work according to the response's status before flushing its header(s).
+func (w *writer[T]) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker]. A hijacked connection's response status is unknown, so its unit of work is rolled back.
+func (w *writer[T]) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, e := http.NewResponseController(w.ResponseWriter).Hijack()
+	if e == nil && !(w.written) {
+		w.written = true
+
+		w.finish(false)
+	}
+
+	return conn, rw, e
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer[T]) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler begins a unit of work per request - read-only for safe methods - and stores it in the request context. The unit is
+// committed when a successful (2xx) response's header(s) are written, and rolled back for any other response, or if the next
+// handler panics. A unit that can't be begun or committed results in an [Options.Status] response.
+func (x *Txn[T]) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Begin == nil {
+		slog.Warn("Txn Begin Function Unspecified - Units of Work Disabled")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if x.options.Begin == nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		unit, e := x.options.Begin(ctx, x.options.ReadOnly(r))
+		if e != nil {
+			slog.ErrorContext(ctx, "Unable to Begin Unit of Work", slog.String("error", e.Error()))
+
+			http.Error(w, http.StatusText(x.options.Status), x.options.Status)
+
+			return
+		}
+
+		// The unit is finalized with a context detached from the request's cancellation, so a disconnecting client can't
+		// interrupt a commit or rollback.
+		wrapper := &writer[T]{ResponseWriter: w, txn: x, unit: unit, ctx: context.WithoutCancel(ctx)}
+
+		defer func() {
+			if value := recover(); value != nil {
+				wrapper.finish(false)
+
+				panic(value)
+			}
+
+			if !(wrapper.written) {
+				wrapper.WriteHeader(http.StatusOK)
+			}
+		}()
+
+		next.ServeHTTP(wrapper, r.WithContext(context.WithValue(ctx, key, unit)))
+	})
+}
+
+// New creates a new instance of the [Txn] middleware, managing units of work of type T, implementing [middleware.Configurable].
+// If [Txn.Settings] isn't called, then the [Txn.Handler] function will hydrate the middleware's configuration with sane
+// default(s) if applicable.
+func New[T Unit]() middleware.Configurable[Options[T]] {
+	return new(Txn[T])
+}
+
+// Value retrieves the request's unit of work from the provided context. The type parameter must match the [New] function's. If
+// a zero value is returned, it can be assumed that the [Txn] middleware isn't enabled for the particular caller's chain.
+func Value[T Unit](ctx context.Context) (value T) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(T); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(T); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Txn] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options[*Tx]] = (*Txn[*Tx])(nil)
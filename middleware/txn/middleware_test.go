@@ -0,0 +1,204 @@
+package txn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/txn"
+)
+
+// unit represents a testing [txn.Unit], recording its operations.
+type unit struct {
+	readonly   bool
+	operations []string
+	commit     error
+}
+
+func (u *unit) Commit(ctx context.Context) error {
+	u.operations = append(u.operations, "commit")
+
+	return u.commit
+}
+
+func (u *unit) Rollback(ctx context.Context) error {
+	u.operations = append(u.operations, "rollback")
+
+	return nil
+}
+
+func (u *unit) Savepoint(ctx context.Context, name string) error {
+	u.operations = append(u.operations, "savepoint:"+name)
+
+	return nil
+}
+
+func (u *unit) RollbackTo(ctx context.Context, name string) error {
+	u.operations = append(u.operations, "rollback-to:"+name)
+
+	return nil
+}
+
+func (u *unit) Release(ctx context.Context, name string) error {
+	u.operations = append(u.operations, "release:"+name)
+
+	return nil
+}
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := txn.Value[*unit](r.Context())
+
+		w.Header().Set("X-Read-Only", strconv.FormatBool(u.readonly))
+
+		if r.URL.Query().Has("savepoint") {
+			txn.Savepoint(r.Context(), "first", func(u *unit) error { return nil })
+			txn.Savepoint(r.Context(), "second", func(u *unit) error { return errors.New("failure") })
+		}
+
+		if r.URL.Query().Has("panic") {
+			panic("unexpected state")
+		}
+
+		status, _ := strconv.Atoi(r.URL.Query().Get("status"))
+		if status == 0 {
+			w.Write([]byte("implicit"))
+
+			return
+		}
+
+		w.WriteHeader(status)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		commit     error
+		begin      error
+		status     int
+		readonly   string
+		operations []string
+	}{
+		{name: "Commit", method: http.MethodPost, query: "status=201", status: http.StatusCreated, readonly: "false", operations: []string{"commit"}},
+		{name: "Implicit-Commit", method: http.MethodGet, query: "", status: http.StatusOK, readonly: "true", operations: []string{"commit"}},
+		{name: "Rollback", method: http.MethodPut, query: "status=409", status: http.StatusConflict, readonly: "false", operations: []string{"rollback"}},
+		{name: "Commit-Failure", method: http.MethodPost, query: "status=200", commit: errors.New("serialization failure"), status: http.StatusServiceUnavailable, readonly: "false", operations: []string{"commit"}},
+		{name: "Begin-Failure", method: http.MethodPost, query: "status=200", begin: errors.New("connection refused"), status: http.StatusServiceUnavailable, readonly: "", operations: nil},
+		{name: "Savepoints", method: http.MethodPost, query: "savepoint&status=200", status: http.StatusOK, readonly: "false", operations: []string{"savepoint:first", "release:first", "savepoint:second", "rollback-to:second", "commit"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var u *unit
+
+			m := txn.New[*unit]().Settings(func(o *txn.Options[*unit]) {
+				o.Begin = func(ctx context.Context, readonly bool) (*unit, error) {
+					if test.begin != nil {
+						return nil, test.begin
+					}
+
+					u = &unit{readonly: readonly, commit: test.commit}
+
+					return u, nil
+				}
+			}).Handler(handler)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, httptest.NewRequest(test.method, "/?"+test.query, nil))
+
+			if recorder.Code != test.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if v := recorder.Header().Get("X-Read-Only"); v != test.readonly {
+				t.Errorf("Read-Only = %s\n    - Expectation = %s", v, test.readonly)
+			}
+
+			var operations []string
+			if u != nil {
+				operations = u.operations
+			}
+
+			if !(slices.Equal(operations, test.operations)) {
+				t.Errorf("Operations = %v\n    - Expectation = %v", operations, test.operations)
+			}
+		})
+	}
+
+	t.Run("Panic", func(t *testing.T) {
+		var u *unit
+
+		m := txn.New[*unit]().Settings(func(o *txn.Options[*unit]) {
+			o.Begin = func(ctx context.Context, readonly bool) (*unit, error) {
+				u = &unit{readonly: readonly}
+
+				return u, nil
+			}
+		}).Handler(handler)
+
+		func() {
+			defer func() {
+				if v := recover(); v != "unexpected state" {
+					t.Errorf("Panic = %v\n    - Expectation = %s", v, "unexpected state")
+				}
+			}()
+
+			m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/?panic", nil))
+		}()
+
+		if !(slices.Equal(u.operations, []string{"rollback"})) {
+			t.Errorf("Operations = %v\n    - Expectation = %v", u.operations, []string{"rollback"})
+		}
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		var u *unit
+
+		m := txn.New[*unit]().Settings(func(o *txn.Options[*unit]) {
+			o.Begin = func(ctx context.Context, readonly bool) (*unit, error) {
+				u = &unit{readonly: readonly, commit: errors.New("serialization failure")}
+
+				return u, nil
+			}
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if e := http.NewResponseController(w).Flush(); e != nil {
+				t.Errorf("Unexpected Error While Flushing Response: %v", e)
+			}
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusServiceUnavailable)
+		}
+
+		if !(recorder.Flushed) {
+			t.Errorf("Flushed = %t\n    - Expectation = %t", recorder.Flushed, true)
+		}
+
+		if !(slices.Equal(u.operations, []string{"commit"})) {
+			t.Errorf("Operations = %v\n    - Expectation = %v", u.operations, []string{"commit"})
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := txn.Value[*unit](context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &unit{readonly: true}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := txn.Value[*unit](ctx); v != expectation {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
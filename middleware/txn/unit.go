@@ -0,0 +1,126 @@
+package txn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+)
+
+// Unit represents a unit of work, such as a database transaction.
+type Unit interface {
+	// Commit persists the unit of work.
+	Commit(ctx context.Context) error
+
+	// Rollback discards the unit of work.
+	Rollback(ctx context.Context) error
+}
+
+// Savepointer is optionally implemented by a [Unit] supporting nested savepoints. See [Savepoint].
+type Savepointer interface {
+	// Savepoint establishes the named savepoint.
+	Savepoint(ctx context.Context, name string) error
+
+	// RollbackTo discards the unit's work since the named savepoint.
+	RollbackTo(ctx context.Context, name string) error
+
+	// Release discards the named savepoint, retaining the unit's work since it.
+	Release(ctx context.Context, name string) error
+}
+
+// ErrSavepoints is returned by [Savepoint] for a [Unit] not implementing [Savepointer].
+var ErrSavepoints = errors.New("unit of work doesn't support savepoints")
+
+// ErrSavepointName is returned for savepoint names that aren't valid SQL identifiers.
+var ErrSavepointName = errors.New("invalid savepoint name")
+
+// identifier matches valid, unquoted SQL identifiers.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+// Tx adapts a [sql.Tx] into a [Unit] implementing [Savepointer].
+type Tx struct {
+	*sql.Tx
+}
+
+// Commit commits the transaction.
+func (t *Tx) Commit(ctx context.Context) error {
+	return t.Tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (t *Tx) Rollback(ctx context.Context) error {
+	return t.Tx.Rollback()
+}
+
+// Savepoint establishes the named savepoint.
+func (t *Tx) Savepoint(ctx context.Context, name string) error {
+	if !(identifier.MatchString(name)) {
+		return ErrSavepointName
+	}
+
+	_, e := t.Tx.ExecContext(ctx, "SAVEPOINT "+name)
+
+	return e
+}
+
+// RollbackTo rolls the transaction back to the named savepoint.
+func (t *Tx) RollbackTo(ctx context.Context, name string) error {
+	if !(identifier.MatchString(name)) {
+		return ErrSavepointName
+	}
+
+	_, e := t.Tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+
+	return e
+}
+
+// Release releases the named savepoint.
+func (t *Tx) Release(ctx context.Context, name string) error {
+	if !(identifier.MatchString(name)) {
+		return ErrSavepointName
+	}
+
+	_, e := t.Tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+
+	return e
+}
+
+// Database returns an [Options.Begin] function beginning [sql.DB] transactions, read-only when requested.
+func Database(db *sql.DB) func(ctx context.Context, readonly bool) (*Tx, error) {
+	return func(ctx context.Context, readonly bool) (*Tx, error) {
+		tx, e := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: readonly})
+		if e != nil {
+			return nil, e
+		}
+
+		return &Tx{Tx: tx}, nil
+	}
+}
+
+// Savepoint runs fn within the named savepoint of the request's unit of work: the savepoint is rolled back if fn returns an
+// error, and released otherwise. The type parameter must match the [New] function's.
+func Savepoint[T Unit](ctx context.Context, name string, fn func(unit T) error) error {
+	unit, ok := ctx.Value(key).(T)
+	if !(ok) {
+		return errors.New("unit of work not found in context")
+	}
+
+	savepointer, ok := interface{}(unit).(Savepointer)
+	if !(ok) {
+		return ErrSavepoints
+	}
+
+	if e := savepointer.Savepoint(ctx, name); e != nil {
+		return e
+	}
+
+	if e := fn(unit); e != nil {
+		if exception := savepointer.RollbackTo(ctx, name); exception != nil {
+			return errors.Join(e, exception)
+		}
+
+		return e
+	}
+
+	return savepointer.Release(ctx, name)
+}
@@ -0,0 +1,9 @@
+// Package upload provides middleware streaming multipart ("multipart/form-data") file parts directly to a pluggable blob [Store]
+// (e.g. an S3-compatible object store), such that large uploads are never buffered in memory, nor spooled to temporary files.
+//
+// The middleware should be chained after authentication, rate limiting, and any other policy middleware, such that rejected
+// requests are never streamed. Each file part is bounded by a size limit, optionally restricted to an allowlist of sniffed content
+// types, and optionally streamed through a scanner (see [Options.Scan]); on any failure, the request's already-stored objects are
+// deleted. Once every part is stored, the handler receives the uploads' descriptors - and the form's non-file values - through the
+// request context (see [Value]).
+package upload
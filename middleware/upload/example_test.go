@@ -0,0 +1,69 @@
+package upload_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/upload"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(upload.New().Settings(func(o *upload.Options) {
+		o.Store = new(upload.Memory)
+		o.Types = []string{"text/plain"}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /documents", func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range upload.Value(r.Context()).Files {
+			fmt.Printf("Stored %s (%s, %d Bytes)\n", file.Filename, file.ContentType, file.Size)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	var buffer bytes.Buffer
+
+	writer := multipart.NewWriter(&buffer)
+	part, _ := writer.CreateFormFile("document", "notes.txt")
+	part.Write([]byte("Meeting Notes"))
+	writer.Close()
+
+	request, e := http.NewRequest(http.MethodPost, server.URL+"/documents", &buffer)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+		panic(e)
+	}
+
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	response, e := client.Do(request)
+	if e != nil {
+		e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+		panic(e)
+	}
+
+	response.Body.Close()
+
+	fmt.Printf("Status: %d\n", response.StatusCode)
+
+	// Output:
+	// Stored notes.txt (text/plain, 13 Bytes)
+	// Status: 201
+}
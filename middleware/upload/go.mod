@@ -0,0 +1,7 @@
+module github.com/poly-gun/go-middleware/middleware/upload
+
+go 1.22.7
+
+replace github.com/poly-gun/go-middleware => ../../
+
+require github.com/poly-gun/go-middleware v1.1.5
@@ -0,0 +1,419 @@
+package upload
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "upload"
+
+var (
+	// ErrLimit is reported for file parts exceeding [Options.Limit], and for requests exceeding [Options.Files] or [Options.Values].
+	ErrLimit = errors.New("upload: limit exceeded")
+
+	// ErrType is reported for file parts whose sniffed content type isn't in [Options.Types].
+	ErrType = errors.New("upload: unsupported content type")
+
+	// ErrScan is reported for file parts rejected by [Options.Scan].
+	ErrScan = errors.New("upload: rejected by scanner")
+
+	// ErrStore is reported for file parts the [Store] failed to store.
+	ErrStore = errors.New("upload: unable to store object")
+
+	// ErrMalformed is reported for malformed multipart bodies.
+	ErrMalformed = errors.New("upload: malformed multipart body")
+)
+
+// Descriptor represents a stored file part.
+type Descriptor struct {
+	// Field represents the part's form field name.
+	Field string `json:"field"`
+
+	// Filename represents the client-provided file name. It must not be trusted as a path.
+	Filename string `json:"filename"`
+
+	// ContentType represents the part's sniffed content type.
+	ContentType string `json:"content-type"`
+
+	// Key represents the object's key in the [Store]. See [Options.Key].
+	Key string `json:"key"`
+
+	// Location represents the object's location, as returned by the [Store].
+	Location string `json:"location"`
+
+	// Size represents the object's size, in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 represents the object's hex-encoded SHA-256 digest.
+	SHA256 string `json:"sha256"`
+}
+
+// Valuer is the context return type relating to the [Upload] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Files represents the request's stored file parts, in order of appearance.
+	Files []Descriptor `json:"files"`
+
+	// Values represents the request's non-file form values.
+	Values url.Values `json:"values,omitempty"`
+}
+
+// Options represents the configuration settings for the [Upload] middleware component.
+type Options struct {
+	// Store represents the blob store file parts are streamed to. A nil value rejects every multipart request with a
+	// [http.StatusInternalServerError] response. Defaults to nil.
+	Store Store
+
+	// Key returns the object key of a file part. Defaults to a function returning a random, 32-character hex identifier, suffixed
+	// with the part's (sanitized) file name extension.
+	Key func(r *http.Request, descriptor *Descriptor) string
+
+	// Limit represents the maximum size, in bytes, of each file part. Larger parts receive a [http.StatusRequestEntityTooLarge]
+	// response. Defaults to 1 GiB.
+	Limit int64
+
+	// Files represents the maximum number of file parts per request. Defaults to 16.
+	Files int
+
+	// Values represents the maximum combined size, in bytes, of the request's non-file values. Defaults to 1 MiB.
+	Values int64
+
+	// Types represents the allowlist of file parts' sniffed content types (e.g. "image/png", or "image/*"). Other parts receive a
+	// [http.StatusUnsupportedMediaType] response. An empty allowlist accepts every content type. Defaults to an empty slice.
+	Types []string
+
+	// Scan optionally wraps each file part's stream (e.g. for streaming malware scanning): a read error from the returned reader
+	// aborts the upload with a [http.StatusUnprocessableEntity] response. Defaults to nil.
+	Scan func(ctx context.Context, descriptor *Descriptor, body io.Reader) io.Reader
+}
+
+// Upload represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Upload struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// extension matches acceptable file name extensions.
+var extension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// identifier is the default [Options.Key] function.
+func identifier(r *http.Request, descriptor *Descriptor) string {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+
+	k := hex.EncodeToString(buffer)
+	if v := strings.ToLower(filepath.Ext(descriptor.Filename)); extension.MatchString(v) {
+		k += v
+	}
+
+	return k
+}
+
+// Settings applies configuration functions to modify the [Upload] middleware's [Options] and returns the updated middleware instance.
+func (x *Upload) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Store:  nil,
+			Key:    identifier,
+			Limit:  1 << 30,
+			Files:  16,
+			Values: 1 << 20,
+			Types:  []string{},
+			Scan:   nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Key == nil {
+		x.options.Key = identifier
+	}
+
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Upload Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1 << 30
+	}
+
+	if x.options.Files <= 0 {
+		slog.Warn("Invalid Upload Files Specified - Using Default Files")
+
+		x.options.Files = 16
+	}
+
+	if x.options.Values <= 0 {
+		slog.Warn("Invalid Upload Values Specified - Using Default Values")
+
+		x.options.Values = 1 << 20
+	}
+
+	return x
+}
+
+// limiter counts, and hashes, the bytes read from a part, failing with [ErrLimit] once more than limit bytes are read. Read errors
+// (e.g. a truncated body) are reported as [ErrMalformed].
+type limiter struct {
+	reader io.Reader
+	hash   hash.Hash
+	limit  int64
+	size   int64
+}
+
+func (l *limiter) Read(p []byte) (int, error) {
+	n, e := l.reader.Read(p)
+
+	l.size += int64(n)
+	l.hash.Write(p[:n])
+
+	if l.size > l.limit {
+		return n, ErrLimit
+	} else if e != nil && e != io.EOF {
+		return n, fmt.Errorf("%w: %w", ErrMalformed, e)
+	}
+
+	return n, e
+}
+
+// capture records the first non-EOF read error of a reader, such that errors surfaced through the [Store] can be attributed.
+type capture struct {
+	reader io.Reader
+	e      error
+}
+
+func (c *capture) Read(p []byte) (int, error) {
+	n, e := c.reader.Read(p)
+	if e != nil && e != io.EOF && c.e == nil {
+		c.e = e
+	}
+
+	return n, e
+}
+
+// allowed reports whether the content type satisfies [Options.Types].
+func (x *Upload) allowed(contentType string) bool {
+	if len(x.options.Types) == 0 {
+		return true
+	}
+
+	for _, v := range x.options.Types {
+		if strings.EqualFold(v, contentType) || (strings.HasSuffix(v, "/*") && strings.HasPrefix(contentType, strings.ToLower(strings.TrimSuffix(v, "*")))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// store streams a file part to the [Store].
+func (x *Upload) store(r *http.Request, part io.Reader, descriptor *Descriptor) error {
+	ctx := r.Context()
+
+	counter := &limiter{reader: part, hash: sha256.New(), limit: x.options.Limit}
+
+	reader := bufio.NewReaderSize(counter, 512)
+
+	head, e := reader.Peek(512)
+	if e != nil && e != io.EOF {
+		return e
+	}
+
+	descriptor.ContentType, _, _ = mime.ParseMediaType(http.DetectContentType(head))
+	if !(x.allowed(descriptor.ContentType)) {
+		return fmt.Errorf("%w: %s", ErrType, descriptor.ContentType)
+	}
+
+	descriptor.Key = x.options.Key(r, descriptor)
+
+	var body io.Reader = reader
+	if x.options.Scan != nil {
+		body = x.options.Scan(ctx, descriptor, body)
+	}
+
+	captured := &capture{reader: body}
+
+	location, e := x.options.Store.Put(ctx, descriptor.Key, descriptor.ContentType, captured)
+
+	switch {
+	case errors.Is(captured.e, ErrLimit), errors.Is(captured.e, ErrMalformed):
+		e = captured.e
+	case captured.e != nil:
+		e = fmt.Errorf("%w: %w", ErrScan, captured.e)
+	case e != nil:
+		e = fmt.Errorf("%w: %w", ErrStore, e)
+	}
+
+	if e != nil {
+		// The object may have been partially written.
+		x.options.Store.Delete(context.WithoutCancel(ctx), descriptor.Key)
+
+		return e
+	}
+
+	descriptor.Location = location
+	descriptor.Size = counter.size
+	descriptor.SHA256 = hex.EncodeToString(counter.hash.Sum(nil))
+
+	return nil
+}
+
+// stream stores the request's file parts, and collects its non-file values.
+func (x *Upload) stream(r *http.Request, valuer *Valuer) error {
+	reader, e := r.MultipartReader()
+	if e != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, e)
+	}
+
+	remaining := x.options.Values
+
+	for {
+		part, e := reader.NextPart()
+		if e == io.EOF {
+			return nil
+		} else if e != nil {
+			return fmt.Errorf("%w: %w", ErrMalformed, e)
+		}
+
+		if part.FileName() == "" {
+			value, e := io.ReadAll(io.LimitReader(part, remaining+1))
+			if e != nil {
+				return fmt.Errorf("%w: %w", ErrMalformed, e)
+			}
+
+			if remaining -= int64(len(value)); remaining < 0 {
+				return fmt.Errorf("%w: values exceed %d bytes", ErrLimit, x.options.Values)
+			}
+
+			valuer.Values.Add(part.FormName(), string(value))
+
+			continue
+		}
+
+		if len(valuer.Files) >= x.options.Files {
+			return fmt.Errorf("%w: more than %d files", ErrLimit, x.options.Files)
+		}
+
+		descriptor := Descriptor{Field: part.FormName(), Filename: filepath.Base(part.FileName())}
+		if e := x.store(r, part, &descriptor); e != nil {
+			return e
+		}
+
+		valuer.Files = append(valuer.Files, descriptor)
+	}
+}
+
+// status returns the response status code, and rejection code, of a failed upload.
+func status(e error) (int, string) {
+	switch {
+	case errors.Is(e, ErrLimit):
+		return http.StatusRequestEntityTooLarge, "limit-exceeded"
+	case errors.Is(e, ErrType):
+		return http.StatusUnsupportedMediaType, "content-type-unsupported"
+	case errors.Is(e, ErrScan):
+		return http.StatusUnprocessableEntity, "scan-rejected"
+	case errors.Is(e, ErrStore):
+		return http.StatusBadGateway, "store-failed"
+	}
+
+	return http.StatusBadRequest, "malformed"
+}
+
+// Handler streams the file parts of "multipart/form-data" requests to the [Options.Store], storing the uploads' [Valuer] in the
+// request context; other requests are forwarded as-is. The request's body is consumed: the next handler must rely on [Value],
+// rather than [http.Request.ParseMultipartForm]. If any part fails, the request's stored objects are deleted, and the next handler
+// isn't called.
+func (x *Upload) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	if x.options.Store == nil {
+		slog.Warn("Upload Store Unspecified - Multipart Requests Will be Rejected")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if media, _, e := mime.ParseMediaType(r.Header.Get("Content-Type")); e != nil || media != "multipart/form-data" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if x.options.Store == nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		valuer := &Valuer{Files: []Descriptor{}, Values: url.Values{}}
+
+		if e := x.stream(r, valuer); e != nil {
+			for _, descriptor := range valuer.Files {
+				if e := x.options.Store.Delete(context.WithoutCancel(ctx), descriptor.Key); e != nil {
+					slog.WarnContext(ctx, "Unable to Delete Failed Upload's Object", slog.String("key", descriptor.Key), slog.String("error", e.Error()))
+				}
+			}
+
+			code, reason := status(e)
+
+			slog.DebugContext(ctx, "Upload Failed", slog.String("error", e.Error()), slog.Int("status", code))
+
+			reject.Record(ctx, reject.Reason{Subsystem: "upload", Code: reason, Status: code})
+
+			http.Error(w, http.StatusText(code), code)
+
+			return
+		}
+
+		r = r.WithContext(context.WithValue(ctx, key, valuer))
+		r.Body = http.NoBody
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// New creates a new instance of the [Upload] middleware, implementing [middleware.Configurable]. If [Upload.Settings] isn't called,
+// then the [Upload.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Upload)
+}
+
+// Value retrieves the request's [Valuer] from the provided context. If a nil value is returned, the request wasn't a multipart
+// request, or it can be assumed that the [Upload] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Upload Valuer Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Upload] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Upload)(nil)
@@ -0,0 +1,234 @@
+package upload_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/upload"
+)
+
+// part represents a multipart body's part.
+type part struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+// body encodes the parts as a multipart body, returning the body and its content type.
+func body(t *testing.T, parts ...part) (*bytes.Buffer, string) {
+	var buffer bytes.Buffer
+
+	w := multipart.NewWriter(&buffer)
+	for _, p := range parts {
+		var writer io.Writer
+		var e error
+
+		if p.filename == "" {
+			writer, e = w.CreateFormField(p.field)
+		} else {
+			writer, e = w.CreateFormFile(p.field, p.filename)
+		}
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Creating Part: %v", e)
+		}
+
+		writer.Write(p.content)
+	}
+
+	w.Close()
+
+	return &buffer, w.FormDataContentType()
+}
+
+// failing represents a [upload.Store] failing every write.
+type failing struct {
+	upload.Memory
+}
+
+func (*failing) Put(ctx context.Context, key string, contentType string, body io.Reader) (string, error) {
+	return "", errors.New("bucket unavailable")
+}
+
+func Test(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+	tests := []struct {
+		name     string
+		settings func(o *upload.Options)
+		store    upload.Store
+		parts    []part
+		status   int
+		files    int
+		types    []string
+		values   map[string]string
+	}{
+		{
+			name:   "Files-And-Values",
+			parts:  []part{{field: "title", content: []byte("Holiday")}, {field: "photo", filename: "beach.PNG", content: png}, {field: "notes", filename: "notes.txt", content: []byte("plain text")}},
+			status: http.StatusOK,
+			files:  2,
+			types:  []string{"image/png", "text/plain"},
+			values: map[string]string{"title": "Holiday"},
+		},
+		{
+			name:     "File-Limit",
+			settings: func(o *upload.Options) { o.Limit = 16 },
+			parts:    []part{{field: "photo", filename: "beach.png", content: png}},
+			status:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "File-Count",
+			settings: func(o *upload.Options) { o.Files = 1 },
+			parts:    []part{{field: "a", filename: "a.txt", content: []byte("a")}, {field: "b", filename: "b.txt", content: []byte("b")}},
+			status:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "Values-Limit",
+			settings: func(o *upload.Options) { o.Values = 4 },
+			parts:    []part{{field: "title", content: []byte("Holiday")}},
+			status:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "Disallowed-Type",
+			settings: func(o *upload.Options) { o.Types = []string{"image/*"} },
+			parts:    []part{{field: "photo", filename: "beach.png", content: png}, {field: "script", filename: "photo.png", content: []byte("#!/bin/sh\necho")}},
+			status:   http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "Scan-Rejected",
+			settings: func(o *upload.Options) {
+				o.Scan = func(ctx context.Context, descriptor *upload.Descriptor, body io.Reader) io.Reader {
+					content, _ := io.ReadAll(body)
+					if bytes.Contains(content, []byte("EICAR")) {
+						return io.MultiReader(bytes.NewReader(content), &erroring{})
+					}
+
+					return bytes.NewReader(content)
+				}
+			},
+			parts:  []part{{field: "clean", filename: "clean.txt", content: []byte("clean")}, {field: "virus", filename: "virus.txt", content: []byte("X5O!P%@AP EICAR")}},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "Store-Failure",
+			store:  new(failing),
+			parts:  []part{{field: "photo", filename: "beach.png", content: png}},
+			status: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := new(upload.Memory)
+
+			var store upload.Store = memory
+			if tt.store != nil {
+				store = tt.store
+			}
+
+			var valuer *upload.Valuer
+
+			handler := upload.New().Settings(func(o *upload.Options) {
+				o.Store = store
+			}, tt.settings).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				valuer = upload.Value(r.Context())
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			buffer, contentType := body(t, tt.parts...)
+
+			request := httptest.NewRequest(http.MethodPost, "/uploads", buffer)
+			request.Header.Set("Content-Type", contentType)
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, tt.status)
+			}
+
+			if tt.status != http.StatusOK {
+				if memory.Len() != 0 {
+					t.Errorf("Stored Objects = %d\n    - Expectation = %d", memory.Len(), 0)
+				}
+
+				return
+			}
+
+			if len(valuer.Files) != tt.files {
+				t.Fatalf("Files = %d\n    - Expectation = %d", len(valuer.Files), tt.files)
+			}
+
+			for index, descriptor := range valuer.Files {
+				if !(strings.HasPrefix(descriptor.ContentType, tt.types[index])) {
+					t.Errorf("Content Type = %s\n    - Expectation = %s", descriptor.ContentType, tt.types[index])
+				}
+
+				object, found := memory.Object(descriptor.Key)
+				if !(found) {
+					t.Fatalf("Object (%s) Not Found", descriptor.Key)
+				}
+
+				digest := sha256.Sum256(object)
+				if descriptor.SHA256 != hex.EncodeToString(digest[:]) || descriptor.Size != int64(len(object)) {
+					t.Errorf("Descriptor = %+v\n    - Expectation = %d Bytes", descriptor, len(object))
+				}
+			}
+
+			if v := valuer.Files[0].Key; !(strings.HasSuffix(v, ".png")) {
+				t.Errorf("Key = %s\n    - Expectation = *.png", v)
+			}
+
+			for k, expectation := range tt.values {
+				if v := valuer.Values.Get(k); v != expectation {
+					t.Errorf("%s = %s\n    - Expectation = %s", k, v, expectation)
+				}
+			}
+		})
+	}
+
+	t.Run("Non-Multipart", func(t *testing.T) {
+		handler := upload.New().Settings(func(o *upload.Options) {
+			o.Store = new(upload.Memory)
+		}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if upload.Value(r.Context()) != nil {
+				t.Errorf("Unexpected Upload Valuer")
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+
+		if recorder.Code != http.StatusNoContent {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusNoContent)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := upload.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
+
+// erroring represents a scanner's verdict, failing every read.
+type erroring struct{}
+
+func (*erroring) Read(p []byte) (int, error) {
+	return 0, errors.New("malware detected")
+}
@@ -0,0 +1,71 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// Store represents a blob store, as implemented by S3-style object store clients.
+type Store interface {
+	// Put streams the body to the object identified by key, returning the object's location (e.g. a URL, or bucket path).
+	Put(ctx context.Context, key string, contentType string, body io.Reader) (location string, e error)
+
+	// Delete removes the object identified by key. It's used to discard a failed request's already-stored objects.
+	Delete(ctx context.Context, key string) error
+}
+
+// Memory is an in-memory [Store], suitable for tests and development. The zero value is ready for use.
+type Memory struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *Memory) Put(ctx context.Context, key string, contentType string, body io.Reader) (string, error) {
+	var buffer bytes.Buffer
+	if _, e := io.Copy(&buffer, body); e != nil {
+		return "", e
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.objects == nil {
+		m.objects = make(map[string][]byte)
+	}
+
+	m.objects[key] = buffer.Bytes()
+
+	return "memory://" + key, nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.objects, key)
+
+	return nil
+}
+
+// Object returns the stored object identified by key.
+func (m *Memory) Object(key string) ([]byte, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	v, ok := m.objects[key]
+
+	return v, ok
+}
+
+// Len returns the number of stored objects.
+func (m *Memory) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.objects)
+}
+
+// Runtime assurance that [Memory] satisfies [Store] requirement(s).
+var _ Store = (*Memory)(nil)
@@ -0,0 +1,20 @@
+// Package validate provides request validation middleware, validating query parameters, headers, and JSON bodies against per-route
+// [Schema] rules - required values, types, regular expressions, and minimum and maximum bounds.
+//
+// Schemas are registered by route pattern, of the [net/http.ServeMux] form:
+//
+//	validate.New().Settings(func(o *validate.Options) {
+//		o.Schemas["POST /users"] = validate.Schema{
+//			Query: map[string]validate.Rule{"dry-run": {Type: validate.Boolean}},
+//			Body: map[string]validate.Rule{
+//				"name":         {Required: true, Maximum: validate.Bound(64)},
+//				"email":        {Required: true, Pattern: regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)},
+//				"age":          {Type: validate.Integer, Minimum: validate.Bound(0)},
+//				"address.city": {Required: true},
+//			},
+//		}
+//	})
+//
+// Invalid requests receive a JSON [net/http.StatusBadRequest] response listing every [Violation]. Valid requests' decoded bodies are
+// stored in the request context - see [Value], and [Valuer.Decode] - such that handlers needn't read, and decode, the body again.
+package validate
@@ -0,0 +1,72 @@
+package validate_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/validate"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(validate.New().Settings(func(o *validate.Options) {
+		o.Schemas["POST /users"] = validate.Schema{
+			Body: map[string]validate.Rule{
+				"name": {Required: true},
+				"age":  {Type: validate.Integer, Minimum: validate.Bound(0)},
+			},
+		}
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		var user struct {
+			Name string `json:"name"`
+		}
+
+		validate.Value(r.Context()).Decode(&user)
+
+		fmt.Fprintf(w, "created %s", user.Name)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, body := range []string{`{"name": "Jane", "age": 30}`, `{"age": -1}`} {
+		request, e := http.NewRequest(http.MethodPost, server.URL+"/users", strings.NewReader(body))
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("Content-Type", "application/json")
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		content, _ := io.ReadAll(response.Body)
+
+		response.Body.Close()
+
+		fmt.Println(response.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	// Output:
+	// 200 created Jane
+	// 400 {"title":"Validation Failed","status":400,"violations":[{"location":"body","field":"age","message":"must be at least 0"},{"location":"body","field":"name","message":"is required"}]}
+}
@@ -0,0 +1,312 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "validate"
+
+// Valuer is the context return type relating to the [Validate] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Pattern represents the matched [Options.Schemas] pattern.
+	Pattern string `json:"pattern"`
+
+	// Body represents the validated JSON body, decoded with [json.Decoder.UseNumber]. Nil for requests without a validated body.
+	Body any `json:"-"`
+
+	// Raw represents the validated JSON body's bytes.
+	Raw []byte `json:"-"`
+}
+
+// Decode unmarshals the validated JSON body into v, without re-reading the request's body.
+func (v *Valuer) Decode(target any) error {
+	if len(v.Raw) == 0 {
+		return errors.New("validate: no validated body")
+	}
+
+	return json.Unmarshal(v.Raw, target)
+}
+
+// Options represents the configuration settings for the [Validate] middleware component.
+type Options struct {
+	// Schemas maps route patterns - of the [http.ServeMux] form, e.g. "POST /users/{id}" - to their [Schema]. Requests not matching
+	// a pattern are forwarded unvalidated. Defaults to an empty map.
+	Schemas map[string]Schema
+
+	// Limit represents the maximum JSON body size, in bytes. Larger bodies receive a [http.StatusRequestEntityTooLarge] response.
+	// Defaults to 1 MiB.
+	Limit int64
+}
+
+// Validate represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Validate struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mux *http.ServeMux // mux matches requests to their [Options.Schemas] pattern.
+}
+
+// Settings applies configuration functions to modify the [Validate] middleware's [Options] and returns the updated middleware instance.
+func (x *Validate) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Schemas: map[string]Schema{},
+			Limit:   1 << 20,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid Validate Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1 << 20
+	}
+
+	x.mux = http.NewServeMux()
+	for pattern := range x.options.Schemas {
+		register(x.mux, pattern)
+	}
+
+	return x
+}
+
+// register adds the pattern to the mux, logging - rather than panicking upon - invalid, or conflicting, patterns.
+func register(mux *http.ServeMux, pattern string) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			slog.Warn("Invalid Validate Schema Pattern Specified - Ignoring Schema", slog.String("pattern", pattern), slog.Any("error", exception))
+		}
+	}()
+
+	mux.Handle(pattern, http.NotFoundHandler())
+}
+
+// query validates the request's query parameters.
+func (schema Schema) query(r *http.Request) (violations []Violation) {
+	values := r.URL.Query()
+
+	for name, rule := range schema.Query {
+		entries, ok := values[name]
+		if !(ok) || len(entries) == 0 {
+			if rule.Required {
+				violations = append(violations, Violation{Location: "query", Field: name, Message: "is required"})
+			}
+
+			continue
+		}
+
+		for _, entry := range entries {
+			if message := rule.parameter(entry); message != "" {
+				violations = append(violations, Violation{Location: "query", Field: name, Message: message})
+
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// headers validates the request's headers.
+func (schema Schema) headers(r *http.Request) (violations []Violation) {
+	for name, rule := range schema.Headers {
+		name = http.CanonicalHeaderKey(name)
+
+		value := r.Header.Get(name)
+		if value == "" {
+			if rule.Required {
+				violations = append(violations, Violation{Location: "header", Field: name, Message: "is required"})
+			}
+
+			continue
+		}
+
+		if message := rule.parameter(value); message != "" {
+			violations = append(violations, Violation{Location: "header", Field: name, Message: message})
+		}
+	}
+
+	return
+}
+
+// body validates the decoded JSON body.
+func (schema Schema) body(body any) (violations []Violation) {
+	for path, rule := range schema.Body {
+		value, ok := lookup(body, path)
+		if !(ok) {
+			if rule.Required {
+				violations = append(violations, Violation{Location: "body", Field: path, Message: "is required"})
+			}
+
+			continue
+		}
+
+		if message := rule.field(value); message != "" {
+			violations = append(violations, Violation{Location: "body", Field: path, Message: message})
+		}
+	}
+
+	return
+}
+
+// decode reads, and decodes, the request's JSON body.
+func (x *Validate) decode(w http.ResponseWriter, r *http.Request) (raw []byte, body any, status int, e error) {
+	if mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediatype != "application/json" && !(strings.HasSuffix(mediatype, "+json")) {
+		return nil, nil, http.StatusUnsupportedMediaType, errors.New("request body must be json")
+	}
+
+	if r.Body == nil {
+		return nil, nil, http.StatusBadRequest, errors.New("request body is required")
+	}
+
+	raw, e = io.ReadAll(http.MaxBytesReader(w, r.Body, x.options.Limit))
+	if e != nil {
+		if v := new(http.MaxBytesError); errors.As(e, &v) {
+			return nil, nil, http.StatusRequestEntityTooLarge, e
+		}
+
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil, http.StatusBadRequest, errors.New("request body is required")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	if e = decoder.Decode(&body); e != nil {
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("invalid json: %w", e)
+	}
+
+	if decoder.More() {
+		return nil, nil, http.StatusBadRequest, errors.New("invalid json: unexpected data after top-level value")
+	}
+
+	if _, ok := body.(map[string]any); !(ok) {
+		return nil, nil, http.StatusBadRequest, errors.New("request body must be a json object")
+	}
+
+	return raw, body, http.StatusOK, nil
+}
+
+// failure writes the validation failure's JSON response.
+func failure(w http.ResponseWriter, r *http.Request, status int, title string, violations []Violation) {
+	reject.Record(r.Context(), reject.Reason{Subsystem: "validate", Code: "validation-failed", Status: status})
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Location != violations[j].Location {
+			return violations[i].Location < violations[j].Location
+		}
+
+		return violations[i].Field < violations[j].Field
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(struct {
+		Title      string      `json:"title"`
+		Status     int         `json:"status"`
+		Violations []Violation `json:"violations,omitempty"`
+	}{Title: title, Status: status, Violations: violations})
+}
+
+// Handler validates requests matching an [Options.Schemas] pattern. Requests violating their [Schema] receive a
+// [http.StatusBadRequest] response, detailing every [Violation]; valid requests are forwarded with their decoded body stored in the
+// request context, and their body restored for downstream handlers.
+func (x *Validate) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := x.mux.Handler(r)
+
+		schema, ok := x.options.Schemas[pattern]
+		if !(ok) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := r.Context()
+
+		valuer := &Valuer{Pattern: pattern}
+
+		violations := append(schema.query(r), schema.headers(r)...)
+
+		if len(schema.Body) > 0 {
+			raw, body, status, e := x.decode(w, r)
+			if e != nil {
+				slog.DebugContext(ctx, "Invalid Request Body", slog.String("pattern", pattern), slog.String("error", e.Error()), slog.Int("status", status))
+
+				failure(w, r, status, e.Error(), nil)
+
+				return
+			}
+
+			violations = append(violations, schema.body(body)...)
+
+			valuer.Raw, valuer.Body = raw, body
+
+			r.Body = io.NopCloser(bytes.NewReader(raw))
+		}
+
+		if len(violations) > 0 {
+			slog.DebugContext(ctx, "Request Validation Failed", slog.String("pattern", pattern), slog.Int("violations", len(violations)))
+
+			failure(w, r, http.StatusBadRequest, "Validation Failed", violations)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [Validate] middleware, implementing [middleware.Configurable]. If [Validate.Settings] isn't called,
+// then the [Validate.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Validate)
+}
+
+// Value retrieves the request's validation [Valuer] from the provided context. If a nil value is returned, it can be assumed that the
+// request didn't match a schema, or that the [Validate] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Validate Valuer Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [Validate] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Validate)(nil)
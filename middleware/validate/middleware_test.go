@@ -0,0 +1,184 @@
+package validate_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/validate"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := validate.Value(r.Context()); v != nil && v.Raw != nil {
+			var user struct {
+				Name string `json:"name"`
+			}
+
+			if e := v.Decode(&user); e != nil {
+				t.Errorf("Unexpected Error While Decoding Body: %v", e)
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != string(v.Raw) {
+				t.Errorf("Body = %s\n    - Expectation = %s", body, v.Raw)
+			}
+
+			w.Header().Set("X-Name", user.Name)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(validate.New().Settings(func(o *validate.Options) {
+		o.Limit = 512
+		o.Schemas["GET /users"] = validate.Schema{
+			Query: map[string]validate.Rule{
+				"page":   {Type: validate.Integer, Minimum: validate.Bound(1), Maximum: validate.Bound(100)},
+				"sort":   {Pattern: regexp.MustCompile(`^(name|age)$`)},
+				"active": {Type: validate.Boolean},
+			},
+			Headers: map[string]validate.Rule{
+				"x-tenant": {Required: true, Maximum: validate.Bound(8)},
+			},
+		}
+		o.Schemas["POST /users"] = validate.Schema{
+			Body: map[string]validate.Rule{
+				"name":         {Required: true, Minimum: validate.Bound(1), Maximum: validate.Bound(16)},
+				"email":        {Required: true, Pattern: regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)},
+				"age":          {Type: validate.Integer, Minimum: validate.Bound(0)},
+				"score":        {Type: validate.Number, Maximum: validate.Bound(1)},
+				"admin":        {Type: validate.Boolean},
+				"tags":         {Type: validate.Array, Maximum: validate.Bound(2)},
+				"address":      {Type: validate.Object},
+				"address.city": {Required: true},
+			},
+		}
+		o.Schemas["invalid pattern {"] = validate.Schema{}
+	}).Handler(handler))
+
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		body       string
+		status     int
+		violations []validate.Violation
+		forwarded  string
+	}{
+		{name: "Unmatched", method: http.MethodGet, path: "/orders?page=abc", status: http.StatusOK},
+		{name: "Query-Valid", method: http.MethodGet, path: "/users?page=2&sort=name&active=true", headers: map[string]string{"X-Tenant": "acme"}, status: http.StatusOK},
+		{
+			name:    "Query-Invalid",
+			method:  http.MethodGet,
+			path:    "/users?page=0&sort=email&active=maybe",
+			headers: map[string]string{"X-Tenant": "acme"},
+			status:  http.StatusBadRequest,
+			violations: []validate.Violation{
+				{Location: "query", Field: "active", Message: "must be a boolean"},
+				{Location: "query", Field: "page", Message: "must be at least 1"},
+				{Location: "query", Field: "sort", Message: `must match the pattern "^(name|age)$"`},
+			},
+		},
+		{name: "Query-Type", method: http.MethodGet, path: "/users?page=two", headers: map[string]string{"X-Tenant": "acme"}, status: http.StatusBadRequest, violations: []validate.Violation{{Location: "query", Field: "page", Message: "must be an integer"}}},
+		{name: "Header-Missing", method: http.MethodGet, path: "/users", status: http.StatusBadRequest, violations: []validate.Violation{{Location: "header", Field: "X-Tenant", Message: "is required"}}},
+		{name: "Header-Length", method: http.MethodGet, path: "/users", headers: map[string]string{"X-Tenant": "organization"}, status: http.StatusBadRequest, violations: []validate.Violation{{Location: "header", Field: "X-Tenant", Message: "must be at most 8 characters"}}},
+		{
+			name:      "Body-Valid",
+			method:    http.MethodPost,
+			path:      "/users",
+			headers:   map[string]string{"Content-Type": "application/json"},
+			body:      `{"name": "Jane", "email": "jane@example.com", "age": 30, "score": 0.5, "admin": false, "tags": ["a"], "address": {"city": "Oslo"}}`,
+			status:    http.StatusOK,
+			forwarded: "Jane",
+		},
+		{
+			name:    "Body-Invalid",
+			method:  http.MethodPost,
+			path:    "/users",
+			headers: map[string]string{"Content-Type": "application/json"},
+			body:    `{"name": "", "age": 1.5, "score": 2, "admin": "yes", "tags": ["a", "b", "c"], "address": {"city": null}}`,
+			status:  http.StatusBadRequest,
+			violations: []validate.Violation{
+				{Location: "body", Field: "address.city", Message: "is required"},
+				{Location: "body", Field: "admin", Message: "must be a boolean"},
+				{Location: "body", Field: "age", Message: "must be an integer"},
+				{Location: "body", Field: "email", Message: "is required"},
+				{Location: "body", Field: "name", Message: "must be at least 1 characters"},
+				{Location: "body", Field: "score", Message: "must be at most 1"},
+				{Location: "body", Field: "tags", Message: "must be at most 2 items"},
+			},
+		},
+		{name: "Body-Type", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{"name": 1, "email": "a@b", "address": "Oslo"}`, status: http.StatusBadRequest, violations: []validate.Violation{{Location: "body", Field: "address", Message: "must be an object"}, {Location: "body", Field: "address.city", Message: "is required"}, {Location: "body", Field: "name", Message: "must be a string"}}},
+		{name: "Body-Malformed", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{"name": `, status: http.StatusBadRequest},
+		{name: "Body-Trailing", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{} {}`, status: http.StatusBadRequest},
+		{name: "Body-Array", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, body: `[]`, status: http.StatusBadRequest},
+		{name: "Body-Empty", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, status: http.StatusBadRequest},
+		{name: "Body-Media-Type", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "text/plain"}, body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "Body-Too-Large", method: http.MethodPost, path: "/users", headers: map[string]string{"Content-Type": "application/json"}, body: `{"name": "` + strings.Repeat("x", 1024) + `"}`, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, e := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Request: %v", e)
+			}
+
+			for k, v := range tt.headers {
+				request.Header.Set(k, v)
+			}
+
+			response, e := server.Client().Do(request)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.status {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, tt.status)
+			}
+
+			if v := response.Header.Get("X-Name"); v != tt.forwarded {
+				t.Errorf("X-Name = %s\n    - Expectation = %s", v, tt.forwarded)
+			}
+
+			if tt.violations == nil {
+				return
+			}
+
+			var body struct {
+				Violations []validate.Violation `json:"violations"`
+			}
+
+			if e := json.NewDecoder(response.Body).Decode(&body); e != nil {
+				t.Fatalf("Unexpected Error While Decoding Response: %v", e)
+			}
+
+			if len(body.Violations) != len(tt.violations) {
+				t.Fatalf("Violations = %+v\n    - Expectation = %+v", body.Violations, tt.violations)
+			}
+
+			for index := range tt.violations {
+				if body.Violations[index] != tt.violations[index] {
+					t.Errorf("Violation = %+v\n    - Expectation = %+v", body.Violations[index], tt.violations[index])
+				}
+			}
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		if v := validate.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Type represents a value's expected type.
+type Type string
+
+const (
+	String  Type = "string"  // String represents a string value. It's the default type.
+	Integer Type = "integer" // Integer represents an integral number.
+	Number  Type = "number"  // Number represents any number.
+	Boolean Type = "boolean" // Boolean represents a boolean - for query parameters and headers, as parsed by [strconv.ParseBool].
+	Object  Type = "object"  // Object represents a JSON object. Only applicable to body fields.
+	Array   Type = "array"   // Array represents a JSON array. Only applicable to body fields.
+)
+
+// Rule represents a value's validation rule.
+type Rule struct {
+	// Type represents the value's expected type. Defaults to [String].
+	Type Type
+
+	// Required reports whether the value must be present. A JSON null is considered absent.
+	Required bool
+
+	// Pattern optionally represents the regular expression a [String] value must match.
+	Pattern *regexp.Regexp
+
+	// Minimum optionally represents the minimum of a numeric value, the minimum length (in characters) of a [String] value, or the
+	// minimum number of an [Array] value's items. See [Bound].
+	Minimum *float64
+
+	// Maximum optionally represents the maximum of a numeric value, the maximum length (in characters) of a [String] value, or the
+	// maximum number of an [Array] value's items. See [Bound].
+	Maximum *float64
+}
+
+// Bound returns a pointer to v, for use as a [Rule.Minimum] or [Rule.Maximum].
+func Bound(v float64) *float64 {
+	return &v
+}
+
+// Schema represents a route's validation rules, keyed by query parameter, header, and JSON body field name. Nested body fields are
+// addressed by their dot-separated path (e.g. "address.city").
+type Schema struct {
+	// Query represents the query parameters' rules.
+	Query map[string]Rule
+
+	// Headers represents the headers' rules. Header names are canonicalized.
+	Headers map[string]Rule
+
+	// Body represents the JSON body fields' rules. A non-empty map requires a JSON object body.
+	Body map[string]Rule
+}
+
+// Violation represents a value's failed validation rule.
+type Violation struct {
+	// Location represents the value's location: "query", "header", or "body".
+	Location string `json:"location"`
+
+	// Field represents the query parameter, header, or body field's name.
+	Field string `json:"field"`
+
+	// Message represents the rule's failure.
+	Message string `json:"message"`
+}
+
+// bounds validates the measure against the rule's [Rule.Minimum] and [Rule.Maximum].
+func (rule Rule) bounds(measure float64, unit string) string {
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	if rule.Minimum != nil && measure < *rule.Minimum {
+		return fmt.Sprintf("must be at least %s%s", format(*rule.Minimum), unit)
+	}
+
+	if rule.Maximum != nil && measure > *rule.Maximum {
+		return fmt.Sprintf("must be at most %s%s", format(*rule.Maximum), unit)
+	}
+
+	return ""
+}
+
+// text validates a string value.
+func (rule Rule) text(value string) string {
+	if rule.Pattern != nil && !(rule.Pattern.MatchString(value)) {
+		return "must match the pattern " + strconv.Quote(rule.Pattern.String())
+	}
+
+	return rule.bounds(float64(utf8.RuneCountInString(value)), " characters")
+}
+
+// parameter validates a query parameter's, or header's, string value against the rule's type.
+func (rule Rule) parameter(value string) string {
+	switch rule.Type {
+	case Integer:
+		n, e := strconv.ParseInt(value, 10, 64)
+		if e != nil {
+			return "must be an integer"
+		}
+
+		return rule.bounds(float64(n), "")
+	case Number:
+		n, e := strconv.ParseFloat(value, 64)
+		if e != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "must be a number"
+		}
+
+		return rule.bounds(n, "")
+	case Boolean:
+		if _, e := strconv.ParseBool(value); e != nil {
+			return "must be a boolean"
+		}
+
+		return ""
+	default:
+		return rule.text(value)
+	}
+}
+
+// field validates a decoded JSON value - decoded with [json.Decoder.UseNumber] - against the rule's type.
+func (rule Rule) field(value any) string {
+	switch rule.Type {
+	case Integer, Number:
+		number, ok := value.(json.Number)
+		if !(ok) {
+			return "must be " + map[Type]string{Integer: "an integer", Number: "a number"}[rule.Type]
+		}
+
+		if rule.Type == Integer {
+			if _, e := number.Int64(); e != nil {
+				return "must be an integer"
+			}
+		}
+
+		n, e := number.Float64()
+		if e != nil {
+			return "must be a number"
+		}
+
+		return rule.bounds(n, "")
+	case Boolean:
+		if _, ok := value.(bool); !(ok) {
+			return "must be a boolean"
+		}
+
+		return ""
+	case Object:
+		if _, ok := value.(map[string]any); !(ok) {
+			return "must be an object"
+		}
+
+		return ""
+	case Array:
+		items, ok := value.([]any)
+		if !(ok) {
+			return "must be an array"
+		}
+
+		return rule.bounds(float64(len(items)), " items")
+	default:
+		text, ok := value.(string)
+		if !(ok) {
+			return "must be a string"
+		}
+
+		return rule.text(text)
+	}
+}
+
+// lookup returns the body's value at the dot-separated path, and whether it's present.
+func lookup(body any, path string) (any, bool) {
+	current := body
+
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !(ok) {
+			return nil, false
+		}
+
+		if current, ok = object[segment]; !(ok) {
+			return nil, false
+		}
+	}
+
+	return current, current != nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"sync"
 
 	"github.com/poly-gun/go-middleware"
 )
@@ -24,6 +25,15 @@ type Options struct {
 
 	// Warnings specifies whether a warning log message should be logged in the [Versioning] middleware component's [Versioning.Handler] function. Defaults to false.
 	Warnings bool
+
+	// Stack optionally returns the service's middleware chain [middleware.Manifest] - typically [middleware.Middleware.Manifest] - from
+	// which an "X-Middleware-Stack" response header is derived, identifying the chain's composition and its middleware's versions for
+	// fleet-wide audits. The manifest is evaluated once, upon the first request. Defaults to nil, omitting the header.
+	Stack func() middleware.Manifest
+
+	// Digest specifies whether the "X-Middleware-Stack" header's value is the composition's hash - see [middleware.Manifest.Digest] -
+	// rather than its short list of middleware and versions - see [middleware.Manifest.Stack]. Defaults to false.
+	Digest bool
 }
 
 type Versions struct {
@@ -46,6 +56,8 @@ func (v *Versioning) Settings(configuration ...func(o *Options)) middleware.Conf
 			API:      "",
 			Service:  "",
 			Warnings: false,
+			Stack:    nil,
+			Digest:   false,
 		}
 	}
 
@@ -62,9 +74,17 @@ func (v *Versioning) Settings(configuration ...func(o *Options)) middleware.Conf
 func (v *Versioning) Handler(next http.Handler) http.Handler {
 	v.Settings() // Ensure the options field isn't nil.
 
+	composition := sync.OnceValue(func() string {
+		return stack(v.options)
+	})
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		if value := composition(); value != "" {
+			w.Header().Set("X-Middleware-Stack", value)
+		}
+
 		if value := r.Header.Get(http.CanonicalHeaderKey("X-API-Version")); value != "" {
 			v.options.API = value
 		}
@@ -95,6 +115,20 @@ func (v *Versioning) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// stack returns the "X-Middleware-Stack" header's value, or an empty string if [Options.Stack] isn't specified.
+func stack(options *Options) string {
+	if options.Stack == nil {
+		return ""
+	}
+
+	manifest := options.Stack()
+	if options.Digest {
+		return manifest.Digest()
+	}
+
+	return manifest.Stack()
+}
+
 // New creates a new instance of the [Versioning] middleware, implementing [middleware.Configurable]. If [Versions.Settings] isn't called,
 // then the [Versions.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
 func New() middleware.Configurable[Options] {
@@ -0,0 +1,71 @@
+package waf
+
+import (
+	"regexp"
+)
+
+// Category represents a [Detection]'s classification.
+type Category string
+
+const (
+	// CategorySQLi classifies SQL-injection detections.
+	CategorySQLi Category = "sqli"
+
+	// CategoryXSS classifies cross-site-scripting detections.
+	CategoryXSS Category = "xss"
+
+	// CategoryTraversal classifies path-traversal and local-file-inclusion detections.
+	CategoryTraversal Category = "traversal"
+
+	// CategoryEncoding classifies suspicious encodings. Unlike other categories, encoding detections evaluate raw, undecoded values.
+	CategoryEncoding Category = "encoding"
+)
+
+// Severity scores, mirroring the OWASP Core Rule Set's anomaly-scoring defaults.
+const (
+	Critical = 5
+	Error    = 4
+	Warning  = 3
+	Notice   = 2
+)
+
+// Detection represents a single pattern contributing to a request's anomaly score.
+type Detection struct {
+	// ID uniquely identifies the detection (e.g. "sqli-union"). See [Exclusion.Detections].
+	ID string
+
+	// Category classifies the detection, and determines whether it evaluates raw or decoded values. See [CategoryEncoding].
+	Category Category
+
+	// Score represents the detection's contribution to the request's anomaly score. See [Critical], [Error], [Warning], and [Notice].
+	Score int
+
+	// Expression represents the detection's pattern.
+	Expression *regexp.Regexp
+}
+
+// Detections returns the curated, default set of detections. The set is intentionally small and tunable - a basic layer rather
+// than a port of the OWASP Core Rule Set.
+func Detections() []Detection {
+	return []Detection{
+		{ID: "sqli-union", Category: CategorySQLi, Score: Critical, Expression: regexp.MustCompile(`(?i)\bunion\b[\s\S]{0,40}?\bselect\b`)},
+		{ID: "sqli-tautology", Category: CategorySQLi, Score: Critical, Expression: regexp.MustCompile("(?i)['\"`]\\s*(or|and)\\s+['\"`]?\\w+['\"`]?\\s*(=|like)\\s*['\"`]?\\w+")},
+		{ID: "sqli-comment", Category: CategorySQLi, Score: Error, Expression: regexp.MustCompile(`(?i)['"]\s*(--|#|/\*)`)},
+		{ID: "sqli-stacked", Category: CategorySQLi, Score: Critical, Expression: regexp.MustCompile(`(?i);\s*(drop|delete|insert|update|alter|create|truncate|exec)\b`)},
+		{ID: "sqli-function", Category: CategorySQLi, Score: Error, Expression: regexp.MustCompile(`(?i)\b(sleep|benchmark|pg_sleep|load_file)\s*\(|\bwaitfor\s+delay\b|\binto\s+(out|dump)file\b`)},
+
+		{ID: "xss-script", Category: CategoryXSS, Score: Critical, Expression: regexp.MustCompile(`(?i)<\s*/?\s*script\b`)},
+		{ID: "xss-handler", Category: CategoryXSS, Score: Error, Expression: regexp.MustCompile(`(?i)\bon(error|load|click|mouseover|mouseenter|focus|blur|submit|animationstart|toggle)\s*=`)},
+		{ID: "xss-uri", Category: CategoryXSS, Score: Error, Expression: regexp.MustCompile(`(?i)\b(javascript|vbscript)\s*:|\bdata\s*:\s*text/html\b`)},
+		{ID: "xss-tag", Category: CategoryXSS, Score: Warning, Expression: regexp.MustCompile(`(?i)<\s*(iframe|object|embed|svg|base|meta|math)\b`)},
+
+		{ID: "traversal-dot", Category: CategoryTraversal, Score: Critical, Expression: regexp.MustCompile(`(^|[\\/])\.\.([\\/]|$)`)},
+		{ID: "traversal-file", Category: CategoryTraversal, Score: Critical, Expression: regexp.MustCompile(`(?i)/etc/(passwd|shadow|hosts)\b|/proc/self/|\b(win|boot)\.ini\b`)},
+
+		{ID: "encoding-double", Category: CategoryEncoding, Score: Warning, Expression: regexp.MustCompile(`(?i)%25[0-9a-f]{2}`)},
+		{ID: "encoding-null", Category: CategoryEncoding, Score: Critical, Expression: regexp.MustCompile(`%00|\x00`)},
+		{ID: "encoding-overlong", Category: CategoryEncoding, Score: Critical, Expression: regexp.MustCompile(`(?i)%c0%(ae|af|80)|%c1%(1c|9c)|%e0%80%ae`)},
+		{ID: "encoding-unicode", Category: CategoryEncoding, Score: Warning, Expression: regexp.MustCompile(`(?i)%u[0-9a-f]{4}`)},
+		{ID: "encoding-invalid", Category: CategoryEncoding, Score: Notice, Expression: regexp.MustCompile(`%([^0-9a-fA-F]|[0-9a-fA-F]([^0-9a-fA-F]|$)|$)`)},
+	}
+}
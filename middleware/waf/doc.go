@@ -0,0 +1,10 @@
+// Package waf provides a basic web-application-firewall middleware, inspired by the OWASP Core Rule Set's anomaly-scoring model.
+//
+// The middleware evaluates a curated set of [Detections] - SQL injection and cross-site-scripting patterns, path traversal, and
+// suspicious encodings - against the request's path, query, and body (up to a size cap). Every matched detection contributes its
+// score to the request's anomaly score; requests meeting the configured threshold are blocked, or - in report-only mode - only
+// logged.
+//
+// The package is explicitly scoped as a basic, tunable layer rather than a full Core Rule Set port: detections can be replaced or
+// extended, thresholds adjusted, and false positives excluded per route. New deployments should start in report-only mode.
+package waf
@@ -0,0 +1,59 @@
+package waf_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/waf"
+)
+
+func Example() {
+	middleware := middleware.New()
+
+	middleware.Add(waf.New().Settings(func(o *waf.Options) {
+		o.Level = nil
+	}).Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Score: %d\n", waf.Value(r.Context()).Score)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, query := range []string{"go middleware", "1 UNION SELECT password FROM users"} {
+		request, e := http.NewRequest(http.MethodGet, server.URL+"/search?q="+url.QueryEscape(query), nil)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Score: 0
+	// Status: 200
+	// Status: 403
+}
@@ -0,0 +1,356 @@
+package waf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "waf"
+
+// Match represents a [Detection] matched by a request.
+type Match struct {
+	// ID represents the matched [Detection.ID].
+	ID string `json:"id"`
+
+	// Category represents the matched [Detection.Category].
+	Category Category `json:"category"`
+
+	// Location represents where the detection matched: "path", "query", or "body".
+	Location string `json:"location"`
+}
+
+// Valuer is the context return type relating to the [WAF] middleware. See the [Value] function for additional details.
+type Valuer struct {
+	// Score represents the request's anomaly score - the sum of every matched detection's [Detection.Score].
+	Score int `json:"score"`
+
+	// Matches represents the request's matched detections, each reported once.
+	Matches []Match `json:"matches,omitempty"`
+
+	// Anomalous reports whether the request's score met [Options.Threshold]. In report-only mode, anomalous requests aren't blocked.
+	Anomalous bool `json:"anomalous"`
+}
+
+// Exclusion disables detections for a route - typically to tune false positives (e.g. a CMS endpoint accepting HTML).
+type Exclusion struct {
+	// Route represents the route the exclusion applies to, as returned by [Options.Route]. A trailing "*" matches any route
+	// sharing the preceding prefix (e.g. "/api/cms/*").
+	Route string
+
+	// Detections represents the excluded [Detection.ID] values. An empty slice excludes every detection, skipping inspection.
+	Detections []string
+}
+
+// Options represents the configuration settings for the [WAF] middleware component.
+type Options struct {
+	// Detections represents the detections evaluated against every request. Defaults to [Detections].
+	Detections []Detection
+
+	// Threshold represents the inbound anomaly score at, or above which, a request is considered anomalous. Defaults to 5 - a
+	// single critical detection.
+	Threshold int
+
+	// Limit represents the maximum number of request body bytes inspected; bytes beyond the limit are forwarded uninspected. Only
+	// form, JSON, XML, and text bodies are inspected. A value of zero disables body inspection. Defaults to 64 KiB.
+	Limit int64
+
+	// Report enables report-only mode, where anomalous requests are logged and recorded in the request's [Valuer], but not
+	// blocked. Defaults to false.
+	Report bool
+
+	// Route returns the request's route, evaluated against [Options.Exclusions]. Defaults to a function returning the request's
+	// URL path.
+	Route func(r *http.Request) string
+
+	// Exclusions represents per-route detection exclusions. Defaults to an empty slice.
+	Exclusions []Exclusion
+
+	// Status represents the response status code written for blocked requests. Defaults to [http.StatusForbidden].
+	Status int
+
+	// Message represents the plain-text response body written for blocked requests.
+	Message string
+
+	// Level specifies the log level used to record requests with a non-zero anomaly score. A value of nil disables logging.
+	// Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+}
+
+// WAF represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type WAF struct {
+	middleware.Configurable[Options]
+
+	options *Options
+}
+
+// Settings applies configuration functions to modify the [WAF] middleware's [Options] and returns the updated middleware instance.
+func (w *WAF) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if w.options == nil {
+		w.options = &Options{
+			Detections: Detections(),
+			Threshold:  Critical,
+			Limit:      64 * 1024,
+			Report:     false,
+			Route: func(r *http.Request) string {
+				return r.URL.Path
+			},
+			Exclusions: []Exclusion{},
+			Status:     http.StatusForbidden,
+			Message:    "Forbidden",
+			Level:      slog.LevelWarn,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(w.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if w.options.Threshold <= 0 {
+		slog.Warn("Invalid WAF Threshold Specified - Using Default Threshold")
+
+		w.options.Threshold = Critical
+	}
+
+	if w.options.Limit < 0 {
+		slog.Warn("Invalid WAF Limit Specified - Using Default Limit")
+
+		w.options.Limit = 64 * 1024
+	}
+
+	if w.options.Status < 400 || w.options.Status > 599 {
+		slog.Warn("Invalid WAF Status Specified - Using Default Status")
+
+		w.options.Status = http.StatusForbidden
+	}
+
+	return w
+}
+
+// target represents an inspected request component.
+type target struct {
+	location string
+	raw      string   // raw represents the undecoded value, evaluated by encoding detections.
+	decoded  []string // decoded represents the decoded value(s), evaluated by all other detections.
+}
+
+// body restores a partially consumed request body.
+type body struct {
+	io.Reader
+	io.Closer
+}
+
+// inspectable reports whether the content type's body is textual, and whether it's form-encoded.
+func inspectable(content string) (textual, form bool) {
+	mediatype, _, e := mime.ParseMediaType(content)
+	if e != nil {
+		return false, false
+	}
+
+	switch {
+	case mediatype == "application/x-www-form-urlencoded":
+		return true, true
+	case mediatype == "application/json", mediatype == "application/xml", strings.HasSuffix(mediatype, "+json"), strings.HasSuffix(mediatype, "+xml"):
+		return true, false
+	case strings.HasPrefix(mediatype, "text/"):
+		return true, false
+	}
+
+	return false, false
+}
+
+// values returns the keys and values of a form-encoded string. Malformed pairs are retained by the parser where possible, and
+// are otherwise left to encoding detections.
+func values(raw string) []string {
+	parsed, _ := url.ParseQuery(raw)
+
+	result := make([]string, 0, len(parsed)*2)
+	for k, v := range parsed {
+		result = append(result, k)
+		result = append(result, v...)
+	}
+
+	return result
+}
+
+// targets returns the request's inspected components, buffering up to [Options.Limit] bytes of the request's body.
+func (w *WAF) targets(r *http.Request) ([]target, error) {
+	targets := []target{{location: "path", raw: r.URL.EscapedPath(), decoded: []string{r.URL.Path}}}
+
+	if r.URL.RawQuery != "" {
+		targets = append(targets, target{location: "query", raw: r.URL.RawQuery, decoded: values(r.URL.RawQuery)})
+	}
+
+	textual, form := inspectable(r.Header.Get("Content-Type"))
+	if w.options.Limit == 0 || !(textual) || r.Body == nil || r.Body == http.NoBody {
+		return targets, nil
+	}
+
+	prefix, e := io.ReadAll(io.LimitReader(r.Body, w.options.Limit))
+	r.Body = body{Reader: io.MultiReader(bytes.NewReader(prefix), r.Body), Closer: r.Body}
+	if e != nil {
+		return nil, e
+	}
+
+	content := string(prefix)
+	if form {
+		targets = append(targets, target{location: "body", raw: content, decoded: values(content)})
+	} else {
+		targets = append(targets, target{location: "body", raw: content, decoded: []string{content}})
+	}
+
+	return targets, nil
+}
+
+// excluded returns the request's excluded detection IDs; all reports whether every detection is excluded.
+func (w *WAF) excluded(r *http.Request) (ids map[string]struct{}, all bool) {
+	if len(w.options.Exclusions) == 0 {
+		return nil, false
+	}
+
+	route := w.options.Route(r)
+
+	for _, exclusion := range w.options.Exclusions {
+		if v, wildcard := strings.CutSuffix(exclusion.Route, "*"); !(route == exclusion.Route || (wildcard && strings.HasPrefix(route, v))) {
+			continue
+		}
+
+		if len(exclusion.Detections) == 0 {
+			return nil, true
+		}
+
+		if ids == nil {
+			ids = make(map[string]struct{})
+		}
+
+		for _, id := range exclusion.Detections {
+			ids[id] = struct{}{}
+		}
+	}
+
+	return ids, false
+}
+
+// evaluate scores the request's targets against the configured detections, excluding the provided detection IDs.
+func (w *WAF) evaluate(targets []target, excluded map[string]struct{}) *Valuer {
+	valuer := &Valuer{}
+
+	for _, detection := range w.options.Detections {
+		if _, skip := excluded[detection.ID]; skip || detection.Expression == nil {
+			continue
+		}
+
+	inspection:
+		for _, t := range targets {
+			inputs := t.decoded
+			if detection.Category == CategoryEncoding {
+				inputs = []string{t.raw}
+			}
+
+			for _, input := range inputs {
+				if detection.Expression.MatchString(input) {
+					valuer.Score += detection.Score
+					valuer.Matches = append(valuer.Matches, Match{ID: detection.ID, Category: detection.Category, Location: t.location})
+
+					break inspection
+				}
+			}
+		}
+	}
+
+	valuer.Anomalous = valuer.Score >= w.options.Threshold
+
+	return valuer
+}
+
+// Handler inspects the request's path, query, and body against the configured detections, blocking requests whose anomaly score
+// meets the [Options.Threshold] unless report-only mode is enabled. It stores the request's [Valuer] in the request context.
+func (w *WAF) Handler(next http.Handler) http.Handler {
+	w.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		excluded, all := w.excluded(r)
+		if all {
+			next.ServeHTTP(writer, r.WithContext(context.WithValue(ctx, key, &Valuer{})))
+
+			return
+		}
+
+		targets, e := w.targets(r)
+		if e != nil {
+			slog.WarnContext(ctx, "Unable to Read Request Body for Inspection", slog.String("error", e.Error()))
+
+			reject.Record(ctx, reject.Reason{Subsystem: "waf", Code: "body-unreadable", Status: http.StatusBadRequest})
+
+			http.Error(writer, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+			return
+		}
+
+		valuer := w.evaluate(targets, excluded)
+
+		ids := make([]string, 0, len(valuer.Matches))
+		for _, match := range valuer.Matches {
+			ids = append(ids, match.ID)
+		}
+
+		if v := w.options.Level; v != nil && valuer.Score > 0 {
+			slog.Log(ctx, v.Level(), "WAF Anomaly Detected", slog.Int("score", valuer.Score), slog.Int("threshold", w.options.Threshold), slog.Any("detections", ids), slog.Bool("anomalous", valuer.Anomalous), slog.Bool("report", w.options.Report), slog.String("url", r.URL.String()))
+		}
+
+		if valuer.Anomalous && !(w.options.Report) {
+			reject.Record(ctx, reject.Reason{Subsystem: "waf", Code: "anomaly-threshold", Rule: strings.Join(ids, ","), Status: w.options.Status})
+
+			http.Error(writer, w.options.Message, w.options.Status)
+
+			return
+		}
+
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(ctx, key, valuer)))
+	})
+}
+
+// New creates a new instance of the [WAF] middleware, implementing [middleware.Configurable]. If [WAF.Settings] isn't called,
+// then the [WAF.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(WAF)
+}
+
+// Value retrieves the request's inspection result from the provided context. If a nil value is returned, it can be assumed that
+// the [WAF] middleware isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Valuer) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*Valuer); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*Valuer); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [WAF] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*WAF)(nil)
@@ -0,0 +1,144 @@
+package waf_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/waf"
+)
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := waf.Value(r.Context())
+
+		body, _ := io.ReadAll(r.Body)
+
+		ids := make([]string, 0, len(v.Matches))
+		for _, match := range v.Matches {
+			ids = append(ids, match.ID+"@"+match.Location)
+		}
+
+		w.Header().Set("X-Score", strconv.Itoa(v.Score))
+		w.Header().Set("X-Matches", strings.Join(ids, ","))
+		w.Header().Set("X-Body", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		options func(o *waf.Options)
+		method  string
+		target  string
+		content string
+		body    string
+		status  int
+		score   int
+		matches string
+	}{
+		{name: "Benign", target: "/search?q=hello+world", status: http.StatusOK},
+		{name: "Benign-Apostrophe", target: "/search?q=o'reilly", status: http.StatusOK},
+		{name: "SQLi-Union", target: "/search?q=1+UNION+ALL+SELECT+password+FROM+users", status: http.StatusForbidden},
+		{name: "SQLi-Tautology", target: "/login?user=admin'+OR+'1'='1", status: http.StatusForbidden},
+		{name: "XSS-Script", target: "/search?q=%3Cscript%3Ealert(1)%3C/script%3E", status: http.StatusForbidden},
+		{name: "XSS-Key", target: "/search?%3Cscript%3E=1", status: http.StatusForbidden},
+		{name: "Traversal", target: "/files/../../etc/passwd", status: http.StatusForbidden},
+		{name: "Encoding-Null", target: "/files/report.pdf%00.txt", status: http.StatusForbidden},
+		{name: "Encoding-Double", target: "/files?name=%252e%252e", status: http.StatusOK, score: waf.Warning, matches: "encoding-double@query"},
+		{name: "Below-Threshold", target: "/search?q=%3Csvg%3E", status: http.StatusOK, score: waf.Warning, matches: "xss-tag@query"},
+		{name: "Threshold", options: func(o *waf.Options) { o.Threshold = waf.Warning }, target: "/search?q=%3Csvg%3E", status: http.StatusForbidden},
+		{name: "Body-Form", method: http.MethodPost, target: "/comments", content: "application/x-www-form-urlencoded", body: "comment=%3Cscript%3Ealert(1)%3C%2Fscript%3E", status: http.StatusForbidden},
+		{name: "Body-JSON", method: http.MethodPost, target: "/comments", content: "application/json; charset=utf-8", body: `{"q": "1; DROP TABLE users"}`, status: http.StatusForbidden},
+		{name: "Body-Binary", method: http.MethodPost, target: "/upload", content: "application/octet-stream", body: "<script>", status: http.StatusOK, matches: ""},
+		{name: "Body-Preserved", method: http.MethodPost, target: "/comments", content: "text/plain", body: "hello", status: http.StatusOK},
+		{name: "Body-Limit", options: func(o *waf.Options) { o.Limit = 8 }, method: http.MethodPost, target: "/comments", content: "text/plain", body: "padding-<script>", status: http.StatusOK},
+		{name: "Body-Disabled", options: func(o *waf.Options) { o.Limit = 0 }, method: http.MethodPost, target: "/comments", content: "text/plain", body: "<script>", status: http.StatusOK},
+		{name: "Report-Only", options: func(o *waf.Options) { o.Report = true }, target: "/files/../../etc/passwd", status: http.StatusOK, score: 2 * waf.Critical, matches: "traversal-dot@path,traversal-file@path"},
+		{name: "Exclusion-Detection", options: func(o *waf.Options) {
+			o.Exclusions = []waf.Exclusion{{Route: "/cms/*", Detections: []string{"xss-script"}}}
+		}, method: http.MethodPost, target: "/cms/pages", content: "text/html", body: "<script src=/app.js></script>", status: http.StatusOK},
+		{name: "Exclusion-Detection-Other", options: func(o *waf.Options) {
+			o.Exclusions = []waf.Exclusion{{Route: "/cms/*", Detections: []string{"xss-script"}}}
+		}, method: http.MethodPost, target: "/cms/pages", content: "text/html", body: "' UNION SELECT 1", status: http.StatusForbidden},
+		{name: "Exclusion-Route", options: func(o *waf.Options) {
+			o.Exclusions = []waf.Exclusion{{Route: "/cms/pages"}}
+		}, target: "/cms/pages?q=%3Cscript%3E", status: http.StatusOK},
+		{name: "Exclusion-Route-Unmatched", options: func(o *waf.Options) {
+			o.Exclusions = []waf.Exclusion{{Route: "/cms/pages"}}
+		}, target: "/cms/pages/1?q=%3Cscript%3E", status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := waf.New().Settings(func(o *waf.Options) { o.Level = nil }, test.options).Handler(handler)
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			request := httptest.NewRequest(method, test.target, strings.NewReader(test.body))
+			if test.content != "" {
+				request.Header.Set("Content-Type", test.content)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if test.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("X-Score"); v != strconv.Itoa(test.score) {
+				t.Errorf("Score = %s\n    - Expectation = %d", v, test.score)
+			}
+
+			if v := recorder.Header().Get("X-Matches"); test.matches != "" && v != test.matches {
+				t.Errorf("Matches = %s\n    - Expectation = %s", v, test.matches)
+			}
+
+			if v := recorder.Header().Get("X-Body"); v != test.body {
+				t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+			}
+		})
+	}
+
+	t.Run("Detections", func(t *testing.T) {
+		seen := make(map[string]struct{})
+		for _, detection := range waf.Detections() {
+			if _, duplicate := seen[detection.ID]; duplicate {
+				t.Errorf("Duplicate Detection ID: %s", detection.ID)
+			}
+
+			seen[detection.ID] = struct{}{}
+
+			if detection.Expression == nil || detection.Score <= 0 {
+				t.Errorf("Invalid Detection: %s", detection.ID)
+			}
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := waf.Value(context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &waf.Valuer{Score: waf.Critical, Anomalous: true}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := waf.Value(ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+// Package xmlbody provides an XML request-decoding middleware for SOAP and legacy integrations hosted behind the same chain as
+// modern endpoints.
+//
+// The middleware decodes request bodies into a caller-provided type, storing the typed document in the request context (see
+// [Value]). Documents are protected against:
+//
+//   - Oversized bodies, via a configurable size limit.
+//   - Entity expansion ("billion laughs") and external entities (XXE), by rejecting any document type declaration (DTD). The
+//     underlying decoder never resolves external resources, and only recognizes XML's predefined entities.
+//   - Deeply nested documents, via a configurable maximum depth.
+//
+// An optional validation hook enforces schema constraints against the decoded document.
+package xmlbody
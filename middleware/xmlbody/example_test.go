@@ -0,0 +1,68 @@
+package xmlbody_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+	"github.com/poly-gun/go-middleware/middleware/xmlbody"
+)
+
+func Example() {
+	type Greeting struct {
+		XMLName xml.Name `xml:"greeting"`
+		Name    string   `xml:"name"`
+	}
+
+	middleware := middleware.New()
+
+	middleware.Add(xmlbody.New[Greeting]().Handler)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Name: %s\n", xmlbody.Value[Greeting](r.Context()).Name)
+
+		w.WriteHeader(http.StatusOK)
+		return
+	})
+
+	server := httptest.NewServer(middleware.Handler(mux))
+
+	defer server.Close()
+
+	client := server.Client()
+
+	for _, body := range []string{
+		`<greeting><name>World</name></greeting>`,
+		`<!DOCTYPE greeting [<!ENTITY xxe SYSTEM "file:///etc/passwd">]><greeting><name>&xxe;</name></greeting>`,
+	} {
+		request, e := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating request: %w", e)
+
+			panic(e)
+		}
+
+		request.Header.Set("Content-Type", "application/xml")
+
+		response, e := client.Do(request)
+		if e != nil {
+			e = fmt.Errorf("unexpected error while generating response: %w", e)
+
+			panic(e)
+		}
+
+		response.Body.Close()
+
+		fmt.Printf("Status: %d\n", response.StatusCode)
+	}
+
+	// Output:
+	// Name: World
+	// Status: 200
+	// Status: 400
+}
@@ -0,0 +1,238 @@
+package xmlbody
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "xmlbody"
+
+// ErrDoctype is returned for documents containing a document type declaration (DTD). DTDs are the vehicle for entity-expansion
+// ("billion laughs") and external-entity (XXE) attacks, and are always rejected.
+var ErrDoctype = errors.New("document type declarations are prohibited")
+
+// ErrDepth is returned for documents exceeding [Options.Depth].
+var ErrDepth = errors.New("maximum element depth exceeded")
+
+// Options represents the configuration settings for the [XML] middleware component.
+type Options[T any] struct {
+	// Limit represents the maximum request body size, in bytes. Larger bodies receive a [http.StatusRequestEntityTooLarge]
+	// response. Defaults to 1 MiB.
+	Limit int64
+
+	// Depth represents the maximum element nesting depth. Deeper documents receive a [http.StatusBadRequest] response. Defaults to 64.
+	Depth int
+
+	// Types represents the accepted media types; media types with a "+xml" suffix are always accepted. Other requests receive a
+	// [http.StatusUnsupportedMediaType] response. Defaults to "application/xml" and "text/xml".
+	Types []string
+
+	// Validate represents an optional schema-validation hook, evaluated against the decoded document. A non-nil error results in
+	// a [http.StatusUnprocessableEntity] response. Defaults to nil.
+	Validate func(ctx context.Context, document *T) error
+}
+
+// XML represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type XML[T any] struct {
+	middleware.Configurable[Options[T]]
+
+	options *Options[T]
+}
+
+// Settings applies configuration functions to modify the [XML] middleware's [Options] and returns the updated middleware instance.
+func (x *XML[T]) Settings(configuration ...func(o *Options[T])) middleware.Configurable[Options[T]] {
+	if x.options == nil {
+		x.options = &Options[T]{
+			Limit:    1 << 20,
+			Depth:    64,
+			Types:    []string{"application/xml", "text/xml"},
+			Validate: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Limit <= 0 {
+		slog.Warn("Invalid XML Limit Specified - Using Default Limit")
+
+		x.options.Limit = 1 << 20
+	}
+
+	if x.options.Depth <= 0 {
+		slog.Warn("Invalid XML Depth Specified - Using Default Depth")
+
+		x.options.Depth = 64
+	}
+
+	return x
+}
+
+// accepted reports whether the request's Content-Type header is an accepted XML media type.
+func (x *XML[T]) accepted(r *http.Request) bool {
+	mediatype, _, e := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if e != nil {
+		return false
+	}
+
+	if strings.HasSuffix(mediatype, "+xml") {
+		return true
+	}
+
+	for _, v := range x.options.Types {
+		if strings.EqualFold(v, mediatype) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decoder returns a strict decoder without custom entities.
+func decoder(data []byte) *xml.Decoder {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+	d.Entity = nil
+
+	return d
+}
+
+// inspect scans the document's tokens, rejecting document type declarations and documents exceeding the maximum depth.
+func inspect(data []byte, maximum int) error {
+	d := decoder(data)
+
+	depth := 0
+	for {
+		token, e := d.RawToken()
+		if errors.Is(e, io.EOF) {
+			return nil
+		} else if e != nil {
+			return e
+		}
+
+		switch v := token.(type) {
+		case xml.Directive:
+			if directive := strings.TrimSpace(string(v)); len(directive) >= 7 && strings.EqualFold(directive[:7], "DOCTYPE") {
+				return ErrDoctype
+			}
+		case xml.StartElement:
+			if depth++; depth > maximum {
+				return ErrDepth
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// decode reads, inspects, and decodes the request's XML body.
+func (x *XML[T]) decode(w http.ResponseWriter, r *http.Request) (data []byte, document *T, status int, e error) {
+	data, e = io.ReadAll(http.MaxBytesReader(w, r.Body, x.options.Limit))
+	if e != nil {
+		if v := new(http.MaxBytesError); errors.As(e, &v) {
+			return nil, nil, http.StatusRequestEntityTooLarge, e
+		}
+
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	if e = inspect(data, x.options.Depth); e != nil {
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	document = new(T)
+	if e = decoder(data).Decode(document); e != nil {
+		return nil, nil, http.StatusBadRequest, e
+	}
+
+	if x.options.Validate != nil {
+		if e = x.options.Validate(r.Context(), document); e != nil {
+			return nil, nil, http.StatusUnprocessableEntity, e
+		}
+	}
+
+	return data, document, http.StatusOK, nil
+}
+
+// Handler decodes the request's XML body into a T - guarding against oversized documents, entity expansion, and external
+// entities - and stores the decoded document in the request context. The request's body remains readable by downstream handlers.
+// Requests without a body are forwarded without a stored document.
+func (x *XML[T]) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if !(x.accepted(r)) {
+			http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+
+			return
+		}
+
+		data, document, status, e := x.decode(w, r)
+		if e != nil {
+			slog.WarnContext(ctx, "Unable to Decode XML Request Body", slog.String("error", e.Error()), slog.Int("status", status))
+
+			http.Error(w, http.StatusText(status), status)
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, key, document)))
+	})
+}
+
+// New creates a new instance of the [XML] middleware, decoding request bodies into a T, implementing [middleware.Configurable].
+// If [XML.Settings] isn't called, then the [XML.Handler] function will hydrate the middleware's configuration with sane
+// default(s) if applicable.
+func New[T any]() middleware.Configurable[Options[T]] {
+	return new(XML[T])
+}
+
+// Value retrieves the request's decoded document from the provided context. The type parameter must match the [New] function's.
+// If a nil value is returned, it can be assumed that the [XML] middleware isn't enabled for the particular caller's chain, or
+// that the request didn't include a body.
+func Value[T any](ctx context.Context) (value *T) {
+	const t = "x-testing-key" // t represents a context key for unit-testing.
+
+	if v, ok := ctx.Value(key).(*T); ok {
+		value = v
+	} else if test, valid := ctx.Value(t).(*T); valid {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Received Unit-Testing Context", slog.String("key", t))
+
+		value = test
+	} else {
+		slog.WarnContext(ctx, "Unable to Typecast Context Key Value", slog.String("error", "Bad-Context-Evaluation"), slog.String("key", string(key)), slog.Any("value", ctx.Value(key)))
+	}
+
+	return
+}
+
+// Runtime assurance that [XML] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options[struct{}]] = (*XML[struct{}])(nil)
@@ -0,0 +1,125 @@
+package xmlbody_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middleware/xmlbody"
+)
+
+type Order struct {
+	XMLName xml.Name `xml:"order"`
+	ID      string   `xml:"id,attr"`
+	Items   []string `xml:"item"`
+}
+
+func Test(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if v := xmlbody.Value[Order](r.Context()); v != nil {
+			w.Header().Set("X-Order", v.ID+":"+strings.Join(v.Items, ","))
+		}
+
+		w.Header().Set("X-Body-Length", strconv.Itoa(len(body)))
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		options func(o *xmlbody.Options[Order])
+		content string
+		body    string
+		status  int
+		order   string
+	}{
+		{name: "Valid", content: "application/xml", body: `<?xml version="1.0"?><order id="1"><item>a</item><item>b</item></order>`, status: http.StatusOK, order: "1:a,b"},
+		{name: "Valid-Text", content: "text/xml; charset=utf-8", body: `<order id="2"><item>a</item></order>`, status: http.StatusOK, order: "2:a"},
+		{name: "Valid-Suffix", content: "application/soap+xml", body: `<order id="3"/>`, status: http.StatusOK, order: "3:"},
+		{name: "Predefined-Entity", content: "application/xml", body: `<order id="4"><item>a &amp; b</item></order>`, status: http.StatusOK, order: "4:a & b"},
+		{name: "Unsupported-Media-Type", content: "application/json", body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "Malformed", content: "application/xml", body: `<order id="5">`, status: http.StatusBadRequest},
+		{name: "Undefined-Entity", content: "application/xml", body: `<order id="6"><item>&custom;</item></order>`, status: http.StatusBadRequest},
+		{name: "Billion-Laughs", content: "application/xml", body: `<?xml version="1.0"?><!DOCTYPE lolz [<!ENTITY lol "lol"><!ENTITY lol2 "&lol;&lol;&lol;&lol;">]><order id="7"><item>&lol2;</item></order>`, status: http.StatusBadRequest},
+		{name: "External-Entity", content: "application/xml", body: `<?xml version="1.0"?><!DOCTYPE order [<!ENTITY xxe SYSTEM "file:///etc/passwd">]><order id="8"><item>&xxe;</item></order>`, status: http.StatusBadRequest},
+		{name: "Doctype-Lowercase", content: "application/xml", body: `<!doctype order><order id="9"/>`, status: http.StatusBadRequest},
+		{name: "Depth", options: func(o *xmlbody.Options[Order]) { o.Depth = 2 }, content: "application/xml", body: `<order id="10"><item><nested/></item></order>`, status: http.StatusBadRequest},
+		{name: "Limit", options: func(o *xmlbody.Options[Order]) { o.Limit = 16 }, content: "application/xml", body: `<order id="11"><item>a</item></order>`, status: http.StatusRequestEntityTooLarge},
+		{name: "Validation", options: func(o *xmlbody.Options[Order]) {
+			o.Validate = func(ctx context.Context, document *Order) error {
+				if len(document.Items) == 0 {
+					return errors.New("order requires at least one item")
+				}
+
+				return nil
+			}
+		}, content: "application/xml", body: `<order id="12"/>`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := xmlbody.New[Order]().Settings(test.options).Handler(handler)
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			request.Header.Set("Content-Type", test.content)
+
+			recorder := httptest.NewRecorder()
+
+			m.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+			}
+
+			if test.status != http.StatusOK {
+				return
+			}
+
+			if v := recorder.Header().Get("X-Order"); v != test.order {
+				t.Errorf("Order = %s\n    - Expectation = %s", v, test.order)
+			}
+
+			if v := recorder.Header().Get("X-Body-Length"); v != strconv.Itoa(len(test.body)) {
+				t.Errorf("Body Length = %s\n    - Expectation = %d", v, len(test.body))
+			}
+		})
+	}
+
+	t.Run("Empty-Body", func(t *testing.T) {
+		m := xmlbody.New[Order]().Handler(handler)
+
+		recorder := httptest.NewRecorder()
+
+		m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, http.StatusOK)
+		}
+
+		if v := recorder.Header().Get("X-Order"); v != "" {
+			t.Errorf("Unexpected Order: %s", v)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := xmlbody.Value[Order](context.Background()); v != nil {
+			t.Errorf("Unexpected Non-Nil Context Value: %v", v)
+		}
+
+		expectation := &Order{ID: "test"}
+
+		ctx := context.WithValue(context.Background(), "x-testing-key", expectation)
+		if v := xmlbody.Value[Order](ctx); !(reflect.DeepEqual(v, expectation)) {
+			t.Errorf("Invalid Context Value: %v", v)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Command middlewarecheck reports raw string context keys matching the middleware packages' key names. It's invoked either by
+// go vet (go vet -vettool=$(which middlewarecheck) ./...), or directly with file and directory arguments. See the
+// middlewarecheck package for additional details.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/poly-gun/go-middleware/middlewarecheck"
+)
+
+// configuration represents the subset of go vet's per-package unit configuration file the command requires.
+type configuration struct {
+	ImportPath string
+	GoFiles    []string
+	VetxOnly   bool
+	VetxOutput string
+}
+
+// version prints the tool's version, as go vet requires for caching purposes.
+func version() {
+	var digest []byte
+
+	if path, e := os.Executable(); e == nil {
+		if file, e := os.Open(path); e == nil {
+			hash := sha256.New()
+			io.Copy(hash, file)
+			file.Close()
+
+			digest = hash.Sum(nil)
+		}
+	}
+
+	fmt.Printf("%s version devel buildID=%02x\n", filepath.Base(os.Args[0]), digest)
+}
+
+// report parses and inspects the files, printing diagnostics to standard error, and returns the number of diagnostics.
+func report(files []string) (int, error) {
+	set := token.NewFileSet()
+
+	parsed := make([]*ast.File, 0, len(files))
+	for _, path := range files {
+		file, e := parser.ParseFile(set, path, nil, parser.SkipObjectResolution)
+		if e != nil {
+			return 0, e
+		}
+
+		parsed = append(parsed, file)
+	}
+
+	diagnostics := middlewarecheck.Inspect(parsed...)
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", set.Position(diagnostic.Pos), diagnostic.Message)
+	}
+
+	return len(diagnostics), nil
+}
+
+// unit inspects the package described by go vet's unit configuration file.
+func unit(path string) (int, error) {
+	buffer, e := os.ReadFile(path)
+	if e != nil {
+		return 0, e
+	}
+
+	var cfg configuration
+	if e := json.Unmarshal(buffer, &cfg); e != nil {
+		return 0, fmt.Errorf("unable to decode %s: %w", path, e)
+	}
+
+	// The analyzer doesn't export facts; go vet nonetheless expects the output file.
+	if cfg.VetxOutput != "" {
+		if e := os.WriteFile(cfg.VetxOutput, nil, 0o666); e != nil {
+			return 0, e
+		}
+	}
+
+	if cfg.VetxOnly {
+		return 0, nil
+	}
+
+	return report(cfg.GoFiles)
+}
+
+// expand returns the Go files of the provided file, directory, and recursive ("./...") arguments.
+func expand(arguments []string) ([]string, error) {
+	var files []string
+
+	for _, argument := range arguments {
+		root, recursive := strings.CutSuffix(argument, "...")
+		if recursive {
+			root = filepath.Clean(root)
+		}
+
+		info, e := os.Stat(root)
+		if e != nil {
+			return nil, e
+		}
+
+		if !(info.IsDir()) {
+			files = append(files, root)
+
+			continue
+		}
+
+		e = filepath.WalkDir(root, func(path string, entry fs.DirEntry, e error) error {
+			if e != nil {
+				return e
+			}
+
+			if entry.IsDir() {
+				if path != root && (!(recursive) || entry.Name() == "testdata" || strings.HasPrefix(entry.Name(), ".")) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if strings.HasSuffix(path, ".go") {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	return files, nil
+}
+
+func main() {
+	arguments := os.Args[1:]
+
+	switch {
+	case len(arguments) == 1 && arguments[0] == "-V=full":
+		version()
+
+		return
+	case len(arguments) == 1 && arguments[0] == "-flags":
+		fmt.Println("[]")
+
+		return
+	case len(arguments) == 0:
+		fmt.Fprintf(os.Stderr, "usage: %s [file|directory|./...]...\n", filepath.Base(os.Args[0]))
+
+		os.Exit(2)
+	}
+
+	var count int
+	var e error
+
+	if last := arguments[len(arguments)-1]; strings.HasSuffix(last, ".cfg") {
+		count, e = unit(last)
+	} else {
+		var files []string
+		if files, e = expand(arguments); e == nil {
+			count, e = report(files)
+		}
+	}
+
+	if e != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), e)
+
+		os.Exit(1)
+	}
+
+	if count > 0 {
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,15 @@
+// Package middlewarecheck provides a static analyzer flagging raw string context keys matching the middleware packages' key
+// names - e.g. ctx.Value("telemetrics"), or the deprecated "x-testing-key" - in user code. Such usages never observe the
+// middleware's values (keys are unexported, typed constants), and rely on the unit-testing fallback slated for removal;
+// each diagnostic names the typed accessor to migrate to (e.g. telemetrics.Value).
+//
+// The analyzer is distributed as a vet-compatible tool:
+//
+//	go install github.com/poly-gun/go-middleware/middlewarecheck/cmd/middlewarecheck@latest
+//	go vet -vettool=$(which middlewarecheck) ./...
+//
+// The command additionally accepts file and directory arguments - including recursive "./..." patterns - when invoked directly.
+//
+// The analyzer is syntactic: it reports string literal keys passed to a Value method (e.g. [context.Context.Value]), or as the
+// key argument of a WithValue function (e.g. [context.WithValue]).
+package middlewarecheck
@@ -0,0 +1,185 @@
+package middlewarecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// Testing represents the deprecated, unit-testing context key each middleware package's accessor falls back to.
+const Testing = "x-testing-key"
+
+// Keys maps the middleware packages' context key names - every unexported keyer constant in this repository - to their typed
+// accessor.
+var Keys = map[string]string{
+	"affinity":              "affinity.Value",
+	"after":                 "after.Defer",
+	"authentication":        "authentication.Value",
+	"authentication-edge":   "authentication.EdgeValue",
+	"authorization":         "authorization.Value",
+	"bandwidth":             "bandwidth.Value",
+	"body":                  "body.Value",
+	"breaker":               "breaker.Value",
+	"brownout":              "brownout.Value",
+	"budget":                "budget.Value",
+	"challenge":             "challenge.Value",
+	"cleanup":               "cleanup.Register",
+	"client":                "client.Value",
+	"compress":              "compress.Value",
+	"connection":            "listener.Conn",
+	"connstats":             "connstats.Value",
+	"consent":               "consent.Value",
+	"cookies":               "cookies.Value",
+	"cors":                  "cors.Value",
+	"cost":                  "cost.Value",
+	"deployment":            "deployment.Value",
+	"envoy":                 "envoy.Value",
+	"extauthz":              "extauthz.Options.Policy",
+	"form":                  "form.Value",
+	"hosts":                 "hosts.Value",
+	"imaging":               "imaging.Value",
+	"keepalive":             "keepalive.Value",
+	"leak":                  "leak.Go",
+	"locale":                "locale.Value",
+	"maintenance":           "maintenance.Value",
+	"memento":               "memento.Value",
+	"memo":                  "memo.Value",
+	"metering":              "metering.Value",
+	"minify":                "minify.Value",
+	"openapi":               "openapi.Value",
+	"override":              "override.Value",
+	"preferences":           "preferences.Value",
+	"privacy":               "privacy.Value",
+	"problem":               "problem.Value",
+	"propagation":           "propagation.Value",
+	"proto":                 "proto.Value",
+	"proxy":                 "proxy.Value",
+	"proxy-protocol":        "proxyproto.Value",
+	"proxy-protocol-header": "proxyproto.Proxied",
+	"proxy-rewrite":         "proxy.Rewrite",
+	"ratelimit":             "ratelimit.Value",
+	"real-ip":               "rip.Value",
+	"real-ip-resolution":    "rip.Resolution",
+	"recycle":               "recycle.Value",
+	"reject":                "reject.Value",
+	"request-id":            "requestid.Value",
+	"resumable":             "resumable.Value",
+	"route":                 "route.Value",
+	"rules":                 "rules.Value",
+	"sampling":              "sampling.Value",
+	"server-name":           "name.Value",
+	"service-name":          "service.Value",
+	"shutdown":              "middleware.Stopping",
+	"signature":             "signature.Value",
+	"sniff":                 "sniff.Value",
+	"soap":                  "soap.Value",
+	"telemetrics":           "telemetrics.Value",
+	"throttle":              "throttle.Value",
+	"timeout":               "timeout.Value",
+	"translate":             "translate.Value",
+	"txn":                   "txn.Value",
+	"upload":                "upload.Value",
+	"user-agent":            "useragent.Value",
+	"validate":              "validate.Value",
+	"versioning":            "versioning.Value",
+	"waf":                   "waf.Value",
+	"xmlbody":               "xmlbody.Value",
+}
+
+// Diagnostic represents a raw string context key usage.
+type Diagnostic struct {
+	// Pos represents the string literal's position.
+	Pos token.Pos
+
+	// Key represents the raw string context key.
+	Key string
+
+	// Message represents the diagnostic's human-readable message, including the typed accessor to migrate to.
+	Message string
+}
+
+// message returns the key's diagnostic message, and whether the key matches a middleware package's key name.
+func message(key string) (string, bool) {
+	if key == Testing {
+		return fmt.Sprintf("raw string context key %q is deprecated: use the middleware package's typed accessor (e.g. telemetrics.Value)", key), true
+	}
+
+	if accessor, found := Keys[key]; found {
+		return fmt.Sprintf("raw string context key %q never observes the middleware's value: use %s", key, accessor), true
+	}
+
+	return "", false
+}
+
+// literal returns the expression's string value, if it's a string literal.
+func literal(expression ast.Expr) (string, bool) {
+	v, ok := expression.(*ast.BasicLit)
+	if !(ok) || v.Kind != token.STRING {
+		return "", false
+	}
+
+	value, e := strconv.Unquote(v.Value)
+	if e != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// name returns the called function's (or method's) name.
+func name(call *ast.CallExpr) string {
+	switch typecast := call.Fun.(type) {
+	case *ast.Ident:
+		return typecast.Name
+	case *ast.SelectorExpr:
+		return typecast.Sel.Name
+	}
+
+	return ""
+}
+
+// Inspect returns the diagnostics of the provided files, ordered by position.
+func Inspect(files ...*ast.File) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !(ok) {
+				return true
+			}
+
+			var argument ast.Expr
+			switch name(call) {
+			case "Value":
+				if len(call.Args) == 1 {
+					argument = call.Args[0]
+				}
+			case "WithValue":
+				if len(call.Args) == 3 {
+					argument = call.Args[1]
+				}
+			}
+
+			if argument == nil {
+				return true
+			}
+
+			if key, ok := literal(argument); ok {
+				if text, matched := message(key); matched {
+					diagnostics = append(diagnostics, Diagnostic{Pos: argument.Pos(), Key: key, Message: text})
+				}
+			}
+
+			return true
+		})
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Pos < diagnostics[j].Pos
+	})
+
+	return diagnostics
+}
@@ -0,0 +1,163 @@
+package middlewarecheck_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/middlewarecheck"
+)
+
+func Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name: "Context-Value",
+			source: `package p
+func f(ctx context.Context) { _ = ctx.Value("telemetrics") }`,
+			expected: []string{"telemetrics"},
+		},
+		{
+			name: "Context-With-Value",
+			source: `package p
+func f(ctx context.Context) { _ = context.WithValue(ctx, "x-testing-key", nil) }`,
+			expected: []string{"x-testing-key"},
+		},
+		{
+			name:     "Raw-String-Literal",
+			source:   "package p\nfunc f(r *http.Request) { _ = r.Context().Value(`request-id`) }",
+			expected: []string{"request-id"},
+		},
+		{
+			name: "Multiple",
+			source: `package p
+func f(ctx context.Context) {
+	ctx = context.WithValue(ctx, "x-testing-key", nil)
+	_ = ctx.Value("real-ip")
+}`,
+			expected: []string{"x-testing-key", "real-ip"},
+		},
+		{
+			name: "Unrelated-Key",
+			source: `package p
+func f(ctx context.Context) { _ = ctx.Value("application") }`,
+			expected: nil,
+		},
+		{
+			name: "Typed-Key",
+			source: `package p
+type keyer string
+func f(ctx context.Context) { _ = ctx.Value(keyer("telemetrics")) }`,
+			expected: nil,
+		},
+		{
+			name: "Unrelated-Function",
+			source: `package p
+func f(m map[string]string) { _ = m.Get("telemetrics") }`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := token.NewFileSet()
+
+			file, e := parser.ParseFile(set, "p.go", tt.source, 0)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Parsing Source: %v", e)
+			}
+
+			diagnostics := middlewarecheck.Inspect(file)
+			if len(diagnostics) != len(tt.expected) {
+				t.Fatalf("Diagnostics = %d\n    - Expectation = %d", len(diagnostics), len(tt.expected))
+			}
+
+			for index, diagnostic := range diagnostics {
+				if diagnostic.Key != tt.expected[index] {
+					t.Errorf("Key = %s\n    - Expectation = %s", diagnostic.Key, tt.expected[index])
+				}
+
+				if diagnostic.Message == "" {
+					t.Errorf("Empty Diagnostic Message")
+				}
+			}
+		})
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		if _, found := middlewarecheck.Keys[middlewarecheck.Testing]; found {
+			t.Errorf("Testing Key Mapped to an Accessor")
+		}
+
+		for key, accessor := range middlewarecheck.Keys {
+			if accessor == "" {
+				t.Errorf("Key %s Without an Accessor", key)
+			}
+		}
+	})
+	// Every package's context key - an unexported keyer constant - must be registered, such that new packages can't be missed.
+	t.Run("Registered", func(t *testing.T) {
+		set := token.NewFileSet()
+
+		e := filepath.WalkDir("..", func(path string, entry fs.DirEntry, e error) error {
+			if e != nil {
+				return e
+			}
+
+			if entry.IsDir() && strings.HasPrefix(entry.Name(), ".") && path != ".." {
+				return filepath.SkipDir
+			}
+
+			if entry.IsDir() || !(strings.HasSuffix(path, ".go")) || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+
+			file, e := parser.ParseFile(set, path, nil, parser.SkipObjectResolution)
+			if e != nil {
+				return e
+			}
+
+			for _, declaration := range file.Decls {
+				general, ok := declaration.(*ast.GenDecl)
+				if !(ok) || general.Tok != token.CONST {
+					continue
+				}
+
+				for _, specification := range general.Specs {
+					value, ok := specification.(*ast.ValueSpec)
+					if !(ok) || len(value.Values) != 1 {
+						continue
+					}
+
+					if typename, ok := value.Type.(*ast.Ident); !(ok) || typename.Name != "keyer" {
+						continue
+					}
+
+					literal, ok := value.Values[0].(*ast.BasicLit)
+					if !(ok) || literal.Kind != token.STRING {
+						continue
+					}
+
+					key, _ := strconv.Unquote(literal.Value)
+					if _, found := middlewarecheck.Keys[key]; !(found) {
+						t.Errorf("Unregistered Key = %s\n    - Source = %s", key, set.Position(literal.Pos()))
+					}
+				}
+			}
+
+			return nil
+		})
+
+		if e != nil {
+			t.Fatalf("Unexpected Error While Walking Repository: %v", e)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 )
 
 // Configurable defines an interface for applying configurable behaviors to HTTP handlers using generic Options settings.
@@ -17,6 +18,47 @@ type Configurable[Options interface{}] interface {
 // It wraps and applies middleware to an [http.Handler] in order of addition.
 type Middleware struct {
 	middleware []func(http.Handler) http.Handler
+	entries    []Entry // entries represents the middleware's [Manifest] entries - see [Middleware.Describe].
+
+	routes []route
+}
+
+// route represents a URL path prefix and its associated middleware chain.
+type route struct {
+	prefix string
+	chain  *Middleware
+}
+
+// Route registers a middleware chain for requests whose URL path matches the prefix, and returns the route's [Middleware] for
+// further additions. Prefixes ending in "/" match the path's subtree (e.g. "/api/" matches "/api/users"); other prefixes match
+// the path exactly. When multiple routes match, the longest prefix wins.
+//
+// Route chains apply after - within - the [Middleware] instance's own chain. Calling Route with an already-registered prefix
+// appends to that route's chain.
+func (m *Middleware) Route(prefix string, middleware ...func(http.Handler) http.Handler) *Middleware {
+	for index := range m.routes {
+		if m.routes[index].prefix == prefix {
+			m.routes[index].chain.Add(middleware...)
+
+			return m.routes[index].chain
+		}
+	}
+
+	chain := New()
+	chain.Add(middleware...)
+
+	m.routes = append(m.routes, route{prefix: prefix, chain: chain})
+
+	return chain
+}
+
+// match reports whether the route's prefix matches the URL path.
+func (r route) match(path string) bool {
+	if strings.HasSuffix(r.prefix, "/") {
+		return strings.HasPrefix(path, r.prefix)
+	}
+
+	return path == r.prefix
 }
 
 // Add appends one or more middleware functions to the middleware chain in the order they are provided.
@@ -26,11 +68,20 @@ func (m *Middleware) Add(middleware ...func(http.Handler) http.Handler) {
 	}
 
 	m.middleware = append(m.middleware, middleware...)
+
+	for index := range middleware {
+		m.entries = append(m.entries, describe(middleware[index]))
+	}
 }
 
 // Handler applies the middleware chain to the provided parent [http.Handler] and returns the final wrapped handler.
-// If no middleware is present, the parent handler is returned as is.
+// If routes are registered (see [Middleware.Route]), the handler dispatches each request to its longest-matching route's
+// chain before reaching the parent. If no middleware is present, the parent handler is returned as is.
 func (m *Middleware) Handler(parent http.Handler) (handler http.Handler) {
+	if len(m.routes) > 0 {
+		parent = m.dispatch(parent)
+	}
+
 	if length := len(m.middleware); length == 0 {
 		return parent
 	}
@@ -44,6 +95,34 @@ func (m *Middleware) Handler(parent http.Handler) (handler http.Handler) {
 	return
 }
 
+// dispatch returns a handler routing requests through their longest-matching route's chain, then to the parent handler.
+// Requests without a matching route are served by the parent directly.
+func (m *Middleware) dispatch(parent http.Handler) http.Handler {
+	routes := make([]route, len(m.routes))
+	handlers := make([]http.Handler, len(m.routes))
+	for index := range m.routes {
+		routes[index] = m.routes[index]
+		handlers[index] = m.routes[index].chain.Handler(parent)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selection, length := -1, -1
+		for index := range routes {
+			if routes[index].match(r.URL.Path) && len(routes[index].prefix) > length {
+				selection, length = index, len(routes[index].prefix)
+			}
+		}
+
+		if selection < 0 {
+			parent.ServeHTTP(w, r)
+
+			return
+		}
+
+		handlers[selection].ServeHTTP(w, r)
+	})
+}
+
 // New initializes and returns a pointer to a new [Middleware] instance.
 func New() *Middleware {
 	return new(Middleware)
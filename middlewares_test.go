@@ -3,10 +3,13 @@ package middleware_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/poly-gun/go-middleware"
 )
@@ -96,4 +99,275 @@ func Test(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("Routes", func(t *testing.T) {
+		label := func(value string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Add("X-Chain", value)
+
+					next.ServeHTTP(w, r)
+				})
+			}
+		}
+
+		middleware := middleware.New()
+
+		middleware.Add(label("global"))
+		middleware.Route("/api/", label("api"))
+		middleware.Route("/api/admin/", label("admin")).Add(label("audit"))
+		middleware.Route("/public/", label("public"))
+		middleware.Route("/health", label("health"))
+		middleware.Route("/public/", label("cache"))
+
+		handler := middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		tests := []struct {
+			path  string
+			chain string
+		}{
+			{path: "/", chain: "global"},
+			{path: "/api/users", chain: "global,api"},
+			{path: "/api/admin/users", chain: "global,admin,audit"},
+			{path: "/api", chain: "global"},
+			{path: "/public/index.html", chain: "global,public,cache"},
+			{path: "/health", chain: "global,health"},
+			{path: "/healthz", chain: "global"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.path, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+				if v := strings.Join(recorder.Header().Values("X-Chain"), ","); v != test.chain {
+					t.Errorf("Chain = %s\n    - Expectation = %s", v, test.chain)
+				}
+			})
+		}
+	})
+
+	t.Run("Conditional", func(t *testing.T) {
+		chain := middleware.New()
+
+		chain.Add(middleware.Conditional(func(r *http.Request) bool {
+			return r.URL.Path == "/health" || r.Method == http.MethodOptions
+		}, func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Middleware", "applied")
+
+				next.ServeHTTP(w, r)
+			})
+		}))
+
+		handler := chain.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		tests := []struct {
+			method   string
+			path     string
+			expected string
+		}{
+			{method: http.MethodGet, path: "/", expected: "applied"},
+			{method: http.MethodGet, path: "/health", expected: ""},
+			{method: http.MethodOptions, path: "/", expected: ""},
+		}
+
+		for _, test := range tests {
+			t.Run(test.method+" "+test.path, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+
+				if v := recorder.Header().Get("X-Middleware"); v != test.expected {
+					t.Errorf("Middleware = %s\n    - Expectation = %s", v, test.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("Mux", func(t *testing.T) {
+		tag := func(value string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Add("X-Chain", value)
+
+					next.ServeHTTP(w, r)
+				})
+			}
+		}
+
+		mux := http.NewServeMux()
+
+		middleware.HandleFunc(mux, "GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("user " + r.PathValue("id")))
+		}, tag("outer"), tag("inner"))
+
+		middleware.HandleFunc(mux, "DELETE /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}, tag("admin"))
+
+		mux.Handle("/documents/{id}", middleware.Methods{
+			http.MethodGet: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("document " + r.PathValue("id")))
+			}),
+			http.MethodPut: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			}),
+		})
+
+		tests := []struct {
+			method string
+			path   string
+			status int
+			chain  string
+			allow  string
+			body   string
+		}{
+			{method: http.MethodGet, path: "/users/7", status: http.StatusOK, chain: "outer,inner", body: "user 7"},
+			{method: http.MethodDelete, path: "/users/7", status: http.StatusNoContent, chain: "admin"},
+			{method: http.MethodPost, path: "/users/7", status: http.StatusMethodNotAllowed},
+			{method: http.MethodGet, path: "/documents/3", status: http.StatusOK, body: "document 3"},
+			{method: http.MethodHead, path: "/documents/3", status: http.StatusOK},
+			{method: http.MethodPut, path: "/documents/3", status: http.StatusAccepted},
+			{method: http.MethodOptions, path: "/documents/3", status: http.StatusNoContent, allow: "GET, HEAD, OPTIONS, PUT"},
+			{method: http.MethodDelete, path: "/documents/3", status: http.StatusMethodNotAllowed, allow: "GET, HEAD, OPTIONS, PUT"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.method+" "+test.path, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+
+				mux.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+
+				if recorder.Code != test.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", recorder.Code, test.status)
+				}
+
+				if v := strings.Join(recorder.Header().Values("X-Chain"), ","); v != test.chain {
+					t.Errorf("Chain = %s\n    - Expectation = %s", v, test.chain)
+				}
+
+				if v := recorder.Header().Get("Allow"); test.allow != "" && v != test.allow {
+					t.Errorf("Allow = %s\n    - Expectation = %s", v, test.allow)
+				}
+
+				if v := recorder.Body.String(); test.body != "" && v != test.body {
+					t.Errorf("Body = %s\n    - Expectation = %s", v, test.body)
+				}
+			})
+		}
+	})
+
+	t.Run("Detach", func(t *testing.T) {
+		type contextual string
+
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), contextual("request-id"), "example"), time.Minute)
+
+		detached := middleware.Detach(ctx)
+
+		cancel()
+
+		if e := detached.Err(); e != nil {
+			t.Errorf("Detached Context Error = %v\n    - Expectation = <nil>", e)
+		}
+
+		if _, found := detached.Deadline(); found {
+			t.Errorf("Unexpected Detached Context Deadline")
+		}
+
+		if v := detached.Value(contextual("request-id")); v != "example" {
+			t.Errorf("Detached Context Value = %v\n    - Expectation = %s", v, "example")
+		}
+	})
+	t.Run("Recorder", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			handler func(w http.ResponseWriter)
+			status  int
+			bytes   int64
+			written bool
+		}{
+			{name: "Unwritten", handler: func(w http.ResponseWriter) {}, status: http.StatusOK, bytes: 0, written: false},
+			{name: "Implicit-Status", handler: func(w http.ResponseWriter) { w.Write([]byte("Hello World")) }, status: http.StatusOK, bytes: 11, written: true},
+			{name: "Explicit-Status", handler: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound); w.Write([]byte("Not Found")) }, status: http.StatusNotFound, bytes: 9, written: true},
+			{name: "Informational-Status", handler: func(w http.ResponseWriter) { w.WriteHeader(http.StatusEarlyHints); w.WriteHeader(http.StatusCreated) }, status: http.StatusCreated, bytes: 0, written: true},
+			{name: "Superfluous-Status", handler: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusAccepted)
+				w.WriteHeader(http.StatusInternalServerError)
+			}, status: http.StatusAccepted, bytes: 0, written: true},
+			{name: "Flushed", handler: func(w http.ResponseWriter) { w.(http.Flusher).Flush() }, status: http.StatusOK, bytes: 0, written: true},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				response := httptest.NewRecorder()
+
+				recorder := middleware.Wrap(response)
+
+				test.handler(recorder)
+
+				if v := recorder.Status(); v != test.status {
+					t.Errorf("Status = %d\n    - Expectation = %d", v, test.status)
+				}
+
+				if v := recorder.Bytes(); v != test.bytes {
+					t.Errorf("Bytes = %d\n    - Expectation = %d", v, test.bytes)
+				}
+
+				if v := recorder.Written(); v != test.written {
+					t.Errorf("Written = %t\n    - Expectation = %t", v, test.written)
+				}
+
+				if v := middleware.Wrap(recorder); v != recorder {
+					t.Errorf("Wrapping a Recorder Didn't Return the Same Recorder")
+				}
+			})
+		}
+
+		t.Run("Unsupported", func(t *testing.T) {
+			recorder := middleware.Wrap(httptest.NewRecorder())
+
+			if _, _, e := recorder.Hijack(); !(errors.Is(e, http.ErrNotSupported)) {
+				t.Errorf("Hijack Error = %v\n    - Expectation = %v", e, http.ErrNotSupported)
+			}
+
+			if e := recorder.Push("/style.css", nil); !(errors.Is(e, http.ErrNotSupported)) {
+				t.Errorf("Push Error = %v\n    - Expectation = %v", e, http.ErrNotSupported)
+			}
+		})
+
+		t.Run("Hijack", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				connection, buffer, e := middleware.Wrap(w).Hijack()
+				if e != nil {
+					t.Errorf("Unexpected Hijack Error: %v", e)
+
+					return
+				}
+
+				defer connection.Close()
+
+				buffer.WriteString("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n")
+				buffer.Flush()
+			}))
+
+			defer server.Close()
+
+			response, e := server.Client().Get(server.URL)
+			if e != nil {
+				t.Fatalf("Unexpected Error While Generating Response: %v", e)
+			}
+
+			defer response.Body.Close()
+
+			if response.StatusCode != http.StatusNoContent {
+				t.Errorf("Status = %d\n    - Expectation = %d", response.StatusCode, http.StatusNoContent)
+			}
+		})
+	})
 }
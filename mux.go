@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Handle registers the handler for the given pattern on the [http.ServeMux], wrapped by a pattern-specific middleware chain.
+// Middleware apply in the order provided, such that the first is the outermost. Patterns follow [http.ServeMux] syntax,
+// including Go 1.22+ methods and wildcards (e.g. "GET /users/{id}"):
+//
+//	middleware.Handle(mux, "DELETE /users/{id}", handler, authorization.New().Handler, audit.Handler)
+//
+// Requests matching the pattern's path, but not its method, receive a [http.StatusMethodNotAllowed] response - with an Allow
+// header - from the [http.ServeMux] itself.
+func Handle(mux *http.ServeMux, pattern string, handler http.Handler, middleware ...func(http.Handler) http.Handler) {
+	chain := New()
+	chain.Add(middleware...)
+
+	mux.Handle(pattern, chain.Handler(handler))
+}
+
+// HandleFunc registers the handler function for the given pattern on the [http.ServeMux]. See [Handle] for additional details.
+func HandleFunc(mux *http.ServeMux, pattern string, handler func(w http.ResponseWriter, r *http.Request), middleware ...func(http.Handler) http.Handler) {
+	Handle(mux, pattern, http.HandlerFunc(handler), middleware...)
+}
+
+// Methods maps request methods (e.g. [http.MethodGet]) to their handlers, for patterns registered without a method. HEAD requests
+// fall back to the GET handler, and OPTIONS requests - unless explicitly mapped - receive a [http.StatusNoContent] response
+// listing the allowed methods. Requests with any other unmapped method receive a [http.StatusMethodNotAllowed] response, with
+// an Allow header:
+//
+//	mux.Handle("/users/{id}", middleware.Methods{
+//		http.MethodGet:    http.HandlerFunc(read),
+//		http.MethodDelete: authorization.New().Handler(http.HandlerFunc(remove)),
+//	})
+type Methods map[string]http.Handler
+
+// allow returns the Allow header's value.
+func (m Methods) allow() string {
+	methods := make([]string, 0, len(m)+2)
+	for method := range m {
+		methods = append(methods, method)
+	}
+
+	if _, found := m[http.MethodGet]; found {
+		if _, found := m[http.MethodHead]; !(found) {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+
+	if _, found := m[http.MethodOptions]; !(found) {
+		methods = append(methods, http.MethodOptions)
+	}
+
+	sort.Strings(methods)
+
+	return strings.Join(methods, ", ")
+}
+
+func (m Methods) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler, found := m[r.Method]; found && handler != nil {
+		handler.ServeHTTP(w, r)
+
+		return
+	}
+
+	if handler, found := m[http.MethodGet]; found && handler != nil && r.Method == http.MethodHead {
+		handler.ServeHTTP(w, r)
+
+		return
+	}
+
+	w.Header().Set("Allow", m.allow())
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,7 @@
+// Package pii provides a shared sanitizer detecting and masking personally identifiable information - email addresses, phone
+// numbers, national identifiers, or any configured [Pattern] - in URLs, headers, and bodies before a sink (e.g. logs, request
+// captures, audit trails, or telemetry) receives them.
+//
+// Sanitization is governed by per-field [Policy] values: matches can be masked, hashed for correlation, or entire fields
+// redacted. Detections are counted per pattern for metrics, and optionally reported through [Sanitizer.Observe].
+package pii
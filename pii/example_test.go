@@ -0,0 +1,24 @@
+package pii_test
+
+import (
+	"fmt"
+
+	"github.com/poly-gun/go-middleware/pii"
+)
+
+func Example() {
+	sanitizer := pii.New()
+
+	sanitizer.Fields["x-account"] = pii.PolicyHash
+
+	fmt.Println(sanitizer.String("message", "Reset link sent to alice@example.com"))
+	fmt.Println(sanitizer.String("X-Account", "alice@example.com"))
+	fmt.Println(sanitizer.String("Cookie", "session=abc123"))
+	fmt.Println(sanitizer.Detections()["email"])
+
+	// Output:
+	// Reset link sent to [REDACTED:email]
+	// [email:ff8d9819fc0e]
+	// [REDACTED]
+	// 2
+}
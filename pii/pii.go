@@ -0,0 +1,222 @@
+package pii
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Pattern represents a named personally identifiable information (PII) pattern.
+type Pattern struct {
+	// Name identifies the pattern in masked values and detection metrics (e.g. "email").
+	Name string
+
+	// Expression represents the pattern's regular expression.
+	Expression *regexp.Regexp
+}
+
+var (
+	// Email matches email addresses.
+	Email = Pattern{Name: "email", Expression: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)}
+
+	// Phone matches North American Numbering Plan phone numbers, with an optional international calling code.
+	Phone = Pattern{Name: "phone", Expression: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?\b\d{3}[ .-]?\d{3}[ .-]?\d{4}\b`)}
+
+	// SSN matches United States social security numbers in their hyphenated form.
+	SSN = Pattern{Name: "ssn", Expression: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)}
+)
+
+// Patterns returns the default PII patterns.
+func Patterns() []Pattern {
+	return []Pattern{Email, SSN, Phone}
+}
+
+// Policy represents a field's sanitization policy.
+type Policy string
+
+const (
+	// PolicyMask replaces each pattern match with a "[REDACTED:<name>]" placeholder, retaining the remainder of the value.
+	PolicyMask Policy = "mask"
+
+	// PolicyHash replaces each pattern match with a truncated SHA-256 digest, allowing correlation without disclosure.
+	PolicyHash Policy = "hash"
+
+	// PolicyRedact replaces the field's entire value with "[REDACTED]", irrespective of pattern matches.
+	PolicyRedact Policy = "redact"
+
+	// PolicyAllow retains the field's value as is.
+	PolicyAllow Policy = "allow"
+)
+
+// Sanitizer detects and sanitizes PII in URLs, headers, and bodies before they reach a sink (e.g. logs, captures, or telemetry).
+// A Sanitizer is safe for concurrent use.
+type Sanitizer struct {
+	// Patterns represents the detected PII patterns. Defaults to [Patterns].
+	Patterns []Pattern
+
+	// Fields maps field names - header names and query parameter names, case-insensitively - to their [Policy]. Bodies and URL
+	// paths are referenced as "body" and "path", respectively. Fields without a policy use [Sanitizer.Default].
+	Fields map[string]Policy
+
+	// Default represents the policy of fields absent from [Sanitizer.Fields]. Defaults to [PolicyMask].
+	Default Policy
+
+	// Observe optionally receives every detection's pattern name and field (e.g. for metrics). Defaults to nil.
+	Observe func(pattern, field string)
+
+	mutex      sync.Mutex
+	detections map[string]uint64
+}
+
+// New creates a new [Sanitizer] detecting the provided patterns, or the default [Patterns] if none are provided. Sensitive
+// credential headers (e.g. Authorization and Cookie) are redacted in full.
+func New(patterns ...Pattern) *Sanitizer {
+	if len(patterns) == 0 {
+		patterns = Patterns()
+	}
+
+	return &Sanitizer{
+		Patterns: patterns,
+		Fields: map[string]Policy{
+			"authorization":       PolicyRedact,
+			"proxy-authorization": PolicyRedact,
+			"cookie":              PolicyRedact,
+			"set-cookie":          PolicyRedact,
+		},
+		Default: PolicyMask,
+	}
+}
+
+// policy returns the field's policy.
+func (s *Sanitizer) policy(field string) Policy {
+	for k, v := range s.Fields {
+		if strings.EqualFold(k, field) {
+			return v
+		}
+	}
+
+	if s.Default == "" {
+		return PolicyMask
+	}
+
+	return s.Default
+}
+
+// detected records a detection.
+func (s *Sanitizer) detected(pattern, field string) {
+	s.mutex.Lock()
+	if s.detections == nil {
+		s.detections = make(map[string]uint64)
+	}
+
+	s.detections[pattern]++
+	s.mutex.Unlock()
+
+	if s.Observe != nil {
+		s.Observe(pattern, field)
+	}
+}
+
+// Detections returns the number of detections per pattern name, since the [Sanitizer]'s creation.
+func (s *Sanitizer) Detections() map[string]uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	detections := make(map[string]uint64, len(s.detections))
+	for k, v := range s.detections {
+		detections[k] = v
+	}
+
+	return detections
+}
+
+// String returns the field's sanitized value.
+func (s *Sanitizer) String(field, value string) string {
+	policy := s.policy(field)
+
+	switch policy {
+	case PolicyAllow:
+		return value
+	case PolicyRedact:
+		if value == "" {
+			return value
+		}
+
+		return "[REDACTED]"
+	}
+
+	patterns := s.Patterns
+	if patterns == nil {
+		patterns = Patterns()
+	}
+
+	for _, pattern := range patterns {
+		value = pattern.Expression.ReplaceAllStringFunc(value, func(match string) string {
+			s.detected(pattern.Name, field)
+
+			if policy == PolicyHash {
+				digest := sha256.Sum256([]byte(match))
+
+				return "[" + pattern.Name + ":" + hex.EncodeToString(digest[:6]) + "]"
+			}
+
+			return "[REDACTED:" + pattern.Name + "]"
+		})
+	}
+
+	return value
+}
+
+// Header returns a sanitized copy of the header.
+func (s *Sanitizer) Header(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	sanitized := make(http.Header, len(header))
+	for k, values := range header {
+		sanitized[k] = make([]string, len(values))
+		for index := range values {
+			sanitized[k][index] = s.String(k, values[index])
+		}
+	}
+
+	return sanitized
+}
+
+// URL returns the sanitized URL's string representation. The path is sanitized as the "path" field, and each query parameter
+// by its name.
+func (s *Sanitizer) URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	sanitized := *u
+	sanitized.User = nil
+	sanitized.RawPath = s.String("path", u.EscapedPath())
+	if v, e := url.PathUnescape(sanitized.RawPath); e == nil {
+		sanitized.Path = v
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for k, values := range query {
+			for index := range values {
+				values[index] = s.String(k, values[index])
+			}
+		}
+
+		sanitized.RawQuery = query.Encode()
+	}
+
+	return sanitized.String()
+}
+
+// Body returns the sanitized body, as the "body" field.
+func (s *Sanitizer) Body(data []byte) []byte {
+	return []byte(s.String("body", string(data)))
+}
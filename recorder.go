@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// Recorder represents an [http.ResponseWriter] recording the response's status code and the number of body bytes written. See
+// [Wrap].
+type Recorder struct {
+	http.ResponseWriter
+
+	status  int
+	bytes   int64
+	written bool
+}
+
+// Wrap returns a [Recorder] wrapping w. If w is already a [Recorder], it's returned as is, such that chained middleware share
+// a single recording.
+//
+// The [Recorder] passes [http.Flusher], [http.Hijacker], and [http.Pusher] calls through to w, and implements Unwrap for use
+// with [http.ResponseController].
+func Wrap(w http.ResponseWriter) *Recorder {
+	if recorder, ok := w.(*Recorder); ok {
+		return recorder
+	}
+
+	return &Recorder{ResponseWriter: w}
+}
+
+// Status returns the response's status code. Informational (1xx) status codes aren't recorded. If the response's header(s)
+// weren't written, [http.StatusOK] is returned - the status net/http sends for handlers that don't write a response.
+func (r *Recorder) Status() int {
+	if !(r.written) {
+		return http.StatusOK
+	}
+
+	return r.status
+}
+
+// Bytes returns the number of response body bytes written.
+func (r *Recorder) Bytes() int64 {
+	return r.bytes
+}
+
+// Written reports whether the response's header(s) were written.
+func (r *Recorder) Written() bool {
+	return r.written
+}
+
+func (r *Recorder) WriteHeader(status int) {
+	if !(r.written) && status >= 200 {
+		r.status, r.written = status, true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *Recorder) Write(p []byte) (int, error) {
+	if !(r.written) {
+		r.status, r.written = http.StatusOK, true
+	}
+
+	n, e := r.ResponseWriter.Write(p)
+
+	r.bytes += int64(n)
+
+	return n, e
+}
+
+// Flush implements [http.Flusher], flushing the underlying [http.ResponseWriter] if supported.
+func (r *Recorder) Flush() {
+	if !(r.written) {
+		r.status, r.written = http.StatusOK, true
+	}
+
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (r *Recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// Push implements [http.Pusher], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// server push.
+func (r *Recorder) Push(target string, options *http.PushOptions) error {
+	if pusher, ok := r.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, options)
+	}
+
+	return http.ErrNotSupported
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Runtime assurance that [Recorder] satisfies the optional [http.ResponseWriter] interface(s).
+var (
+	_ http.Flusher  = (*Recorder)(nil)
+	_ http.Hijacker = (*Recorder)(nil)
+	_ http.Pusher   = (*Recorder)(nil)
+)
@@ -0,0 +1,10 @@
+// Package reject provides a shared, structured rejection-reason API for middleware denying requests (e.g. authentication,
+// authorization, rate limiting, or the web application firewall).
+//
+// A denying middleware records a machine-readable [Reason] - its subsystem, code, and the rule responsible - through [Record],
+// prior to writing its response. The [Middleware], placed early in the chain, observes every recorded reason: reasons are counted
+// (see [Middleware.Counts]), logged as audit events, passed to an optional [Options.Observe] hook for metrics, and - for internal
+// clients only - exposed through a response header, such that operators can tell why a request was denied without searching logs.
+//
+// Without the [Middleware], [Record] is a no-op; denying middleware needn't know whether it's enabled.
+package reject
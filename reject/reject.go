@@ -0,0 +1,269 @@
+package reject
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/poly-gun/go-middleware"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// key is the package's unexported context key. Only through the use of [Value] can the context's value be derived.
+const key keyer = "reject"
+
+// Reason represents a machine-readable rejection reason.
+type Reason struct {
+	// Subsystem represents the denying middleware (e.g. "authentication", "ratelimit", or "waf").
+	Subsystem string `json:"subsystem"`
+
+	// Code represents the subsystem-specific rejection code (e.g. "token-expired", or "limit-exceeded").
+	Code string `json:"code"`
+
+	// Rule optionally represents the rule, policy, or detection responsible (e.g. a route pattern, or detection identifiers).
+	Rule string `json:"rule,omitempty"`
+
+	// Status represents the rejected response's status code.
+	Status int `json:"status"`
+}
+
+// String returns the reason's "subsystem/code" form - e.g. "authentication/token-expired" - suitable as a metric label.
+func (r Reason) String() string {
+	return r.Subsystem + "/" + r.Code
+}
+
+// recorder holds the request's recorded reason.
+type recorder struct {
+	mutex  sync.Mutex
+	reason *Reason
+}
+
+// Record records the request's rejection reason, prior to the denying middleware writing its response. Only the first reason is
+// retained. False is returned if the [Middleware] isn't enabled for the particular caller's chain.
+func Record(ctx context.Context, reason Reason) bool {
+	v, ok := ctx.Value(key).(*recorder)
+	if !(ok) {
+		return false
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.reason == nil {
+		v.reason = &reason
+	}
+
+	return true
+}
+
+// Value retrieves the request's recorded [Reason] from the provided context. If a nil value is returned, the request wasn't
+// rejected (yet), or the [Middleware] isn't enabled for the particular caller's chain.
+func Value(ctx context.Context) (value *Reason) {
+	if v, ok := ctx.Value(key).(*recorder); ok {
+		v.mutex.Lock()
+		value = v.reason
+		v.mutex.Unlock()
+	} else {
+		slog.Log(ctx, (slog.LevelDebug - 4), "Rejection Recorder Not Found", slog.String("key", string(key)))
+	}
+
+	return
+}
+
+// Options represents the configuration settings for the [Middleware] middleware component.
+type Options struct {
+	// Header represents the response header exposing the recorded reason (e.g. "authentication/token-expired; rule=..."). An empty
+	// value disables the header. Defaults to "X-Reject-Reason".
+	Header string
+
+	// Expose reports whether the request's client may receive the [Options.Header]. Defaults to a function trusting loopback and
+	// private-network remote addresses only, as reasons may aid attackers in evading a rule.
+	Expose func(r *http.Request) bool
+
+	// Level specifies the log level of the rejection audit events. A nil value disables the audit log. Defaults to [slog.LevelInfo].
+	Level slog.Leveler
+
+	// Observe optionally receives every recorded reason, once the rejected response is written (e.g. for metrics, or an external
+	// audit trail). Defaults to nil.
+	Observe func(r *http.Request, reason Reason)
+}
+
+// Middleware represents a middleware component that applies configurable [Options] settings to HTTP requests. It
+// embeds [middleware.Configurable] for [Options] configuration.
+type Middleware struct {
+	middleware.Configurable[Options]
+
+	options *Options
+
+	mutex  sync.Mutex
+	counts map[string]uint64
+}
+
+// internal reports whether the request's remote address is a loopback or private-network address.
+func internal(r *http.Request) bool {
+	host, _, e := net.SplitHostPort(r.RemoteAddr)
+	if e != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
+
+// Settings applies configuration functions to modify the [Middleware] middleware's [Options] and returns the updated middleware instance.
+func (x *Middleware) Settings(configuration ...func(o *Options)) middleware.Configurable[Options] {
+	if x.options == nil {
+		x.options = &Options{
+			Header:  "X-Reject-Reason",
+			Expose:  internal,
+			Level:   slog.LevelInfo,
+			Observe: nil,
+		}
+	}
+
+	for index := range configuration {
+		if callable := configuration[index]; callable != nil {
+			callable(x.options)
+		}
+	}
+
+	// Ensure user-provided configuration is compliant with the middleware's expectations.
+	if x.options.Expose == nil {
+		x.options.Expose = internal
+	}
+
+	return x
+}
+
+// Counts returns a snapshot of the number of rejections per reason, keyed by [Reason.String].
+func (x *Middleware) Counts() map[string]uint64 {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	counts := make(map[string]uint64, len(x.counts))
+	for k, v := range x.counts {
+		counts[k] = v
+	}
+
+	return counts
+}
+
+// observe counts, audits, and reports the recorded reason.
+func (x *Middleware) observe(r *http.Request, reason Reason) {
+	x.mutex.Lock()
+	if x.counts == nil {
+		x.counts = make(map[string]uint64)
+	}
+
+	x.counts[reason.String()]++
+	x.mutex.Unlock()
+
+	if v := x.options.Level; v != nil {
+		slog.Log(r.Context(), v.Level(), "Request Rejected", slog.String("subsystem", reason.Subsystem), slog.String("code", reason.Code), slog.String("rule", reason.Rule), slog.Int("status", reason.Status), slog.String("method", r.Method), slog.String("path", r.URL.Path))
+	}
+
+	if x.options.Observe != nil {
+		x.options.Observe(r, reason)
+	}
+}
+
+// format returns the reason's header value.
+func format(reason *Reason) string {
+	value := reason.String()
+	if reason.Rule != "" {
+		value += "; rule=" + strings.ReplaceAll(reason.Rule, ";", ",")
+	}
+
+	return value
+}
+
+// writer stamps the reason's header, if recorded, prior to the response's header(s) being written.
+type writer struct {
+	http.ResponseWriter
+
+	stamp   func(header http.Header)
+	written bool
+}
+
+func (w *writer) WriteHeader(status int) {
+	if !(w.written) {
+		w.written = true
+
+		if w.stamp != nil {
+			w.stamp(w.ResponseWriter.Header())
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements [http.Flusher], stamping the reason's header, if recorded, before flushing the response's header(s).
+func (w *writer) Flush() {
+	if !(w.written) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements [http.Hijacker], returning [http.ErrNotSupported] if the underlying [http.ResponseWriter] doesn't support
+// hijacking.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for use with [http.ResponseController].
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handler stores a rejection recorder in the request context. A reason recorded by a subsequent middleware - see [Record] - is
+// exposed through the [Options.Header] (for [Options.Expose] clients), and observed once the next handler returns.
+func (x *Middleware) Handler(next http.Handler) http.Handler {
+	x.Settings() // Ensure the options field isn't nil.
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := new(recorder)
+
+		r = r.WithContext(context.WithValue(r.Context(), key, v))
+
+		wrapper := &writer{ResponseWriter: w}
+		if x.options.Header != "" && x.options.Expose(r) {
+			wrapper.stamp = func(header http.Header) {
+				if reason := Value(r.Context()); reason != nil {
+					header.Set(x.options.Header, format(reason))
+				}
+			}
+		}
+
+		next.ServeHTTP(wrapper, r)
+
+		if reason := Value(r.Context()); reason != nil {
+			x.observe(r, *reason)
+		}
+	})
+}
+
+// New creates a new instance of the [Middleware] middleware, implementing [middleware.Configurable]. If [Middleware.Settings] isn't called,
+// then the [Middleware.Handler] function will hydrate the middleware's configuration with sane default(s) if applicable.
+func New() middleware.Configurable[Options] {
+	return new(Middleware)
+}
+
+// Runtime assurance that [Middleware] satisfies [middleware.Configurable] requirement(s).
+var _ middleware.Configurable[Options] = (*Middleware)(nil)
@@ -0,0 +1,100 @@
+package reject_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poly-gun/go-middleware/reject"
+)
+
+func Test(t *testing.T) {
+	// deny represents a denying middleware, recording its reason prior to writing its response.
+	deny := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reject.Record(r.Context(), reject.Reason{Subsystem: "waf", Code: "anomaly-threshold", Rule: "942100;941100", Status: http.StatusForbidden})
+		reject.Record(r.Context(), reject.Reason{Subsystem: "ratelimit", Code: "limit-exceeded", Status: http.StatusTooManyRequests})
+
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+
+	tests := []struct {
+		name    string
+		remote  string
+		handler http.Handler
+		header  string
+		counts  map[string]uint64
+	}{
+		{
+			name:    "Internal",
+			remote:  "10.0.0.1:54321",
+			handler: deny,
+			header:  "waf/anomaly-threshold; rule=942100,941100",
+			counts:  map[string]uint64{"waf/anomaly-threshold": 1},
+		},
+		{
+			name:    "External",
+			remote:  "203.0.113.1:54321",
+			handler: deny,
+			header:  "",
+			counts:  map[string]uint64{"waf/anomaly-threshold": 1},
+		},
+		{
+			name:   "Allowed",
+			remote: "10.0.0.1:54321",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			header: "",
+			counts: map[string]uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var observed []reject.Reason
+
+			instance := reject.New().Settings(func(o *reject.Options) {
+				o.Observe = func(r *http.Request, reason reject.Reason) {
+					observed = append(observed, reason)
+				}
+			})
+
+			handler := instance.Handler(tt.handler)
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.RemoteAddr = tt.remote
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if v := recorder.Header().Get("X-Reject-Reason"); v != tt.header {
+				t.Errorf("X-Reject-Reason = %s\n    - Expectation = %s", v, tt.header)
+			}
+
+			if len(observed) != len(tt.counts) {
+				t.Errorf("Observed = %d\n    - Expectation = %d", len(observed), len(tt.counts))
+			}
+
+			counts := instance.(*reject.Middleware).Counts()
+			for k, expectation := range tt.counts {
+				if v := counts[k]; v != expectation {
+					t.Errorf("Count (%s) = %d\n    - Expectation = %d", k, v, expectation)
+				}
+			}
+		})
+	}
+
+	t.Run("Without-Middleware", func(t *testing.T) {
+		if reject.Record(context.Background(), reject.Reason{Subsystem: "waf", Code: "anomaly-threshold"}) {
+			t.Errorf("Expected Record to Report a Missing Recorder")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if v := reject.Value(context.Background()); v != nil {
+			t.Errorf("Value = %v\n    - Expectation = %v", v, nil)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// keyer is a private string type, unexported to ensure the context, constant key is always unique.
+type keyer string
+
+// shutdown is the package's unexported context key for the serving [Notifier].
+const shutdown keyer = "shutdown"
+
+// Notifier signals long-lived handlers - e.g. streaming, server-sent event, and WebSocket endpoints - that a graceful shutdown
+// has begun. [http.Server.Shutdown] closes idle connections, then waits on active ones; a handler that never returns is cut off
+// once the drain deadline elapses. Handlers served by a [Notifier.Handler] can instead observe [Stopping] (or register an
+// [OnShutdown] callback) to write a termination event and return early.
+//
+// [Chains.ListenAndServe] notifies each chain automatically. For a standalone [http.Server], register [Notifier.Notify]:
+//
+//	notifier := new(middleware.Notifier)
+//	server := &http.Server{Handler: notifier.Handler(mux)}
+//	server.RegisterOnShutdown(notifier.Notify)
+//
+// The zero value is ready for use. A Notifier is safe for concurrent use, and is notified at most once.
+type Notifier struct {
+	once   sync.Once
+	notify sync.Once
+	done   chan struct{}
+}
+
+// channel returns the notifier's lazily-initialized channel.
+func (n *Notifier) channel() chan struct{} {
+	n.once.Do(func() {
+		n.done = make(chan struct{})
+	})
+
+	return n.done
+}
+
+// Notify signals the shutdown to all current, and future, handlers. Subsequent calls are no-ops.
+func (n *Notifier) Notify() {
+	done := n.channel()
+
+	n.notify.Do(func() { close(done) })
+}
+
+// Done returns a channel closed once [Notifier.Notify] is called.
+func (n *Notifier) Done() <-chan struct{} {
+	return n.channel()
+}
+
+// Handler stores the notifier in the request context, such that the next handler can observe the shutdown via [Stopping] or [OnShutdown].
+func (n *Notifier) Handler(next http.Handler) http.Handler {
+	n.channel()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), shutdown, n)))
+	})
+}
+
+// Stopping returns a channel closed once the serving [Notifier] signals a graceful shutdown. A nil channel - blocking forever -
+// is returned if the request isn't served by a [Notifier.Handler], such that Stopping is always safe to select on:
+//
+//	for {
+//		select {
+//		case event := <-events:
+//			fmt.Fprintf(w, "data: %s\n\n", event)
+//			controller.Flush()
+//		case <-middleware.Stopping(r.Context()):
+//			fmt.Fprint(w, "event: shutdown\ndata: reconnect\n\n")
+//			controller.Flush()
+//			return
+//		case <-r.Context().Done():
+//			return
+//		}
+//	}
+func Stopping(ctx context.Context) <-chan struct{} {
+	if notifier, ok := ctx.Value(shutdown).(*Notifier); ok {
+		return notifier.Done()
+	}
+
+	return nil
+}
+
+// OnShutdown arranges for fn to be called in its own goroutine once the serving [Notifier] signals a graceful shutdown - e.g. to send
+// a WebSocket close frame from outside the handler's read loop. The callback isn't called if ctx is done first, nor if stop is called
+// beforehand; stop reports whether it prevented the callback. Handlers should call stop once they return:
+//
+//	stop := middleware.OnShutdown(r.Context(), func() { connection.Close(websocket.StatusGoingAway, "shutdown") })
+//	defer stop()
+func OnShutdown(ctx context.Context, fn func()) (stop func() bool) {
+	done := Stopping(ctx)
+	if done == nil {
+		return func() bool { return false }
+	}
+
+	var once sync.Once
+	canceled := make(chan struct{})
+
+	// claim reports whether the caller is the first to claim the callback's single execution.
+	claim := func() (claimed bool) {
+		once.Do(func() { claimed = true })
+
+		return
+	}
+
+	go func() {
+		select {
+		case <-done:
+			if claim() {
+				fn()
+			}
+		case <-ctx.Done():
+			claim()
+		case <-canceled:
+		}
+	}()
+
+	return func() bool {
+		if claim() {
+			close(canceled)
+
+			return true
+		}
+
+		return false
+	}
+}